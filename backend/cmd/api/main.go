@@ -41,6 +41,14 @@ func main() {
 		log.Fatalf("Failed to initialize services: %v", err)
 	}
 
+	// Purge expired records from the trash in the background
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	defer stopPurge()
+	go serviceContainer.TrashPurger.Run(purgeCtx)
+
+	// Release inventory whose reservation has expired in the background
+	go serviceContainer.ReservationSweeper.Run(purgeCtx)
+
 	// Initialize handlers
 	handlerContainer := handlers.NewContainer(
 		serviceContainer.AuthService,
@@ -48,9 +56,13 @@ func main() {
 		serviceContainer.ClientService,
 		serviceContainer.LeadService,
 		serviceContainer.SalesService,
+		serviceContainer.CommissionService,
+		serviceContainer.PropertyService,
 		serviceContainer.TaskService,
 		nil, // notificationService - not available in container yet
 		serviceContainer.AnalyticsService,
+		serviceContainer.SearchService,
+		serviceContainer.OrganizationService,
 	)
 
 	// Setup router
@@ -60,7 +72,7 @@ func main() {
 	r.Use(chimiddleware.Logger)
 	r.Use(chimiddleware.Recoverer)
 	r.Use(chimiddleware.RequestID)
-	r.Use(chimiddleware.RealIP)
+	r.Use(middleware.RealIP(cfg.TrustedProxies))
 	r.Use(middleware.Observability)
 	r.Use(middleware.RateLimiter(cfg.RateLimit))
 
@@ -68,8 +80,8 @@ func main() {
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   cfg.CORS.AllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "If-Match"},
+		ExposedHeaders:   []string{"Link", "ETag"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -99,16 +111,41 @@ func main() {
 			// Client management routes (require employee+ role)
 			r.Group(func(r chi.Router) {
 				r.Use(middleware.EmployeeOrAbove())
-				r.Route("/clients", handlerContainer.ClientHandler.Routes)
+				r.Route("/clients", func(r chi.Router) {
+					handlerContainer.ClientHandler.Routes(r)
+					handlerContainer.PropertyHandler.ClientRoutes(r)
+				})
 			})
 
+			// Lead management routes (require employee+ role)
+			r.Route("/leads", handlerContainer.LeadHandler.Routes)
+
 			// Task management routes
 			r.Route("/tasks", handlerContainer.TaskHandler.Routes)
 
+			// Sales routes (require employee+ role); transitions are checked
+			// against the user's role
+			r.Route("/sales", handlerContainer.SalesHandler.Routes)
+
+			// Commissions; payouts, plans and reports are for managers
+			r.Route("/commissions", handlerContainer.CommissionHandler.Routes)
+
+			// Societies, projects and units; changes are for managers
+			r.Route("/societies", handlerContainer.PropertyHandler.SocietyRoutes)
+			r.Route("/projects", handlerContainer.PropertyHandler.ProjectRoutes)
+			r.Route("/inventory", handlerContainer.PropertyHandler.InventoryRoutes)
+
+			// Search across leads, clients, companies and inventory
+			r.Route("/search", handlerContainer.SearchHandler.Routes)
+
 			// Admin-only routes
 			r.Group(func(r chi.Router) {
 				r.Use(middleware.AdminOnly())
 
+				// Organizations; admins manage their own, super admins
+				// create them and move users between them
+				r.Route("/organizations", handlerContainer.OrganizationHandler.Routes)
+
 				// TODO: Add admin-only routes
 				// - System configuration
 				// - User role management
@@ -116,8 +153,6 @@ func main() {
 			})
 
 			// TODO: Add other routes when handlers are implemented
-			// - Lead management routes
-			// - Sales management routes
 			// - Notification routes
 			// - Analytics routes
 		})
@@ -145,6 +180,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopPurge()
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
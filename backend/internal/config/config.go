@@ -1,11 +1,14 @@
 package config
 
 import (
+	"fmt"
+	"net/netip"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 )
 
@@ -17,18 +20,46 @@ type Config struct {
 	ServiceName string
 
 	// Database configuration
+	DataBackend       string // supabase, postgres or memory
 	SupabaseURL       string
 	SupabaseKey       string
 	SupabaseSecretKey string
+	DatabaseURL       string
+	DatabaseMaxConns  int
+
+	// Trash configuration
+	TrashRetention     time.Duration
+	TrashPurgeInterval time.Duration
+
+	// Inventory reservation configuration
+	ReservationSweepInterval time.Duration
+
+	// Geocoding configuration
+	GeocoderProvider      string // offline or none
+	GeocoderGazetteerPath string // extra places for the offline geocoder
 
 	// JWT configuration
 	JWT JWTConfig
 
+	// Account configuration
+	Auth AuthConfig
+
+	// Mail configuration
+	MailProvider  string // file, smtp or none
+	MailFrom      string
+	MailOutboxDir string // where the file mailer writes messages
+	SMTPAddr      string // host:port of the SMTP server
+	SMTPUsername  string
+	SMTPPassword  string
+
+	// AppURL is the address of the web app, for links in emails
+	AppURL string
+
 	// Ethereum configuration
-	EthereumNetwork    string
-	EthereumRPCURL     string
-	ContractAddress    string
-	PrivateKey         string
+	EthereumNetwork string
+	EthereumRPCURL  string
+	ContractAddress string
+	PrivateKey      string
 
 	// CORS configuration
 	CORS CORSConfig
@@ -36,6 +67,10 @@ type Config struct {
 	// Rate limiting
 	RateLimit RateLimitConfig
 
+	// TrustedProxies are the proxies in front of the server, whose
+	// X-Forwarded-For and X-Real-IP headers are believed
+	TrustedProxies []netip.Prefix
+
 	// Observability
 	JaegerEndpoint string
 	LogLevel       string
@@ -45,17 +80,61 @@ type Config struct {
 	MaxFileSize   int64
 
 	// External APIs
-	IPFSGateway string
+	IPFSGateway  string
 	PinataAPIKey string
 	PinataSecret string
 }
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	AccessSecret         string
-	RefreshSecret        string
-	AccessTokenExpiry    time.Duration
-	RefreshTokenExpiry   time.Duration
+	AccessSecret       string
+	RefreshSecret      string
+	AccessTokenExpiry  time.Duration
+	RefreshTokenExpiry time.Duration
+}
+
+// AuthConfig holds account verification, recovery, MFA, lockout and wallet
+// login configuration
+type AuthConfig struct {
+	// RequireEmailVerification refuses logins until the user has verified
+	// their email address
+	RequireEmailVerification bool
+	EmailVerificationExpiry  time.Duration
+	PasswordResetExpiry      time.Duration
+
+	// RequireMFA refuses users without an authenticator on the endpoints
+	// for MFAMinRole and above
+	RequireMFA bool
+	MFAMinRole string
+	// MFAIssuer names the account in authenticator apps
+	MFAIssuer string
+	// MFAChallengeExpiry is how long a user has to give their code after
+	// their password
+	MFAChallengeExpiry time.Duration
+
+	// LockoutThreshold failed logins in a row lock an account, and
+	// IPLockoutThreshold failures within FailureWindow lock out the address
+	// they come from; 0 turns either off. A lock lasts LockoutDuration,
+	// doubling with every further failure up to MaxLockoutDuration.
+	LockoutThreshold   int
+	IPLockoutThreshold int
+	FailureWindow      time.Duration
+	LockoutDuration    time.Duration
+	MaxLockoutDuration time.Duration
+
+	// SIWEDomain is the host, and port if any, that Sign-In with Ethereum
+	// messages must name, and their URI must be on the origin of AppURL;
+	// messages for other sites are refused. So are messages signed for a
+	// chain not in SIWEChainIDs.
+	SIWEDomain   string
+	SIWEChainIDs []int64
+	// SIWENonceExpiry is how long a wallet has to sign a message with a
+	// nonce
+	SIWENonceExpiry time.Duration
+
+	// RegistrationOrganizationID is the organization users who register
+	// themselves join; by default the one migration 012 created
+	RegistrationOrganizationID uuid.UUID
 }
 
 // CORSConfig holds CORS configuration
@@ -80,9 +159,23 @@ func Load() (*Config, error) {
 		ServiceName: getEnv("SERVICE_NAME", "goreal-backend"),
 
 		// Database
+		DataBackend:       getEnv("DATA_BACKEND", "supabase"),
 		SupabaseURL:       getEnv("SUPABASE_URL", ""),
 		SupabaseKey:       getEnv("SUPABASE_ANON_KEY", ""),
 		SupabaseSecretKey: getEnv("SUPABASE_SERVICE_ROLE_KEY", ""),
+		DatabaseURL:       getEnv("DATABASE_URL", ""),
+		DatabaseMaxConns:  getEnvAsInt("DATABASE_MAX_CONNS", 10),
+
+		// Trash
+		TrashRetention:     time.Duration(getEnvAsInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour,
+		TrashPurgeInterval: time.Duration(getEnvAsInt("TRASH_PURGE_INTERVAL_MINUTES", 60)) * time.Minute,
+
+		// Inventory reservations
+		ReservationSweepInterval: time.Duration(getEnvAsInt("RESERVATION_SWEEP_INTERVAL_MINUTES", 5)) * time.Minute,
+
+		// Geocoding
+		GeocoderProvider:      getEnv("GEOCODER_PROVIDER", "offline"),
+		GeocoderGazetteerPath: getEnv("GEOCODER_GAZETTEER_PATH", ""),
 
 		// JWT
 		JWT: JWTConfig{
@@ -92,6 +185,33 @@ func Load() (*Config, error) {
 			RefreshTokenExpiry: time.Duration(getEnvAsInt("JWT_REFRESH_EXPIRY_DAYS", 7)) * 24 * time.Hour,
 		},
 
+		// Accounts
+		Auth: AuthConfig{
+			RequireEmailVerification: getEnvAsBool("AUTH_REQUIRE_EMAIL_VERIFICATION", false),
+			EmailVerificationExpiry:  time.Duration(getEnvAsInt("AUTH_EMAIL_VERIFICATION_EXPIRY_HOURS", 48)) * time.Hour,
+			PasswordResetExpiry:      time.Duration(getEnvAsInt("AUTH_PASSWORD_RESET_EXPIRY_MINUTES", 60)) * time.Minute,
+			RequireMFA:               getEnvAsBool("AUTH_REQUIRE_MFA", false),
+			MFAMinRole:               getEnv("AUTH_MFA_MIN_ROLE", "manager"),
+			MFAIssuer:                getEnv("AUTH_MFA_ISSUER", "GoReal"),
+			MFAChallengeExpiry:       time.Duration(getEnvAsInt("AUTH_MFA_CHALLENGE_EXPIRY_MINUTES", 5)) * time.Minute,
+			LockoutThreshold:         getEnvAsInt("AUTH_LOCKOUT_THRESHOLD", 5),
+			IPLockoutThreshold:       getEnvAsInt("AUTH_IP_LOCKOUT_THRESHOLD", 20),
+			FailureWindow:            time.Duration(getEnvAsInt("AUTH_FAILURE_WINDOW_MINUTES", 60)) * time.Minute,
+			LockoutDuration:          time.Duration(getEnvAsInt("AUTH_LOCKOUT_MINUTES", 1)) * time.Minute,
+			MaxLockoutDuration:       time.Duration(getEnvAsInt("AUTH_MAX_LOCKOUT_MINUTES", 60)) * time.Minute,
+			SIWEDomain:               getEnv("AUTH_SIWE_DOMAIN", "localhost:3000"),
+			SIWENonceExpiry:          time.Duration(getEnvAsInt("AUTH_SIWE_NONCE_EXPIRY_MINUTES", 10)) * time.Minute,
+		},
+
+		// Mail
+		MailProvider:  getEnv("MAIL_PROVIDER", "file"),
+		MailFrom:      getEnv("MAIL_FROM", "GoReal <no-reply@goreal.local>"),
+		MailOutboxDir: getEnv("MAIL_OUTBOX_DIR", ""),
+		SMTPAddr:      getEnv("SMTP_ADDR", "localhost:1025"),
+		SMTPUsername:  getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:  getEnv("SMTP_PASSWORD", ""),
+		AppURL:        getEnv("APP_URL", "http://localhost:3000"),
+
 		// Ethereum
 		EthereumNetwork: getEnv("ETHEREUM_NETWORK", "sepolia"),
 		EthereumRPCURL:  getEnv("ETHEREUM_RPC_URL", ""),
@@ -123,9 +243,66 @@ func Load() (*Config, error) {
 		PinataSecret: getEnv("PINATA_SECRET_API_KEY", ""),
 	}
 
+	trustedProxies, err := parsePrefixes(getEnv("TRUSTED_PROXIES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+	cfg.TrustedProxies = trustedProxies
+
+	chainIDs, err := parseInt64s(getEnv("AUTH_SIWE_CHAIN_IDS", "1,11155111"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTH_SIWE_CHAIN_IDS: %w", err)
+	}
+	cfg.Auth.SIWEChainIDs = chainIDs
+
+	registrationOrganization, err := uuid.Parse(getEnv("AUTH_REGISTRATION_ORGANIZATION_ID", "00000000-0000-0000-0000-000000000001"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTH_REGISTRATION_ORGANIZATION_ID: %w", err)
+	}
+	cfg.Auth.RegistrationOrganizationID = registrationOrganization
+
 	return cfg, nil
 }
 
+// parseInt64s reads a comma-separated list of integers
+func parseInt64s(value string) ([]int64, error) {
+	var values []int64
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+// parsePrefixes reads a comma-separated list of CIDR ranges; a bare
+// address stands for itself
+func parsePrefixes(value string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if addr, err := netip.ParseAddr(item); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(item)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
 // getEnv gets an environment variable with a fallback value
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
@@ -144,6 +321,16 @@ func getEnvAsInt(key string, fallback int) int {
 	return fallback
 }
 
+// getEnvAsBool gets an environment variable as boolean with a fallback value
+func getEnvAsBool(key string, fallback bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return fallback
+}
+
 // getEnvAsInt64 gets an environment variable as int64 with a fallback value
 func getEnvAsInt64(key string, fallback int64) int64 {
 	if value := os.Getenv(key); value != "" {
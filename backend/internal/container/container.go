@@ -6,6 +6,10 @@ import (
 	"goreal-backend/internal/config"
 	"goreal-backend/internal/domain"
 	"goreal-backend/internal/handlers"
+	"goreal-backend/internal/infrastructure/geocoding"
+	"goreal-backend/internal/infrastructure/mail"
+	"goreal-backend/internal/infrastructure/memory"
+	"goreal-backend/internal/infrastructure/postgres"
 	"goreal-backend/internal/infrastructure/supabase"
 	"goreal-backend/internal/observability"
 	"goreal-backend/internal/services"
@@ -20,25 +24,39 @@ type Container struct {
 
 	// Infrastructure
 	SupabaseClient *supabase.Client
+	DB             *postgres.DB
 
 	// Repositories
 	UserRepository         domain.UserRepository
 	LeadRepository         domain.LeadRepository
 	CompanyRepository      domain.CompanyRepository
+	SocietyRepository      domain.SocietyRepository
 	ProjectRepository      domain.ProjectRepository
 	TaskRepository         domain.TaskRepository
 	SaleRepository         domain.SaleRepository
+	SaleHistoryRepository  domain.SaleStatusHistoryRepository
 	ClientRepository       domain.ClientRepository
 	NotificationRepository domain.NotificationRepository
+	InventoryRepository    domain.InventoryRepository
+	OrganizationRepository domain.OrganizationRepository
+	SearchRepository       domain.SearchRepository
+	UnitOfWork             domain.UnitOfWork
 
 	// Services
-	AuthService      domain.AuthService
-	UserService      domain.UserService
-	LeadService      domain.LeadService
-	ClientService    domain.ClientService
-	TaskService      domain.TaskService
-	SalesService     domain.SalesService
-	AnalyticsService domain.AnalyticsService
+	AuthService         domain.AuthService
+	UserService         domain.UserService
+	LeadService         domain.LeadService
+	ClientService       domain.ClientService
+	TaskService         domain.TaskService
+	SalesService        domain.SalesService
+	CommissionService   domain.CommissionService
+	PropertyService     domain.PropertyService
+	ReservationService  domain.ReservationService
+	AnalyticsService    domain.AnalyticsService
+	SearchService       domain.SearchService
+	OrganizationService domain.OrganizationService
+	TrashPurger         *services.TrashPurger
+	ReservationSweeper  *services.ReservationSweeper
 
 	// Handlers
 	AuthHandler *handlers.AuthHandlerNew
@@ -53,21 +71,146 @@ func NewContainer() (*Container, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Initialize Supabase client
-	supabaseClient, err := supabase.NewClient(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Supabase client: %w", err)
-	}
+	// Initialize repositories for the configured data backend
+	var (
+		supabaseClient   *supabase.Client
+		db               *postgres.DB
+		userRepo         domain.UserRepository
+		sessionRepo      domain.SessionRepository
+		tokenRepo        domain.AuthTokenRepository
+		mfaRepo          domain.MFARepository
+		eventRepo        domain.AuthEventRepository
+		walletRepo       domain.WalletRepository
+		nonceRepo        domain.SIWENonceRepository
+		leadRepo         domain.LeadRepository
+		companyRepo      domain.CompanyRepository
+		societyRepo      domain.SocietyRepository
+		projectRepo      domain.ProjectRepository
+		taskRepo         domain.TaskRepository
+		saleRepo         domain.SaleRepository
+		saleHistoryRepo  domain.SaleStatusHistoryRepository
+		paymentRepo      domain.PaymentScheduleRepository
+		revisionRepo     domain.PaymentScheduleRevisionRepository
+		commissionRepo   domain.CommissionRepository
+		planRepo         domain.CommissionPlanRepository
+		templateRepo     domain.AgreementTemplateRepository
+		agreementRepo    domain.AgreementRepository
+		clientRepo       domain.ClientRepository
+		notificationRepo domain.NotificationRepository
+		inventoryRepo    domain.InventoryRepository
+		interestRepo     domain.PropertyInterestRepository
+		priceListRepo    domain.PriceListRepository
+		organizationRepo domain.OrganizationRepository
+		searchRepo       domain.SearchRepository
+		uow              domain.UnitOfWork
+	)
+
+	switch cfg.DataBackend {
+	case "postgres":
+		db, err = postgres.NewDB(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create PostgreSQL connection: %w", err)
+		}
+
+		userRepo = postgres.NewUserRepository(db)
+		sessionRepo = postgres.NewSessionRepository(db)
+		tokenRepo = postgres.NewAuthTokenRepository(db)
+		mfaRepo = postgres.NewMFARepository(db)
+		eventRepo = postgres.NewAuthEventRepository(db)
+		walletRepo = postgres.NewWalletRepository(db)
+		nonceRepo = postgres.NewSIWENonceRepository(db)
+		leadRepo = postgres.NewLeadRepository(db)
+		companyRepo = postgres.NewCompanyRepository(db)
+		societyRepo = postgres.NewSocietyRepository(db)
+		projectRepo = postgres.NewProjectRepository(db)
+		taskRepo = postgres.NewTaskRepository(db)
+		saleRepo = postgres.NewSaleRepository(db)
+		saleHistoryRepo = postgres.NewSaleStatusHistoryRepository(db)
+		paymentRepo = postgres.NewPaymentScheduleRepository(db)
+		revisionRepo = postgres.NewPaymentScheduleRevisionRepository(db)
+		commissionRepo = postgres.NewCommissionRepository(db)
+		planRepo = postgres.NewCommissionPlanRepository(db)
+		templateRepo = postgres.NewAgreementTemplateRepository(db)
+		agreementRepo = postgres.NewAgreementRepository(db)
+		clientRepo = postgres.NewClientRepository(db)
+		notificationRepo = postgres.NewNotificationRepository(db)
+		inventoryRepo = postgres.NewInventoryRepository(db)
+		interestRepo = postgres.NewPropertyInterestRepository(db)
+		priceListRepo = postgres.NewPriceListRepository(db)
+		organizationRepo = postgres.NewOrganizationRepository(db)
+		searchRepo = postgres.NewSearchRepository(db)
+		uow = postgres.NewUnitOfWork(db)
+	case "memory":
+		store := memory.NewStore()
+		if err := memory.Seed(store); err != nil {
+			return nil, fmt.Errorf("failed to seed in-memory store: %w", err)
+		}
+
+		userRepo = memory.NewUserRepository(store)
+		sessionRepo = memory.NewSessionRepository(store)
+		tokenRepo = memory.NewAuthTokenRepository(store)
+		mfaRepo = memory.NewMFARepository(store)
+		eventRepo = memory.NewAuthEventRepository(store)
+		walletRepo = memory.NewWalletRepository(store)
+		nonceRepo = memory.NewSIWENonceRepository(store)
+		leadRepo = memory.NewLeadRepository(store)
+		companyRepo = memory.NewCompanyRepository(store)
+		societyRepo = memory.NewSocietyRepository(store)
+		projectRepo = memory.NewProjectRepository(store)
+		taskRepo = memory.NewTaskRepository(store)
+		saleRepo = memory.NewSaleRepository(store)
+		saleHistoryRepo = memory.NewSaleStatusHistoryRepository(store)
+		paymentRepo = memory.NewPaymentScheduleRepository(store)
+		revisionRepo = memory.NewPaymentScheduleRevisionRepository(store)
+		commissionRepo = memory.NewCommissionRepository(store)
+		planRepo = memory.NewCommissionPlanRepository(store)
+		templateRepo = memory.NewAgreementTemplateRepository(store)
+		agreementRepo = memory.NewAgreementRepository(store)
+		clientRepo = memory.NewClientRepository(store)
+		notificationRepo = memory.NewNotificationRepository(store)
+		inventoryRepo = memory.NewInventoryRepository(store)
+		interestRepo = memory.NewPropertyInterestRepository(store)
+		priceListRepo = memory.NewPriceListRepository(store)
+		organizationRepo = memory.NewOrganizationRepository(store)
+		searchRepo = memory.NewSearchRepository(store)
+		uow = memory.NewUnitOfWork(store)
+	case "supabase", "":
+		supabaseClient, err = supabase.NewClient(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Supabase client: %w", err)
+		}
 
-	// Initialize repositories
-	userRepo := supabase.NewUserRepository(supabaseClient)
-	leadRepo := supabase.NewLeadRepository(supabaseClient)
-	companyRepo := supabase.NewCompanyRepository(supabaseClient)
-	projectRepo := supabase.NewProjectRepository(supabaseClient)
-	taskRepo := supabase.NewTaskRepository(supabaseClient)
-	saleRepo := supabase.NewSaleRepository(supabaseClient)
-	clientRepo := supabase.NewClientRepository(supabaseClient)
-	notificationRepo := supabase.NewNotificationRepository(supabaseClient)
+		userRepo = supabase.NewUserRepository(supabaseClient)
+		sessionRepo = supabase.NewSessionRepository(supabaseClient)
+		tokenRepo = supabase.NewAuthTokenRepository(supabaseClient)
+		mfaRepo = supabase.NewMFARepository(supabaseClient)
+		eventRepo = supabase.NewAuthEventRepository(supabaseClient)
+		walletRepo = supabase.NewWalletRepository(supabaseClient)
+		nonceRepo = supabase.NewSIWENonceRepository(supabaseClient)
+		leadRepo = supabase.NewLeadRepository(supabaseClient)
+		companyRepo = supabase.NewCompanyRepository(supabaseClient)
+		societyRepo = supabase.NewSocietyRepository(supabaseClient)
+		projectRepo = supabase.NewProjectRepository(supabaseClient)
+		taskRepo = supabase.NewTaskRepository(supabaseClient)
+		saleRepo = supabase.NewSaleRepository(supabaseClient)
+		saleHistoryRepo = supabase.NewSaleStatusHistoryRepository(supabaseClient)
+		paymentRepo = supabase.NewPaymentScheduleRepository(supabaseClient)
+		revisionRepo = supabase.NewPaymentScheduleRevisionRepository(supabaseClient)
+		commissionRepo = supabase.NewCommissionRepository(supabaseClient)
+		planRepo = supabase.NewCommissionPlanRepository(supabaseClient)
+		templateRepo = supabase.NewAgreementTemplateRepository(supabaseClient)
+		agreementRepo = supabase.NewAgreementRepository(supabaseClient)
+		clientRepo = supabase.NewClientRepository(supabaseClient)
+		notificationRepo = supabase.NewNotificationRepository(supabaseClient)
+		inventoryRepo = supabase.NewInventoryRepository(supabaseClient)
+		interestRepo = supabase.NewPropertyInterestRepository(supabaseClient)
+		priceListRepo = supabase.NewPriceListRepository(supabaseClient)
+		organizationRepo = supabase.NewOrganizationRepository(supabaseClient)
+		searchRepo = supabase.NewSearchRepository(supabaseClient)
+		uow = supabase.NewUnitOfWork(supabaseClient)
+	default:
+		return nil, fmt.Errorf("unknown DATA_BACKEND %q", cfg.DataBackend)
+	}
 
 	// Initialize observability
 	obsConfig := observability.DefaultConfig("goreal-backend", "1.0.0", cfg.Environment)
@@ -76,52 +219,106 @@ func NewContainer() (*Container, error) {
 		return nil, fmt.Errorf("failed to create observability: %w", err)
 	}
 
+	geocoder, err := geocoding.New(cfg.GeocoderProvider, cfg.GeocoderGazetteerPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create geocoder: %w", err)
+	}
+
+	mailer, err := mail.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mailer: %w", err)
+	}
+
+	// Login metrics are only recorded when metrics are collected
+	var authMetrics domain.AuthMetrics
+	if metrics := obs.Metrics(); metrics != nil {
+		authMetrics = metrics
+	}
+
 	// Initialize services
-	authService := services.NewAuthService(cfg, userRepo)
+	authService := services.NewAuthService(cfg, userRepo, sessionRepo, tokenRepo, mfaRepo, eventRepo, walletRepo, nonceRepo,
+		mailer, authMetrics)
 	userService := services.NewUserService(cfg, userRepo)
 	notificationService := services.NewNotificationService(cfg)
 
 	// Initialize core business services
 	clientService := services.NewClientService(cfg, clientRepo, userRepo, companyRepo, leadRepo, notificationService)
 	taskService := services.NewTaskService(cfg, taskRepo, userRepo, notificationService)
-	salesService := services.NewSalesService(cfg, saleRepo, clientRepo, nil, userRepo, notificationService) // inventoryRepo will be added when implemented
+	commissionService := services.NewCommissionService(commissionRepo, planRepo, saleRepo, userRepo, uow)
+	unitFeed := services.NewUnitFeed()
+	reservationService := services.NewReservationService(inventoryRepo, projectRepo, saleRepo, clientRepo, uow,
+		notificationService, unitFeed)
+	salesService := services.NewSalesService(cfg, saleRepo, saleHistoryRepo, clientRepo, inventoryRepo, projectRepo,
+		paymentRepo, revisionRepo, templateRepo, agreementRepo, userRepo, uow, notificationService, commissionService,
+		reservationService)
+	propertyService := services.NewPropertyService(societyRepo, projectRepo, inventoryRepo, interestRepo, priceListRepo,
+		clientRepo, leadRepo, saleRepo, uow, reservationService, unitFeed, geocoder)
 
-	leadService := services.NewLeadService(cfg, leadRepo, clientRepo, userRepo, taskRepo, nil, notificationService) // followUpRepo will be added when implemented
+	leadService := services.NewLeadService(cfg, leadRepo, clientRepo, userRepo, taskRepo, nil, uow, notificationService) // followUpRepo will be added when implemented
 
 	// Initialize analytics service
-	analyticsService := services.NewAnalyticsService(leadRepo, clientRepo, saleRepo, nil, taskRepo, userRepo, nil) // inventoryRepo and cashbookRepo will be added when implemented
+	analyticsService := services.NewAnalyticsService(leadRepo, clientRepo, saleRepo, inventoryRepo, taskRepo, userRepo, nil) // cashbookRepo will be added when implemented
+
+	searchService := services.NewSearchService(searchRepo)
+	organizationService := services.NewOrganizationService(organizationRepo, userRepo)
+
+	trashPurger := services.NewTrashPurger(cfg, leadRepo, clientRepo, saleRepo, taskRepo)
+	reservationSweeper := services.NewReservationSweeper(cfg, reservationService)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandlerNew(authService)
 
 	return &Container{
-		Config:               cfg,
-		Observability:        obs,
-		SupabaseClient:       supabaseClient,
-		UserRepository:       userRepo,
-		LeadRepository:       leadRepo,
-		CompanyRepository:    companyRepo,
-		ProjectRepository:    projectRepo,
-		TaskRepository:       taskRepo,
-		SaleRepository:       saleRepo,
-		ClientRepository:     clientRepo,
+		Config:                 cfg,
+		Observability:          obs,
+		SupabaseClient:         supabaseClient,
+		DB:                     db,
+		UserRepository:         userRepo,
+		LeadRepository:         leadRepo,
+		CompanyRepository:      companyRepo,
+		SocietyRepository:      societyRepo,
+		ProjectRepository:      projectRepo,
+		TaskRepository:         taskRepo,
+		SaleRepository:         saleRepo,
+		SaleHistoryRepository:  saleHistoryRepo,
+		ClientRepository:       clientRepo,
 		NotificationRepository: notificationRepo,
-		AuthService:          authService,
-		UserService:          userService,
-		LeadService:          leadService,
-		ClientService:        clientService,
-		TaskService:          taskService,
-		SalesService:         salesService,
-		AnalyticsService:     analyticsService,
-		AuthHandler:          authHandler,
+		InventoryRepository:    inventoryRepo,
+		OrganizationRepository: organizationRepo,
+		SearchRepository:       searchRepo,
+		UnitOfWork:             uow,
+		AuthService:            authService,
+		UserService:            userService,
+		LeadService:            leadService,
+		ClientService:          clientService,
+		TaskService:            taskService,
+		SalesService:           salesService,
+		CommissionService:      commissionService,
+		PropertyService:        propertyService,
+		ReservationService:     reservationService,
+		AnalyticsService:       analyticsService,
+		SearchService:          searchService,
+		OrganizationService:    organizationService,
+		TrashPurger:            trashPurger,
+		ReservationSweeper:     reservationSweeper,
+		AuthHandler:            authHandler,
 	}, nil
 }
 
 // Health checks the health of all dependencies
 func (c *Container) Health() error {
 	// Check Supabase connection
-	if err := c.SupabaseClient.Health(nil); err != nil {
-		return fmt.Errorf("supabase health check failed: %w", err)
+	if c.SupabaseClient != nil {
+		if err := c.SupabaseClient.Health(nil); err != nil {
+			return fmt.Errorf("supabase health check failed: %w", err)
+		}
+	}
+
+	// Check PostgreSQL connection
+	if c.DB != nil {
+		if err := c.DB.Health(nil); err != nil {
+			return fmt.Errorf("postgres health check failed: %w", err)
+		}
 	}
 
 	// Add other health checks as needed
@@ -131,9 +328,12 @@ func (c *Container) Health() error {
 
 // Close gracefully shuts down all dependencies
 func (c *Container) Close() error {
-	// Add cleanup logic for any resources that need to be closed
-	// For example, database connections, message queues, etc.
-	
+	if c.DB != nil {
+		if err := c.DB.Close(); err != nil {
+			return fmt.Errorf("failed to close database: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AgreementFormat is the file format of a generated sale agreement
+type AgreementFormat string
+
+const (
+	AgreementFormatPDF  AgreementFormat = "pdf"
+	AgreementFormatDOCX AgreementFormat = "docx"
+)
+
+// ParseAgreementFormat validates a requested format, defaulting to PDF
+func ParseAgreementFormat(s string) (AgreementFormat, error) {
+	switch format := AgreementFormat(strings.ToLower(strings.TrimSpace(s))); format {
+	case "":
+		return AgreementFormatPDF, nil
+	case AgreementFormatPDF, AgreementFormatDOCX:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported agreement format %q: %w", s, ErrInvalidInput)
+	}
+}
+
+// ContentType returns the MIME type of the format
+func (f AgreementFormat) ContentType() string {
+	if f == AgreementFormatDOCX {
+		return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	}
+	return "application/pdf"
+}
+
+// AgreementTemplate is one version of a sale agreement template. Templates
+// without a project are the organization default; a project's own templates
+// override it for that project's sales. Each save adds a new version.
+type AgreementTemplate struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	OrganizationID *uuid.UUID `json:"organization_id" db:"organization_id"`
+	ProjectID      *uuid.UUID `json:"project_id" db:"project_id"`
+	Name           string     `json:"name" db:"name"`
+	Version        int        `json:"version" db:"version"`
+	// Body is a text/template producing document markup: "#" headings,
+	// "|" table rows and blank-line separated paragraphs
+	Body      string    `json:"body" db:"body"`
+	CreatedBy uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Agreement is a generated sale agreement document
+type Agreement struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	OrganizationID *uuid.UUID `json:"organization_id" db:"organization_id"`
+	SaleID         uuid.UUID  `json:"sale_id" db:"sale_id"`
+	// TemplateID is nil when the built-in template was used
+	TemplateID      *uuid.UUID      `json:"template_id" db:"template_id"`
+	TemplateVersion int             `json:"template_version" db:"template_version"`
+	Format          AgreementFormat `json:"format" db:"format"`
+	FileName        string          `json:"file_name" db:"file_name"`
+	// Checksum is the hex SHA-256 of Content
+	Checksum  string    `json:"checksum" db:"checksum"`
+	Content   []byte    `json:"-" db:"content"`
+	CreatedBy uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// DocumentPath is the reference recorded in Sale.Documents
+func (a *Agreement) DocumentPath() string {
+	return fmt.Sprintf("agreements/%s/%s", a.ID, a.FileName)
+}
+
+// SaveAgreementTemplateRequest adds a new template version
+type SaveAgreementTemplateRequest struct {
+	ProjectID *uuid.UUID `json:"project_id"`
+	Name      string     `json:"name"`
+	Body      string     `json:"body"`
+	CreatedBy uuid.UUID  `json:"-"`
+}
+
+// GenerateAgreementRequest asks for a sale's agreement in a format
+type GenerateAgreementRequest struct {
+	Format      AgreementFormat `json:"format"`
+	GeneratedBy uuid.UUID       `json:"-"`
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthEvent is an entry in the audit trail of logins: a failed attempt, or
+// an account being locked or unlocked. Failed attempts with an unknown
+// email address have no user.
+type AuthEvent struct {
+	ID        uuid.UUID     `json:"id" db:"id"`
+	UserID    *uuid.UUID    `json:"user_id" db:"user_id"`
+	Email     string        `json:"email" db:"email"`
+	Type      AuthEventType `json:"type" db:"type"`
+	IPAddress *string       `json:"ip_address" db:"ip_address"`
+	UserAgent *string       `json:"user_agent" db:"user_agent"`
+	// ActorID is the admin who unlocked an account
+	ActorID *uuid.UUID `json:"actor_id,omitempty" db:"actor_id"`
+	// LockedUntil is when a lock set by the event ends
+	LockedUntil *time.Time `json:"locked_until,omitempty" db:"locked_until"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// AuthEventType is what an AuthEvent records
+type AuthEventType string
+
+const (
+	AuthEventLoginFailed     AuthEventType = "login_failed"
+	AuthEventAccountLocked   AuthEventType = "account_locked"
+	AuthEventAccountUnlocked AuthEventType = "account_unlocked"
+)
+
+// LockoutError refuses a login while an account, or the address it comes
+// from, is locked after too many failed attempts. It wraps ErrAccountLocked.
+type LockoutError struct {
+	Until time.Time
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("too many failed logins, try again after %s", e.Until.UTC().Format(time.RFC3339))
+}
+
+func (e *LockoutError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// AuthMetrics records login outcomes
+type AuthMetrics interface {
+	RecordLoginAttempt(ctx context.Context, success bool, method string)
+	RecordAuthFailure(ctx context.Context, reason string)
+}
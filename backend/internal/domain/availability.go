@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AvailabilityMatrix is the stacking plan of a project: its units laid out
+// tower by tower and floor by floor, the way site offices show them
+type AvailabilityMatrix struct {
+	ProjectID   uuid.UUID           `json:"project_id"`
+	ProjectName string              `json:"project_name"`
+	Towers      []AvailabilityTower `json:"towers"`
+	// Summary counts the units in the matrix by status
+	Summary     map[UnitStatus]int `json:"summary"`
+	TotalUnits  int                `json:"total_units"`
+	GeneratedAt time.Time          `json:"generated_at"`
+}
+
+// AvailabilityTower is one column of a stacking plan. Units with no tower
+// block are grouped under an empty name. Floors run from the top floor
+// down, with units that have no floor number last.
+type AvailabilityTower struct {
+	Name   string              `json:"name"`
+	Floors []AvailabilityFloor `json:"floors"`
+	// Width is the number of units on the tower's widest floor
+	Width int `json:"width"`
+}
+
+// AvailabilityFloor is one row of a tower, its units in unit number order
+type AvailabilityFloor struct {
+	Floor *int               `json:"floor"`
+	Units []AvailabilityCell `json:"units"`
+}
+
+// AvailabilityCell is a unit as shown in a stacking plan
+type AvailabilityCell struct {
+	InventoryID   uuid.UUID  `json:"inventory_id"`
+	UnitNumber    string     `json:"unit_number"`
+	UnitType      *string    `json:"unit_type"`
+	Facing        *string    `json:"facing"`
+	Status        UnitStatus `json:"status"`
+	FinalPrice    *float64   `json:"final_price"`
+	ReservedUntil *time.Time `json:"reserved_until"`
+}
+
+// NewAvailabilityCell returns the stacking plan cell of a unit
+func NewAvailabilityCell(unit *Inventory) AvailabilityCell {
+	return AvailabilityCell{
+		InventoryID:   unit.ID,
+		UnitNumber:    unit.UnitNumber,
+		UnitType:      unit.UnitType,
+		Facing:        unit.Facing,
+		Status:        unit.Status,
+		FinalPrice:    unit.FinalPrice,
+		ReservedUntil: unit.ReservedUntil,
+	}
+}
+
+// AvailabilityFilters narrow a stacking plan, and the changes streamed for
+// it, to units of a type or facing a direction. Both match ignoring case.
+type AvailabilityFilters struct {
+	UnitType *string `json:"unit_type,omitempty"`
+	Facing   *string `json:"facing,omitempty"`
+}
+
+// UnitChange is the state of a unit after it was added or changed, placed
+// in its project's stacking plan
+type UnitChange struct {
+	ProjectID  uuid.UUID `json:"project_id"`
+	TowerBlock string    `json:"tower_block"`
+	Floor      *int      `json:"floor"`
+	AvailabilityCell
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// UnitChangeFeed passes unit changes on to the clients watching a project's
+// stacking plan. Services publish units once their changes are committed.
+type UnitChangeFeed interface {
+	Publish(units ...*Inventory)
+	// Subscribe returns the changes to a project's units and a function
+	// that ends the subscription. The channel is closed when the
+	// subscription ends or the watcher falls too far behind.
+	Subscribe(projectID uuid.UUID) (<-chan UnitChange, func())
+}
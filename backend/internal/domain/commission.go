@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommissionPlanType selects how a plan finds the commission rate of a sale
+type CommissionPlanType string
+
+const (
+	// CommissionPlanFlat pays the same rate on every sale
+	CommissionPlanFlat CommissionPlanType = "flat"
+	// CommissionPlanTiered pays the rate of the highest slab the
+	// salesperson's monthly sales volume has reached
+	CommissionPlanTiered CommissionPlanType = "tiered"
+)
+
+// Commission types recorded on Commission rows
+const (
+	CommissionTypePrimary  = "primary"
+	CommissionTypeManager  = "manager"
+	CommissionTypeClawback = "clawback"
+)
+
+// CommissionPlan decides the commissions earned on a sale. Each save adds
+// a new plan; the newest one applies to sales approved from then on.
+type CommissionPlan struct {
+	ID             uuid.UUID          `json:"id" db:"id"`
+	OrganizationID *uuid.UUID         `json:"organization_id" db:"organization_id"`
+	Name           string             `json:"name" db:"name"`
+	Type           CommissionPlanType `json:"type" db:"type"`
+	// Rate is the percentage of the final amount paid by a flat plan
+	Rate float64 `json:"rate" db:"rate"`
+	// Slabs are the rates of a tiered plan, by ascending monthly volume
+	Slabs []CommissionSlab `json:"slabs" db:"slabs"`
+	// ManagerShare is the percentage of a sale's commission that goes to
+	// the sale's manager rather than its salesperson
+	ManagerShare float64 `json:"manager_share" db:"manager_share"`
+	// Clawback recovers commission already paid when a sale is cancelled
+	// or refunded; unpaid commission is always cancelled with the sale
+	Clawback  bool      `json:"clawback" db:"clawback"`
+	CreatedBy uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CommissionSlab is the rate paid once monthly volume reaches MinVolume
+type CommissionSlab struct {
+	MinVolume float64 `json:"min_volume"`
+	Rate      float64 `json:"rate"`
+}
+
+// Validate checks that a plan can be applied
+func (p *CommissionPlan) Validate() error {
+	rules := BusinessRules{}
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("plan name is required: %w", ErrInvalidInput)
+	}
+	if p.ManagerShare < 0 || p.ManagerShare > 100 {
+		return fmt.Errorf("manager share must be between 0 and 100 percent: %w", ErrInvalidInput)
+	}
+
+	switch p.Type {
+	case CommissionPlanFlat:
+		if len(p.Slabs) > 0 {
+			return fmt.Errorf("flat plans have no slabs: %w", ErrInvalidInput)
+		}
+		if err := rules.ValidateCommissionRate(p.Rate); err != nil {
+			return fmt.Errorf("%s: %w", err, ErrInvalidInput)
+		}
+	case CommissionPlanTiered:
+		if len(p.Slabs) == 0 {
+			return fmt.Errorf("tiered plans need at least one slab: %w", ErrInvalidInput)
+		}
+		if p.Slabs[0].MinVolume != 0 {
+			return fmt.Errorf("the first slab must start at a volume of 0: %w", ErrInvalidInput)
+		}
+		for i, slab := range p.Slabs {
+			if i > 0 && slab.MinVolume <= p.Slabs[i-1].MinVolume {
+				return fmt.Errorf("slab %d must start above the one before it: %w", i+1, ErrInvalidInput)
+			}
+			if err := rules.ValidateCommissionRate(slab.Rate); err != nil {
+				return fmt.Errorf("slab %d: %s: %w", i+1, err, ErrInvalidInput)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown commission plan type %q: %w", p.Type, ErrInvalidInput)
+	}
+	return nil
+}
+
+// RateFor returns the percentage paid on a sale by a salesperson whose
+// sales that month add up to volume. Tiered plans apply the reached slab's
+// rate to the whole sale.
+func (p *CommissionPlan) RateFor(volume float64) float64 {
+	if p.Type != CommissionPlanTiered {
+		return p.Rate
+	}
+	rate := 0.0
+	for _, slab := range p.Slabs {
+		if volume >= slab.MinVolume {
+			rate = slab.Rate
+		}
+	}
+	return rate
+}
+
+// SaveCommissionPlanRequest adds a new commission plan
+type SaveCommissionPlanRequest struct {
+	Name         string             `json:"name"`
+	Type         CommissionPlanType `json:"type"`
+	Rate         float64            `json:"rate"`
+	Slabs        []CommissionSlab   `json:"slabs"`
+	ManagerShare float64            `json:"manager_share"`
+	Clawback     bool               `json:"clawback"`
+	CreatedBy    uuid.UUID          `json:"-"`
+}
+
+// ClawbackRequest recovers commission on a cancelled or refunded sale
+type ClawbackRequest struct {
+	// Amount is the refunded part of the sale; zero claws back everything
+	Amount      float64   `json:"amount"`
+	Reason      string    `json:"reason"`
+	RequestedBy uuid.UUID `json:"-"`
+}
+
+// SplitCommission works out the commission at rate percent on amount and
+// splits it between the salesperson and the manager, who gets managerShare
+// percent of it. The salesperson keeps the rounding difference.
+func SplitCommission(amount, rate, managerShare float64) (primary, manager float64) {
+	total := int64(math.Round(float64(toCents(amount)) * rate / 100))
+	managed := int64(math.Round(float64(total) * managerShare / 100))
+	return float64(total-managed) / 100, float64(managed) / 100
+}
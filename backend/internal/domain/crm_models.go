@@ -11,6 +11,7 @@ import (
 // Company represents a business organization
 type Company struct {
 	ID                 uuid.UUID              `json:"id" db:"id"`
+	OrganizationID     *uuid.UUID             `json:"organization_id" db:"organization_id"`
 	Name               string                 `json:"name" db:"name"`
 	RegistrationNumber *string                `json:"registration_number" db:"registration_number"`
 	TaxID              *string                `json:"tax_id" db:"tax_id"`
@@ -27,16 +28,19 @@ type Company struct {
 
 // Address represents a physical address
 type Address struct {
-	Street     string `json:"street"`
-	City       string `json:"city"`
-	State      string `json:"state"`
-	Country    string `json:"country"`
-	PostalCode string `json:"postal_code"`
+	Street     string   `json:"street"`
+	City       string   `json:"city"`
+	State      string   `json:"state"`
+	Country    string   `json:"country"`
+	PostalCode string   `json:"postal_code"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
 }
 
 // Lead represents a potential customer
 type Lead struct {
 	ID               uuid.UUID    `json:"id" db:"id"`
+	OrganizationID   *uuid.UUID   `json:"organization_id" db:"organization_id"`
 	Name             string       `json:"name" db:"name"`
 	Email            *string      `json:"email" db:"email"`
 	Phone            *string      `json:"phone" db:"phone"`
@@ -58,6 +62,8 @@ type Lead struct {
 	CreatedBy        uuid.UUID    `json:"created_by" db:"created_by"`
 	CreatedAt        time.Time    `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time    `json:"updated_at" db:"updated_at"`
+	DeletedAt        *time.Time   `json:"deleted_at,omitempty" db:"deleted_at"`
+	DeletedBy        *uuid.UUID   `json:"deleted_by,omitempty" db:"deleted_by"`
 }
 
 // LeadSource represents the source of a lead
@@ -92,6 +98,7 @@ const (
 // Client represents a converted lead or direct client
 type Client struct {
 	ID               uuid.UUID    `json:"id" db:"id"`
+	OrganizationID   *uuid.UUID   `json:"organization_id" db:"organization_id"`
 	ProfileID        *uuid.UUID   `json:"profile_id" db:"profile_id"`
 	Profile          *User        `json:"profile,omitempty"`
 	LeadID           *uuid.UUID   `json:"lead_id" db:"lead_id"`
@@ -117,6 +124,8 @@ type Client struct {
 	CreatedBy        uuid.UUID    `json:"created_by" db:"created_by"`
 	CreatedAt        time.Time    `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time    `json:"updated_at" db:"updated_at"`
+	DeletedAt        *time.Time   `json:"deleted_at,omitempty" db:"deleted_at"`
+	DeletedBy        *uuid.UUID   `json:"deleted_by,omitempty" db:"deleted_by"`
 }
 
 // ClientType represents the type of client
@@ -139,26 +148,30 @@ type Contact struct {
 
 // Society represents a real estate development/society
 type Society struct {
-	ID            uuid.UUID `json:"id" db:"id"`
-	Name          string    `json:"name" db:"name"`
-	DeveloperName *string   `json:"developer_name" db:"developer_name"`
-	Location      string    `json:"location" db:"location"`
-	Address       *Address  `json:"address" db:"address"`
-	TotalArea     *float64  `json:"total_area" db:"total_area"`
-	TotalUnits    *int      `json:"total_units" db:"total_units"`
-	Amenities     []string  `json:"amenities" db:"amenities"`
-	Description   *string   `json:"description" db:"description"`
-	Images        []string  `json:"images" db:"images"`
-	BrochureURL   *string   `json:"brochure_url" db:"brochure_url"`
-	IsActive      bool      `json:"is_active" db:"is_active"`
-	CreatedBy     uuid.UUID `json:"created_by" db:"created_by"`
-	CreatedAt     time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+	ID             uuid.UUID  `json:"id" db:"id"`
+	OrganizationID *uuid.UUID `json:"organization_id" db:"organization_id"`
+	Name           string     `json:"name" db:"name"`
+	DeveloperName  *string    `json:"developer_name" db:"developer_name"`
+	Location       string     `json:"location" db:"location"`
+	Address        *Address   `json:"address" db:"address"`
+	Latitude       *float64   `json:"latitude" db:"latitude"`
+	Longitude      *float64   `json:"longitude" db:"longitude"`
+	TotalArea      *float64   `json:"total_area" db:"total_area"`
+	TotalUnits     *int       `json:"total_units" db:"total_units"`
+	Amenities      []string   `json:"amenities" db:"amenities"`
+	Description    *string    `json:"description" db:"description"`
+	Images         []string   `json:"images" db:"images"`
+	BrochureURL    *string    `json:"brochure_url" db:"brochure_url"`
+	IsActive       bool       `json:"is_active" db:"is_active"`
+	CreatedBy      uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // Project represents a project within a society
 type Project struct {
 	ID                  uuid.UUID     `json:"id" db:"id"`
+	OrganizationID      *uuid.UUID    `json:"organization_id" db:"organization_id"`
 	SocietyID           uuid.UUID     `json:"society_id" db:"society_id"`
 	Society             *Society      `json:"society,omitempty"`
 	Name                string        `json:"name" db:"name"`
@@ -182,7 +195,7 @@ type Project struct {
 	RERANumber          *string       `json:"rera_number" db:"rera_number"`
 	Approvals           CustomFields  `json:"approvals" db:"approvals"`
 	Amenities           []string      `json:"amenities" db:"amenities"`
-	NearbyFacilities    CustomFields  `json:"nearby_facilities" db:"nearby_facilities"`
+	NearbyFacilities    []NearbyFacility `json:"nearby_facilities" db:"nearby_facilities"`
 	CreatedBy           uuid.UUID     `json:"created_by" db:"created_by"`
 	CreatedAt           time.Time     `json:"created_at" db:"created_at"`
 	UpdatedAt           time.Time     `json:"updated_at" db:"updated_at"`
@@ -203,6 +216,7 @@ const (
 // Inventory represents individual units/properties
 type Inventory struct {
 	ID                uuid.UUID    `json:"id" db:"id"`
+	OrganizationID    *uuid.UUID   `json:"organization_id" db:"organization_id"`
 	ProjectID         uuid.UUID    `json:"project_id" db:"project_id"`
 	Project           *Project     `json:"project,omitempty"`
 	UnitNumber        string       `json:"unit_number" db:"unit_number"`
@@ -225,6 +239,8 @@ type Inventory struct {
 	ReservedBy        *uuid.UUID   `json:"reserved_by" db:"reserved_by"`
 	ReservedClient    *Client      `json:"reserved_client,omitempty"`
 	ReservedUntil     *time.Time   `json:"reserved_until" db:"reserved_until"`
+	// DistanceKm is the distance from the centre of a property search
+	DistanceKm        *float64     `json:"distance_km,omitempty" db:"-"`
 	CreatedBy         uuid.UUID    `json:"created_by" db:"created_by"`
 	CreatedAt         time.Time    `json:"created_at" db:"created_at"`
 	UpdatedAt         time.Time    `json:"updated_at" db:"updated_at"`
@@ -246,6 +262,7 @@ type CustomFields map[string]interface{}
 // Sale represents a property sale transaction
 type Sale struct {
 	ID               uuid.UUID   `json:"id" db:"id"`
+	OrganizationID   *uuid.UUID  `json:"organization_id" db:"organization_id"`
 	SaleNumber       string      `json:"sale_number" db:"sale_number"`
 	ClientID         uuid.UUID   `json:"client_id" db:"client_id"`
 	Client           *Client     `json:"client,omitempty"`
@@ -272,6 +289,8 @@ type Sale struct {
 	CreatedBy        uuid.UUID   `json:"created_by" db:"created_by"`
 	CreatedAt        time.Time   `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time   `json:"updated_at" db:"updated_at"`
+	DeletedAt        *time.Time  `json:"deleted_at,omitempty" db:"deleted_at"`
+	DeletedBy        *uuid.UUID  `json:"deleted_by,omitempty" db:"deleted_by"`
 }
 
 // SaleStatus represents the status of a sale
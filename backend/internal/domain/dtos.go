@@ -14,14 +14,21 @@ type AuthResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 	ExpiresIn    int    `json:"expires_in"`
+	// MFARequired is set when the password was right but the user must also
+	// give a code from their authenticator: MFAToken is then exchanged for
+	// the tokens, and ExpiresIn is how long it may take
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
 }
 
+// RegisterRequest signs up a new account. Role is optional and may only
+// name the role every registration gets.
 type RegisterRequest struct {
 	Email    string   `json:"email" validate:"required,email"`
 	Password string   `json:"password" validate:"required,min=8"`
 	Username string   `json:"username" validate:"required,min=3"`
 	FullName string   `json:"full_name" validate:"required"`
-	Role     UserRole `json:"role" validate:"required"`
+	Role     UserRole `json:"role,omitempty"`
 }
 
 type LoginRequest struct {
@@ -104,6 +111,8 @@ type UpdateLeadRequest struct {
 	NextFollowUp    *time.Time  `json:"next_follow_up"`
 	Score           *int        `json:"score"`
 	Tags            []string    `json:"tags"`
+
+	Version string `json:"-"` // expected version from If-Match
 }
 
 type ConvertLeadRequest struct {
@@ -161,6 +170,8 @@ type UpdateClientRequest struct {
 	AssignedTo       *uuid.UUID  `json:"assigned_to"`
 	CreditLimit      *float64    `json:"credit_limit"`
 	Tags             []string    `json:"tags"`
+
+	Version string `json:"-"` // expected version from If-Match
 }
 
 type ClientHistory struct {
@@ -178,12 +189,15 @@ type CreateSocietyRequest struct {
 	DeveloperName *string  `json:"developer_name"`
 	Location      string   `json:"location" validate:"required"`
 	Address       *Address `json:"address"`
+	Latitude      *float64 `json:"latitude"`
+	Longitude     *float64 `json:"longitude"`
 	TotalArea     *float64 `json:"total_area"`
 	TotalUnits    *int     `json:"total_units"`
 	Amenities     []string `json:"amenities"`
 	Description   *string  `json:"description"`
 	Images        []string `json:"images"`
 	BrochureURL   *string  `json:"brochure_url"`
+	CreatedBy     uuid.UUID `json:"-"`
 }
 
 type UpdateSocietyRequest struct {
@@ -191,6 +205,8 @@ type UpdateSocietyRequest struct {
 	DeveloperName *string  `json:"developer_name"`
 	Location      *string  `json:"location"`
 	Address       *Address `json:"address"`
+	Latitude      *float64 `json:"latitude"`
+	Longitude     *float64 `json:"longitude"`
 	TotalArea     *float64 `json:"total_area"`
 	TotalUnits    *int     `json:"total_units"`
 	Amenities     []string `json:"amenities"`
@@ -218,6 +234,8 @@ type CreateProjectRequest struct {
 	BrochureURL         *string       `json:"brochure_url"`
 	RERANumber          *string       `json:"rera_number"`
 	Amenities           []string      `json:"amenities"`
+	NearbyFacilities    []NearbyFacility `json:"nearby_facilities"`
+	CreatedBy           uuid.UUID     `json:"-"`
 }
 
 type UpdateProjectRequest struct {
@@ -239,6 +257,7 @@ type UpdateProjectRequest struct {
 	BrochureURL         *string        `json:"brochure_url"`
 	RERANumber          *string        `json:"rera_number"`
 	Amenities           []string       `json:"amenities"`
+	NearbyFacilities    []NearbyFacility `json:"nearby_facilities"`
 }
 
 type CreateInventoryRequest struct {
@@ -259,6 +278,7 @@ type CreateInventoryRequest struct {
 	Bathrooms         int       `json:"bathrooms"`
 	FloorPlanURL      *string   `json:"floor_plan_url"`
 	Features          []string  `json:"features"`
+	CreatedBy         uuid.UUID `json:"-"`
 }
 
 type UpdateInventoryRequest struct {
@@ -282,19 +302,26 @@ type UpdateInventoryRequest struct {
 }
 
 type PropertySearchRequest struct {
-	Location         *string     `json:"location"`
-	ProjectType      *string     `json:"project_type"`
-	UnitType         *string     `json:"unit_type"`
-	PriceMin         *float64    `json:"price_min"`
-	PriceMax         *float64    `json:"price_max"`
-	AreaMin          *float64    `json:"area_min"`
-	AreaMax          *float64    `json:"area_max"`
-	Amenities        []string    `json:"amenities"`
-	Status           *UnitStatus `json:"status"`
-	Facing           *string     `json:"facing"`
-	ParkingRequired  *bool       `json:"parking_required"`
-	Limit            int         `json:"limit"`
-	Offset           int         `json:"offset"`
+	Location         *string         `json:"location"`
+	ProjectType      *string         `json:"project_type"`
+	UnitType         *string         `json:"unit_type"`
+	PriceMin         *float64        `json:"price_min"`
+	PriceMax         *float64        `json:"price_max"`
+	AreaMin          *float64        `json:"area_min"`
+	AreaMax          *float64        `json:"area_max"`
+	Amenities        []string        `json:"amenities"`
+	Status           *UnitStatus     `json:"status"`
+	Facing           *string         `json:"facing"`
+	ParkingRequired  *bool           `json:"parking_required"`
+	// Near is the centre of a radius search; without it a radius is
+	// measured from the geocoded Location. Results are then ranked by
+	// distance.
+	Near             *GeoPoint       `json:"near"`
+	RadiusKm         *float64        `json:"radius_km"`
+	BoundingBox      *GeoBoundingBox `json:"bounding_box"`
+	Polygon          []GeoPoint      `json:"polygon"`
+	Limit            int             `json:"limit"`
+	Offset           int             `json:"offset"`
 }
 
 // Sale DTOs
@@ -324,14 +351,20 @@ type UpdateSaleRequest struct {
 	RegistrationDate *time.Time   `json:"registration_date"`
 	Notes            *string      `json:"notes"`
 	Documents        []string     `json:"documents"`
+
+	Version string `json:"-"` // expected version from If-Match
 }
 
+// CreatePaymentScheduleRequest gives either the installments themselves or
+// a plan to generate them from. Installments are numbered in list order.
 type CreatePaymentScheduleRequest struct {
-	Installments []PaymentInstallment `json:"installments" validate:"required"`
+	Installments []PaymentInstallment `json:"installments"`
+	Plan         *PaymentPlan         `json:"plan"`
+	CreatedBy    uuid.UUID            `json:"-"`
 }
 
 type PaymentInstallment struct {
-	InstallmentNumber int     `json:"installment_number" validate:"required"`
+	InstallmentNumber int     `json:"installment_number"`
 	DueDate           time.Time `json:"due_date" validate:"required"`
 	Amount            float64 `json:"amount" validate:"required,gt=0"`
 	Description       *string `json:"description"`
@@ -394,6 +427,8 @@ type UpdateTaskRequest struct {
 	CompletedAt  *time.Time `json:"completed_at"`
 	Notes        *string    `json:"notes"`
 	Tags         []string   `json:"tags"`
+
+	Version string `json:"-"` // expected version from If-Match
 }
 
 // Financial DTOs
@@ -462,6 +497,7 @@ type CommissionReport struct {
 	TotalCommission float64   `json:"total_commission"`
 	PaidCommission  float64   `json:"paid_commission"`
 	PendingCommission float64 `json:"pending_commission"`
+	ClawbackCommission float64 `json:"clawback_commission"`
 	SalesCount      int       `json:"sales_count"`
 	Period          string    `json:"period"`
 }
@@ -14,6 +14,7 @@ var (
 	ErrUserNotFound     = errors.New("user not found")
 	ErrInvalidToken     = errors.New("invalid token")
 	ErrTokenExpired     = errors.New("token expired")
+	ErrConflict         = errors.New("conflict")
 )
 
 // Authentication errors
@@ -23,6 +24,7 @@ var (
 	ErrAccountInactive    = errors.New("account inactive")
 	ErrPasswordTooWeak    = errors.New("password too weak")
 	ErrEmailNotVerified   = errors.New("email not verified")
+	ErrInvalidMFACode     = errors.New("invalid verification code")
 )
 
 // Business logic errors
@@ -33,6 +35,7 @@ var (
 	ErrLeadAlreadyConverted = errors.New("lead already converted")
 	ErrSaleAlreadyApproved  = errors.New("sale already approved")
 	ErrInventoryNotAvailable = errors.New("inventory not available")
+	ErrInvalidTransition     = errors.New("invalid status transition")
 )
 
 // Validation errors
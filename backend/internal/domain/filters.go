@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"encoding/base64"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +17,96 @@ type BaseFilters struct {
 	Offset int `json:"offset"`
 	SortBy string `json:"sort_by"`
 	SortOrder string `json:"sort_order"` // asc, desc
+	Cursor *Cursor `json:"cursor,omitempty"` // keyset pagination; Offset is ignored when set
+}
+
+// KeysetOrder reports whether rows are listed by created_at and then id,
+// the order cursors follow, and whether created_at descends. The default
+// order is newest first; an explicit created_at sort follows SortOrder.
+func (f BaseFilters) KeysetOrder() (desc bool, ok bool) {
+	switch f.SortBy {
+	case "":
+		return true, true
+	case "created_at":
+		return strings.EqualFold(f.SortOrder, "desc"), true
+	}
+	return false, false
+}
+
+// Cursor is a keyset pagination position: the created_at and id of the last
+// row of a page. The next page holds the rows after it in KeysetOrder, so
+// rows inserted while a client pages through a list never shift the pages.
+// Clients see it as an opaque string.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// String encodes the cursor for clients
+func (c Cursor) String() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes a cursor produced by Cursor.String
+func ParseCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", ErrInvalidInput)
+	}
+	at, id, found := strings.Cut(string(raw), "|")
+	if !found {
+		return nil, fmt.Errorf("malformed cursor: %w", ErrInvalidInput)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor time: %w", ErrInvalidInput)
+	}
+	cursorID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor id: %w", ErrInvalidInput)
+	}
+	return &Cursor{CreatedAt: createdAt, ID: cursorID}, nil
+}
+
+// Cursor returns the keyset position of the lead
+func (l *Lead) Cursor() Cursor { return Cursor{CreatedAt: l.CreatedAt, ID: l.ID} }
+
+// Cursor returns the keyset position of the client
+func (c *Client) Cursor() Cursor { return Cursor{CreatedAt: c.CreatedAt, ID: c.ID} }
+
+// Cursor returns the keyset position of the sale
+func (s *Sale) Cursor() Cursor { return Cursor{CreatedAt: s.CreatedAt, ID: s.ID} }
+
+// Cursor returns the keyset position of the task
+func (t *Task) Cursor() Cursor { return Cursor{CreatedAt: t.CreatedAt, ID: t.ID} }
+
+// Cursor returns the keyset position of the commission
+func (c *Commission) Cursor() Cursor { return Cursor{CreatedAt: c.CreatedAt, ID: c.ID} }
+
+// Cursor returns the keyset position of the society
+func (s *Society) Cursor() Cursor { return Cursor{CreatedAt: s.CreatedAt, ID: s.ID} }
+
+// Cursor returns the keyset position of the project
+func (p *Project) Cursor() Cursor { return Cursor{CreatedAt: p.CreatedAt, ID: p.ID} }
+
+// Cursor returns the keyset position of the unit
+func (i *Inventory) Cursor() Cursor { return Cursor{CreatedAt: i.CreatedAt, ID: i.ID} }
+
+// MarshalText encodes the cursor as its opaque string
+func (c Cursor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes an opaque cursor string
+func (c *Cursor) UnmarshalText(text []byte) error {
+	parsed, err := ParseCursor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
 }
 
 // UserFilters for filtering user queries
@@ -39,6 +132,7 @@ type LeadFilters struct {
 	Search       *string     `json:"search"`
 	CreatedAfter *time.Time  `json:"created_after"`
 	CreatedBefore *time.Time `json:"created_before"`
+	IncludeDeleted bool      `json:"include_deleted"`
 }
 
 // ClientFilters for filtering client queries
@@ -53,6 +147,14 @@ type ClientFilters struct {
 	Search       *string     `json:"search"`
 	CreatedAfter *time.Time  `json:"created_after"`
 	CreatedBefore *time.Time `json:"created_before"`
+	IncludeDeleted bool      `json:"include_deleted"`
+}
+
+// OrganizationFilters for filtering organization queries
+type OrganizationFilters struct {
+	BaseFilters
+	IsActive *bool   `json:"is_active"`
+	Search   *string `json:"search"`
 }
 
 // CompanyFilters for filtering company queries
@@ -88,6 +190,7 @@ type ProjectFilters struct {
 type InventoryFilters struct {
 	BaseFilters
 	ProjectID        *uuid.UUID  `json:"project_id"`
+	ProjectIDs       []uuid.UUID `json:"project_ids"` // units of any of these projects
 	UnitType         *string     `json:"unit_type"`
 	Status           *UnitStatus `json:"status"`
 	FloorNumber      *int        `json:"floor_number"`
@@ -97,7 +200,9 @@ type InventoryFilters struct {
 	AreaMin          *float64    `json:"area_min"`
 	AreaMax          *float64    `json:"area_max"`
 	ParkingSlots     *int        `json:"parking_slots"`
+	MinParkingSlots  *int        `json:"min_parking_slots"`
 	ReservedBy       *uuid.UUID  `json:"reserved_by"`
+	ReservedUntilTo  *time.Time  `json:"reserved_until_to"`
 	Search           *string     `json:"search"`
 }
 
@@ -114,6 +219,7 @@ type SaleFilters struct {
 	SaleDateTo    *time.Time  `json:"sale_date_to"`
 	ProjectID     *uuid.UUID  `json:"project_id"`
 	Search        *string     `json:"search"`
+	IncludeDeleted bool       `json:"include_deleted"`
 }
 
 // TaskFilters for filtering task queries
@@ -129,6 +235,7 @@ type TaskFilters struct {
 	DueDateTo      *time.Time    `json:"due_date_to"`
 	Tags           []string      `json:"tags"`
 	Search         *string       `json:"search"`
+	IncludeDeleted bool          `json:"include_deleted"`
 }
 
 // FollowUpFilters for filtering follow-up queries
@@ -205,6 +312,8 @@ type CommissionFilters struct {
 	Status         *PaymentStatus `json:"status"`
 	AmountMin      *float64       `json:"amount_min"`
 	AmountMax      *float64       `json:"amount_max"`
+	CreatedFrom    *time.Time     `json:"created_from"`
+	CreatedTo      *time.Time     `json:"created_to"`
 }
 
 // NFTFilters for filtering NFT queries
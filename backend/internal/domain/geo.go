@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+)
+
+// GeoPoint is a position given in WGS 84 degrees
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// GeoBoundingBox is the area between two latitudes and two longitudes. A
+// box whose min_longitude is greater than its max_longitude crosses the
+// antimeridian.
+type GeoBoundingBox struct {
+	MinLatitude  float64 `json:"min_latitude"`
+	MinLongitude float64 `json:"min_longitude"`
+	MaxLatitude  float64 `json:"max_latitude"`
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+// NearbyFacility is a place of interest near a project, such as a school
+// or a metro station. DistanceKm is worked out from the coordinates of
+// the facility and of the project's society, and is nil when either is
+// unknown.
+type NearbyFacility struct {
+	Name       string   `json:"name"`
+	Category   string   `json:"category"`
+	Latitude   *float64 `json:"latitude"`
+	Longitude  *float64 `json:"longitude"`
+	DistanceKm *float64 `json:"distance_km"`
+}
+
+// Geocoder finds the coordinates of an address. It returns ErrNotFound
+// for addresses it does not know.
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (*GeoPoint, error)
+}
+
+// Coordinates returns the position of a society, if it is known
+func (s *Society) Coordinates() (GeoPoint, bool) {
+	if s.Latitude == nil || s.Longitude == nil {
+		return GeoPoint{}, false
+	}
+	return GeoPoint{Latitude: *s.Latitude, Longitude: *s.Longitude}, true
+}
+
+// Coordinates returns the position of an address, if it was given
+func (a *Address) Coordinates() (GeoPoint, bool) {
+	if a == nil || a.Latitude == nil || a.Longitude == nil {
+		return GeoPoint{}, false
+	}
+	return GeoPoint{Latitude: *a.Latitude, Longitude: *a.Longitude}, true
+}
+
+// Coordinates returns the position of a facility, if it is known
+func (f *NearbyFacility) Coordinates() (GeoPoint, bool) {
+	if f.Latitude == nil || f.Longitude == nil {
+		return GeoPoint{}, false
+	}
+	return GeoPoint{Latitude: *f.Latitude, Longitude: *f.Longitude}, true
+}
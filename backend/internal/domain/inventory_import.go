@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// MaxImportRows caps the units a single inventory import may add
+const MaxImportRows = 5000
+
+// InventoryImportRequest is a spreadsheet of units to add to a project.
+// The first row holds the column names.
+type InventoryImportRequest struct {
+	ProjectID uuid.UUID
+	Rows      [][]string
+	// DryRun validates and prices the units without saving them
+	DryRun    bool
+	CreatedBy uuid.UUID
+}
+
+// ImportRowError is a problem with one row of an import. Row is the
+// spreadsheet row number, counting the header as row 1.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// InventoryImportResult reports on an import. Imports are all or
+// nothing: when any row has errors no unit is created.
+type InventoryImportResult struct {
+	DryRun  bool             `json:"dry_run"`
+	Rows    int              `json:"rows"`
+	Created int              `json:"created"`
+	Units   []*Inventory     `json:"units"`
+	Errors  []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MFAEnrollment is the authenticator app (TOTP, RFC 6238) of a user. It is
+// pending until the user confirms it with a first code.
+type MFAEnrollment struct {
+	UserID uuid.UUID `json:"user_id" db:"user_id"`
+	// Secret is the base32 key shared with the authenticator app
+	Secret      string     `json:"-" db:"secret"`
+	ConfirmedAt *time.Time `json:"confirmed_at" db:"confirmed_at"`
+	// LastStep is the time step of the last code accepted; codes of that
+	// step and earlier are refused
+	LastStep  int64     `json:"-" db:"last_step"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// MFARecoveryCode is a single-use code that stands in for an authenticator
+// code when the user has lost their device. Only its hash is stored.
+type MFARecoveryCode struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	CodeHash  string     `json:"-" db:"code_hash"`
+	UsedAt    *time.Time `json:"used_at" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// MFASetup is what a user needs to add an authenticator to their app. The
+// provisioning URI is meant to be shown as a QR code.
+type MFASetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// MFAPolicy decides which users need MFA. When Required, users of MinRole
+// and above are refused by every role-checked endpoint until they have it,
+// whatever the least role the endpoint is open to.
+type MFAPolicy struct {
+	Required bool
+	MinRole  UserRole
+}
+
+// Covers reports whether users with a role need MFA
+func (p MFAPolicy) Covers(role UserRole) bool {
+	return p.Required && role.AtLeast(p.MinRole)
+}
@@ -9,6 +9,7 @@ import (
 // User represents a user in the system
 type User struct {
 	ID           uuid.UUID  `json:"id" db:"id"`
+	OrganizationID *uuid.UUID `json:"organization_id" db:"organization_id"`
 	Email        string     `json:"email" db:"email"`
 	Username     string     `json:"username" db:"username"`
 	FullName     string     `json:"full_name" db:"full_name"`
@@ -19,6 +20,16 @@ type User struct {
 	PasswordHash string     `json:"-" db:"password_hash"` // Hidden from JSON
 	IsActive     bool       `json:"is_active" db:"is_active"`
 	LastLoginAt  *time.Time `json:"last_login_at" db:"last_login_at"`
+	// EmailVerifiedAt is when the user proved they own their email address
+	EmailVerifiedAt *time.Time `json:"email_verified_at" db:"email_verified_at"`
+	// MFAEnabled is set once the user has confirmed an authenticator app;
+	// from then on logging in takes a code from it
+	MFAEnabled   bool       `json:"mfa_enabled" db:"mfa_enabled"`
+	// FailedLoginAttempts counts failed logins in a row, the latest at
+	// LastFailedLoginAt; LockedUntil is set once there are too many
+	FailedLoginAttempts int        `json:"-" db:"failed_login_attempts"`
+	LastFailedLoginAt   *time.Time `json:"-" db:"last_failed_login_at"`
+	LockedUntil         *time.Time `json:"locked_until,omitempty" db:"locked_until"`
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
 }
@@ -36,6 +47,24 @@ const (
 	RoleSuperAdmin UserRole = "super_admin"
 )
 
+// roleLevels ranks roles from least to most privileged
+var roleLevels = map[UserRole]int{
+	RoleUser:       1,
+	RoleCreator:    2,
+	RoleClient:     2,
+	RoleEmployee:   3,
+	RoleManager:    4,
+	RoleAdmin:      5,
+	RoleSuperAdmin: 6,
+}
+
+// AtLeast reports whether r is as privileged as min. Unknown roles never are.
+func (r UserRole) AtLeast(min UserRole) bool {
+	level, ok := roleLevels[r]
+	minLevel, minOK := roleLevels[min]
+	return ok && minOK && level >= minLevel
+}
+
 // Challenge represents a social challenge
 type Challenge struct {
 	ID                  uuid.UUID       `json:"id" db:"id"`
@@ -205,4 +234,5 @@ const (
 	NotificationTypeSaleCreated         NotificationType = "sale_created"
 	NotificationTypeSaleStatusChanged   NotificationType = "sale_status_changed"
 	NotificationTypeProjectStatusChanged NotificationType = "project_status_changed"
+	NotificationTypeReservationExpired  NotificationType = "reservation_expired"
 )
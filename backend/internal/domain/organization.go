@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization is a tenant of the deployment, typically one brokerage.
+// Users and CRM records belong to exactly one organization.
+type Organization struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Slug      string    `json:"slug" db:"slug"`
+	IsActive  bool      `json:"is_active" db:"is_active"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateOrganizationRequest creates a tenant. The slug is derived from the
+// name when it is left empty.
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// UpdateOrganizationRequest renames a tenant or changes its slug
+type UpdateOrganizationRequest struct {
+	Name *string `json:"name"`
+	Slug *string `json:"slug"`
+}
+
+// AddOrganizationMemberRequest moves an existing user into an organization
+type AddOrganizationMemberRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// organizationKey carries the tenant of a request in its context
+type organizationKey struct{}
+
+// acrossOrganizations is the organizationKey value of a context that was
+// deliberately lifted out of tenancy
+type acrossOrganizations struct{}
+
+// ErrNoOrganization is returned for a write made from a context that is
+// neither scoped to an organization nor lifted out of tenancy
+var ErrNoOrganization = errors.New("no organization")
+
+// WithOrganization scopes ctx to an organization. Repositories only return
+// and modify records of that organization and stamp it on new records.
+func WithOrganization(ctx context.Context, organizationID uuid.UUID) context.Context {
+	return context.WithValue(ctx, organizationKey{}, organizationID)
+}
+
+// AcrossOrganizations lifts tenancy from ctx so it sees every organization.
+// It is for work done for the deployment rather than one tenant: background
+// jobs, and resolving who signs in before their organization is known.
+func AcrossOrganizations(ctx context.Context) context.Context {
+	return context.WithValue(ctx, organizationKey{}, acrossOrganizations{})
+}
+
+// OrganizationFromContext returns the organization ctx is scoped to, and
+// false only for a context lifted out of tenancy with AcrossOrganizations.
+// Any other context is scoped to uuid.Nil, which owns no records, so a
+// request that lost its tenant sees nothing rather than everything.
+func OrganizationFromContext(ctx context.Context) (uuid.UUID, bool) {
+	switch v := ctx.Value(organizationKey{}).(type) {
+	case uuid.UUID:
+		return v, true
+	case acrossOrganizations:
+		return uuid.Nil, false
+	default:
+		return uuid.Nil, true
+	}
+}
+
+// InOrganization reports whether a record owned by organizationID is visible
+// from ctx
+func InOrganization(ctx context.Context, organizationID *uuid.UUID) bool {
+	id, ok := OrganizationFromContext(ctx)
+	return !ok || organizationID != nil && *organizationID == id
+}
+
+// AssignOrganization stamps the organization ctx is scoped to onto a record
+// being written, so a tenant can never write into another tenant. A context
+// lifted out of tenancy keeps the record's own organization, and one without
+// an organization may not write at all.
+func AssignOrganization(ctx context.Context, organizationID **uuid.UUID) error {
+	id, ok := OrganizationFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if id == uuid.Nil {
+		return ErrNoOrganization
+	}
+	*organizationID = &id
+	return nil
+}
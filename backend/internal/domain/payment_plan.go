@@ -0,0 +1,265 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentPlanType selects how a payment schedule is generated
+type PaymentPlanType string
+
+const (
+	// PaymentPlanConstructionLinked ties instalments to construction
+	// milestones, each a percentage of the amount due
+	PaymentPlanConstructionLinked PaymentPlanType = "construction_linked"
+	// PaymentPlanTimeBased splits the amount due into equal instalments at
+	// a fixed interval
+	PaymentPlanTimeBased PaymentPlanType = "time_based"
+	// PaymentPlanDownPaymentEMI takes a down payment followed by equal
+	// monthly instalments
+	PaymentPlanDownPaymentEMI PaymentPlanType = "down_payment_emi"
+	// PaymentPlanCustom marks schedules given instalment by instalment
+	PaymentPlanCustom PaymentPlanType = "custom"
+)
+
+// maxInstallments bounds generated schedules
+const maxInstallments = 360
+
+// PaymentPlan describes a schedule to generate. Only the fields of its
+// type are used.
+type PaymentPlan struct {
+	Type PaymentPlanType `json:"type"`
+	// StartDate is the first due date; it defaults to today
+	StartDate time.Time `json:"start_date"`
+
+	// Time based
+	Installments   int `json:"installments,omitempty"`
+	IntervalMonths int `json:"interval_months,omitempty"`
+
+	// Construction linked
+	Milestones []PaymentMilestone `json:"milestones,omitempty"`
+
+	// Down payment plus EMI
+	DownPaymentPercent float64 `json:"down_payment_percent,omitempty"`
+	EMIMonths          int     `json:"emi_months,omitempty"`
+}
+
+// PaymentMilestone is a construction stage that triggers an instalment
+type PaymentMilestone struct {
+	Name    string    `json:"name"`
+	Percent float64   `json:"percent"`
+	DueDate time.Time `json:"due_date"`
+}
+
+// Generate splits amount into instalments. Amounts are rounded to the
+// cent and the last instalment absorbs the rounding difference.
+func (p *PaymentPlan) Generate(amount float64) ([]PaymentInstallment, error) {
+	total := toCents(amount)
+	if total <= 0 {
+		return nil, fmt.Errorf("nothing left to schedule: %w", ErrInvalidInput)
+	}
+	start := p.StartDate
+	if start.IsZero() {
+		start = time.Now()
+	}
+	start = dateOf(start)
+
+	var shares []float64
+	var dues []time.Time
+	var labels []string
+
+	switch p.Type {
+	case PaymentPlanTimeBased:
+		if p.Installments < 1 || p.Installments > maxInstallments {
+			return nil, fmt.Errorf("installments must be between 1 and %d: %w", maxInstallments, ErrInvalidInput)
+		}
+		interval := p.IntervalMonths
+		if interval == 0 {
+			interval = 1
+		}
+		if interval < 0 {
+			return nil, fmt.Errorf("interval must be positive: %w", ErrInvalidInput)
+		}
+		for i := 0; i < p.Installments; i++ {
+			shares = append(shares, 1/float64(p.Installments))
+			dues = append(dues, addMonths(start, i*interval))
+			labels = append(labels, fmt.Sprintf("Installment %d of %d", i+1, p.Installments))
+		}
+
+	case PaymentPlanConstructionLinked:
+		if len(p.Milestones) == 0 || len(p.Milestones) > maxInstallments {
+			return nil, fmt.Errorf("between 1 and %d milestones are required: %w", maxInstallments, ErrInvalidInput)
+		}
+		sum := 0.0
+		for _, m := range p.Milestones {
+			if strings.TrimSpace(m.Name) == "" || m.Percent <= 0 || m.DueDate.IsZero() {
+				return nil, fmt.Errorf("milestones need a name, a positive percent and a due date: %w", ErrInvalidInput)
+			}
+			sum += m.Percent
+			shares = append(shares, m.Percent/100)
+			dues = append(dues, dateOf(m.DueDate))
+			labels = append(labels, strings.TrimSpace(m.Name))
+		}
+		if math.Abs(sum-100) > 0.001 {
+			return nil, fmt.Errorf("milestone percentages add up to %g, not 100: %w", sum, ErrInvalidInput)
+		}
+
+	case PaymentPlanDownPaymentEMI:
+		if p.DownPaymentPercent <= 0 || p.DownPaymentPercent >= 100 {
+			return nil, fmt.Errorf("down payment must be between 0 and 100 percent: %w", ErrInvalidInput)
+		}
+		if p.EMIMonths < 1 || p.EMIMonths >= maxInstallments {
+			return nil, fmt.Errorf("EMI months must be between 1 and %d: %w", maxInstallments-1, ErrInvalidInput)
+		}
+		shares = append(shares, p.DownPaymentPercent/100)
+		dues = append(dues, start)
+		labels = append(labels, "Down payment")
+		for i := 1; i <= p.EMIMonths; i++ {
+			shares = append(shares, (1-p.DownPaymentPercent/100)/float64(p.EMIMonths))
+			dues = append(dues, addMonths(start, i))
+			labels = append(labels, fmt.Sprintf("EMI %d of %d", i, p.EMIMonths))
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown payment plan %q: %w", p.Type, ErrInvalidInput)
+	}
+
+	installments := make([]PaymentInstallment, len(shares))
+	allocated := int64(0)
+	for i := range shares {
+		cents := int64(math.Round(float64(total) * shares[i]))
+		if i == len(shares)-1 {
+			cents = total - allocated
+		}
+		allocated += cents
+
+		description := labels[i]
+		installments[i] = PaymentInstallment{
+			InstallmentNumber: i + 1,
+			DueDate:           dues[i],
+			Amount:            float64(cents) / 100,
+			Description:       &description,
+		}
+	}
+	return installments, nil
+}
+
+// ValidateInstallments checks that a schedule is in due date order, has
+// only positive amounts and adds up to total exactly
+func ValidateInstallments(installments []PaymentInstallment, total float64) error {
+	if len(installments) == 0 {
+		return fmt.Errorf("at least one installment is required: %w", ErrInvalidInput)
+	}
+
+	sum := int64(0)
+	for i, installment := range installments {
+		if installment.Amount <= 0 {
+			return fmt.Errorf("installment %d must have a positive amount: %w", i+1, ErrInvalidInput)
+		}
+		if installment.DueDate.IsZero() {
+			return fmt.Errorf("installment %d needs a due date: %w", i+1, ErrInvalidInput)
+		}
+		if i > 0 && installment.DueDate.Before(installments[i-1].DueDate) {
+			return fmt.Errorf("installment %d is due before the one ahead of it: %w", i+1, ErrInvalidInput)
+		}
+		sum += toCents(installment.Amount)
+	}
+
+	if sum != toCents(total) {
+		return fmt.Errorf("installments add up to %.2f instead of %.2f: %w",
+			float64(sum)/100, float64(toCents(total))/100, ErrInvalidInput)
+	}
+	return nil
+}
+
+// Schedule returns the requested installments for amount: generated from
+// the plan if there is one, otherwise as given. They are checked with
+// ValidateInstallments and numbered from first on.
+func (r *CreatePaymentScheduleRequest) Schedule(amount float64, first int) ([]PaymentInstallment, PaymentPlanType, error) {
+	plan := PaymentPlanCustom
+	installments := r.Installments
+	if r.Plan != nil {
+		if len(r.Installments) > 0 {
+			return nil, "", fmt.Errorf("give either installments or a plan, not both: %w", ErrInvalidInput)
+		}
+		generated, err := r.Plan.Generate(amount)
+		if err != nil {
+			return nil, "", err
+		}
+		plan, installments = r.Plan.Type, generated
+	}
+
+	if err := ValidateInstallments(installments, amount); err != nil {
+		return nil, "", err
+	}
+
+	numbered := make([]PaymentInstallment, len(installments))
+	for i, installment := range installments {
+		installment.InstallmentNumber = first + i
+		installment.DueDate = dateOf(installment.DueDate)
+		numbered[i] = installment
+	}
+	return numbered, plan, nil
+}
+
+// AmountDue is what the payment schedule of a sale has to cover: the final
+// amount less the booking amount
+func (s *Sale) AmountDue() float64 {
+	due := toCents(s.FinalAmount)
+	if s.BookingAmount != nil {
+		due -= toCents(*s.BookingAmount)
+	}
+	return float64(due) / 100
+}
+
+// IsSettled reports whether money has been received against an
+// installment; settled installments are never rescheduled
+func (p *PaymentSchedule) IsSettled() bool {
+	return p.Status == PaymentStatusPaid || p.PaidAmount > 0
+}
+
+// PaymentScheduleRevision is the audit record of one change to a sale's
+// payment schedule: the installments it replaced and the ones it added
+type PaymentScheduleRevision struct {
+	ID             uuid.UUID            `json:"id" db:"id"`
+	OrganizationID *uuid.UUID           `json:"organization_id" db:"organization_id"`
+	SaleID         uuid.UUID            `json:"sale_id" db:"sale_id"`
+	Revision       int                  `json:"revision" db:"revision"`
+	Plan           PaymentPlanType      `json:"plan" db:"plan"`
+	Reason         *string              `json:"reason" db:"reason"`
+	Previous       []PaymentInstallment `json:"previous" db:"previous"`
+	Installments   []PaymentInstallment `json:"installments" db:"installments"`
+	ChangedBy      uuid.UUID            `json:"changed_by" db:"changed_by"`
+	CreatedAt      time.Time            `json:"created_at" db:"created_at"`
+}
+
+// ReschedulePaymentsRequest replaces the unsettled installments of a sale
+type ReschedulePaymentsRequest struct {
+	CreatePaymentScheduleRequest
+	Reason string `json:"reason"`
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// dateOf drops the time of day, as due dates are stored as dates
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// addMonths moves a date by whole months, clamping to the end of shorter
+// months so that 31 January is followed by 28 or 29 February
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
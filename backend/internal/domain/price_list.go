@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PriceList prices the units of a project. Rates are per square foot of a
+// unit's saleable area (see SaleableArea). Price lists are never edited:
+// each save adds a new version, and the version in effect at a time is
+// the one most recently effective by then.
+type PriceList struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	OrganizationID *uuid.UUID `json:"organization_id" db:"organization_id"`
+	ProjectID      uuid.UUID  `json:"project_id" db:"project_id"`
+	Version        int        `json:"version" db:"version"`
+	EffectiveFrom  time.Time  `json:"effective_from" db:"effective_from"`
+	// BaseRate is the price per square foot before any charges
+	BaseRate float64 `json:"base_rate" db:"base_rate"`
+	// FloorRise is added to the rate once for every floor from
+	// FloorRiseFrom upwards
+	FloorRise     float64 `json:"floor_rise" db:"floor_rise"`
+	FloorRiseFrom int     `json:"floor_rise_from" db:"floor_rise_from"`
+	// FacingPremiums are added to the rate of units facing a direction;
+	// negative premiums are discounts
+	FacingPremiums map[string]float64 `json:"facing_premiums" db:"facing_premiums"`
+	// PLCs are preferential location charges added to the rate of units
+	// with a feature, such as "Corner" or "Park View"
+	PLCs      map[string]float64 `json:"plcs" db:"plcs"`
+	Notes     *string            `json:"notes" db:"notes"`
+	CreatedBy uuid.UUID          `json:"created_by" db:"created_by"`
+	CreatedAt time.Time          `json:"created_at" db:"created_at"`
+}
+
+// CreatePriceListRequest is the body of a request to add a price list
+// version to a project. EffectiveFrom defaults to now.
+type CreatePriceListRequest struct {
+	EffectiveFrom  *time.Time         `json:"effective_from"`
+	BaseRate       float64            `json:"base_rate"`
+	FloorRise      float64            `json:"floor_rise"`
+	FloorRiseFrom  int                `json:"floor_rise_from"`
+	FacingPremiums map[string]float64 `json:"facing_premiums"`
+	PLCs           map[string]float64 `json:"plcs"`
+	Notes          *string            `json:"notes"`
+	CreatedBy      uuid.UUID          `json:"-"`
+}
+
+// Validate checks that a price list can price units
+func (p *PriceList) Validate() error {
+	if p.BaseRate <= 0 {
+		return fmt.Errorf("base rate must be positive: %w", ErrInvalidInput)
+	}
+	if p.FloorRise < 0 {
+		return fmt.Errorf("floor rise cannot be negative: %w", ErrInvalidInput)
+	}
+	if p.FloorRiseFrom < 0 {
+		return fmt.Errorf("floor rise cannot start below the ground floor: %w", ErrInvalidInput)
+	}
+	for facing := range p.FacingPremiums {
+		if strings.TrimSpace(facing) == "" {
+			return fmt.Errorf("facing premiums need a facing: %w", ErrInvalidInput)
+		}
+	}
+	for feature := range p.PLCs {
+		if strings.TrimSpace(feature) == "" {
+			return fmt.Errorf("location charges need a feature: %w", ErrInvalidInput)
+		}
+	}
+	return nil
+}
+
+// UnitQuote is the price of a unit under a price list. The rates are per
+// square foot and add up to PricePerSqft.
+type UnitQuote struct {
+	Area          float64 `json:"area"`
+	BaseRate      float64 `json:"base_rate"`
+	FloorRise     float64 `json:"floor_rise"`
+	FacingPremium float64 `json:"facing_premium"`
+	PLC           float64 `json:"plc"`
+	PricePerSqft  float64 `json:"price_per_sqft"`
+	BasePrice     float64 `json:"base_price"`
+	FinalPrice    float64 `json:"final_price"`
+}
+
+// Quote prices a unit. Prices are rounded to whole currency units.
+func (p *PriceList) Quote(unit *Inventory) (*UnitQuote, error) {
+	area := SaleableArea(unit)
+	if area == nil {
+		return nil, &PricingError{UnitNumber: unit.UnitNumber, Reason: "it has no area"}
+	}
+
+	quote := &UnitQuote{Area: *area, BaseRate: p.BaseRate}
+	if unit.FloorNumber != nil && *unit.FloorNumber >= p.FloorRiseFrom {
+		quote.FloorRise = float64(*unit.FloorNumber-p.FloorRiseFrom+1) * p.FloorRise
+	}
+	if unit.Facing != nil {
+		for facing, premium := range p.FacingPremiums {
+			if strings.EqualFold(strings.TrimSpace(facing), strings.TrimSpace(*unit.Facing)) {
+				quote.FacingPremium += premium
+			}
+		}
+	}
+	for feature, charge := range p.PLCs {
+		for _, have := range unit.Features {
+			if strings.EqualFold(strings.TrimSpace(feature), strings.TrimSpace(have)) {
+				quote.PLC += charge
+				break
+			}
+		}
+	}
+
+	quote.PricePerSqft = quote.BaseRate + quote.FloorRise + quote.FacingPremium + quote.PLC
+	quote.BasePrice = math.Round(quote.BaseRate * quote.Area)
+	quote.FinalPrice = math.Round(quote.PricePerSqft * quote.Area)
+
+	if err := (BusinessRules{}).ValidatePropertyPrice(quote.FinalPrice, quote.PricePerSqft, quote.Area); err != nil {
+		return nil, &PricingError{UnitNumber: unit.UnitNumber, Reason: err.Error()}
+	}
+	return quote, nil
+}
+
+// PricingError explains why a price list cannot price a unit. It wraps
+// ErrInvalidInput.
+type PricingError struct {
+	UnitNumber string `json:"unit_number"`
+	Reason     string `json:"reason"`
+}
+
+func (e *PricingError) Error() string {
+	return fmt.Sprintf("unit %s cannot be priced: %s", e.UnitNumber, e.Reason)
+}
+
+func (e *PricingError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// Apply sets the prices of a unit from a quote
+func (q *UnitQuote) Apply(unit *Inventory) {
+	basePrice, finalPrice, pricePerSqft := q.BasePrice, q.FinalPrice, q.PricePerSqft
+	unit.BasePrice = &basePrice
+	unit.FinalPrice = &finalPrice
+	unit.PricePerSqft = &pricePerSqft
+}
+
+// SaleableArea returns the area a unit is priced by: its super built-up
+// area, else its built-up area, else its carpet area
+func SaleableArea(unit *Inventory) *float64 {
+	for _, area := range []*float64{unit.SuperBuiltUpArea, unit.BuiltUpArea, unit.CarpetArea} {
+		if area != nil && *area > 0 {
+			return area
+		}
+	}
+	return nil
+}
+
+// PriceChange is what a price revision does to one unit
+type PriceChange struct {
+	InventoryID uuid.UUID  `json:"inventory_id"`
+	UnitNumber  string     `json:"unit_number"`
+	OldPrice    *float64   `json:"old_price"`
+	NewPrice    float64    `json:"new_price"`
+	Difference  float64    `json:"difference"`
+	Quote       *UnitQuote `json:"quote"`
+}
+
+// PriceRevision is the outcome of applying a price list to the units of
+// its project. Only available and blocked units are repriced; reserved
+// and sold units keep the price they were offered at.
+type PriceRevision struct {
+	PriceList *PriceList    `json:"price_list"`
+	DryRun    bool          `json:"dry_run"`
+	Changes   []PriceChange `json:"changes"`
+	Unchanged int           `json:"unchanged"`
+	// Skipped counts the reserved and sold units
+	Skipped int `json:"skipped"`
+	// Unpriced lists the units the price list cannot price
+	Unpriced []*PricingError `json:"unpriced"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PropertyInterestKind says how a client showed interest in a unit
+type PropertyInterestKind string
+
+const (
+	PropertyInterestViewed      PropertyInterestKind = "viewed"
+	PropertyInterestShortlisted PropertyInterestKind = "shortlisted"
+)
+
+// Valid reports whether k is a known kind of interest
+func (k PropertyInterestKind) Valid() bool {
+	return k == PropertyInterestViewed || k == PropertyInterestShortlisted
+}
+
+// PropertyInterest records that a client viewed or shortlisted a unit.
+// A client has at most one interest of each kind in a unit.
+type PropertyInterest struct {
+	ID             uuid.UUID            `json:"id" db:"id"`
+	OrganizationID *uuid.UUID           `json:"organization_id" db:"organization_id"`
+	ClientID       uuid.UUID            `json:"client_id" db:"client_id"`
+	InventoryID    uuid.UUID            `json:"inventory_id" db:"inventory_id"`
+	Kind           PropertyInterestKind `json:"kind" db:"kind"`
+	RecordedBy     uuid.UUID            `json:"recorded_by" db:"recorded_by"`
+	CreatedAt      time.Time            `json:"created_at" db:"created_at"`
+}
+
+// RecordInterestRequest is the body of a request to record a client's
+// interest in a unit
+type RecordInterestRequest struct {
+	InventoryID uuid.UUID            `json:"inventory_id"`
+	Kind        PropertyInterestKind `json:"kind"`
+	RecordedBy  uuid.UUID            `json:"-"`
+}
+
+// PropertyRecommendation is an available unit suggested to a client. The
+// score adds up the points of every reason the unit was picked for.
+type PropertyRecommendation struct {
+	Inventory *Inventory `json:"inventory"`
+	Score     int        `json:"score"`
+	Reasons   []string   `json:"reasons"`
+}
@@ -9,6 +9,35 @@ import (
 
 // Repository interfaces following the Repository pattern
 // These interfaces define the contract for data access operations
+//
+// Leads, clients, sales and tasks are soft-deleted: Delete stamps
+// deleted_at and deleted_by, reads skip those rows unless the filters set
+// IncludeDeleted, Restore brings a row back and Purge removes rows deleted
+// before the cutoff for good.
+
+// UnitOfWork runs several repository calls as one atomic operation.
+// Repositories called with the ctx passed to fn take part in the unit;
+// if fn returns an error every write made through that ctx is undone.
+// Calling Do with a ctx that is already inside a unit joins it, and
+// functions passed to AfterCommit run once the outermost unit commits.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// OrganizationRepository defines the interface for organization data operations
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *Organization) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Organization, error)
+	GetBySlug(ctx context.Context, slug string) (*Organization, error)
+	Update(ctx context.Context, organization *Organization) error
+	List(ctx context.Context, filters OrganizationFilters) ([]*Organization, error)
+}
+
+// SearchRepository runs ranked full-text and fuzzy searches across leads,
+// clients, companies and inventory. Hits are ordered best match first.
+type SearchRepository interface {
+	Search(ctx context.Context, query SearchQuery) ([]*SearchHit, error)
+}
 
 // UserRepository defines the interface for user data operations
 type UserRepository interface {
@@ -20,6 +49,92 @@ type UserRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, filters UserFilters) ([]*User, error)
 	Count(ctx context.Context, filters UserFilters) (int, error)
+	// RecordFailedLogin counts a failed login at a time against a user and
+	// returns the failures in a row, starting again from one when the
+	// previous failure was before since. The count is read and raised in
+	// one step, so concurrent failures are all counted.
+	RecordFailedLogin(ctx context.Context, id uuid.UUID, at, since time.Time) (int, error)
+	// LockUntil locks a user until a time, unless already locked for longer
+	LockUntil(ctx context.Context, id uuid.UUID, until time.Time) error
+}
+
+// SessionRepository stores the signed-in sessions of users
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Session, error)
+	// ListActive returns the sessions of a user that are neither revoked
+	// nor expired at a time, most recently used first
+	ListActive(ctx context.Context, userID uuid.UUID, at time.Time) ([]*Session, error)
+	// Rotate replaces the refresh token of an active session, provided its
+	// current token is still oldHash. It returns ErrNotFound otherwise, so
+	// of two refreshes racing with the same token only one succeeds.
+	Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash string, expiresAt, usedAt time.Time) error
+	// Revoke ends a session; ending one already revoked changes nothing
+	Revoke(ctx context.Context, id uuid.UUID, reason RevokeReason, at time.Time) error
+	// RevokeByUser ends every active session of a user and returns how
+	// many were ended
+	RevokeByUser(ctx context.Context, userID uuid.UUID, reason RevokeReason, at time.Time) (int, error)
+}
+
+// AuthTokenRepository stores the single-use tokens mailed to users
+type AuthTokenRepository interface {
+	Create(ctx context.Context, token *AuthToken) error
+	// Consume redeems the unused, unexpired token of a purpose with the given
+	// hash. It returns ErrNotFound if there is none, so a token can only be
+	// redeemed once.
+	Consume(ctx context.Context, purpose TokenPurpose, tokenHash string, at time.Time) (*AuthToken, error)
+	// InvalidateByUser marks every outstanding token of a purpose issued to a
+	// user as used and returns how many there were
+	InvalidateByUser(ctx context.Context, userID uuid.UUID, purpose TokenPurpose, at time.Time) (int, error)
+}
+
+// MFARepository stores the authenticators and recovery codes of users
+type MFARepository interface {
+	// Save creates or replaces the authenticator of a user
+	Save(ctx context.Context, enrollment *MFAEnrollment) error
+	GetByUser(ctx context.Context, userID uuid.UUID) (*MFAEnrollment, error)
+	// UseStep records that the code of a time step was accepted. It returns
+	// ErrNotFound unless the step is later than the last one accepted, so a
+	// code can only be used once.
+	UseStep(ctx context.Context, userID uuid.UUID, step int64, at time.Time) error
+	// Delete removes the authenticator and recovery codes of a user
+	Delete(ctx context.Context, userID uuid.UUID) error
+	// ReplaceRecoveryCodes swaps a user's recovery codes for new ones
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string, at time.Time) error
+	// UseRecoveryCode redeems an unused recovery code of a user. It returns
+	// ErrNotFound if there is none with the hash.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, at time.Time) error
+	// CountRecoveryCodes returns how many unused recovery codes a user has
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+// AuthEventRepository stores the audit trail of logins
+type AuthEventRepository interface {
+	Create(ctx context.Context, event *AuthEvent) error
+	// FailuresFromAddress counts the failed logins from an IP address since
+	// a time, and returns when the latest of them was
+	FailuresFromAddress(ctx context.Context, ipAddress string, since time.Time) (int, *time.Time, error)
+	// ListByUser returns the latest events of a user, newest first
+	ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*AuthEvent, error)
+}
+
+// WalletRepository stores the wallets users have linked to their accounts.
+// Addresses are EIP-55 checksummed and belong to at most one wallet.
+type WalletRepository interface {
+	Create(ctx context.Context, wallet *Wallet) error
+	GetByAddress(ctx context.Context, address string) (*Wallet, error)
+	// ListByUser returns the wallets of a user, oldest first
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*Wallet, error)
+	Update(ctx context.Context, wallet *Wallet) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// SIWENonceRepository stores the nonces issued for Sign-In with Ethereum
+type SIWENonceRepository interface {
+	Create(ctx context.Context, nonce *SIWENonce) error
+	// Consume redeems an unused, unexpired nonce. It returns ErrNotFound if
+	// there is none, so a nonce can only be redeemed once.
+	Consume(ctx context.Context, nonce string, at time.Time) error
 }
 
 // LeadRepository defines the interface for lead data operations
@@ -27,7 +142,9 @@ type LeadRepository interface {
 	Create(ctx context.Context, lead *Lead) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Lead, error)
 	Update(ctx context.Context, lead *Lead) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
 	List(ctx context.Context, filters LeadFilters) ([]*Lead, error)
 	Count(ctx context.Context, filters LeadFilters) (int, error)
 	GetByAssignedUser(ctx context.Context, userID uuid.UUID) ([]*Lead, error)
@@ -40,7 +157,9 @@ type ClientRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*Client, error)
 	GetByEmail(ctx context.Context, email string) (*Client, error)
 	Update(ctx context.Context, client *Client) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
 	List(ctx context.Context, filters ClientFilters) ([]*Client, error)
 	Count(ctx context.Context, filters ClientFilters) (int, error)
 	GetByAssignedUser(ctx context.Context, userID uuid.UUID) ([]*Client, error)
@@ -77,6 +196,9 @@ type ProjectRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, filters ProjectFilters) ([]*Project, error)
 	Count(ctx context.Context, filters ProjectFilters) (int, error)
+	// RefreshUnitCounts recounts the available, sold and blocked units of a
+	// project from its inventory; reserved units count as blocked
+	RefreshUnitCounts(ctx context.Context, id uuid.UUID) error
 }
 
 // InventoryRepository defines the interface for inventory data operations
@@ -91,6 +213,31 @@ type InventoryRepository interface {
 	GetAvailable(ctx context.Context, projectID uuid.UUID) ([]*Inventory, error)
 	Reserve(ctx context.Context, id uuid.UUID, clientID uuid.UUID, until time.Time) error
 	Release(ctx context.Context, id uuid.UUID) error
+	// ReleaseIfExpired releases a unit only if its reservation ran out by
+	// at. It returns ErrNotFound when the unit is missing or was sold,
+	// released or re-reserved in the meantime.
+	ReleaseIfExpired(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// PriceListRepository stores the versions of project price lists.
+// Versions are unique per organization and project.
+type PriceListRepository interface {
+	Create(ctx context.Context, priceList *PriceList) error
+	GetVersion(ctx context.Context, projectID uuid.UUID, version int) (*PriceList, error)
+	// GetEffective returns the version in effect at a time: the one with
+	// the latest effective date not after it, the newest version first
+	GetEffective(ctx context.Context, projectID uuid.UUID, at time.Time) (*PriceList, error)
+	// ListVersions returns every version of a project's price list,
+	// newest first
+	ListVersions(ctx context.Context, projectID uuid.UUID) ([]*PriceList, error)
+}
+
+// PropertyInterestRepository stores the units clients viewed or
+// shortlisted. Lists are newest first.
+type PropertyInterestRepository interface {
+	Create(ctx context.Context, interest *PropertyInterest) error
+	ListByClient(ctx context.Context, clientID uuid.UUID) ([]*PropertyInterest, error)
+	ListByInventory(ctx context.Context, inventoryID uuid.UUID) ([]*PropertyInterest, error)
 }
 
 // SaleRepository defines the interface for sale data operations
@@ -100,20 +247,47 @@ type SaleRepository interface {
 	GetByClient(ctx context.Context, clientID uuid.UUID) ([]*Sale, error)
 	GetBySalesperson(ctx context.Context, salespersonID uuid.UUID) ([]*Sale, error)
 	Update(ctx context.Context, sale *Sale) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
 	List(ctx context.Context, filters SaleFilters) ([]*Sale, error)
 	Count(ctx context.Context, filters SaleFilters) (int, error)
-	UpdateStatus(ctx context.Context, id uuid.UUID, status SaleStatus) error
+	// UpdateStatus moves a live sale from one status to another, failing
+	// with ErrConflict if the sale is no longer in the from status
+	UpdateStatus(ctx context.Context, id uuid.UUID, from, to SaleStatus) error
 	GetSalesStats(ctx context.Context, filters SaleFilters) (*SalesStats, error)
 }
 
+// SaleStatusHistoryRepository stores the transitions of sales
+type SaleStatusHistoryRepository interface {
+	Create(ctx context.Context, entry *SaleStatusHistory) error
+	ListBySale(ctx context.Context, saleID uuid.UUID) ([]*SaleStatusHistory, error)
+}
+
+// AgreementTemplateRepository stores the versions of sale agreement
+// templates. A nil project ID addresses the organization default.
+type AgreementTemplateRepository interface {
+	Create(ctx context.Context, template *AgreementTemplate) error
+	GetLatest(ctx context.Context, projectID *uuid.UUID) (*AgreementTemplate, error)
+	ListVersions(ctx context.Context, projectID *uuid.UUID) ([]*AgreementTemplate, error)
+}
+
+// AgreementRepository stores generated sale agreements
+type AgreementRepository interface {
+	Create(ctx context.Context, agreement *Agreement) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Agreement, error)
+	GetLatest(ctx context.Context, saleID uuid.UUID, format AgreementFormat) (*Agreement, error)
+}
+
 // TaskRepository defines the interface for task data operations
 type TaskRepository interface {
 	Create(ctx context.Context, task *Task) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Task, error)
 	GetByAssignedUser(ctx context.Context, userID uuid.UUID) ([]*Task, error)
 	Update(ctx context.Context, task *Task) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
 	List(ctx context.Context, filters TaskFilters) ([]*Task, error)
 	Count(ctx context.Context, filters TaskFilters) (int, error)
 	GetOverdueTasks(ctx context.Context) ([]*Task, error)
@@ -173,6 +347,13 @@ type PaymentScheduleRepository interface {
 	GetOverduePayments(ctx context.Context) ([]*PaymentSchedule, error)
 }
 
+// PaymentScheduleRevisionRepository stores the audit trail of payment
+// schedule changes
+type PaymentScheduleRevisionRepository interface {
+	Create(ctx context.Context, revision *PaymentScheduleRevision) error
+	ListBySale(ctx context.Context, saleID uuid.UUID) ([]*PaymentScheduleRevision, error)
+}
+
 // CommissionRepository defines the interface for commission operations
 type CommissionRepository interface {
 	Create(ctx context.Context, commission *Commission) error
@@ -184,6 +365,15 @@ type CommissionRepository interface {
 	List(ctx context.Context, filters CommissionFilters) ([]*Commission, error)
 }
 
+// CommissionPlanRepository stores commission plans. Plans are never
+// changed; saving a plan adds a new one that supersedes the last.
+type CommissionPlanRepository interface {
+	Create(ctx context.Context, plan *CommissionPlan) error
+	GetByID(ctx context.Context, id uuid.UUID) (*CommissionPlan, error)
+	GetLatest(ctx context.Context) (*CommissionPlan, error)
+	List(ctx context.Context) ([]*CommissionPlan, error)
+}
+
 // RealEstateNFTRepository defines the interface for NFT operations
 type RealEstateNFTRepository interface {
 	Create(ctx context.Context, nft *RealEstateNFT) error
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SaleTransition is a permitted change of a sale's status
+type SaleTransition struct {
+	From SaleStatus `json:"from"`
+	To   SaleStatus `json:"to"`
+	// MinRole is the least privileged role allowed to make the change
+	MinRole UserRole `json:"min_role"`
+	// ReasonRequired is set for transitions that must be explained
+	ReasonRequired bool `json:"reason_required"`
+}
+
+// SaleTransitions is the sale lifecycle. A sale moves from draft through
+// pending and approved to completed, and can be cancelled until it is
+// completed; completed and cancelled sales are final. Sending a pending
+// sale back to draft or cancelling one needs a reason.
+var SaleTransitions = []SaleTransition{
+	{From: SaleStatusDraft, To: SaleStatusPending, MinRole: RoleEmployee},
+	{From: SaleStatusDraft, To: SaleStatusCancelled, MinRole: RoleEmployee, ReasonRequired: true},
+	{From: SaleStatusPending, To: SaleStatusApproved, MinRole: RoleManager},
+	{From: SaleStatusPending, To: SaleStatusDraft, MinRole: RoleManager, ReasonRequired: true},
+	{From: SaleStatusPending, To: SaleStatusCancelled, MinRole: RoleEmployee, ReasonRequired: true},
+	{From: SaleStatusApproved, To: SaleStatusCompleted, MinRole: RoleManager},
+	{From: SaleStatusApproved, To: SaleStatusCancelled, MinRole: RoleManager, ReasonRequired: true},
+}
+
+// FindSaleTransition returns the transition from one status to another,
+// failing with ErrInvalidTransition when the lifecycle does not allow it
+func FindSaleTransition(from, to SaleStatus) (SaleTransition, error) {
+	for _, t := range SaleTransitions {
+		if t.From == from && t.To == to {
+			return t, nil
+		}
+	}
+	return SaleTransition{}, fmt.Errorf("sale cannot move from %s to %s: %w", from, to, ErrInvalidTransition)
+}
+
+// Authorize checks that a user with the given role may make the transition
+// and has given a reason where one is required
+func (t SaleTransition) Authorize(role UserRole, reason *string) error {
+	if !role.AtLeast(t.MinRole) {
+		return fmt.Errorf("moving a sale from %s to %s requires the %s role: %w", t.From, t.To, t.MinRole, ErrForbidden)
+	}
+	if t.ReasonRequired && (reason == nil || strings.TrimSpace(*reason) == "") {
+		return fmt.Errorf("a reason is required to move a sale from %s to %s: %w", t.From, t.To, ErrInvalidInput)
+	}
+	return nil
+}
+
+// SaleStatusHistory records one transition of a sale
+type SaleStatusHistory struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	OrganizationID *uuid.UUID `json:"organization_id" db:"organization_id"`
+	SaleID         uuid.UUID  `json:"sale_id" db:"sale_id"`
+	FromStatus     SaleStatus `json:"from_status" db:"from_status"`
+	ToStatus       SaleStatus `json:"to_status" db:"to_status"`
+	ChangedBy      uuid.UUID  `json:"changed_by" db:"changed_by"`
+	Reason         *string    `json:"reason" db:"reason"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// SaleTransitionRequest asks for a sale to move to another status
+type SaleTransitionRequest struct {
+	Status    SaleStatus `json:"status" validate:"required"`
+	Reason    *string    `json:"reason"`
+	ChangedBy uuid.UUID  `json:"-"`
+}
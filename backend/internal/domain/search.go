@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// SearchEntityType is the kind of record a search hit refers to
+type SearchEntityType string
+
+const (
+	SearchEntityLead      SearchEntityType = "lead"
+	SearchEntityClient    SearchEntityType = "client"
+	SearchEntityCompany   SearchEntityType = "company"
+	SearchEntityInventory SearchEntityType = "inventory"
+)
+
+// SearchEntityTypes lists every searchable entity type
+var SearchEntityTypes = []SearchEntityType{
+	SearchEntityLead, SearchEntityClient, SearchEntityCompany, SearchEntityInventory,
+}
+
+// IsValid reports whether t is a searchable entity type
+func (t SearchEntityType) IsValid() bool {
+	for _, known := range SearchEntityTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchQuery is a search across the CRM. Text is matched as prefixes of
+// the words of each record, fuzzily against names and as digits against
+// phone numbers. An empty Types searches every entity type.
+type SearchQuery struct {
+	Text  string             `json:"q"`
+	Types []SearchEntityType `json:"types,omitempty"`
+	Limit int                `json:"limit"`
+}
+
+// SearchHit is one record matching a search. Highlight is a fragment of
+// the record with the matched words wrapped in <b> tags.
+type SearchHit struct {
+	Type      SearchEntityType `json:"type"`
+	ID        uuid.UUID        `json:"id"`
+	Title     string           `json:"title"`
+	Subtitle  *string          `json:"subtitle,omitempty"`
+	Score     float64          `json:"score"`
+	Highlight *string          `json:"highlight,omitempty"`
+}
+
+// SearchResults are the hits of a search, best match first
+type SearchResults struct {
+	Query string       `json:"query"`
+	Hits  []*SearchHit `json:"hits"`
+}
@@ -15,11 +15,37 @@ type AuthService interface {
 	Login(ctx context.Context, email, password string) (*AuthResponse, error)
 	Register(ctx context.Context, req *RegisterRequest) (*AuthResponse, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*AuthResponse, error)
-	Logout(ctx context.Context, userID uuid.UUID) error
+	Logout(ctx context.Context, userID uuid.UUID, refreshToken string) error
 	ValidateToken(ctx context.Context, token string) (*User, error)
 	ChangePassword(ctx context.Context, userID uuid.UUID, oldPassword, newPassword string) error
 	ResetPassword(ctx context.Context, email string) error
 	ConfirmPasswordReset(ctx context.Context, token, newPassword string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, email string) error
+
+	// Session operations
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]*Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// Multi-factor authentication
+	VerifyMFA(ctx context.Context, mfaToken, code string) (*AuthResponse, error)
+	EnrollMFA(ctx context.Context, userID uuid.UUID) (*MFASetup, error)
+	ConfirmMFA(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	DisableMFA(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	MFAPolicy() MFAPolicy
+
+	// Account lockout
+	UnlockAccount(ctx context.Context, userID, unlockedBy uuid.UUID) error
+	ListAuthEvents(ctx context.Context, userID uuid.UUID, limit int) ([]*AuthEvent, error)
+
+	// Sign-In with Ethereum
+	IssueSIWENonce(ctx context.Context) (*SIWEChallenge, error)
+	LoginWithWallet(ctx context.Context, message, signature string) (*AuthResponse, error)
+	LinkWallet(ctx context.Context, userID uuid.UUID, message, signature, walletType string) (*Wallet, error)
+	ListWallets(ctx context.Context, userID uuid.UUID) ([]*Wallet, error)
+	UnlinkWallet(ctx context.Context, userID, walletID uuid.UUID) error
 }
 
 // UserService handles user management operations
@@ -41,7 +67,8 @@ type LeadService interface {
 	Create(ctx context.Context, req *CreateLeadRequest) (*Lead, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Lead, error)
 	Update(ctx context.Context, id uuid.UUID, req *UpdateLeadRequest) (*Lead, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) (*Lead, error)
 	List(ctx context.Context, filters LeadFilters) ([]*Lead, error)
 	Count(ctx context.Context, filters LeadFilters) (int, error)
 	AssignToUser(ctx context.Context, leadID, userID uuid.UUID) error
@@ -59,7 +86,8 @@ type ClientService interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*Client, error)
 	GetByEmail(ctx context.Context, email string) (*Client, error)
 	Update(ctx context.Context, id uuid.UUID, req *UpdateClientRequest) (*Client, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) (*Client, error)
 	List(ctx context.Context, filters ClientFilters) ([]*Client, error)
 	Count(ctx context.Context, filters ClientFilters) (int, error)
 	VerifyClient(ctx context.Context, id uuid.UUID) error
@@ -89,12 +117,57 @@ type PropertyService interface {
 	UpdateInventory(ctx context.Context, id uuid.UUID, req *UpdateInventoryRequest) (*Inventory, error)
 	ListInventory(ctx context.Context, filters InventoryFilters) ([]*Inventory, error)
 	GetAvailableUnits(ctx context.Context, projectID uuid.UUID) ([]*Inventory, error)
-	ReserveUnit(ctx context.Context, unitID, clientID uuid.UUID, duration time.Duration) error
-	ReleaseUnit(ctx context.Context, unitID uuid.UUID) error
+	ImportInventory(ctx context.Context, req *InventoryImportRequest) (*InventoryImportResult, error)
+	ReservationService
+
+	// Stacking plan of a project and the live changes to its units
+	GetAvailabilityMatrix(ctx context.Context, projectID uuid.UUID, filters AvailabilityFilters) (*AvailabilityMatrix, error)
+	WatchAvailability(ctx context.Context, projectID uuid.UUID, filters AvailabilityFilters) (<-chan UnitChange, error)
+
+	// Price lists; new units are priced from the version in effect
+	CreatePriceList(ctx context.Context, projectID uuid.UUID, req *CreatePriceListRequest) (*PriceList, error)
+	ListPriceLists(ctx context.Context, projectID uuid.UUID) ([]*PriceList, error)
+	GetEffectivePriceList(ctx context.Context, projectID uuid.UUID, at time.Time) (*PriceList, error)
+	ApplyPriceList(ctx context.Context, projectID uuid.UUID, version int, dryRun bool) (*PriceRevision, error)
 	
 	// Property search and filtering
 	SearchProperties(ctx context.Context, req *PropertySearchRequest) ([]*Inventory, error)
-	GetPropertyRecommendations(ctx context.Context, clientID uuid.UUID) ([]*Inventory, error)
+	GetPropertyRecommendations(ctx context.Context, clientID uuid.UUID, limit int) ([]*PropertyRecommendation, error)
+
+	// Client interest in units, which feeds the recommendations
+	RecordInterest(ctx context.Context, clientID uuid.UUID, req *RecordInterestRequest) (*PropertyInterest, error)
+	GetClientInterests(ctx context.Context, clientID uuid.UUID) ([]*PropertyInterest, error)
+}
+
+// ReservationService holds inventory units for clients. Every change of a
+// unit's status goes through it so its project's unit counts stay in step.
+type ReservationService interface {
+	ReserveUnit(ctx context.Context, unitID, clientID uuid.UUID, duration time.Duration) error
+	ReleaseUnit(ctx context.Context, unitID uuid.UUID) error
+	// ConfirmReservation holds a unit for the client until it is sold or
+	// released, reserving it first if it is available
+	ConfirmReservation(ctx context.Context, unitID, clientID uuid.UUID) error
+	// SellUnit marks a unit held by the client, or still available, as sold
+	SellUnit(ctx context.Context, unitID, clientID uuid.UUID) error
+	// ReleaseExpired releases every unit whose reservation has run out and
+	// returns how many were released
+	ReleaseExpired(ctx context.Context) (int, error)
+}
+
+// SearchService handles search across the CRM
+type SearchService interface {
+	Search(ctx context.Context, query SearchQuery) (*SearchResults, error)
+}
+
+// OrganizationService manages the tenants of the deployment. Creating an
+// organization and moving users between organizations are deployment-level
+// operations; everything else acts on the caller's own organization.
+type OrganizationService interface {
+	Create(ctx context.Context, req *CreateOrganizationRequest) (*Organization, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Organization, error)
+	Update(ctx context.Context, id uuid.UUID, req *UpdateOrganizationRequest) (*Organization, error)
+	List(ctx context.Context, filters OrganizationFilters) ([]*Organization, error)
+	AddMember(ctx context.Context, organizationID, userID uuid.UUID) (*User, error)
 }
 
 // SalesService handles sales operations
@@ -102,14 +175,24 @@ type SalesService interface {
 	Create(ctx context.Context, req *CreateSaleRequest) (*Sale, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Sale, error)
 	Update(ctx context.Context, id uuid.UUID, req *UpdateSaleRequest) (*Sale, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) (*Sale, error)
 	List(ctx context.Context, filters SaleFilters) ([]*Sale, error)
+	Count(ctx context.Context, filters SaleFilters) (int, error)
+	TransitionSale(ctx context.Context, saleID uuid.UUID, req *SaleTransitionRequest) (*Sale, error)
 	ApproveSale(ctx context.Context, saleID uuid.UUID, approverID uuid.UUID) error
-	CompleteSale(ctx context.Context, saleID uuid.UUID) error
-	CancelSale(ctx context.Context, saleID uuid.UUID, reason string) error
+	CompleteSale(ctx context.Context, saleID uuid.UUID, completedBy uuid.UUID) error
+	CancelSale(ctx context.Context, saleID uuid.UUID, cancelledBy uuid.UUID, reason string) error
+	GetStatusHistory(ctx context.Context, saleID uuid.UUID) ([]*SaleStatusHistory, error)
 	GetSalesStats(ctx context.Context, filters SaleFilters) (*SalesStats, error)
-	GenerateAgreement(ctx context.Context, saleID uuid.UUID) ([]byte, error)
+	GenerateAgreement(ctx context.Context, saleID uuid.UUID, req *GenerateAgreementRequest) (*Agreement, error)
+	GetAgreement(ctx context.Context, saleID, agreementID uuid.UUID) (*Agreement, error)
+	SaveAgreementTemplate(ctx context.Context, req *SaveAgreementTemplateRequest) (*AgreementTemplate, error)
+	ListAgreementTemplates(ctx context.Context, projectID *uuid.UUID) ([]*AgreementTemplate, error)
 	CreatePaymentSchedule(ctx context.Context, saleID uuid.UUID, req *CreatePaymentScheduleRequest) ([]*PaymentSchedule, error)
+	ReschedulePayments(ctx context.Context, saleID uuid.UUID, req *ReschedulePaymentsRequest) ([]*PaymentSchedule, error)
+	GetPaymentSchedule(ctx context.Context, saleID uuid.UUID) ([]*PaymentSchedule, error)
+	GetPaymentScheduleRevisions(ctx context.Context, saleID uuid.UUID) ([]*PaymentScheduleRevision, error)
 	CalculateCommission(ctx context.Context, saleID uuid.UUID) ([]*Commission, error)
 }
 
@@ -118,7 +201,8 @@ type TaskService interface {
 	Create(ctx context.Context, req *CreateTaskRequest) (*Task, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Task, error)
 	Update(ctx context.Context, id uuid.UUID, req *UpdateTaskRequest) (*Task, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) (*Task, error)
 	List(ctx context.Context, filters TaskFilters) ([]*Task, error)
 	GetByAssignedUser(ctx context.Context, userID uuid.UUID) ([]*Task, error)
 	CompleteTask(ctx context.Context, taskID uuid.UUID, notes string) error
@@ -157,9 +241,18 @@ type FinancialService interface {
 	SendPaymentReminders(ctx context.Context) error
 	
 	// Commission operations
+	CommissionService
+}
+
+// CommissionService calculates, pays and reports sales commissions
+type CommissionService interface {
 	CalculateCommissions(ctx context.Context, saleID uuid.UUID) ([]*Commission, error)
+	ClawBackCommissions(ctx context.Context, saleID uuid.UUID, req *ClawbackRequest) ([]*Commission, error)
 	PayCommission(ctx context.Context, commissionID uuid.UUID, req *PayCommissionRequest) error
-	GetCommissionReport(ctx context.Context, filters CommissionFilters) (*CommissionReport, error)
+	ListCommissions(ctx context.Context, filters CommissionFilters) ([]*Commission, error)
+	GetCommissionReport(ctx context.Context, filters CommissionFilters) ([]*CommissionReport, error)
+	SaveCommissionPlan(ctx context.Context, req *SaveCommissionPlanRequest) (*CommissionPlan, error)
+	ListCommissionPlans(ctx context.Context) ([]*CommissionPlan, error)
 }
 
 // NFTService handles NFT and blockchain operations
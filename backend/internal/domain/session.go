@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a signed-in device. It holds one family of refresh tokens:
+// each refresh replaces the session's token with a new one, and a token of
+// the family presented again after it was replaced revokes the session.
+type Session struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	DeviceName *string   `json:"device_name" db:"device_name"`
+	UserAgent  *string   `json:"user_agent" db:"user_agent"`
+	IPAddress  *string   `json:"ip_address" db:"ip_address"`
+	// TokenHash is the SHA-256 of the session's current refresh token
+	TokenHash string `json:"-" db:"token_hash"`
+	// Rotations counts the refreshes of the session
+	Rotations     int           `json:"rotations" db:"rotations"`
+	ExpiresAt     time.Time     `json:"expires_at" db:"expires_at"`
+	LastUsedAt    time.Time     `json:"last_used_at" db:"last_used_at"`
+	RevokedAt     *time.Time    `json:"revoked_at" db:"revoked_at"`
+	RevokedReason *RevokeReason `json:"revoked_reason" db:"revoked_reason"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+}
+
+// RevokeReason records why a session was ended
+type RevokeReason string
+
+const (
+	RevokeReasonLogout          RevokeReason = "logout"
+	RevokeReasonUser            RevokeReason = "revoked_by_user"
+	RevokeReasonAdmin           RevokeReason = "revoked_by_admin"
+	RevokeReasonTokenReuse      RevokeReason = "token_reuse"
+	RevokeReasonPasswordChanged RevokeReason = "password_changed"
+	RevokeReasonMFAEnabled      RevokeReason = "mfa_enabled"
+)
+
+// Active reports whether the session can still be used at a time
+func (s *Session) Active(at time.Time) bool {
+	return s.RevokedAt == nil && at.Before(s.ExpiresAt)
+}
+
+// ClientInfo describes the device a request comes from
+type ClientInfo struct {
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+}
+
+// clientInfoKey carries the device of a request in its context
+type clientInfoKey struct{}
+
+// WithClientInfo records the device a request comes from, for the
+// sessions it starts
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+// ClientInfoFromContext returns the device recorded in ctx, if any
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
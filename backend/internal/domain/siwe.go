@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SIWENonce is a nonce issued for a Sign-In with Ethereum (EIP-4361)
+// message. A signed message is only accepted once, with a nonce that was
+// issued and has not expired.
+type SIWENonce struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	Nonce     string     `json:"nonce" db:"nonce"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// SIWEChallenge is what a wallet needs to write the message it signs: the
+// domain and URI the message must name, the chains it may be signed for,
+// and a fresh nonce
+type SIWEChallenge struct {
+	Domain    string    `json:"domain"`
+	URI       string    `json:"uri"`
+	ChainIDs  []int64   `json:"chain_ids"`
+	Nonce     string    `json:"nonce"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
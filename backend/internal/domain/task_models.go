@@ -9,6 +9,7 @@ import (
 // Task represents a task in the system
 type Task struct {
 	ID             uuid.UUID    `json:"id" db:"id"`
+	OrganizationID *uuid.UUID   `json:"organization_id" db:"organization_id"`
 	Title          string       `json:"title" db:"title"`
 	Description    *string      `json:"description" db:"description"`
 	AssignedTo     *uuid.UUID   `json:"assigned_to" db:"assigned_to"`
@@ -28,6 +29,8 @@ type Task struct {
 	CreatedBy      uuid.UUID    `json:"created_by" db:"created_by"`
 	CreatedAt      time.Time    `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time    `json:"updated_at" db:"updated_at"`
+	DeletedAt      *time.Time   `json:"deleted_at,omitempty" db:"deleted_at"`
+	DeletedBy      *uuid.UUID   `json:"deleted_by,omitempty" db:"deleted_by"`
 }
 
 // TaskStatus represents the status of a task
@@ -187,6 +190,7 @@ type Refund struct {
 // PaymentSchedule represents installment payment schedule
 type PaymentSchedule struct {
 	ID                  uuid.UUID     `json:"id" db:"id"`
+	OrganizationID      *uuid.UUID    `json:"organization_id" db:"organization_id"`
 	SaleID              uuid.UUID     `json:"sale_id" db:"sale_id"`
 	Sale                *Sale         `json:"sale,omitempty"`
 	InstallmentNumber   int           `json:"installment_number" db:"installment_number"`
@@ -217,8 +221,10 @@ const (
 // Commission represents sales commission
 type Commission struct {
 	ID               uuid.UUID     `json:"id" db:"id"`
+	OrganizationID   *uuid.UUID    `json:"organization_id" db:"organization_id"`
 	SaleID           uuid.UUID     `json:"sale_id" db:"sale_id"`
 	Sale             *Sale         `json:"sale,omitempty"`
+	PlanID           *uuid.UUID    `json:"plan_id" db:"plan_id"`
 	EmployeeID       *uuid.UUID    `json:"employee_id" db:"employee_id"`
 	Employee         *User         `json:"employee,omitempty"`
 	CommissionType   string        `json:"commission_type" db:"commission_type"`
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// commitHooksKey carries the hooks of a running unit of work in a context
+type commitHooksKey struct{}
+
+// commitHooks are the functions to run once a unit of work commits
+type commitHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+// AfterCommit runs fn once the unit of work running in ctx has committed,
+// and drops it if the unit fails. Outside a unit of work fn runs at once.
+func AfterCommit(ctx context.Context, fn func()) {
+	h, ok := ctx.Value(commitHooksKey{}).(*commitHooks)
+	if !ok {
+		fn()
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, fn)
+}
+
+// WithCommitHooks is for UnitOfWork implementations. It returns the ctx of
+// a new unit, which collects the functions passed to AfterCommit, and a
+// function that runs them in order once the unit has committed.
+func WithCommitHooks(ctx context.Context) (context.Context, func()) {
+	h := &commitHooks{}
+	return context.WithValue(ctx, commitHooksKey{}, h), func() {
+		h.mu.Lock()
+		hooks := h.hooks
+		h.hooks = nil
+		h.mu.Unlock()
+		for _, fn := range hooks {
+			fn()
+		}
+	}
+}
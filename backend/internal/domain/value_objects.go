@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -395,3 +396,18 @@ func (br BusinessRules) CanSaleBeApproved(sale *Sale) error {
 	
 	return nil
 }
+
+// Version returns the concurrency token of a record last changed at
+// updatedAt. Handlers send it as the ETag and read it back from If-Match.
+func Version(updatedAt time.Time) string {
+	return strconv.FormatInt(updatedAt.UnixMicro(), 36)
+}
+
+// CheckVersion returns ErrConflict when an expected version was given and
+// the record has changed since
+func CheckVersion(expected string, updatedAt time.Time) error {
+	if expected != "" && expected != Version(updatedAt) {
+		return fmt.Errorf("%w: record was modified by someone else", ErrConflict)
+	}
+	return nil
+}
@@ -1,10 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"goreal-backend/internal/domain"
+	"goreal-backend/internal/middleware"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -31,15 +38,39 @@ func NewAuthChiHandler(authService domain.AuthService) *AuthChiHandler {
 	}
 }
 
-// Routes registers auth routes
+// Routes registers auth routes. Logging out, changing the password and
+// managing sessions, MFA and wallets need a signed-in user; forcing another
+// user to log out, unlocking accounts and reading their login audit trail
+// are for admins.
 func (h *AuthChiHandler) Routes(r chi.Router) {
 	r.Post("/login", h.Login)
 	r.Post("/register", h.Register)
 	r.Post("/refresh", h.RefreshToken)
-	r.Post("/logout", h.Logout)
-	r.Post("/change-password", h.ChangePassword)
 	r.Post("/reset-password", h.ResetPassword)
 	r.Post("/confirm-reset", h.ConfirmPasswordReset)
+	r.Post("/verify-email", h.VerifyEmail)
+	r.Post("/resend-verification", h.ResendVerification)
+	r.Post("/mfa/verify", h.VerifyMFA)
+	r.Post("/siwe/nonce", h.IssueSIWENonce)
+	r.Post("/siwe/login", h.LoginWithWallet)
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AuthRequired(h.authService))
+		r.Post("/logout", h.Logout)
+		r.Post("/change-password", h.ChangePassword)
+		r.Get("/sessions", h.ListSessions)
+		r.Delete("/sessions/{id}", h.RevokeSession)
+		r.Post("/mfa/enroll", h.EnrollMFA)
+		r.Post("/mfa/confirm", h.ConfirmMFA)
+		r.Post("/mfa/disable", h.DisableMFA)
+		r.Post("/mfa/recovery-codes", h.RegenerateRecoveryCodes)
+		r.Get("/wallets", h.ListWallets)
+		r.Post("/wallets", h.LinkWallet)
+		r.Delete("/wallets/{id}", h.UnlinkWallet)
+		r.With(middleware.AdminOnly()).Delete("/users/{id}/sessions", h.RevokeUserSessions)
+		r.With(middleware.AdminOnly()).Post("/users/{id}/unlock", h.UnlockAccount)
+		r.With(middleware.AdminOnly()).Get("/users/{id}/events", h.ListAuthEvents)
+	})
 }
 
 // Login authenticates a user
@@ -48,8 +79,9 @@ func (h *AuthChiHandler) Login(w http.ResponseWriter, r *http.Request) {
 	defer span.End()
 
 	var req struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
+		Email      string `json:"email"`
+		Password   string `json:"password"`
+		DeviceName string `json:"device_name"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -63,9 +95,17 @@ func (h *AuthChiHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authResponse, err := h.authService.Login(ctx, req.Email, req.Password)
+	authResponse, err := h.authService.Login(withClientInfo(ctx, r, req.DeviceName), req.Email, req.Password)
 	if err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrEmailNotVerified) {
+			http.Error(w, "Email address is not verified", http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, domain.ErrAccountLocked) {
+			writeAuthError(w, err)
+			return
+		}
 		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
 		return
 	}
@@ -75,9 +115,15 @@ func (h *AuthChiHandler) Login(w http.ResponseWriter, r *http.Request) {
 		attribute.String("user.id", authResponse.User.ID.String()),
 	)
 
+	// Users with MFA get a challenge to answer at /mfa/verify
+	message := "Login successful"
+	if authResponse.MFARequired {
+		message = "Verification code required"
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"message": "Login successful",
+		"message": message,
 		"data":    authResponse,
 	})
 }
@@ -94,7 +140,7 @@ func (h *AuthChiHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authResponse, err := h.authService.Register(ctx, &req)
+	authResponse, err := h.authService.Register(withClientInfo(ctx, r, ""), &req)
 	if err != nil {
 		span.RecordError(err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -106,10 +152,16 @@ func (h *AuthChiHandler) Register(w http.ResponseWriter, r *http.Request) {
 		attribute.String("user.id", authResponse.User.ID.String()),
 	)
 
+	// Accounts that must verify their address first get no tokens
+	message := "Registration successful"
+	if authResponse.AccessToken == "" {
+		message = "Registration successful, check your email to verify your address"
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"message": "Registration successful",
+		"message": message,
 		"data":    authResponse,
 	})
 }
@@ -160,6 +212,18 @@ func (h *AuthChiHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The refresh token names the session to end; without one the user is
+	// logged out everywhere
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
 	// Parse user ID
 	uid, err := parseUUID(userID)
 	if err != nil {
@@ -168,9 +232,9 @@ func (h *AuthChiHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.authService.Logout(ctx, uid); err != nil {
+	if err := h.authService.Logout(ctx, uid, req.RefreshToken); err != nil {
 		span.RecordError(err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeAuthError(w, err)
 		return
 	}
 
@@ -289,6 +353,10 @@ func (h *AuthChiHandler) ConfirmPasswordReset(w http.ResponseWriter, r *http.Req
 
 	if err := h.authService.ConfirmPasswordReset(ctx, req.Token, req.NewPassword); err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Invalid or expired token", http.StatusBadRequest)
 		return
 	}
@@ -298,3 +366,590 @@ func (h *AuthChiHandler) ConfirmPasswordReset(w http.ResponseWriter, r *http.Req
 		"message": "Password reset successful",
 	})
 }
+
+// VerifyEmail verifies an email address with a mailed token
+func (h *AuthChiHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.VerifyEmail")
+	defer span.End()
+
+	var req struct {
+		Token string `json:"token"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Token == "" {
+		http.Error(w, "Token is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.VerifyEmail(ctx, req.Token); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrInvalidToken) {
+			http.Error(w, "Invalid or expired token", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to verify email", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Email verified",
+	})
+}
+
+// ResendVerification mails a new email verification link
+func (h *AuthChiHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.ResendVerification")
+	defer span.End()
+
+	var req struct {
+		Email string `json:"email"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Email == "" {
+		http.Error(w, "Email is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.ResendVerification(ctx, req.Email); err != nil {
+		span.RecordError(err)
+		// Like password resets, don't reveal whether the email exists
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "If the email needs verifying, a new link has been sent",
+	})
+}
+
+// ListSessions lists the devices the user is signed in on
+func (h *AuthChiHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.ListSessions")
+	defer span.End()
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := h.authService.ListSessions(ctx, user.ID)
+	if err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", user.ID.String()),
+		attribute.Int("result.count", len(sessions)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": sessions,
+	})
+}
+
+// RevokeSession signs the user out of one of their devices
+func (h *AuthChiHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.RevokeSession")
+	defer span.End()
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	sessionID, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.RevokeSession(ctx, user.ID, sessionID); err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", user.ID.String()),
+		attribute.String("session.id", sessionID.String()),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Session revoked",
+	})
+}
+
+// RevokeUserSessions forces a user to log in again on every device
+func (h *AuthChiHandler) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.RevokeUserSessions")
+	defer span.End()
+
+	userID, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	revoked, err := h.authService.RevokeAllSessions(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Int("sessions.revoked", revoked),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "User logged out of all sessions",
+		"data": map[string]int{
+			"revoked": revoked,
+		},
+	})
+}
+
+// UnlockAccount lifts the lock on a user's account after failed logins
+func (h *AuthChiHandler) UnlockAccount(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.UnlockAccount")
+	defer span.End()
+
+	admin, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.UnlockAccount(withClientInfo(ctx, r, ""), userID, admin.ID); err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Account unlocked",
+	})
+}
+
+// ListAuthEvents returns a user's failed logins, lockouts and unlocks,
+// newest first
+func (h *AuthChiHandler) ListAuthEvents(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.ListAuthEvents")
+	defer span.End()
+
+	userID, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	events, err := h.authService.ListAuthEvents(ctx, userID, limit)
+	if err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Int("result.count", len(events)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": events,
+	})
+}
+
+// VerifyMFA completes a login with a code from the user's authenticator
+func (h *AuthChiHandler) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.VerifyMFA")
+	defer span.End()
+
+	var req struct {
+		MFAToken   string `json:"mfa_token"`
+		Code       string `json:"code"`
+		DeviceName string `json:"device_name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	if req.MFAToken == "" || req.Code == "" {
+		http.Error(w, "MFA token and code are required", http.StatusBadRequest)
+		return
+	}
+
+	authResponse, err := h.authService.VerifyMFA(withClientInfo(ctx, r, req.DeviceName), req.MFAToken, req.Code)
+	if err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user.id", authResponse.User.ID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Login successful",
+		"data":    authResponse,
+	})
+}
+
+// EnrollMFA starts setting up an authenticator app for the user
+func (h *AuthChiHandler) EnrollMFA(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.EnrollMFA")
+	defer span.End()
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	setup, err := h.authService.EnrollMFA(ctx, user.ID)
+	if err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user.id", user.ID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Add the authenticator to your app, then confirm it with a code",
+		"data":    setup,
+	})
+}
+
+// ConfirmMFA turns MFA on with a first code from the new authenticator
+func (h *AuthChiHandler) ConfirmMFA(w http.ResponseWriter, r *http.Request) {
+	h.withMFACode(w, r, "authHandler.ConfirmMFA", func(ctx context.Context, userID uuid.UUID, code string) (interface{}, error) {
+		codes, err := h.authService.ConfirmMFA(ctx, userID, code)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"message": "MFA enabled; log in again on your devices. Keep the recovery codes somewhere safe",
+			"data":    map[string]interface{}{"recovery_codes": codes},
+		}, nil
+	})
+}
+
+// DisableMFA turns MFA off
+func (h *AuthChiHandler) DisableMFA(w http.ResponseWriter, r *http.Request) {
+	h.withMFACode(w, r, "authHandler.DisableMFA", func(ctx context.Context, userID uuid.UUID, code string) (interface{}, error) {
+		if err := h.authService.DisableMFA(ctx, userID, code); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"message": "MFA disabled",
+		}, nil
+	})
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes
+func (h *AuthChiHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	h.withMFACode(w, r, "authHandler.RegenerateRecoveryCodes", func(ctx context.Context, userID uuid.UUID, code string) (interface{}, error) {
+		codes, err := h.authService.RegenerateRecoveryCodes(ctx, userID, code)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"message": "Recovery codes replaced",
+			"data":    map[string]interface{}{"recovery_codes": codes},
+		}, nil
+	})
+}
+
+// withMFACode serves an MFA request of the signed-in user that takes a
+// code, writing what fn returns
+func (h *AuthChiHandler) withMFACode(w http.ResponseWriter, r *http.Request, spanName string,
+	fn func(ctx context.Context, userID uuid.UUID, code string) (interface{}, error)) {
+	ctx, span := authChiTracer.Start(r.Context(), spanName)
+	defer span.End()
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Code string `json:"code"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Code == "" {
+		http.Error(w, "Code is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := fn(ctx, user.ID, req.Code)
+	if err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user.id", user.ID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// IssueSIWENonce returns a nonce for a Sign-In with Ethereum message
+func (h *AuthChiHandler) IssueSIWENonce(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.IssueSIWENonce")
+	defer span.End()
+
+	challenge, err := h.authService.IssueSIWENonce(ctx)
+	if err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": challenge,
+	})
+}
+
+// LoginWithWallet authenticates a user with a message signed by a wallet
+// linked to their account
+func (h *AuthChiHandler) LoginWithWallet(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.LoginWithWallet")
+	defer span.End()
+
+	var req struct {
+		Message    string `json:"message"`
+		Signature  string `json:"signature"`
+		DeviceName string `json:"device_name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Message == "" || req.Signature == "" {
+		http.Error(w, "Message and signature are required", http.StatusBadRequest)
+		return
+	}
+
+	authResponse, err := h.authService.LoginWithWallet(withClientInfo(ctx, r, req.DeviceName), req.Message, req.Signature)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrInvalidCredentials) {
+			http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+			return
+		}
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user.id", authResponse.User.ID.String()))
+
+	message := "Login successful"
+	if authResponse.MFARequired {
+		message = "Verification code required"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": message,
+		"data":    authResponse,
+	})
+}
+
+// ListWallets lists the wallets linked to the user's account
+func (h *AuthChiHandler) ListWallets(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.ListWallets")
+	defer span.End()
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	wallets, err := h.authService.ListWallets(ctx, user.ID)
+	if err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", user.ID.String()),
+		attribute.Int("result.count", len(wallets)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": wallets,
+	})
+}
+
+// LinkWallet adds a wallet to the user's account with a message it signed
+func (h *AuthChiHandler) LinkWallet(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.LinkWallet")
+	defer span.End()
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Message    string `json:"message"`
+		Signature  string `json:"signature"`
+		WalletType string `json:"wallet_type"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Message == "" || req.Signature == "" {
+		http.Error(w, "Message and signature are required", http.StatusBadRequest)
+		return
+	}
+
+	wallet, err := h.authService.LinkWallet(ctx, user.ID, req.Message, req.Signature, req.WalletType)
+	if err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", user.ID.String()),
+		attribute.String("wallet.address", wallet.Address),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Wallet linked",
+		"data":    wallet,
+	})
+}
+
+// UnlinkWallet removes a wallet from the user's account
+func (h *AuthChiHandler) UnlinkWallet(w http.ResponseWriter, r *http.Request) {
+	ctx, span := authChiTracer.Start(r.Context(), "authHandler.UnlinkWallet")
+	defer span.End()
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	walletID, err := parseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid wallet ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.UnlinkWallet(ctx, user.ID, walletID); err != nil {
+		span.RecordError(err)
+		writeAuthError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", user.ID.String()),
+		attribute.String("wallet.id", walletID.String()),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Wallet unlinked",
+	})
+}
+
+// withClientInfo records the device a sign-in request comes from
+func withClientInfo(ctx context.Context, r *http.Request, deviceName string) context.Context {
+	return domain.WithClientInfo(ctx, domain.ClientInfo{
+		DeviceName: deviceName,
+		UserAgent:  r.UserAgent(),
+		IPAddress:  middleware.ClientIP(r),
+	})
+}
+
+// writeAuthError maps a failed auth request to a response
+func writeAuthError(w http.ResponseWriter, err error) {
+	var lockout *domain.LockoutError
+	switch {
+	case errors.As(err, &lockout):
+		retryAfter := math.Ceil(time.Until(lockout.Until).Seconds())
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Max(retryAfter, 1))))
+		http.Error(w, lockout.Error(), http.StatusTooManyRequests)
+	case errors.Is(err, domain.ErrAccountLocked):
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+	case errors.Is(err, domain.ErrNotFound), errors.Is(err, domain.ErrUserNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidToken), errors.Is(err, domain.ErrInvalidMFACode):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, domain.ErrAccountInactive), errors.Is(err, domain.ErrEmailNotVerified):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, domain.ErrInvalidInput):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "Failed to process auth request", http.StatusInternalServerError)
+	}
+}
@@ -173,8 +173,15 @@ func (h *AuthHandlerNew) Logout(c *gin.Context) {
 		return
 	}
 
+	// The refresh token, if one is sent, names the session to end;
+	// without one the user is logged out everywhere
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	_ = c.ShouldBindJSON(&req)
+
 	// Logout user
-	if err := h.authService.Logout(ctx, user.ID); err != nil {
+	if err := h.authService.Logout(ctx, user.ID, req.RefreshToken); err != nil {
 		span.RecordError(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Logout failed",
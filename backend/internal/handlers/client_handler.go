@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -36,6 +37,7 @@ func (h *ClientHandler) RegisterRoutes(router *gin.RouterGroup) {
 		clients.GET("/:id", h.GetClient)
 		clients.PUT("/:id", h.UpdateClient)
 		clients.DELETE("/:id", h.DeleteClient)
+		clients.POST("/:id/restore", h.RestoreClient)
 		clients.POST("/:id/verify", h.VerifyClient)
 		clients.GET("/:id/sales", h.GetClientSales)
 		clients.GET("/:id/documents", h.GetClientDocuments)
@@ -124,6 +126,7 @@ func (h *ClientHandler) GetClient(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("client.id", id.String()))
 
+	c.Header("ETag", etag(client.UpdatedAt))
 	c.JSON(http.StatusOK, gin.H{
 		"data": client,
 	})
@@ -154,9 +157,16 @@ func (h *ClientHandler) UpdateClient(c *gin.Context) {
 		return
 	}
 
+	req.Version = ifMatch(c.GetHeader("If-Match"))
 	client, err := h.clientService.Update(ctx, id, &req)
 	if err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrConflict) {
+			if current, getErr := h.clientService.GetByID(ctx, id); getErr == nil {
+				respondConflict(c, current, current.UpdatedAt)
+				return
+			}
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Failed to update client",
 			"details": err.Error(),
@@ -166,13 +176,14 @@ func (h *ClientHandler) UpdateClient(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("client.id", id.String()))
 
+	c.Header("ETag", etag(client.UpdatedAt))
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Client updated successfully",
 		"data": client,
 	})
 }
 
-// DeleteClient deletes a client
+// DeleteClient moves a client to the trash
 func (h *ClientHandler) DeleteClient(c *gin.Context) {
 	ctx, span := clientTracer.Start(c.Request.Context(), "clientHandler.DeleteClient")
 	defer span.End()
@@ -187,8 +198,23 @@ func (h *ClientHandler) DeleteClient(c *gin.Context) {
 		return
 	}
 
-	if err := h.clientService.Delete(ctx, id); err != nil {
+	user, err := middleware.GetUserFromGinContext(c)
+	if err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return
+	}
+
+	if err := h.clientService.Delete(ctx, id, user.ID); err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Client not found",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to delete client",
 			"details": err.Error(),
@@ -203,6 +229,45 @@ func (h *ClientHandler) DeleteClient(c *gin.Context) {
 	})
 }
 
+// RestoreClient takes a client out of the trash
+func (h *ClientHandler) RestoreClient(c *gin.Context) {
+	ctx, span := clientTracer.Start(c.Request.Context(), "clientHandler.RestoreClient")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid client ID",
+		})
+		return
+	}
+
+	client, err := h.clientService.Restore(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Client not found in trash",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to restore client",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(attribute.String("client.id", id.String()))
+
+	c.Header("ETag", etag(client.UpdatedAt))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Client restored successfully",
+		"data":    client,
+	})
+}
+
 // ListClients lists clients with filtering and pagination
 func (h *ClientHandler) ListClients(c *gin.Context) {
 	ctx, span := clientTracer.Start(c.Request.Context(), "clientHandler.ListClients")
@@ -232,6 +297,12 @@ func (h *ClientHandler) ListClients(c *gin.Context) {
 		}
 	}
 
+	if includeDeleted := c.Query("include_deleted"); includeDeleted != "" {
+		if include, err := strconv.ParseBool(includeDeleted); err == nil {
+			filters.IncludeDeleted = include
+		}
+	}
+
 	// Parse pagination
 	if limitStr := c.Query("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
@@ -245,6 +316,14 @@ func (h *ClientHandler) ListClients(c *gin.Context) {
 		}
 	}
 
+	if err := parseCursor(c.Query("cursor"), &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid cursor",
+		})
+		return
+	}
+
 	// Get clients
 	clients, err := h.clientService.List(ctx, filters)
 	if err != nil {
@@ -279,6 +358,7 @@ func (h *ClientHandler) ListClients(c *gin.Context) {
 			"count": len(clients),
 			"limit": filters.Limit,
 			"offset": filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, clients),
 		},
 	})
 }
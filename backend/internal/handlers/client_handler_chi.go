@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"goreal-backend/internal/domain"
+	"goreal-backend/internal/middleware"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -34,6 +36,7 @@ func (h *ClientChiHandler) Routes(r chi.Router) {
 	r.Get("/{id}", h.GetClient)
 	r.Put("/{id}", h.UpdateClient)
 	r.Delete("/{id}", h.DeleteClient)
+	r.Post("/{id}/restore", h.RestoreClient)
 	r.Post("/{id}/verify", h.VerifyClient)
 	r.Get("/{id}/history", h.GetClientHistory)
 }
@@ -92,6 +95,7 @@ func (h *ClientChiHandler) GetClient(w http.ResponseWriter, r *http.Request) {
 
 	span.SetAttributes(attribute.String("client.id", id.String()))
 
+	w.Header().Set("ETag", etag(client.UpdatedAt))
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"data": client,
@@ -118,15 +122,23 @@ func (h *ClientChiHandler) UpdateClient(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	req.Version = ifMatch(r.Header.Get("If-Match"))
 	client, err := h.clientService.Update(ctx, id, &req)
 	if err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrConflict) {
+			if current, getErr := h.clientService.GetByID(ctx, id); getErr == nil {
+				writeConflict(w, current, current.UpdatedAt)
+				return
+			}
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	span.SetAttributes(attribute.String("client.id", id.String()))
 
+	w.Header().Set("ETag", etag(client.UpdatedAt))
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"message": "Client updated successfully",
@@ -134,7 +146,7 @@ func (h *ClientChiHandler) UpdateClient(w http.ResponseWriter, r *http.Request)
 	})
 }
 
-// DeleteClient deletes a client
+// DeleteClient moves a client to the trash
 func (h *ClientChiHandler) DeleteClient(w http.ResponseWriter, r *http.Request) {
 	ctx, span := clientChiTracer.Start(r.Context(), "clientHandler.DeleteClient")
 	defer span.End()
@@ -147,8 +159,19 @@ func (h *ClientChiHandler) DeleteClient(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := h.clientService.Delete(ctx, id); err != nil {
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.clientService.Delete(ctx, id, user.ID); err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Client not found", http.StatusNotFound)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -161,6 +184,39 @@ func (h *ClientChiHandler) DeleteClient(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// RestoreClient takes a client out of the trash
+func (h *ClientChiHandler) RestoreClient(w http.ResponseWriter, r *http.Request) {
+	ctx, span := clientChiTracer.Start(r.Context(), "clientHandler.RestoreClient")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid client ID", http.StatusBadRequest)
+		return
+	}
+
+	client, err := h.clientService.Restore(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Client not found in trash", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.String("client.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(client.UpdatedAt))
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Client restored successfully",
+		"data":    client,
+	})
+}
+
 // ListClients lists clients with filtering and pagination
 func (h *ClientChiHandler) ListClients(w http.ResponseWriter, r *http.Request) {
 	ctx, span := clientChiTracer.Start(r.Context(), "clientHandler.ListClients")
@@ -190,6 +246,12 @@ func (h *ClientChiHandler) ListClients(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if includeDeleted := r.URL.Query().Get("include_deleted"); includeDeleted != "" {
+		if include, err := strconv.ParseBool(includeDeleted); err == nil {
+			filters.IncludeDeleted = include
+		}
+	}
+
 	// Parse pagination
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
@@ -203,6 +265,12 @@ func (h *ClientChiHandler) ListClients(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if err := parseCursor(r.URL.Query().Get("cursor"), &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	}
+
 	// Get clients
 	clients, err := h.clientService.List(ctx, filters)
 	if err != nil {
@@ -228,10 +296,11 @@ func (h *ClientChiHandler) ListClients(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"data": clients,
 		"pagination": map[string]interface{}{
-			"total":  total,
-			"count":  len(clients),
-			"limit":  filters.Limit,
-			"offset": filters.Offset,
+			"total":       total,
+			"count":       len(clients),
+			"limit":       filters.Limit,
+			"offset":      filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, clients),
 		},
 	})
 }
@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var commissionChiTracer = otel.Tracer("goreal-backend/handlers/commission")
+
+// CommissionChiHandler handles commission-related HTTP requests using Chi router
+type CommissionChiHandler struct {
+	commissionService domain.CommissionService
+}
+
+// NewCommissionChiHandler creates a new commission handler
+func NewCommissionChiHandler(commissionService domain.CommissionService) *CommissionChiHandler {
+	return &CommissionChiHandler{
+		commissionService: commissionService,
+	}
+}
+
+// Routes registers commission routes
+func (h *CommissionChiHandler) Routes(r chi.Router) {
+	r.Get("/", h.ListCommissions)
+	r.Get("/plans", h.ListCommissionPlans)
+	r.With(middleware.ManagerOrAbove()).Get("/report", h.GetCommissionReport)
+	r.With(middleware.ManagerOrAbove()).Post("/plans", h.SaveCommissionPlan)
+	r.With(middleware.ManagerOrAbove()).Post("/{id}/pay", h.PayCommission)
+	r.With(middleware.ManagerOrAbove()).Post("/sales/{saleID}/calculate", h.CalculateCommissions)
+	r.With(middleware.ManagerOrAbove()).Post("/sales/{saleID}/clawback", h.ClawBackCommissions)
+}
+
+// ListCommissions lists commissions with filtering and pagination; users
+// below manager only see their own
+func (h *CommissionChiHandler) ListCommissions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := commissionChiTracer.Start(r.Context(), "commissionHandler.ListCommissions")
+	defer span.End()
+
+	filters := domain.CommissionFilters{}
+
+	if saleID := r.URL.Query().Get("sale_id"); saleID != "" {
+		if id, err := uuid.Parse(saleID); err == nil {
+			filters.SaleID = &id
+		}
+	}
+
+	if employeeID := r.URL.Query().Get("employee_id"); employeeID != "" {
+		if id, err := uuid.Parse(employeeID); err == nil {
+			filters.EmployeeID = &id
+		}
+	}
+
+	if commissionType := r.URL.Query().Get("commission_type"); commissionType != "" {
+		filters.CommissionType = &commissionType
+	}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		paymentStatus := domain.PaymentStatus(status)
+		filters.Status = &paymentStatus
+	}
+
+	if user, err := middleware.GetUserFromContext(r); err == nil && !user.Role.AtLeast(domain.RoleManager) {
+		filters.EmployeeID = &user.ID
+	}
+
+	// Parse pagination
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			filters.Limit = limit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filters.Offset = offset
+		}
+	}
+
+	if err := parseCursor(r.URL.Query().Get("cursor"), &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	commissions, err := h.commissionService.ListCommissions(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Failed to list commissions", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("commissions.count", len(commissions)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": commissions,
+		"pagination": map[string]interface{}{
+			"count":       len(commissions),
+			"limit":       filters.Limit,
+			"offset":      filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, commissions),
+		},
+	})
+}
+
+// GetCommissionReport sums commissions per employee and month
+func (h *CommissionChiHandler) GetCommissionReport(w http.ResponseWriter, r *http.Request) {
+	ctx, span := commissionChiTracer.Start(r.Context(), "commissionHandler.GetCommissionReport")
+	defer span.End()
+
+	filters := domain.CommissionFilters{}
+
+	if employeeID := r.URL.Query().Get("employee_id"); employeeID != "" {
+		id, err := uuid.Parse(employeeID)
+		if err != nil {
+			span.RecordError(err)
+			http.Error(w, "Invalid employee ID", http.StatusBadRequest)
+			return
+		}
+		filters.EmployeeID = &id
+	}
+
+	for param, target := range map[string]**time.Time{"from": &filters.CreatedFrom, "to": &filters.CreatedTo} {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+		at, err := parseReportTime(value)
+		if err != nil {
+			span.RecordError(err)
+			http.Error(w, "Invalid "+param+" date", http.StatusBadRequest)
+			return
+		}
+		*target = &at
+	}
+
+	reports, err := h.commissionService.GetCommissionReport(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Failed to get commission report", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(reports)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": reports,
+	})
+}
+
+// parseReportTime accepts an RFC 3339 timestamp or a plain date
+func parseReportTime(value string) (time.Time, error) {
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// ListCommissionPlans lists the commission plans, newest (in effect) first
+func (h *CommissionChiHandler) ListCommissionPlans(w http.ResponseWriter, r *http.Request) {
+	ctx, span := commissionChiTracer.Start(r.Context(), "commissionHandler.ListCommissionPlans")
+	defer span.End()
+
+	plans, err := h.commissionService.ListCommissionPlans(ctx)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Failed to list commission plans", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(plans)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": plans,
+	})
+}
+
+// SaveCommissionPlan creates a commission plan that applies to sales from now on
+func (h *CommissionChiHandler) SaveCommissionPlan(w http.ResponseWriter, r *http.Request) {
+	ctx, span := commissionChiTracer.Start(r.Context(), "commissionHandler.SaveCommissionPlan")
+	defer span.End()
+
+	var req domain.SaveCommissionPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.CreatedBy = user.ID
+
+	plan, err := h.commissionService.SaveCommissionPlan(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		writeCommissionError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("plan.id", plan.ID.String()),
+		attribute.String("plan.type", string(plan.Type)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Commission plan saved successfully",
+		"data":    plan,
+	})
+}
+
+// PayCommission records the payout of a pending commission
+func (h *CommissionChiHandler) PayCommission(w http.ResponseWriter, r *http.Request) {
+	ctx, span := commissionChiTracer.Start(r.Context(), "commissionHandler.PayCommission")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid commission ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.PayCommissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.commissionService.PayCommission(ctx, id, &req); err != nil {
+		span.RecordError(err)
+		writeCommissionError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("commission.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Commission paid successfully",
+	})
+}
+
+// CalculateCommissions calculates the commissions of an approved sale
+func (h *CommissionChiHandler) CalculateCommissions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := commissionChiTracer.Start(r.Context(), "commissionHandler.CalculateCommissions")
+	defer span.End()
+
+	saleID, err := uuid.Parse(chi.URLParam(r, "saleID"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	commissions, err := h.commissionService.CalculateCommissions(ctx, saleID)
+	if err != nil {
+		span.RecordError(err)
+		writeCommissionError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("sale.id", saleID.String()),
+		attribute.Int("result.count", len(commissions)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": commissions,
+	})
+}
+
+// ClawBackCommissions recovers commission on a refund of a sale
+func (h *CommissionChiHandler) ClawBackCommissions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := commissionChiTracer.Start(r.Context(), "commissionHandler.ClawBackCommissions")
+	defer span.End()
+
+	saleID, err := uuid.Parse(chi.URLParam(r, "saleID"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.ClawbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.RequestedBy = user.ID
+
+	commissions, err := h.commissionService.ClawBackCommissions(ctx, saleID, &req)
+	if err != nil {
+		span.RecordError(err)
+		writeCommissionError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("sale.id", saleID.String()),
+		attribute.Int("result.count", len(commissions)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Commissions clawed back successfully",
+		"data":    commissions,
+	})
+}
+
+// writeCommissionError maps a failed commission change to a response
+func writeCommissionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrAlreadyExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrValidationFailed):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, domain.ErrInvalidInput):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "Failed to update commissions", http.StatusInternalServerError)
+	}
+}
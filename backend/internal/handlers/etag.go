@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const conflictMessage = "Resource was modified by another request"
+
+// etag formats a record version as a strong entity tag
+func etag(updatedAt time.Time) string {
+	return `"` + domain.Version(updatedAt) + `"`
+}
+
+// ifMatch returns the version from an If-Match header, or "" when the
+// header is missing or "*" so the update is unconditional
+func ifMatch(header string) string {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return ""
+	}
+	return strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+}
+
+// respondConflict answers a stale update with 409 and the current record
+func respondConflict(c *gin.Context, current interface{}, updatedAt time.Time) {
+	c.Header("ETag", etag(updatedAt))
+	c.JSON(http.StatusConflict, gin.H{
+		"error": conflictMessage,
+		"data":  current,
+	})
+}
+
+// writeConflict is respondConflict for the chi handlers
+func writeConflict(w http.ResponseWriter, current interface{}, updatedAt time.Time) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(updatedAt))
+	w.WriteHeader(http.StatusConflict)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": conflictMessage,
+		"data":  current,
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -36,6 +37,7 @@ func (h *LeadHandler) RegisterRoutes(router *gin.RouterGroup) {
 		leads.GET("/:id", h.GetLead)
 		leads.PUT("/:id", h.UpdateLead)
 		leads.DELETE("/:id", h.DeleteLead)
+		leads.POST("/:id/restore", h.RestoreLead)
 		leads.POST("/:id/assign", h.AssignLead)
 		leads.POST("/:id/convert", h.ConvertLead)
 		leads.PUT("/:id/score", h.UpdateLeadScore)
@@ -123,6 +125,7 @@ func (h *LeadHandler) GetLead(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("lead.id", id.String()))
 
+	c.Header("ETag", etag(lead.UpdatedAt))
 	c.JSON(http.StatusOK, gin.H{
 		"data": lead,
 	})
@@ -153,9 +156,16 @@ func (h *LeadHandler) UpdateLead(c *gin.Context) {
 		return
 	}
 
+	req.Version = ifMatch(c.GetHeader("If-Match"))
 	lead, err := h.leadService.Update(ctx, id, &req)
 	if err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrConflict) {
+			if current, getErr := h.leadService.GetByID(ctx, id); getErr == nil {
+				respondConflict(c, current, current.UpdatedAt)
+				return
+			}
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Failed to update lead",
 			"details": err.Error(),
@@ -165,13 +175,14 @@ func (h *LeadHandler) UpdateLead(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("lead.id", id.String()))
 
+	c.Header("ETag", etag(lead.UpdatedAt))
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Lead updated successfully",
 		"data": lead,
 	})
 }
 
-// DeleteLead deletes a lead
+// DeleteLead moves a lead to the trash
 func (h *LeadHandler) DeleteLead(c *gin.Context) {
 	ctx, span := leadTracer.Start(c.Request.Context(), "leadHandler.DeleteLead")
 	defer span.End()
@@ -186,8 +197,23 @@ func (h *LeadHandler) DeleteLead(c *gin.Context) {
 		return
 	}
 
-	if err := h.leadService.Delete(ctx, id); err != nil {
+	user, err := middleware.GetUserFromGinContext(c)
+	if err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return
+	}
+
+	if err := h.leadService.Delete(ctx, id, user.ID); err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Lead not found",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to delete lead",
 			"details": err.Error(),
@@ -202,6 +228,45 @@ func (h *LeadHandler) DeleteLead(c *gin.Context) {
 	})
 }
 
+// RestoreLead takes a lead out of the trash
+func (h *LeadHandler) RestoreLead(c *gin.Context) {
+	ctx, span := leadTracer.Start(c.Request.Context(), "leadHandler.RestoreLead")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid lead ID",
+		})
+		return
+	}
+
+	lead, err := h.leadService.Restore(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Lead not found in trash",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to restore lead",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(attribute.String("lead.id", id.String()))
+
+	c.Header("ETag", etag(lead.UpdatedAt))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Lead restored successfully",
+		"data":    lead,
+	})
+}
+
 // ListLeads lists leads with filtering and pagination
 func (h *LeadHandler) ListLeads(c *gin.Context) {
 	ctx, span := leadTracer.Start(c.Request.Context(), "leadHandler.ListLeads")
@@ -234,6 +299,12 @@ func (h *LeadHandler) ListLeads(c *gin.Context) {
 		filters.Tags = []string{tags}
 	}
 
+	if includeDeleted := c.Query("include_deleted"); includeDeleted != "" {
+		if include, err := strconv.ParseBool(includeDeleted); err == nil {
+			filters.IncludeDeleted = include
+		}
+	}
+
 	// Parse pagination
 	if limitStr := c.Query("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
@@ -247,6 +318,14 @@ func (h *LeadHandler) ListLeads(c *gin.Context) {
 		}
 	}
 
+	if err := parseCursor(c.Query("cursor"), &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid cursor",
+		})
+		return
+	}
+
 	// Get leads
 	leads, err := h.leadService.List(ctx, filters)
 	if err != nil {
@@ -281,6 +360,7 @@ func (h *LeadHandler) ListLeads(c *gin.Context) {
 			"count": len(leads),
 			"limit": filters.Limit,
 			"offset": filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, leads),
 		},
 	})
 }
@@ -0,0 +1,533 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var leadChiTracer = otel.Tracer("goreal-backend/handlers/lead")
+
+// LeadChiHandler handles lead-related HTTP requests using Chi router
+type LeadChiHandler struct {
+	leadService domain.LeadService
+}
+
+// NewLeadChiHandler creates a new lead handler
+func NewLeadChiHandler(leadService domain.LeadService) *LeadChiHandler {
+	return &LeadChiHandler{
+		leadService: leadService,
+	}
+}
+
+// Routes registers lead routes. Leads carry prospects' contact details, so
+// they are for employees and above; bulk changes are for managers.
+func (h *LeadChiHandler) Routes(r chi.Router) {
+	r.Use(middleware.EmployeeOrAbove())
+
+	r.Get("/", h.ListLeads)
+	r.Post("/", h.CreateLead)
+	r.Get("/overdue-follow-ups", h.GetOverdueFollowUps)
+	r.With(middleware.ManagerOrAbove()).Post("/bulk-assign", h.BulkAssignLeads)
+	r.With(middleware.ManagerOrAbove()).Post("/import", h.ImportLeads)
+	r.Get("/{id}", h.GetLead)
+	r.Put("/{id}", h.UpdateLead)
+	r.Delete("/{id}", h.DeleteLead)
+	r.Post("/{id}/restore", h.RestoreLead)
+	r.Post("/{id}/assign", h.AssignLead)
+	r.Post("/{id}/convert", h.ConvertLead)
+	r.Put("/{id}/score", h.UpdateLeadScore)
+}
+
+// CreateLead creates a new lead, assigned to the current user unless
+// another assignee is named
+func (h *LeadChiHandler) CreateLead(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.CreateLead")
+	defer span.End()
+
+	var req domain.CreateLeadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if req.AssignedTo == nil {
+		req.AssignedTo = &user.ID
+	}
+
+	lead, err := h.leadService.Create(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("lead.id", lead.ID.String()),
+		attribute.String("lead.name", lead.Name),
+	)
+
+	w.Header().Set("ETag", etag(lead.UpdatedAt))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Lead created successfully",
+		"data":    lead,
+	})
+}
+
+// GetLead retrieves a lead by ID
+func (h *LeadChiHandler) GetLead(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.GetLead")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid lead ID", http.StatusBadRequest)
+		return
+	}
+
+	lead, err := h.leadService.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Lead not found", http.StatusNotFound)
+		return
+	}
+
+	span.SetAttributes(attribute.String("lead.id", id.String()))
+
+	w.Header().Set("ETag", etag(lead.UpdatedAt))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": lead,
+	})
+}
+
+// UpdateLead updates an existing lead
+func (h *LeadChiHandler) UpdateLead(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.UpdateLead")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid lead ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.UpdateLeadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	req.Version = ifMatch(r.Header.Get("If-Match"))
+	lead, err := h.leadService.Update(ctx, id, &req)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrConflict) {
+			if current, getErr := h.leadService.GetByID(ctx, id); getErr == nil {
+				writeConflict(w, current, current.UpdatedAt)
+				return
+			}
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	span.SetAttributes(attribute.String("lead.id", id.String()))
+
+	w.Header().Set("ETag", etag(lead.UpdatedAt))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Lead updated successfully",
+		"data":    lead,
+	})
+}
+
+// DeleteLead moves a lead to the trash
+func (h *LeadChiHandler) DeleteLead(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.DeleteLead")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid lead ID", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.leadService.Delete(ctx, id, user.ID); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Lead not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.String("lead.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Lead deleted successfully",
+	})
+}
+
+// RestoreLead takes a lead out of the trash
+func (h *LeadChiHandler) RestoreLead(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.RestoreLead")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid lead ID", http.StatusBadRequest)
+		return
+	}
+
+	lead, err := h.leadService.Restore(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Lead not found in trash", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.String("lead.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(lead.UpdatedAt))
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Lead restored successfully",
+		"data":    lead,
+	})
+}
+
+// ListLeads lists leads with filtering and keyset or offset pagination
+func (h *LeadChiHandler) ListLeads(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.ListLeads")
+	defer span.End()
+
+	// Parse query parameters
+	filters := domain.LeadFilters{}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		leadStatus := domain.LeadStatus(status)
+		filters.Status = &leadStatus
+	}
+
+	if source := r.URL.Query().Get("source"); source != "" {
+		leadSource := domain.LeadSource(source)
+		filters.Source = &leadSource
+	}
+
+	if assignedTo := r.URL.Query().Get("assigned_to"); assignedTo != "" {
+		if id, err := uuid.Parse(assignedTo); err == nil {
+			filters.AssignedTo = &id
+		}
+	}
+
+	if search := r.URL.Query().Get("search"); search != "" {
+		filters.Search = &search
+	}
+
+	if tags := r.URL.Query().Get("tags"); tags != "" {
+		filters.Tags = []string{tags}
+	}
+
+	if includeDeleted := r.URL.Query().Get("include_deleted"); includeDeleted != "" {
+		if include, err := strconv.ParseBool(includeDeleted); err == nil {
+			filters.IncludeDeleted = include
+		}
+	}
+
+	// Parse pagination
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			filters.Limit = limit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filters.Offset = offset
+		}
+	}
+
+	if err := parseCursor(r.URL.Query().Get("cursor"), &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	leads, err := h.leadService.List(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	total, err := h.leadService.Count(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.Int("leads.count", len(leads)),
+		attribute.Int("leads.total", total),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": leads,
+		"pagination": map[string]interface{}{
+			"total":       total,
+			"count":       len(leads),
+			"limit":       filters.Limit,
+			"offset":      filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, leads),
+		},
+	})
+}
+
+// AssignLead assigns a lead to a user
+func (h *LeadChiHandler) AssignLead(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.AssignLead")
+	defer span.End()
+
+	leadID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid lead ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		UserID uuid.UUID `json:"user_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == uuid.Nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.leadService.AssignToUser(ctx, leadID, req.UserID); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("lead.id", leadID.String()),
+		attribute.String("user.id", req.UserID.String()),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Lead assigned successfully",
+	})
+}
+
+// ConvertLead converts a lead to a client
+func (h *LeadChiHandler) ConvertLead(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.ConvertLead")
+	defer span.End()
+
+	leadID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid lead ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.ConvertLeadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	client, err := h.leadService.ConvertToClient(ctx, leadID, &req)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Lead not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("lead.id", leadID.String()),
+		attribute.String("client.id", client.ID.String()),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Lead converted successfully",
+		"data":    client,
+	})
+}
+
+// UpdateLeadScore sets a lead's score
+func (h *LeadChiHandler) UpdateLeadScore(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.UpdateLeadScore")
+	defer span.End()
+
+	leadID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid lead ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Score *int `json:"score"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Score == nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.leadService.UpdateScore(ctx, leadID, *req.Score); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Lead not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("lead.id", leadID.String()),
+		attribute.Int("lead.score", *req.Score),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Lead score updated successfully",
+	})
+}
+
+// GetOverdueFollowUps lists leads whose next follow-up is past due
+func (h *LeadChiHandler) GetOverdueFollowUps(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.GetOverdueFollowUps")
+	defer span.End()
+
+	leads, err := h.leadService.GetOverdueFollowUps(ctx)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("leads.count", len(leads)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": leads,
+	})
+}
+
+// BulkAssignLeads assigns several leads to one user
+func (h *LeadChiHandler) BulkAssignLeads(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.BulkAssignLeads")
+	defer span.End()
+
+	var req struct {
+		LeadIDs []uuid.UUID `json:"lead_ids"`
+		UserID  uuid.UUID   `json:"user_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.LeadIDs) == 0 || req.UserID == uuid.Nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.leadService.BulkAssign(ctx, req.LeadIDs, req.UserID); err != nil {
+		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.Int("leads.count", len(req.LeadIDs)),
+		attribute.String("user.id", req.UserID.String()),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Leads assigned successfully",
+	})
+}
+
+// ImportLeads creates leads in bulk and reports the ones that failed
+func (h *LeadChiHandler) ImportLeads(w http.ResponseWriter, r *http.Request) {
+	ctx, span := leadChiTracer.Start(r.Context(), "leadHandler.ImportLeads")
+	defer span.End()
+
+	var req domain.ImportLeadsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.leadService.ImportLeads(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.Int("import.total", result.TotalRecords),
+		attribute.Int("import.failed", result.FailedImports),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Leads imported",
+		"data":    result,
+	})
+}
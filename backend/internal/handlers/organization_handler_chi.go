@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var organizationChiTracer = otel.Tracer("goreal-backend/handlers/organization")
+
+// OrganizationChiHandler handles organization requests using Chi router
+type OrganizationChiHandler struct {
+	organizationService domain.OrganizationService
+}
+
+// NewOrganizationChiHandler creates a new organization handler for Chi
+func NewOrganizationChiHandler(organizationService domain.OrganizationService) *OrganizationChiHandler {
+	return &OrganizationChiHandler{
+		organizationService: organizationService,
+	}
+}
+
+// Routes registers organization routes. They are mounted for admins, who
+// manage their own organization; creating organizations and moving users
+// between them is for super admins.
+func (h *OrganizationChiHandler) Routes(r chi.Router) {
+	r.Get("/", h.ListOrganizations)
+	r.Get("/{id}", h.GetOrganization)
+	r.Put("/{id}", h.UpdateOrganization)
+	r.With(middleware.SuperAdminOnly()).Post("/", h.CreateOrganization)
+	r.With(middleware.SuperAdminOnly()).Post("/{id}/members", h.AddMember)
+}
+
+// ListOrganizations lists the organizations visible to the caller
+func (h *OrganizationChiHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := organizationChiTracer.Start(r.Context(), "organizationHandler.ListOrganizations")
+	defer span.End()
+
+	filters := domain.OrganizationFilters{}
+
+	if isActive := r.URL.Query().Get("is_active"); isActive != "" {
+		if active, err := strconv.ParseBool(isActive); err == nil {
+			filters.IsActive = &active
+		}
+	}
+
+	if search := r.URL.Query().Get("search"); search != "" {
+		filters.Search = &search
+	}
+
+	organizations, err := h.organizationService.List(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Failed to list organizations", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("organizations.count", len(organizations)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": organizations,
+	})
+}
+
+// GetOrganization retrieves an organization by ID
+func (h *OrganizationChiHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx, span := organizationChiTracer.Start(r.Context(), "organizationHandler.GetOrganization")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	organization, err := h.organizationService.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		writeOrganizationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": organization,
+	})
+}
+
+// CreateOrganization creates a new organization
+func (h *OrganizationChiHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx, span := organizationChiTracer.Start(r.Context(), "organizationHandler.CreateOrganization")
+	defer span.End()
+
+	var req domain.CreateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	organization, err := h.organizationService.Create(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		writeOrganizationError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("organization.id", organization.ID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Organization created successfully",
+		"data":    organization,
+	})
+}
+
+// UpdateOrganization renames an organization or changes its slug
+func (h *OrganizationChiHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx, span := organizationChiTracer.Start(r.Context(), "organizationHandler.UpdateOrganization")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.UpdateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	organization, err := h.organizationService.Update(ctx, id, &req)
+	if err != nil {
+		span.RecordError(err)
+		writeOrganizationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Organization updated successfully",
+		"data":    organization,
+	})
+}
+
+// AddMember moves an existing user into an organization
+func (h *OrganizationChiHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	ctx, span := organizationChiTracer.Start(r.Context(), "organizationHandler.AddMember")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid organization ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.AddOrganizationMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.organizationService.AddMember(ctx, id, req.UserID)
+	if err != nil {
+		span.RecordError(err)
+		writeOrganizationError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user.id", user.ID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "User added to organization successfully",
+		"data":    user,
+	})
+}
+
+// writeOrganizationError maps an organization service error to a response
+func writeOrganizationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrAlreadyExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrValidationFailed):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, domain.ErrInvalidInput):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "Failed to update organization", http.StatusInternalServerError)
+	}
+}
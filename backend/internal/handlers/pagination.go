@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"fmt"
+
+	"goreal-backend/internal/domain"
+)
+
+// cursored is a listed entity that can mark a keyset pagination position
+type cursored interface {
+	Cursor() domain.Cursor
+}
+
+// parseCursor reads the cursor query parameter into filters; an empty value
+// leaves the list in offset mode. Cursors follow only the created_at order,
+// so a cursor with any other sort is refused; read the sort first.
+func parseCursor(value string, filters *domain.BaseFilters) error {
+	if value == "" {
+		return nil
+	}
+	cursor, err := domain.ParseCursor(value)
+	if err != nil {
+		return err
+	}
+	if _, ok := filters.KeysetOrder(); !ok {
+		return fmt.Errorf("a cursor cannot page a list sorted by %s: %w", filters.SortBy, domain.ErrInvalidInput)
+	}
+	filters.Cursor = cursor
+	return nil
+}
+
+// nextCursor returns the cursor for the page after rows, or "" when rows
+// is the last page or was not listed in keyset order
+func nextCursor[T cursored](filters domain.BaseFilters, rows []T) string {
+	if filters.Limit <= 0 || len(rows) < filters.Limit {
+		return ""
+	}
+	if _, ok := filters.KeysetOrder(); !ok {
+		return ""
+	}
+	return rows[len(rows)-1].Cursor().String()
+}
@@ -0,0 +1,1279 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/middleware"
+	"goreal-backend/pkg/spreadsheet"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var propertyChiTracer = otel.Tracer("goreal-backend/handlers/property")
+
+// PropertyChiHandler handles society, project and inventory HTTP requests
+// using Chi router
+type PropertyChiHandler struct {
+	propertyService domain.PropertyService
+}
+
+// NewPropertyChiHandler creates a new property handler
+func NewPropertyChiHandler(propertyService domain.PropertyService) *PropertyChiHandler {
+	return &PropertyChiHandler{
+		propertyService: propertyService,
+	}
+}
+
+// SocietyRoutes registers society routes; changes are for managers
+func (h *PropertyChiHandler) SocietyRoutes(r chi.Router) {
+	r.Get("/", h.ListSocieties)
+	r.Get("/{id}", h.GetSociety)
+	r.Get("/{id}/projects", h.GetSocietyProjects)
+	r.With(middleware.RequireMinRole(domain.RoleManager)).Post("/", h.CreateSociety)
+	r.With(middleware.RequireMinRole(domain.RoleManager)).Put("/{id}", h.UpdateSociety)
+}
+
+// ProjectRoutes registers project routes; changes are for managers
+func (h *PropertyChiHandler) ProjectRoutes(r chi.Router) {
+	r.Get("/", h.ListProjects)
+	r.Get("/{id}", h.GetProject)
+	r.Get("/{id}/available-units", h.GetAvailableUnits)
+	r.Get("/{id}/availability-matrix", h.GetAvailabilityMatrix)
+	r.Get("/{id}/availability-matrix/stream", h.StreamAvailabilityMatrix)
+	r.Get("/{id}/price-lists", h.ListPriceLists)
+	r.Get("/{id}/price-lists/current", h.GetCurrentPriceList)
+	r.With(middleware.RequireMinRole(domain.RoleManager)).Post("/", h.CreateProject)
+	r.With(middleware.RequireMinRole(domain.RoleManager)).Put("/{id}", h.UpdateProject)
+	r.With(middleware.RequireMinRole(domain.RoleManager)).Post("/{id}/inventory/import", h.ImportInventory)
+	r.With(middleware.RequireMinRole(domain.RoleManager)).Post("/{id}/price-lists", h.CreatePriceList)
+	r.With(middleware.RequireMinRole(domain.RoleManager)).Post("/{id}/price-lists/{version}/apply", h.ApplyPriceList)
+}
+
+// InventoryRoutes registers inventory routes. Employees reserve and release
+// units; managers add and change them.
+func (h *PropertyChiHandler) InventoryRoutes(r chi.Router) {
+	r.Get("/", h.ListInventory)
+	r.Post("/search", h.SearchProperties)
+	r.Get("/{id}", h.GetInventory)
+	r.With(middleware.RequireMinRole(domain.RoleManager)).Post("/", h.CreateInventory)
+	r.With(middleware.RequireMinRole(domain.RoleManager)).Put("/{id}", h.UpdateInventory)
+	r.With(middleware.RequireMinRole(domain.RoleEmployee)).Post("/{id}/reserve", h.ReserveUnit)
+	r.With(middleware.RequireMinRole(domain.RoleEmployee)).Post("/{id}/release", h.ReleaseUnit)
+}
+
+// ClientRoutes registers the property routes of a client. They are mounted
+// next to the client routes, which are for employees.
+func (h *PropertyChiHandler) ClientRoutes(r chi.Router) {
+	r.Get("/{id}/recommendations", h.GetRecommendations)
+	r.Get("/{id}/interests", h.GetClientInterests)
+	r.Post("/{id}/interests", h.RecordInterest)
+}
+
+// CreateSociety creates a new society
+func (h *PropertyChiHandler) CreateSociety(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.CreateSociety")
+	defer span.End()
+
+	var req domain.CreateSocietyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.CreatedBy = user.ID
+
+	society, err := h.propertyService.CreateSociety(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("society.id", society.ID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Society created successfully",
+		"data":    society,
+	})
+}
+
+// GetSociety retrieves a society by ID
+func (h *PropertyChiHandler) GetSociety(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.GetSociety")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid society ID", http.StatusBadRequest)
+		return
+	}
+
+	society, err := h.propertyService.GetSociety(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("society.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": society,
+	})
+}
+
+// UpdateSociety updates an existing society
+func (h *PropertyChiHandler) UpdateSociety(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.UpdateSociety")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid society ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.UpdateSocietyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	society, err := h.propertyService.UpdateSociety(ctx, id, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("society.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Society updated successfully",
+		"data":    society,
+	})
+}
+
+// ListSocieties lists societies with filtering and pagination
+func (h *PropertyChiHandler) ListSocieties(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.ListSocieties")
+	defer span.End()
+
+	query := r.URL.Query()
+	filters := domain.SocietyFilters{}
+
+	if location := query.Get("location"); location != "" {
+		filters.Location = &location
+	}
+
+	if isActive := query.Get("is_active"); isActive != "" {
+		if active, err := strconv.ParseBool(isActive); err == nil {
+			filters.IsActive = &active
+		}
+	}
+
+	if search := query.Get("search"); search != "" {
+		filters.Search = &search
+	}
+
+	if err := parseListParams(r, &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	societies, err := h.propertyService.ListSocieties(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Failed to list societies", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(societies)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": societies,
+		"pagination": map[string]interface{}{
+			"count":       len(societies),
+			"limit":       filters.Limit,
+			"offset":      filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, societies),
+		},
+	})
+}
+
+// GetSocietyProjects lists the projects of a society
+func (h *PropertyChiHandler) GetSocietyProjects(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.GetSocietyProjects")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid society ID", http.StatusBadRequest)
+		return
+	}
+
+	projects, err := h.propertyService.GetProjectsBySociety(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("society.id", id.String()),
+		attribute.Int("result.count", len(projects)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": projects,
+	})
+}
+
+// CreateProject creates a new project
+func (h *PropertyChiHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.CreateProject")
+	defer span.End()
+
+	var req domain.CreateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.CreatedBy = user.ID
+
+	project, err := h.propertyService.CreateProject(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("project.id", project.ID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Project created successfully",
+		"data":    project,
+	})
+}
+
+// GetProject retrieves a project by ID
+func (h *PropertyChiHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.GetProject")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	project, err := h.propertyService.GetProject(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("project.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": project,
+	})
+}
+
+// UpdateProject updates an existing project
+func (h *PropertyChiHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.UpdateProject")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.UpdateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	project, err := h.propertyService.UpdateProject(ctx, id, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("project.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Project updated successfully",
+		"data":    project,
+	})
+}
+
+// ListProjects lists projects with filtering and pagination
+func (h *PropertyChiHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.ListProjects")
+	defer span.End()
+
+	query := r.URL.Query()
+	filters := domain.ProjectFilters{}
+
+	if societyID := query.Get("society_id"); societyID != "" {
+		if id, err := uuid.Parse(societyID); err == nil {
+			filters.SocietyID = &id
+		}
+	}
+
+	if projectType := query.Get("project_type"); projectType != "" {
+		filters.ProjectType = &projectType
+	}
+
+	if status := query.Get("status"); status != "" {
+		projectStatus := domain.ProjectStatus(status)
+		filters.Status = &projectStatus
+	}
+
+	filters.PriceMin = queryFloat(r, "price_min")
+	filters.PriceMax = queryFloat(r, "price_max")
+
+	if search := query.Get("search"); search != "" {
+		filters.Search = &search
+	}
+
+	if err := parseListParams(r, &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	projects, err := h.propertyService.ListProjects(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Failed to list projects", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(projects)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": projects,
+		"pagination": map[string]interface{}{
+			"count":       len(projects),
+			"limit":       filters.Limit,
+			"offset":      filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, projects),
+		},
+	})
+}
+
+// GetAvailableUnits lists the available units of a project
+func (h *PropertyChiHandler) GetAvailableUnits(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.GetAvailableUnits")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	units, err := h.propertyService.GetAvailableUnits(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("project.id", id.String()),
+		attribute.Int("result.count", len(units)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": units,
+	})
+}
+
+// availabilityHeartbeat is how often an idle availability stream sends a
+// comment so proxies keep the connection open
+const availabilityHeartbeat = 15 * time.Second
+
+// GetAvailabilityMatrix returns the stacking plan of a project
+func (h *PropertyChiHandler) GetAvailabilityMatrix(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.GetAvailabilityMatrix")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	matrix, err := h.propertyService.GetAvailabilityMatrix(ctx, id, availabilityFilters(r))
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("project.id", id.String()),
+		attribute.Int("result.count", matrix.TotalUnits),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": matrix,
+	})
+}
+
+// StreamAvailabilityMatrix streams a project's stacking plan as
+// server-sent events: a "matrix" event with the whole plan, then a "unit"
+// event for every unit that changes. A "reset" event asks the client to
+// reconnect, after which it gets the whole plan again.
+func (h *PropertyChiHandler) StreamAvailabilityMatrix(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.StreamAvailabilityMatrix")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+	filters := availabilityFilters(r)
+
+	// Watch before reading the matrix so no change falls between the two
+	changes, err := h.propertyService.WatchAvailability(ctx, id, filters)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+	matrix, err := h.propertyService.GetAvailabilityMatrix(ctx, id, filters)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("project.id", id.String()))
+
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeEvent(w, rc, "matrix", matrix); err != nil {
+		span.RecordError(err)
+		return
+	}
+
+	heartbeat := time.NewTicker(availabilityHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case change, ok := <-changes:
+			if !ok {
+				if ctx.Err() == nil {
+					writeEvent(w, rc, "reset", map[string]string{"reason": "stream fell behind"})
+				}
+				return
+			}
+			if err := writeEvent(w, rc, "unit", change); err != nil {
+				span.RecordError(err)
+				return
+			}
+		}
+	}
+}
+
+// writeEvent writes a server-sent event with a JSON payload and flushes it
+func writeEvent(w io.Writer, rc *http.ResponseController, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
+
+// availabilityFilters reads the stacking plan filters of a request
+func availabilityFilters(r *http.Request) domain.AvailabilityFilters {
+	query := r.URL.Query()
+	filters := domain.AvailabilityFilters{}
+
+	if unitType := query.Get("unit_type"); unitType != "" {
+		filters.UnitType = &unitType
+	}
+
+	if facing := query.Get("facing"); facing != "" {
+		filters.Facing = &facing
+	}
+
+	return filters
+}
+
+// CreateInventory adds a unit to a project
+func (h *PropertyChiHandler) CreateInventory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.CreateInventory")
+	defer span.End()
+
+	var req domain.CreateInventoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.CreatedBy = user.ID
+
+	unit, err := h.propertyService.CreateInventory(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("inventory.id", unit.ID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Unit created successfully",
+		"data":    unit,
+	})
+}
+
+// GetInventory retrieves a unit by ID
+func (h *PropertyChiHandler) GetInventory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.GetInventory")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid inventory ID", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.propertyService.GetInventory(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": unit,
+	})
+}
+
+// UpdateInventory updates an existing unit
+func (h *PropertyChiHandler) UpdateInventory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.UpdateInventory")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid inventory ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.UpdateInventoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.propertyService.UpdateInventory(ctx, id, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Unit updated successfully",
+		"data":    unit,
+	})
+}
+
+// ListInventory lists units with filtering and pagination
+func (h *PropertyChiHandler) ListInventory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.ListInventory")
+	defer span.End()
+
+	query := r.URL.Query()
+	filters := domain.InventoryFilters{}
+
+	if projectID := query.Get("project_id"); projectID != "" {
+		if id, err := uuid.Parse(projectID); err == nil {
+			filters.ProjectID = &id
+		}
+	}
+
+	if unitType := query.Get("unit_type"); unitType != "" {
+		filters.UnitType = &unitType
+	}
+
+	if status := query.Get("status"); status != "" {
+		unitStatus := domain.UnitStatus(status)
+		filters.Status = &unitStatus
+	}
+
+	if facing := query.Get("facing"); facing != "" {
+		filters.Facing = &facing
+	}
+
+	if floor := query.Get("floor_number"); floor != "" {
+		if n, err := strconv.Atoi(floor); err == nil {
+			filters.FloorNumber = &n
+		}
+	}
+
+	filters.PriceMin = queryFloat(r, "price_min")
+	filters.PriceMax = queryFloat(r, "price_max")
+	filters.AreaMin = queryFloat(r, "area_min")
+	filters.AreaMax = queryFloat(r, "area_max")
+
+	if search := query.Get("search"); search != "" {
+		filters.Search = &search
+	}
+
+	if err := parseListParams(r, &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	units, err := h.propertyService.ListInventory(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Failed to list inventory", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(units)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": units,
+		"pagination": map[string]interface{}{
+			"count":       len(units),
+			"limit":       filters.Limit,
+			"offset":      filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, units),
+		},
+	})
+}
+
+// SearchProperties finds units matching a buyer's criteria
+func (h *PropertyChiHandler) SearchProperties(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.SearchProperties")
+	defer span.End()
+
+	var req domain.PropertySearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	units, err := h.propertyService.SearchProperties(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(units)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": units,
+	})
+}
+
+// reserveUnitRequest holds a unit for a client for a number of hours
+type reserveUnitRequest struct {
+	ClientID      uuid.UUID `json:"client_id"`
+	DurationHours int       `json:"duration_hours"`
+}
+
+// ReserveUnit reserves an available unit for a client
+func (h *PropertyChiHandler) ReserveUnit(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.ReserveUnit")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid inventory ID", http.StatusBadRequest)
+		return
+	}
+
+	var req reserveUnitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+	if req.ClientID == uuid.Nil {
+		http.Error(w, "client_id is required", http.StatusBadRequest)
+		return
+	}
+
+	duration := time.Duration(req.DurationHours) * time.Hour
+	if err := h.propertyService.ReserveUnit(ctx, id, req.ClientID, duration); err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("inventory.id", id.String()),
+		attribute.String("client.id", req.ClientID.String()),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Unit reserved successfully",
+	})
+}
+
+// ReleaseUnit returns a reserved unit to the available pool
+func (h *PropertyChiHandler) ReleaseUnit(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.ReleaseUnit")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid inventory ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.propertyService.ReleaseUnit(ctx, id); err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Unit released successfully",
+	})
+}
+
+// maxImportSize caps the size of an inventory import file
+const maxImportSize = 10 << 20
+
+// ImportInventory adds the units of a CSV or XLSX file to a project. The
+// file is the request body or the "file" field of a multipart form. With
+// dry_run=true the units are checked and priced but not saved.
+func (h *PropertyChiHandler) ImportInventory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.ImportInventory")
+	defer span.End()
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	req := domain.InventoryImportRequest{ProjectID: projectID}
+	if dryRun := r.URL.Query().Get("dry_run"); dryRun != "" {
+		if req.DryRun, err = strconv.ParseBool(dryRun); err != nil {
+			http.Error(w, "dry_run must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.CreatedBy = user.ID
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	data, err := readImportFile(r)
+	if err != nil {
+		span.RecordError(err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "Import file is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Invalid import file", http.StatusBadRequest)
+		return
+	}
+	if req.Rows, err = spreadsheet.Read(data); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Import file is not a readable CSV or XLSX file", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.propertyService.ImportInventory(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("project.id", projectID.String()),
+		attribute.Int("import.rows", result.Rows),
+		attribute.Int("import.errors", len(result.Errors)),
+	)
+
+	status, message := http.StatusCreated, "Inventory imported successfully"
+	switch {
+	case len(result.Errors) > 0:
+		status, message = http.StatusUnprocessableEntity, "Inventory import has errors; no units were created"
+	case result.DryRun:
+		status, message = http.StatusOK, "Inventory import checked; no units were created"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": message,
+		"data":    result,
+	})
+}
+
+// readImportFile returns the uploaded file of an import request
+func readImportFile(r *http.Request) ([]byte, error) {
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "multipart/form-data" {
+		return io.ReadAll(r.Body)
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// ListPriceLists lists every version of a project's price list, newest first
+func (h *PropertyChiHandler) ListPriceLists(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.ListPriceLists")
+	defer span.End()
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	priceLists, err := h.propertyService.ListPriceLists(ctx, projectID)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("project.id", projectID.String()),
+		attribute.Int("result.count", len(priceLists)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": priceLists,
+	})
+}
+
+// GetCurrentPriceList returns the version of a project's price list in
+// effect now, or at the time given by "at"
+func (h *PropertyChiHandler) GetCurrentPriceList(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.GetCurrentPriceList")
+	defer span.End()
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	at := time.Now()
+	if value := r.URL.Query().Get("at"); value != "" {
+		if at, err = parseReportTime(value); err != nil {
+			http.Error(w, "at must be an RFC 3339 time or a date", http.StatusBadRequest)
+			return
+		}
+	}
+
+	priceList, err := h.propertyService.GetEffectivePriceList(ctx, projectID, at)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("project.id", projectID.String()),
+		attribute.Int("price_list.version", priceList.Version),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": priceList,
+	})
+}
+
+// CreatePriceList adds the next version of a project's price list
+func (h *PropertyChiHandler) CreatePriceList(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.CreatePriceList")
+	defer span.End()
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.CreatePriceListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.CreatedBy = user.ID
+
+	priceList, err := h.propertyService.CreatePriceList(ctx, projectID, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("project.id", projectID.String()),
+		attribute.Int("price_list.version", priceList.Version),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Price list created successfully",
+		"data":    priceList,
+	})
+}
+
+// ApplyPriceList reprices a project's unsold units from a price list
+// version. With dry_run=true it only returns the changes it would make.
+func (h *PropertyChiHandler) ApplyPriceList(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.ApplyPriceList")
+	defer span.End()
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil || version <= 0 {
+		http.Error(w, "Invalid price list version", http.StatusBadRequest)
+		return
+	}
+
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, "dry_run must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	revision, err := h.propertyService.ApplyPriceList(ctx, projectID, version, dryRun)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("project.id", projectID.String()),
+		attribute.Int("price_list.version", version),
+		attribute.Int("price_revision.changes", len(revision.Changes)),
+	)
+
+	message := "Price list applied successfully"
+	if dryRun {
+		message = "Price list preview; no units were changed"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": message,
+		"data":    revision,
+	})
+}
+
+// maxRecommendations caps the limit of a recommendations request
+const maxRecommendations = 50
+
+// GetRecommendations suggests available units to a client, best first
+func (h *PropertyChiHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.GetRecommendations")
+	defer span.End()
+
+	clientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid client ID", http.StatusBadRequest)
+		return
+	}
+
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			http.Error(w, "limit must be a positive number", http.StatusBadRequest)
+			return
+		}
+		if limit > maxRecommendations {
+			limit = maxRecommendations
+		}
+	}
+
+	recommendations, err := h.propertyService.GetPropertyRecommendations(ctx, clientID, limit)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("client.id", clientID.String()),
+		attribute.Int("result.count", len(recommendations)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": recommendations,
+	})
+}
+
+// GetClientInterests lists the units a client viewed or shortlisted
+func (h *PropertyChiHandler) GetClientInterests(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.GetClientInterests")
+	defer span.End()
+
+	clientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid client ID", http.StatusBadRequest)
+		return
+	}
+
+	interests, err := h.propertyService.GetClientInterests(ctx, clientID)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("client.id", clientID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": interests,
+	})
+}
+
+// RecordInterest records that a client viewed or shortlisted a unit
+func (h *PropertyChiHandler) RecordInterest(w http.ResponseWriter, r *http.Request) {
+	ctx, span := propertyChiTracer.Start(r.Context(), "propertyHandler.RecordInterest")
+	defer span.End()
+
+	clientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid client ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.RecordInterestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.RecordedBy = user.ID
+
+	interest, err := h.propertyService.RecordInterest(ctx, clientID, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePropertyError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("client.id", clientID.String()),
+		attribute.String("inventory.id", interest.InventoryID.String()),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Interest recorded successfully",
+		"data":    interest,
+	})
+}
+
+// parseListParams reads limit, offset and cursor into filters
+func parseListParams(r *http.Request, filters *domain.BaseFilters) error {
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			filters.Limit = limit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filters.Offset = offset
+		}
+	}
+
+	if sortBy := r.URL.Query().Get("sort_by"); sortBy != "" {
+		filters.SortBy = sortBy
+		filters.SortOrder = r.URL.Query().Get("sort_order")
+	}
+
+	return parseCursor(r.URL.Query().Get("cursor"), filters)
+}
+
+// queryFloat returns a numeric query parameter, or nil when it is missing
+// or malformed
+func queryFloat(r *http.Request, name string) *float64 {
+	value, err := strconv.ParseFloat(r.URL.Query().Get(name), 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// writePropertyError maps a failed property change to a response
+func writePropertyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrAlreadyExists), errors.Is(err, domain.ErrConflict),
+		errors.Is(err, domain.ErrInventoryNotAvailable), errors.Is(err, domain.ErrInvalidTransition):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrValidationFailed):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, domain.ErrInvalidInput):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "Failed to process property request", http.StatusInternalServerError)
+	}
+}
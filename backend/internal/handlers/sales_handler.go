@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -36,6 +37,7 @@ func (h *SalesHandler) RegisterRoutes(router *gin.RouterGroup) {
 		sales.GET("/:id", h.GetSale)
 		sales.PUT("/:id", h.UpdateSale)
 		sales.DELETE("/:id", h.DeleteSale)
+		sales.POST("/:id/restore", h.RestoreSale)
 		sales.POST("/:id/approve", h.ApproveSale)
 		sales.POST("/:id/reject", h.RejectSale)
 		sales.GET("/:id/payments", h.GetSalePayments)
@@ -125,6 +127,7 @@ func (h *SalesHandler) GetSale(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("sale.id", id.String()))
 
+	c.Header("ETag", etag(sale.UpdatedAt))
 	c.JSON(http.StatusOK, gin.H{
 		"data": sale,
 	})
@@ -155,9 +158,16 @@ func (h *SalesHandler) UpdateSale(c *gin.Context) {
 		return
 	}
 
+	req.Version = ifMatch(c.GetHeader("If-Match"))
 	sale, err := h.salesService.Update(ctx, id, &req)
 	if err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrConflict) {
+			if current, getErr := h.salesService.GetByID(ctx, id); getErr == nil {
+				respondConflict(c, current, current.UpdatedAt)
+				return
+			}
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Failed to update sale",
 			"details": err.Error(),
@@ -167,13 +177,14 @@ func (h *SalesHandler) UpdateSale(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("sale.id", id.String()))
 
+	c.Header("ETag", etag(sale.UpdatedAt))
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Sale updated successfully",
 		"data": sale,
 	})
 }
 
-// DeleteSale deletes a sale
+// DeleteSale moves a sale to the trash
 func (h *SalesHandler) DeleteSale(c *gin.Context) {
 	ctx, span := salesTracer.Start(c.Request.Context(), "salesHandler.DeleteSale")
 	defer span.End()
@@ -188,8 +199,23 @@ func (h *SalesHandler) DeleteSale(c *gin.Context) {
 		return
 	}
 
-	if err := h.salesService.Delete(ctx, id); err != nil {
+	user, err := middleware.GetUserFromGinContext(c)
+	if err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return
+	}
+
+	if err := h.salesService.Delete(ctx, id, user.ID); err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Sale not found",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to delete sale",
 			"details": err.Error(),
@@ -204,6 +230,45 @@ func (h *SalesHandler) DeleteSale(c *gin.Context) {
 	})
 }
 
+// RestoreSale takes a sale out of the trash
+func (h *SalesHandler) RestoreSale(c *gin.Context) {
+	ctx, span := salesTracer.Start(c.Request.Context(), "salesHandler.RestoreSale")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid sale ID",
+		})
+		return
+	}
+
+	sale, err := h.salesService.Restore(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Sale not found in trash",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to restore sale",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(attribute.String("sale.id", id.String()))
+
+	c.Header("ETag", etag(sale.UpdatedAt))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sale restored successfully",
+		"data":    sale,
+	})
+}
+
 // ListSales lists sales with filtering and pagination
 func (h *SalesHandler) ListSales(c *gin.Context) {
 	ctx, span := salesTracer.Start(c.Request.Context(), "salesHandler.ListSales")
@@ -233,6 +298,12 @@ func (h *SalesHandler) ListSales(c *gin.Context) {
 		filters.Search = &search
 	}
 
+	if includeDeleted := c.Query("include_deleted"); includeDeleted != "" {
+		if include, err := strconv.ParseBool(includeDeleted); err == nil {
+			filters.IncludeDeleted = include
+		}
+	}
+
 	// Parse pagination
 	if limitStr := c.Query("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
@@ -246,6 +317,14 @@ func (h *SalesHandler) ListSales(c *gin.Context) {
 		}
 	}
 
+	if err := parseCursor(c.Query("cursor"), &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid cursor",
+		})
+		return
+	}
+
 	// Get sales
 	sales, err := h.salesService.List(ctx, filters)
 	if err != nil {
@@ -273,6 +352,7 @@ func (h *SalesHandler) ListSales(c *gin.Context) {
 			"count": len(sales),
 			"limit": filters.Limit,
 			"offset": filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, sales),
 		},
 	})
 }
@@ -372,7 +452,7 @@ func (h *SalesHandler) RejectSale(c *gin.Context) {
 		return
 	}
 
-	if err := h.salesService.CancelSale(ctx, saleID, req.Reason); err != nil {
+	if err := h.salesService.CancelSale(ctx, saleID, user.ID, req.Reason); err != nil {
 		span.RecordError(err)
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Failed to reject sale",
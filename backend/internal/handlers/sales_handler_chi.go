@@ -0,0 +1,777 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var salesChiTracer = otel.Tracer("goreal-backend/handlers/sales")
+
+// SalesChiHandler handles sales-related HTTP requests using Chi router
+type SalesChiHandler struct {
+	salesService domain.SalesService
+}
+
+// NewSalesChiHandler creates a new sales handler
+func NewSalesChiHandler(salesService domain.SalesService) *SalesChiHandler {
+	return &SalesChiHandler{
+		salesService: salesService,
+	}
+}
+
+// Routes registers sales routes. Sales carry client details and
+// financials, so they are for employees and above.
+func (h *SalesChiHandler) Routes(r chi.Router) {
+	r.Use(middleware.EmployeeOrAbove())
+
+	r.Get("/", h.ListSales)
+	r.Post("/", h.CreateSale)
+	r.Get("/agreement-templates", h.ListAgreementTemplates)
+	r.With(middleware.ManagerOrAbove()).Post("/agreement-templates", h.SaveAgreementTemplate)
+	r.Get("/{id}", h.GetSale)
+	r.Put("/{id}", h.UpdateSale)
+	r.Delete("/{id}", h.DeleteSale)
+	r.Post("/{id}/restore", h.RestoreSale)
+	r.Post("/{id}/transition", h.TransitionSale)
+	r.Get("/{id}/history", h.GetSaleHistory)
+	r.Get("/{id}/agreement", h.GenerateAgreement)
+	r.Get("/{id}/agreements/{agreementID}", h.GetAgreement)
+	r.Get("/{id}/payments", h.GetPaymentSchedule)
+	r.With(middleware.ManagerOrAbove()).Post("/{id}/payments", h.CreatePaymentSchedule)
+	r.With(middleware.ManagerOrAbove()).Post("/{id}/payments/reschedule", h.ReschedulePayments)
+	r.Get("/{id}/payments/revisions", h.GetPaymentScheduleRevisions)
+}
+
+// CreateSale creates a new sale, sold by the current user unless another
+// salesperson is named
+func (h *SalesChiHandler) CreateSale(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.CreateSale")
+	defer span.End()
+
+	var req domain.CreateSaleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if req.SalespersonID == nil {
+		req.SalespersonID = &user.ID
+	}
+
+	sale, err := h.salesService.Create(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("sale.id", sale.ID.String()),
+		attribute.Float64("sale.amount", sale.TotalAmount),
+	)
+
+	w.Header().Set("ETag", etag(sale.UpdatedAt))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Sale created successfully",
+		"data":    sale,
+	})
+}
+
+// GetSale retrieves a sale by ID
+func (h *SalesChiHandler) GetSale(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.GetSale")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	sale, err := h.salesService.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Sale not found", http.StatusNotFound)
+		return
+	}
+
+	span.SetAttributes(attribute.String("sale.id", id.String()))
+
+	w.Header().Set("ETag", etag(sale.UpdatedAt))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": sale,
+	})
+}
+
+// UpdateSale updates an existing sale
+func (h *SalesChiHandler) UpdateSale(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.UpdateSale")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.UpdateSaleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	req.Version = ifMatch(r.Header.Get("If-Match"))
+	sale, err := h.salesService.Update(ctx, id, &req)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, domain.ErrConflict):
+			if current, getErr := h.salesService.GetByID(ctx, id); getErr == nil {
+				writeConflict(w, current, current.UpdatedAt)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, domain.ErrNotFound):
+			http.Error(w, "Sale not found", http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	span.SetAttributes(attribute.String("sale.id", id.String()))
+
+	w.Header().Set("ETag", etag(sale.UpdatedAt))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Sale updated successfully",
+		"data":    sale,
+	})
+}
+
+// DeleteSale moves a sale to the trash
+func (h *SalesChiHandler) DeleteSale(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.DeleteSale")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.salesService.Delete(ctx, id, user.ID); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Sale not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.String("sale.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Sale deleted successfully",
+	})
+}
+
+// RestoreSale takes a sale out of the trash
+func (h *SalesChiHandler) RestoreSale(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.RestoreSale")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	sale, err := h.salesService.Restore(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Sale not found in trash", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.String("sale.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(sale.UpdatedAt))
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Sale restored successfully",
+		"data":    sale,
+	})
+}
+
+// ListSales lists sales with filtering and keyset or offset pagination
+func (h *SalesChiHandler) ListSales(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.ListSales")
+	defer span.End()
+
+	// Parse query parameters
+	filters := domain.SaleFilters{}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		saleStatus := domain.SaleStatus(status)
+		filters.Status = &saleStatus
+	}
+
+	if clientID := r.URL.Query().Get("client_id"); clientID != "" {
+		if id, err := uuid.Parse(clientID); err == nil {
+			filters.ClientID = &id
+		}
+	}
+
+	if salespersonID := r.URL.Query().Get("salesperson_id"); salespersonID != "" {
+		if id, err := uuid.Parse(salespersonID); err == nil {
+			filters.SalespersonID = &id
+		}
+	}
+
+	if search := r.URL.Query().Get("search"); search != "" {
+		filters.Search = &search
+	}
+
+	if includeDeleted := r.URL.Query().Get("include_deleted"); includeDeleted != "" {
+		if include, err := strconv.ParseBool(includeDeleted); err == nil {
+			filters.IncludeDeleted = include
+		}
+	}
+
+	// Parse pagination
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			filters.Limit = limit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filters.Offset = offset
+		}
+	}
+
+	if err := parseCursor(r.URL.Query().Get("cursor"), &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	}
+
+	sales, err := h.salesService.List(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	total, err := h.salesService.Count(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.Int("sales.count", len(sales)),
+		attribute.Int("sales.total", total),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": sales,
+		"pagination": map[string]interface{}{
+			"total":       total,
+			"count":       len(sales),
+			"limit":       filters.Limit,
+			"offset":      filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, sales),
+		},
+	})
+}
+
+// TransitionSale moves a sale to another status on behalf of the current user
+func (h *SalesChiHandler) TransitionSale(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.TransitionSale")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.SaleTransitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.ChangedBy = user.ID
+
+	sale, err := h.salesService.TransitionSale(ctx, id, &req)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			http.Error(w, "Sale not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, domain.ErrInvalidTransition), errors.Is(err, domain.ErrConflict),
+			errors.Is(err, domain.ErrInventoryNotAvailable):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("sale.id", id.String()),
+		attribute.String("sale.status", string(sale.Status)),
+	)
+
+	w.Header().Set("ETag", etag(sale.UpdatedAt))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Sale status updated successfully",
+		"data":    sale,
+	})
+}
+
+// GetSaleHistory lists the status changes of a sale, oldest first
+func (h *SalesChiHandler) GetSaleHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.GetSaleHistory")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.salesService.GetStatusHistory(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Sale not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to get sale history", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("sale.id", id.String()),
+		attribute.Int("result.count", len(history)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": history,
+	})
+}
+
+// GenerateAgreement renders the sale agreement as PDF or DOCX (?format=)
+// and returns the file
+func (h *SalesChiHandler) GenerateAgreement(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.GenerateAgreement")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	format, err := domain.ParseAgreementFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Format must be pdf or docx", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	agreement, err := h.salesService.GenerateAgreement(ctx, id, &domain.GenerateAgreementRequest{
+		Format:      format,
+		GeneratedBy: user.ID,
+	})
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			http.Error(w, "Sale not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidInput):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, domain.ErrConflict):
+			http.Error(w, "Sale was modified while generating the agreement, retry", http.StatusConflict)
+		default:
+			http.Error(w, "Failed to generate agreement", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("sale.id", id.String()),
+		attribute.String("agreement.id", agreement.ID.String()),
+	)
+
+	writeAgreement(w, agreement)
+}
+
+// GetAgreement downloads a previously generated agreement of a sale
+func (h *SalesChiHandler) GetAgreement(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.GetAgreement")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+	agreementID, err := uuid.Parse(chi.URLParam(r, "agreementID"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid agreement ID", http.StatusBadRequest)
+		return
+	}
+
+	agreement, err := h.salesService.GetAgreement(ctx, id, agreementID)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Agreement not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to get agreement", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.String("agreement.id", agreementID.String()))
+
+	writeAgreement(w, agreement)
+}
+
+func writeAgreement(w http.ResponseWriter, agreement *domain.Agreement) {
+	w.Header().Set("Content-Type", agreement.Format.ContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": agreement.FileName}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(agreement.Content)))
+	w.Header().Set("ETag", `"`+agreement.Checksum+`"`)
+	w.Header().Set("X-Agreement-ID", agreement.ID.String())
+	w.Header().Set("X-Template-Version", strconv.Itoa(agreement.TemplateVersion))
+	w.Write(agreement.Content)
+}
+
+// ListAgreementTemplates lists the versions of the organization's default
+// agreement template, or of a project's override (?project_id=)
+func (h *SalesChiHandler) ListAgreementTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.ListAgreementTemplates")
+	defer span.End()
+
+	var projectID *uuid.UUID
+	if raw := r.URL.Query().Get("project_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			span.RecordError(err)
+			http.Error(w, "Invalid project ID", http.StatusBadRequest)
+			return
+		}
+		projectID = &id
+	}
+
+	templates, err := h.salesService.ListAgreementTemplates(ctx, projectID)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Failed to list agreement templates", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(templates)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": templates,
+	})
+}
+
+// SaveAgreementTemplate adds a new agreement template version
+func (h *SalesChiHandler) SaveAgreementTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.SaveAgreementTemplate")
+	defer span.End()
+
+	var req domain.SaveAgreementTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.CreatedBy = user.ID
+
+	template, err := h.salesService.SaveAgreementTemplate(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			http.Error(w, "Project not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrAlreadyExists):
+			http.Error(w, "Template was saved concurrently, retry", http.StatusConflict)
+		case errors.Is(err, domain.ErrInvalidInput):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Failed to save agreement template", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	span.SetAttributes(attribute.Int("template.version", template.Version))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Agreement template saved successfully",
+		"data":    template,
+	})
+}
+
+// GetPaymentSchedule lists the installments of a sale in order
+func (h *SalesChiHandler) GetPaymentSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.GetPaymentSchedule")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	schedule, err := h.salesService.GetPaymentSchedule(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Sale not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to get payment schedule", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("sale.id", id.String()),
+		attribute.Int("result.count", len(schedule)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": schedule,
+	})
+}
+
+// CreatePaymentSchedule sets up the payment schedule of a sale from
+// installments or a payment plan
+func (h *SalesChiHandler) CreatePaymentSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.CreatePaymentSchedule")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.CreatePaymentScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.CreatedBy = user.ID
+
+	schedule, err := h.salesService.CreatePaymentSchedule(ctx, id, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePaymentScheduleError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("sale.id", id.String()),
+		attribute.Int("result.count", len(schedule)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Payment schedule created successfully",
+		"data":    schedule,
+	})
+}
+
+// ReschedulePayments replaces the unpaid installments of a sale
+func (h *SalesChiHandler) ReschedulePayments(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.ReschedulePayments")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.ReschedulePaymentsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+	req.CreatedBy = user.ID
+
+	schedule, err := h.salesService.ReschedulePayments(ctx, id, &req)
+	if err != nil {
+		span.RecordError(err)
+		writePaymentScheduleError(w, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("sale.id", id.String()),
+		attribute.Int("result.count", len(schedule)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Payments rescheduled successfully",
+		"data":    schedule,
+	})
+}
+
+// GetPaymentScheduleRevisions lists the changes to a sale's payment
+// schedule, oldest first
+func (h *SalesChiHandler) GetPaymentScheduleRevisions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := salesChiTracer.Start(r.Context(), "salesHandler.GetPaymentScheduleRevisions")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid sale ID", http.StatusBadRequest)
+		return
+	}
+
+	revisions, err := h.salesService.GetPaymentScheduleRevisions(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Sale not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to get payment schedule revisions", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("sale.id", id.String()),
+		attribute.Int("result.count", len(revisions)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": revisions,
+	})
+}
+
+// writePaymentScheduleError maps a failed schedule change to a response
+func writePaymentScheduleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrAlreadyExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrValidationFailed):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, domain.ErrInvalidInput):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "Failed to schedule payments", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var searchTracer = otel.Tracer("goreal-backend/handlers/search")
+
+// SearchHandler handles search requests using Chi router
+type SearchHandler struct {
+	searchService domain.SearchService
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(searchService domain.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// Routes registers search routes
+func (h *SearchHandler) Routes(r chi.Router) {
+	r.Get("/", h.Search)
+}
+
+// Search searches leads, clients, companies and inventory. The types
+// parameter is a comma separated list limiting the entity types searched.
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx, span := searchTracer.Start(r.Context(), "searchHandler.Search")
+	defer span.End()
+
+	query := domain.SearchQuery{
+		Text: r.URL.Query().Get("q"),
+	}
+
+	if types := r.URL.Query().Get("types"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				query.Types = append(query.Types, domain.SearchEntityType(t))
+			}
+		}
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			query.Limit = limit
+		}
+	}
+
+	results, err := h.searchService.Search(ctx, query)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to search", http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("search.hits", len(results.Hits)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": results,
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -36,6 +37,7 @@ func (h *TaskHandler) RegisterRoutes(router *gin.RouterGroup) {
 		tasks.GET("/:id", h.GetTask)
 		tasks.PUT("/:id", h.UpdateTask)
 		tasks.DELETE("/:id", h.DeleteTask)
+		tasks.POST("/:id/restore", h.RestoreTask)
 		tasks.POST("/:id/complete", h.CompleteTask)
 		tasks.GET("/assigned/:user_id", h.GetTasksByUser)
 		tasks.GET("/overdue", h.GetOverdueTasks)
@@ -119,6 +121,7 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("task.id", id.String()))
 
+	c.Header("ETag", etag(task.UpdatedAt))
 	c.JSON(http.StatusOK, gin.H{
 		"data": task,
 	})
@@ -149,9 +152,16 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 
+	req.Version = ifMatch(c.GetHeader("If-Match"))
 	task, err := h.taskService.Update(ctx, id, &req)
 	if err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrConflict) {
+			if current, getErr := h.taskService.GetByID(ctx, id); getErr == nil {
+				respondConflict(c, current, current.UpdatedAt)
+				return
+			}
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Failed to update task",
 			"details": err.Error(),
@@ -161,13 +171,14 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("task.id", id.String()))
 
+	c.Header("ETag", etag(task.UpdatedAt))
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Task updated successfully",
 		"data": task,
 	})
 }
 
-// DeleteTask deletes a task
+// DeleteTask moves a task to the trash
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	ctx, span := taskTracer.Start(c.Request.Context(), "taskHandler.DeleteTask")
 	defer span.End()
@@ -182,8 +193,23 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 		return
 	}
 
-	if err := h.taskService.Delete(ctx, id); err != nil {
+	user, err := middleware.GetUserFromGinContext(c)
+	if err != nil {
 		span.RecordError(err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+		})
+		return
+	}
+
+	if err := h.taskService.Delete(ctx, id, user.ID); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Task not found",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to delete task",
 			"details": err.Error(),
@@ -198,6 +224,45 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	})
 }
 
+// RestoreTask takes a task out of the trash
+func (h *TaskHandler) RestoreTask(c *gin.Context) {
+	ctx, span := taskTracer.Start(c.Request.Context(), "taskHandler.RestoreTask")
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid task ID",
+		})
+		return
+	}
+
+	task, err := h.taskService.Restore(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Task not found in trash",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to restore task",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(attribute.String("task.id", id.String()))
+
+	c.Header("ETag", etag(task.UpdatedAt))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Task restored successfully",
+		"data":    task,
+	})
+}
+
 // ListTasks lists tasks with filtering and pagination
 func (h *TaskHandler) ListTasks(c *gin.Context) {
 	ctx, span := taskTracer.Start(c.Request.Context(), "taskHandler.ListTasks")
@@ -242,6 +307,12 @@ func (h *TaskHandler) ListTasks(c *gin.Context) {
 		filters.Search = &search
 	}
 
+	if includeDeleted := c.Query("include_deleted"); includeDeleted != "" {
+		if include, err := strconv.ParseBool(includeDeleted); err == nil {
+			filters.IncludeDeleted = include
+		}
+	}
+
 	// Parse pagination
 	if limitStr := c.Query("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
@@ -255,6 +326,14 @@ func (h *TaskHandler) ListTasks(c *gin.Context) {
 		}
 	}
 
+	if err := parseCursor(c.Query("cursor"), &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid cursor",
+		})
+		return
+	}
+
 	// Get tasks
 	tasks, err := h.taskService.List(ctx, filters)
 	if err != nil {
@@ -274,6 +353,7 @@ func (h *TaskHandler) ListTasks(c *gin.Context) {
 			"count": len(tasks),
 			"limit": filters.Limit,
 			"offset": filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, tasks),
 		},
 	})
 }
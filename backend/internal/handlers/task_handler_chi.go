@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"goreal-backend/internal/domain"
+	"goreal-backend/internal/middleware"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -34,6 +36,7 @@ func (h *TaskChiHandler) Routes(r chi.Router) {
 	r.Get("/{id}", h.GetTask)
 	r.Put("/{id}", h.UpdateTask)
 	r.Delete("/{id}", h.DeleteTask)
+	r.Post("/{id}/restore", h.RestoreTask)
 	r.Post("/{id}/complete", h.CompleteTask)
 	r.Get("/assigned/{userID}", h.GetTasksByUser)
 	r.Get("/overdue", h.GetOverdueTasks)
@@ -94,6 +97,7 @@ func (h *TaskChiHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 
 	span.SetAttributes(attribute.String("task.id", id.String()))
 
+	w.Header().Set("ETag", etag(task.UpdatedAt))
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"data": task,
@@ -120,15 +124,23 @@ func (h *TaskChiHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	req.Version = ifMatch(r.Header.Get("If-Match"))
 	task, err := h.taskService.Update(ctx, id, &req)
 	if err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrConflict) {
+			if current, getErr := h.taskService.GetByID(ctx, id); getErr == nil {
+				writeConflict(w, current, current.UpdatedAt)
+				return
+			}
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	span.SetAttributes(attribute.String("task.id", id.String()))
 
+	w.Header().Set("ETag", etag(task.UpdatedAt))
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"message": "Task updated successfully",
@@ -136,7 +148,7 @@ func (h *TaskChiHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// DeleteTask deletes a task
+// DeleteTask moves a task to the trash
 func (h *TaskChiHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	ctx, span := taskChiTracer.Start(r.Context(), "taskHandler.DeleteTask")
 	defer span.End()
@@ -149,8 +161,19 @@ func (h *TaskChiHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.taskService.Delete(ctx, id); err != nil {
+	user, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.taskService.Delete(ctx, id, user.ID); err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Task not found", http.StatusNotFound)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -163,6 +186,39 @@ func (h *TaskChiHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RestoreTask takes a task out of the trash
+func (h *TaskChiHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	ctx, span := taskChiTracer.Start(r.Context(), "taskHandler.RestoreTask")
+	defer span.End()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.taskService.Restore(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "Task not found in trash", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	span.SetAttributes(attribute.String("task.id", id.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(task.UpdatedAt))
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Task restored successfully",
+		"data":    task,
+	})
+}
+
 // ListTasks lists tasks with filtering and pagination
 func (h *TaskChiHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 	ctx, span := taskChiTracer.Start(r.Context(), "taskHandler.ListTasks")
@@ -197,6 +253,12 @@ func (h *TaskChiHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 		filters.Search = &search
 	}
 
+	if includeDeleted := r.URL.Query().Get("include_deleted"); includeDeleted != "" {
+		if include, err := strconv.ParseBool(includeDeleted); err == nil {
+			filters.IncludeDeleted = include
+		}
+	}
+
 	// Parse pagination
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
@@ -210,6 +272,12 @@ func (h *TaskChiHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if err := parseCursor(r.URL.Query().Get("cursor"), &filters.BaseFilters); err != nil {
+		span.RecordError(err)
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	}
+
 	// Get tasks
 	tasks, err := h.taskService.List(ctx, filters)
 	if err != nil {
@@ -224,9 +292,10 @@ func (h *TaskChiHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"data": tasks,
 		"pagination": map[string]interface{}{
-			"count":  len(tasks),
-			"limit":  filters.Limit,
-			"offset": filters.Offset,
+			"count":       len(tasks),
+			"limit":       filters.Limit,
+			"offset":      filters.Offset,
+			"next_cursor": nextCursor(filters.BaseFilters, tasks),
 		},
 	})
 }
@@ -0,0 +1,64 @@
+// Package geocoding provides the geocoders the application can be
+// configured with
+package geocoding
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/pkg/geo"
+)
+
+// OfflineGeocoder geocodes addresses from a local gazetteer of place
+// names. It needs no network access, so it resolves towns and districts
+// rather than street addresses.
+type OfflineGeocoder struct {
+	gazetteer *geo.Gazetteer
+}
+
+// NewOfflineGeocoder creates a geocoder that knows the principal Indian
+// cities, together with the places in the gazetteer CSV file at path, if
+// one is given
+func NewOfflineGeocoder(path string) (*OfflineGeocoder, error) {
+	gazetteer := geo.NewGazetteer(geo.IndianCities...)
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gazetteer: %w", err)
+		}
+		defer f.Close()
+		if err := gazetteer.Load(f); err != nil {
+			return nil, fmt.Errorf("failed to load gazetteer %s: %w", path, err)
+		}
+	}
+	return &OfflineGeocoder{gazetteer: gazetteer}, nil
+}
+
+// Geocode returns the position of the most specific place named in the
+// address
+func (g *OfflineGeocoder) Geocode(ctx context.Context, address string) (*domain.GeoPoint, error) {
+	if strings.TrimSpace(address) == "" {
+		return nil, fmt.Errorf("address is empty: %w", domain.ErrInvalidInput)
+	}
+	point, ok := g.gazetteer.Lookup(address)
+	if !ok {
+		return nil, fmt.Errorf("no place known for %q: %w", address, domain.ErrNotFound)
+	}
+	return &domain.GeoPoint{Latitude: point.Lat, Longitude: point.Lng}, nil
+}
+
+// New creates the geocoder named by provider: "offline", or "none" for no
+// geocoding at all, in which case it returns nil
+func New(provider, gazetteerPath string) (domain.Geocoder, error) {
+	switch provider {
+	case "offline", "":
+		return NewOfflineGeocoder(gazetteerPath)
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown GEOCODER_PROVIDER %q", provider)
+	}
+}
@@ -0,0 +1,136 @@
+// Package mail provides the mailers the application can be configured with
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/config"
+	"goreal-backend/internal/domain"
+)
+
+// FileMailer writes every message to a directory as an .eml file instead
+// of sending it. It stands in for a mail server in development and tests.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer creates a mailer that writes messages into dir, creating
+// it if needed
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail outbox: %w", err)
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+// Send writes the message to a new file named after the time it was sent
+func (m *FileMailer) Send(ctx context.Context, msg *domain.EmailMessage) error {
+	data, err := compose(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("failed to name message: %w", err)
+	}
+	name := time.Now().UTC().Format("20060102T150405.000000000") + "-" + hex.EncodeToString(suffix) + ".eml"
+	if err := os.WriteFile(filepath.Join(m.dir, name), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
+
+// SMTPMailer sends messages through an SMTP server, such as a local
+// capture server like Mailpit in development
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer creates a mailer for the SMTP server at addr (host:port).
+// Credentials are optional.
+func NewSMTPMailer(addr, username, password, from string) (*SMTPMailer, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %q: %w", addr, err)
+	}
+	m := &SMTPMailer{addr: addr, from: from}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m, nil
+}
+
+// Send delivers the message to the SMTP server
+func (m *SMTPMailer) Send(ctx context.Context, msg *domain.EmailMessage) error {
+	data, err := compose(m.from, msg)
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(m.addr, m.auth, address(m.from), []string{msg.To}, data); err != nil {
+		return fmt.Errorf("failed to send mail to %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+// New creates the mailer named by cfg.MailProvider: "file", "smtp", or
+// "none" for no mail at all, in which case it returns nil
+func New(cfg *config.Config) (domain.Mailer, error) {
+	switch cfg.MailProvider {
+	case "file", "":
+		dir := cfg.MailOutboxDir
+		if dir == "" {
+			dir = filepath.Join(os.TempDir(), "goreal-mail")
+		}
+		return NewFileMailer(dir, cfg.MailFrom)
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown MAIL_PROVIDER %q", cfg.MailProvider)
+	}
+}
+
+// compose renders a message in RFC 5322 form
+func compose(from string, msg *domain.EmailMessage) ([]byte, error) {
+	for _, header := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(header, "\r\n") {
+			return nil, fmt.Errorf("mail header contains a line break: %w", domain.ErrInvalidInput)
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return b.Bytes(), nil
+}
+
+// address returns the bare address of a "Name <address>" sender
+func address(from string) string {
+	if parsed, err := netmail.ParseAddress(from); err == nil {
+		return parsed.Address
+	}
+	return from
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var agreementSortKeys = sortKeys[domain.Agreement]{
+	"created_at": func(a *domain.Agreement) interface{} { return a.CreatedAt },
+}
+
+// agreementRepository implements domain.AgreementRepository in memory
+type agreementRepository struct {
+	store *Store
+}
+
+// NewAgreementRepository creates a new agreement repository
+func NewAgreementRepository(store *Store) domain.AgreementRepository {
+	return &agreementRepository{
+		store: store,
+	}
+}
+
+// Create stores a generated agreement
+func (r *agreementRepository) Create(ctx context.Context, agreement *domain.Agreement) error {
+	if agreement.CreatedAt.IsZero() {
+		agreement.CreatedAt = time.Now()
+	}
+
+	if err := domain.AssignOrganization(ctx, &agreement.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.agreements.insert(agreement, nil); err != nil {
+		return fmt.Errorf("failed to create agreement: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an agreement with its content
+func (r *agreementRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Agreement, error) {
+	agreement, err := r.store.agreements.get(id)
+	if err == nil && !domain.InOrganization(ctx, agreement.OrganizationID) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agreement by ID: %w", err)
+	}
+	return agreement, nil
+}
+
+// GetLatest returns the most recently generated agreement of a sale in a format
+func (r *agreementRepository) GetLatest(ctx context.Context, saleID uuid.UUID, format domain.AgreementFormat) (*domain.Agreement, error) {
+	agreements := r.store.agreements.find(func(a *domain.Agreement) bool {
+		return a.SaleID == saleID && a.Format == format && domain.InOrganization(ctx, a.OrganizationID)
+	}, agreementSortKeys, order{key: "created_at", desc: true})
+	if len(agreements) == 0 {
+		return nil, fmt.Errorf("failed to get latest agreement: %w", domain.ErrNotFound)
+	}
+	return agreements[0], nil
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var agreementTemplateSortKeys = sortKeys[domain.AgreementTemplate]{
+	"version": func(t *domain.AgreementTemplate) interface{} { return t.Version },
+}
+
+// agreementTemplateRepository implements domain.AgreementTemplateRepository in memory
+type agreementTemplateRepository struct {
+	store *Store
+}
+
+// NewAgreementTemplateRepository creates a new agreement template repository
+func NewAgreementTemplateRepository(store *Store) domain.AgreementTemplateRepository {
+	return &agreementTemplateRepository{
+		store: store,
+	}
+}
+
+// Create stores a template version; versions are unique per organization
+// and project
+func (r *agreementTemplateRepository) Create(ctx context.Context, template *domain.AgreementTemplate) error {
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = time.Now()
+	}
+
+	if err := domain.AssignOrganization(ctx, &template.OrganizationID); err != nil {
+		return err
+	}
+	conflict := func(t *domain.AgreementTemplate) bool {
+		return t.Version == template.Version && sameOptionalID(t.ProjectID, template.ProjectID) &&
+			sameOptionalID(t.OrganizationID, template.OrganizationID)
+	}
+	if err := r.store.agreementTemplates.insert(template, conflict); err != nil {
+		return fmt.Errorf("failed to create agreement template: %w", err)
+	}
+	return nil
+}
+
+// GetLatest returns the newest version of a project's template, or of the
+// organization default when projectID is nil
+func (r *agreementTemplateRepository) GetLatest(ctx context.Context, projectID *uuid.UUID) (*domain.AgreementTemplate, error) {
+	versions, _ := r.ListVersions(ctx, projectID)
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("failed to get agreement template: %w", domain.ErrNotFound)
+	}
+	return versions[0], nil
+}
+
+// ListVersions returns every version of a project's template, newest first
+func (r *agreementTemplateRepository) ListVersions(ctx context.Context, projectID *uuid.UUID) ([]*domain.AgreementTemplate, error) {
+	return r.store.agreementTemplates.find(func(t *domain.AgreementTemplate) bool {
+		return sameOptionalID(t.ProjectID, projectID) && domain.InOrganization(ctx, t.OrganizationID)
+	}, agreementTemplateSortKeys, order{key: "version", desc: true}), nil
+}
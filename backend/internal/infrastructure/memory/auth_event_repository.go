@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var authEventSortKeys = sortKeys[domain.AuthEvent]{
+	"created_at": func(e *domain.AuthEvent) interface{} { return e.CreatedAt },
+}
+
+// authEventRepository implements domain.AuthEventRepository in memory
+type authEventRepository struct {
+	store *Store
+}
+
+// NewAuthEventRepository creates a new auth event repository
+func NewAuthEventRepository(store *Store) domain.AuthEventRepository {
+	return &authEventRepository{
+		store: store,
+	}
+}
+
+// Create records an event
+func (r *authEventRepository) Create(ctx context.Context, event *domain.AuthEvent) error {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	if err := r.store.authEvents.insert(event, nil); err != nil {
+		return fmt.Errorf("failed to create auth event: %w", err)
+	}
+	return nil
+}
+
+// FailuresFromAddress counts the failed logins from an IP address since a time
+func (r *authEventRepository) FailuresFromAddress(ctx context.Context, ipAddress string, since time.Time) (int, *time.Time, error) {
+	failures := r.store.authEvents.find(func(e *domain.AuthEvent) bool {
+		return e.Type == domain.AuthEventLoginFailed && e.IPAddress != nil && *e.IPAddress == ipAddress &&
+			!e.CreatedAt.Before(since)
+	}, authEventSortKeys, newestFirst)
+
+	if len(failures) == 0 {
+		return 0, nil, nil
+	}
+	latest := failures[0].CreatedAt
+	return len(failures), &latest, nil
+}
+
+// ListByUser returns the latest events of a user, newest first
+func (r *authEventRepository) ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.AuthEvent, error) {
+	events := r.store.authEvents.find(func(e *domain.AuthEvent) bool {
+		return e.UserID != nil && *e.UserID == userID
+	}, authEventSortKeys, newestFirst)
+
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
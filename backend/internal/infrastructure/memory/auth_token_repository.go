@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// authTokenRepository implements domain.AuthTokenRepository in memory
+type authTokenRepository struct {
+	store *Store
+}
+
+// NewAuthTokenRepository creates a new auth token repository
+func NewAuthTokenRepository(store *Store) domain.AuthTokenRepository {
+	return &authTokenRepository{
+		store: store,
+	}
+}
+
+// Create stores a new token
+func (r *authTokenRepository) Create(ctx context.Context, token *domain.AuthToken) error {
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+
+	err := r.store.authTokens.insert(token, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create auth token: %w", err)
+	}
+	return nil
+}
+
+// Consume redeems an unused, unexpired token
+func (r *authTokenRepository) Consume(ctx context.Context, purpose domain.TokenPurpose, tokenHash string, at time.Time) (*domain.AuthToken, error) {
+	usable := func(t *domain.AuthToken) bool {
+		return t.Purpose == purpose && t.TokenHash == tokenHash && t.UsedAt == nil && at.Before(t.ExpiresAt)
+	}
+
+	token, err := r.store.authTokens.first(usable)
+	if err == nil {
+		// Checked again under the table's lock so that a token redeemed
+		// concurrently is only redeemed once
+		err = r.store.authTokens.modify(token.ID, func(t *domain.AuthToken) error {
+			if !usable(t) {
+				return domain.ErrNotFound
+			}
+			t.UsedAt = &at
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume auth token: %w", err)
+	}
+
+	token.UsedAt = &at
+	return token, nil
+}
+
+// InvalidateByUser marks a user's outstanding tokens of a purpose as used
+func (r *authTokenRepository) InvalidateByUser(ctx context.Context, userID uuid.UUID, purpose domain.TokenPurpose, at time.Time) (int, error) {
+	return r.store.authTokens.modifyWhere(func(t *domain.AuthToken) bool {
+		return t.UserID == userID && t.Purpose == purpose && t.UsedAt == nil
+	}, func(t *domain.AuthToken) {
+		t.UsedAt = &at
+	}), nil
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var blockchainTransactionSortKeys = sortKeys[domain.BlockchainTransaction]{
+	"block_number": func(t *domain.BlockchainTransaction) interface{} { return t.BlockNumber },
+	"status":       func(t *domain.BlockchainTransaction) interface{} { return t.Status },
+	"confirmed_at": func(t *domain.BlockchainTransaction) interface{} { return t.ConfirmedAt },
+	"created_at":   func(t *domain.BlockchainTransaction) interface{} { return t.CreatedAt },
+}
+
+// blockchainTransactionRepository implements domain.BlockchainTransactionRepository in memory
+type blockchainTransactionRepository struct {
+	store *Store
+}
+
+// NewBlockchainTransactionRepository creates a new blockchain transaction repository
+func NewBlockchainTransactionRepository(store *Store) domain.BlockchainTransactionRepository {
+	return &blockchainTransactionRepository{
+		store: store,
+	}
+}
+
+// Create stores a new transaction; hashes must be unique
+func (r *blockchainTransactionRepository) Create(ctx context.Context, tx *domain.BlockchainTransaction) error {
+	tx.CreatedAt = time.Now()
+
+	err := r.store.blockchainTransactions.insert(tx, func(t *domain.BlockchainTransaction) bool {
+		return t.TransactionHash == tx.TransactionHash
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create blockchain transaction: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a transaction by ID
+func (r *blockchainTransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.BlockchainTransaction, error) {
+	tx, err := r.store.blockchainTransactions.get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockchain transaction by ID: %w", err)
+	}
+	return tx, nil
+}
+
+// GetByHash retrieves a transaction by its hash
+func (r *blockchainTransactionRepository) GetByHash(ctx context.Context, hash string) (*domain.BlockchainTransaction, error) {
+	tx, err := r.store.blockchainTransactions.first(func(t *domain.BlockchainTransaction) bool {
+		return t.TransactionHash == hash
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockchain transaction by hash: %w", err)
+	}
+	return tx, nil
+}
+
+// Update updates an existing transaction
+func (r *blockchainTransactionRepository) Update(ctx context.Context, tx *domain.BlockchainTransaction) error {
+	if err := r.store.blockchainTransactions.replace(tx, nil); err != nil {
+		return fmt.Errorf("failed to update blockchain transaction: %w", err)
+	}
+	return nil
+}
+
+// List retrieves transactions with pagination and filtering
+func (r *blockchainTransactionRepository) List(ctx context.Context, filters domain.BlockchainTransactionFilters) ([]*domain.BlockchainTransaction, error) {
+	return r.store.blockchainTransactions.list(blockchainTransactionMatch(filters), filters.BaseFilters,
+		blockchainTransactionSortKeys, newestFirst), nil
+}
+
+// GetPendingTransactions retrieves unconfirmed transactions, oldest first
+func (r *blockchainTransactionRepository) GetPendingTransactions(ctx context.Context) ([]*domain.BlockchainTransaction, error) {
+	return r.store.blockchainTransactions.find(func(t *domain.BlockchainTransaction) bool {
+		return t.Status == domain.TransactionStatusPending
+	}, blockchainTransactionSortKeys, order{key: "created_at"}), nil
+}
+
+func blockchainTransactionMatch(filters domain.BlockchainTransactionFilters) func(*domain.BlockchainTransaction) bool {
+	return func(t *domain.BlockchainTransaction) bool {
+		if filters.FromAddress != nil && t.FromAddress != *filters.FromAddress {
+			return false
+		}
+		if filters.ToAddress != nil && t.ToAddress != *filters.ToAddress {
+			return false
+		}
+		if filters.TransactionType != nil && t.TransactionType != *filters.TransactionType {
+			return false
+		}
+		if filters.Status != nil && t.Status != *filters.Status {
+			return false
+		}
+		if filters.RelatedTable != nil && (t.RelatedTable == nil || *t.RelatedTable != *filters.RelatedTable) {
+			return false
+		}
+		if filters.RelatedID != nil && !sameID(t.RelatedID, *filters.RelatedID) {
+			return false
+		}
+		return filters.BlockNumber == nil || (t.BlockNumber != nil && *t.BlockNumber == *filters.BlockNumber)
+	}
+}
@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var cashbookSortKeys = sortKeys[domain.Cashbook]{
+	"transaction_number": func(c *domain.Cashbook) interface{} { return c.TransactionNumber },
+	"transaction_date":   func(c *domain.Cashbook) interface{} { return c.TransactionDate },
+	"transaction_type":   func(c *domain.Cashbook) interface{} { return c.TransactionType },
+	"amount":             func(c *domain.Cashbook) interface{} { return c.Amount },
+	"created_at":         func(c *domain.Cashbook) interface{} { return c.CreatedAt },
+	"updated_at":         func(c *domain.Cashbook) interface{} { return c.UpdatedAt },
+}
+
+// cashbookRepository implements domain.CashbookRepository in memory
+type cashbookRepository struct {
+	store *Store
+}
+
+// NewCashbookRepository creates a new cashbook repository
+func NewCashbookRepository(store *Store) domain.CashbookRepository {
+	return &cashbookRepository{
+		store: store,
+	}
+}
+
+// Create stores a new transaction
+func (r *cashbookRepository) Create(ctx context.Context, transaction *domain.Cashbook) error {
+	now := time.Now()
+	if transaction.CreatedAt.IsZero() {
+		transaction.CreatedAt = now
+	}
+	if transaction.UpdatedAt.IsZero() {
+		transaction.UpdatedAt = transaction.CreatedAt
+	}
+	if transaction.TransactionDate.IsZero() {
+		transaction.TransactionDate = now
+	}
+
+	if err := r.store.cashbook.insert(transaction, nil); err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a transaction by ID
+func (r *cashbookRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Cashbook, error) {
+	transaction, err := r.store.cashbook.get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction by ID: %w", err)
+	}
+	return transaction, nil
+}
+
+// Update updates an existing transaction
+func (r *cashbookRepository) Update(ctx context.Context, transaction *domain.Cashbook) error {
+	transaction.UpdatedAt = time.Now()
+
+	if err := r.store.cashbook.replace(transaction, nil); err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a transaction by ID
+func (r *cashbookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.store.cashbook.remove(id); err != nil {
+		return fmt.Errorf("failed to delete transaction: %w", err)
+	}
+	return nil
+}
+
+// List retrieves transactions with pagination and filtering
+func (r *cashbookRepository) List(ctx context.Context, filters domain.CashbookFilters) ([]*domain.Cashbook, error) {
+	return r.store.cashbook.list(cashbookMatch(filters), filters.BaseFilters, cashbookSortKeys,
+		order{key: "transaction_date", desc: true}), nil
+}
+
+// GetFinancialSummary aggregates the matching transactions together with
+// outstanding payment schedule balances
+func (r *cashbookRepository) GetFinancialSummary(ctx context.Context, filters domain.CashbookFilters) (*domain.FinancialSummary, error) {
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	yearStart := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+
+	summary := &domain.FinancialSummary{}
+	for _, tx := range r.store.cashbook.find(cashbookMatch(filters), nil, order{}) {
+		var total, monthly, yearly *float64
+		switch tx.TransactionType {
+		case domain.TransactionTypeIncome:
+			total, monthly, yearly = &summary.TotalIncome, &summary.MonthlyIncome, &summary.YearlyIncome
+		case domain.TransactionTypeExpense:
+			total, monthly, yearly = &summary.TotalExpense, &summary.MonthlyExpense, &summary.YearlyExpense
+		default:
+			continue
+		}
+
+		*total += tx.Amount
+		if !tx.TransactionDate.Before(monthStart) {
+			*monthly += tx.Amount
+		}
+		if !tx.TransactionDate.Before(yearStart) {
+			*yearly += tx.Amount
+		}
+	}
+	summary.NetProfit = summary.TotalIncome - summary.TotalExpense
+
+	for _, schedule := range r.store.paymentSchedules.find(nil, nil, order{}) {
+		outstanding := schedule.Amount - schedule.PaidAmount
+		switch {
+		case schedule.Status == domain.PaymentStatusOverdue,
+			schedule.Status == domain.PaymentStatusPending && schedule.DueDate.Before(now):
+			summary.OverduePayments += outstanding
+		case schedule.Status == domain.PaymentStatusPending:
+			summary.PendingPayments += outstanding
+		}
+	}
+
+	return summary, nil
+}
+
+func cashbookMatch(filters domain.CashbookFilters) func(*domain.Cashbook) bool {
+	return func(c *domain.Cashbook) bool {
+		if filters.TransactionType != nil && c.TransactionType != *filters.TransactionType {
+			return false
+		}
+		if filters.Category != nil && (c.Category == nil || *c.Category != *filters.Category) {
+			return false
+		}
+		if !inRange(&c.Amount, filters.AmountMin, filters.AmountMax) {
+			return false
+		}
+		if !inTimeRange(&c.TransactionDate, filters.DateFrom, filters.DateTo) {
+			return false
+		}
+		if filters.CreatedBy != nil && c.CreatedBy != *filters.CreatedBy {
+			return false
+		}
+		if filters.ApprovedBy != nil && !sameID(c.ApprovedBy, *filters.ApprovedBy) {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, c.TransactionNumber, c.Description, c.ReferenceNumber) {
+			return false
+		}
+		return true
+	}
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var challengeSortKeys = sortKeys[domain.Challenge]{
+	"title":                func(c *domain.Challenge) interface{} { return c.Title },
+	"status":               func(c *domain.Challenge) interface{} { return c.Status },
+	"start_date":           func(c *domain.Challenge) interface{} { return c.StartDate },
+	"end_date":             func(c *domain.Challenge) interface{} { return c.EndDate },
+	"reward_amount":        func(c *domain.Challenge) interface{} { return c.RewardAmount },
+	"current_participants": func(c *domain.Challenge) interface{} { return c.CurrentParticipants },
+	"created_at":           func(c *domain.Challenge) interface{} { return c.CreatedAt },
+	"updated_at":           func(c *domain.Challenge) interface{} { return c.UpdatedAt },
+}
+
+// challengeRepository implements domain.ChallengeRepository in memory
+type challengeRepository struct {
+	store *Store
+}
+
+// NewChallengeRepository creates a new challenge repository
+func NewChallengeRepository(store *Store) domain.ChallengeRepository {
+	return &challengeRepository{
+		store: store,
+	}
+}
+
+// Create stores a new challenge
+func (r *challengeRepository) Create(ctx context.Context, challenge *domain.Challenge) error {
+	now := time.Now()
+	if challenge.CreatedAt.IsZero() {
+		challenge.CreatedAt = now
+	}
+	if challenge.UpdatedAt.IsZero() {
+		challenge.UpdatedAt = challenge.CreatedAt
+	}
+
+	if err := r.store.challenges.insert(challenge, nil); err != nil {
+		return fmt.Errorf("failed to create challenge: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a challenge by ID
+func (r *challengeRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Challenge, error) {
+	challenge, err := r.store.challenges.get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get challenge by ID: %w", err)
+	}
+	return challenge, nil
+}
+
+// GetByCreator retrieves the challenges created by a user
+func (r *challengeRepository) GetByCreator(ctx context.Context, creatorID uuid.UUID) ([]*domain.Challenge, error) {
+	return r.store.challenges.find(func(c *domain.Challenge) bool {
+		return c.CreatorID == creatorID
+	}, challengeSortKeys, newestFirst), nil
+}
+
+// Update updates an existing challenge
+func (r *challengeRepository) Update(ctx context.Context, challenge *domain.Challenge) error {
+	challenge.UpdatedAt = time.Now()
+
+	if err := r.store.challenges.replace(challenge, nil); err != nil {
+		return fmt.Errorf("failed to update challenge: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a challenge by ID
+func (r *challengeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.store.challenges.remove(id); err != nil {
+		return fmt.Errorf("failed to delete challenge: %w", err)
+	}
+	return nil
+}
+
+// List retrieves challenges with pagination and filtering
+func (r *challengeRepository) List(ctx context.Context, filters domain.ChallengeFilters) ([]*domain.Challenge, error) {
+	return r.store.challenges.list(challengeMatch(filters), filters.BaseFilters, challengeSortKeys, newestFirst), nil
+}
+
+// Count returns the total number of challenges matching the filters
+func (r *challengeRepository) Count(ctx context.Context, filters domain.ChallengeFilters) (int, error) {
+	return r.store.challenges.count(challengeMatch(filters)), nil
+}
+
+func challengeMatch(filters domain.ChallengeFilters) func(*domain.Challenge) bool {
+	return func(c *domain.Challenge) bool {
+		if filters.CreatorID != nil && c.CreatorID != *filters.CreatorID {
+			return false
+		}
+		if filters.Status != nil && string(c.Status) != *filters.Status {
+			return false
+		}
+		if filters.RewardType != nil && string(c.RewardType) != *filters.RewardType {
+			return false
+		}
+		if !inTimeRange(c.StartDate, filters.StartAfter, filters.StartBefore) {
+			return false
+		}
+		if !inTimeRange(c.EndDate, filters.EndAfter, filters.EndBefore) {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, c.Title, c.Description) {
+			return false
+		}
+		return containsAll(c.Tags, filters.Tags)
+	}
+}
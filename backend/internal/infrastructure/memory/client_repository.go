@@ -0,0 +1,206 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var clientSortKeys = sortKeys[domain.Client]{
+	"name":         func(c *domain.Client) interface{} { return c.Name },
+	"email":        func(c *domain.Client) interface{} { return c.Email },
+	"client_type":  func(c *domain.Client) interface{} { return c.ClientType },
+	"is_verified":  func(c *domain.Client) interface{} { return c.IsVerified },
+	"credit_limit": func(c *domain.Client) interface{} { return c.CreditLimit },
+	"created_at":   func(c *domain.Client) interface{} { return c.CreatedAt },
+	"updated_at":   func(c *domain.Client) interface{} { return c.UpdatedAt },
+}
+
+// clientRepository implements domain.ClientRepository in memory
+type clientRepository struct {
+	store *Store
+}
+
+// NewClientRepository creates a new client repository
+func NewClientRepository(store *Store) domain.ClientRepository {
+	return &clientRepository{
+		store: store,
+	}
+}
+
+// Create stores a new client
+func (r *clientRepository) Create(ctx context.Context, client *domain.Client) error {
+	now := time.Now()
+	if client.CreatedAt.IsZero() {
+		client.CreatedAt = now
+	}
+	if client.UpdatedAt.IsZero() {
+		client.UpdatedAt = client.CreatedAt
+	}
+
+	if err := domain.AssignOrganization(ctx, &client.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.clients.insert(client, nil); err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a client by ID
+func (r *clientRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Client, error) {
+	client, err := r.store.clients.get(id)
+	if err == nil && (client.DeletedAt != nil || !domain.InOrganization(ctx, client.OrganizationID)) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client by ID: %w", err)
+	}
+	return client, nil
+}
+
+// GetByEmail retrieves a client by email
+func (r *clientRepository) GetByEmail(ctx context.Context, email string) (*domain.Client, error) {
+	client, err := r.store.clients.first(func(c *domain.Client) bool {
+		return c.DeletedAt == nil && domain.InOrganization(ctx, c.OrganizationID) &&
+			c.Email != nil && *c.Email == email
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client by email: %w", err)
+	}
+	return client, nil
+}
+
+// Update updates an existing client
+func (r *clientRepository) Update(ctx context.Context, client *domain.Client) error {
+	since := client.UpdatedAt
+	client.UpdatedAt = time.Now()
+
+	if err := domain.AssignOrganization(ctx, &client.OrganizationID); err != nil {
+		return err
+	}
+	check := func(stored *domain.Client) error {
+		if stored.DeletedAt != nil || !domain.InOrganization(ctx, stored.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		if !stored.UpdatedAt.Equal(since) {
+			return domain.ErrConflict
+		}
+		return nil
+	}
+	if err := r.store.clients.replaceIf(client, check, nil); err != nil {
+		client.UpdatedAt = since
+		return fmt.Errorf("failed to update client: %w", err)
+	}
+	return nil
+}
+
+// Delete moves a client to the trash
+func (r *clientRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	err := r.store.clients.modify(id, func(c *domain.Client) error {
+		if c.DeletedAt != nil || !domain.InOrganization(ctx, c.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		now := time.Now()
+		c.DeletedAt, c.DeletedBy, c.UpdatedAt = &now, &deletedBy, now
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete client: %w", err)
+	}
+	return nil
+}
+
+// Restore takes a client out of the trash
+func (r *clientRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	err := r.store.clients.modify(id, func(c *domain.Client) error {
+		if c.DeletedAt == nil || !domain.InOrganization(ctx, c.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		c.DeletedAt, c.DeletedBy, c.UpdatedAt = nil, nil, time.Now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore client: %w", err)
+	}
+	return nil
+}
+
+// Purge permanently removes clients that were deleted before the cutoff
+func (r *clientRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return r.store.clients.removeWhere(func(c *domain.Client) bool {
+		return c.DeletedAt != nil && c.DeletedAt.Before(deletedBefore) && domain.InOrganization(ctx, c.OrganizationID)
+	}), nil
+}
+
+// List retrieves clients with pagination and filtering
+func (r *clientRepository) List(ctx context.Context, filters domain.ClientFilters) ([]*domain.Client, error) {
+	return r.store.clients.list(clientMatch(ctx, filters), filters.BaseFilters, clientSortKeys, newestFirst), nil
+}
+
+// Count returns the total number of clients matching the filters
+func (r *clientRepository) Count(ctx context.Context, filters domain.ClientFilters) (int, error) {
+	return r.store.clients.count(clientMatch(ctx, filters)), nil
+}
+
+// GetByAssignedUser retrieves clients assigned to a user
+func (r *clientRepository) GetByAssignedUser(ctx context.Context, userID uuid.UUID) ([]*domain.Client, error) {
+	return r.store.clients.find(func(c *domain.Client) bool {
+		return c.DeletedAt == nil && domain.InOrganization(ctx, c.OrganizationID) &&
+			sameID(c.AssignedTo, userID)
+	}, clientSortKeys, newestFirst), nil
+}
+
+// UpdateVerificationStatus updates the verification status of a client
+func (r *clientRepository) UpdateVerificationStatus(ctx context.Context, id uuid.UUID, isVerified bool) error {
+	err := r.store.clients.modify(id, func(c *domain.Client) error {
+		if !domain.InOrganization(ctx, c.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		c.IsVerified = isVerified
+		c.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update client verification status: %w", err)
+	}
+	return nil
+}
+
+func clientMatch(ctx context.Context, filters domain.ClientFilters) func(*domain.Client) bool {
+	return func(c *domain.Client) bool {
+		if c.DeletedAt != nil && !filters.IncludeDeleted {
+			return false
+		}
+		if !domain.InOrganization(ctx, c.OrganizationID) {
+			return false
+		}
+		if filters.ClientType != nil && c.ClientType != *filters.ClientType {
+			return false
+		}
+		if filters.AssignedTo != nil && !sameID(c.AssignedTo, *filters.AssignedTo) {
+			return false
+		}
+		if filters.CreatedBy != nil && c.CreatedBy != *filters.CreatedBy {
+			return false
+		}
+		if filters.CompanyID != nil && !sameID(c.CompanyID, *filters.CompanyID) {
+			return false
+		}
+		if filters.IsVerified != nil && c.IsVerified != *filters.IsVerified {
+			return false
+		}
+		if !inTimeRange(&c.CreatedAt, filters.CreatedAfter, filters.CreatedBefore) {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, c.Name, c.Email, c.Phone) {
+			return false
+		}
+		return containsAll(c.Tags, filters.Tags)
+	}
+}
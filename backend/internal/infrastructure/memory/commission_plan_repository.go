@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var commissionPlanSortKeys = sortKeys[domain.CommissionPlan]{
+	"created_at": func(p *domain.CommissionPlan) interface{} { return p.CreatedAt },
+}
+
+// commissionPlanRepository implements domain.CommissionPlanRepository in memory
+type commissionPlanRepository struct {
+	store *Store
+}
+
+// NewCommissionPlanRepository creates a new commission plan repository
+func NewCommissionPlanRepository(store *Store) domain.CommissionPlanRepository {
+	return &commissionPlanRepository{
+		store: store,
+	}
+}
+
+// Create stores a new commission plan
+func (r *commissionPlanRepository) Create(ctx context.Context, plan *domain.CommissionPlan) error {
+	if plan.CreatedAt.IsZero() {
+		plan.CreatedAt = time.Now()
+	}
+
+	if err := domain.AssignOrganization(ctx, &plan.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.commissionPlans.insert(plan, nil); err != nil {
+		return fmt.Errorf("failed to create commission plan: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a commission plan by ID
+func (r *commissionPlanRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CommissionPlan, error) {
+	plan, err := r.store.commissionPlans.get(id)
+	if err == nil && !domain.InOrganization(ctx, plan.OrganizationID) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commission plan by ID: %w", err)
+	}
+	return plan, nil
+}
+
+// GetLatest returns the plan in effect: the most recently created one
+func (r *commissionPlanRepository) GetLatest(ctx context.Context) (*domain.CommissionPlan, error) {
+	plans, _ := r.List(ctx)
+	if len(plans) == 0 {
+		return nil, fmt.Errorf("failed to get latest commission plan: %w", domain.ErrNotFound)
+	}
+	return plans[0], nil
+}
+
+// List returns the commission plans, newest first
+func (r *commissionPlanRepository) List(ctx context.Context) ([]*domain.CommissionPlan, error) {
+	return r.store.commissionPlans.find(func(p *domain.CommissionPlan) bool {
+		return domain.InOrganization(ctx, p.OrganizationID)
+	}, commissionPlanSortKeys, order{key: "created_at", desc: true}), nil
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var commissionSortKeys = sortKeys[domain.Commission]{
+	"commission_type":   func(c *domain.Commission) interface{} { return c.CommissionType },
+	"commission_amount": func(c *domain.Commission) interface{} { return c.CommissionAmount },
+	"status":            func(c *domain.Commission) interface{} { return c.Status },
+	"paid_date":         func(c *domain.Commission) interface{} { return c.PaidDate },
+	"created_at":        func(c *domain.Commission) interface{} { return c.CreatedAt },
+	"updated_at":        func(c *domain.Commission) interface{} { return c.UpdatedAt },
+}
+
+// commissionRepository implements domain.CommissionRepository in memory
+type commissionRepository struct {
+	store *Store
+}
+
+// NewCommissionRepository creates a new commission repository
+func NewCommissionRepository(store *Store) domain.CommissionRepository {
+	return &commissionRepository{
+		store: store,
+	}
+}
+
+// Create stores a new commission
+func (r *commissionRepository) Create(ctx context.Context, commission *domain.Commission) error {
+	now := time.Now()
+	if commission.CreatedAt.IsZero() {
+		commission.CreatedAt = now
+	}
+	if commission.UpdatedAt.IsZero() {
+		commission.UpdatedAt = commission.CreatedAt
+	}
+
+	if err := domain.AssignOrganization(ctx, &commission.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.commissions.insert(commission, nil); err != nil {
+		return fmt.Errorf("failed to create commission: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a commission by ID
+func (r *commissionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Commission, error) {
+	commission, err := r.store.commissions.get(id)
+	if err == nil && !domain.InOrganization(ctx, commission.OrganizationID) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commission by ID: %w", err)
+	}
+	return commission, nil
+}
+
+// GetByEmployee retrieves the commissions earned by an employee
+func (r *commissionRepository) GetByEmployee(ctx context.Context, employeeID uuid.UUID) ([]*domain.Commission, error) {
+	return r.store.commissions.find(func(c *domain.Commission) bool {
+		return sameID(c.EmployeeID, employeeID) && domain.InOrganization(ctx, c.OrganizationID)
+	}, commissionSortKeys, newestFirst), nil
+}
+
+// GetBySale retrieves the commissions for a sale
+func (r *commissionRepository) GetBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.Commission, error) {
+	return r.store.commissions.find(func(c *domain.Commission) bool {
+		return c.SaleID == saleID && domain.InOrganization(ctx, c.OrganizationID)
+	}, commissionSortKeys, newestFirst), nil
+}
+
+// Update updates an existing commission
+func (r *commissionRepository) Update(ctx context.Context, commission *domain.Commission) error {
+	since := commission.UpdatedAt
+	commission.UpdatedAt = time.Now()
+
+	if err := domain.AssignOrganization(ctx, &commission.OrganizationID); err != nil {
+		return err
+	}
+	check := func(stored *domain.Commission) error {
+		if !domain.InOrganization(ctx, stored.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		return nil
+	}
+	if err := r.store.commissions.replaceIf(commission, check, nil); err != nil {
+		commission.UpdatedAt = since
+		return fmt.Errorf("failed to update commission: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a commission by ID
+func (r *commissionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	removed := r.store.commissions.removeWhere(func(c *domain.Commission) bool {
+		return c.ID == id && domain.InOrganization(ctx, c.OrganizationID)
+	})
+	if removed == 0 {
+		return fmt.Errorf("failed to delete commission: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// List retrieves commissions with pagination and filtering
+func (r *commissionRepository) List(ctx context.Context, filters domain.CommissionFilters) ([]*domain.Commission, error) {
+	return r.store.commissions.list(commissionMatch(ctx, filters), filters.BaseFilters, commissionSortKeys, newestFirst), nil
+}
+
+func commissionMatch(ctx context.Context, filters domain.CommissionFilters) func(*domain.Commission) bool {
+	return func(c *domain.Commission) bool {
+		if !domain.InOrganization(ctx, c.OrganizationID) {
+			return false
+		}
+		if filters.SaleID != nil && c.SaleID != *filters.SaleID {
+			return false
+		}
+		if filters.EmployeeID != nil && !sameID(c.EmployeeID, *filters.EmployeeID) {
+			return false
+		}
+		if filters.CommissionType != nil && c.CommissionType != *filters.CommissionType {
+			return false
+		}
+		if filters.Status != nil && c.Status != *filters.Status {
+			return false
+		}
+		if !inTimeRange(&c.CreatedAt, filters.CreatedFrom, filters.CreatedTo) {
+			return false
+		}
+		return inRange(&c.CommissionAmount, filters.AmountMin, filters.AmountMax)
+	}
+}
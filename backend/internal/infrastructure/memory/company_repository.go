@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var companySortKeys = sortKeys[domain.Company]{
+	"name":       func(c *domain.Company) interface{} { return c.Name },
+	"industry":   func(c *domain.Company) interface{} { return c.Industry },
+	"created_at": func(c *domain.Company) interface{} { return c.CreatedAt },
+	"updated_at": func(c *domain.Company) interface{} { return c.UpdatedAt },
+}
+
+// companyRepository implements domain.CompanyRepository in memory
+type companyRepository struct {
+	store *Store
+}
+
+// NewCompanyRepository creates a new company repository
+func NewCompanyRepository(store *Store) domain.CompanyRepository {
+	return &companyRepository{
+		store: store,
+	}
+}
+
+// Create stores a new company
+func (r *companyRepository) Create(ctx context.Context, company *domain.Company) error {
+	now := time.Now()
+	if company.CreatedAt.IsZero() {
+		company.CreatedAt = now
+	}
+	if company.UpdatedAt.IsZero() {
+		company.UpdatedAt = company.CreatedAt
+	}
+
+	if err := domain.AssignOrganization(ctx, &company.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.companies.insert(company, nil); err != nil {
+		return fmt.Errorf("failed to create company: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a company by ID
+func (r *companyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Company, error) {
+	company, err := r.store.companies.get(id)
+	if err == nil && !domain.InOrganization(ctx, company.OrganizationID) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company by ID: %w", err)
+	}
+	return company, nil
+}
+
+// GetByName retrieves a company by name
+func (r *companyRepository) GetByName(ctx context.Context, name string) (*domain.Company, error) {
+	company, err := r.store.companies.first(func(c *domain.Company) bool {
+		return c.Name == name && domain.InOrganization(ctx, c.OrganizationID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company by name: %w", err)
+	}
+	return company, nil
+}
+
+// Update updates an existing company
+func (r *companyRepository) Update(ctx context.Context, company *domain.Company) error {
+	since := company.UpdatedAt
+	company.UpdatedAt = time.Now()
+
+	if err := domain.AssignOrganization(ctx, &company.OrganizationID); err != nil {
+		return err
+	}
+	check := func(stored *domain.Company) error {
+		if !domain.InOrganization(ctx, stored.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		return nil
+	}
+	if err := r.store.companies.replaceIf(company, check, nil); err != nil {
+		company.UpdatedAt = since
+		return fmt.Errorf("failed to update company: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a company by ID
+func (r *companyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	removed := r.store.companies.removeWhere(func(c *domain.Company) bool {
+		return c.ID == id && domain.InOrganization(ctx, c.OrganizationID)
+	})
+	if removed == 0 {
+		return fmt.Errorf("failed to delete company: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// List retrieves companies with pagination and filtering
+func (r *companyRepository) List(ctx context.Context, filters domain.CompanyFilters) ([]*domain.Company, error) {
+	return r.store.companies.list(companyMatch(ctx, filters), filters.BaseFilters, companySortKeys, order{key: "name"}), nil
+}
+
+// Count returns the total number of companies matching the filters
+func (r *companyRepository) Count(ctx context.Context, filters domain.CompanyFilters) (int, error) {
+	return r.store.companies.count(companyMatch(ctx, filters)), nil
+}
+
+func companyMatch(ctx context.Context, filters domain.CompanyFilters) func(*domain.Company) bool {
+	return func(c *domain.Company) bool {
+		if !domain.InOrganization(ctx, c.OrganizationID) {
+			return false
+		}
+		if filters.Industry != nil && (c.Industry == nil || *c.Industry != *filters.Industry) {
+			return false
+		}
+		if filters.IsActive != nil && c.IsActive != *filters.IsActive {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, c.Name, c.Email, c.Website) {
+			return false
+		}
+		return true
+	}
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"testing"
+
+	"goreal-backend/internal/infrastructure/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) *repotest.Backend {
+		store := NewStore()
+		return &repotest.Backend{
+			Users:              NewUserRepository(store),
+			Sessions:           NewSessionRepository(store),
+			AuthTokens:         NewAuthTokenRepository(store),
+			MFA:                NewMFARepository(store),
+			AuthEvents:         NewAuthEventRepository(store),
+			Wallets:            NewWalletRepository(store),
+			SIWENonces:         NewSIWENonceRepository(store),
+			Leads:              NewLeadRepository(store),
+			Clients:            NewClientRepository(store),
+			Companies:          NewCompanyRepository(store),
+			Societies:          NewSocietyRepository(store),
+			Projects:           NewProjectRepository(store),
+			Inventory:          NewInventoryRepository(store),
+			Interests:          NewPropertyInterestRepository(store),
+			PriceLists:         NewPriceListRepository(store),
+			Sales:              NewSaleRepository(store),
+			SaleHistory:        NewSaleStatusHistoryRepository(store),
+			AgreementTemplates: NewAgreementTemplateRepository(store),
+			Agreements:         NewAgreementRepository(store),
+			PaymentSchedules:   NewPaymentScheduleRepository(store),
+			PaymentRevisions:   NewPaymentScheduleRevisionRepository(store),
+			Commissions:        NewCommissionRepository(store),
+			CommissionPlans:    NewCommissionPlanRepository(store),
+			Tasks:              NewTaskRepository(store),
+			Notifications:      NewNotificationRepository(store),
+			Organizations:      NewOrganizationRepository(store),
+			Search:             NewSearchRepository(store),
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var filmSortKeys = sortKeys[domain.Film]{
+	"title":      func(f *domain.Film) interface{} { return f.Title },
+	"rating":     func(f *domain.Film) interface{} { return f.Rating },
+	"view_count": func(f *domain.Film) interface{} { return f.ViewCount },
+	"duration":   func(f *domain.Film) interface{} { return f.Duration },
+	"created_at": func(f *domain.Film) interface{} { return f.CreatedAt },
+	"updated_at": func(f *domain.Film) interface{} { return f.UpdatedAt },
+}
+
+// filmRepository implements domain.FilmRepository in memory
+type filmRepository struct {
+	store *Store
+}
+
+// NewFilmRepository creates a new film repository
+func NewFilmRepository(store *Store) domain.FilmRepository {
+	return &filmRepository{
+		store: store,
+	}
+}
+
+// Create stores a new film
+func (r *filmRepository) Create(ctx context.Context, film *domain.Film) error {
+	now := time.Now()
+	if film.CreatedAt.IsZero() {
+		film.CreatedAt = now
+	}
+	if film.UpdatedAt.IsZero() {
+		film.UpdatedAt = film.CreatedAt
+	}
+
+	if err := r.store.films.insert(film, nil); err != nil {
+		return fmt.Errorf("failed to create film: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a film by ID
+func (r *filmRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Film, error) {
+	film, err := r.store.films.get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get film by ID: %w", err)
+	}
+	return film, nil
+}
+
+// GetByCreator retrieves the films uploaded by a user
+func (r *filmRepository) GetByCreator(ctx context.Context, creatorID uuid.UUID) ([]*domain.Film, error) {
+	return r.store.films.find(func(f *domain.Film) bool {
+		return f.CreatorID == creatorID
+	}, filmSortKeys, newestFirst), nil
+}
+
+// Update updates an existing film
+func (r *filmRepository) Update(ctx context.Context, film *domain.Film) error {
+	film.UpdatedAt = time.Now()
+
+	if err := r.store.films.replace(film, nil); err != nil {
+		return fmt.Errorf("failed to update film: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a film by ID
+func (r *filmRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.store.films.remove(id); err != nil {
+		return fmt.Errorf("failed to delete film: %w", err)
+	}
+	return nil
+}
+
+// List retrieves films with pagination and filtering
+func (r *filmRepository) List(ctx context.Context, filters domain.FilmFilters) ([]*domain.Film, error) {
+	return r.store.films.list(filmMatch(filters), filters.BaseFilters, filmSortKeys, newestFirst), nil
+}
+
+// IncrementViews atomically increments the view count of a film
+func (r *filmRepository) IncrementViews(ctx context.Context, id uuid.UUID) error {
+	err := r.store.films.modify(id, func(f *domain.Film) error {
+		f.ViewCount++
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to increment film views: %w", err)
+	}
+	return nil
+}
+
+func filmMatch(filters domain.FilmFilters) func(*domain.Film) bool {
+	return func(f *domain.Film) bool {
+		if filters.CreatorID != nil && f.CreatorID != *filters.CreatorID {
+			return false
+		}
+		if filters.Status != nil && string(f.Status) != *filters.Status {
+			return false
+		}
+		if filters.IsPublic != nil && f.IsPublic != *filters.IsPublic {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, f.Title, f.Description) {
+			return false
+		}
+		return containsAll(f.Genre, filters.Genre)
+	}
+}
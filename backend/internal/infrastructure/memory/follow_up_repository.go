@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var followUpSortKeys = sortKeys[domain.FollowUp]{
+	"follow_up_date": func(f *domain.FollowUp) interface{} { return f.FollowUpDate },
+	"follow_up_type": func(f *domain.FollowUp) interface{} { return f.FollowUpType },
+	"status":         func(f *domain.FollowUp) interface{} { return f.Status },
+	"created_at":     func(f *domain.FollowUp) interface{} { return f.CreatedAt },
+	"updated_at":     func(f *domain.FollowUp) interface{} { return f.UpdatedAt },
+}
+
+// followUpScheduled is the status of a follow-up that has not happened yet
+const followUpScheduled = "scheduled"
+
+// followUpRepository implements domain.FollowUpRepository in memory
+type followUpRepository struct {
+	store *Store
+}
+
+// NewFollowUpRepository creates a new follow-up repository
+func NewFollowUpRepository(store *Store) domain.FollowUpRepository {
+	return &followUpRepository{
+		store: store,
+	}
+}
+
+// Create stores a new follow-up
+func (r *followUpRepository) Create(ctx context.Context, followUp *domain.FollowUp) error {
+	now := time.Now()
+	if followUp.CreatedAt.IsZero() {
+		followUp.CreatedAt = now
+	}
+	if followUp.UpdatedAt.IsZero() {
+		followUp.UpdatedAt = followUp.CreatedAt
+	}
+
+	if err := r.store.followUps.insert(followUp, nil); err != nil {
+		return fmt.Errorf("failed to create follow-up: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a follow-up by ID
+func (r *followUpRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.FollowUp, error) {
+	followUp, err := r.store.followUps.get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow-up by ID: %w", err)
+	}
+	return followUp, nil
+}
+
+// GetByLead retrieves the follow-ups of a lead in date order
+func (r *followUpRepository) GetByLead(ctx context.Context, leadID uuid.UUID) ([]*domain.FollowUp, error) {
+	return r.store.followUps.find(func(f *domain.FollowUp) bool {
+		return sameID(f.LeadID, leadID)
+	}, followUpSortKeys, order{key: "follow_up_date"}), nil
+}
+
+// GetByClient retrieves the follow-ups of a client in date order
+func (r *followUpRepository) GetByClient(ctx context.Context, clientID uuid.UUID) ([]*domain.FollowUp, error) {
+	return r.store.followUps.find(func(f *domain.FollowUp) bool {
+		return sameID(f.ClientID, clientID)
+	}, followUpSortKeys, order{key: "follow_up_date"}), nil
+}
+
+// Update updates an existing follow-up
+func (r *followUpRepository) Update(ctx context.Context, followUp *domain.FollowUp) error {
+	followUp.UpdatedAt = time.Now()
+
+	if err := r.store.followUps.replace(followUp, nil); err != nil {
+		return fmt.Errorf("failed to update follow-up: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a follow-up by ID
+func (r *followUpRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.store.followUps.remove(id); err != nil {
+		return fmt.Errorf("failed to delete follow-up: %w", err)
+	}
+	return nil
+}
+
+// List retrieves follow-ups with pagination and filtering
+func (r *followUpRepository) List(ctx context.Context, filters domain.FollowUpFilters) ([]*domain.FollowUp, error) {
+	return r.store.followUps.list(followUpMatch(filters), filters.BaseFilters, followUpSortKeys,
+		order{key: "follow_up_date"}), nil
+}
+
+// GetUpcoming retrieves scheduled follow-ups for a user that are still ahead
+func (r *followUpRepository) GetUpcoming(ctx context.Context, userID uuid.UUID) ([]*domain.FollowUp, error) {
+	now := time.Now()
+	return r.store.followUps.find(func(f *domain.FollowUp) bool {
+		return sameID(f.AssignedTo, userID) && f.Status == followUpScheduled && !f.FollowUpDate.Before(now)
+	}, followUpSortKeys, order{key: "follow_up_date"}), nil
+}
+
+func followUpMatch(filters domain.FollowUpFilters) func(*domain.FollowUp) bool {
+	return func(f *domain.FollowUp) bool {
+		if filters.LeadID != nil && !sameID(f.LeadID, *filters.LeadID) {
+			return false
+		}
+		if filters.ClientID != nil && !sameID(f.ClientID, *filters.ClientID) {
+			return false
+		}
+		if filters.AssignedTo != nil && !sameID(f.AssignedTo, *filters.AssignedTo) {
+			return false
+		}
+		if filters.FollowUpType != nil && f.FollowUpType != *filters.FollowUpType {
+			return false
+		}
+		if filters.Status != nil && f.Status != *filters.Status {
+			return false
+		}
+		return inTimeRange(&f.FollowUpDate, filters.DateFrom, filters.DateTo)
+	}
+}
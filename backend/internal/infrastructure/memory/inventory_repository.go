@@ -0,0 +1,234 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var inventorySortKeys = sortKeys[domain.Inventory]{
+	"unit_number":    func(i *domain.Inventory) interface{} { return i.UnitNumber },
+	"floor_number":   func(i *domain.Inventory) interface{} { return i.FloorNumber },
+	"unit_type":      func(i *domain.Inventory) interface{} { return i.UnitType },
+	"status":         func(i *domain.Inventory) interface{} { return i.Status },
+	"carpet_area":    func(i *domain.Inventory) interface{} { return i.CarpetArea },
+	"base_price":     func(i *domain.Inventory) interface{} { return i.BasePrice },
+	"final_price":    func(i *domain.Inventory) interface{} { return i.FinalPrice },
+	"price_per_sqft": func(i *domain.Inventory) interface{} { return i.PricePerSqft },
+	"created_at":     func(i *domain.Inventory) interface{} { return i.CreatedAt },
+	"updated_at":     func(i *domain.Inventory) interface{} { return i.UpdatedAt },
+}
+
+// inventoryRepository implements domain.InventoryRepository in memory
+type inventoryRepository struct {
+	store *Store
+}
+
+// NewInventoryRepository creates a new inventory repository
+func NewInventoryRepository(store *Store) domain.InventoryRepository {
+	return &inventoryRepository{
+		store: store,
+	}
+}
+
+// Create stores a new inventory unit
+func (r *inventoryRepository) Create(ctx context.Context, inventory *domain.Inventory) error {
+	now := time.Now()
+	if inventory.CreatedAt.IsZero() {
+		inventory.CreatedAt = now
+	}
+	if inventory.UpdatedAt.IsZero() {
+		inventory.UpdatedAt = inventory.CreatedAt
+	}
+
+	if err := domain.AssignOrganization(ctx, &inventory.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.inventory.insert(inventory, nil); err != nil {
+		return fmt.Errorf("failed to create inventory: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an inventory unit by ID
+func (r *inventoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Inventory, error) {
+	inventory, err := r.store.inventory.get(id)
+	if err == nil && !domain.InOrganization(ctx, inventory.OrganizationID) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory by ID: %w", err)
+	}
+	return inventory, nil
+}
+
+// GetByProject retrieves the inventory of a project ordered by unit number
+func (r *inventoryRepository) GetByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Inventory, error) {
+	return r.store.inventory.find(func(i *domain.Inventory) bool {
+		return i.ProjectID == projectID && domain.InOrganization(ctx, i.OrganizationID)
+	}, inventorySortKeys, order{key: "unit_number"}), nil
+}
+
+// Update updates an existing inventory unit
+func (r *inventoryRepository) Update(ctx context.Context, inventory *domain.Inventory) error {
+	since := inventory.UpdatedAt
+	inventory.UpdatedAt = time.Now()
+
+	if err := domain.AssignOrganization(ctx, &inventory.OrganizationID); err != nil {
+		return err
+	}
+	check := func(stored *domain.Inventory) error {
+		if !domain.InOrganization(ctx, stored.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		if !stored.UpdatedAt.Equal(since) {
+			return domain.ErrConflict
+		}
+		return nil
+	}
+	if err := r.store.inventory.replaceIf(inventory, check, nil); err != nil {
+		inventory.UpdatedAt = since
+		return fmt.Errorf("failed to update inventory: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes an inventory unit by ID
+func (r *inventoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	removed := r.store.inventory.removeWhere(func(i *domain.Inventory) bool {
+		return i.ID == id && domain.InOrganization(ctx, i.OrganizationID)
+	})
+	if removed == 0 {
+		return fmt.Errorf("failed to delete inventory: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// List retrieves inventory with pagination and filtering
+func (r *inventoryRepository) List(ctx context.Context, filters domain.InventoryFilters) ([]*domain.Inventory, error) {
+	return r.store.inventory.list(inventoryMatch(ctx, filters), filters.BaseFilters, inventorySortKeys, newestFirst), nil
+}
+
+// Count returns the total number of inventory units matching the filters
+func (r *inventoryRepository) Count(ctx context.Context, filters domain.InventoryFilters) (int, error) {
+	return r.store.inventory.count(inventoryMatch(ctx, filters)), nil
+}
+
+// GetAvailable retrieves the available units of a project
+func (r *inventoryRepository) GetAvailable(ctx context.Context, projectID uuid.UUID) ([]*domain.Inventory, error) {
+	return r.store.inventory.find(func(i *domain.Inventory) bool {
+		return i.ProjectID == projectID && domain.InOrganization(ctx, i.OrganizationID) &&
+			i.Status == domain.UnitStatusAvailable
+	}, inventorySortKeys, order{key: "unit_number"}), nil
+}
+
+// Reserve marks an available unit as reserved for a client until the given time
+func (r *inventoryRepository) Reserve(ctx context.Context, id uuid.UUID, clientID uuid.UUID, until time.Time) error {
+	err := r.store.inventory.modify(id, func(i *domain.Inventory) error {
+		if !domain.InOrganization(ctx, i.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		if i.Status != domain.UnitStatusAvailable {
+			return domain.ErrInventoryNotAvailable
+		}
+		i.Status = domain.UnitStatusReserved
+		i.ReservedBy = &clientID
+		i.ReservedUntil = &until
+		i.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reserve inventory: %w", err)
+	}
+	return nil
+}
+
+// Release returns a reserved unit to the available pool
+func (r *inventoryRepository) Release(ctx context.Context, id uuid.UUID) error {
+	err := r.store.inventory.modify(id, func(i *domain.Inventory) error {
+		if !domain.InOrganization(ctx, i.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		i.Status = domain.UnitStatusAvailable
+		i.ReservedBy = nil
+		i.ReservedUntil = nil
+		i.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release inventory: %w", err)
+	}
+	return nil
+}
+
+// ReleaseIfExpired releases a unit only if its reservation ran out by at
+func (r *inventoryRepository) ReleaseIfExpired(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := r.store.inventory.modify(id, func(i *domain.Inventory) error {
+		if !domain.InOrganization(ctx, i.OrganizationID) || i.Status != domain.UnitStatusReserved ||
+			i.ReservedUntil == nil || i.ReservedUntil.After(at) {
+			return domain.ErrNotFound
+		}
+		i.Status = domain.UnitStatusAvailable
+		i.ReservedBy = nil
+		i.ReservedUntil = nil
+		i.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release expired inventory: %w", err)
+	}
+	return nil
+}
+
+func inventoryMatch(ctx context.Context, filters domain.InventoryFilters) func(*domain.Inventory) bool {
+	return func(i *domain.Inventory) bool {
+		if !domain.InOrganization(ctx, i.OrganizationID) {
+			return false
+		}
+		if filters.ProjectID != nil && i.ProjectID != *filters.ProjectID {
+			return false
+		}
+		if len(filters.ProjectIDs) > 0 && !containsID(filters.ProjectIDs, i.ProjectID) {
+			return false
+		}
+		if filters.UnitType != nil && (i.UnitType == nil || *i.UnitType != *filters.UnitType) {
+			return false
+		}
+		if filters.Status != nil && i.Status != *filters.Status {
+			return false
+		}
+		if filters.FloorNumber != nil && (i.FloorNumber == nil || *i.FloorNumber != *filters.FloorNumber) {
+			return false
+		}
+		if filters.Facing != nil && (i.Facing == nil || *i.Facing != *filters.Facing) {
+			return false
+		}
+		if !inRange(i.FinalPrice, filters.PriceMin, filters.PriceMax) {
+			return false
+		}
+		if !inRange(i.CarpetArea, filters.AreaMin, filters.AreaMax) {
+			return false
+		}
+		if filters.ParkingSlots != nil && i.ParkingSlots != *filters.ParkingSlots {
+			return false
+		}
+		if filters.MinParkingSlots != nil && i.ParkingSlots < *filters.MinParkingSlots {
+			return false
+		}
+		if filters.ReservedBy != nil && !sameID(i.ReservedBy, *filters.ReservedBy) {
+			return false
+		}
+		if !inTimeRange(i.ReservedUntil, nil, filters.ReservedUntilTo) {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, i.UnitNumber, i.UnitType, i.TowerBlock) {
+			return false
+		}
+		return true
+	}
+}
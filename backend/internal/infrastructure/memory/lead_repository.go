@@ -0,0 +1,196 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var leadSortKeys = sortKeys[domain.Lead]{
+	"name":              func(l *domain.Lead) interface{} { return l.Name },
+	"status":            func(l *domain.Lead) interface{} { return l.Status },
+	"source":            func(l *domain.Lead) interface{} { return l.Source },
+	"score":             func(l *domain.Lead) interface{} { return l.Score },
+	"budget_min":        func(l *domain.Lead) interface{} { return l.BudgetMin },
+	"budget_max":        func(l *domain.Lead) interface{} { return l.BudgetMax },
+	"next_follow_up":    func(l *domain.Lead) interface{} { return l.NextFollowUp },
+	"last_contact_date": func(l *domain.Lead) interface{} { return l.LastContactDate },
+	"created_at":        func(l *domain.Lead) interface{} { return l.CreatedAt },
+	"updated_at":        func(l *domain.Lead) interface{} { return l.UpdatedAt },
+}
+
+// leadRepository implements domain.LeadRepository in memory
+type leadRepository struct {
+	store *Store
+}
+
+// NewLeadRepository creates a new lead repository
+func NewLeadRepository(store *Store) domain.LeadRepository {
+	return &leadRepository{
+		store: store,
+	}
+}
+
+// Create stores a new lead
+func (r *leadRepository) Create(ctx context.Context, lead *domain.Lead) error {
+	if lead.CreatedAt.IsZero() {
+		lead.CreatedAt = time.Now()
+	}
+	if lead.UpdatedAt.IsZero() {
+		lead.UpdatedAt = lead.CreatedAt
+	}
+
+	if err := domain.AssignOrganization(ctx, &lead.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.leads.insert(lead, nil); err != nil {
+		return fmt.Errorf("failed to create lead: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a lead by ID
+func (r *leadRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Lead, error) {
+	lead, err := r.store.leads.get(id)
+	if err == nil && (lead.DeletedAt != nil || !domain.InOrganization(ctx, lead.OrganizationID)) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lead not found: %w", err)
+	}
+	return lead, nil
+}
+
+// Update updates an existing lead
+func (r *leadRepository) Update(ctx context.Context, lead *domain.Lead) error {
+	since := lead.UpdatedAt
+	lead.UpdatedAt = time.Now()
+
+	if err := domain.AssignOrganization(ctx, &lead.OrganizationID); err != nil {
+		return err
+	}
+	check := func(stored *domain.Lead) error {
+		if stored.DeletedAt != nil || !domain.InOrganization(ctx, stored.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		if !stored.UpdatedAt.Equal(since) {
+			return domain.ErrConflict
+		}
+		return nil
+	}
+	if err := r.store.leads.replaceIf(lead, check, nil); err != nil {
+		lead.UpdatedAt = since
+		return fmt.Errorf("failed to update lead: %w", err)
+	}
+	return nil
+}
+
+// Delete moves a lead to the trash
+func (r *leadRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	err := r.store.leads.modify(id, func(l *domain.Lead) error {
+		if l.DeletedAt != nil || !domain.InOrganization(ctx, l.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		now := time.Now()
+		l.DeletedAt, l.DeletedBy, l.UpdatedAt = &now, &deletedBy, now
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete lead: %w", err)
+	}
+	return nil
+}
+
+// Restore takes a lead out of the trash
+func (r *leadRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	err := r.store.leads.modify(id, func(l *domain.Lead) error {
+		if l.DeletedAt == nil || !domain.InOrganization(ctx, l.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		l.DeletedAt, l.DeletedBy, l.UpdatedAt = nil, nil, time.Now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore lead: %w", err)
+	}
+	return nil
+}
+
+// Purge permanently removes leads that were deleted before the cutoff
+func (r *leadRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return r.store.leads.removeWhere(func(l *domain.Lead) bool {
+		return l.DeletedAt != nil && l.DeletedAt.Before(deletedBefore) && domain.InOrganization(ctx, l.OrganizationID)
+	}), nil
+}
+
+// List retrieves leads with pagination and filtering
+func (r *leadRepository) List(ctx context.Context, filters domain.LeadFilters) ([]*domain.Lead, error) {
+	return r.store.leads.list(leadMatch(ctx, filters), filters.BaseFilters, leadSortKeys, newestFirst), nil
+}
+
+// Count returns the total number of leads matching the filters
+func (r *leadRepository) Count(ctx context.Context, filters domain.LeadFilters) (int, error) {
+	return r.store.leads.count(leadMatch(ctx, filters)), nil
+}
+
+// GetByAssignedUser retrieves leads assigned to a user
+func (r *leadRepository) GetByAssignedUser(ctx context.Context, userID uuid.UUID) ([]*domain.Lead, error) {
+	return r.store.leads.find(func(l *domain.Lead) bool {
+		return l.DeletedAt == nil && domain.InOrganization(ctx, l.OrganizationID) &&
+			sameID(l.AssignedTo, userID)
+	}, leadSortKeys, newestFirst), nil
+}
+
+// GetOverdueFollowUps retrieves open leads whose next follow-up has passed
+func (r *leadRepository) GetOverdueFollowUps(ctx context.Context) ([]*domain.Lead, error) {
+	now := time.Now()
+	return r.store.leads.find(func(l *domain.Lead) bool {
+		return l.DeletedAt == nil && domain.InOrganization(ctx, l.OrganizationID) &&
+			l.NextFollowUp != nil && l.NextFollowUp.Before(now) &&
+			l.Status != domain.LeadStatusConverted && l.Status != domain.LeadStatusLost
+	}, leadSortKeys, order{key: "next_follow_up"}), nil
+}
+
+func leadMatch(ctx context.Context, filters domain.LeadFilters) func(*domain.Lead) bool {
+	return func(l *domain.Lead) bool {
+		if l.DeletedAt != nil && !filters.IncludeDeleted {
+			return false
+		}
+		if !domain.InOrganization(ctx, l.OrganizationID) {
+			return false
+		}
+		if filters.Status != nil && l.Status != *filters.Status {
+			return false
+		}
+		if filters.Source != nil && l.Source != *filters.Source {
+			return false
+		}
+		if filters.AssignedTo != nil && !sameID(l.AssignedTo, *filters.AssignedTo) {
+			return false
+		}
+		if filters.CreatedBy != nil && l.CreatedBy != *filters.CreatedBy {
+			return false
+		}
+		if !inRange(l.BudgetMin, filters.BudgetMin, nil) || !inRange(l.BudgetMax, nil, filters.BudgetMax) {
+			return false
+		}
+		if filters.ScoreMin != nil && l.Score < *filters.ScoreMin {
+			return false
+		}
+		if filters.ScoreMax != nil && l.Score > *filters.ScoreMax {
+			return false
+		}
+		if !inTimeRange(&l.CreatedAt, filters.CreatedAfter, filters.CreatedBefore) {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, l.Name, l.Email, l.CompanyName, l.Requirements) {
+			return false
+		}
+		return containsAll(l.Tags, filters.Tags)
+	}
+}
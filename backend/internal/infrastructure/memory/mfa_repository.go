@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// mfaRepository implements domain.MFARepository in memory
+type mfaRepository struct {
+	store *Store
+}
+
+// NewMFARepository creates a new MFA repository
+func NewMFARepository(store *Store) domain.MFARepository {
+	return &mfaRepository{
+		store: store,
+	}
+}
+
+// Save creates or replaces the authenticator of a user
+func (r *mfaRepository) Save(ctx context.Context, enrollment *domain.MFAEnrollment) error {
+	now := time.Now()
+	if enrollment.CreatedAt.IsZero() {
+		enrollment.CreatedAt = now
+	}
+	enrollment.UpdatedAt = now
+
+	err := r.store.mfaEnrollments.modify(enrollment.UserID, func(e *domain.MFAEnrollment) error {
+		*e = *enrollment
+		return nil
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		err = r.store.mfaEnrollments.insert(enrollment, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save MFA enrollment: %w", err)
+	}
+	return nil
+}
+
+// GetByUser retrieves the authenticator of a user
+func (r *mfaRepository) GetByUser(ctx context.Context, userID uuid.UUID) (*domain.MFAEnrollment, error) {
+	enrollment, err := r.store.mfaEnrollments.get(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MFA enrollment: %w", err)
+	}
+	return enrollment, nil
+}
+
+// UseStep records an accepted code if its step is later than the last one
+func (r *mfaRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64, at time.Time) error {
+	err := r.store.mfaEnrollments.modify(userID, func(e *domain.MFAEnrollment) error {
+		if step <= e.LastStep {
+			return domain.ErrNotFound
+		}
+		e.LastStep = step
+		e.UpdatedAt = at
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to use MFA code: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the authenticator and recovery codes of a user
+func (r *mfaRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	r.store.recoveryCodes.removeWhere(func(c *domain.MFARecoveryCode) bool { return c.UserID == userID })
+	if err := r.store.mfaEnrollments.remove(userID); err != nil {
+		return fmt.Errorf("failed to delete MFA enrollment: %w", err)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes swaps a user's recovery codes for new ones
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string, at time.Time) error {
+	r.store.recoveryCodes.removeWhere(func(c *domain.MFARecoveryCode) bool { return c.UserID == userID })
+	for _, hash := range codeHashes {
+		code := &domain.MFARecoveryCode{ID: uuid.New(), UserID: userID, CodeHash: hash, CreatedAt: at}
+		if err := r.store.recoveryCodes.insert(code, nil); err != nil {
+			return fmt.Errorf("failed to store recovery codes: %w", err)
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode redeems an unused recovery code of a user
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, at time.Time) error {
+	usable := func(c *domain.MFARecoveryCode) bool {
+		return c.UserID == userID && c.CodeHash == codeHash && c.UsedAt == nil
+	}
+
+	code, err := r.store.recoveryCodes.first(usable)
+	if err == nil {
+		// Checked again under the table's lock so that a code is only
+		// redeemed once
+		err = r.store.recoveryCodes.modify(code.ID, func(c *domain.MFARecoveryCode) error {
+			if !usable(c) {
+				return domain.ErrNotFound
+			}
+			c.UsedAt = &at
+			return nil
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	return nil
+}
+
+// CountRecoveryCodes returns how many unused recovery codes a user has
+func (r *mfaRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	return r.store.recoveryCodes.count(func(c *domain.MFARecoveryCode) bool {
+		return c.UserID == userID && c.UsedAt == nil
+	}), nil
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var listingSortKeys = sortKeys[domain.NFTListing]{
+	"price":      func(l *domain.NFTListing) interface{} { return l.Price },
+	"quantity":   func(l *domain.NFTListing) interface{} { return l.Quantity },
+	"status":     func(l *domain.NFTListing) interface{} { return l.Status },
+	"expires_at": func(l *domain.NFTListing) interface{} { return l.ExpiresAt },
+	"created_at": func(l *domain.NFTListing) interface{} { return l.CreatedAt },
+	"updated_at": func(l *domain.NFTListing) interface{} { return l.UpdatedAt },
+}
+
+// nftListingRepository implements domain.NFTListingRepository in memory
+type nftListingRepository struct {
+	store *Store
+}
+
+// NewNFTListingRepository creates a new NFT listing repository
+func NewNFTListingRepository(store *Store) domain.NFTListingRepository {
+	return &nftListingRepository{
+		store: store,
+	}
+}
+
+// Create stores a new listing
+func (r *nftListingRepository) Create(ctx context.Context, listing *domain.NFTListing) error {
+	now := time.Now()
+	if listing.CreatedAt.IsZero() {
+		listing.CreatedAt = now
+	}
+	if listing.UpdatedAt.IsZero() {
+		listing.UpdatedAt = listing.CreatedAt
+	}
+
+	if err := r.store.listings.insert(listing, nil); err != nil {
+		return fmt.Errorf("failed to create listing: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a listing by ID
+func (r *nftListingRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.NFTListing, error) {
+	listing, err := r.store.listings.get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get listing by ID: %w", err)
+	}
+	return listing, nil
+}
+
+// GetByNFT retrieves the listings of an NFT
+func (r *nftListingRepository) GetByNFT(ctx context.Context, nftID uuid.UUID) ([]*domain.NFTListing, error) {
+	return r.store.listings.find(func(l *domain.NFTListing) bool {
+		return l.NFTID == nftID
+	}, listingSortKeys, newestFirst), nil
+}
+
+// GetBySeller retrieves the listings created by a seller address
+func (r *nftListingRepository) GetBySeller(ctx context.Context, sellerAddress string) ([]*domain.NFTListing, error) {
+	return r.store.listings.find(func(l *domain.NFTListing) bool {
+		return l.SellerAddress == sellerAddress
+	}, listingSortKeys, newestFirst), nil
+}
+
+// Update updates an existing listing
+func (r *nftListingRepository) Update(ctx context.Context, listing *domain.NFTListing) error {
+	listing.UpdatedAt = time.Now()
+
+	if err := r.store.listings.replace(listing, nil); err != nil {
+		return fmt.Errorf("failed to update listing: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a listing by ID
+func (r *nftListingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.store.listings.remove(id); err != nil {
+		return fmt.Errorf("failed to delete listing: %w", err)
+	}
+	return nil
+}
+
+// List retrieves listings with pagination and filtering
+func (r *nftListingRepository) List(ctx context.Context, filters domain.ListingFilters) ([]*domain.NFTListing, error) {
+	return r.store.listings.list(listingMatch(filters), filters.BaseFilters, listingSortKeys, newestFirst), nil
+}
+
+// GetActiveListings retrieves active listings that have not expired, cheapest first
+func (r *nftListingRepository) GetActiveListings(ctx context.Context) ([]*domain.NFTListing, error) {
+	now := time.Now()
+	return r.store.listings.find(func(l *domain.NFTListing) bool {
+		return l.Status == domain.ListingStatusActive && (l.ExpiresAt == nil || l.ExpiresAt.After(now))
+	}, listingSortKeys, order{key: "price"}), nil
+}
+
+func listingMatch(filters domain.ListingFilters) func(*domain.NFTListing) bool {
+	return func(l *domain.NFTListing) bool {
+		if filters.NFTID != nil && l.NFTID != *filters.NFTID {
+			return false
+		}
+		if filters.SellerAddress != nil && l.SellerAddress != *filters.SellerAddress {
+			return false
+		}
+		if filters.Status != nil && l.Status != *filters.Status {
+			return false
+		}
+		if !inRange(&l.Price, filters.PriceMin, filters.PriceMax) {
+			return false
+		}
+		return filters.Currency == nil || l.Currency == *filters.Currency
+	}
+}
@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var nftSortKeys = sortKeys[domain.RealEstateNFT]{
+	"name":            func(n *domain.RealEstateNFT) interface{} { return n.Name },
+	"token_id":        func(n *domain.RealEstateNFT) interface{} { return n.TokenID },
+	"total_value":     func(n *domain.RealEstateNFT) interface{} { return n.TotalValue },
+	"price_per_token": func(n *domain.RealEstateNFT) interface{} { return n.PricePerToken },
+	"created_at":      func(n *domain.RealEstateNFT) interface{} { return n.CreatedAt },
+	"updated_at":      func(n *domain.RealEstateNFT) interface{} { return n.UpdatedAt },
+}
+
+// nftRepository implements domain.RealEstateNFTRepository in memory
+type nftRepository struct {
+	store *Store
+}
+
+// NewRealEstateNFTRepository creates a new real estate NFT repository
+func NewRealEstateNFTRepository(store *Store) domain.RealEstateNFTRepository {
+	return &nftRepository{
+		store: store,
+	}
+}
+
+// Create stores a new NFT; token IDs must be unique per contract
+func (r *nftRepository) Create(ctx context.Context, nft *domain.RealEstateNFT) error {
+	now := time.Now()
+	if nft.CreatedAt.IsZero() {
+		nft.CreatedAt = now
+	}
+	if nft.UpdatedAt.IsZero() {
+		nft.UpdatedAt = nft.CreatedAt
+	}
+
+	if err := r.store.nfts.insert(nft, nftConflict(nft)); err != nil {
+		return fmt.Errorf("failed to create NFT: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an NFT by ID
+func (r *nftRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.RealEstateNFT, error) {
+	nft, err := r.store.nfts.get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NFT by ID: %w", err)
+	}
+	return nft, nil
+}
+
+// GetByTokenID retrieves an NFT by its on-chain token ID
+func (r *nftRepository) GetByTokenID(ctx context.Context, tokenID int64) (*domain.RealEstateNFT, error) {
+	nft, err := r.store.nfts.first(func(n *domain.RealEstateNFT) bool { return n.TokenID == tokenID })
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NFT by token ID: %w", err)
+	}
+	return nft, nil
+}
+
+// GetByProperty retrieves the NFT minted for an inventory unit
+func (r *nftRepository) GetByProperty(ctx context.Context, propertyID uuid.UUID) (*domain.RealEstateNFT, error) {
+	nft, err := r.store.nfts.first(func(n *domain.RealEstateNFT) bool { return sameID(n.PropertyID, propertyID) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NFT by property: %w", err)
+	}
+	return nft, nil
+}
+
+// Update updates an existing NFT
+func (r *nftRepository) Update(ctx context.Context, nft *domain.RealEstateNFT) error {
+	nft.UpdatedAt = time.Now()
+
+	if err := r.store.nfts.replace(nft, nftConflict(nft)); err != nil {
+		return fmt.Errorf("failed to update NFT: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes an NFT by ID
+func (r *nftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.store.nfts.remove(id); err != nil {
+		return fmt.Errorf("failed to delete NFT: %w", err)
+	}
+	return nil
+}
+
+// List retrieves NFTs with pagination and filtering
+func (r *nftRepository) List(ctx context.Context, filters domain.NFTFilters) ([]*domain.RealEstateNFT, error) {
+	return r.store.nfts.list(nftMatch(filters), filters.BaseFilters, nftSortKeys, newestFirst), nil
+}
+
+// GetMarketplaceStats aggregates NFTs and their listings
+func (r *nftRepository) GetMarketplaceStats(ctx context.Context) (*domain.MarketplaceStats, error) {
+	stats := &domain.MarketplaceStats{}
+
+	owners := make(map[string]bool)
+	for _, nft := range r.store.nfts.find(nil, nil, order{}) {
+		stats.TotalProperties++
+		if nft.TotalValue != nil {
+			stats.TokenizedValue += *nft.TotalValue
+		}
+		if nft.OwnerAddress != nil && *nft.OwnerAddress != "" {
+			owners[*nft.OwnerAddress] = true
+		}
+	}
+	stats.UniqueOwners = len(owners)
+
+	for _, listing := range r.store.listings.find(nil, nil, order{}) {
+		stats.TotalListings++
+		switch listing.Status {
+		case domain.ListingStatusActive:
+			stats.ActiveListings++
+			if stats.FloorPrice == 0 || listing.Price < stats.FloorPrice {
+				stats.FloorPrice = listing.Price
+			}
+		case domain.ListingStatusSold:
+			stats.TotalSales++
+			stats.TotalVolume += listing.Price * float64(listing.Quantity)
+			if listing.Price > stats.TopSale {
+				stats.TopSale = listing.Price
+			}
+		}
+	}
+	if stats.TotalSales > 0 {
+		stats.AveragePrice = stats.TotalVolume / float64(stats.TotalSales)
+	}
+
+	return stats, nil
+}
+
+func nftMatch(filters domain.NFTFilters) func(*domain.RealEstateNFT) bool {
+	return func(n *domain.RealEstateNFT) bool {
+		if filters.PropertyID != nil && !sameID(n.PropertyID, *filters.PropertyID) {
+			return false
+		}
+		if filters.PropertyType != nil && n.PropertyType != *filters.PropertyType {
+			return false
+		}
+		if filters.CreatorID != nil && !sameID(n.CreatorID, *filters.CreatorID) {
+			return false
+		}
+		if filters.IsListed != nil && n.IsListed != *filters.IsListed {
+			return false
+		}
+		if !inRange(n.PricePerToken, filters.PriceMin, filters.PriceMax) {
+			return false
+		}
+		if filters.Currency != nil && n.Currency != *filters.Currency {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, n.Name, n.Description, n.PropertyAddress) {
+			return false
+		}
+		return true
+	}
+}
+
+func nftConflict(nft *domain.RealEstateNFT) func(*domain.RealEstateNFT) bool {
+	return func(n *domain.RealEstateNFT) bool {
+		return n.ContractAddress == nft.ContractAddress && n.TokenID == nft.TokenID
+	}
+}
@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var notificationSortKeys = sortKeys[domain.Notification]{
+	"created_at": func(n *domain.Notification) interface{} { return n.CreatedAt },
+}
+
+// notificationRepository implements domain.NotificationRepository in memory
+type notificationRepository struct {
+	store *Store
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(store *Store) domain.NotificationRepository {
+	return &notificationRepository{
+		store: store,
+	}
+}
+
+// Create stores a new notification
+func (r *notificationRepository) Create(ctx context.Context, notification *domain.Notification) error {
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	if err := r.store.notifications.insert(notification, nil); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a notification by ID
+func (r *notificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Notification, error) {
+	notification, err := r.store.notifications.get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification by ID: %w", err)
+	}
+	return notification, nil
+}
+
+// GetByUser retrieves notifications for a user, most recent first
+func (r *notificationRepository) GetByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Notification, error) {
+	return r.store.notifications.find(func(n *domain.Notification) bool {
+		return n.UserID == userID
+	}, notificationSortKeys, newestFirst), nil
+}
+
+// Update updates an existing notification
+func (r *notificationRepository) Update(ctx context.Context, notification *domain.Notification) error {
+	if err := r.store.notifications.replace(notification, nil); err != nil {
+		return fmt.Errorf("failed to update notification: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a notification by ID
+func (r *notificationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.store.notifications.remove(id); err != nil {
+		return fmt.Errorf("failed to delete notification: %w", err)
+	}
+	return nil
+}
+
+// MarkAsRead marks a notification as read
+func (r *notificationRepository) MarkAsRead(ctx context.Context, id uuid.UUID) error {
+	err := r.store.notifications.modify(id, func(n *domain.Notification) error {
+		n.IsRead = true
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllAsRead marks all notifications as read for a user
+func (r *notificationRepository) MarkAllAsRead(ctx context.Context, userID uuid.UUID) error {
+	r.store.notifications.modifyWhere(func(n *domain.Notification) bool {
+		return n.UserID == userID && !n.IsRead
+	}, func(n *domain.Notification) {
+		n.IsRead = true
+	})
+	return nil
+}
+
+// GetUnreadCount returns the count of unread notifications for a user
+func (r *notificationRepository) GetUnreadCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	return r.store.notifications.count(func(n *domain.Notification) bool {
+		return n.UserID == userID && !n.IsRead
+	}), nil
+}
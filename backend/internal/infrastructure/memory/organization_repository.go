@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var organizationSortKeys = sortKeys[domain.Organization]{
+	"name":       func(o *domain.Organization) interface{} { return o.Name },
+	"slug":       func(o *domain.Organization) interface{} { return o.Slug },
+	"created_at": func(o *domain.Organization) interface{} { return o.CreatedAt },
+	"updated_at": func(o *domain.Organization) interface{} { return o.UpdatedAt },
+}
+
+// organizationRepository implements domain.OrganizationRepository in memory
+type organizationRepository struct {
+	store *Store
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(store *Store) domain.OrganizationRepository {
+	return &organizationRepository{
+		store: store,
+	}
+}
+
+// Create stores a new organization; slugs must be unique
+func (r *organizationRepository) Create(ctx context.Context, organization *domain.Organization) error {
+	if organization.CreatedAt.IsZero() {
+		organization.CreatedAt = time.Now()
+	}
+	if organization.UpdatedAt.IsZero() {
+		organization.UpdatedAt = organization.CreatedAt
+	}
+
+	if err := r.store.organizations.insert(organization, organizationConflict(organization)); err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an organization by ID
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	organization, err := r.store.organizations.get(id)
+	if err == nil && !domain.InOrganization(ctx, &organization.ID) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization by ID: %w", err)
+	}
+	return organization, nil
+}
+
+// GetBySlug retrieves an organization by slug
+func (r *organizationRepository) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	organization, err := r.store.organizations.first(func(o *domain.Organization) bool {
+		return o.Slug == slug && domain.InOrganization(ctx, &o.ID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization by slug: %w", err)
+	}
+	return organization, nil
+}
+
+// Update updates an existing organization
+func (r *organizationRepository) Update(ctx context.Context, organization *domain.Organization) error {
+	organization.UpdatedAt = time.Now()
+
+	check := func(stored *domain.Organization) error {
+		if !domain.InOrganization(ctx, &stored.ID) {
+			return domain.ErrNotFound
+		}
+		return nil
+	}
+	if err := r.store.organizations.replaceIf(organization, check, organizationConflict(organization)); err != nil {
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+	return nil
+}
+
+// List retrieves organizations with pagination and filtering
+func (r *organizationRepository) List(ctx context.Context, filters domain.OrganizationFilters) ([]*domain.Organization, error) {
+	match := func(o *domain.Organization) bool {
+		if !domain.InOrganization(ctx, &o.ID) {
+			return false
+		}
+		if filters.IsActive != nil && o.IsActive != *filters.IsActive {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" && !matchesSearch(*filters.Search, o.Name, o.Slug) {
+			return false
+		}
+		return true
+	}
+	return r.store.organizations.list(match, filters.BaseFilters, organizationSortKeys, order{key: "name"}), nil
+}
+
+func organizationConflict(organization *domain.Organization) func(*domain.Organization) bool {
+	return func(o *domain.Organization) bool {
+		return o.Slug == organization.Slug
+	}
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var paymentScheduleSortKeys = sortKeys[domain.PaymentSchedule]{
+	"installment_number": func(p *domain.PaymentSchedule) interface{} { return p.InstallmentNumber },
+	"due_date":           func(p *domain.PaymentSchedule) interface{} { return p.DueDate },
+	"amount":             func(p *domain.PaymentSchedule) interface{} { return p.Amount },
+	"status":             func(p *domain.PaymentSchedule) interface{} { return p.Status },
+	"created_at":         func(p *domain.PaymentSchedule) interface{} { return p.CreatedAt },
+	"updated_at":         func(p *domain.PaymentSchedule) interface{} { return p.UpdatedAt },
+}
+
+// paymentScheduleRepository implements domain.PaymentScheduleRepository in memory
+type paymentScheduleRepository struct {
+	store *Store
+}
+
+// NewPaymentScheduleRepository creates a new payment schedule repository
+func NewPaymentScheduleRepository(store *Store) domain.PaymentScheduleRepository {
+	return &paymentScheduleRepository{
+		store: store,
+	}
+}
+
+// Create stores a new installment; installment numbers are unique per sale
+func (r *paymentScheduleRepository) Create(ctx context.Context, schedule *domain.PaymentSchedule) error {
+	now := time.Now()
+	if schedule.CreatedAt.IsZero() {
+		schedule.CreatedAt = now
+	}
+	if schedule.UpdatedAt.IsZero() {
+		schedule.UpdatedAt = schedule.CreatedAt
+	}
+
+	conflict := func(p *domain.PaymentSchedule) bool {
+		return p.SaleID == schedule.SaleID && p.InstallmentNumber == schedule.InstallmentNumber
+	}
+	if err := domain.AssignOrganization(ctx, &schedule.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.paymentSchedules.insert(schedule, conflict); err != nil {
+		return fmt.Errorf("failed to create payment schedule: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an installment by ID
+func (r *paymentScheduleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PaymentSchedule, error) {
+	schedule, err := r.store.paymentSchedules.get(id)
+	if err == nil && !domain.InOrganization(ctx, schedule.OrganizationID) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment schedule by ID: %w", err)
+	}
+	return schedule, nil
+}
+
+// GetBySale retrieves the installments of a sale in order
+func (r *paymentScheduleRepository) GetBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.PaymentSchedule, error) {
+	return r.store.paymentSchedules.find(func(p *domain.PaymentSchedule) bool {
+		return p.SaleID == saleID && domain.InOrganization(ctx, p.OrganizationID)
+	}, paymentScheduleSortKeys, order{key: "installment_number"}), nil
+}
+
+// Update updates an existing installment
+func (r *paymentScheduleRepository) Update(ctx context.Context, schedule *domain.PaymentSchedule) error {
+	since := schedule.UpdatedAt
+	schedule.UpdatedAt = time.Now()
+
+	if err := domain.AssignOrganization(ctx, &schedule.OrganizationID); err != nil {
+		return err
+	}
+	check := func(stored *domain.PaymentSchedule) error {
+		if !domain.InOrganization(ctx, stored.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		return nil
+	}
+	if err := r.store.paymentSchedules.replaceIf(schedule, check, nil); err != nil {
+		schedule.UpdatedAt = since
+		return fmt.Errorf("failed to update payment schedule: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes an installment by ID
+func (r *paymentScheduleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	removed := r.store.paymentSchedules.removeWhere(func(p *domain.PaymentSchedule) bool {
+		return p.ID == id && domain.InOrganization(ctx, p.OrganizationID)
+	})
+	if removed == 0 {
+		return fmt.Errorf("failed to delete payment schedule: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// List retrieves installments with pagination and filtering
+func (r *paymentScheduleRepository) List(ctx context.Context, filters domain.PaymentScheduleFilters) ([]*domain.PaymentSchedule, error) {
+	return r.store.paymentSchedules.list(paymentScheduleMatch(ctx, filters), filters.BaseFilters, paymentScheduleSortKeys,
+		order{key: "due_date"}), nil
+}
+
+// GetOverduePayments retrieves unpaid installments whose due date has passed
+func (r *paymentScheduleRepository) GetOverduePayments(ctx context.Context) ([]*domain.PaymentSchedule, error) {
+	now := time.Now()
+	return r.store.paymentSchedules.find(func(p *domain.PaymentSchedule) bool {
+		return p.DueDate.Before(now) && domain.InOrganization(ctx, p.OrganizationID) &&
+			(p.Status == domain.PaymentStatusPending || p.Status == domain.PaymentStatusOverdue)
+	}, paymentScheduleSortKeys, order{key: "due_date"}), nil
+}
+
+func paymentScheduleMatch(ctx context.Context, filters domain.PaymentScheduleFilters) func(*domain.PaymentSchedule) bool {
+	return func(p *domain.PaymentSchedule) bool {
+		if !domain.InOrganization(ctx, p.OrganizationID) {
+			return false
+		}
+		if filters.SaleID != nil && p.SaleID != *filters.SaleID {
+			return false
+		}
+		if filters.Status != nil && p.Status != *filters.Status {
+			return false
+		}
+		if !inTimeRange(&p.DueDate, filters.DueDateFrom, filters.DueDateTo) {
+			return false
+		}
+		return inRange(&p.Amount, filters.AmountMin, filters.AmountMax)
+	}
+}
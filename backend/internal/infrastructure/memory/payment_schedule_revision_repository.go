@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var paymentRevisionSortKeys = sortKeys[domain.PaymentScheduleRevision]{
+	"revision": func(r *domain.PaymentScheduleRevision) interface{} { return r.Revision },
+}
+
+// paymentScheduleRevisionRepository implements domain.PaymentScheduleRevisionRepository in memory
+type paymentScheduleRevisionRepository struct {
+	store *Store
+}
+
+// NewPaymentScheduleRevisionRepository creates a new payment schedule revision repository
+func NewPaymentScheduleRevisionRepository(store *Store) domain.PaymentScheduleRevisionRepository {
+	return &paymentScheduleRevisionRepository{
+		store: store,
+	}
+}
+
+// Create records a schedule revision; revision numbers are unique per sale
+func (r *paymentScheduleRevisionRepository) Create(ctx context.Context, revision *domain.PaymentScheduleRevision) error {
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now()
+	}
+
+	if err := domain.AssignOrganization(ctx, &revision.OrganizationID); err != nil {
+		return err
+	}
+	conflict := func(p *domain.PaymentScheduleRevision) bool {
+		return p.SaleID == revision.SaleID && p.Revision == revision.Revision
+	}
+	if err := r.store.paymentRevisions.insert(revision, conflict); err != nil {
+		return fmt.Errorf("failed to create payment schedule revision: %w", err)
+	}
+	return nil
+}
+
+// ListBySale returns the revisions of a sale's schedule, oldest first
+func (r *paymentScheduleRevisionRepository) ListBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.PaymentScheduleRevision, error) {
+	return r.store.paymentRevisions.find(func(p *domain.PaymentScheduleRevision) bool {
+		return p.SaleID == saleID && domain.InOrganization(ctx, p.OrganizationID)
+	}, paymentRevisionSortKeys, order{key: "revision"}), nil
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var priceListSortKeys = sortKeys[domain.PriceList]{
+	"version": func(p *domain.PriceList) interface{} { return p.Version },
+}
+
+// priceListRepository implements domain.PriceListRepository in memory
+type priceListRepository struct {
+	store *Store
+}
+
+// NewPriceListRepository creates a new price list repository
+func NewPriceListRepository(store *Store) domain.PriceListRepository {
+	return &priceListRepository{
+		store: store,
+	}
+}
+
+// Create stores a price list version; versions are unique per
+// organization and project
+func (r *priceListRepository) Create(ctx context.Context, priceList *domain.PriceList) error {
+	if priceList.CreatedAt.IsZero() {
+		priceList.CreatedAt = time.Now()
+	}
+
+	if err := domain.AssignOrganization(ctx, &priceList.OrganizationID); err != nil {
+		return err
+	}
+	conflict := func(p *domain.PriceList) bool {
+		return p.Version == priceList.Version && p.ProjectID == priceList.ProjectID &&
+			sameOptionalID(p.OrganizationID, priceList.OrganizationID)
+	}
+	if err := r.store.priceLists.insert(priceList, conflict); err != nil {
+		return fmt.Errorf("failed to create price list: %w", err)
+	}
+	return nil
+}
+
+// GetVersion returns one version of a project's price list
+func (r *priceListRepository) GetVersion(ctx context.Context, projectID uuid.UUID, version int) (*domain.PriceList, error) {
+	priceList, err := r.store.priceLists.first(func(p *domain.PriceList) bool {
+		return p.ProjectID == projectID && p.Version == version && domain.InOrganization(ctx, p.OrganizationID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price list: %w", err)
+	}
+	return priceList, nil
+}
+
+// GetEffective returns the version of a project's price list in effect at a time
+func (r *priceListRepository) GetEffective(ctx context.Context, projectID uuid.UUID, at time.Time) (*domain.PriceList, error) {
+	versions, _ := r.ListVersions(ctx, projectID)
+	var effective *domain.PriceList
+	for _, priceList := range versions {
+		if priceList.EffectiveFrom.After(at) {
+			continue
+		}
+		if effective == nil || priceList.EffectiveFrom.After(effective.EffectiveFrom) {
+			effective = priceList
+		}
+	}
+	if effective == nil {
+		return nil, fmt.Errorf("failed to get price list: %w", domain.ErrNotFound)
+	}
+	return effective, nil
+}
+
+// ListVersions returns every version of a project's price list, newest first
+func (r *priceListRepository) ListVersions(ctx context.Context, projectID uuid.UUID) ([]*domain.PriceList, error) {
+	return r.store.priceLists.find(func(p *domain.PriceList) bool {
+		return p.ProjectID == projectID && domain.InOrganization(ctx, p.OrganizationID)
+	}, priceListSortKeys, order{key: "version", desc: true}), nil
+}
@@ -0,0 +1,169 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var projectSortKeys = sortKeys[domain.Project]{
+	"name":                func(p *domain.Project) interface{} { return p.Name },
+	"status":              func(p *domain.Project) interface{} { return p.Status },
+	"project_type":        func(p *domain.Project) interface{} { return p.ProjectType },
+	"start_date":          func(p *domain.Project) interface{} { return p.StartDate },
+	"expected_completion": func(p *domain.Project) interface{} { return p.ExpectedCompletion },
+	"base_price":          func(p *domain.Project) interface{} { return p.BasePrice },
+	"price_per_sqft":      func(p *domain.Project) interface{} { return p.PricePerSqft },
+	"available_units":     func(p *domain.Project) interface{} { return p.AvailableUnits },
+	"created_at":          func(p *domain.Project) interface{} { return p.CreatedAt },
+	"updated_at":          func(p *domain.Project) interface{} { return p.UpdatedAt },
+}
+
+// projectRepository implements domain.ProjectRepository in memory
+type projectRepository struct {
+	store *Store
+}
+
+// NewProjectRepository creates a new project repository
+func NewProjectRepository(store *Store) domain.ProjectRepository {
+	return &projectRepository{
+		store: store,
+	}
+}
+
+// Create stores a new project
+func (r *projectRepository) Create(ctx context.Context, project *domain.Project) error {
+	now := time.Now()
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = now
+	}
+	if project.UpdatedAt.IsZero() {
+		project.UpdatedAt = project.CreatedAt
+	}
+
+	if err := domain.AssignOrganization(ctx, &project.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.projects.insert(project, nil); err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a project by ID
+func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	project, err := r.store.projects.get(id)
+	if err == nil && !domain.InOrganization(ctx, project.OrganizationID) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project by ID: %w", err)
+	}
+	return project, nil
+}
+
+// GetBySociety retrieves projects belonging to a society
+func (r *projectRepository) GetBySociety(ctx context.Context, societyID uuid.UUID) ([]*domain.Project, error) {
+	return r.store.projects.find(func(p *domain.Project) bool {
+		return p.SocietyID == societyID && domain.InOrganization(ctx, p.OrganizationID)
+	}, projectSortKeys, newestFirst), nil
+}
+
+// Update updates an existing project
+func (r *projectRepository) Update(ctx context.Context, project *domain.Project) error {
+	since := project.UpdatedAt
+	project.UpdatedAt = time.Now()
+
+	if err := domain.AssignOrganization(ctx, &project.OrganizationID); err != nil {
+		return err
+	}
+	check := func(stored *domain.Project) error {
+		if !domain.InOrganization(ctx, stored.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		return nil
+	}
+	if err := r.store.projects.replaceIf(project, check, nil); err != nil {
+		project.UpdatedAt = since
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a project by ID
+func (r *projectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	removed := r.store.projects.removeWhere(func(p *domain.Project) bool {
+		return p.ID == id && domain.InOrganization(ctx, p.OrganizationID)
+	})
+	if removed == 0 {
+		return fmt.Errorf("failed to delete project: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// List retrieves projects with pagination and filtering
+func (r *projectRepository) List(ctx context.Context, filters domain.ProjectFilters) ([]*domain.Project, error) {
+	return r.store.projects.list(projectMatch(ctx, filters), filters.BaseFilters, projectSortKeys, newestFirst), nil
+}
+
+// Count returns the total number of projects matching the filters
+func (r *projectRepository) Count(ctx context.Context, filters domain.ProjectFilters) (int, error) {
+	return r.store.projects.count(projectMatch(ctx, filters)), nil
+}
+
+// RefreshUnitCounts recounts the units of a project from its inventory
+func (r *projectRepository) RefreshUnitCounts(ctx context.Context, id uuid.UUID) error {
+	counts := map[domain.UnitStatus]int{}
+	for _, unit := range r.store.inventory.find(func(i *domain.Inventory) bool {
+		return i.ProjectID == id
+	}, inventorySortKeys, newestFirst) {
+		counts[unit.Status]++
+	}
+
+	err := r.store.projects.modify(id, func(p *domain.Project) error {
+		if !domain.InOrganization(ctx, p.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		available := counts[domain.UnitStatusAvailable]
+		p.AvailableUnits = &available
+		p.SoldUnits = counts[domain.UnitStatusSold]
+		p.BlockedUnits = counts[domain.UnitStatusReserved] + counts[domain.UnitStatusBlocked]
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to refresh project unit counts: %w", err)
+	}
+	return nil
+}
+
+func projectMatch(ctx context.Context, filters domain.ProjectFilters) func(*domain.Project) bool {
+	return func(p *domain.Project) bool {
+		if !domain.InOrganization(ctx, p.OrganizationID) {
+			return false
+		}
+		if filters.SocietyID != nil && p.SocietyID != *filters.SocietyID {
+			return false
+		}
+		if filters.ProjectType != nil && (p.ProjectType == nil || *p.ProjectType != *filters.ProjectType) {
+			return false
+		}
+		if filters.Status != nil && p.Status != *filters.Status {
+			return false
+		}
+		if filters.CreatedBy != nil && p.CreatedBy != *filters.CreatedBy {
+			return false
+		}
+		if !inRange(p.BasePrice, filters.PriceMin, filters.PriceMax) {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, p.Name, p.Description) {
+			return false
+		}
+		return true
+	}
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var interestSortKeys = sortKeys[domain.PropertyInterest]{
+	"created_at": func(i *domain.PropertyInterest) interface{} { return i.CreatedAt },
+}
+
+// propertyInterestRepository implements domain.PropertyInterestRepository in memory
+type propertyInterestRepository struct {
+	store *Store
+}
+
+// NewPropertyInterestRepository creates a new property interest repository
+func NewPropertyInterestRepository(store *Store) domain.PropertyInterestRepository {
+	return &propertyInterestRepository{
+		store: store,
+	}
+}
+
+// Create records a client's interest in a unit
+func (r *propertyInterestRepository) Create(ctx context.Context, interest *domain.PropertyInterest) error {
+	if interest.CreatedAt.IsZero() {
+		interest.CreatedAt = time.Now()
+	}
+
+	if err := domain.AssignOrganization(ctx, &interest.OrganizationID); err != nil {
+		return err
+	}
+	err := r.store.interests.insert(interest, func(existing *domain.PropertyInterest) bool {
+		return existing.ClientID == interest.ClientID && existing.InventoryID == interest.InventoryID &&
+			existing.Kind == interest.Kind
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create property interest: %w", err)
+	}
+	return nil
+}
+
+// ListByClient returns the interests of a client, newest first
+func (r *propertyInterestRepository) ListByClient(ctx context.Context, clientID uuid.UUID) ([]*domain.PropertyInterest, error) {
+	return r.store.interests.find(func(i *domain.PropertyInterest) bool {
+		return i.ClientID == clientID && domain.InOrganization(ctx, i.OrganizationID)
+	}, interestSortKeys, newestFirst), nil
+}
+
+// ListByInventory returns the interests shown in a unit, newest first
+func (r *propertyInterestRepository) ListByInventory(ctx context.Context, inventoryID uuid.UUID) ([]*domain.PropertyInterest, error) {
+	return r.store.interests.find(func(i *domain.PropertyInterest) bool {
+		return i.InventoryID == inventoryID && domain.InOrganization(ctx, i.OrganizationID)
+	}, interestSortKeys, newestFirst), nil
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var refundSortKeys = sortKeys[domain.Refund]{
+	"refund_number": func(r *domain.Refund) interface{} { return r.RefundNumber },
+	"refund_date":   func(r *domain.Refund) interface{} { return r.RefundDate },
+	"refund_amount": func(r *domain.Refund) interface{} { return r.RefundAmount },
+	"status":        func(r *domain.Refund) interface{} { return r.Status },
+	"created_at":    func(r *domain.Refund) interface{} { return r.CreatedAt },
+	"updated_at":    func(r *domain.Refund) interface{} { return r.UpdatedAt },
+}
+
+// refundRepository implements domain.RefundRepository in memory
+type refundRepository struct {
+	store *Store
+}
+
+// NewRefundRepository creates a new refund repository
+func NewRefundRepository(store *Store) domain.RefundRepository {
+	return &refundRepository{
+		store: store,
+	}
+}
+
+// Create stores a new refund
+func (r *refundRepository) Create(ctx context.Context, refund *domain.Refund) error {
+	now := time.Now()
+	if refund.CreatedAt.IsZero() {
+		refund.CreatedAt = now
+	}
+	if refund.UpdatedAt.IsZero() {
+		refund.UpdatedAt = refund.CreatedAt
+	}
+
+	if err := r.store.refunds.insert(refund, nil); err != nil {
+		return fmt.Errorf("failed to create refund: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a refund by ID
+func (r *refundRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Refund, error) {
+	refund, err := r.store.refunds.get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refund by ID: %w", err)
+	}
+	return refund, nil
+}
+
+// GetBySale retrieves the refunds issued against a sale
+func (r *refundRepository) GetBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.Refund, error) {
+	return r.store.refunds.find(func(f *domain.Refund) bool {
+		return sameID(f.SaleID, saleID)
+	}, refundSortKeys, newestFirst), nil
+}
+
+// Update updates an existing refund
+func (r *refundRepository) Update(ctx context.Context, refund *domain.Refund) error {
+	refund.UpdatedAt = time.Now()
+
+	if err := r.store.refunds.replace(refund, nil); err != nil {
+		return fmt.Errorf("failed to update refund: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a refund by ID
+func (r *refundRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.store.refunds.remove(id); err != nil {
+		return fmt.Errorf("failed to delete refund: %w", err)
+	}
+	return nil
+}
+
+// List retrieves refunds with pagination and filtering
+func (r *refundRepository) List(ctx context.Context, filters domain.RefundFilters) ([]*domain.Refund, error) {
+	return r.store.refunds.list(refundMatch(filters), filters.BaseFilters, refundSortKeys, newestFirst), nil
+}
+
+func refundMatch(filters domain.RefundFilters) func(*domain.Refund) bool {
+	return func(f *domain.Refund) bool {
+		if filters.SaleID != nil && !sameID(f.SaleID, *filters.SaleID) {
+			return false
+		}
+		if filters.ClientID != nil && f.ClientID != *filters.ClientID {
+			return false
+		}
+		if filters.Status != nil && f.Status != *filters.Status {
+			return false
+		}
+		if filters.RequestedBy != nil && !sameID(f.RequestedBy, *filters.RequestedBy) {
+			return false
+		}
+		if filters.ApprovedBy != nil && !sameID(f.ApprovedBy, *filters.ApprovedBy) {
+			return false
+		}
+		if !inRange(&f.RefundAmount, filters.AmountMin, filters.AmountMax) {
+			return false
+		}
+		return inTimeRange(f.RefundDate, filters.DateFrom, filters.DateTo)
+	}
+}
@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newSeededStore(t *testing.T) *Store {
+	t.Helper()
+
+	store := NewStore()
+	require.NoError(t, Seed(store))
+	return store
+}
+
+func TestSeed_LoadsDemoData(t *testing.T) {
+	store := newSeededStore(t)
+	ctx := domain.WithOrganization(context.Background(), SeedOrganizationID)
+
+	user, err := NewUserRepository(store).GetByEmail(ctx, "manager@goreal.com")
+	require.NoError(t, err)
+	assert.Equal(t, SeedManagerID, user.ID)
+	assert.Equal(t, domain.RoleManager, user.Role)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(DemoPassword)))
+
+	stats, err := NewSaleRepository(store).GetSalesStats(ctx, domain.SaleFilters{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.TotalSales)
+
+	units, err := NewInventoryRepository(store).GetAvailable(ctx, uuid.MustParse("050e8400-e29b-41d4-a716-446655440001"))
+	require.NoError(t, err)
+	require.Len(t, units, 2)
+	assert.Equal(t, "A-101", units[0].UnitNumber)
+
+	assert.ErrorIs(t, Seed(store), domain.ErrAlreadyExists)
+}
+
+func TestLeadRepository_ListFiltersSortsAndPaginates(t *testing.T) {
+	repo := NewLeadRepository(newSeededStore(t))
+	ctx := domain.WithOrganization(context.Background(), SeedOrganizationID)
+
+	status := domain.LeadStatusNew
+	leads, err := repo.List(ctx, domain.LeadFilters{Status: &status})
+	require.NoError(t, err)
+	require.Len(t, leads, 1)
+	assert.Equal(t, "Robert Johnson", leads[0].Name)
+
+	search := "CHEN"
+	leads, err = repo.List(ctx, domain.LeadFilters{Search: &search})
+	require.NoError(t, err)
+	require.Len(t, leads, 1)
+	assert.Equal(t, "Lisa Chen", leads[0].Name)
+
+	min := 500000.0
+	filters := domain.LeadFilters{
+		BaseFilters: domain.BaseFilters{Limit: 2, SortBy: "budget_max", SortOrder: "desc"},
+		BudgetMin:   &min,
+	}
+	leads, err = repo.List(ctx, filters)
+	require.NoError(t, err)
+	require.Len(t, leads, 2)
+	assert.Equal(t, "Jennifer Martinez", leads[0].Name)
+	assert.Equal(t, "Lisa Chen", leads[1].Name)
+
+	count, err := repo.Count(ctx, filters)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	filters.Offset = 2
+	leads, err = repo.List(ctx, filters)
+	require.NoError(t, err)
+	require.Len(t, leads, 1)
+	assert.Equal(t, "Thomas Anderson", leads[0].Name)
+}
+
+func TestLeadRepository_CRUD(t *testing.T) {
+	repo := NewLeadRepository(NewStore())
+	ctx := domain.WithOrganization(context.Background(), SeedOrganizationID)
+
+	lead := &domain.Lead{ID: uuid.New(), Name: "Walk-in", Source: domain.LeadSourceWalkIn, Status: domain.LeadStatusNew}
+	require.NoError(t, repo.Create(ctx, lead))
+	assert.False(t, lead.CreatedAt.IsZero())
+	assert.True(t, errors.Is(repo.Create(ctx, lead), domain.ErrAlreadyExists))
+
+	// Callers must not be able to mutate stored rows through returned pointers
+	got, err := repo.GetByID(ctx, lead.ID)
+	require.NoError(t, err)
+	got.Name = "Changed"
+	got, err = repo.GetByID(ctx, lead.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Walk-in", got.Name)
+
+	got.Status = domain.LeadStatusContacted
+	require.NoError(t, repo.Update(ctx, got))
+	got, err = repo.GetByID(ctx, lead.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LeadStatusContacted, got.Status)
+
+	require.NoError(t, repo.Delete(ctx, lead.ID, lead.CreatedBy))
+	_, err = repo.GetByID(ctx, lead.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	assert.True(t, errors.Is(repo.Update(ctx, lead), domain.ErrNotFound))
+	assert.True(t, errors.Is(repo.Delete(ctx, lead.ID, lead.CreatedBy), domain.ErrNotFound))
+}
+
+func TestUnitOfWork_RollsBackEveryTableOnError(t *testing.T) {
+	store := newSeededStore(t)
+	ctx := domain.WithOrganization(context.Background(), SeedOrganizationID)
+	uow := NewUnitOfWork(store)
+	leads := NewLeadRepository(store)
+	inventory := NewInventoryRepository(store)
+
+	units, err := inventory.GetAvailable(ctx, uuid.MustParse("050e8400-e29b-41d4-a716-446655440001"))
+	require.NoError(t, err)
+	unit := units[0]
+	before, err := leads.Count(ctx, domain.LeadFilters{})
+	require.NoError(t, err)
+
+	boom := errors.New("boom")
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		require.NoError(t, leads.Create(ctx, &domain.Lead{ID: uuid.New(), Name: "Temporary",
+			Source: domain.LeadSourceWebsite, Status: domain.LeadStatusNew, CreatedBy: SeedAdminID}))
+		require.NoError(t, inventory.Reserve(ctx, unit.ID, SeedClient1ID, unit.CreatedAt))
+
+		// Nested units join the outer one
+		return uow.Do(ctx, func(ctx context.Context) error { return boom })
+	})
+	assert.ErrorIs(t, err, boom)
+
+	after, err := leads.Count(ctx, domain.LeadFilters{})
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+	got, err := inventory.GetByID(ctx, unit.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UnitStatusAvailable, got.Status)
+
+	require.NoError(t, uow.Do(ctx, func(ctx context.Context) error {
+		return inventory.Reserve(ctx, unit.ID, SeedClient1ID, unit.CreatedAt)
+	}))
+	got, err = inventory.GetByID(ctx, unit.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UnitStatusReserved, got.Status)
+}
+
+func TestUnitOfWork_RunsCommitHooksOnlyAfterCommit(t *testing.T) {
+	uow := NewUnitOfWork(NewStore())
+	ctx := domain.WithOrganization(context.Background(), SeedOrganizationID)
+
+	var ran []string
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		domain.AfterCommit(ctx, func() { ran = append(ran, "failed") })
+		return errors.New("boom")
+	})
+	require.Error(t, err)
+	assert.Empty(t, ran)
+
+	require.NoError(t, uow.Do(ctx, func(ctx context.Context) error {
+		domain.AfterCommit(ctx, func() { ran = append(ran, "outer") })
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			domain.AfterCommit(ctx, func() { ran = append(ran, "nested") })
+			return nil
+		})
+		assert.Empty(t, ran, "hooks wait for the outermost unit")
+		return err
+	}))
+	assert.Equal(t, []string{"outer", "nested"}, ran)
+
+	domain.AfterCommit(ctx, func() { ran = append(ran, "no unit") })
+	assert.Equal(t, "no unit", ran[2])
+}
@@ -0,0 +1,262 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var saleSortKeys = sortKeys[domain.Sale]{
+	"sale_number":  func(s *domain.Sale) interface{} { return s.SaleNumber },
+	"sale_date":    func(s *domain.Sale) interface{} { return s.SaleDate },
+	"status":       func(s *domain.Sale) interface{} { return s.Status },
+	"total_amount": func(s *domain.Sale) interface{} { return s.TotalAmount },
+	"final_amount": func(s *domain.Sale) interface{} { return s.FinalAmount },
+	"created_at":   func(s *domain.Sale) interface{} { return s.CreatedAt },
+	"updated_at":   func(s *domain.Sale) interface{} { return s.UpdatedAt },
+}
+
+// saleRepository implements domain.SaleRepository in memory
+type saleRepository struct {
+	store *Store
+}
+
+// NewSaleRepository creates a new sale repository
+func NewSaleRepository(store *Store) domain.SaleRepository {
+	return &saleRepository{
+		store: store,
+	}
+}
+
+// Create stores a new sale; sale numbers must be unique
+func (r *saleRepository) Create(ctx context.Context, sale *domain.Sale) error {
+	now := time.Now()
+	if sale.CreatedAt.IsZero() {
+		sale.CreatedAt = now
+	}
+	if sale.UpdatedAt.IsZero() {
+		sale.UpdatedAt = sale.CreatedAt
+	}
+
+	if err := domain.AssignOrganization(ctx, &sale.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.sales.insert(sale, saleConflict(sale)); err != nil {
+		return fmt.Errorf("failed to create sale: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a sale by ID
+func (r *saleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Sale, error) {
+	sale, err := r.store.sales.get(id)
+	if err == nil && (sale.DeletedAt != nil || !domain.InOrganization(ctx, sale.OrganizationID)) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sale by ID: %w", err)
+	}
+	return sale, nil
+}
+
+// GetByClient retrieves sales for a client
+func (r *saleRepository) GetByClient(ctx context.Context, clientID uuid.UUID) ([]*domain.Sale, error) {
+	return r.store.sales.find(func(s *domain.Sale) bool {
+		return s.DeletedAt == nil && domain.InOrganization(ctx, s.OrganizationID) &&
+			s.ClientID == clientID
+	}, saleSortKeys, newestFirst), nil
+}
+
+// GetBySalesperson retrieves sales made by a salesperson
+func (r *saleRepository) GetBySalesperson(ctx context.Context, salespersonID uuid.UUID) ([]*domain.Sale, error) {
+	return r.store.sales.find(func(s *domain.Sale) bool {
+		return s.DeletedAt == nil && domain.InOrganization(ctx, s.OrganizationID) &&
+			sameID(s.SalespersonID, salespersonID)
+	}, saleSortKeys, newestFirst), nil
+}
+
+// Update updates an existing sale
+func (r *saleRepository) Update(ctx context.Context, sale *domain.Sale) error {
+	since := sale.UpdatedAt
+	sale.UpdatedAt = time.Now()
+
+	if err := domain.AssignOrganization(ctx, &sale.OrganizationID); err != nil {
+		return err
+	}
+	check := func(stored *domain.Sale) error {
+		if stored.DeletedAt != nil || !domain.InOrganization(ctx, stored.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		if !stored.UpdatedAt.Equal(since) {
+			return domain.ErrConflict
+		}
+		return nil
+	}
+	if err := r.store.sales.replaceIf(sale, check, saleConflict(sale)); err != nil {
+		sale.UpdatedAt = since
+		return fmt.Errorf("failed to update sale: %w", err)
+	}
+	return nil
+}
+
+// Delete moves a sale to the trash
+func (r *saleRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	err := r.store.sales.modify(id, func(s *domain.Sale) error {
+		if s.DeletedAt != nil || !domain.InOrganization(ctx, s.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		now := time.Now()
+		s.DeletedAt, s.DeletedBy, s.UpdatedAt = &now, &deletedBy, now
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete sale: %w", err)
+	}
+	return nil
+}
+
+// Restore takes a sale out of the trash
+func (r *saleRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	err := r.store.sales.modify(id, func(s *domain.Sale) error {
+		if s.DeletedAt == nil || !domain.InOrganization(ctx, s.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		s.DeletedAt, s.DeletedBy, s.UpdatedAt = nil, nil, time.Now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore sale: %w", err)
+	}
+	return nil
+}
+
+// Purge permanently removes sales that were deleted before the cutoff
+func (r *saleRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return r.store.sales.removeWhere(func(s *domain.Sale) bool {
+		return s.DeletedAt != nil && s.DeletedAt.Before(deletedBefore) && domain.InOrganization(ctx, s.OrganizationID)
+	}), nil
+}
+
+// List retrieves sales with pagination and filtering
+func (r *saleRepository) List(ctx context.Context, filters domain.SaleFilters) ([]*domain.Sale, error) {
+	return r.store.sales.list(r.match(ctx, filters), filters.BaseFilters, saleSortKeys, newestFirst), nil
+}
+
+// Count returns the total number of sales matching the filters
+func (r *saleRepository) Count(ctx context.Context, filters domain.SaleFilters) (int, error) {
+	return r.store.sales.count(r.match(ctx, filters)), nil
+}
+
+// UpdateStatus moves a live sale from one status to another
+func (r *saleRepository) UpdateStatus(ctx context.Context, id uuid.UUID, from, to domain.SaleStatus) error {
+	err := r.store.sales.modify(id, func(s *domain.Sale) error {
+		if s.DeletedAt != nil || !domain.InOrganization(ctx, s.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		if s.Status != from {
+			return domain.ErrConflict
+		}
+		s.Status = to
+		s.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update sale status: %w", err)
+	}
+	return nil
+}
+
+// GetSalesStats aggregates the sales matching the filters
+func (r *saleRepository) GetSalesStats(ctx context.Context, filters domain.SaleFilters) (*domain.SalesStats, error) {
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	yearStart := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+
+	stats := &domain.SalesStats{}
+	for _, sale := range r.store.sales.find(r.match(ctx, filters), nil, order{}) {
+		stats.TotalSales++
+		stats.TotalRevenue += sale.FinalAmount
+
+		switch sale.Status {
+		case domain.SaleStatusPending:
+			stats.PendingSales++
+		case domain.SaleStatusApproved:
+			stats.ApprovedSales++
+		case domain.SaleStatusCompleted:
+			stats.CompletedSales++
+		case domain.SaleStatusCancelled:
+			stats.CancelledSales++
+		}
+
+		if !sale.SaleDate.Before(monthStart) {
+			stats.MonthlyRevenue += sale.FinalAmount
+		}
+		if !sale.SaleDate.Before(yearStart) {
+			stats.YearlyRevenue += sale.FinalAmount
+		}
+	}
+
+	if stats.TotalSales > 0 {
+		stats.AverageValue = stats.TotalRevenue / float64(stats.TotalSales)
+	}
+
+	return stats, nil
+}
+
+// match builds the filter predicate. The project filter is resolved through
+// the inventory table up front, as the SQL backends do with a subquery.
+func (r *saleRepository) match(ctx context.Context, filters domain.SaleFilters) func(*domain.Sale) bool {
+	var projectUnits map[uuid.UUID]bool
+	if filters.ProjectID != nil {
+		projectUnits = make(map[uuid.UUID]bool)
+		for _, unit := range r.store.inventory.find(func(i *domain.Inventory) bool {
+			return i.ProjectID == *filters.ProjectID
+		}, nil, order{}) {
+			projectUnits[unit.ID] = true
+		}
+	}
+
+	return func(s *domain.Sale) bool {
+		if s.DeletedAt != nil && !filters.IncludeDeleted {
+			return false
+		}
+		if !domain.InOrganization(ctx, s.OrganizationID) {
+			return false
+		}
+		if filters.ClientID != nil && s.ClientID != *filters.ClientID {
+			return false
+		}
+		if filters.SalespersonID != nil && !sameID(s.SalespersonID, *filters.SalespersonID) {
+			return false
+		}
+		if filters.ManagerID != nil && !sameID(s.ManagerID, *filters.ManagerID) {
+			return false
+		}
+		if filters.Status != nil && s.Status != *filters.Status {
+			return false
+		}
+		if !inRange(&s.TotalAmount, filters.AmountMin, filters.AmountMax) {
+			return false
+		}
+		if !inTimeRange(&s.SaleDate, filters.SaleDateFrom, filters.SaleDateTo) {
+			return false
+		}
+		if projectUnits != nil && !projectUnits[s.InventoryID] {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" && !matchesSearch(*filters.Search, s.SaleNumber) {
+			return false
+		}
+		return true
+	}
+}
+
+func saleConflict(sale *domain.Sale) func(*domain.Sale) bool {
+	return func(s *domain.Sale) bool {
+		return sale.SaleNumber != "" && s.SaleNumber == sale.SaleNumber
+	}
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var saleHistorySortKeys = sortKeys[domain.SaleStatusHistory]{
+	"created_at": func(h *domain.SaleStatusHistory) interface{} { return h.CreatedAt },
+}
+
+// saleStatusHistoryRepository implements domain.SaleStatusHistoryRepository in memory
+type saleStatusHistoryRepository struct {
+	store *Store
+}
+
+// NewSaleStatusHistoryRepository creates a new sale status history repository
+func NewSaleStatusHistoryRepository(store *Store) domain.SaleStatusHistoryRepository {
+	return &saleStatusHistoryRepository{
+		store: store,
+	}
+}
+
+// Create records a sale transition
+func (r *saleStatusHistoryRepository) Create(ctx context.Context, entry *domain.SaleStatusHistory) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if err := domain.AssignOrganization(ctx, &entry.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.saleHistory.insert(entry, nil); err != nil {
+		return fmt.Errorf("failed to create sale status history: %w", err)
+	}
+	return nil
+}
+
+// ListBySale returns the transitions of a sale, oldest first
+func (r *saleStatusHistoryRepository) ListBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.SaleStatusHistory, error) {
+	return r.store.saleHistory.find(func(h *domain.SaleStatusHistory) bool {
+		return h.SaleID == saleID && domain.InOrganization(ctx, h.OrganizationID)
+	}, saleHistorySortKeys, order{key: "created_at"}), nil
+}
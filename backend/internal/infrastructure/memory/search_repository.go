@@ -0,0 +1,325 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"goreal-backend/internal/domain"
+)
+
+// Weights of the parts of a searched record, as setweight assigns them in
+// 014_crm_search.sql, and the limits the search_crm function applies
+const (
+	weightA = 1.0
+	weightB = 0.4
+	weightC = 0.2
+
+	similarityThreshold = 0.6
+	phoneBonus          = 0.5
+	minPhoneDigits      = 4
+	highlightWords      = 15
+)
+
+// searchRepository implements domain.SearchRepository in memory. It follows
+// the matching and scoring rules of the search_crm SQL function closely
+// enough for results to rank the same way.
+type searchRepository struct {
+	store *Store
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(store *Store) domain.SearchRepository {
+	return &searchRepository{
+		store: store,
+	}
+}
+
+// Search returns the leads, clients, companies and inventory units matching
+// query, best match first
+func (r *searchRepository) Search(ctx context.Context, query domain.SearchQuery) ([]*domain.SearchHit, error) {
+	q := newSearchTerms(query.Text)
+	wanted := func(t domain.SearchEntityType) bool {
+		if len(query.Types) == 0 {
+			return true
+		}
+		for _, want := range query.Types {
+			if want == t {
+				return true
+			}
+		}
+		return false
+	}
+
+	var hits []*domain.SearchHit
+	if wanted(domain.SearchEntityLead) {
+		for _, l := range r.store.leads.find(func(l *domain.Lead) bool {
+			return l.DeletedAt == nil && domain.InOrganization(ctx, l.OrganizationID)
+		}, nil, order{}) {
+			doc := searchDocument{
+				{weightA, []string{l.Name}},
+				{weightB, strs(l.Email, l.CompanyName, l.Designation)},
+				{weightC, strs(l.Requirements, l.Notes)},
+			}
+			if hit := q.match(doc, l.Name, l.Phone); hit != nil {
+				hit.Type, hit.ID, hit.Title = domain.SearchEntityLead, l.ID, l.Name
+				hit.Subtitle = firstOf(l.CompanyName, l.Email)
+				hits = append(hits, hit)
+			}
+		}
+	}
+	if wanted(domain.SearchEntityClient) {
+		for _, c := range r.store.clients.find(func(c *domain.Client) bool {
+			return c.DeletedAt == nil && domain.InOrganization(ctx, c.OrganizationID)
+		}, nil, order{}) {
+			doc := searchDocument{
+				{weightA, []string{c.Name}},
+				{weightB, strs(c.Email)},
+			}
+			if hit := q.match(doc, c.Name, c.Phone); hit != nil {
+				hit.Type, hit.ID, hit.Title = domain.SearchEntityClient, c.ID, c.Name
+				hit.Subtitle = firstOf(c.Email, c.Phone)
+				hits = append(hits, hit)
+			}
+		}
+	}
+	if wanted(domain.SearchEntityCompany) {
+		for _, c := range r.store.companies.find(func(c *domain.Company) bool {
+			return domain.InOrganization(ctx, c.OrganizationID)
+		}, nil, order{}) {
+			doc := searchDocument{
+				{weightA, []string{c.Name}},
+				{weightB, strs(c.Email, c.Website, c.RegistrationNumber)},
+				{weightC, strs(c.Industry)},
+			}
+			if hit := q.match(doc, c.Name, c.Phone); hit != nil {
+				hit.Type, hit.ID, hit.Title = domain.SearchEntityCompany, c.ID, c.Name
+				hit.Subtitle = c.Industry
+				hits = append(hits, hit)
+			}
+		}
+	}
+	if wanted(domain.SearchEntityInventory) {
+		for _, u := range r.store.inventory.find(func(u *domain.Inventory) bool {
+			return domain.InOrganization(ctx, u.OrganizationID)
+		}, nil, order{}) {
+			doc := searchDocument{
+				{weightA, []string{u.UnitNumber}},
+				{weightB, strs(u.TowerBlock, u.UnitType, u.Facing)},
+			}
+			// Units have no name or phone, so they only match on their words
+			if hit := q.match(doc, "", nil); hit != nil {
+				hit.Type, hit.ID, hit.Title = domain.SearchEntityInventory, u.ID, u.UnitNumber
+				var parts []string
+				if project, err := r.store.projects.get(u.ProjectID); err == nil {
+					parts = append(parts, project.Name)
+				}
+				parts = append(parts, strs(u.UnitType)...)
+				if subtitle := strings.Join(parts, ", "); subtitle != "" {
+					hit.Subtitle = &subtitle
+				}
+				hits = append(hits, hit)
+			}
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Title != hits[j].Title {
+			return hits[i].Title < hits[j].Title
+		}
+		return hits[i].ID.String() < hits[j].ID.String()
+	})
+	if query.Limit > 0 && len(hits) > query.Limit {
+		hits = hits[:query.Limit]
+	}
+	if hits == nil {
+		hits = []*domain.SearchHit{}
+	}
+	return hits, nil
+}
+
+// searchDocument is the weighted text of one record
+type searchDocument []struct {
+	weight float64
+	values []string
+}
+
+// searchTerms is a parsed search query
+type searchTerms struct {
+	words    []string
+	trigrams map[string]bool
+	digits   string
+}
+
+func newSearchTerms(text string) *searchTerms {
+	return &searchTerms{
+		words:    searchWords(text),
+		trigrams: trigrams(text),
+		digits:   digitsOf(text),
+	}
+}
+
+// match scores a record. It returns nil when the record matches neither
+// every query word as a word prefix, nor the name fuzzily, nor the phone.
+func (q *searchTerms) match(doc searchDocument, name string, phone *string) *domain.SearchHit {
+	rank, matched := q.rank(doc)
+	similarity := q.similarity(name)
+	phoneMatch := len(q.digits) >= minPhoneDigits && phone != nil && strings.Contains(digitsOf(*phone), q.digits)
+	if !matched && similarity < similarityThreshold && !phoneMatch {
+		return nil
+	}
+
+	score := rank + similarity
+	if phoneMatch {
+		score += phoneBonus
+	}
+	hit := &domain.SearchHit{Score: score}
+	if highlight := q.highlight(doc); highlight != "" {
+		hit.Highlight = &highlight
+	}
+	return hit
+}
+
+// rank is the full-text rank: the mean of the best weight each query word
+// matched with. A record matches only if every word matched.
+func (q *searchTerms) rank(doc searchDocument) (float64, bool) {
+	if len(q.words) == 0 {
+		return 0, false
+	}
+
+	total := 0.0
+	for _, word := range q.words {
+		best := 0.0
+		for _, part := range doc {
+			for _, value := range part.values {
+				for _, w := range searchWords(value) {
+					if strings.HasPrefix(w, word) && part.weight > best {
+						best = part.weight
+					}
+				}
+			}
+		}
+		if best == 0 {
+			return 0, false
+		}
+		total += best
+	}
+	return 0.6 * total / float64(len(q.words)), true
+}
+
+// similarity is pg_trgm's word_similarity of the query and name: the share
+// of the query's trigrams that also occur in name
+func (q *searchTerms) similarity(name string) float64 {
+	if len(q.trigrams) == 0 || name == "" {
+		return 0
+	}
+	target := trigrams(name)
+	common := 0
+	for t := range q.trigrams {
+		if target[t] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(q.trigrams))
+}
+
+// highlight returns up to highlightWords words of the record around the
+// first match, with matching words wrapped in <b> tags like ts_headline
+func (q *searchTerms) highlight(doc searchDocument) string {
+	var words []string
+	for _, part := range doc {
+		for _, value := range part.values {
+			words = append(words, strings.Fields(value)...)
+		}
+	}
+
+	first := -1
+	marked := make([]string, len(words))
+	for i, word := range words {
+		marked[i] = word
+		for _, w := range searchWords(word) {
+			if q.prefixes(w) {
+				marked[i] = "<b>" + word + "</b>"
+				if first < 0 {
+					first = i
+				}
+				break
+			}
+		}
+	}
+
+	start := 0
+	if first > 3 && len(words) > highlightWords {
+		start = first - 3
+	}
+	end := start + highlightWords
+	if end > len(marked) {
+		end = len(marked)
+	}
+	return strings.Join(marked[start:end], " ")
+}
+
+// prefixes reports whether a query word is a prefix of w
+func (q *searchTerms) prefixes(w string) bool {
+	for _, word := range q.words {
+		if strings.HasPrefix(w, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchWords splits text into lower case words of letters and digits
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// trigrams returns the trigram set of text the way pg_trgm builds it: each
+// word is padded with two spaces in front and one behind
+func trigrams(text string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range searchWords(text) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// digitsOf returns the digits of text
+func digitsOf(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, text)
+}
+
+// strs returns the set values among values
+func strs(values ...*string) []string {
+	var out []string
+	for _, v := range values {
+		if v != nil && *v != "" {
+			out = append(out, *v)
+		}
+	}
+	return out
+}
+
+// firstOf returns the first set value among values
+func firstOf(values ...*string) *string {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,459 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DemoPassword is the password of every seeded user
+const DemoPassword = "password123"
+
+// SeedOrganizationID is the default organization created by
+// supabase/migrations/012_organizations.sql; all demo data belongs to it
+var SeedOrganizationID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// Seeded user IDs, shared with supabase/seed.sql and the CRM seed migration
+var (
+	SeedAdminID     = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	SeedManagerID   = uuid.MustParse("550e8400-e29b-41d4-a716-446655440010")
+	SeedEmployee1ID = uuid.MustParse("550e8400-e29b-41d4-a716-446655440011")
+	SeedEmployee2ID = uuid.MustParse("550e8400-e29b-41d4-a716-446655440012")
+	SeedClient1ID   = uuid.MustParse("550e8400-e29b-41d4-a716-446655440013")
+	SeedClient2ID   = uuid.MustParse("550e8400-e29b-41d4-a716-446655440014")
+)
+
+// Seed loads the demo data set from supabase/migrations/008_crm_seed_data.sql
+// into the store. Relative dates are computed from the current time.
+func Seed(store *Store) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(DemoPassword), bcrypt.MinCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash demo password: %w", err)
+	}
+
+	now := time.Now()
+	day := 24 * time.Hour
+	created := func(d time.Duration) time.Time { return now.Add(-d) }
+
+	org := SeedOrganizationID
+	if err := store.organizations.insert(&domain.Organization{
+		ID: org, Name: "Default Organization", Slug: "default", IsActive: true, CreatedAt: created(365 * day), UpdatedAt: created(365 * day),
+	}, nil); err != nil {
+		return fmt.Errorf("failed to seed organizations: %w", err)
+	}
+
+	users := []domain.User{
+		{ID: SeedAdminID, Email: "admin@goreal.com", Username: "admin", FullName: "Platform Admin",
+			Bio: ptr("Platform administrator"), Role: domain.RoleAdmin},
+		{ID: SeedManagerID, Email: "manager@goreal.com", Username: "manager1", FullName: "Sarah Manager",
+			Bio: ptr("Sales manager with 10+ years experience"), Role: domain.RoleManager},
+		{ID: SeedEmployee1ID, Email: "employee1@goreal.com", Username: "employee1", FullName: "John Employee",
+			Bio: ptr("Sales executive specializing in residential properties"), Role: domain.RoleEmployee},
+		{ID: SeedEmployee2ID, Email: "employee2@goreal.com", Username: "employee2", FullName: "Lisa Sales",
+			Bio: ptr("Commercial property specialist"), Role: domain.RoleEmployee},
+		{ID: SeedClient1ID, Email: "client1@goreal.com", Username: "client1", FullName: "Michael Brown",
+			Bio: ptr("Property investor and entrepreneur"), Role: domain.RoleClient},
+		{ID: SeedClient2ID, Email: "client2@goreal.com", Username: "client2", FullName: "Emma Davis",
+			Bio: ptr("First-time home buyer"), Role: domain.RoleClient},
+	}
+	for i := range users {
+		users[i].PasswordHash = string(hash)
+		users[i].IsActive = true
+		users[i].CreatedAt = created(time.Duration(90-i) * day)
+		users[i].UpdatedAt = users[i].CreatedAt
+		users[i].EmailVerifiedAt = &users[i].CreatedAt
+		users[i].OrganizationID = &org
+		if err := store.users.insert(&users[i], nil); err != nil {
+			return fmt.Errorf("failed to seed users: %w", err)
+		}
+	}
+
+	companies := []domain.Company{
+		{ID: id("450e8400-e29b-41d4-a716-446655440001"), Name: "Tech Innovations Ltd", RegistrationNumber: ptr("REG123456"),
+			Industry: ptr("Technology"), Website: ptr("https://techinnovations.com"), Phone: ptr("+1-555-0101"),
+			Email: ptr("info@techinnovations.com"), Address: &domain.Address{Street: "123 Tech Street", City: "San Francisco", State: "CA", Country: "USA", PostalCode: "94105"}},
+		{ID: id("450e8400-e29b-41d4-a716-446655440002"), Name: "Green Energy Corp", RegistrationNumber: ptr("REG789012"),
+			Industry: ptr("Energy"), Website: ptr("https://greenenergy.com"), Phone: ptr("+1-555-0102"),
+			Email: ptr("contact@greenenergy.com"), Address: &domain.Address{Street: "456 Green Ave", City: "Austin", State: "TX", Country: "USA", PostalCode: "73301"}},
+		{ID: id("450e8400-e29b-41d4-a716-446655440003"), Name: "Urban Developers Inc", RegistrationNumber: ptr("REG345678"),
+			Industry: ptr("Real Estate"), Website: ptr("https://urbandevelopers.com"), Phone: ptr("+1-555-0103"),
+			Email: ptr("info@urbandevelopers.com"), Address: &domain.Address{Street: "789 Urban Plaza", City: "New York", State: "NY", Country: "USA", PostalCode: "10001"}},
+	}
+	for i := range companies {
+		companies[i].OrganizationID = &org
+		companies[i].IsActive = true
+		companies[i].CreatedAt = created(time.Duration(60-i) * day)
+		companies[i].UpdatedAt = companies[i].CreatedAt
+		if err := store.companies.insert(&companies[i], nil); err != nil {
+			return fmt.Errorf("failed to seed companies: %w", err)
+		}
+	}
+
+	leads := []domain.Lead{
+		{ID: id("350e8400-e29b-41d4-a716-446655440001"), Name: "Robert Johnson", Email: ptr("robert.j@email.com"), Phone: ptr("+1-555-1001"),
+			CompanyName: ptr("Johnson Enterprises"), Source: domain.LeadSourceWebsite, Status: domain.LeadStatusNew, AssignedTo: &SeedEmployee1ID,
+			BudgetMin: ptr(300000.0), BudgetMax: ptr(500000.0), Requirements: ptr("Looking for 2-3 BHK apartment in downtown area")},
+		{ID: id("350e8400-e29b-41d4-a716-446655440002"), Name: "Lisa Chen", Email: ptr("lisa.chen@email.com"), Phone: ptr("+1-555-1002"),
+			CompanyName: ptr("Chen Holdings"), Source: domain.LeadSourceReferral, Status: domain.LeadStatusQualified, AssignedTo: &SeedEmployee2ID,
+			BudgetMin: ptr(800000.0), BudgetMax: ptr(1200000.0), Requirements: ptr("Commercial space for tech startup")},
+		{ID: id("350e8400-e29b-41d4-a716-446655440003"), Name: "David Wilson", Email: ptr("david.w@email.com"), Phone: ptr("+1-555-1003"),
+			Source: domain.LeadSourceSocialMedia, Status: domain.LeadStatusContacted, AssignedTo: &SeedEmployee1ID,
+			BudgetMin: ptr(200000.0), BudgetMax: ptr(400000.0), Requirements: ptr("First-time home buyer")},
+		{ID: id("350e8400-e29b-41d4-a716-446655440004"), Name: "Jennifer Martinez", Email: ptr("jennifer.m@email.com"), Phone: ptr("+1-555-1004"),
+			CompanyName: ptr("Martinez Corp"), Source: domain.LeadSourceAdvertisement, Status: domain.LeadStatusProposal, AssignedTo: &SeedEmployee2ID,
+			BudgetMin: ptr(1500000.0), BudgetMax: ptr(2500000.0), Requirements: ptr("Office space for expanding business")},
+		{ID: id("350e8400-e29b-41d4-a716-446655440005"), Name: "Thomas Anderson", Email: ptr("thomas.a@email.com"), Phone: ptr("+1-555-1005"),
+			Source: domain.LeadSourceWalkIn, Status: domain.LeadStatusNegotiation, AssignedTo: &SeedEmployee1ID,
+			BudgetMin: ptr(600000.0), BudgetMax: ptr(900000.0), Requirements: ptr("Investment property for rental income")},
+	}
+	for i := range leads {
+		leads[i].Tags = []string{}
+		leads[i].CustomFields = domain.CustomFields{}
+		leads[i].CreatedBy = SeedManagerID
+		leads[i].CreatedAt = created(time.Duration(30-i) * day)
+		leads[i].UpdatedAt = leads[i].CreatedAt
+		leads[i].OrganizationID = &org
+		if err := store.leads.insert(&leads[i], nil); err != nil {
+			return fmt.Errorf("failed to seed leads: %w", err)
+		}
+	}
+
+	clients := []domain.Client{
+		{ID: id("250e8400-e29b-41d4-a716-446655440001"), Name: "Michael Brown", Email: ptr("michael.b@email.com"), Phone: ptr("+1-555-2001"),
+			ClientType: domain.ClientTypeIndividual, Address: &domain.Address{Street: "789 Oak Street", City: "New York", State: "NY", Country: "USA", PostalCode: "10001"}},
+		{ID: id("250e8400-e29b-41d4-a716-446655440002"), Name: "Emma Davis", Email: ptr("emma.d@email.com"), Phone: ptr("+1-555-2002"),
+			ClientType: domain.ClientTypeInvestor, Address: &domain.Address{Street: "321 Pine Avenue", City: "Los Angeles", State: "CA", Country: "USA", PostalCode: "90210"}},
+		{ID: id("250e8400-e29b-41d4-a716-446655440003"), LeadID: ptr(leads[1].ID), Name: "Lisa Chen", Email: ptr("lisa.chen@email.com"), Phone: ptr("+1-555-1002"),
+			ClientType: domain.ClientTypeCorporate, Address: &domain.Address{Street: "456 Business Blvd", City: "Seattle", State: "WA", Country: "USA", PostalCode: "98101"}},
+	}
+	clients[0].AssignedTo, clients[1].AssignedTo, clients[2].AssignedTo = &SeedEmployee1ID, &SeedEmployee1ID, &SeedEmployee2ID
+	for i := range clients {
+		clients[i].KYCDocuments = []string{}
+		clients[i].Tags = []string{}
+		clients[i].CustomFields = domain.CustomFields{}
+		clients[i].CreatedBy = SeedManagerID
+		clients[i].CreatedAt = created(time.Duration(20-i) * day)
+		clients[i].UpdatedAt = clients[i].CreatedAt
+		clients[i].OrganizationID = &org
+		if err := store.clients.insert(&clients[i], nil); err != nil {
+			return fmt.Errorf("failed to seed clients: %w", err)
+		}
+	}
+
+	societies := []domain.Society{
+		{ID: id("150e8400-e29b-41d4-a716-446655440001"), Name: "Green Valley Heights", DeveloperName: ptr("Premium Developers"),
+			Location: "North Bangalore", Latitude: ptr(13.0827), Longitude: ptr(77.5877), TotalArea: ptr(25.5), TotalUnits: ptr(500),
+			Amenities: []string{"Swimming Pool", "Gym", "Clubhouse", "Children Play Area", "Security", "24/7 Power Backup"}},
+		{ID: id("150e8400-e29b-41d4-a716-446655440002"), Name: "Sunset Residency", DeveloperName: ptr("Elite Constructions"),
+			Location: "South Mumbai", Latitude: ptr(18.9220), Longitude: ptr(72.8347), TotalArea: ptr(15.2), TotalUnits: ptr(300),
+			Amenities: []string{"Rooftop Garden", "Parking", "Elevator", "Power Backup", "Water Supply", "CCTV Surveillance"}},
+		{ID: id("150e8400-e29b-41d4-a716-446655440003"), Name: "Tech Park Plaza", DeveloperName: ptr("Urban Developers Inc"),
+			Location: "Gurgaon", Latitude: ptr(28.4595), Longitude: ptr(77.0266), TotalArea: ptr(35.8), TotalUnits: ptr(200),
+			Amenities: []string{"Business Center", "Conference Rooms", "Cafeteria", "Parking", "High-Speed Internet"}},
+	}
+	for i := range societies {
+		societies[i].Images = []string{}
+		societies[i].OrganizationID = &org
+		societies[i].IsActive = true
+		societies[i].CreatedBy = SeedManagerID
+		societies[i].CreatedAt = created(time.Duration(120-i) * day)
+		societies[i].UpdatedAt = societies[i].CreatedAt
+		if err := store.societies.insert(&societies[i], nil); err != nil {
+			return fmt.Errorf("failed to seed societies: %w", err)
+		}
+	}
+
+	projects := []domain.Project{
+		{ID: id("050e8400-e29b-41d4-a716-446655440001"), SocietyID: societies[0].ID, Name: "Tower A - Premium Apartments",
+			ProjectType: ptr("residential"), Status: domain.ProjectStatusUnderConstruction, TotalUnits: ptr(150), AvailableUnits: ptr(120),
+			BasePrice: ptr(4500000.0), PricePerSqft: ptr(4500.0)},
+		{ID: id("050e8400-e29b-41d4-a716-446655440002"), SocietyID: societies[0].ID, Name: "Tower B - Luxury Villas",
+			ProjectType: ptr("residential"), Status: domain.ProjectStatusPlanning, TotalUnits: ptr(50), AvailableUnits: ptr(50),
+			BasePrice: ptr(8500000.0), PricePerSqft: ptr(5500.0)},
+		{ID: id("050e8400-e29b-41d4-a716-446655440003"), SocietyID: societies[1].ID, Name: "Sunset Commercial Complex",
+			ProjectType: ptr("commercial"), Status: domain.ProjectStatusApproved, TotalUnits: ptr(100), AvailableUnits: ptr(85),
+			BasePrice: ptr(12000000.0), PricePerSqft: ptr(8000.0)},
+		{ID: id("050e8400-e29b-41d4-a716-446655440004"), SocietyID: societies[2].ID, Name: "Tech Park Office Spaces",
+			ProjectType: ptr("commercial"), Status: domain.ProjectStatusCompleted, TotalUnits: ptr(80), AvailableUnits: ptr(25),
+			BasePrice: ptr(15000000.0), PricePerSqft: ptr(10000.0)},
+	}
+	for i := range projects {
+		projects[i].FloorPlans, projects[i].Images, projects[i].Videos, projects[i].Amenities = []string{}, []string{}, []string{}, []string{}
+		projects[i].Approvals, projects[i].NearbyFacilities = domain.CustomFields{}, []domain.NearbyFacility{}
+		projects[i].OrganizationID = &org
+		projects[i].CreatedBy = SeedManagerID
+		projects[i].CreatedAt = created(time.Duration(100-i) * day)
+		projects[i].UpdatedAt = projects[i].CreatedAt
+		if err := store.projects.insert(&projects[i], nil); err != nil {
+			return fmt.Errorf("failed to seed projects: %w", err)
+		}
+	}
+
+	unit := func(uid string, project int, number string, floor int, unitType string, carpet, builtUp, superBuiltUp float64,
+		facing string, status domain.UnitStatus, base, final float64) domain.Inventory {
+		return domain.Inventory{ID: id(uid), ProjectID: projects[project].ID, UnitNumber: number, FloorNumber: ptr(floor),
+			UnitType: ptr(unitType), CarpetArea: ptr(carpet), BuiltUpArea: ptr(builtUp), SuperBuiltUpArea: ptr(superBuiltUp),
+			Facing: ptr(facing), Status: status, BasePrice: ptr(base), FinalPrice: ptr(final)}
+	}
+	inventory := []domain.Inventory{
+		unit("950e8400-e29b-41d4-a716-446655440001", 0, "A-101", 1, "2BHK", 850, 1000, 1200, "North", domain.UnitStatusAvailable, 4200000, 4200000),
+		unit("950e8400-e29b-41d4-a716-446655440002", 0, "A-102", 1, "3BHK", 1200, 1400, 1650, "South", domain.UnitStatusAvailable, 6300000, 6300000),
+		unit("950e8400-e29b-41d4-a716-446655440003", 0, "A-201", 2, "2BHK", 850, 1000, 1200, "East", domain.UnitStatusReserved, 4300000, 4300000),
+		unit("950e8400-e29b-41d4-a716-446655440004", 1, "B-V01", 0, "Villa", 2500, 3000, 3500, "West", domain.UnitStatusAvailable, 8500000, 8500000),
+		unit("950e8400-e29b-41d4-a716-446655440005", 2, "C-101", 1, "Office", 1500, 1800, 2000, "North", domain.UnitStatusSold, 12000000, 11500000),
+	}
+	for i := range inventory {
+		inventory[i].Features = []string{}
+		inventory[i].CreatedBy = SeedManagerID
+		inventory[i].CreatedAt = created(time.Duration(80-i) * day)
+		inventory[i].UpdatedAt = inventory[i].CreatedAt
+		inventory[i].OrganizationID = &org
+		if err := store.inventory.insert(&inventory[i], nil); err != nil {
+			return fmt.Errorf("failed to seed inventory: %w", err)
+		}
+	}
+
+	sales := []domain.Sale{
+		{ID: id("850e8400-e29b-41d4-a716-446655440001"), SaleNumber: "SAL202400001", ClientID: clients[0].ID, InventoryID: inventory[2].ID,
+			SalespersonID: &SeedEmployee1ID, Status: domain.SaleStatusPending, TotalAmount: 4300000, FinalAmount: 4300000,
+			BookingAmount: ptr(500000.0), CreatedBy: SeedEmployee1ID},
+		{ID: id("850e8400-e29b-41d4-a716-446655440002"), SaleNumber: "SAL202400002", ClientID: clients[1].ID, InventoryID: inventory[0].ID,
+			SalespersonID: &SeedEmployee1ID, Status: domain.SaleStatusApproved, TotalAmount: 4200000, DiscountAmount: 200000, FinalAmount: 4000000,
+			BookingAmount: ptr(400000.0), CreatedBy: SeedEmployee1ID},
+		{ID: id("850e8400-e29b-41d4-a716-446655440003"), SaleNumber: "SAL202400003", ClientID: clients[2].ID, InventoryID: inventory[4].ID,
+			SalespersonID: &SeedEmployee2ID, Status: domain.SaleStatusCompleted, TotalAmount: 12000000, DiscountAmount: 500000, FinalAmount: 11500000,
+			BookingAmount: ptr(1000000.0), CreatedBy: SeedEmployee2ID},
+	}
+	for i := range sales {
+		sales[i].ManagerID = &SeedManagerID
+		sales[i].PaymentPlan = domain.CustomFields{}
+		sales[i].Documents = []string{}
+		sales[i].SaleDate = created(time.Duration(10-i) * day)
+		sales[i].CreatedAt = sales[i].SaleDate
+		sales[i].UpdatedAt = sales[i].SaleDate
+		sales[i].OrganizationID = &org
+		if err := store.sales.insert(&sales[i], nil); err != nil {
+			return fmt.Errorf("failed to seed sales: %w", err)
+		}
+	}
+
+	transition := func(uid string, sale int, from, to domain.SaleStatus, at time.Duration) domain.SaleStatusHistory {
+		return domain.SaleStatusHistory{ID: id(uid), OrganizationID: &org, SaleID: sales[sale].ID,
+			FromStatus: from, ToStatus: to, ChangedBy: SeedManagerID, CreatedAt: created(at)}
+	}
+	history := []domain.SaleStatusHistory{
+		transition("860e8400-e29b-41d4-a716-446655440001", 1, domain.SaleStatusPending, domain.SaleStatusApproved, 8*day),
+		transition("860e8400-e29b-41d4-a716-446655440002", 2, domain.SaleStatusPending, domain.SaleStatusApproved, 6*day),
+		transition("860e8400-e29b-41d4-a716-446655440003", 2, domain.SaleStatusApproved, domain.SaleStatusCompleted, 3*day),
+	}
+	for i := range history {
+		if err := store.saleHistory.insert(&history[i], nil); err != nil {
+			return fmt.Errorf("failed to seed sale status history: %w", err)
+		}
+	}
+
+	task := func(uid, title, description string, assignee uuid.UUID, relatedType string, relatedID uuid.UUID,
+		status domain.TaskStatus, priority domain.TaskPriority, due time.Duration) domain.Task {
+		dueDate := now.Add(due)
+		return domain.Task{ID: id(uid), Title: title, Description: ptr(description), AssignedTo: ptr(assignee),
+			AssignedBy: &SeedManagerID, RelatedToType: ptr(relatedType), RelatedToID: ptr(relatedID),
+			Status: status, Priority: priority, DueDate: &dueDate}
+	}
+	tasks := []domain.Task{
+		task("750e8400-e29b-41d4-a716-446655440001", "Follow up with Robert Johnson", "Call to discuss property requirements and schedule site visit",
+			SeedEmployee1ID, "lead", leads[0].ID, domain.TaskStatusPending, domain.TaskPriorityHigh, 2*day),
+		task("750e8400-e29b-41d4-a716-446655440002", "Prepare sales agreement", "Draft sales agreement for Michael Brown",
+			SeedEmployee1ID, "sale", sales[0].ID, domain.TaskStatusInProgress, domain.TaskPriorityUrgent, day),
+		task("750e8400-e29b-41d4-a716-446655440003", "Site visit with Lisa Chen", "Show commercial properties to potential client",
+			SeedEmployee2ID, "lead", leads[1].ID, domain.TaskStatusCompleted, domain.TaskPriorityMedium, -day),
+		task("750e8400-e29b-41d4-a716-446655440004", "Update project status", "Update Tower A construction progress",
+			SeedEmployee1ID, "project", projects[0].ID, domain.TaskStatusPending, domain.TaskPriorityLow, 7*day),
+	}
+	for i := range tasks {
+		if tasks[i].Status == domain.TaskStatusCompleted {
+			tasks[i].CompletedAt = tasks[i].DueDate
+		}
+		tasks[i].Tags, tasks[i].Attachments = []string{}, []string{}
+		tasks[i].CreatedBy = SeedManagerID
+		tasks[i].CreatedAt = created(time.Duration(5-i) * day)
+		tasks[i].UpdatedAt = tasks[i].CreatedAt
+		tasks[i].OrganizationID = &org
+		if err := store.tasks.insert(&tasks[i], nil); err != nil {
+			return fmt.Errorf("failed to seed tasks: %w", err)
+		}
+	}
+
+	followUps := []domain.FollowUp{
+		{ID: id("650e8400-e29b-41d4-a716-446655440001"), LeadID: ptr(leads[0].ID), AssignedTo: &SeedEmployee1ID, FollowUpDate: now.Add(day),
+			FollowUpType: "call", Status: "scheduled", Notes: ptr("Initial discussion about requirements")},
+		{ID: id("650e8400-e29b-41d4-a716-446655440002"), LeadID: ptr(leads[1].ID), AssignedTo: &SeedEmployee2ID, FollowUpDate: now.Add(3 * day),
+			FollowUpType: "site_visit", Status: "scheduled", Notes: ptr("Show commercial properties")},
+		{ID: id("650e8400-e29b-41d4-a716-446655440003"), LeadID: ptr(leads[2].ID), AssignedTo: &SeedEmployee1ID, FollowUpDate: now.Add(-2 * day),
+			FollowUpType: "email", Status: "completed", Notes: ptr("Sent property brochures and pricing")},
+		{ID: id("650e8400-e29b-41d4-a716-446655440004"), LeadID: ptr(leads[4].ID), AssignedTo: &SeedEmployee1ID, FollowUpDate: now.Add(5 * day),
+			FollowUpType: "meeting", Status: "scheduled", Notes: ptr("Final negotiation meeting")},
+	}
+	for i := range followUps {
+		followUps[i].CreatedBy = SeedManagerID
+		followUps[i].CreatedAt = created(time.Duration(4-i) * day)
+		followUps[i].UpdatedAt = followUps[i].CreatedAt
+		if err := store.followUps.insert(&followUps[i], nil); err != nil {
+			return fmt.Errorf("failed to seed follow-ups: %w", err)
+		}
+	}
+
+	entry := func(uid, number string, txType domain.TransactionType, category, description string, amount float64,
+		method string, createdBy uuid.UUID) domain.Cashbook {
+		return domain.Cashbook{ID: id(uid), TransactionNumber: number, TransactionType: txType, Category: ptr(category),
+			Description: description, Amount: amount, PaymentMethod: ptr(method), CreatedBy: createdBy}
+	}
+	cashbook := []domain.Cashbook{
+		entry("550e8400-e29b-41d4-a716-446655440001", "TXN202400001", domain.TransactionTypeIncome, "booking",
+			"Booking amount received from Michael Brown", 500000, "bank_transfer", SeedEmployee1ID),
+		entry("550e8400-e29b-41d4-a716-446655440002", "TXN202400002", domain.TransactionTypeExpense, "marketing",
+			"Digital marketing campaign for Q4", 25000, "card", SeedManagerID),
+		entry("550e8400-e29b-41d4-a716-446655440003", "TXN202400003", domain.TransactionTypeIncome, "commission",
+			"Commission from Emma Davis sale", 120000, "bank_transfer", SeedEmployee1ID),
+		entry("550e8400-e29b-41d4-a716-446655440004", "TXN202400004", domain.TransactionTypeExpense, "utilities",
+			"Office electricity and water bills", 8500, "bank_transfer", SeedManagerID),
+		entry("550e8400-e29b-41d4-a716-446655440005", "TXN202400005", domain.TransactionTypeIncome, "sale",
+			"Final payment from Lisa Chen", 10500000, "bank_transfer", SeedEmployee2ID),
+	}
+	for i := range cashbook {
+		cashbook[i].Attachments = []string{}
+		cashbook[i].TransactionDate = created(time.Duration(5-i) * day)
+		cashbook[i].CreatedAt = cashbook[i].TransactionDate
+		cashbook[i].UpdatedAt = cashbook[i].TransactionDate
+		if err := store.cashbook.insert(&cashbook[i], nil); err != nil {
+			return fmt.Errorf("failed to seed cashbook: %w", err)
+		}
+	}
+
+	voucher := func(uid, number, voucherType, party string, amount float64, description string,
+		status domain.ApprovalStatus, requestedBy uuid.UUID) domain.Voucher {
+		return domain.Voucher{ID: id(uid), VoucherNumber: number, VoucherType: ptr(voucherType), PartyName: ptr(party),
+			Amount: amount, Description: description, Status: status, RequestedBy: ptr(requestedBy)}
+	}
+	vouchers := []domain.Voucher{
+		voucher("450e8400-e29b-41d4-a716-446655440001", "VOU202400001", "payment", "Office Supplies Inc", 15000,
+			"Office furniture and supplies for new branch", domain.ApprovalStatusPending, SeedEmployee1ID),
+		voucher("450e8400-e29b-41d4-a716-446655440002", "VOU202400002", "receipt", "Michael Brown", 500000,
+			"Booking amount received for A-201", domain.ApprovalStatusApproved, SeedEmployee1ID),
+		voucher("450e8400-e29b-41d4-a716-446655440003", "VOU202400003", "payment", "Marketing Agency Ltd", 50000,
+			"Social media marketing campaign", domain.ApprovalStatusApproved, SeedManagerID),
+		voucher("450e8400-e29b-41d4-a716-446655440004", "VOU202400004", "journal", "Commission Adjustment", 5000,
+			"Adjustment for overpaid commission", domain.ApprovalStatusPending, SeedManagerID),
+	}
+	for i := range vouchers {
+		vouchers[i].BankDetails = domain.CustomFields{}
+		vouchers[i].Attachments = []string{}
+		vouchers[i].VoucherDate = created(time.Duration(4-i) * day)
+		vouchers[i].CreatedAt = vouchers[i].VoucherDate
+		vouchers[i].UpdatedAt = vouchers[i].VoucherDate
+		if err := store.vouchers.insert(&vouchers[i], nil); err != nil {
+			return fmt.Errorf("failed to seed vouchers: %w", err)
+		}
+	}
+
+	installment := func(uid string, sale, number int, due time.Duration, amount float64, description string) domain.PaymentSchedule {
+		return domain.PaymentSchedule{ID: id(uid), SaleID: sales[sale].ID, InstallmentNumber: number, DueDate: now.Add(due),
+			Amount: amount, Description: ptr(description), Status: domain.PaymentStatusPending}
+	}
+	schedules := []domain.PaymentSchedule{
+		installment("350e8400-e29b-41d4-a716-446655440001", 0, 1, 30*day, 1000000, "First installment - 30 days"),
+		installment("350e8400-e29b-41d4-a716-446655440002", 0, 2, 90*day, 1000000, "Second installment - 90 days"),
+		installment("350e8400-e29b-41d4-a716-446655440003", 0, 3, 180*day, 1800000, "Final installment - 180 days"),
+		installment("350e8400-e29b-41d4-a716-446655440004", 1, 1, 15*day, 800000, "First installment - 15 days"),
+		installment("350e8400-e29b-41d4-a716-446655440005", 1, 2, 60*day, 2800000, "Final installment - 60 days"),
+	}
+	for i := range schedules {
+		schedules[i].OrganizationID = &org
+		schedules[i].CreatedAt = sales[0].CreatedAt
+		schedules[i].UpdatedAt = schedules[i].CreatedAt
+		if err := store.paymentSchedules.insert(&schedules[i], nil); err != nil {
+			return fmt.Errorf("failed to seed payment schedules: %w", err)
+		}
+	}
+
+	commission := func(uid string, sale int, employee uuid.UUID, commissionType string, rate, amount float64,
+		status domain.PaymentStatus) domain.Commission {
+		return domain.Commission{ID: id(uid), OrganizationID: &org, SaleID: sales[sale].ID, EmployeeID: ptr(employee),
+			CommissionType: commissionType, CommissionRate: ptr(rate), CommissionAmount: amount, Status: status}
+	}
+	commissions := []domain.Commission{
+		commission("250e8400-e29b-41d4-a716-446655440001", 0, SeedEmployee1ID, "primary", 2.5, 107500, domain.PaymentStatusPending),
+		commission("250e8400-e29b-41d4-a716-446655440002", 1, SeedEmployee1ID, "primary", 3.0, 120000, domain.PaymentStatusPaid),
+		commission("250e8400-e29b-41d4-a716-446655440003", 2, SeedEmployee2ID, "primary", 2.0, 230000, domain.PaymentStatusPaid),
+		commission("250e8400-e29b-41d4-a716-446655440004", 0, SeedManagerID, "manager", 0.5, 21500, domain.PaymentStatusPending),
+		commission("250e8400-e29b-41d4-a716-446655440005", 2, SeedManagerID, "manager", 0.5, 57500, domain.PaymentStatusPaid),
+	}
+	for i := range commissions {
+		commissions[i].CreatedAt = sales[0].CreatedAt
+		commissions[i].UpdatedAt = commissions[i].CreatedAt
+		if err := store.commissions.insert(&commissions[i], nil); err != nil {
+			return fmt.Errorf("failed to seed commissions: %w", err)
+		}
+	}
+
+	nft := func(uid string, property int, tokenID int64, name, description string, value float64, supply int,
+		pricePerToken float64) domain.RealEstateNFT {
+		return domain.RealEstateNFT{ID: id(uid), PropertyID: ptr(inventory[property].ID), TokenID: tokenID,
+			ContractAddress: "0x1234567890123456789012345678901234567890", Name: name, Description: ptr(description),
+			PropertyType: domain.PropertyTypeResidential, TotalValue: ptr(value), TokenSupply: ptr(supply),
+			PricePerToken: ptr(pricePerToken), Currency: "ETH", CreatorID: &SeedManagerID}
+	}
+	nfts := []domain.RealEstateNFT{
+		nft("850e8400-e29b-41d4-a716-446655440001", 0, 1001, "Green Valley Heights A-101 NFT",
+			"Fractional ownership of premium 2BHK apartment in Green Valley Heights", 4200000, 100, 42000),
+		nft("850e8400-e29b-41d4-a716-446655440002", 1, 1002, "Green Valley Heights A-102 NFT",
+			"Fractional ownership of luxury 3BHK apartment in Green Valley Heights", 6300000, 150, 42000),
+		nft("850e8400-e29b-41d4-a716-446655440003", 3, 1003, "Green Valley Villa B-V01 NFT",
+			"Fractional ownership of luxury villa in Green Valley Heights", 8500000, 200, 42500),
+	}
+	for i := range nfts {
+		nfts[i].Attributes = domain.CustomFields{}
+		nfts[i].CreatedAt = created(time.Duration(15-i) * day)
+		nfts[i].UpdatedAt = nfts[i].CreatedAt
+		if err := store.nfts.insert(&nfts[i], nil); err != nil {
+			return fmt.Errorf("failed to seed NFTs: %w", err)
+		}
+	}
+
+	notification := func(user uuid.UUID, notificationType domain.NotificationType, title, message, key string,
+		ref uuid.UUID) domain.Notification {
+		return domain.Notification{ID: uuid.New(), UserID: user, Type: notificationType, Title: title, Message: message,
+			Data: map[string]interface{}{key: ref.String()}}
+	}
+	notifications := []domain.Notification{
+		notification(SeedEmployee1ID, domain.NotificationTypeSystem, "New Lead Assigned",
+			"You have been assigned a new lead: Robert Johnson", "lead_id", leads[0].ID),
+		notification(SeedEmployee1ID, domain.NotificationTypeSystem, "Task Due Soon",
+			`Task "Follow up with Robert Johnson" is due in 2 days`, "task_id", tasks[0].ID),
+		notification(SeedEmployee2ID, domain.NotificationTypeSystem, "Sale Completed",
+			"Congratulations! Your sale to Lisa Chen has been completed", "sale_id", sales[2].ID),
+		notification(SeedManagerID, domain.NotificationTypeSystem, "Voucher Pending Approval",
+			"Voucher VOU202400001 is pending your approval", "voucher_id", vouchers[0].ID),
+		notification(SeedClient1ID, domain.NotificationTypeProperty, "New Property Available",
+			"A new property matching your criteria has been listed", "property_id", inventory[0].ID),
+	}
+	for i := range notifications {
+		notifications[i].CreatedAt = created(time.Duration(len(notifications)-i) * time.Hour)
+		if err := store.notifications.insert(&notifications[i], nil); err != nil {
+			return fmt.Errorf("failed to seed notifications: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func id(s string) uuid.UUID {
+	return uuid.MustParse(s)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
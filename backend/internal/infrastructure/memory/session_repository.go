@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var sessionSortKeys = sortKeys[domain.Session]{
+	"last_used_at": func(s *domain.Session) interface{} { return s.LastUsedAt },
+}
+
+// sessionRepository implements domain.SessionRepository in memory
+type sessionRepository struct {
+	store *Store
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(store *Store) domain.SessionRepository {
+	return &sessionRepository{
+		store: store,
+	}
+}
+
+// Create stores a new session
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = time.Now()
+	}
+	if session.LastUsedAt.IsZero() {
+		session.LastUsedAt = session.CreatedAt
+	}
+
+	if err := r.store.sessions.insert(session, nil); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a session by ID
+func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	session, err := r.store.sessions.get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, nil
+}
+
+// ListActive returns the sessions of a user still usable at a time, most
+// recently used first
+func (r *sessionRepository) ListActive(ctx context.Context, userID uuid.UUID, at time.Time) ([]*domain.Session, error) {
+	return r.store.sessions.find(func(s *domain.Session) bool {
+		return s.UserID == userID && s.Active(at)
+	}, sessionSortKeys, order{key: "last_used_at", desc: true}), nil
+}
+
+// Rotate replaces the refresh token of an active session still holding oldHash
+func (r *sessionRepository) Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash string, expiresAt, usedAt time.Time) error {
+	err := r.store.sessions.modify(id, func(s *domain.Session) error {
+		if !s.Active(usedAt) || s.TokenHash != oldHash {
+			return domain.ErrNotFound
+		}
+		s.TokenHash = newHash
+		s.Rotations++
+		s.ExpiresAt = expiresAt
+		s.LastUsedAt = usedAt
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rotate session: %w", err)
+	}
+	return nil
+}
+
+// Revoke ends a session
+func (r *sessionRepository) Revoke(ctx context.Context, id uuid.UUID, reason domain.RevokeReason, at time.Time) error {
+	err := r.store.sessions.modify(id, func(s *domain.Session) error {
+		if s.RevokedAt == nil {
+			s.RevokedAt, s.RevokedReason = &at, &reason
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// RevokeByUser ends every active session of a user
+func (r *sessionRepository) RevokeByUser(ctx context.Context, userID uuid.UUID, reason domain.RevokeReason, at time.Time) (int, error) {
+	return r.store.sessions.modifyWhere(func(s *domain.Session) bool {
+		return s.UserID == userID && s.Active(at)
+	}, func(s *domain.Session) {
+		s.RevokedAt, s.RevokedReason = &at, &reason
+	}), nil
+}
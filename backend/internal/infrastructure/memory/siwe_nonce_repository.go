@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+)
+
+// siweNonceRepository implements domain.SIWENonceRepository in memory
+type siweNonceRepository struct {
+	store *Store
+}
+
+// NewSIWENonceRepository creates a new SIWE nonce repository
+func NewSIWENonceRepository(store *Store) domain.SIWENonceRepository {
+	return &siweNonceRepository{
+		store: store,
+	}
+}
+
+// Create stores a new nonce
+func (r *siweNonceRepository) Create(ctx context.Context, nonce *domain.SIWENonce) error {
+	if nonce.CreatedAt.IsZero() {
+		nonce.CreatedAt = time.Now()
+	}
+
+	err := r.store.siweNonces.insert(nonce, func(n *domain.SIWENonce) bool {
+		return n.Nonce == nonce.Nonce
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create SIWE nonce: %w", err)
+	}
+	return nil
+}
+
+// Consume redeems an unused, unexpired nonce
+func (r *siweNonceRepository) Consume(ctx context.Context, nonce string, at time.Time) error {
+	usable := func(n *domain.SIWENonce) bool {
+		return n.Nonce == nonce && n.UsedAt == nil && at.Before(n.ExpiresAt)
+	}
+
+	stored, err := r.store.siweNonces.first(usable)
+	if err == nil {
+		// Checked again under the table's lock so that a nonce redeemed
+		// concurrently is only redeemed once
+		err = r.store.siweNonces.modify(stored.ID, func(n *domain.SIWENonce) error {
+			if !usable(n) {
+				return domain.ErrNotFound
+			}
+			n.UsedAt = &at
+			return nil
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to consume SIWE nonce: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var societySortKeys = sortKeys[domain.Society]{
+	"name":       func(s *domain.Society) interface{} { return s.Name },
+	"location":   func(s *domain.Society) interface{} { return s.Location },
+	"created_at": func(s *domain.Society) interface{} { return s.CreatedAt },
+	"updated_at": func(s *domain.Society) interface{} { return s.UpdatedAt },
+}
+
+// societyRepository implements domain.SocietyRepository in memory
+type societyRepository struct {
+	store *Store
+}
+
+// NewSocietyRepository creates a new society repository
+func NewSocietyRepository(store *Store) domain.SocietyRepository {
+	return &societyRepository{
+		store: store,
+	}
+}
+
+// Create stores a new society
+func (r *societyRepository) Create(ctx context.Context, society *domain.Society) error {
+	now := time.Now()
+	if society.CreatedAt.IsZero() {
+		society.CreatedAt = now
+	}
+	if society.UpdatedAt.IsZero() {
+		society.UpdatedAt = society.CreatedAt
+	}
+
+	if err := domain.AssignOrganization(ctx, &society.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.societies.insert(society, nil); err != nil {
+		return fmt.Errorf("failed to create society: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a society by ID
+func (r *societyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Society, error) {
+	society, err := r.store.societies.get(id)
+	if err == nil && !domain.InOrganization(ctx, society.OrganizationID) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get society by ID: %w", err)
+	}
+	return society, nil
+}
+
+// Update updates an existing society
+func (r *societyRepository) Update(ctx context.Context, society *domain.Society) error {
+	since := society.UpdatedAt
+	society.UpdatedAt = time.Now()
+
+	if err := domain.AssignOrganization(ctx, &society.OrganizationID); err != nil {
+		return err
+	}
+	check := func(stored *domain.Society) error {
+		if !domain.InOrganization(ctx, stored.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		return nil
+	}
+	if err := r.store.societies.replaceIf(society, check, nil); err != nil {
+		society.UpdatedAt = since
+		return fmt.Errorf("failed to update society: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a society by ID
+func (r *societyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	removed := r.store.societies.removeWhere(func(s *domain.Society) bool {
+		return s.ID == id && domain.InOrganization(ctx, s.OrganizationID)
+	})
+	if removed == 0 {
+		return fmt.Errorf("failed to delete society: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// List retrieves societies with pagination and filtering
+func (r *societyRepository) List(ctx context.Context, filters domain.SocietyFilters) ([]*domain.Society, error) {
+	return r.store.societies.list(societyMatch(ctx, filters), filters.BaseFilters, societySortKeys, newestFirst), nil
+}
+
+// Count returns the total number of societies matching the filters
+func (r *societyRepository) Count(ctx context.Context, filters domain.SocietyFilters) (int, error) {
+	return r.store.societies.count(societyMatch(ctx, filters)), nil
+}
+
+func societyMatch(ctx context.Context, filters domain.SocietyFilters) func(*domain.Society) bool {
+	return func(s *domain.Society) bool {
+		if !domain.InOrganization(ctx, s.OrganizationID) {
+			return false
+		}
+		if filters.Location != nil && !matchesSearch(*filters.Location, s.Location) {
+			return false
+		}
+		if filters.IsActive != nil && s.IsActive != *filters.IsActive {
+			return false
+		}
+		if filters.CreatedBy != nil && s.CreatedBy != *filters.CreatedBy {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, s.Name, s.DeveloperName, s.Location, s.Description) {
+			return false
+		}
+		return true
+	}
+}
@@ -0,0 +1,543 @@
+package memory
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// Store holds the tables shared by the in-memory repositories.
+// It is safe for concurrent use and is intended for tests and demo mode.
+type Store struct {
+	organizations          *table[domain.Organization]
+	users                  *table[domain.User]
+	leads                  *table[domain.Lead]
+	clients                *table[domain.Client]
+	companies              *table[domain.Company]
+	societies              *table[domain.Society]
+	projects               *table[domain.Project]
+	inventory              *table[domain.Inventory]
+	interests              *table[domain.PropertyInterest]
+	sales                  *table[domain.Sale]
+	saleHistory            *table[domain.SaleStatusHistory]
+	agreementTemplates     *table[domain.AgreementTemplate]
+	agreements             *table[domain.Agreement]
+	priceLists             *table[domain.PriceList]
+	tasks                  *table[domain.Task]
+	followUps              *table[domain.FollowUp]
+	cashbook               *table[domain.Cashbook]
+	vouchers               *table[domain.Voucher]
+	refunds                *table[domain.Refund]
+	paymentSchedules       *table[domain.PaymentSchedule]
+	paymentRevisions       *table[domain.PaymentScheduleRevision]
+	commissions            *table[domain.Commission]
+	commissionPlans        *table[domain.CommissionPlan]
+	nfts                   *table[domain.RealEstateNFT]
+	listings               *table[domain.NFTListing]
+	blockchainTransactions *table[domain.BlockchainTransaction]
+	challenges             *table[domain.Challenge]
+	films                  *table[domain.Film]
+	notifications          *table[domain.Notification]
+	sessions               *table[domain.Session]
+	authTokens             *table[domain.AuthToken]
+	mfaEnrollments         *table[domain.MFAEnrollment]
+	recoveryCodes          *table[domain.MFARecoveryCode]
+	authEvents             *table[domain.AuthEvent]
+	wallets                *table[domain.Wallet]
+	siweNonces             *table[domain.SIWENonce]
+
+	// units serialises units of work; see unitOfWork
+	units sync.Mutex
+}
+
+// NewStore creates an empty store
+func NewStore() *Store {
+	return &Store{
+		organizations:          newTable(func(o *domain.Organization) uuid.UUID { return o.ID }),
+		users:                  newTable(func(u *domain.User) uuid.UUID { return u.ID }),
+		leads:                  newTable(func(l *domain.Lead) uuid.UUID { return l.ID }),
+		clients:                newTable(func(c *domain.Client) uuid.UUID { return c.ID }),
+		companies:              newTable(func(c *domain.Company) uuid.UUID { return c.ID }),
+		societies:              newTable(func(s *domain.Society) uuid.UUID { return s.ID }),
+		projects:               newTable(func(p *domain.Project) uuid.UUID { return p.ID }),
+		inventory:              newTable(func(i *domain.Inventory) uuid.UUID { return i.ID }),
+		interests:              newTable(func(i *domain.PropertyInterest) uuid.UUID { return i.ID }),
+		sales:                  newTable(func(s *domain.Sale) uuid.UUID { return s.ID }),
+		saleHistory:            newTable(func(h *domain.SaleStatusHistory) uuid.UUID { return h.ID }),
+		agreementTemplates:     newTable(func(t *domain.AgreementTemplate) uuid.UUID { return t.ID }),
+		agreements:             newTable(func(a *domain.Agreement) uuid.UUID { return a.ID }),
+		priceLists:             newTable(func(p *domain.PriceList) uuid.UUID { return p.ID }),
+		tasks:                  newTable(func(t *domain.Task) uuid.UUID { return t.ID }),
+		followUps:              newTable(func(f *domain.FollowUp) uuid.UUID { return f.ID }),
+		cashbook:               newTable(func(c *domain.Cashbook) uuid.UUID { return c.ID }),
+		vouchers:               newTable(func(v *domain.Voucher) uuid.UUID { return v.ID }),
+		refunds:                newTable(func(r *domain.Refund) uuid.UUID { return r.ID }),
+		paymentSchedules:       newTable(func(p *domain.PaymentSchedule) uuid.UUID { return p.ID }),
+		paymentRevisions:       newTable(func(r *domain.PaymentScheduleRevision) uuid.UUID { return r.ID }),
+		commissions:            newTable(func(c *domain.Commission) uuid.UUID { return c.ID }),
+		commissionPlans:        newTable(func(p *domain.CommissionPlan) uuid.UUID { return p.ID }),
+		nfts:                   newTable(func(n *domain.RealEstateNFT) uuid.UUID { return n.ID }),
+		listings:               newTable(func(l *domain.NFTListing) uuid.UUID { return l.ID }),
+		blockchainTransactions: newTable(func(t *domain.BlockchainTransaction) uuid.UUID { return t.ID }),
+		challenges:             newTable(func(c *domain.Challenge) uuid.UUID { return c.ID }),
+		films:                  newTable(func(f *domain.Film) uuid.UUID { return f.ID }),
+		notifications:          newTable(func(n *domain.Notification) uuid.UUID { return n.ID }),
+		sessions:               newTable(func(s *domain.Session) uuid.UUID { return s.ID }),
+		authTokens:             newTable(func(t *domain.AuthToken) uuid.UUID { return t.ID }),
+		mfaEnrollments:         newTable(func(e *domain.MFAEnrollment) uuid.UUID { return e.UserID }),
+		recoveryCodes:          newTable(func(c *domain.MFARecoveryCode) uuid.UUID { return c.ID }),
+		authEvents:             newTable(func(e *domain.AuthEvent) uuid.UUID { return e.ID }),
+		wallets:                newTable(func(w *domain.Wallet) uuid.UUID { return w.ID }),
+		siweNonces:             newTable(func(n *domain.SIWENonce) uuid.UUID { return n.ID }),
+	}
+}
+
+// table is a map of rows keyed by ID. Rows are copied on the way in and
+// out so callers never share memory with the store.
+type table[T any] struct {
+	mu   sync.RWMutex
+	rows map[uuid.UUID]*T
+	id   func(*T) uuid.UUID
+}
+
+func newTable[T any](id func(*T) uuid.UUID) *table[T] {
+	return &table[T]{
+		rows: make(map[uuid.UUID]*T),
+		id:   id,
+	}
+}
+
+// tables lists every table in the store
+func (s *Store) tables() []snapshotter {
+	return []snapshotter{
+		s.organizations, s.users, s.leads, s.clients, s.companies, s.societies, s.projects, s.inventory, s.sales,
+		s.interests, s.saleHistory, s.agreementTemplates, s.agreements, s.priceLists, s.tasks, s.followUps, s.cashbook,
+		s.vouchers, s.refunds, s.paymentSchedules, s.paymentRevisions, s.commissions, s.commissionPlans, s.nfts, s.listings,
+		s.blockchainTransactions, s.challenges, s.films, s.notifications, s.sessions,
+		s.authTokens, s.mfaEnrollments, s.recoveryCodes, s.authEvents, s.wallets, s.siweNonces,
+	}
+}
+
+// snapshotter is implemented by every table
+type snapshotter interface {
+	snapshot() (restore func())
+}
+
+// snapshot copies the current rows and returns a function that puts them back
+func (t *table[T]) snapshot() func() {
+	t.mu.RLock()
+	rows := make(map[uuid.UUID]*T, len(t.rows))
+	for id, row := range t.rows {
+		rows[id] = clone(row)
+	}
+	t.mu.RUnlock()
+
+	return func() {
+		t.mu.Lock()
+		t.rows = rows
+		t.mu.Unlock()
+	}
+}
+
+// insert stores a copy of row, failing if the ID or a conflicting row exists
+func (t *table[T]) insert(row *T, conflict func(*T) bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.rows[t.id(row)]; ok {
+		return domain.ErrAlreadyExists
+	}
+	if conflict != nil {
+		for _, existing := range t.rows {
+			if conflict(existing) {
+				return domain.ErrAlreadyExists
+			}
+		}
+	}
+
+	t.rows[t.id(row)] = clone(row)
+	return nil
+}
+
+// get returns a copy of the row with the given ID
+func (t *table[T]) get(id uuid.UUID) (*T, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	row, ok := t.rows[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return clone(row), nil
+}
+
+// first returns a copy of the first row accepted by match
+func (t *table[T]) first(match func(*T) bool) (*T, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, row := range t.rows {
+		if match(row) {
+			return clone(row), nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// replace overwrites an existing row, failing if another row conflicts
+func (t *table[T]) replace(row *T, conflict func(*T) bool) error {
+	return t.replaceIf(row, nil, conflict)
+}
+
+// replaceIf is replace guarded by check, which sees the stored row and
+// returns an error when it may not be overwritten, typically
+// domain.ErrConflict because it is no longer the version the caller read
+func (t *table[T]) replaceIf(row *T, check func(stored *T) error, conflict func(*T) bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := t.id(row)
+	stored, ok := t.rows[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	if check != nil {
+		if err := check(stored); err != nil {
+			return err
+		}
+	}
+	if conflict != nil {
+		for otherID, existing := range t.rows {
+			if otherID != id && conflict(existing) {
+				return domain.ErrAlreadyExists
+			}
+		}
+	}
+
+	t.rows[id] = clone(row)
+	return nil
+}
+
+// modify applies fn to the stored row in place
+func (t *table[T]) modify(id uuid.UUID, fn func(*T) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	row, ok := t.rows[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+
+	updated := clone(row)
+	if err := fn(updated); err != nil {
+		return err
+	}
+	t.rows[id] = updated
+	return nil
+}
+
+// modifyWhere applies fn to every row accepted by match and returns the count
+func (t *table[T]) modifyWhere(match func(*T) bool, fn func(*T)) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := 0
+	for _, row := range t.rows {
+		if match(row) {
+			fn(row)
+			n++
+		}
+	}
+	return n
+}
+
+// removeWhere deletes every row accepted by match and returns the count
+func (t *table[T]) removeWhere(match func(*T) bool) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := 0
+	for id, row := range t.rows {
+		if match(row) {
+			delete(t.rows, id)
+			n++
+		}
+	}
+	return n
+}
+
+// remove deletes the row with the given ID
+func (t *table[T]) remove(id uuid.UUID) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.rows[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(t.rows, id)
+	return nil
+}
+
+// count returns the number of rows accepted by match
+func (t *table[T]) count(match func(*T) bool) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	n := 0
+	for _, row := range t.rows {
+		if match == nil || match(row) {
+			n++
+		}
+	}
+	return n
+}
+
+// find returns copies of the rows accepted by match in the given order
+func (t *table[T]) find(match func(*T) bool, keys sortKeys[T], by order) []*T {
+	t.mu.RLock()
+	rows := make([]*T, 0, len(t.rows))
+	for _, row := range t.rows {
+		if match == nil || match(row) {
+			rows = append(rows, clone(row))
+		}
+	}
+	t.mu.RUnlock()
+
+	key := keys[by.key]
+	sort.SliceStable(rows, func(i, j int) bool {
+		if key != nil {
+			if c := compare(key(rows[i]), key(rows[j]), by.desc); c != 0 {
+				return c < 0
+			}
+		}
+		return t.id(rows[i]).String() < t.id(rows[j]).String()
+	})
+	return rows
+}
+
+// list returns the rows accepted by match, sorted and paginated per filters.
+// Unknown sort columns fall back to the default order.
+func (t *table[T]) list(match func(*T) bool, filters domain.BaseFilters, keys sortKeys[T], fallback order) []*T {
+	if filters.Cursor != nil {
+		return t.listAfter(match, filters, keys)
+	}
+
+	by := fallback
+	if _, ok := keys[filters.SortBy]; ok && filters.SortBy != "" {
+		by = order{key: filters.SortBy, desc: strings.EqualFold(filters.SortOrder, "desc")}
+	}
+	return paginate(t.find(match, keys, by), filters)
+}
+
+// listAfter returns the page after filters.Cursor in keyset order: by
+// created_at, then id
+func (t *table[T]) listAfter(match func(*T) bool, filters domain.BaseFilters, keys sortKeys[T]) []*T {
+	desc, _ := filters.KeysetOrder()
+	createdAt := keys["created_at"]
+	cursor := filters.Cursor
+
+	after := func(row *T) bool {
+		if match != nil && !match(row) {
+			return false
+		}
+		c := compare(createdAt(row), cursor.CreatedAt, desc)
+		return c > 0 || c == 0 && t.id(row).String() > cursor.ID.String()
+	}
+
+	rows := t.find(after, keys, order{key: "created_at", desc: desc})
+	if filters.Limit > 0 && filters.Limit < len(rows) {
+		rows = rows[:filters.Limit]
+	}
+	return rows
+}
+
+// sortKeys maps whitelisted sort columns to field accessors
+type sortKeys[T any] map[string]func(*T) interface{}
+
+// order is a sort column and direction
+type order struct {
+	key  string
+	desc bool
+}
+
+// newestFirst is the default order for most tables
+var newestFirst = order{key: "created_at", desc: true}
+
+// paginate applies offset and limit to rows
+func paginate[T any](rows []*T, filters domain.BaseFilters) []*T {
+	if filters.Offset > 0 {
+		if filters.Offset >= len(rows) {
+			return []*T{}
+		}
+		rows = rows[filters.Offset:]
+	}
+	if filters.Limit > 0 && filters.Limit < len(rows) {
+		rows = rows[:filters.Limit]
+	}
+	return rows
+}
+
+func clone[T any](row *T) *T {
+	c := *row
+	return &c
+}
+
+// compare orders two sort values. Nil pointers sort last in either
+// direction, matching NULLS LAST in the SQL backends.
+func compare(a, b interface{}, desc bool) int {
+	av, aNil := deref(a)
+	bv, bNil := deref(b)
+	switch {
+	case aNil && bNil:
+		return 0
+	case aNil:
+		return 1
+	case bNil:
+		return -1
+	}
+
+	c := compareValues(av, bv)
+	if desc {
+		return -c
+	}
+	return c
+}
+
+func deref(v interface{}) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return rv, true
+	}
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return rv, true
+		}
+		rv = rv.Elem()
+	}
+	return rv, false
+}
+
+func compareValues(a, b reflect.Value) int {
+	if at, ok := a.Interface().(time.Time); ok {
+		return at.Compare(b.Interface().(time.Time))
+	}
+
+	switch a.Kind() {
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmpOrdered(a.Int(), b.Int())
+	case reflect.Float32, reflect.Float64:
+		return cmpOrdered(a.Float(), b.Float())
+	case reflect.Bool:
+		return cmpOrdered(boolInt(a.Bool()), boolInt(b.Bool()))
+	default:
+		return strings.Compare(a.Interface().(interface{ String() string }).String(),
+			b.Interface().(interface{ String() string }).String())
+	}
+}
+
+func cmpOrdered[N int64 | float64](a, b N) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// matchesSearch reports whether any of the string or *string values contains
+// term, case-insensitively
+func matchesSearch(term string, values ...interface{}) bool {
+	term = strings.ToLower(term)
+	for _, v := range values {
+		switch s := v.(type) {
+		case string:
+			if strings.Contains(strings.ToLower(s), term) {
+				return true
+			}
+		case *string:
+			if s != nil && strings.Contains(strings.ToLower(*s), term) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsAll reports whether have contains every element of want
+func containsAll(have, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, h := range have {
+			if h == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// sameID reports whether an optional ID is set and equal to id
+func sameID(ptr *uuid.UUID, id uuid.UUID) bool {
+	return ptr != nil && *ptr == id
+}
+
+// containsID reports whether id is one of ids
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// sameOptionalID reports whether two optional IDs are both nil or equal
+func sameOptionalID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// inRange reports whether an optional value lies within optional bounds.
+// A nil value never matches when a bound is set.
+func inRange(v *float64, min, max *float64) bool {
+	if min == nil && max == nil {
+		return true
+	}
+	if v == nil {
+		return false
+	}
+	return (min == nil || *v >= *min) && (max == nil || *v <= *max)
+}
+
+// inTimeRange reports whether an optional time lies within optional bounds
+func inTimeRange(v *time.Time, from, to *time.Time) bool {
+	if from == nil && to == nil {
+		return true
+	}
+	if v == nil {
+		return false
+	}
+	return (from == nil || !v.Before(*from)) && (to == nil || !v.After(*to))
+}
@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var taskSortKeys = sortKeys[domain.Task]{
+	"title":        func(t *domain.Task) interface{} { return t.Title },
+	"status":       func(t *domain.Task) interface{} { return t.Status },
+	"priority":     func(t *domain.Task) interface{} { return t.Priority },
+	"due_date":     func(t *domain.Task) interface{} { return t.DueDate },
+	"completed_at": func(t *domain.Task) interface{} { return t.CompletedAt },
+	"created_at":   func(t *domain.Task) interface{} { return t.CreatedAt },
+	"updated_at":   func(t *domain.Task) interface{} { return t.UpdatedAt },
+}
+
+// taskRepository implements domain.TaskRepository in memory
+type taskRepository struct {
+	store *Store
+}
+
+// NewTaskRepository creates a new task repository
+func NewTaskRepository(store *Store) domain.TaskRepository {
+	return &taskRepository{
+		store: store,
+	}
+}
+
+// Create stores a new task
+func (r *taskRepository) Create(ctx context.Context, task *domain.Task) error {
+	now := time.Now()
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = now
+	}
+	if task.UpdatedAt.IsZero() {
+		task.UpdatedAt = task.CreatedAt
+	}
+
+	if err := domain.AssignOrganization(ctx, &task.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.tasks.insert(task, nil); err != nil {
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a task by ID
+func (r *taskRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	task, err := r.store.tasks.get(id)
+	if err == nil && (task.DeletedAt != nil || !domain.InOrganization(ctx, task.OrganizationID)) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task by ID: %w", err)
+	}
+	return task, nil
+}
+
+// GetByAssignedUser retrieves tasks assigned to a user
+func (r *taskRepository) GetByAssignedUser(ctx context.Context, userID uuid.UUID) ([]*domain.Task, error) {
+	return r.store.tasks.find(func(t *domain.Task) bool {
+		return t.DeletedAt == nil && domain.InOrganization(ctx, t.OrganizationID) &&
+			sameID(t.AssignedTo, userID)
+	}, taskSortKeys, newestFirst), nil
+}
+
+// Update updates an existing task
+func (r *taskRepository) Update(ctx context.Context, task *domain.Task) error {
+	since := task.UpdatedAt
+	task.UpdatedAt = time.Now()
+
+	// Set completion time if status is completed
+	if task.Status == domain.TaskStatusCompleted && task.CompletedAt == nil {
+		now := time.Now()
+		task.CompletedAt = &now
+	}
+
+	if err := domain.AssignOrganization(ctx, &task.OrganizationID); err != nil {
+		return err
+	}
+	check := func(stored *domain.Task) error {
+		if stored.DeletedAt != nil || !domain.InOrganization(ctx, stored.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		if !stored.UpdatedAt.Equal(since) {
+			return domain.ErrConflict
+		}
+		return nil
+	}
+	if err := r.store.tasks.replaceIf(task, check, nil); err != nil {
+		task.UpdatedAt = since
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+	return nil
+}
+
+// Delete moves a task to the trash
+func (r *taskRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	err := r.store.tasks.modify(id, func(t *domain.Task) error {
+		if t.DeletedAt != nil || !domain.InOrganization(ctx, t.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		now := time.Now()
+		t.DeletedAt, t.DeletedBy, t.UpdatedAt = &now, &deletedBy, now
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	return nil
+}
+
+// Restore takes a task out of the trash
+func (r *taskRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	err := r.store.tasks.modify(id, func(t *domain.Task) error {
+		if t.DeletedAt == nil || !domain.InOrganization(ctx, t.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		t.DeletedAt, t.DeletedBy, t.UpdatedAt = nil, nil, time.Now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+	return nil
+}
+
+// Purge permanently removes tasks that were deleted before the cutoff
+func (r *taskRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return r.store.tasks.removeWhere(func(t *domain.Task) bool {
+		return t.DeletedAt != nil && t.DeletedAt.Before(deletedBefore) && domain.InOrganization(ctx, t.OrganizationID)
+	}), nil
+}
+
+// List retrieves tasks with pagination and filtering
+func (r *taskRepository) List(ctx context.Context, filters domain.TaskFilters) ([]*domain.Task, error) {
+	return r.store.tasks.list(taskMatch(ctx, filters), filters.BaseFilters, taskSortKeys, newestFirst), nil
+}
+
+// Count returns the total number of tasks matching the filters
+func (r *taskRepository) Count(ctx context.Context, filters domain.TaskFilters) (int, error) {
+	return r.store.tasks.count(taskMatch(ctx, filters)), nil
+}
+
+// GetOverdueTasks retrieves incomplete tasks whose due date has passed
+func (r *taskRepository) GetOverdueTasks(ctx context.Context) ([]*domain.Task, error) {
+	now := time.Now()
+	return r.store.tasks.find(func(t *domain.Task) bool {
+		return t.DeletedAt == nil && domain.InOrganization(ctx, t.OrganizationID) &&
+			t.DueDate != nil && t.DueDate.Before(now) && t.Status != domain.TaskStatusCompleted
+	}, taskSortKeys, order{key: "due_date"}), nil
+}
+
+// GetTasksByRelatedEntity retrieves tasks related to a specific entity
+func (r *taskRepository) GetTasksByRelatedEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*domain.Task, error) {
+	return r.store.tasks.find(func(t *domain.Task) bool {
+		return t.DeletedAt == nil && domain.InOrganization(ctx, t.OrganizationID) &&
+			t.RelatedToType != nil && *t.RelatedToType == entityType && sameID(t.RelatedToID, entityID)
+	}, taskSortKeys, newestFirst), nil
+}
+
+func taskMatch(ctx context.Context, filters domain.TaskFilters) func(*domain.Task) bool {
+	return func(t *domain.Task) bool {
+		if t.DeletedAt != nil && !filters.IncludeDeleted {
+			return false
+		}
+		if !domain.InOrganization(ctx, t.OrganizationID) {
+			return false
+		}
+		if filters.AssignedTo != nil && !sameID(t.AssignedTo, *filters.AssignedTo) {
+			return false
+		}
+		if filters.AssignedBy != nil && !sameID(t.AssignedBy, *filters.AssignedBy) {
+			return false
+		}
+		if filters.Status != nil && t.Status != *filters.Status {
+			return false
+		}
+		if filters.Priority != nil && t.Priority != *filters.Priority {
+			return false
+		}
+		if filters.RelatedToType != nil && (t.RelatedToType == nil || *t.RelatedToType != *filters.RelatedToType) {
+			return false
+		}
+		if filters.RelatedToID != nil && !sameID(t.RelatedToID, *filters.RelatedToID) {
+			return false
+		}
+		if !inTimeRange(t.DueDate, filters.DueDateFrom, filters.DueDateTo) {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, t.Title, t.Description) {
+			return false
+		}
+		return containsAll(t.Tags, filters.Tags)
+	}
+}
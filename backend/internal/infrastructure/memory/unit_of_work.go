@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+
+	"goreal-backend/internal/domain"
+)
+
+// unitKey marks a context as running inside a unit of work
+type unitKey struct{}
+
+// unitOfWork implements domain.UnitOfWork over a Store. Units run one at a
+// time; a failed unit restores every table to its state when the unit began.
+// Writes made outside any unit while one is running are lost on rollback,
+// which is acceptable for tests and demo mode.
+type unitOfWork struct {
+	store *Store
+}
+
+// NewUnitOfWork creates a unit of work for the store
+func NewUnitOfWork(store *Store) domain.UnitOfWork {
+	return &unitOfWork{
+		store: store,
+	}
+}
+
+// Do runs fn and rolls the store back if it returns an error
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Nested units join the enclosing one
+	if ctx.Value(unitKey{}) != nil {
+		return fn(ctx)
+	}
+
+	u.store.units.Lock()
+	defer u.store.units.Unlock()
+
+	tables := u.store.tables()
+	restore := make([]func(), len(tables))
+	for i, t := range tables {
+		restore[i] = t.snapshot()
+	}
+
+	ctx, committed := domain.WithCommitHooks(context.WithValue(ctx, unitKey{}, true))
+	if err := fn(ctx); err != nil {
+		for _, r := range restore {
+			r()
+		}
+		return err
+	}
+	committed()
+	return nil
+}
@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var userSortKeys = sortKeys[domain.User]{
+	"email":         func(u *domain.User) interface{} { return u.Email },
+	"username":      func(u *domain.User) interface{} { return u.Username },
+	"full_name":     func(u *domain.User) interface{} { return u.FullName },
+	"role":          func(u *domain.User) interface{} { return u.Role },
+	"last_login_at": func(u *domain.User) interface{} { return u.LastLoginAt },
+	"created_at":    func(u *domain.User) interface{} { return u.CreatedAt },
+	"updated_at":    func(u *domain.User) interface{} { return u.UpdatedAt },
+}
+
+// userRepository implements domain.UserRepository in memory
+type userRepository struct {
+	store *Store
+}
+
+// NewUserRepository creates a new user repository
+func NewUserRepository(store *Store) domain.UserRepository {
+	return &userRepository{
+		store: store,
+	}
+}
+
+// Create stores a new user; email and username must be unique
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+	}
+	if user.UpdatedAt.IsZero() {
+		user.UpdatedAt = user.CreatedAt
+	}
+
+	if err := domain.AssignOrganization(ctx, &user.OrganizationID); err != nil {
+		return err
+	}
+	if err := r.store.users.insert(user, userConflict(user)); err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a user by ID
+func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, err := r.store.users.get(id)
+	if err == nil && !domain.InOrganization(ctx, user.OrganizationID) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by ID: %w", err)
+	}
+	return user, nil
+}
+
+// GetByEmail retrieves a user by email
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	user, err := r.store.users.first(func(u *domain.User) bool {
+		return u.Email == email && domain.InOrganization(ctx, u.OrganizationID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by email: %w", err)
+	}
+	return user, nil
+}
+
+// GetByUsername retrieves a user by username
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	user, err := r.store.users.first(func(u *domain.User) bool {
+		return u.Username == username && domain.InOrganization(ctx, u.OrganizationID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by username: %w", err)
+	}
+	return user, nil
+}
+
+// Update updates an existing user
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	user.UpdatedAt = time.Now()
+
+	if err := domain.AssignOrganization(ctx, &user.OrganizationID); err != nil {
+		return err
+	}
+	check := func(stored *domain.User) error {
+		if !domain.InOrganization(ctx, stored.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		return nil
+	}
+	if err := r.store.users.replaceIf(user, check, userConflict(user)); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a user by ID
+func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	removed := r.store.users.removeWhere(func(u *domain.User) bool {
+		return u.ID == id && domain.InOrganization(ctx, u.OrganizationID)
+	})
+	if removed == 0 {
+		return fmt.Errorf("failed to delete user: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// RecordFailedLogin counts a failed login against a user
+func (r *userRepository) RecordFailedLogin(ctx context.Context, id uuid.UUID, at, since time.Time) (int, error) {
+	var attempts int
+	err := r.store.users.modify(id, func(u *domain.User) error {
+		if !domain.InOrganization(ctx, u.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		if u.LastFailedLoginAt == nil || u.LastFailedLoginAt.Before(since) {
+			u.FailedLoginAttempts = 0
+		}
+		u.FailedLoginAttempts++
+		u.LastFailedLoginAt = &at
+		u.UpdatedAt = time.Now()
+		attempts = u.FailedLoginAttempts
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to record failed login: %w", err)
+	}
+	return attempts, nil
+}
+
+// LockUntil locks a user until a time, unless already locked for longer
+func (r *userRepository) LockUntil(ctx context.Context, id uuid.UUID, until time.Time) error {
+	err := r.store.users.modify(id, func(u *domain.User) error {
+		if !domain.InOrganization(ctx, u.OrganizationID) {
+			return domain.ErrNotFound
+		}
+		if u.LockedUntil == nil || u.LockedUntil.Before(until) {
+			u.LockedUntil = &until
+			u.UpdatedAt = time.Now()
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+	return nil
+}
+
+// List retrieves users with pagination and filtering
+func (r *userRepository) List(ctx context.Context, filters domain.UserFilters) ([]*domain.User, error) {
+	return r.store.users.list(userMatch(ctx, filters), filters.BaseFilters, userSortKeys, newestFirst), nil
+}
+
+// Count returns the total number of users matching the filters
+func (r *userRepository) Count(ctx context.Context, filters domain.UserFilters) (int, error) {
+	return r.store.users.count(userMatch(ctx, filters)), nil
+}
+
+func userMatch(ctx context.Context, filters domain.UserFilters) func(*domain.User) bool {
+	return func(u *domain.User) bool {
+		if !domain.InOrganization(ctx, u.OrganizationID) {
+			return false
+		}
+		if filters.Role != nil && u.Role != *filters.Role {
+			return false
+		}
+		if filters.IsActive != nil && u.IsActive != *filters.IsActive {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, u.FullName, u.Email, u.Username) {
+			return false
+		}
+		return true
+	}
+}
+
+func userConflict(user *domain.User) func(*domain.User) bool {
+	return func(u *domain.User) bool {
+		return u.Email == user.Email || u.Username == user.Username
+	}
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var voucherSortKeys = sortKeys[domain.Voucher]{
+	"voucher_number": func(v *domain.Voucher) interface{} { return v.VoucherNumber },
+	"voucher_date":   func(v *domain.Voucher) interface{} { return v.VoucherDate },
+	"status":         func(v *domain.Voucher) interface{} { return v.Status },
+	"amount":         func(v *domain.Voucher) interface{} { return v.Amount },
+	"created_at":     func(v *domain.Voucher) interface{} { return v.CreatedAt },
+	"updated_at":     func(v *domain.Voucher) interface{} { return v.UpdatedAt },
+}
+
+// voucherRepository implements domain.VoucherRepository in memory
+type voucherRepository struct {
+	store *Store
+}
+
+// NewVoucherRepository creates a new voucher repository
+func NewVoucherRepository(store *Store) domain.VoucherRepository {
+	return &voucherRepository{
+		store: store,
+	}
+}
+
+// Create stores a new voucher
+func (r *voucherRepository) Create(ctx context.Context, voucher *domain.Voucher) error {
+	now := time.Now()
+	if voucher.CreatedAt.IsZero() {
+		voucher.CreatedAt = now
+	}
+	if voucher.UpdatedAt.IsZero() {
+		voucher.UpdatedAt = voucher.CreatedAt
+	}
+	if voucher.VoucherDate.IsZero() {
+		voucher.VoucherDate = now
+	}
+
+	if err := r.store.vouchers.insert(voucher, nil); err != nil {
+		return fmt.Errorf("failed to create voucher: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a voucher by ID
+func (r *voucherRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Voucher, error) {
+	voucher, err := r.store.vouchers.get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voucher by ID: %w", err)
+	}
+	return voucher, nil
+}
+
+// Update updates an existing voucher
+func (r *voucherRepository) Update(ctx context.Context, voucher *domain.Voucher) error {
+	voucher.UpdatedAt = time.Now()
+
+	if err := r.store.vouchers.replace(voucher, nil); err != nil {
+		return fmt.Errorf("failed to update voucher: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a voucher by ID
+func (r *voucherRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.store.vouchers.remove(id); err != nil {
+		return fmt.Errorf("failed to delete voucher: %w", err)
+	}
+	return nil
+}
+
+// List retrieves vouchers with pagination and filtering
+func (r *voucherRepository) List(ctx context.Context, filters domain.VoucherFilters) ([]*domain.Voucher, error) {
+	return r.store.vouchers.list(voucherMatch(filters), filters.BaseFilters, voucherSortKeys,
+		order{key: "voucher_date", desc: true}), nil
+}
+
+// GetPendingApprovals retrieves pending vouchers the approver did not request
+// themselves, oldest first
+func (r *voucherRepository) GetPendingApprovals(ctx context.Context, approverID uuid.UUID) ([]*domain.Voucher, error) {
+	return r.store.vouchers.find(func(v *domain.Voucher) bool {
+		return v.Status == domain.ApprovalStatusPending && !sameID(v.RequestedBy, approverID)
+	}, voucherSortKeys, order{key: "voucher_date"}), nil
+}
+
+func voucherMatch(filters domain.VoucherFilters) func(*domain.Voucher) bool {
+	return func(v *domain.Voucher) bool {
+		if filters.VoucherType != nil && (v.VoucherType == nil || *v.VoucherType != *filters.VoucherType) {
+			return false
+		}
+		if filters.Status != nil && v.Status != *filters.Status {
+			return false
+		}
+		if filters.RequestedBy != nil && !sameID(v.RequestedBy, *filters.RequestedBy) {
+			return false
+		}
+		if filters.ApprovedBy != nil && !sameID(v.ApprovedBy, *filters.ApprovedBy) {
+			return false
+		}
+		if !inRange(&v.Amount, filters.AmountMin, filters.AmountMax) {
+			return false
+		}
+		if !inTimeRange(&v.VoucherDate, filters.DateFrom, filters.DateTo) {
+			return false
+		}
+		if filters.Search != nil && *filters.Search != "" &&
+			!matchesSearch(*filters.Search, v.VoucherNumber, v.PartyName, v.Description) {
+			return false
+		}
+		return true
+	}
+}
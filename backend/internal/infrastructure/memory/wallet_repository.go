@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var walletSortKeys = sortKeys[domain.Wallet]{
+	"created_at": func(w *domain.Wallet) interface{} { return w.CreatedAt },
+}
+
+// walletRepository implements domain.WalletRepository in memory
+type walletRepository struct {
+	store *Store
+}
+
+// NewWalletRepository creates a new wallet repository
+func NewWalletRepository(store *Store) domain.WalletRepository {
+	return &walletRepository{
+		store: store,
+	}
+}
+
+// Create stores a new wallet; addresses must be unique
+func (r *walletRepository) Create(ctx context.Context, wallet *domain.Wallet) error {
+	now := time.Now()
+	if wallet.CreatedAt.IsZero() {
+		wallet.CreatedAt = now
+	}
+	wallet.UpdatedAt = now
+
+	err := r.store.wallets.insert(wallet, func(w *domain.Wallet) bool {
+		return w.Address == wallet.Address
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create wallet: %w", err)
+	}
+	return nil
+}
+
+// GetByAddress retrieves the wallet with an address
+func (r *walletRepository) GetByAddress(ctx context.Context, address string) (*domain.Wallet, error) {
+	wallet, err := r.store.wallets.first(func(w *domain.Wallet) bool {
+		return w.Address == address
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet by address: %w", err)
+	}
+	return wallet, nil
+}
+
+// ListByUser returns the wallets of a user, oldest first
+func (r *walletRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Wallet, error) {
+	return r.store.wallets.find(func(w *domain.Wallet) bool {
+		return w.UserID == userID
+	}, walletSortKeys, order{key: "created_at"}), nil
+}
+
+// Update updates an existing wallet
+func (r *walletRepository) Update(ctx context.Context, wallet *domain.Wallet) error {
+	wallet.UpdatedAt = time.Now()
+	if err := r.store.wallets.replace(wallet, nil); err != nil {
+		return fmt.Errorf("failed to update wallet: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a wallet
+func (r *walletRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.store.wallets.remove(id); err != nil {
+		return fmt.Errorf("failed to delete wallet: %w", err)
+	}
+	return nil
+}
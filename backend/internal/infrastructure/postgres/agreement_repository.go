@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var agreementTracer = otel.Tracer("goreal-backend/infrastructure/postgres/agreement")
+
+var agreementColumns = []string{
+	"id", "organization_id", "sale_id", "template_id", "template_version", "format", "file_name",
+	"checksum", "content", "created_by", "created_at",
+}
+
+var agreementSelect = "SELECT " + strings.Join(agreementColumns, ", ") + " FROM sale_agreements"
+
+type agreementRepository struct {
+	db *DB
+}
+
+// NewAgreementRepository creates a new agreement repository
+func NewAgreementRepository(db *DB) domain.AgreementRepository {
+	return &agreementRepository{
+		db: db,
+	}
+}
+
+// Create stores a generated agreement
+func (r *agreementRepository) Create(ctx context.Context, agreement *domain.Agreement) error {
+	if err := domain.AssignOrganization(ctx, &agreement.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := agreementTracer.Start(ctx, "agreementRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", agreement.SaleID.String()),
+		attribute.String("agreement.format", string(agreement.Format)),
+		attribute.Int("agreement.size", len(agreement.Content)),
+	)
+
+	if agreement.CreatedAt.IsZero() {
+		agreement.CreatedAt = timestamp()
+	}
+
+	query := "INSERT INTO sale_agreements (" + strings.Join(agreementColumns, ", ") + ") VALUES (" + placeholders(len(agreementColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "sale_agreements", func(q querier) error {
+		_, err := q.ExecContext(ctx, query,
+			agreement.ID, agreement.OrganizationID, agreement.SaleID, agreement.TemplateID, agreement.TemplateVersion,
+			string(agreement.Format), agreement.FileName, agreement.Checksum, agreement.Content,
+			agreement.CreatedBy, agreement.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create agreement: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an agreement with its content
+func (r *agreementRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Agreement, error) {
+	ctx, span := agreementTracer.Start(ctx, "agreementRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("agreement.id", id.String()))
+
+	var agreement *domain.Agreement
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "select", "sale_agreements", func(q querier) error {
+		var err error
+		agreement, err = scanAgreement(q.QueryRowContext(ctx, agreementSelect+" WHERE id = $1"+s.and(2), s.args(id)...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get agreement by ID: %w", err)
+	}
+
+	return agreement, nil
+}
+
+// GetLatest returns the most recently generated agreement of a sale in a format
+func (r *agreementRepository) GetLatest(ctx context.Context, saleID uuid.UUID, format domain.AgreementFormat) (*domain.Agreement, error) {
+	ctx, span := agreementTracer.Start(ctx, "agreementRepository.GetLatest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", saleID.String()),
+		attribute.String("agreement.format", string(format)),
+	)
+
+	var agreement *domain.Agreement
+	s := scopeOf(ctx)
+	query := agreementSelect + " WHERE sale_id = $1 AND format = $2" + s.and(3) + " ORDER BY created_at DESC, id DESC LIMIT 1"
+	err := r.db.ExecuteQuery(ctx, "select", "sale_agreements", func(q querier) error {
+		var err error
+		agreement, err = scanAgreement(q.QueryRowContext(ctx, query, s.args(saleID, string(format))...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get latest agreement: %w", err)
+	}
+
+	return agreement, nil
+}
+
+func scanAgreement(s scanner) (*domain.Agreement, error) {
+	var agreement domain.Agreement
+	var format string
+
+	err := s.Scan(
+		&agreement.ID, &agreement.OrganizationID, &agreement.SaleID, &agreement.TemplateID, &agreement.TemplateVersion,
+		&format, &agreement.FileName, &agreement.Checksum, &agreement.Content,
+		&agreement.CreatedBy, &agreement.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	agreement.Format = domain.AgreementFormat(format)
+	return &agreement, nil
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var agreementTemplateTracer = otel.Tracer("goreal-backend/infrastructure/postgres/agreement_template")
+
+var agreementTemplateColumns = []string{
+	"id", "organization_id", "project_id", "name", "version", "body", "created_by", "created_at",
+}
+
+var agreementTemplateSelect = "SELECT " + strings.Join(agreementTemplateColumns, ", ") + " FROM agreement_templates"
+
+type agreementTemplateRepository struct {
+	db *DB
+}
+
+// NewAgreementTemplateRepository creates a new agreement template repository
+func NewAgreementTemplateRepository(db *DB) domain.AgreementTemplateRepository {
+	return &agreementTemplateRepository{
+		db: db,
+	}
+}
+
+// Create stores a template version; versions are unique per organization
+// and project
+func (r *agreementTemplateRepository) Create(ctx context.Context, template *domain.AgreementTemplate) error {
+	if err := domain.AssignOrganization(ctx, &template.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := agreementTemplateTracer.Start(ctx, "agreementTemplateRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("template.name", template.Name),
+		attribute.Int("template.version", template.Version),
+	)
+
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = timestamp()
+	}
+
+	query := "INSERT INTO agreement_templates (" + strings.Join(agreementTemplateColumns, ", ") + ") VALUES (" + placeholders(len(agreementTemplateColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "agreement_templates", func(q querier) error {
+		_, err := q.ExecContext(ctx, query,
+			template.ID, template.OrganizationID, template.ProjectID, template.Name, template.Version,
+			template.Body, template.CreatedBy, template.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create agreement template: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatest returns the newest version of a project's template, or of the
+// organization default when projectID is nil
+func (r *agreementTemplateRepository) GetLatest(ctx context.Context, projectID *uuid.UUID) (*domain.AgreementTemplate, error) {
+	ctx, span := agreementTemplateTracer.Start(ctx, "agreementTemplateRepository.GetLatest")
+	defer span.End()
+
+	var template *domain.AgreementTemplate
+	where, args := r.where(ctx, projectID)
+	err := r.db.ExecuteQuery(ctx, "select", "agreement_templates", func(q querier) error {
+		var err error
+		template, err = scanAgreementTemplate(q.QueryRowContext(ctx, agreementTemplateSelect+where+" ORDER BY version DESC LIMIT 1", args...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get agreement template: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("template.version", template.Version))
+
+	return template, nil
+}
+
+// ListVersions returns every version of a project's template, newest first
+func (r *agreementTemplateRepository) ListVersions(ctx context.Context, projectID *uuid.UUID) ([]*domain.AgreementTemplate, error) {
+	ctx, span := agreementTemplateTracer.Start(ctx, "agreementTemplateRepository.ListVersions")
+	defer span.End()
+
+	templates := []*domain.AgreementTemplate{}
+	where, args := r.where(ctx, projectID)
+	err := r.db.ExecuteQuery(ctx, "select", "agreement_templates", func(q querier) error {
+		rows, err := q.QueryContext(ctx, agreementTemplateSelect+where+" ORDER BY version DESC", args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			template, err := scanAgreementTemplate(rows)
+			if err != nil {
+				return err
+			}
+			templates = append(templates, template)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list agreement templates: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(templates)))
+
+	return templates, nil
+}
+
+// where selects a project's templates, or the defaults when projectID is nil
+func (r *agreementTemplateRepository) where(ctx context.Context, projectID *uuid.UUID) (string, []interface{}) {
+	s := scopeOf(ctx)
+	if projectID == nil {
+		return " WHERE project_id IS NULL" + s.and(1), s.args()
+	}
+	return " WHERE project_id = $1" + s.and(2), s.args(*projectID)
+}
+
+func scanAgreementTemplate(s scanner) (*domain.AgreementTemplate, error) {
+	var template domain.AgreementTemplate
+
+	err := s.Scan(
+		&template.ID, &template.OrganizationID, &template.ProjectID, &template.Name, &template.Version,
+		&template.Body, &template.CreatedBy, &template.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var authEventTracer = otel.Tracer("goreal-backend/infrastructure/postgres/auth_event")
+
+var authEventColumns = []string{
+	"id", "user_id", "email", "type", "ip_address", "user_agent", "actor_id", "locked_until", "created_at",
+}
+
+var authEventSelect = "SELECT " + strings.Join(authEventColumns, ", ") + " FROM auth_events"
+
+type authEventRepository struct {
+	db *DB
+}
+
+// NewAuthEventRepository creates a new auth event repository
+func NewAuthEventRepository(db *DB) domain.AuthEventRepository {
+	return &authEventRepository{
+		db: db,
+	}
+}
+
+// Create records an event
+func (r *authEventRepository) Create(ctx context.Context, event *domain.AuthEvent) error {
+	ctx, span := authEventTracer.Start(ctx, "authEventRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event.type", string(event.Type)))
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = timestamp()
+	}
+
+	query := "INSERT INTO auth_events (" + strings.Join(authEventColumns, ", ") + ") VALUES (" + placeholders(len(authEventColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "auth_events", func(q querier) error {
+		_, err := q.ExecContext(ctx, query,
+			event.ID, event.UserID, event.Email, string(event.Type), event.IPAddress, event.UserAgent, event.ActorID,
+			event.LockedUntil, event.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create auth event: %w", err)
+	}
+
+	return nil
+}
+
+// FailuresFromAddress counts the failed logins from an IP address since a time
+func (r *authEventRepository) FailuresFromAddress(ctx context.Context, ipAddress string, since time.Time) (int, *time.Time, error) {
+	ctx, span := authEventTracer.Start(ctx, "authEventRepository.FailuresFromAddress")
+	defer span.End()
+
+	var count int
+	var latest *time.Time
+	err := r.db.ExecuteQuery(ctx, "select", "auth_events", func(q querier) error {
+		return q.QueryRowContext(ctx,
+			"SELECT COUNT(*), MAX(created_at) FROM auth_events WHERE type = $1 AND ip_address = $2 AND created_at >= $3",
+			string(domain.AuthEventLoginFailed), ipAddress, since).Scan(&count, &latest)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, nil, fmt.Errorf("failed to count failed logins: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, latest, nil
+}
+
+// ListByUser returns the latest events of a user, newest first
+func (r *authEventRepository) ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.AuthEvent, error) {
+	ctx, span := authEventTracer.Start(ctx, "authEventRepository.ListByUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	events := []*domain.AuthEvent{}
+	query := authEventSelect + " WHERE user_id = $1 ORDER BY created_at DESC, id"
+	args := []interface{}{userID}
+	if limit > 0 {
+		query += " LIMIT $2"
+		args = append(args, limit)
+	}
+	err := r.db.ExecuteQuery(ctx, "select", "auth_events", func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var event domain.AuthEvent
+			var eventType string
+			if err := rows.Scan(&event.ID, &event.UserID, &event.Email, &eventType, &event.IPAddress, &event.UserAgent,
+				&event.ActorID, &event.LockedUntil, &event.CreatedAt); err != nil {
+				return err
+			}
+			event.Type = domain.AuthEventType(eventType)
+			events = append(events, &event)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list auth events: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(events)))
+
+	return events, nil
+}
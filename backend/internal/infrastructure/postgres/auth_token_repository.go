@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var authTokenTracer = otel.Tracer("goreal-backend/infrastructure/postgres/auth_token")
+
+var authTokenColumns = []string{"id", "user_id", "purpose", "token_hash", "expires_at", "used_at", "created_at"}
+
+type authTokenRepository struct {
+	db *DB
+}
+
+// NewAuthTokenRepository creates a new auth token repository
+func NewAuthTokenRepository(db *DB) domain.AuthTokenRepository {
+	return &authTokenRepository{
+		db: db,
+	}
+}
+
+// Create stores a new token
+func (r *authTokenRepository) Create(ctx context.Context, token *domain.AuthToken) error {
+	ctx, span := authTokenTracer.Start(ctx, "authTokenRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", token.UserID.String()),
+		attribute.String("token.purpose", string(token.Purpose)),
+	)
+
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = timestamp()
+	}
+
+	query := "INSERT INTO auth_tokens (" + strings.Join(authTokenColumns, ", ") + ") VALUES (" + placeholders(len(authTokenColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "auth_tokens", func(q querier) error {
+		_, err := q.ExecContext(ctx, query,
+			token.ID, token.UserID, string(token.Purpose), token.TokenHash, token.ExpiresAt, token.UsedAt, token.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create auth token: %w", err)
+	}
+
+	return nil
+}
+
+// Consume redeems an unused, unexpired token in a single guarded update
+func (r *authTokenRepository) Consume(ctx context.Context, purpose domain.TokenPurpose, tokenHash string, at time.Time) (*domain.AuthToken, error) {
+	ctx, span := authTokenTracer.Start(ctx, "authTokenRepository.Consume")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("token.purpose", string(purpose)))
+
+	var token domain.AuthToken
+	query := `UPDATE auth_tokens SET used_at = $3
+		WHERE purpose = $1 AND token_hash = $2 AND used_at IS NULL AND expires_at > $3
+		RETURNING ` + strings.Join(authTokenColumns, ", ")
+	err := r.db.ExecuteQuery(ctx, "update", "auth_tokens", func(q querier) error {
+		var stored string
+		err := q.QueryRowContext(ctx, query, string(purpose), tokenHash, at).Scan(
+			&token.ID, &token.UserID, &stored, &token.TokenHash, &token.ExpiresAt, &token.UsedAt, &token.CreatedAt)
+		token.Purpose = domain.TokenPurpose(stored)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to consume auth token: %w", err)
+	}
+
+	span.SetAttributes(attribute.String("user.id", token.UserID.String()))
+
+	return &token, nil
+}
+
+// InvalidateByUser marks a user's outstanding tokens of a purpose as used
+func (r *authTokenRepository) InvalidateByUser(ctx context.Context, userID uuid.UUID, purpose domain.TokenPurpose, at time.Time) (int, error) {
+	ctx, span := authTokenTracer.Start(ctx, "authTokenRepository.InvalidateByUser")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("token.purpose", string(purpose)),
+	)
+
+	var invalidated int64
+	err := r.db.ExecuteQuery(ctx, "update", "auth_tokens", func(q querier) error {
+		result, err := q.ExecContext(ctx,
+			"UPDATE auth_tokens SET used_at = $3 WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL",
+			userID, string(purpose), at)
+		if err != nil {
+			return err
+		}
+		invalidated, err = result.RowsAffected()
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to invalidate auth tokens: %w", err)
+	}
+
+	return int(invalidated), nil
+}
@@ -0,0 +1,425 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var clientTracer = otel.Tracer("goreal-backend/infrastructure/postgres/client")
+
+var clientColumns = []string{
+	"id", "profile_id", "lead_id", "company_id", "client_type", "name", "email", "phone",
+	"alternate_phone", "date_of_birth", "anniversary_date", "address", "emergency_contact",
+	"kyc_documents", "is_verified", "credit_limit", "assigned_to", "tags", "custom_fields",
+	"created_by", "created_at", "updated_at",
+	"deleted_at", "deleted_by", "organization_id",
+}
+
+var clientSortable = map[string]bool{
+	"name": true, "email": true, "client_type": true, "is_verified": true, "credit_limit": true,
+	"created_at": true, "updated_at": true,
+}
+
+var clientSelect = "SELECT " + strings.Join(clientColumns, ", ") + " FROM clients"
+
+type clientRepository struct {
+	db *DB
+}
+
+// NewClientRepository creates a new client repository
+func NewClientRepository(db *DB) domain.ClientRepository {
+	return &clientRepository{
+		db: db,
+	}
+}
+
+// Create creates a new client
+func (r *clientRepository) Create(ctx context.Context, client *domain.Client) error {
+	if err := domain.AssignOrganization(ctx, &client.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := clientTracer.Start(ctx, "clientRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("client.name", client.Name),
+		attribute.String("client.id", client.ID.String()),
+		attribute.String("client.client_type", string(client.ClientType)),
+	)
+
+	// Set timestamps unless the caller supplied them
+	now := time.Now()
+	if client.CreatedAt.IsZero() {
+		client.CreatedAt = now
+	}
+	if client.UpdatedAt.IsZero() {
+		client.UpdatedAt = client.CreatedAt
+	}
+
+	query := "INSERT INTO clients (" + strings.Join(clientColumns, ", ") + ") VALUES (" + placeholders(len(clientColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "clients", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, clientArgs(client)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a client by ID
+func (r *clientRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Client, error) {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("client.id", id.String()))
+
+	client, err := r.getOne(ctx, "id", id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get client by ID: %w", err)
+	}
+
+	return client, nil
+}
+
+// GetByEmail retrieves a client by email
+func (r *clientRepository) GetByEmail(ctx context.Context, email string) (*domain.Client, error) {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.GetByEmail")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("client.email", email))
+
+	client, err := r.getOne(ctx, "email", email)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get client by email: %w", err)
+	}
+
+	return client, nil
+}
+
+func (r *clientRepository) getOne(ctx context.Context, column string, value interface{}) (*domain.Client, error) {
+	var client *domain.Client
+	s := scopeOf(ctx)
+	query := clientSelect + " WHERE " + column + " = $1 AND deleted_at IS NULL" + s.and(2) + " LIMIT 1"
+	err := r.db.ExecuteQuery(ctx, "select", "clients", func(q querier) error {
+		var err error
+		client, err = scanClient(q.QueryRowContext(ctx, query, s.args(value)...))
+		return err
+	})
+	return client, err
+}
+
+// Update updates an existing client
+func (r *clientRepository) Update(ctx context.Context, client *domain.Client) error {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("client.id", client.ID.String()),
+		attribute.String("client.name", client.Name),
+	)
+
+	// Update timestamp
+	since := client.UpdatedAt
+	client.UpdatedAt = timestamp()
+
+	if err := domain.AssignOrganization(ctx, &client.OrganizationID); err != nil {
+		return err
+	}
+	s := scopeOf(ctx)
+	query := versionedUpdate("clients", clientColumns, s)
+	err := r.db.ExecuteQuery(ctx, "update", "clients", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(append(clientArgs(client), since)...)...)
+		if err != nil {
+			return err
+		}
+		return expectVersion(ctx, q, result, "clients", client.ID)
+	})
+
+	if err != nil {
+		client.UpdatedAt = since
+		span.RecordError(err)
+		return fmt.Errorf("failed to update client: %w", err)
+	}
+
+	return nil
+}
+
+// Delete moves a client to the trash
+func (r *clientRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("client.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "delete", "clients", func(q querier) error {
+		result, err := q.ExecContext(ctx, softDelete("clients", s), s.args(id, timestamp(), deletedBy)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete client: %w", err)
+	}
+
+	return nil
+}
+
+// Restore takes a client out of the trash
+func (r *clientRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("client.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "restore", "clients", func(q querier) error {
+		result, err := q.ExecContext(ctx, restore("clients", s), s.args(id, timestamp())...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to restore client: %w", err)
+	}
+
+	return nil
+}
+
+// Purge permanently removes clients that were deleted before the cutoff
+func (r *clientRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.Purge")
+	defer span.End()
+
+	var purged int
+	err := r.db.ExecuteQuery(ctx, "purge", "clients", func(q querier) error {
+		var err error
+		purged, err = purge(ctx, q, "clients", deletedBefore)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to purge clients: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", purged))
+
+	return purged, nil
+}
+
+// List retrieves clients with pagination and filtering
+func (r *clientRepository) List(ctx context.Context, filters domain.ClientFilters) ([]*domain.Client, error) {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	c := clientConditions(ctx, filters)
+	c.after(filters.BaseFilters)
+	query := clientSelect + c.where() + orderBy(filters.BaseFilters, clientSortable, "created_at DESC") + paginate(c, filters.BaseFilters)
+
+	var clients []*domain.Client
+	err := r.db.ExecuteQuery(ctx, "select", "clients", func(q querier) error {
+		var err error
+		clients, err = queryClients(ctx, q, query, c.args...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(clients)))
+
+	return clients, nil
+}
+
+// Count returns the total number of clients matching the filters
+func (r *clientRepository) Count(ctx context.Context, filters domain.ClientFilters) (int, error) {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.Count")
+	defer span.End()
+
+	c := clientConditions(ctx, filters)
+
+	var count int
+	err := r.db.ExecuteQuery(ctx, "count", "clients", func(q querier) error {
+		return q.QueryRowContext(ctx, "SELECT COUNT(*) FROM clients"+c.where(), c.args...).Scan(&count)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count clients: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+// GetByAssignedUser retrieves clients assigned to a user
+func (r *clientRepository) GetByAssignedUser(ctx context.Context, userID uuid.UUID) ([]*domain.Client, error) {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.GetByAssignedUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	var clients []*domain.Client
+	s := scopeOf(ctx)
+	query := clientSelect + " WHERE assigned_to = $1 AND deleted_at IS NULL" + s.and(2) + " ORDER BY created_at DESC, id"
+	err := r.db.ExecuteQuery(ctx, "select", "clients", func(q querier) error {
+		var err error
+		clients, err = queryClients(ctx, q, query, s.args(userID)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get clients by assigned to: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(clients)))
+
+	return clients, nil
+}
+
+// UpdateVerificationStatus updates client verification status
+func (r *clientRepository) UpdateVerificationStatus(ctx context.Context, id uuid.UUID, isVerified bool) error {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.UpdateVerificationStatus")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("client.id", id.String()),
+		attribute.Bool("client.is_verified", isVerified),
+	)
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "update", "clients", func(q querier) error {
+		result, err := q.ExecContext(ctx, "UPDATE clients SET is_verified = $2, updated_at = $3 WHERE id = $1"+s.and(4),
+			s.args(id, isVerified, time.Now())...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to update client verification status: %w", err)
+	}
+
+	return nil
+}
+
+// clientConditions translates client filters into a WHERE clause
+func clientConditions(ctx context.Context, filters domain.ClientFilters) *conditions {
+	c := &conditions{}
+	c.scoped(ctx)
+	if !filters.IncludeDeleted {
+		c.add("deleted_at IS NULL")
+	}
+	if filters.ClientType != nil {
+		c.add("client_type = ?", string(*filters.ClientType))
+	}
+	if filters.AssignedTo != nil {
+		c.add("assigned_to = ?", *filters.AssignedTo)
+	}
+	if filters.CreatedBy != nil {
+		c.add("created_by = ?", *filters.CreatedBy)
+	}
+	if filters.CompanyID != nil {
+		c.add("company_id = ?", *filters.CompanyID)
+	}
+	if filters.IsVerified != nil {
+		c.add("is_verified = ?", *filters.IsVerified)
+	}
+	if len(filters.Tags) > 0 {
+		c.add("tags @> ?", pq.Array(filters.Tags))
+	}
+	if filters.CreatedAfter != nil {
+		c.add("created_at >= ?", *filters.CreatedAfter)
+	}
+	if filters.CreatedBefore != nil {
+		c.add("created_at <= ?", *filters.CreatedBefore)
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		c.search(*filters.Search, "name", "email", "phone")
+	}
+	return c
+}
+
+func clientArgs(client *domain.Client) []interface{} {
+	return []interface{}{
+		client.ID, client.ProfileID, client.LeadID, client.CompanyID, string(client.ClientType), client.Name,
+		client.Email, client.Phone, client.AlternatePhone, client.DateOfBirth, client.AnniversaryDate,
+		jsonb{client.Address}, jsonb{client.EmergencyContact}, pq.Array(client.KYCDocuments), client.IsVerified,
+		client.CreditLimit, client.AssignedTo, pq.Array(client.Tags), jsonb{client.CustomFields},
+		nullUUID(client.CreatedBy), client.CreatedAt, client.UpdatedAt,
+		client.DeletedAt, client.DeletedBy, client.OrganizationID,
+	}
+}
+
+func scanClient(s scanner) (*domain.Client, error) {
+	var client domain.Client
+	var clientType string
+	var createdBy uuid.NullUUID
+
+	err := s.Scan(
+		&client.ID, &client.ProfileID, &client.LeadID, &client.CompanyID, &clientType, &client.Name,
+		&client.Email, &client.Phone, &client.AlternatePhone, &client.DateOfBirth, &client.AnniversaryDate,
+		jsonb{&client.Address}, jsonb{&client.EmergencyContact}, pq.Array(&client.KYCDocuments), &client.IsVerified,
+		&client.CreditLimit, &client.AssignedTo, pq.Array(&client.Tags), jsonb{&client.CustomFields},
+		&createdBy, &client.CreatedAt, &client.UpdatedAt,
+		&client.DeletedAt, &client.DeletedBy, &client.OrganizationID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	client.ClientType = domain.ClientType(clientType)
+	client.CreatedBy = createdBy.UUID
+	return &client, nil
+}
+
+func queryClients(ctx context.Context, q querier, query string, args ...interface{}) ([]*domain.Client, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clients := []*domain.Client{}
+	for rows.Next() {
+		client, err := scanClient(rows)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+	return clients, rows.Err()
+}
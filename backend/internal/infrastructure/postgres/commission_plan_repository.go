@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var commissionPlanTracer = otel.Tracer("goreal-backend/infrastructure/postgres/commission_plan")
+
+var commissionPlanColumns = []string{
+	"id", "organization_id", "name", "type", "rate", "slabs", "manager_share", "clawback", "created_by", "created_at",
+}
+
+var commissionPlanSelect = "SELECT " + strings.Join(commissionPlanColumns, ", ") + " FROM commission_plans"
+
+type commissionPlanRepository struct {
+	db *DB
+}
+
+// NewCommissionPlanRepository creates a new commission plan repository
+func NewCommissionPlanRepository(db *DB) domain.CommissionPlanRepository {
+	return &commissionPlanRepository{
+		db: db,
+	}
+}
+
+// Create stores a new commission plan
+func (r *commissionPlanRepository) Create(ctx context.Context, plan *domain.CommissionPlan) error {
+	if err := domain.AssignOrganization(ctx, &plan.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := commissionPlanTracer.Start(ctx, "commissionPlanRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("commission_plan.id", plan.ID.String()),
+		attribute.String("commission_plan.type", string(plan.Type)),
+	)
+
+	if plan.CreatedAt.IsZero() {
+		plan.CreatedAt = timestamp()
+	}
+
+	slabs := plan.Slabs
+	if slabs == nil {
+		slabs = []domain.CommissionSlab{}
+	}
+
+	query := "INSERT INTO commission_plans (" + strings.Join(commissionPlanColumns, ", ") + ") VALUES (" + placeholders(len(commissionPlanColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "commission_plans", func(q querier) error {
+		_, err := q.ExecContext(ctx, query,
+			plan.ID, plan.OrganizationID, plan.Name, string(plan.Type), plan.Rate, jsonb{slabs},
+			plan.ManagerShare, plan.Clawback, plan.CreatedBy, plan.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create commission plan: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a commission plan by ID
+func (r *commissionPlanRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CommissionPlan, error) {
+	ctx, span := commissionPlanTracer.Start(ctx, "commissionPlanRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("commission_plan.id", id.String()))
+
+	var plan *domain.CommissionPlan
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "select", "commission_plans", func(q querier) error {
+		var err error
+		plan, err = scanCommissionPlan(q.QueryRowContext(ctx, commissionPlanSelect+" WHERE id = $1"+s.and(2), s.args(id)...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get commission plan by ID: %w", err)
+	}
+
+	return plan, nil
+}
+
+// GetLatest returns the plan in effect: the most recently created one
+func (r *commissionPlanRepository) GetLatest(ctx context.Context) (*domain.CommissionPlan, error) {
+	ctx, span := commissionPlanTracer.Start(ctx, "commissionPlanRepository.GetLatest")
+	defer span.End()
+
+	var plan *domain.CommissionPlan
+	c := &conditions{}
+	c.scoped(ctx)
+	query := commissionPlanSelect + c.where() + " ORDER BY created_at DESC, id LIMIT 1"
+	err := r.db.ExecuteQuery(ctx, "select", "commission_plans", func(q querier) error {
+		var err error
+		plan, err = scanCommissionPlan(q.QueryRowContext(ctx, query, c.args...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get latest commission plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// List returns the commission plans, newest first
+func (r *commissionPlanRepository) List(ctx context.Context) ([]*domain.CommissionPlan, error) {
+	ctx, span := commissionPlanTracer.Start(ctx, "commissionPlanRepository.List")
+	defer span.End()
+
+	plans := []*domain.CommissionPlan{}
+	c := &conditions{}
+	c.scoped(ctx)
+	query := commissionPlanSelect + c.where() + " ORDER BY created_at DESC, id"
+	err := r.db.ExecuteQuery(ctx, "select", "commission_plans", func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, c.args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			plan, err := scanCommissionPlan(rows)
+			if err != nil {
+				return err
+			}
+			plans = append(plans, plan)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list commission plans: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(plans)))
+
+	return plans, nil
+}
+
+func scanCommissionPlan(s scanner) (*domain.CommissionPlan, error) {
+	var plan domain.CommissionPlan
+	var planType string
+
+	err := s.Scan(
+		&plan.ID, &plan.OrganizationID, &plan.Name, &planType, &plan.Rate, jsonb{&plan.Slabs},
+		&plan.ManagerShare, &plan.Clawback, &plan.CreatedBy, &plan.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Type = domain.CommissionPlanType(planType)
+	return &plan, nil
+}
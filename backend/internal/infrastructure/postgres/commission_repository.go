@@ -0,0 +1,317 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var commissionTracer = otel.Tracer("goreal-backend/infrastructure/postgres/commission")
+
+var commissionColumns = []string{
+	"id", "organization_id", "sale_id", "plan_id", "employee_id", "commission_type", "commission_rate",
+	"commission_amount", "status", "paid_date", "payment_reference", "notes", "created_at", "updated_at",
+}
+
+var commissionSortable = map[string]bool{
+	"commission_type": true, "commission_amount": true, "status": true, "paid_date": true,
+	"created_at": true, "updated_at": true,
+}
+
+var commissionSelect = "SELECT " + strings.Join(commissionColumns, ", ") + " FROM commissions"
+
+type commissionRepository struct {
+	db *DB
+}
+
+// NewCommissionRepository creates a new commission repository
+func NewCommissionRepository(db *DB) domain.CommissionRepository {
+	return &commissionRepository{
+		db: db,
+	}
+}
+
+// Create stores a new commission
+func (r *commissionRepository) Create(ctx context.Context, commission *domain.Commission) error {
+	if err := domain.AssignOrganization(ctx, &commission.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("commission.id", commission.ID.String()),
+		attribute.String("sale.id", commission.SaleID.String()),
+		attribute.String("commission.type", commission.CommissionType),
+	)
+
+	now := timestamp()
+	if commission.CreatedAt.IsZero() {
+		commission.CreatedAt = now
+	}
+	if commission.UpdatedAt.IsZero() {
+		commission.UpdatedAt = commission.CreatedAt
+	}
+
+	query := "INSERT INTO commissions (" + strings.Join(commissionColumns, ", ") + ") VALUES (" + placeholders(len(commissionColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "commissions", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, commissionArgs(commission)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create commission: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a commission by ID
+func (r *commissionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Commission, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("commission.id", id.String()))
+
+	var commission *domain.Commission
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "select", "commissions", func(q querier) error {
+		var err error
+		commission, err = scanCommission(q.QueryRowContext(ctx, commissionSelect+" WHERE id = $1"+s.and(2), s.args(id)...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get commission by ID: %w", err)
+	}
+
+	return commission, nil
+}
+
+// GetByEmployee retrieves the commissions earned by an employee
+func (r *commissionRepository) GetByEmployee(ctx context.Context, employeeID uuid.UUID) ([]*domain.Commission, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.GetByEmployee")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("employee.id", employeeID.String()))
+
+	var commissions []*domain.Commission
+	s := scopeOf(ctx)
+	query := commissionSelect + " WHERE employee_id = $1" + s.and(2) + " ORDER BY created_at DESC, id"
+	err := r.db.ExecuteQuery(ctx, "select", "commissions", func(q querier) error {
+		var err error
+		commissions, err = queryCommissions(ctx, q, query, s.args(employeeID)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get commissions by employee: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(commissions)))
+
+	return commissions, nil
+}
+
+// GetBySale retrieves the commissions for a sale
+func (r *commissionRepository) GetBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.Commission, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.GetBySale")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	var commissions []*domain.Commission
+	s := scopeOf(ctx)
+	query := commissionSelect + " WHERE sale_id = $1" + s.and(2) + " ORDER BY created_at DESC, id"
+	err := r.db.ExecuteQuery(ctx, "select", "commissions", func(q querier) error {
+		var err error
+		commissions, err = queryCommissions(ctx, q, query, s.args(saleID)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get commissions by sale: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(commissions)))
+
+	return commissions, nil
+}
+
+// Update updates an existing commission
+func (r *commissionRepository) Update(ctx context.Context, commission *domain.Commission) error {
+	if err := domain.AssignOrganization(ctx, &commission.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("commission.id", commission.ID.String()),
+		attribute.String("commission.status", string(commission.Status)),
+	)
+
+	since := commission.UpdatedAt
+	commission.UpdatedAt = timestamp()
+
+	s := scopeOf(ctx)
+	query := "UPDATE commissions SET " + assignments(commissionColumns[1:], 2) + " WHERE id = $1" + s.and(len(commissionColumns)+1)
+	err := r.db.ExecuteQuery(ctx, "update", "commissions", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(commissionArgs(commission)...)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		commission.UpdatedAt = since
+		span.RecordError(err)
+		return fmt.Errorf("failed to update commission: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a commission by ID
+func (r *commissionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("commission.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "delete", "commissions", func(q querier) error {
+		result, err := q.ExecContext(ctx, "DELETE FROM commissions WHERE id = $1"+s.and(2), s.args(id)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete commission: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves commissions with pagination and filtering
+func (r *commissionRepository) List(ctx context.Context, filters domain.CommissionFilters) ([]*domain.Commission, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	c := commissionConditions(ctx, filters)
+	c.after(filters.BaseFilters)
+	query := commissionSelect + c.where() + orderBy(filters.BaseFilters, commissionSortable, "created_at DESC") + paginate(c, filters.BaseFilters)
+
+	var commissions []*domain.Commission
+	err := r.db.ExecuteQuery(ctx, "select", "commissions", func(q querier) error {
+		var err error
+		commissions, err = queryCommissions(ctx, q, query, c.args...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list commissions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(commissions)))
+
+	return commissions, nil
+}
+
+func commissionConditions(ctx context.Context, filters domain.CommissionFilters) *conditions {
+	c := &conditions{}
+	c.scoped(ctx)
+	if filters.SaleID != nil {
+		c.add("sale_id = ?", *filters.SaleID)
+	}
+	if filters.EmployeeID != nil {
+		c.add("employee_id = ?", *filters.EmployeeID)
+	}
+	if filters.CommissionType != nil {
+		c.add("commission_type = ?", *filters.CommissionType)
+	}
+	if filters.Status != nil {
+		c.add("status = ?", string(*filters.Status))
+	}
+	if filters.AmountMin != nil {
+		c.add("commission_amount >= ?", *filters.AmountMin)
+	}
+	if filters.AmountMax != nil {
+		c.add("commission_amount <= ?", *filters.AmountMax)
+	}
+	if filters.CreatedFrom != nil {
+		c.add("created_at >= ?", *filters.CreatedFrom)
+	}
+	if filters.CreatedTo != nil {
+		c.add("created_at <= ?", *filters.CreatedTo)
+	}
+	return c
+}
+
+func commissionArgs(commission *domain.Commission) []interface{} {
+	return []interface{}{
+		commission.ID, commission.OrganizationID, commission.SaleID, commission.PlanID, commission.EmployeeID,
+		commission.CommissionType, commission.CommissionRate, commission.CommissionAmount, string(commission.Status),
+		commission.PaidDate, commission.PaymentReference, commission.Notes, commission.CreatedAt, commission.UpdatedAt,
+	}
+}
+
+func queryCommissions(ctx context.Context, q querier, query string, args ...interface{}) ([]*domain.Commission, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	commissions := []*domain.Commission{}
+	for rows.Next() {
+		commission, err := scanCommission(rows)
+		if err != nil {
+			return nil, err
+		}
+		commissions = append(commissions, commission)
+	}
+	return commissions, rows.Err()
+}
+
+func scanCommission(s scanner) (*domain.Commission, error) {
+	var commission domain.Commission
+	var commissionType, status *string
+
+	err := s.Scan(
+		&commission.ID, &commission.OrganizationID, &commission.SaleID, &commission.PlanID, &commission.EmployeeID,
+		&commissionType, &commission.CommissionRate, &commission.CommissionAmount, &status,
+		&commission.PaidDate, &commission.PaymentReference, &commission.Notes, &commission.CreatedAt, &commission.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if commissionType != nil {
+		commission.CommissionType = *commissionType
+	}
+	if status != nil {
+		commission.Status = domain.PaymentStatus(*status)
+	}
+	return &commission, nil
+}
@@ -0,0 +1,278 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var companyTracer = otel.Tracer("goreal-backend/infrastructure/postgres/company")
+
+var companyColumns = []string{
+	"id", "organization_id", "name", "registration_number", "tax_id", "industry", "website", "phone", "email",
+	"address", "logo_url", "is_active", "created_at", "updated_at",
+}
+
+var companySortable = map[string]bool{
+	"name": true, "industry": true, "created_at": true, "updated_at": true,
+}
+
+var companySelect = "SELECT " + strings.Join(companyColumns, ", ") + " FROM companies"
+
+type companyRepository struct {
+	db *DB
+}
+
+// NewCompanyRepository creates a new company repository
+func NewCompanyRepository(db *DB) domain.CompanyRepository {
+	return &companyRepository{
+		db: db,
+	}
+}
+
+// Create creates a new company
+func (r *companyRepository) Create(ctx context.Context, company *domain.Company) error {
+	if err := domain.AssignOrganization(ctx, &company.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := companyTracer.Start(ctx, "companyRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("company.name", company.Name),
+		attribute.String("company.id", company.ID.String()),
+	)
+
+	// Set timestamps unless the caller supplied them
+	now := time.Now()
+	if company.CreatedAt.IsZero() {
+		company.CreatedAt = now
+	}
+	if company.UpdatedAt.IsZero() {
+		company.UpdatedAt = company.CreatedAt
+	}
+
+	query := "INSERT INTO companies (" + strings.Join(companyColumns, ", ") + ") VALUES (" + placeholders(len(companyColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "companies", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, companyArgs(company)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create company: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a company by ID
+func (r *companyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Company, error) {
+	ctx, span := companyTracer.Start(ctx, "companyRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("company.id", id.String()))
+
+	company, err := r.getOne(ctx, "id", id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get company by ID: %w", err)
+	}
+
+	return company, nil
+}
+
+// GetByName retrieves a company by name
+func (r *companyRepository) GetByName(ctx context.Context, name string) (*domain.Company, error) {
+	ctx, span := companyTracer.Start(ctx, "companyRepository.GetByName")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("company.name", name))
+
+	company, err := r.getOne(ctx, "name", name)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get company by name: %w", err)
+	}
+
+	return company, nil
+}
+
+func (r *companyRepository) getOne(ctx context.Context, column string, value interface{}) (*domain.Company, error) {
+	var company *domain.Company
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "select", "companies", func(q querier) error {
+		var err error
+		company, err = scanCompany(q.QueryRowContext(ctx, companySelect+" WHERE "+column+" = $1"+s.and(2)+" LIMIT 1", s.args(value)...))
+		return err
+	})
+	return company, err
+}
+
+// Update updates an existing company
+func (r *companyRepository) Update(ctx context.Context, company *domain.Company) error {
+	if err := domain.AssignOrganization(ctx, &company.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := companyTracer.Start(ctx, "companyRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("company.id", company.ID.String()),
+		attribute.String("company.name", company.Name),
+	)
+
+	// Update timestamp
+	company.UpdatedAt = time.Now()
+
+	s := scopeOf(ctx)
+	query := "UPDATE companies SET " + assignments(companyColumns[1:], 2) + " WHERE id = $1" + s.and(len(companyColumns)+1)
+	err := r.db.ExecuteQuery(ctx, "update", "companies", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(companyArgs(company)...)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to update company: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a company by ID
+func (r *companyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := companyTracer.Start(ctx, "companyRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("company.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "delete", "companies", func(q querier) error {
+		result, err := q.ExecContext(ctx, "DELETE FROM companies WHERE id = $1"+s.and(2), s.args(id)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete company: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves companies with pagination and filtering
+func (r *companyRepository) List(ctx context.Context, filters domain.CompanyFilters) ([]*domain.Company, error) {
+	ctx, span := companyTracer.Start(ctx, "companyRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	c := companyConditions(ctx, filters)
+	c.after(filters.BaseFilters)
+	query := companySelect + c.where() + orderBy(filters.BaseFilters, companySortable, "name ASC") + paginate(c, filters.BaseFilters)
+
+	companies := []*domain.Company{}
+	err := r.db.ExecuteQuery(ctx, "select", "companies", func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, c.args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			company, err := scanCompany(rows)
+			if err != nil {
+				return err
+			}
+			companies = append(companies, company)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list companies: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(companies)))
+
+	return companies, nil
+}
+
+// Count returns the total number of companies matching the filters
+func (r *companyRepository) Count(ctx context.Context, filters domain.CompanyFilters) (int, error) {
+	ctx, span := companyTracer.Start(ctx, "companyRepository.Count")
+	defer span.End()
+
+	c := companyConditions(ctx, filters)
+
+	var count int
+	err := r.db.ExecuteQuery(ctx, "count", "companies", func(q querier) error {
+		return q.QueryRowContext(ctx, "SELECT COUNT(*) FROM companies"+c.where(), c.args...).Scan(&count)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count companies: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+// companyConditions translates company filters into a WHERE clause
+func companyConditions(ctx context.Context, filters domain.CompanyFilters) *conditions {
+	c := &conditions{}
+	c.scoped(ctx)
+	if filters.Industry != nil {
+		c.add("industry = ?", *filters.Industry)
+	}
+	if filters.IsActive != nil {
+		c.add("is_active = ?", *filters.IsActive)
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		c.search(*filters.Search, "name", "email", "website")
+	}
+	return c
+}
+
+func companyArgs(company *domain.Company) []interface{} {
+	return []interface{}{
+		company.ID, company.OrganizationID, company.Name, company.RegistrationNumber, company.TaxID, company.Industry, company.Website,
+		company.Phone, company.Email, jsonb{company.Address}, company.LogoURL, company.IsActive,
+		company.CreatedAt, company.UpdatedAt,
+	}
+}
+
+func scanCompany(s scanner) (*domain.Company, error) {
+	var company domain.Company
+
+	err := s.Scan(
+		&company.ID, &company.OrganizationID, &company.Name, &company.RegistrationNumber, &company.TaxID, &company.Industry, &company.Website,
+		&company.Phone, &company.Email, jsonb{&company.Address}, &company.LogoURL, &company.IsActive,
+		&company.CreatedAt, &company.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &company, nil
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"goreal-backend/internal/infrastructure/repotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestConformance runs the shared repository suite against a plain
+// PostgreSQL database migrated with `make migrate-up DATA_BACKEND=postgres`.
+// Every table it touches is truncated between tests, so point
+// TEST_DATABASE_URL at a throwaway database.
+func TestConformance(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	sqlDB, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	require.NoError(t, sqlDB.Ping())
+
+	db := NewDBFromSQL(sqlDB)
+
+	repotest.Run(t, func(t *testing.T) *repotest.Backend {
+		_, err := sqlDB.Exec(`TRUNCATE auth_events, siwe_nonces, notifications, tasks, sales, inventory, projects, societies,
+			clients, companies, leads, profiles, organizations CASCADE`)
+		require.NoError(t, err)
+
+		return &repotest.Backend{
+			Users:              NewUserRepository(db),
+			Sessions:           NewSessionRepository(db),
+			AuthTokens:         NewAuthTokenRepository(db),
+			MFA:                NewMFARepository(db),
+			AuthEvents:         NewAuthEventRepository(db),
+			Wallets:            NewWalletRepository(db),
+			SIWENonces:         NewSIWENonceRepository(db),
+			Leads:              NewLeadRepository(db),
+			Clients:            NewClientRepository(db),
+			Companies:          NewCompanyRepository(db),
+			Societies:          NewSocietyRepository(db),
+			Projects:           NewProjectRepository(db),
+			Inventory:          NewInventoryRepository(db),
+			Interests:          NewPropertyInterestRepository(db),
+			PriceLists:         NewPriceListRepository(db),
+			Sales:              NewSaleRepository(db),
+			SaleHistory:        NewSaleStatusHistoryRepository(db),
+			AgreementTemplates: NewAgreementTemplateRepository(db),
+			Agreements:         NewAgreementRepository(db),
+			PaymentSchedules:   NewPaymentScheduleRepository(db),
+			PaymentRevisions:   NewPaymentScheduleRevisionRepository(db),
+			Commissions:        NewCommissionRepository(db),
+			CommissionPlans:    NewCommissionPlanRepository(db),
+			Tasks:              NewTaskRepository(db),
+			Notifications:      NewNotificationRepository(db),
+			Organizations:      NewOrganizationRepository(db),
+			Search:             NewSearchRepository(db),
+		}
+	})
+}
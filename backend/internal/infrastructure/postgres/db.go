@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/config"
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var postgresTracer = otel.Tracer("goreal-backend/infrastructure/postgres")
+
+// DB wraps a PostgreSQL connection pool with tracing and error mapping
+type DB struct {
+	db *sql.DB
+}
+
+// querier is the subset of *sql.DB used by the repositories
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// NewDB opens a connection pool to the database configured in DATABASE_URL
+func NewDB(cfg *config.Config) (*DB, error) {
+	if cfg.DatabaseURL == "" {
+		return nil, fmt.Errorf("DATABASE_URL is required")
+	}
+
+	db, err := sql.Open("postgres", cfg.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if cfg.DatabaseMaxConns > 0 {
+		db.SetMaxOpenConns(cfg.DatabaseMaxConns)
+		db.SetMaxIdleConns(cfg.DatabaseMaxConns)
+	}
+	db.SetConnMaxIdleTime(5 * time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return &DB{db: db}, nil
+}
+
+// NewDBFromSQL wraps an existing connection pool
+func NewDBFromSQL(db *sql.DB) *DB {
+	return &DB{db: db}
+}
+
+// SQL returns the underlying connection pool
+func (d *DB) SQL() *sql.DB {
+	return d.db
+}
+
+// Close closes the connection pool
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// Health checks the health of the database connection
+func (d *DB) Health(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, span := postgresTracer.Start(ctx, "postgres.health")
+	defer span.End()
+
+	if err := d.db.PingContext(ctx); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("database ping failed: %w", err)
+	}
+
+	return nil
+}
+
+// conn returns the querier to run statements on: the transaction of an
+// enclosing unit of work, or the pool
+func (d *DB) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return d.db
+}
+
+// ExecuteQuery executes a query with tracing and maps driver errors to domain errors
+func (d *DB) ExecuteQuery(ctx context.Context, operation string, table string, query func(q querier) error) error {
+	ctx, span := postgresTracer.Start(ctx, fmt.Sprintf("postgres.%s", operation))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", operation),
+		attribute.String("db.sql.table", table),
+		attribute.String("db.system", "postgresql"),
+	)
+
+	if err := query(d.conn(ctx)); err != nil {
+		err = mapError(err)
+		span.RecordError(err)
+		return fmt.Errorf("postgres %s operation failed: %w", operation, err)
+	}
+
+	return nil
+}
+
+// mapError wraps driver errors with the matching domain error
+func mapError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %w", domain.ErrNotFound, err)
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505": // unique_violation
+			return fmt.Errorf("%w: %w", domain.ErrAlreadyExists, err)
+		case "23502", "23503", "23514", "22P02": // not_null, foreign_key, check, invalid_text_representation
+			return fmt.Errorf("%w: %w", domain.ErrInvalidInput, err)
+		}
+	}
+
+	return err
+}
+
+// expectRows returns domain.ErrNotFound when a statement affected no rows
+func expectRows(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// purge permanently deletes the rows of table trashed before the cutoff
+func purge(ctx context.Context, q querier, table string, deletedBefore time.Time) (int, error) {
+	s := scopeOf(ctx)
+	result, err := q.ExecContext(ctx, "DELETE FROM "+table+" WHERE deleted_at < $1"+s.and(2), s.args(deletedBefore)...)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// expectVersion is expectRows for a versioned update: when nothing matched
+// it returns domain.ErrConflict if the row still exists, ErrNotFound if not
+func expectVersion(ctx context.Context, q querier, result sql.Result, table string, id uuid.UUID) error {
+	err := expectRows(result)
+	if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	var exists bool
+	s := scopeOf(ctx)
+	query := "SELECT EXISTS (SELECT 1 FROM " + table + " WHERE id = $1" + live(table) + s.and(2) + ")"
+	if err := q.QueryRowContext(ctx, query, s.args(id)...).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrConflict
+	}
+	return domain.ErrNotFound
+}
@@ -0,0 +1,471 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var inventoryTracer = otel.Tracer("goreal-backend/infrastructure/postgres/inventory")
+
+var inventoryColumns = []string{
+	"id", "project_id", "unit_number", "floor_number", "tower_block", "unit_type", "carpet_area",
+	"built_up_area", "super_built_up_area", "facing", "status", "base_price", "final_price", "price_per_sqft",
+	"parking_slots", "balconies", "bathrooms", "floor_plan_url", "features", "reserved_by", "reserved_until",
+	"created_by", "created_at", "updated_at", "organization_id",
+}
+
+var inventorySortable = map[string]bool{
+	"unit_number": true, "floor_number": true, "unit_type": true, "status": true, "carpet_area": true,
+	"base_price": true, "final_price": true, "price_per_sqft": true, "created_at": true, "updated_at": true,
+}
+
+var inventorySelect = "SELECT " + strings.Join(inventoryColumns, ", ") + " FROM inventory"
+
+type inventoryRepository struct {
+	db *DB
+}
+
+// NewInventoryRepository creates a new inventory repository
+func NewInventoryRepository(db *DB) domain.InventoryRepository {
+	return &inventoryRepository{
+		db: db,
+	}
+}
+
+// Create creates a new inventory unit
+func (r *inventoryRepository) Create(ctx context.Context, inventory *domain.Inventory) error {
+	if err := domain.AssignOrganization(ctx, &inventory.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("inventory.id", inventory.ID.String()),
+		attribute.String("inventory.project_id", inventory.ProjectID.String()),
+		attribute.String("inventory.unit_number", inventory.UnitNumber),
+	)
+
+	// Set timestamps unless the caller supplied them
+	now := time.Now()
+	if inventory.CreatedAt.IsZero() {
+		inventory.CreatedAt = now
+	}
+	if inventory.UpdatedAt.IsZero() {
+		inventory.UpdatedAt = inventory.CreatedAt
+	}
+
+	query := "INSERT INTO inventory (" + strings.Join(inventoryColumns, ", ") + ") VALUES (" + placeholders(len(inventoryColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "inventory", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, inventoryArgs(inventory)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create inventory: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an inventory unit by ID
+func (r *inventoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Inventory, error) {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	var inventory *domain.Inventory
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "select", "inventory", func(q querier) error {
+		var err error
+		inventory, err = scanInventory(q.QueryRowContext(ctx, inventorySelect+" WHERE id = $1"+s.and(2), s.args(id)...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get inventory by ID: %w", err)
+	}
+
+	return inventory, nil
+}
+
+// GetByProject retrieves the inventory of a project ordered by unit number
+func (r *inventoryRepository) GetByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Inventory, error) {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.GetByProject")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	var units []*domain.Inventory
+	s := scopeOf(ctx)
+	query := inventorySelect + " WHERE project_id = $1" + s.and(2) + " ORDER BY unit_number, id"
+	err := r.db.ExecuteQuery(ctx, "select", "inventory", func(q querier) error {
+		var err error
+		units, err = queryInventory(ctx, q, query, s.args(projectID)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get inventory by project: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(units)))
+
+	return units, nil
+}
+
+// Update updates an existing inventory unit
+func (r *inventoryRepository) Update(ctx context.Context, inventory *domain.Inventory) error {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", inventory.ID.String()))
+
+	// Update timestamp
+	since := inventory.UpdatedAt
+	inventory.UpdatedAt = timestamp()
+
+	if err := domain.AssignOrganization(ctx, &inventory.OrganizationID); err != nil {
+		return err
+	}
+	s := scopeOf(ctx)
+	query := versionedUpdate("inventory", inventoryColumns, s)
+	err := r.db.ExecuteQuery(ctx, "update", "inventory", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(append(inventoryArgs(inventory), since)...)...)
+		if err != nil {
+			return err
+		}
+		return expectVersion(ctx, q, result, "inventory", inventory.ID)
+	})
+
+	if err != nil {
+		inventory.UpdatedAt = since
+		span.RecordError(err)
+		return fmt.Errorf("failed to update inventory: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes an inventory unit by ID
+func (r *inventoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "delete", "inventory", func(q querier) error {
+		result, err := q.ExecContext(ctx, "DELETE FROM inventory WHERE id = $1"+s.and(2), s.args(id)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete inventory: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves inventory with pagination and filtering
+func (r *inventoryRepository) List(ctx context.Context, filters domain.InventoryFilters) ([]*domain.Inventory, error) {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	c := inventoryConditions(ctx, filters)
+	c.after(filters.BaseFilters)
+	query := inventorySelect + c.where() + orderBy(filters.BaseFilters, inventorySortable, "created_at DESC") + paginate(c, filters.BaseFilters)
+
+	var units []*domain.Inventory
+	err := r.db.ExecuteQuery(ctx, "select", "inventory", func(q querier) error {
+		var err error
+		units, err = queryInventory(ctx, q, query, c.args...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list inventory: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(units)))
+
+	return units, nil
+}
+
+// Count returns the total number of inventory units matching the filters
+func (r *inventoryRepository) Count(ctx context.Context, filters domain.InventoryFilters) (int, error) {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Count")
+	defer span.End()
+
+	c := inventoryConditions(ctx, filters)
+
+	var count int
+	err := r.db.ExecuteQuery(ctx, "count", "inventory", func(q querier) error {
+		return q.QueryRowContext(ctx, "SELECT COUNT(*) FROM inventory"+c.where(), c.args...).Scan(&count)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count inventory: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+// GetAvailable retrieves the available units of a project
+func (r *inventoryRepository) GetAvailable(ctx context.Context, projectID uuid.UUID) ([]*domain.Inventory, error) {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.GetAvailable")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	var units []*domain.Inventory
+	s := scopeOf(ctx)
+	query := inventorySelect + " WHERE project_id = $1 AND status = $2" + s.and(3) + " ORDER BY unit_number, id"
+	err := r.db.ExecuteQuery(ctx, "select", "inventory", func(q querier) error {
+		var err error
+		units, err = queryInventory(ctx, q, query, s.args(projectID, string(domain.UnitStatusAvailable))...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get available inventory: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(units)))
+
+	return units, nil
+}
+
+// Reserve marks an available unit as reserved for a client until the given
+// time. The status check and the update are one statement, so two callers
+// can never reserve the same unit.
+func (r *inventoryRepository) Reserve(ctx context.Context, id uuid.UUID, clientID uuid.UUID, until time.Time) error {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Reserve")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("inventory.id", id.String()),
+		attribute.String("client.id", clientID.String()),
+	)
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "update", "inventory", func(q querier) error {
+		result, err := q.ExecContext(ctx, `UPDATE inventory
+			SET status = $2, reserved_by = $3, reserved_until = $4, updated_at = $5
+			WHERE id = $1 AND status = $6`+s.and(7),
+			s.args(id, string(domain.UnitStatusReserved), clientID, until, time.Now(), string(domain.UnitStatusAvailable))...)
+		if err != nil {
+			return err
+		}
+		if err := expectRows(result); err == nil || !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+
+		// Nothing was updated: tell a missing unit from an unavailable one
+		var exists bool
+		if err := q.QueryRowContext(ctx, "SELECT true FROM inventory WHERE id = $1"+s.and(2), s.args(id)...).Scan(&exists); err != nil {
+			return err
+		}
+		return domain.ErrInventoryNotAvailable
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to reserve inventory: %w", err)
+	}
+
+	return nil
+}
+
+// Release returns a reserved unit to the available pool
+func (r *inventoryRepository) Release(ctx context.Context, id uuid.UUID) error {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Release")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "update", "inventory", func(q querier) error {
+		result, err := q.ExecContext(ctx, `UPDATE inventory
+			SET status = $2, reserved_by = NULL, reserved_until = NULL, updated_at = $3
+			WHERE id = $1`+s.and(4),
+			s.args(id, string(domain.UnitStatusAvailable), time.Now())...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to release inventory: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseIfExpired releases a unit only if its reservation ran out by at.
+// The expiry check is part of the update, so a reservation extended or
+// converted to a sale in the meantime is left alone.
+func (r *inventoryRepository) ReleaseIfExpired(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.ReleaseIfExpired")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "update", "inventory", func(q querier) error {
+		result, err := q.ExecContext(ctx, `UPDATE inventory
+			SET status = $2, reserved_by = NULL, reserved_until = NULL, updated_at = $3
+			WHERE id = $1 AND status = $4 AND reserved_until <= $5`+s.and(6),
+			s.args(id, string(domain.UnitStatusAvailable), time.Now(), string(domain.UnitStatusReserved), at)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to release expired inventory: %w", err)
+	}
+
+	return nil
+}
+
+// inventoryConditions translates inventory filters into a WHERE clause
+func inventoryConditions(ctx context.Context, filters domain.InventoryFilters) *conditions {
+	c := &conditions{}
+	c.scoped(ctx)
+	if filters.ProjectID != nil {
+		c.add("project_id = ?", *filters.ProjectID)
+	}
+	if len(filters.ProjectIDs) > 0 {
+		c.add("project_id = ANY(?::uuid[])", pq.Array(uuidStrings(filters.ProjectIDs)))
+	}
+	if filters.UnitType != nil {
+		c.add("unit_type = ?", *filters.UnitType)
+	}
+	if filters.Status != nil {
+		c.add("status = ?", string(*filters.Status))
+	}
+	if filters.FloorNumber != nil {
+		c.add("floor_number = ?", *filters.FloorNumber)
+	}
+	if filters.Facing != nil {
+		c.add("facing = ?", *filters.Facing)
+	}
+	if filters.PriceMin != nil {
+		c.add("final_price >= ?", *filters.PriceMin)
+	}
+	if filters.PriceMax != nil {
+		c.add("final_price <= ?", *filters.PriceMax)
+	}
+	if filters.AreaMin != nil {
+		c.add("carpet_area >= ?", *filters.AreaMin)
+	}
+	if filters.AreaMax != nil {
+		c.add("carpet_area <= ?", *filters.AreaMax)
+	}
+	if filters.ParkingSlots != nil {
+		c.add("parking_slots = ?", *filters.ParkingSlots)
+	}
+	if filters.MinParkingSlots != nil {
+		c.add("parking_slots >= ?", *filters.MinParkingSlots)
+	}
+	if filters.ReservedBy != nil {
+		c.add("reserved_by = ?", *filters.ReservedBy)
+	}
+	if filters.ReservedUntilTo != nil {
+		c.add("reserved_until <= ?", *filters.ReservedUntilTo)
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		c.search(*filters.Search, "unit_number", "unit_type", "tower_block")
+	}
+	return c
+}
+
+func inventoryArgs(inventory *domain.Inventory) []interface{} {
+	return []interface{}{
+		inventory.ID, inventory.ProjectID, inventory.UnitNumber, inventory.FloorNumber, inventory.TowerBlock,
+		inventory.UnitType, inventory.CarpetArea, inventory.BuiltUpArea, inventory.SuperBuiltUpArea,
+		inventory.Facing, string(inventory.Status), inventory.BasePrice, inventory.FinalPrice,
+		inventory.PricePerSqft, inventory.ParkingSlots, inventory.Balconies, inventory.Bathrooms,
+		inventory.FloorPlanURL, pq.Array(inventory.Features), inventory.ReservedBy, inventory.ReservedUntil,
+		nullUUID(inventory.CreatedBy), inventory.CreatedAt, inventory.UpdatedAt, inventory.OrganizationID,
+	}
+}
+
+func scanInventory(s scanner) (*domain.Inventory, error) {
+	var inventory domain.Inventory
+	var status string
+	var projectID, createdBy uuid.NullUUID
+	var parkingSlots, balconies, bathrooms sql.NullInt64
+
+	err := s.Scan(
+		&inventory.ID, &projectID, &inventory.UnitNumber, &inventory.FloorNumber, &inventory.TowerBlock,
+		&inventory.UnitType, &inventory.CarpetArea, &inventory.BuiltUpArea, &inventory.SuperBuiltUpArea,
+		&inventory.Facing, &status, &inventory.BasePrice, &inventory.FinalPrice,
+		&inventory.PricePerSqft, &parkingSlots, &balconies, &bathrooms,
+		&inventory.FloorPlanURL, pq.Array(&inventory.Features), &inventory.ReservedBy, &inventory.ReservedUntil,
+		&createdBy, &inventory.CreatedAt, &inventory.UpdatedAt, &inventory.OrganizationID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory.Status = domain.UnitStatus(status)
+	inventory.ProjectID = projectID.UUID
+	inventory.ParkingSlots = int(parkingSlots.Int64)
+	inventory.Balconies = int(balconies.Int64)
+	inventory.Bathrooms = int(bathrooms.Int64)
+	inventory.CreatedBy = createdBy.UUID
+	return &inventory, nil
+}
+
+func queryInventory(ctx context.Context, q querier, query string, args ...interface{}) ([]*domain.Inventory, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	units := []*domain.Inventory{}
+	for rows.Next() {
+		unit, err := scanInventory(rows)
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, unit)
+	}
+	return units, rows.Err()
+}
@@ -0,0 +1,292 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var leadColumns = []string{
+	"id", "name", "email", "phone", "company_name", "designation", "source", "status",
+	"assigned_to", "budget_min", "budget_max", "requirements", "notes", "last_contact_date",
+	"next_follow_up", "score", "tags", "custom_fields", "created_by", "created_at", "updated_at",
+	"deleted_at", "deleted_by", "organization_id",
+}
+
+var leadSortable = map[string]bool{
+	"name": true, "status": true, "source": true, "score": true, "budget_min": true, "budget_max": true,
+	"next_follow_up": true, "last_contact_date": true, "created_at": true, "updated_at": true,
+}
+
+var leadSelect = "SELECT " + strings.Join(leadColumns, ", ") + " FROM leads"
+
+// leadRepository implements domain.LeadRepository using PostgreSQL
+type leadRepository struct {
+	db *DB
+}
+
+// NewLeadRepository creates a new lead repository
+func NewLeadRepository(db *DB) domain.LeadRepository {
+	return &leadRepository{
+		db: db,
+	}
+}
+
+func (r *leadRepository) Create(ctx context.Context, lead *domain.Lead) error {
+	if err := domain.AssignOrganization(ctx, &lead.OrganizationID); err != nil {
+		return err
+	}
+	query := "INSERT INTO leads (" + strings.Join(leadColumns, ", ") + ") VALUES (" + placeholders(len(leadColumns)) + ")"
+
+	return r.db.ExecuteQuery(ctx, "insert", "leads", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, leadArgs(lead)...)
+		return err
+	})
+}
+
+func (r *leadRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Lead, error) {
+	var lead *domain.Lead
+	s := scopeOf(ctx)
+
+	err := r.db.ExecuteQuery(ctx, "select_by_id", "leads", func(q querier) error {
+		var err error
+		lead, err = scanLead(q.QueryRowContext(ctx, leadSelect+" WHERE id = $1 AND deleted_at IS NULL"+s.and(2), s.args(id)...))
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("lead not found: %w", err)
+	}
+
+	return lead, nil
+}
+
+func (r *leadRepository) Update(ctx context.Context, lead *domain.Lead) error {
+	since := lead.UpdatedAt
+	lead.UpdatedAt = timestamp()
+	if err := domain.AssignOrganization(ctx, &lead.OrganizationID); err != nil {
+		return err
+	}
+	s := scopeOf(ctx)
+	query := versionedUpdate("leads", leadColumns, s)
+
+	err := r.db.ExecuteQuery(ctx, "update", "leads", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(append(leadArgs(lead), since)...)...)
+		if err != nil {
+			return err
+		}
+		return expectVersion(ctx, q, result, "leads", lead.ID)
+	})
+
+	if err != nil {
+		lead.UpdatedAt = since
+	}
+	return err
+}
+
+func (r *leadRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	s := scopeOf(ctx)
+	return r.db.ExecuteQuery(ctx, "delete", "leads", func(q querier) error {
+		result, err := q.ExecContext(ctx, softDelete("leads", s), s.args(id, timestamp(), deletedBy)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+}
+
+func (r *leadRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	s := scopeOf(ctx)
+	return r.db.ExecuteQuery(ctx, "restore", "leads", func(q querier) error {
+		result, err := q.ExecContext(ctx, restore("leads", s), s.args(id, timestamp())...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+}
+
+func (r *leadRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var purged int
+	err := r.db.ExecuteQuery(ctx, "purge", "leads", func(q querier) error {
+		var err error
+		purged, err = purge(ctx, q, "leads", deletedBefore)
+		return err
+	})
+	return purged, err
+}
+
+func (r *leadRepository) List(ctx context.Context, filters domain.LeadFilters) ([]*domain.Lead, error) {
+	c := leadConditions(ctx, filters)
+	c.after(filters.BaseFilters)
+	query := leadSelect + c.where() + orderBy(filters.BaseFilters, leadSortable, "created_at DESC") + paginate(c, filters.BaseFilters)
+
+	var leads []*domain.Lead
+	err := r.db.ExecuteQuery(ctx, "select_list", "leads", func(q querier) error {
+		var err error
+		leads, err = queryLeads(ctx, q, query, c.args...)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list leads: %w", err)
+	}
+
+	return leads, nil
+}
+
+func (r *leadRepository) Count(ctx context.Context, filters domain.LeadFilters) (int, error) {
+	c := leadConditions(ctx, filters)
+
+	var count int
+	err := r.db.ExecuteQuery(ctx, "count", "leads", func(q querier) error {
+		return q.QueryRowContext(ctx, "SELECT COUNT(*) FROM leads"+c.where(), c.args...).Scan(&count)
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count leads: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *leadRepository) GetByAssignedUser(ctx context.Context, userID uuid.UUID) ([]*domain.Lead, error) {
+	var leads []*domain.Lead
+	s := scopeOf(ctx)
+	query := leadSelect + " WHERE assigned_to = $1 AND deleted_at IS NULL" + s.and(2) + " ORDER BY created_at DESC, id"
+
+	err := r.db.ExecuteQuery(ctx, "select_by_assigned_user", "leads", func(q querier) error {
+		var err error
+		leads, err = queryLeads(ctx, q, query, s.args(userID)...)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leads by assigned user: %w", err)
+	}
+
+	return leads, nil
+}
+
+func (r *leadRepository) GetOverdueFollowUps(ctx context.Context) ([]*domain.Lead, error) {
+	var leads []*domain.Lead
+	s := scopeOf(ctx)
+
+	query := leadSelect + ` WHERE next_follow_up IS NOT NULL AND next_follow_up < $1
+		AND status NOT IN ($2, $3) AND deleted_at IS NULL` + s.and(4) + ` ORDER BY next_follow_up ASC, id`
+
+	err := r.db.ExecuteQuery(ctx, "select_overdue_followups", "leads", func(q querier) error {
+		var err error
+		leads, err = queryLeads(ctx, q, query, s.args(time.Now(),
+			string(domain.LeadStatusConverted), string(domain.LeadStatusLost))...)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue follow-ups: %w", err)
+	}
+
+	return leads, nil
+}
+
+// leadConditions translates lead filters into a WHERE clause
+func leadConditions(ctx context.Context, filters domain.LeadFilters) *conditions {
+	c := &conditions{}
+	c.scoped(ctx)
+	if !filters.IncludeDeleted {
+		c.add("deleted_at IS NULL")
+	}
+	if filters.Status != nil {
+		c.add("status = ?", string(*filters.Status))
+	}
+	if filters.Source != nil {
+		c.add("source = ?", string(*filters.Source))
+	}
+	if filters.AssignedTo != nil {
+		c.add("assigned_to = ?", *filters.AssignedTo)
+	}
+	if filters.CreatedBy != nil {
+		c.add("created_by = ?", *filters.CreatedBy)
+	}
+	if filters.BudgetMin != nil {
+		c.add("budget_min >= ?", *filters.BudgetMin)
+	}
+	if filters.BudgetMax != nil {
+		c.add("budget_max <= ?", *filters.BudgetMax)
+	}
+	if filters.ScoreMin != nil {
+		c.add("score >= ?", *filters.ScoreMin)
+	}
+	if filters.ScoreMax != nil {
+		c.add("score <= ?", *filters.ScoreMax)
+	}
+	if filters.CreatedAfter != nil {
+		c.add("created_at >= ?", *filters.CreatedAfter)
+	}
+	if filters.CreatedBefore != nil {
+		c.add("created_at <= ?", *filters.CreatedBefore)
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		c.search(*filters.Search, "name", "email", "company_name", "requirements")
+	}
+	if len(filters.Tags) > 0 {
+		c.add("tags @> ?", pq.Array(filters.Tags))
+	}
+	return c
+}
+
+func leadArgs(lead *domain.Lead) []interface{} {
+	return []interface{}{
+		lead.ID, lead.Name, lead.Email, lead.Phone, lead.CompanyName, lead.Designation,
+		string(lead.Source), string(lead.Status), lead.AssignedTo, lead.BudgetMin, lead.BudgetMax,
+		lead.Requirements, lead.Notes, lead.LastContactDate, lead.NextFollowUp, lead.Score,
+		pq.Array(lead.Tags), jsonb{lead.CustomFields}, nullUUID(lead.CreatedBy), lead.CreatedAt, lead.UpdatedAt,
+		lead.DeletedAt, lead.DeletedBy, lead.OrganizationID,
+	}
+}
+
+func scanLead(s scanner) (*domain.Lead, error) {
+	var lead domain.Lead
+	var source, status string
+	var createdBy uuid.NullUUID
+
+	err := s.Scan(
+		&lead.ID, &lead.Name, &lead.Email, &lead.Phone, &lead.CompanyName, &lead.Designation,
+		&source, &status, &lead.AssignedTo, &lead.BudgetMin, &lead.BudgetMax,
+		&lead.Requirements, &lead.Notes, &lead.LastContactDate, &lead.NextFollowUp, &lead.Score,
+		pq.Array(&lead.Tags), jsonb{&lead.CustomFields}, &createdBy, &lead.CreatedAt, &lead.UpdatedAt,
+		&lead.DeletedAt, &lead.DeletedBy, &lead.OrganizationID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	lead.Source = domain.LeadSource(source)
+	lead.Status = domain.LeadStatus(status)
+	lead.CreatedBy = createdBy.UUID
+	return &lead, nil
+}
+
+func queryLeads(ctx context.Context, q querier, query string, args ...interface{}) ([]*domain.Lead, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	leads := []*domain.Lead{}
+	for rows.Next() {
+		lead, err := scanLead(rows)
+		if err != nil {
+			return nil, err
+		}
+		leads = append(leads, lead)
+	}
+	return leads, rows.Err()
+}
@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var mfaTracer = otel.Tracer("goreal-backend/infrastructure/postgres/mfa")
+
+var mfaColumns = []string{"user_id", "secret", "confirmed_at", "last_step", "created_at", "updated_at"}
+
+type mfaRepository struct {
+	db *DB
+}
+
+// NewMFARepository creates a new MFA repository
+func NewMFARepository(db *DB) domain.MFARepository {
+	return &mfaRepository{
+		db: db,
+	}
+}
+
+// Save creates or replaces the authenticator of a user
+func (r *mfaRepository) Save(ctx context.Context, enrollment *domain.MFAEnrollment) error {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.Save")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", enrollment.UserID.String()))
+
+	now := timestamp()
+	if enrollment.CreatedAt.IsZero() {
+		enrollment.CreatedAt = now
+	}
+	enrollment.UpdatedAt = now
+
+	query := "INSERT INTO user_mfa (" + strings.Join(mfaColumns, ", ") + ") VALUES (" + placeholders(len(mfaColumns)) + `)
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, confirmed_at = EXCLUDED.confirmed_at,
+			last_step = EXCLUDED.last_step, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at`
+	err := r.db.ExecuteQuery(ctx, "upsert", "user_mfa", func(q querier) error {
+		_, err := q.ExecContext(ctx, query,
+			enrollment.UserID, enrollment.Secret, enrollment.ConfirmedAt, enrollment.LastStep, enrollment.CreatedAt, enrollment.UpdatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to save MFA enrollment: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUser retrieves the authenticator of a user
+func (r *mfaRepository) GetByUser(ctx context.Context, userID uuid.UUID) (*domain.MFAEnrollment, error) {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.GetByUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	var enrollment domain.MFAEnrollment
+	query := "SELECT " + strings.Join(mfaColumns, ", ") + " FROM user_mfa WHERE user_id = $1"
+	err := r.db.ExecuteQuery(ctx, "select", "user_mfa", func(q querier) error {
+		return q.QueryRowContext(ctx, query, userID).Scan(
+			&enrollment.UserID, &enrollment.Secret, &enrollment.ConfirmedAt, &enrollment.LastStep,
+			&enrollment.CreatedAt, &enrollment.UpdatedAt)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get MFA enrollment: %w", err)
+	}
+
+	return &enrollment, nil
+}
+
+// UseStep records an accepted code in an update guarded by the last step,
+// so of two logins racing with one code only the first succeeds
+func (r *mfaRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64, at time.Time) error {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.UseStep")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	err := r.db.ExecuteQuery(ctx, "update", "user_mfa", func(q querier) error {
+		result, err := q.ExecContext(ctx,
+			"UPDATE user_mfa SET last_step = $2, updated_at = $3 WHERE user_id = $1 AND last_step < $2",
+			userID, step, at)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to use MFA code: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the authenticator and recovery codes of a user
+func (r *mfaRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	err := r.db.ExecuteQuery(ctx, "delete", "user_mfa", func(q querier) error {
+		if _, err := q.ExecContext(ctx, "DELETE FROM mfa_recovery_codes WHERE user_id = $1", userID); err != nil {
+			return err
+		}
+		result, err := q.ExecContext(ctx, "DELETE FROM user_mfa WHERE user_id = $1", userID)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete MFA enrollment: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes swaps a user's recovery codes for new ones
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string, at time.Time) error {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.ReplaceRecoveryCodes")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Int("codes.count", len(codeHashes)),
+	)
+
+	err := r.db.ExecuteQuery(ctx, "replace", "mfa_recovery_codes", func(q querier) error {
+		if _, err := q.ExecContext(ctx, "DELETE FROM mfa_recovery_codes WHERE user_id = $1", userID); err != nil {
+			return err
+		}
+		for _, hash := range codeHashes {
+			_, err := q.ExecContext(ctx,
+				"INSERT INTO mfa_recovery_codes (id, user_id, code_hash, created_at) VALUES ($1, $2, $3, $4)",
+				uuid.New(), userID, hash, at)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode redeems an unused recovery code in a single guarded update
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, at time.Time) error {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.UseRecoveryCode")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	err := r.db.ExecuteQuery(ctx, "update", "mfa_recovery_codes", func(q querier) error {
+		result, err := q.ExecContext(ctx,
+			"UPDATE mfa_recovery_codes SET used_at = $3 WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL",
+			userID, codeHash, at)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	return nil
+}
+
+// CountRecoveryCodes returns how many unused recovery codes a user has
+func (r *mfaRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.CountRecoveryCodes")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	var count int
+	err := r.db.ExecuteQuery(ctx, "count", "mfa_recovery_codes", func(q querier) error {
+		return q.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = $1 AND used_at IS NULL", userID).Scan(&count)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return count, nil
+}
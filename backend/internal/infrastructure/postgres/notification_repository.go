@@ -0,0 +1,251 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var notificationTracer = otel.Tracer("goreal-backend/infrastructure/postgres/notification")
+
+const notificationSelect = "SELECT id, user_id, type, title, message, data, is_read, created_at FROM notifications"
+
+type notificationRepository struct {
+	db *DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *DB) domain.NotificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+// Create creates a new notification
+func (r *notificationRepository) Create(ctx context.Context, notification *domain.Notification) error {
+	ctx, span := notificationTracer.Start(ctx, "notificationRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("notification.id", notification.ID.String()),
+		attribute.String("notification.user_id", notification.UserID.String()),
+		attribute.String("notification.type", string(notification.Type)),
+		attribute.String("notification.title", notification.Title),
+	)
+
+	// Set timestamp unless the caller supplied one
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	err := r.db.ExecuteQuery(ctx, "insert", "notifications", func(q querier) error {
+		_, err := q.ExecContext(ctx, `INSERT INTO notifications (id, user_id, type, title, message, data, is_read, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			notification.ID, notification.UserID, string(notification.Type), notification.Title,
+			notification.Message, jsonb{notification.Data}, notification.IsRead, notification.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a notification by ID
+func (r *notificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Notification, error) {
+	ctx, span := notificationTracer.Start(ctx, "notificationRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("notification.id", id.String()))
+
+	var notification *domain.Notification
+	err := r.db.ExecuteQuery(ctx, "select", "notifications", func(q querier) error {
+		var err error
+		notification, err = scanNotification(q.QueryRowContext(ctx, notificationSelect+" WHERE id = $1", id))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get notification by ID: %w", err)
+	}
+
+	return notification, nil
+}
+
+// Update updates an existing notification
+func (r *notificationRepository) Update(ctx context.Context, notification *domain.Notification) error {
+	ctx, span := notificationTracer.Start(ctx, "notificationRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("notification.id", notification.ID.String()),
+		attribute.Bool("notification.is_read", notification.IsRead),
+	)
+
+	err := r.db.ExecuteQuery(ctx, "update", "notifications", func(q querier) error {
+		result, err := q.ExecContext(ctx, `UPDATE notifications
+			SET user_id = $2, type = $3, title = $4, message = $5, data = $6, is_read = $7 WHERE id = $1`,
+			notification.ID, notification.UserID, string(notification.Type), notification.Title,
+			notification.Message, jsonb{notification.Data}, notification.IsRead)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to update notification: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a notification by ID
+func (r *notificationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := notificationTracer.Start(ctx, "notificationRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("notification.id", id.String()))
+
+	err := r.db.ExecuteQuery(ctx, "delete", "notifications", func(q querier) error {
+		result, err := q.ExecContext(ctx, "DELETE FROM notifications WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete notification: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUser retrieves notifications for a user, most recent first
+func (r *notificationRepository) GetByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Notification, error) {
+	ctx, span := notificationTracer.Start(ctx, "notificationRepository.GetByUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	notifications := []*domain.Notification{}
+	err := r.db.ExecuteQuery(ctx, "select", "notifications", func(q querier) error {
+		rows, err := q.QueryContext(ctx, notificationSelect+" WHERE user_id = $1 ORDER BY created_at DESC, id", userID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			notification, err := scanNotification(rows)
+			if err != nil {
+				return err
+			}
+			notifications = append(notifications, notification)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get notifications by user ID: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(notifications)))
+
+	return notifications, nil
+}
+
+// MarkAsRead marks a notification as read
+func (r *notificationRepository) MarkAsRead(ctx context.Context, id uuid.UUID) error {
+	ctx, span := notificationTracer.Start(ctx, "notificationRepository.MarkAsRead")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("notification.id", id.String()))
+
+	err := r.db.ExecuteQuery(ctx, "update", "notifications", func(q querier) error {
+		result, err := q.ExecContext(ctx, "UPDATE notifications SET is_read = true WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	return nil
+}
+
+// MarkAllAsRead marks all notifications as read for a user
+func (r *notificationRepository) MarkAllAsRead(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := notificationTracer.Start(ctx, "notificationRepository.MarkAllAsRead")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	err := r.db.ExecuteQuery(ctx, "update", "notifications", func(q querier) error {
+		_, err := q.ExecContext(ctx, "UPDATE notifications SET is_read = true WHERE user_id = $1 AND is_read = false", userID)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to mark all notifications as read: %w", err)
+	}
+
+	return nil
+}
+
+// GetUnreadCount returns the count of unread notifications for a user
+func (r *notificationRepository) GetUnreadCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, span := notificationTracer.Start(ctx, "notificationRepository.GetUnreadCount")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	var count int
+	err := r.db.ExecuteQuery(ctx, "count", "notifications", func(q querier) error {
+		return q.QueryRowContext(ctx, "SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND is_read = false", userID).Scan(&count)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to get unread count: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+func scanNotification(s scanner) (*domain.Notification, error) {
+	var notification domain.Notification
+	var notificationType string
+
+	err := s.Scan(
+		&notification.ID, &notification.UserID, &notificationType, &notification.Title,
+		&notification.Message, jsonb{&notification.Data}, &notification.IsRead, &notification.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	notification.Type = domain.NotificationType(notificationType)
+	return &notification, nil
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var organizationColumns = []string{
+	"id", "name", "slug", "is_active", "created_at", "updated_at",
+}
+
+var organizationSortable = map[string]bool{
+	"name": true, "slug": true, "created_at": true, "updated_at": true,
+}
+
+var organizationSelect = "SELECT " + strings.Join(organizationColumns, ", ") + " FROM organizations"
+
+// organizationRepository implements domain.OrganizationRepository using PostgreSQL.
+// A tenant scoped context only ever sees its own organization.
+type organizationRepository struct {
+	db *DB
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(db *DB) domain.OrganizationRepository {
+	return &organizationRepository{
+		db: db,
+	}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, organization *domain.Organization) error {
+	if organization.CreatedAt.IsZero() {
+		organization.CreatedAt = timestamp()
+	}
+	if organization.UpdatedAt.IsZero() {
+		organization.UpdatedAt = organization.CreatedAt
+	}
+	query := "INSERT INTO organizations (" + strings.Join(organizationColumns, ", ") + ") VALUES (" + placeholders(len(organizationColumns)) + ")"
+
+	return r.db.ExecuteQuery(ctx, "insert", "organizations", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, organizationArgs(organization)...)
+		return err
+	})
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	return r.getOne(ctx, "select_by_id", "id", id)
+}
+
+func (r *organizationRepository) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	return r.getOne(ctx, "select_by_slug", "slug", slug)
+}
+
+func (r *organizationRepository) getOne(ctx context.Context, operation, column string, value interface{}) (*domain.Organization, error) {
+	c := &conditions{}
+	c.add(column+" = ?", value)
+	organizationScope(ctx, c)
+
+	var organization *domain.Organization
+	err := r.db.ExecuteQuery(ctx, operation, "organizations", func(q querier) error {
+		var err error
+		organization, err = scanOrganization(q.QueryRowContext(ctx, organizationSelect+c.where(), c.args...))
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("organization not found: %w", err)
+	}
+
+	return organization, nil
+}
+
+func (r *organizationRepository) Update(ctx context.Context, organization *domain.Organization) error {
+	if id, ok := domain.OrganizationFromContext(ctx); ok && id != organization.ID {
+		return domain.ErrNotFound
+	}
+
+	organization.UpdatedAt = time.Now()
+	query := "UPDATE organizations SET " + assignments(organizationColumns[1:], 2) + " WHERE id = $1"
+
+	return r.db.ExecuteQuery(ctx, "update", "organizations", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, organizationArgs(organization)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+}
+
+func (r *organizationRepository) List(ctx context.Context, filters domain.OrganizationFilters) ([]*domain.Organization, error) {
+	c := &conditions{}
+	organizationScope(ctx, c)
+	if filters.IsActive != nil {
+		c.add("is_active = ?", *filters.IsActive)
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		c.search(*filters.Search, "name", "slug")
+	}
+	c.after(filters.BaseFilters)
+	query := organizationSelect + c.where() + orderBy(filters.BaseFilters, organizationSortable, "name ASC") + paginate(c, filters.BaseFilters)
+
+	var organizations []*domain.Organization
+	err := r.db.ExecuteQuery(ctx, "select_list", "organizations", func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, c.args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		organizations = []*domain.Organization{}
+		for rows.Next() {
+			organization, err := scanOrganization(rows)
+			if err != nil {
+				return err
+			}
+			organizations = append(organizations, organization)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+// organizationScope limits a tenant scoped context to its own organization
+func organizationScope(ctx context.Context, c *conditions) {
+	if id, ok := domain.OrganizationFromContext(ctx); ok {
+		c.add("id = ?", id)
+	}
+}
+
+func organizationArgs(organization *domain.Organization) []interface{} {
+	return []interface{}{
+		organization.ID, organization.Name, organization.Slug, organization.IsActive,
+		organization.CreatedAt, organization.UpdatedAt,
+	}
+}
+
+func scanOrganization(s scanner) (*domain.Organization, error) {
+	var organization domain.Organization
+
+	err := s.Scan(
+		&organization.ID, &organization.Name, &organization.Slug, &organization.IsActive,
+		&organization.CreatedAt, &organization.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &organization, nil
+}
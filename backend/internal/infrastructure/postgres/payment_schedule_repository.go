@@ -0,0 +1,306 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var paymentScheduleTracer = otel.Tracer("goreal-backend/infrastructure/postgres/payment_schedule")
+
+var paymentScheduleColumns = []string{
+	"id", "organization_id", "sale_id", "installment_number", "due_date", "amount", "description", "status", "paid_amount",
+	"paid_date", "payment_method", "transaction_reference", "late_fee", "notes", "created_at", "updated_at",
+}
+
+var paymentScheduleSortable = map[string]bool{
+	"installment_number": true, "due_date": true, "amount": true, "status": true,
+	"created_at": true, "updated_at": true,
+}
+
+var paymentScheduleSelect = "SELECT " + strings.Join(paymentScheduleColumns, ", ") + " FROM payment_schedules"
+
+type paymentScheduleRepository struct {
+	db *DB
+}
+
+// NewPaymentScheduleRepository creates a new payment schedule repository
+func NewPaymentScheduleRepository(db *DB) domain.PaymentScheduleRepository {
+	return &paymentScheduleRepository{
+		db: db,
+	}
+}
+
+// Create stores a new installment
+func (r *paymentScheduleRepository) Create(ctx context.Context, schedule *domain.PaymentSchedule) error {
+	if err := domain.AssignOrganization(ctx, &schedule.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("payment.id", schedule.ID.String()),
+		attribute.String("sale.id", schedule.SaleID.String()),
+		attribute.Int("payment.installment_number", schedule.InstallmentNumber),
+	)
+
+	now := timestamp()
+	if schedule.CreatedAt.IsZero() {
+		schedule.CreatedAt = now
+	}
+	if schedule.UpdatedAt.IsZero() {
+		schedule.UpdatedAt = schedule.CreatedAt
+	}
+
+	query := "INSERT INTO payment_schedules (" + strings.Join(paymentScheduleColumns, ", ") + ") VALUES (" + placeholders(len(paymentScheduleColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "payment_schedules", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, paymentScheduleArgs(schedule)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create payment schedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an installment by ID
+func (r *paymentScheduleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PaymentSchedule, error) {
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("payment.id", id.String()))
+
+	var schedule *domain.PaymentSchedule
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "select", "payment_schedules", func(q querier) error {
+		var err error
+		schedule, err = scanPaymentSchedule(q.QueryRowContext(ctx, paymentScheduleSelect+" WHERE id = $1"+s.and(2), s.args(id)...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get payment schedule by ID: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// GetBySale retrieves the installments of a sale in order
+func (r *paymentScheduleRepository) GetBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.PaymentSchedule, error) {
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.GetBySale")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	var schedules []*domain.PaymentSchedule
+	s := scopeOf(ctx)
+	query := paymentScheduleSelect + " WHERE sale_id = $1" + s.and(2) + " ORDER BY installment_number, id"
+	err := r.db.ExecuteQuery(ctx, "select", "payment_schedules", func(q querier) error {
+		var err error
+		schedules, err = queryPaymentSchedules(ctx, q, query, s.args(saleID)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get payment schedules by sale: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(schedules)))
+
+	return schedules, nil
+}
+
+// Update updates an existing installment
+func (r *paymentScheduleRepository) Update(ctx context.Context, schedule *domain.PaymentSchedule) error {
+	if err := domain.AssignOrganization(ctx, &schedule.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("payment.id", schedule.ID.String()),
+		attribute.String("payment.status", string(schedule.Status)),
+	)
+
+	since := schedule.UpdatedAt
+	schedule.UpdatedAt = timestamp()
+
+	s := scopeOf(ctx)
+	query := "UPDATE payment_schedules SET " + assignments(paymentScheduleColumns[1:], 2) + " WHERE id = $1" + s.and(len(paymentScheduleColumns)+1)
+	err := r.db.ExecuteQuery(ctx, "update", "payment_schedules", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(paymentScheduleArgs(schedule)...)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		schedule.UpdatedAt = since
+		span.RecordError(err)
+		return fmt.Errorf("failed to update payment schedule: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes an installment by ID
+func (r *paymentScheduleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("payment.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "delete", "payment_schedules", func(q querier) error {
+		result, err := q.ExecContext(ctx, "DELETE FROM payment_schedules WHERE id = $1"+s.and(2), s.args(id)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete payment schedule: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves installments with pagination and filtering
+func (r *paymentScheduleRepository) List(ctx context.Context, filters domain.PaymentScheduleFilters) ([]*domain.PaymentSchedule, error) {
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	c := paymentScheduleConditions(ctx, filters)
+	c.after(filters.BaseFilters)
+	query := paymentScheduleSelect + c.where() + orderBy(filters.BaseFilters, paymentScheduleSortable, "due_date") + paginate(c, filters.BaseFilters)
+
+	var schedules []*domain.PaymentSchedule
+	err := r.db.ExecuteQuery(ctx, "select", "payment_schedules", func(q querier) error {
+		var err error
+		schedules, err = queryPaymentSchedules(ctx, q, query, c.args...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list payment schedules: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(schedules)))
+
+	return schedules, nil
+}
+
+// GetOverduePayments retrieves unpaid installments whose due date has passed
+func (r *paymentScheduleRepository) GetOverduePayments(ctx context.Context) ([]*domain.PaymentSchedule, error) {
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.GetOverduePayments")
+	defer span.End()
+
+	var schedules []*domain.PaymentSchedule
+	s := scopeOf(ctx)
+	query := paymentScheduleSelect + " WHERE due_date < $1 AND status IN ('pending', 'overdue')" + s.and(2) + " ORDER BY due_date, id"
+	err := r.db.ExecuteQuery(ctx, "select", "payment_schedules", func(q querier) error {
+		var err error
+		schedules, err = queryPaymentSchedules(ctx, q, query, s.args(timestamp())...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get overdue payments: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(schedules)))
+
+	return schedules, nil
+}
+
+func paymentScheduleConditions(ctx context.Context, filters domain.PaymentScheduleFilters) *conditions {
+	c := &conditions{}
+	c.scoped(ctx)
+	if filters.SaleID != nil {
+		c.add("sale_id = ?", *filters.SaleID)
+	}
+	if filters.Status != nil {
+		c.add("status = ?", string(*filters.Status))
+	}
+	if filters.DueDateFrom != nil {
+		c.add("due_date >= ?", *filters.DueDateFrom)
+	}
+	if filters.DueDateTo != nil {
+		c.add("due_date <= ?", *filters.DueDateTo)
+	}
+	if filters.AmountMin != nil {
+		c.add("amount >= ?", *filters.AmountMin)
+	}
+	if filters.AmountMax != nil {
+		c.add("amount <= ?", *filters.AmountMax)
+	}
+	return c
+}
+
+func paymentScheduleArgs(schedule *domain.PaymentSchedule) []interface{} {
+	return []interface{}{
+		schedule.ID, schedule.OrganizationID, schedule.SaleID, schedule.InstallmentNumber, schedule.DueDate, schedule.Amount,
+		schedule.Description, string(schedule.Status), schedule.PaidAmount, schedule.PaidDate,
+		schedule.PaymentMethod, schedule.TransactionReference, schedule.LateFee, schedule.Notes,
+		schedule.CreatedAt, schedule.UpdatedAt,
+	}
+}
+
+func queryPaymentSchedules(ctx context.Context, q querier, query string, args ...interface{}) ([]*domain.PaymentSchedule, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := []*domain.PaymentSchedule{}
+	for rows.Next() {
+		schedule, err := scanPaymentSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
+
+func scanPaymentSchedule(s scanner) (*domain.PaymentSchedule, error) {
+	var schedule domain.PaymentSchedule
+	var status string
+
+	err := s.Scan(
+		&schedule.ID, &schedule.OrganizationID, &schedule.SaleID, &schedule.InstallmentNumber, &schedule.DueDate, &schedule.Amount,
+		&schedule.Description, &status, &schedule.PaidAmount, &schedule.PaidDate,
+		&schedule.PaymentMethod, &schedule.TransactionReference, &schedule.LateFee, &schedule.Notes,
+		&schedule.CreatedAt, &schedule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.Status = domain.PaymentStatus(status)
+	return &schedule, nil
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var paymentRevisionTracer = otel.Tracer("goreal-backend/infrastructure/postgres/payment_schedule_revision")
+
+var paymentRevisionColumns = []string{
+	"id", "organization_id", "sale_id", "revision", "plan", "reason", "previous", "installments",
+	"changed_by", "created_at",
+}
+
+var paymentRevisionSelect = "SELECT " + strings.Join(paymentRevisionColumns, ", ") + " FROM payment_schedule_revisions"
+
+type paymentScheduleRevisionRepository struct {
+	db *DB
+}
+
+// NewPaymentScheduleRevisionRepository creates a new payment schedule revision repository
+func NewPaymentScheduleRevisionRepository(db *DB) domain.PaymentScheduleRevisionRepository {
+	return &paymentScheduleRevisionRepository{
+		db: db,
+	}
+}
+
+// Create records a schedule revision; revision numbers are unique per sale
+func (r *paymentScheduleRevisionRepository) Create(ctx context.Context, revision *domain.PaymentScheduleRevision) error {
+	if err := domain.AssignOrganization(ctx, &revision.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := paymentRevisionTracer.Start(ctx, "paymentScheduleRevisionRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", revision.SaleID.String()),
+		attribute.Int("payment.revision", revision.Revision),
+		attribute.String("payment.plan", string(revision.Plan)),
+	)
+
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = timestamp()
+	}
+
+	query := "INSERT INTO payment_schedule_revisions (" + strings.Join(paymentRevisionColumns, ", ") + ") VALUES (" + placeholders(len(paymentRevisionColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "payment_schedule_revisions", func(q querier) error {
+		_, err := q.ExecContext(ctx, query,
+			revision.ID, revision.OrganizationID, revision.SaleID, revision.Revision, string(revision.Plan),
+			revision.Reason, jsonb{installmentList(revision.Previous)}, jsonb{installmentList(revision.Installments)},
+			revision.ChangedBy, revision.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create payment schedule revision: %w", err)
+	}
+
+	return nil
+}
+
+// ListBySale returns the revisions of a sale's schedule, oldest first
+func (r *paymentScheduleRevisionRepository) ListBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.PaymentScheduleRevision, error) {
+	ctx, span := paymentRevisionTracer.Start(ctx, "paymentScheduleRevisionRepository.ListBySale")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	revisions := []*domain.PaymentScheduleRevision{}
+	s := scopeOf(ctx)
+	query := paymentRevisionSelect + " WHERE sale_id = $1" + s.and(2) + " ORDER BY revision"
+	err := r.db.ExecuteQuery(ctx, "select", "payment_schedule_revisions", func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, s.args(saleID)...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			revision, err := scanPaymentScheduleRevision(rows)
+			if err != nil {
+				return err
+			}
+			revisions = append(revisions, revision)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list payment schedule revisions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(revisions)))
+
+	return revisions, nil
+}
+
+// installmentList stores an empty list as [] rather than NULL
+func installmentList(installments []domain.PaymentInstallment) []domain.PaymentInstallment {
+	if installments == nil {
+		return []domain.PaymentInstallment{}
+	}
+	return installments
+}
+
+func scanPaymentScheduleRevision(s scanner) (*domain.PaymentScheduleRevision, error) {
+	var revision domain.PaymentScheduleRevision
+	var plan string
+
+	err := s.Scan(
+		&revision.ID, &revision.OrganizationID, &revision.SaleID, &revision.Revision, &plan,
+		&revision.Reason, jsonb{&revision.Previous}, jsonb{&revision.Installments},
+		&revision.ChangedBy, &revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	revision.Plan = domain.PaymentPlanType(plan)
+	return &revision, nil
+}
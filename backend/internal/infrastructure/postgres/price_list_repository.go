@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var priceListTracer = otel.Tracer("goreal-backend/infrastructure/postgres/price_list")
+
+var priceListColumns = []string{
+	"id", "organization_id", "project_id", "version", "effective_from", "base_rate", "floor_rise", "floor_rise_from",
+	"facing_premiums", "plcs", "notes", "created_by", "created_at",
+}
+
+var priceListSelect = "SELECT " + strings.Join(priceListColumns, ", ") + " FROM price_lists"
+
+type priceListRepository struct {
+	db *DB
+}
+
+// NewPriceListRepository creates a new price list repository
+func NewPriceListRepository(db *DB) domain.PriceListRepository {
+	return &priceListRepository{
+		db: db,
+	}
+}
+
+// Create stores a price list version; versions are unique per
+// organization and project
+func (r *priceListRepository) Create(ctx context.Context, priceList *domain.PriceList) error {
+	if err := domain.AssignOrganization(ctx, &priceList.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := priceListTracer.Start(ctx, "priceListRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project.id", priceList.ProjectID.String()),
+		attribute.Int("price_list.version", priceList.Version),
+	)
+
+	if priceList.CreatedAt.IsZero() {
+		priceList.CreatedAt = timestamp()
+	}
+
+	query := "INSERT INTO price_lists (" + strings.Join(priceListColumns, ", ") + ") VALUES (" + placeholders(len(priceListColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "price_lists", func(q querier) error {
+		_, err := q.ExecContext(ctx, query,
+			priceList.ID, priceList.OrganizationID, priceList.ProjectID, priceList.Version, priceList.EffectiveFrom,
+			priceList.BaseRate, priceList.FloorRise, priceList.FloorRiseFrom, jsonb{premiums(priceList.FacingPremiums)},
+			jsonb{premiums(priceList.PLCs)}, priceList.Notes, priceList.CreatedBy, priceList.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create price list: %w", err)
+	}
+
+	return nil
+}
+
+// GetVersion returns one version of a project's price list
+func (r *priceListRepository) GetVersion(ctx context.Context, projectID uuid.UUID, version int) (*domain.PriceList, error) {
+	ctx, span := priceListTracer.Start(ctx, "priceListRepository.GetVersion")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project.id", projectID.String()),
+		attribute.Int("price_list.version", version),
+	)
+
+	s := scopeOf(ctx)
+	query := priceListSelect + " WHERE project_id = $1 AND version = $2" + s.and(3)
+	priceList, err := r.get(ctx, query, s.args(projectID, version)...)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+// GetEffective returns the version of a project's price list in effect at a time
+func (r *priceListRepository) GetEffective(ctx context.Context, projectID uuid.UUID, at time.Time) (*domain.PriceList, error) {
+	ctx, span := priceListTracer.Start(ctx, "priceListRepository.GetEffective")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	s := scopeOf(ctx)
+	query := priceListSelect + " WHERE project_id = $1 AND effective_from <= $2" + s.and(3) +
+		" ORDER BY effective_from DESC, version DESC LIMIT 1"
+	priceList, err := r.get(ctx, query, s.args(projectID, at)...)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("price_list.version", priceList.Version))
+
+	return priceList, nil
+}
+
+// ListVersions returns every version of a project's price list, newest first
+func (r *priceListRepository) ListVersions(ctx context.Context, projectID uuid.UUID) ([]*domain.PriceList, error) {
+	ctx, span := priceListTracer.Start(ctx, "priceListRepository.ListVersions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	priceLists := []*domain.PriceList{}
+	s := scopeOf(ctx)
+	query := priceListSelect + " WHERE project_id = $1" + s.and(2) + " ORDER BY version DESC"
+	err := r.db.ExecuteQuery(ctx, "select", "price_lists", func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, s.args(projectID)...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			priceList, err := scanPriceList(rows)
+			if err != nil {
+				return err
+			}
+			priceLists = append(priceLists, priceList)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list price lists: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(priceLists)))
+
+	return priceLists, nil
+}
+
+// get returns the single price list selected by query
+func (r *priceListRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.PriceList, error) {
+	var priceList *domain.PriceList
+	err := r.db.ExecuteQuery(ctx, "select", "price_lists", func(q querier) error {
+		var err error
+		priceList, err = scanPriceList(q.QueryRowContext(ctx, query, args...))
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price list: %w", err)
+	}
+
+	return priceList, nil
+}
+
+// premiums stores a missing set of premiums as an empty object
+func premiums(values map[string]float64) map[string]float64 {
+	if values == nil {
+		return map[string]float64{}
+	}
+	return values
+}
+
+func scanPriceList(s scanner) (*domain.PriceList, error) {
+	var priceList domain.PriceList
+
+	err := s.Scan(
+		&priceList.ID, &priceList.OrganizationID, &priceList.ProjectID, &priceList.Version, &priceList.EffectiveFrom,
+		&priceList.BaseRate, &priceList.FloorRise, &priceList.FloorRiseFrom, jsonb{&priceList.FacingPremiums},
+		jsonb{&priceList.PLCs}, &priceList.Notes, &priceList.CreatedBy, &priceList.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &priceList, nil
+}
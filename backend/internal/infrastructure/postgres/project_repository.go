@@ -0,0 +1,349 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var projectTracer = otel.Tracer("goreal-backend/infrastructure/postgres/project")
+
+var projectColumns = []string{
+	"id", "organization_id", "society_id", "name", "project_type", "status", "start_date", "expected_completion",
+	"actual_completion", "total_units", "available_units", "sold_units", "blocked_units", "base_price",
+	"price_per_sqft", "description", "specifications", "floor_plans", "images", "videos", "brochure_url",
+	"rera_number", "approvals", "amenities", "nearby_facilities", "created_by", "created_at", "updated_at",
+}
+
+var projectSortable = map[string]bool{
+	"name": true, "status": true, "project_type": true, "start_date": true, "expected_completion": true,
+	"base_price": true, "price_per_sqft": true, "available_units": true, "created_at": true, "updated_at": true,
+}
+
+var projectSelect = "SELECT " + strings.Join(projectColumns, ", ") + " FROM projects"
+
+type projectRepository struct {
+	db *DB
+}
+
+// NewProjectRepository creates a new project repository
+func NewProjectRepository(db *DB) domain.ProjectRepository {
+	return &projectRepository{
+		db: db,
+	}
+}
+
+// Create creates a new project
+func (r *projectRepository) Create(ctx context.Context, project *domain.Project) error {
+	if err := domain.AssignOrganization(ctx, &project.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := projectTracer.Start(ctx, "projectRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project.name", project.Name),
+		attribute.String("project.id", project.ID.String()),
+		attribute.String("project.society_id", project.SocietyID.String()),
+	)
+
+	// Set timestamps unless the caller supplied them
+	now := time.Now()
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = now
+	}
+	if project.UpdatedAt.IsZero() {
+		project.UpdatedAt = project.CreatedAt
+	}
+
+	query := "INSERT INTO projects (" + strings.Join(projectColumns, ", ") + ") VALUES (" + placeholders(len(projectColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "projects", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, projectArgs(project)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a project by ID
+func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	ctx, span := projectTracer.Start(ctx, "projectRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", id.String()))
+
+	var project *domain.Project
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "select", "projects", func(q querier) error {
+		var err error
+		project, err = scanProject(q.QueryRowContext(ctx, projectSelect+" WHERE id = $1"+s.and(2), s.args(id)...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get project by ID: %w", err)
+	}
+
+	return project, nil
+}
+
+// Update updates an existing project
+func (r *projectRepository) Update(ctx context.Context, project *domain.Project) error {
+	if err := domain.AssignOrganization(ctx, &project.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := projectTracer.Start(ctx, "projectRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project.id", project.ID.String()),
+		attribute.String("project.name", project.Name),
+	)
+
+	// Update timestamp
+	project.UpdatedAt = time.Now()
+
+	s := scopeOf(ctx)
+	query := "UPDATE projects SET " + assignments(projectColumns[1:], 2) + " WHERE id = $1" + s.and(len(projectColumns)+1)
+	err := r.db.ExecuteQuery(ctx, "update", "projects", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(projectArgs(project)...)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a project by ID
+func (r *projectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := projectTracer.Start(ctx, "projectRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "delete", "projects", func(q querier) error {
+		result, err := q.ExecContext(ctx, "DELETE FROM projects WHERE id = $1"+s.and(2), s.args(id)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves projects with pagination and filtering
+func (r *projectRepository) List(ctx context.Context, filters domain.ProjectFilters) ([]*domain.Project, error) {
+	ctx, span := projectTracer.Start(ctx, "projectRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	c := projectConditions(ctx, filters)
+	c.after(filters.BaseFilters)
+	query := projectSelect + c.where() + orderBy(filters.BaseFilters, projectSortable, "created_at DESC") + paginate(c, filters.BaseFilters)
+
+	var projects []*domain.Project
+	err := r.db.ExecuteQuery(ctx, "select", "projects", func(q querier) error {
+		var err error
+		projects, err = queryProjects(ctx, q, query, c.args...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(projects)))
+
+	return projects, nil
+}
+
+// Count returns the total number of projects matching the filters
+func (r *projectRepository) Count(ctx context.Context, filters domain.ProjectFilters) (int, error) {
+	ctx, span := projectTracer.Start(ctx, "projectRepository.Count")
+	defer span.End()
+
+	c := projectConditions(ctx, filters)
+
+	var count int
+	err := r.db.ExecuteQuery(ctx, "count", "projects", func(q querier) error {
+		return q.QueryRowContext(ctx, "SELECT COUNT(*) FROM projects"+c.where(), c.args...).Scan(&count)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count projects: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+// GetBySociety retrieves projects by society ID
+func (r *projectRepository) GetBySociety(ctx context.Context, societyID uuid.UUID) ([]*domain.Project, error) {
+	ctx, span := projectTracer.Start(ctx, "projectRepository.GetBySociety")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("society.id", societyID.String()))
+
+	var projects []*domain.Project
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "select", "projects", func(q querier) error {
+		var err error
+		projects, err = queryProjects(ctx, q, projectSelect+" WHERE society_id = $1"+s.and(2)+" ORDER BY created_at DESC, id", s.args(societyID)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get projects by society ID: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(projects)))
+
+	return projects, nil
+}
+
+// RefreshUnitCounts recounts the units of a project from its inventory in
+// a single statement, so concurrent reservations cannot skew the counts
+func (r *projectRepository) RefreshUnitCounts(ctx context.Context, id uuid.UUID) error {
+	ctx, span := projectTracer.Start(ctx, "projectRepository.RefreshUnitCounts")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "update", "projects", func(q querier) error {
+		result, err := q.ExecContext(ctx, `UPDATE projects SET
+			available_units = (SELECT COUNT(*) FROM inventory WHERE project_id = $1 AND status = $2),
+			sold_units = (SELECT COUNT(*) FROM inventory WHERE project_id = $1 AND status = $3),
+			blocked_units = (SELECT COUNT(*) FROM inventory WHERE project_id = $1 AND status IN ($4, $5))
+			WHERE id = $1`+s.and(6),
+			s.args(id, string(domain.UnitStatusAvailable), string(domain.UnitStatusSold),
+				string(domain.UnitStatusReserved), string(domain.UnitStatusBlocked))...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to refresh project unit counts: %w", err)
+	}
+
+	return nil
+}
+
+// projectConditions translates project filters into a WHERE clause
+func projectConditions(ctx context.Context, filters domain.ProjectFilters) *conditions {
+	c := &conditions{}
+	c.scoped(ctx)
+	if filters.SocietyID != nil {
+		c.add("society_id = ?", *filters.SocietyID)
+	}
+	if filters.ProjectType != nil {
+		c.add("project_type = ?", *filters.ProjectType)
+	}
+	if filters.Status != nil {
+		c.add("status = ?", string(*filters.Status))
+	}
+	if filters.CreatedBy != nil {
+		c.add("created_by = ?", *filters.CreatedBy)
+	}
+	if filters.PriceMin != nil {
+		c.add("base_price >= ?", *filters.PriceMin)
+	}
+	if filters.PriceMax != nil {
+		c.add("base_price <= ?", *filters.PriceMax)
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		c.search(*filters.Search, "name", "description")
+	}
+	return c
+}
+
+func projectArgs(project *domain.Project) []interface{} {
+	return []interface{}{
+		project.ID, project.OrganizationID, project.SocietyID, project.Name, project.ProjectType, string(project.Status), project.StartDate,
+		project.ExpectedCompletion, project.ActualCompletion, project.TotalUnits, project.AvailableUnits,
+		project.SoldUnits, project.BlockedUnits, project.BasePrice, project.PricePerSqft, project.Description,
+		project.Specifications, pq.Array(project.FloorPlans), pq.Array(project.Images), pq.Array(project.Videos),
+		project.BrochureURL, project.RERANumber, jsonb{project.Approvals}, pq.Array(project.Amenities),
+		jsonb{project.NearbyFacilities}, nullUUID(project.CreatedBy), project.CreatedAt, project.UpdatedAt,
+	}
+}
+
+func scanProject(s scanner) (*domain.Project, error) {
+	var project domain.Project
+	var status string
+	var societyID, createdBy uuid.NullUUID
+
+	err := s.Scan(
+		&project.ID, &project.OrganizationID, &societyID, &project.Name, &project.ProjectType, &status, &project.StartDate,
+		&project.ExpectedCompletion, &project.ActualCompletion, &project.TotalUnits, &project.AvailableUnits,
+		&project.SoldUnits, &project.BlockedUnits, &project.BasePrice, &project.PricePerSqft, &project.Description,
+		&project.Specifications, pq.Array(&project.FloorPlans), pq.Array(&project.Images), pq.Array(&project.Videos),
+		&project.BrochureURL, &project.RERANumber, jsonb{&project.Approvals}, pq.Array(&project.Amenities),
+		jsonb{&project.NearbyFacilities}, &createdBy, &project.CreatedAt, &project.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	project.Status = domain.ProjectStatus(status)
+	project.SocietyID = societyID.UUID
+	project.CreatedBy = createdBy.UUID
+	return &project, nil
+}
+
+func queryProjects(ctx context.Context, q querier, query string, args ...interface{}) ([]*domain.Project, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := []*domain.Project{}
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
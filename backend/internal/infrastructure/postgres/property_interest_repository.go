@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var interestTracer = otel.Tracer("goreal-backend/infrastructure/postgres/property_interest")
+
+var interestColumns = []string{
+	"id", "organization_id", "client_id", "inventory_id", "kind", "recorded_by", "created_at",
+}
+
+var interestSelect = "SELECT " + strings.Join(interestColumns, ", ") + " FROM property_interests"
+
+type propertyInterestRepository struct {
+	db *DB
+}
+
+// NewPropertyInterestRepository creates a new property interest repository
+func NewPropertyInterestRepository(db *DB) domain.PropertyInterestRepository {
+	return &propertyInterestRepository{
+		db: db,
+	}
+}
+
+// Create records a client's interest in a unit
+func (r *propertyInterestRepository) Create(ctx context.Context, interest *domain.PropertyInterest) error {
+	if err := domain.AssignOrganization(ctx, &interest.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := interestTracer.Start(ctx, "propertyInterestRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("client.id", interest.ClientID.String()),
+		attribute.String("inventory.id", interest.InventoryID.String()),
+		attribute.String("interest.kind", string(interest.Kind)),
+	)
+
+	if interest.CreatedAt.IsZero() {
+		interest.CreatedAt = timestamp()
+	}
+
+	query := "INSERT INTO property_interests (" + strings.Join(interestColumns, ", ") + ") VALUES (" + placeholders(len(interestColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "property_interests", func(q querier) error {
+		_, err := q.ExecContext(ctx, query,
+			interest.ID, interest.OrganizationID, interest.ClientID, interest.InventoryID, string(interest.Kind),
+			interest.RecordedBy, interest.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create property interest: %w", err)
+	}
+
+	return nil
+}
+
+// ListByClient returns the interests of a client, newest first
+func (r *propertyInterestRepository) ListByClient(ctx context.Context, clientID uuid.UUID) ([]*domain.PropertyInterest, error) {
+	ctx, span := interestTracer.Start(ctx, "propertyInterestRepository.ListByClient")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("client.id", clientID.String()))
+
+	interests, err := r.list(ctx, "client_id", clientID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(interests)))
+
+	return interests, nil
+}
+
+// ListByInventory returns the interests shown in a unit, newest first
+func (r *propertyInterestRepository) ListByInventory(ctx context.Context, inventoryID uuid.UUID) ([]*domain.PropertyInterest, error) {
+	ctx, span := interestTracer.Start(ctx, "propertyInterestRepository.ListByInventory")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", inventoryID.String()))
+
+	interests, err := r.list(ctx, "inventory_id", inventoryID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(interests)))
+
+	return interests, nil
+}
+
+// list returns the interests whose column equals id, newest first
+func (r *propertyInterestRepository) list(ctx context.Context, column string, id uuid.UUID) ([]*domain.PropertyInterest, error) {
+	interests := []*domain.PropertyInterest{}
+	s := scopeOf(ctx)
+	query := interestSelect + " WHERE " + column + " = $1" + s.and(2) + " ORDER BY created_at DESC, id DESC"
+	err := r.db.ExecuteQuery(ctx, "select", "property_interests", func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, s.args(id)...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			interest, err := scanPropertyInterest(rows)
+			if err != nil {
+				return err
+			}
+			interests = append(interests, interest)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list property interests: %w", err)
+	}
+
+	return interests, nil
+}
+
+func scanPropertyInterest(s scanner) (*domain.PropertyInterest, error) {
+	var interest domain.PropertyInterest
+	var kind string
+
+	err := s.Scan(
+		&interest.ID, &interest.OrganizationID, &interest.ClientID, &interest.InventoryID, &kind,
+		&interest.RecordedBy, &interest.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	interest.Kind = domain.PropertyInterestKind(kind)
+	return &interest, nil
+}
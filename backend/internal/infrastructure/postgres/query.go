@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// scanner is implemented by *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// conditions accumulates a WHERE clause with numbered placeholders.
+// Clauses are written with "?" which is rewritten to $1, $2, ...
+type conditions struct {
+	clauses []string
+	args    []interface{}
+}
+
+// add appends a clause and its arguments
+func (c *conditions) add(clause string, args ...interface{}) {
+	var b strings.Builder
+	for _, r := range clause {
+		if r == '?' {
+			c.args = append(c.args, args[0])
+			args = args[1:]
+			b.WriteString("$" + strconv.Itoa(len(c.args)))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	c.clauses = append(c.clauses, b.String())
+}
+
+// search adds a case-insensitive substring match over the given columns
+func (c *conditions) search(term string, columns ...string) {
+	pattern := "%" + escapeLike(term) + "%"
+	parts := make([]string, len(columns))
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		parts[i] = column + " ILIKE ?"
+		args[i] = pattern
+	}
+	c.add("("+strings.Join(parts, " OR ")+")", args...)
+}
+
+// after adds the keyset condition for the page after filters.Cursor,
+// matching the order orderBy renders for cursor pages
+func (c *conditions) after(filters domain.BaseFilters) {
+	if filters.Cursor == nil {
+		return
+	}
+	op := ">"
+	if desc, _ := filters.KeysetOrder(); desc {
+		op = "<"
+	}
+	at := filters.Cursor.CreatedAt
+	c.add("(created_at "+op+" ? OR (created_at = ? AND id > ?))", at, at, filters.Cursor.ID)
+}
+
+// scoped restricts the clause to the organization ctx is scoped to, unless
+// ctx was lifted out of tenancy
+func (c *conditions) scoped(ctx context.Context) {
+	if id, ok := domain.OrganizationFromContext(ctx); ok {
+		c.add("organization_id = ?", id)
+	}
+}
+
+// where renders the WHERE clause, or an empty string without conditions
+func (c *conditions) where() string {
+	if len(c.clauses) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c.clauses, " AND ")
+}
+
+// next returns the placeholder for an argument appended after the clause
+func (c *conditions) next(arg interface{}) string {
+	c.args = append(c.args, arg)
+	return "$" + strconv.Itoa(len(c.args))
+}
+
+// orderBy renders ORDER BY for a whitelisted sort column. Unknown columns
+// fall back to the default so user input never reaches the SQL text.
+func orderBy(filters domain.BaseFilters, sortable map[string]bool, fallback string) string {
+	if filters.Cursor != nil {
+		if desc, _ := filters.KeysetOrder(); desc {
+			return " ORDER BY created_at DESC, id"
+		}
+		return " ORDER BY created_at ASC, id"
+	}
+	if filters.SortBy == "" || !sortable[filters.SortBy] {
+		return " ORDER BY " + fallback + ", id"
+	}
+
+	direction := "ASC"
+	if strings.EqualFold(filters.SortOrder, "desc") {
+		direction = "DESC"
+	}
+	return fmt.Sprintf(" ORDER BY %s %s NULLS LAST, id", filters.SortBy, direction)
+}
+
+// paginate renders LIMIT and OFFSET. A cursor replaces the offset with the
+// keyset condition added by after.
+func paginate(c *conditions, filters domain.BaseFilters) string {
+	var b strings.Builder
+	if filters.Limit > 0 {
+		b.WriteString(" LIMIT " + c.next(filters.Limit))
+	}
+	if filters.Offset > 0 && filters.Cursor == nil {
+		b.WriteString(" OFFSET " + c.next(filters.Offset))
+	}
+	return b.String()
+}
+
+// escapeLike escapes LIKE wildcards in user supplied search terms
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// jsonb reads and writes a JSONB column from a Go value.
+// For scanning, v must be a pointer; NULL leaves the target untouched.
+type jsonb struct {
+	v interface{}
+}
+
+// Value implements driver.Valuer
+func (j jsonb) Value() (driver.Value, error) {
+	if j.v == nil {
+		return nil, nil
+	}
+	rv := reflect.ValueOf(j.v)
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice) && rv.IsNil() {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(j.v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode jsonb: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (j jsonb) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into jsonb", src)
+	}
+	return json.Unmarshal(data, j.v)
+}
+
+// placeholders renders "$1, $2, ..., $n"
+func placeholders(n int) string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = "$" + strconv.Itoa(i+1)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// assignments renders "a = $2, b = $3, ..." with numbering starting at from
+func assignments(columns []string, from int) string {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		parts[i] = column + " = $" + strconv.Itoa(from+i)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// trashTables are the tables whose rows are soft deleted. Rows in the trash
+// can only be restored, so guarded updates treat them as missing.
+var trashTables = map[string]bool{"leads": true, "clients": true, "sales": true, "tasks": true}
+
+// live returns the condition that excludes trashed rows of table, if any
+func live(table string) string {
+	if trashTables[table] {
+		return " AND deleted_at IS NULL"
+	}
+	return ""
+}
+
+// scope is the organization a statement is restricted to. Statements with
+// fixed placeholders render its condition with and(n) and pass its argument
+// last with args.
+type scope struct {
+	id uuid.UUID
+	ok bool
+}
+
+// scopeOf returns the scope of ctx; only a context lifted out of tenancy
+// sees every tenant
+func scopeOf(ctx context.Context) scope {
+	id, ok := domain.OrganizationFromContext(ctx)
+	return scope{id: id, ok: ok}
+}
+
+// and renders the organization condition with placeholder $n
+func (s scope) and(n int) string {
+	if !s.ok {
+		return ""
+	}
+	return " AND organization_id = $" + strconv.Itoa(n)
+}
+
+// args appends the organization argument, if any, to args
+func (s scope) args(args ...interface{}) []interface{} {
+	if s.ok {
+		args = append(args, s.id)
+	}
+	return args
+}
+
+// versionedUpdate builds an UPDATE of columns[1:] for the row with id $1
+// whose updated_at still equals the argument after the columns
+func versionedUpdate(table string, columns []string, s scope) string {
+	return "UPDATE " + table + " SET " + assignments(columns[1:], 2) +
+		" WHERE id = $1 AND updated_at = $" + strconv.Itoa(len(columns)+1) + live(table) + s.and(len(columns)+2)
+}
+
+// softDelete builds an UPDATE that moves the live row with id $1 to the
+// trash at $2 on behalf of the user $3
+func softDelete(table string, s scope) string {
+	return "UPDATE " + table + " SET deleted_at = $2, deleted_by = $3, updated_at = $2" +
+		" WHERE id = $1 AND deleted_at IS NULL" + s.and(4)
+}
+
+// restore builds an UPDATE that takes the trashed row with id $1 out of the
+// trash, stamping updated_at with $2
+func restore(table string, s scope) string {
+	return "UPDATE " + table + " SET deleted_at = NULL, deleted_by = NULL, updated_at = $2" +
+		" WHERE id = $1 AND deleted_at IS NOT NULL" + s.and(3)
+}
+
+// timestamp returns the current time at the microsecond precision Postgres
+// keeps, so the value a write sets is the one the next guarded write sees
+func timestamp() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
+
+// nullUUID stores the zero UUID as NULL for optional foreign keys
+func nullUUID(id uuid.UUID) interface{} {
+	if id == uuid.Nil {
+		return nil
+	}
+	return id
+}
+
+// uuidStrings renders IDs as text for a uuid[] parameter
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditions_NumbersPlaceholdersInOrder(t *testing.T) {
+	c := &conditions{}
+	c.add("status = ?", "new")
+	c.add("budget_min >= ? AND budget_max <= ?", 100.0, 500.0)
+	c.search("50%_off", "name", "email")
+
+	assert.Equal(t,
+		" WHERE status = $1 AND budget_min >= $2 AND budget_max <= $3 AND (name ILIKE $4 OR email ILIKE $5)",
+		c.where())
+	assert.Equal(t, []interface{}{"new", 100.0, 500.0, `%50\%\_off%`, `%50\%\_off%`}, c.args)
+
+	pagination := paginate(c, domain.BaseFilters{Limit: 10, Offset: 20})
+	assert.Equal(t, " LIMIT $6 OFFSET $7", pagination)
+	assert.Len(t, c.args, 7)
+}
+
+func TestConditions_EmptyWhere(t *testing.T) {
+	c := &conditions{}
+	assert.Equal(t, "", c.where())
+	assert.Equal(t, "", paginate(c, domain.BaseFilters{}))
+}
+
+func TestScope_FiltersTenantScopedContexts(t *testing.T) {
+	across := scopeOf(domain.AcrossOrganizations(context.Background()))
+	assert.Equal(t, "", across.and(3))
+	assert.Equal(t, []interface{}{"x"}, across.args("x"))
+
+	lost := scopeOf(context.Background())
+	assert.Equal(t, " AND organization_id = $3", lost.and(3))
+	assert.Equal(t, []interface{}{"x", uuid.Nil}, lost.args("x"), "a context without an organization matches no rows")
+
+	org := uuid.New()
+	ctx := domain.WithOrganization(context.Background(), org)
+	s := scopeOf(ctx)
+	assert.Equal(t, " AND organization_id = $3", s.and(3))
+	assert.Equal(t, []interface{}{"x", org}, s.args("x"))
+
+	c := &conditions{}
+	c.add("status = ?", "new")
+	c.scoped(ctx)
+	assert.Equal(t, " WHERE status = $1 AND organization_id = $2", c.where())
+	assert.Equal(t, []interface{}{"new", org}, c.args)
+}
+
+func TestOrderBy_WhitelistsColumns(t *testing.T) {
+	sortable := map[string]bool{"name": true}
+
+	assert.Equal(t, " ORDER BY created_at DESC, id",
+		orderBy(domain.BaseFilters{}, sortable, "created_at DESC"))
+	assert.Equal(t, " ORDER BY created_at DESC, id",
+		orderBy(domain.BaseFilters{SortBy: "name; DROP TABLE leads"}, sortable, "created_at DESC"))
+	assert.Equal(t, " ORDER BY name ASC NULLS LAST, id",
+		orderBy(domain.BaseFilters{SortBy: "name"}, sortable, "created_at DESC"))
+	assert.Equal(t, " ORDER BY name DESC NULLS LAST, id",
+		orderBy(domain.BaseFilters{SortBy: "name", SortOrder: "DESC"}, sortable, "created_at DESC"))
+}
+
+func TestJSONB_RoundTrip(t *testing.T) {
+	var nilMap map[string]interface{}
+	value, err := jsonb{nilMap}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	value, err = jsonb{map[string]interface{}{"city": "Austin"}}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"city":"Austin"}`, value)
+
+	var decoded map[string]interface{}
+	require.NoError(t, jsonb{&decoded}.Scan([]byte(`{"city":"Austin"}`)))
+	assert.Equal(t, "Austin", decoded["city"])
+
+	decoded = nil
+	require.NoError(t, jsonb{&decoded}.Scan(nil))
+	assert.Nil(t, decoded)
+}
+
+func TestMapError(t *testing.T) {
+	assert.True(t, errors.Is(mapError(&pq.Error{Code: "23505"}), domain.ErrAlreadyExists))
+	assert.True(t, errors.Is(mapError(&pq.Error{Code: "23503"}), domain.ErrInvalidInput))
+	assert.Nil(t, mapError(nil))
+}
@@ -0,0 +1,486 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var saleTracer = otel.Tracer("goreal-backend/infrastructure/postgres/sale")
+
+var saleColumns = []string{
+	"id", "sale_number", "client_id", "inventory_id", "salesperson_id", "manager_id", "sale_date", "status",
+	"total_amount", "discount_amount", "final_amount", "booking_amount", "payment_plan", "commission_rate",
+	"commission_amount", "agreement_date", "possession_date", "registration_date", "notes", "documents",
+	"created_by", "created_at", "updated_at",
+	"deleted_at", "deleted_by", "organization_id",
+}
+
+var saleSortable = map[string]bool{
+	"sale_number": true, "sale_date": true, "status": true, "total_amount": true, "final_amount": true,
+	"created_at": true, "updated_at": true,
+}
+
+var saleSelect = "SELECT " + strings.Join(saleColumns, ", ") + " FROM sales"
+
+type saleRepository struct {
+	db *DB
+}
+
+// NewSaleRepository creates a new sale repository
+func NewSaleRepository(db *DB) domain.SaleRepository {
+	return &saleRepository{
+		db: db,
+	}
+}
+
+// Create creates a new sale
+func (r *saleRepository) Create(ctx context.Context, sale *domain.Sale) error {
+	if err := domain.AssignOrganization(ctx, &sale.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := saleTracer.Start(ctx, "saleRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", sale.ID.String()),
+		attribute.String("sale.sale_number", sale.SaleNumber),
+		attribute.String("sale.client_id", sale.ClientID.String()),
+		attribute.Float64("sale.total_amount", sale.TotalAmount),
+	)
+
+	// Set timestamps unless the caller supplied them
+	now := time.Now()
+	if sale.CreatedAt.IsZero() {
+		sale.CreatedAt = now
+	}
+	if sale.UpdatedAt.IsZero() {
+		sale.UpdatedAt = sale.CreatedAt
+	}
+
+	query := "INSERT INTO sales (" + strings.Join(saleColumns, ", ") + ") VALUES (" + placeholders(len(saleColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "sales", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, saleArgs(sale)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create sale: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a sale by ID
+func (r *saleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Sale, error) {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", id.String()))
+
+	var sale *domain.Sale
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "select", "sales", func(q querier) error {
+		var err error
+		sale, err = scanSale(q.QueryRowContext(ctx, saleSelect+" WHERE id = $1 AND deleted_at IS NULL"+s.and(2), s.args(id)...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get sale by ID: %w", err)
+	}
+
+	return sale, nil
+}
+
+// Update updates an existing sale
+func (r *saleRepository) Update(ctx context.Context, sale *domain.Sale) error {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", sale.ID.String()),
+		attribute.String("sale.sale_number", sale.SaleNumber),
+		attribute.String("sale.status", string(sale.Status)),
+	)
+
+	// Update timestamp
+	since := sale.UpdatedAt
+	sale.UpdatedAt = timestamp()
+
+	if err := domain.AssignOrganization(ctx, &sale.OrganizationID); err != nil {
+		return err
+	}
+	s := scopeOf(ctx)
+	query := versionedUpdate("sales", saleColumns, s)
+	err := r.db.ExecuteQuery(ctx, "update", "sales", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(append(saleArgs(sale), since)...)...)
+		if err != nil {
+			return err
+		}
+		return expectVersion(ctx, q, result, "sales", sale.ID)
+	})
+
+	if err != nil {
+		sale.UpdatedAt = since
+		span.RecordError(err)
+		return fmt.Errorf("failed to update sale: %w", err)
+	}
+
+	return nil
+}
+
+// Delete moves a sale to the trash
+func (r *saleRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "delete", "sales", func(q querier) error {
+		result, err := q.ExecContext(ctx, softDelete("sales", s), s.args(id, timestamp(), deletedBy)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete sale: %w", err)
+	}
+
+	return nil
+}
+
+// Restore takes a sale out of the trash
+func (r *saleRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "restore", "sales", func(q querier) error {
+		result, err := q.ExecContext(ctx, restore("sales", s), s.args(id, timestamp())...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to restore sale: %w", err)
+	}
+
+	return nil
+}
+
+// Purge permanently removes sales that were deleted before the cutoff
+func (r *saleRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.Purge")
+	defer span.End()
+
+	var purged int
+	err := r.db.ExecuteQuery(ctx, "purge", "sales", func(q querier) error {
+		var err error
+		purged, err = purge(ctx, q, "sales", deletedBefore)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to purge sales: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", purged))
+
+	return purged, nil
+}
+
+// List retrieves sales with pagination and filtering
+func (r *saleRepository) List(ctx context.Context, filters domain.SaleFilters) ([]*domain.Sale, error) {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	c := saleConditions(ctx, filters)
+	c.after(filters.BaseFilters)
+	query := saleSelect + c.where() + orderBy(filters.BaseFilters, saleSortable, "created_at DESC") + paginate(c, filters.BaseFilters)
+
+	var sales []*domain.Sale
+	err := r.db.ExecuteQuery(ctx, "select", "sales", func(q querier) error {
+		var err error
+		sales, err = querySales(ctx, q, query, c.args...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list sales: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(sales)))
+
+	return sales, nil
+}
+
+// Count returns the total number of sales matching the filters
+func (r *saleRepository) Count(ctx context.Context, filters domain.SaleFilters) (int, error) {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.Count")
+	defer span.End()
+
+	c := saleConditions(ctx, filters)
+
+	var count int
+	err := r.db.ExecuteQuery(ctx, "count", "sales", func(q querier) error {
+		return q.QueryRowContext(ctx, "SELECT COUNT(*) FROM sales"+c.where(), c.args...).Scan(&count)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count sales: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+// GetByClient retrieves sales by client ID
+func (r *saleRepository) GetByClient(ctx context.Context, clientID uuid.UUID) ([]*domain.Sale, error) {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.GetByClient")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("client.id", clientID.String()))
+
+	var sales []*domain.Sale
+	s := scopeOf(ctx)
+	query := saleSelect + " WHERE client_id = $1 AND deleted_at IS NULL" + s.and(2) + " ORDER BY created_at DESC, id"
+	err := r.db.ExecuteQuery(ctx, "select", "sales", func(q querier) error {
+		var err error
+		sales, err = querySales(ctx, q, query, s.args(clientID)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get sales by client ID: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(sales)))
+
+	return sales, nil
+}
+
+// GetBySalesperson retrieves sales by salesperson ID
+func (r *saleRepository) GetBySalesperson(ctx context.Context, salespersonID uuid.UUID) ([]*domain.Sale, error) {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.GetBySalesperson")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("salesperson.id", salespersonID.String()))
+
+	var sales []*domain.Sale
+	s := scopeOf(ctx)
+	query := saleSelect + " WHERE salesperson_id = $1 AND deleted_at IS NULL" + s.and(2) + " ORDER BY created_at DESC, id"
+	err := r.db.ExecuteQuery(ctx, "select", "sales", func(q querier) error {
+		var err error
+		sales, err = querySales(ctx, q, query, s.args(salespersonID)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get sales by salesperson: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(sales)))
+
+	return sales, nil
+}
+
+// UpdateStatus moves a live sale from one status to another
+func (r *saleRepository) UpdateStatus(ctx context.Context, id uuid.UUID, from, to domain.SaleStatus) error {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.UpdateStatus")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", id.String()),
+		attribute.String("sale.from_status", string(from)),
+		attribute.String("sale.status", string(to)),
+	)
+
+	s := scopeOf(ctx)
+	query := "UPDATE sales SET status = $3, updated_at = $4 WHERE id = $1 AND status = $2 AND deleted_at IS NULL" + s.and(5)
+	err := r.db.ExecuteQuery(ctx, "update", "sales", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(id, string(from), string(to), timestamp())...)
+		if err != nil {
+			return err
+		}
+		return expectVersion(ctx, q, result, "sales", id)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to update sale status: %w", err)
+	}
+
+	return nil
+}
+
+// GetSalesStats returns sales statistics based on filters.
+// Pagination is ignored; the statistics cover every matching sale.
+func (r *saleRepository) GetSalesStats(ctx context.Context, filters domain.SaleFilters) (*domain.SalesStats, error) {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.GetSalesStats")
+	defer span.End()
+
+	c := saleConditions(ctx, filters)
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	yearStart := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+
+	query := fmt.Sprintf(`SELECT
+			COUNT(*),
+			COALESCE(SUM(final_amount), 0),
+			COUNT(*) FILTER (WHERE status = 'pending'),
+			COUNT(*) FILTER (WHERE status = 'approved'),
+			COUNT(*) FILTER (WHERE status = 'completed'),
+			COUNT(*) FILTER (WHERE status = 'cancelled'),
+			COALESCE(SUM(final_amount) FILTER (WHERE sale_date >= %s), 0),
+			COALESCE(SUM(final_amount) FILTER (WHERE sale_date >= %s), 0)
+		FROM sales`, c.next(monthStart), c.next(yearStart))
+
+	// The month and year arguments were appended after the filter arguments,
+	// so the WHERE clause placeholders keep their numbering.
+	stats := &domain.SalesStats{}
+	err := r.db.ExecuteQuery(ctx, "stats", "sales", func(q querier) error {
+		return q.QueryRowContext(ctx, query+c.where(), c.args...).Scan(
+			&stats.TotalSales, &stats.TotalRevenue, &stats.PendingSales, &stats.ApprovedSales,
+			&stats.CompletedSales, &stats.CancelledSales, &stats.MonthlyRevenue, &stats.YearlyRevenue,
+		)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get sales stats: %w", err)
+	}
+
+	if stats.TotalSales > 0 {
+		stats.AverageValue = stats.TotalRevenue / float64(stats.TotalSales)
+	}
+
+	span.SetAttributes(
+		attribute.Int("stats.total_sales", stats.TotalSales),
+		attribute.Float64("stats.total_revenue", stats.TotalRevenue),
+		attribute.Float64("stats.average_value", stats.AverageValue),
+	)
+
+	return stats, nil
+}
+
+// saleConditions translates sale filters into a WHERE clause
+func saleConditions(ctx context.Context, filters domain.SaleFilters) *conditions {
+	c := &conditions{}
+	c.scoped(ctx)
+	if !filters.IncludeDeleted {
+		c.add("deleted_at IS NULL")
+	}
+	if filters.ClientID != nil {
+		c.add("client_id = ?", *filters.ClientID)
+	}
+	if filters.SalespersonID != nil {
+		c.add("salesperson_id = ?", *filters.SalespersonID)
+	}
+	if filters.ManagerID != nil {
+		c.add("manager_id = ?", *filters.ManagerID)
+	}
+	if filters.Status != nil {
+		c.add("status = ?", string(*filters.Status))
+	}
+	if filters.AmountMin != nil {
+		c.add("total_amount >= ?", *filters.AmountMin)
+	}
+	if filters.AmountMax != nil {
+		c.add("total_amount <= ?", *filters.AmountMax)
+	}
+	if filters.SaleDateFrom != nil {
+		c.add("sale_date >= ?", *filters.SaleDateFrom)
+	}
+	if filters.SaleDateTo != nil {
+		c.add("sale_date <= ?", *filters.SaleDateTo)
+	}
+	if filters.ProjectID != nil {
+		c.add("inventory_id IN (SELECT id FROM inventory WHERE project_id = ?)", *filters.ProjectID)
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		c.search(*filters.Search, "sale_number")
+	}
+	return c
+}
+
+func saleArgs(sale *domain.Sale) []interface{} {
+	return []interface{}{
+		sale.ID, sale.SaleNumber, sale.ClientID, sale.InventoryID, sale.SalespersonID, sale.ManagerID,
+		sale.SaleDate, string(sale.Status), sale.TotalAmount, sale.DiscountAmount, sale.FinalAmount,
+		sale.BookingAmount, jsonb{sale.PaymentPlan}, sale.CommissionRate, sale.CommissionAmount,
+		sale.AgreementDate, sale.PossessionDate, sale.RegistrationDate, sale.Notes, pq.Array(sale.Documents),
+		nullUUID(sale.CreatedBy), sale.CreatedAt, sale.UpdatedAt,
+		sale.DeletedAt, sale.DeletedBy, sale.OrganizationID,
+	}
+}
+
+func scanSale(s scanner) (*domain.Sale, error) {
+	var sale domain.Sale
+	var status string
+	var createdBy uuid.NullUUID
+
+	err := s.Scan(
+		&sale.ID, &sale.SaleNumber, &sale.ClientID, &sale.InventoryID, &sale.SalespersonID, &sale.ManagerID,
+		&sale.SaleDate, &status, &sale.TotalAmount, &sale.DiscountAmount, &sale.FinalAmount,
+		&sale.BookingAmount, jsonb{&sale.PaymentPlan}, &sale.CommissionRate, &sale.CommissionAmount,
+		&sale.AgreementDate, &sale.PossessionDate, &sale.RegistrationDate, &sale.Notes, pq.Array(&sale.Documents),
+		&createdBy, &sale.CreatedAt, &sale.UpdatedAt,
+		&sale.DeletedAt, &sale.DeletedBy, &sale.OrganizationID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sale.Status = domain.SaleStatus(status)
+	sale.CreatedBy = createdBy.UUID
+	return &sale, nil
+}
+
+func querySales(ctx context.Context, q querier, query string, args ...interface{}) ([]*domain.Sale, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sales := []*domain.Sale{}
+	for rows.Next() {
+		sale, err := scanSale(rows)
+		if err != nil {
+			return nil, err
+		}
+		sales = append(sales, sale)
+	}
+	return sales, rows.Err()
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var saleHistoryTracer = otel.Tracer("goreal-backend/infrastructure/postgres/sale_status_history")
+
+var saleHistoryColumns = []string{
+	"id", "organization_id", "sale_id", "from_status", "to_status", "changed_by", "reason", "created_at",
+}
+
+var saleHistorySelect = "SELECT " + strings.Join(saleHistoryColumns, ", ") + " FROM sale_status_history"
+
+type saleStatusHistoryRepository struct {
+	db *DB
+}
+
+// NewSaleStatusHistoryRepository creates a new sale status history repository
+func NewSaleStatusHistoryRepository(db *DB) domain.SaleStatusHistoryRepository {
+	return &saleStatusHistoryRepository{
+		db: db,
+	}
+}
+
+// Create records a sale transition
+func (r *saleStatusHistoryRepository) Create(ctx context.Context, entry *domain.SaleStatusHistory) error {
+	if err := domain.AssignOrganization(ctx, &entry.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := saleHistoryTracer.Start(ctx, "saleStatusHistoryRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", entry.SaleID.String()),
+		attribute.String("sale.from_status", string(entry.FromStatus)),
+		attribute.String("sale.status", string(entry.ToStatus)),
+	)
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = timestamp()
+	}
+
+	query := "INSERT INTO sale_status_history (" + strings.Join(saleHistoryColumns, ", ") + ") VALUES (" + placeholders(len(saleHistoryColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "sale_status_history", func(q querier) error {
+		_, err := q.ExecContext(ctx, query,
+			entry.ID, entry.OrganizationID, entry.SaleID, string(entry.FromStatus), string(entry.ToStatus),
+			entry.ChangedBy, entry.Reason, entry.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create sale status history: %w", err)
+	}
+
+	return nil
+}
+
+// ListBySale returns the transitions of a sale, oldest first
+func (r *saleStatusHistoryRepository) ListBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.SaleStatusHistory, error) {
+	ctx, span := saleHistoryTracer.Start(ctx, "saleStatusHistoryRepository.ListBySale")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	entries := []*domain.SaleStatusHistory{}
+	s := scopeOf(ctx)
+	query := saleHistorySelect + " WHERE sale_id = $1" + s.and(2) + " ORDER BY created_at, id"
+	err := r.db.ExecuteQuery(ctx, "select", "sale_status_history", func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, s.args(saleID)...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			entry, err := scanSaleStatusHistory(rows)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list sale status history: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(entries)))
+
+	return entries, nil
+}
+
+func scanSaleStatusHistory(s scanner) (*domain.SaleStatusHistory, error) {
+	var entry domain.SaleStatusHistory
+	var from, to string
+
+	err := s.Scan(
+		&entry.ID, &entry.OrganizationID, &entry.SaleID, &from, &to,
+		&entry.ChangedBy, &entry.Reason, &entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.FromStatus = domain.SaleStatus(from)
+	entry.ToStatus = domain.SaleStatus(to)
+	return &entry, nil
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// searchQuery calls the search_crm function from 014_crm_search.sql, which
+// does the ranking and highlighting
+const searchQuery = "SELECT entity_type, id, title, subtitle, score, highlight FROM search_crm($1, $2, $3, $4)"
+
+// searchRepository implements domain.SearchRepository using PostgreSQL
+type searchRepository struct {
+	db *DB
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(db *DB) domain.SearchRepository {
+	return &searchRepository{
+		db: db,
+	}
+}
+
+func (r *searchRepository) Search(ctx context.Context, query domain.SearchQuery) ([]*domain.SearchHit, error) {
+	var types []string
+	for _, t := range query.Types {
+		types = append(types, string(t))
+	}
+	s := scopeOf(ctx)
+	var organization interface{}
+	if s.ok {
+		organization = s.id
+	}
+
+	var hits []*domain.SearchHit
+	err := r.db.ExecuteQuery(ctx, "search", "search_crm", func(q querier) error {
+		rows, err := q.QueryContext(ctx, searchQuery, query.Text, pq.Array(types), organization, query.Limit)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		hits = []*domain.SearchHit{}
+		for rows.Next() {
+			var hit domain.SearchHit
+			var entityType string
+			if err := rows.Scan(&entityType, &hit.ID, &hit.Title, &hit.Subtitle, &hit.Score, &hit.Highlight); err != nil {
+				return err
+			}
+			hit.Type = domain.SearchEntityType(entityType)
+			hits = append(hits, &hit)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	return hits, nil
+}
@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var sessionTracer = otel.Tracer("goreal-backend/infrastructure/postgres/session")
+
+var sessionColumns = []string{
+	"id", "user_id", "device_name", "user_agent", "ip_address", "token_hash", "rotations", "expires_at",
+	"last_used_at", "revoked_at", "revoked_reason", "created_at",
+}
+
+var sessionSelect = "SELECT " + strings.Join(sessionColumns, ", ") + " FROM user_sessions"
+
+type sessionRepository struct {
+	db *DB
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db *DB) domain.SessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+// Create stores a new session
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", session.UserID.String()))
+
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = timestamp()
+	}
+	if session.LastUsedAt.IsZero() {
+		session.LastUsedAt = session.CreatedAt
+	}
+
+	query := "INSERT INTO user_sessions (" + strings.Join(sessionColumns, ", ") + ") VALUES (" + placeholders(len(sessionColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "user_sessions", func(q querier) error {
+		_, err := q.ExecContext(ctx, query,
+			session.ID, session.UserID, session.DeviceName, session.UserAgent, session.IPAddress, session.TokenHash,
+			session.Rotations, session.ExpiresAt, session.LastUsedAt, session.RevokedAt, session.RevokedReason,
+			session.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a session by ID
+func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("session.id", id.String()))
+
+	var session *domain.Session
+	err := r.db.ExecuteQuery(ctx, "select", "user_sessions", func(q querier) error {
+		var err error
+		session, err = scanSession(q.QueryRowContext(ctx, sessionSelect+" WHERE id = $1", id))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// ListActive returns the sessions of a user still usable at a time, most
+// recently used first
+func (r *sessionRepository) ListActive(ctx context.Context, userID uuid.UUID, at time.Time) ([]*domain.Session, error) {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.ListActive")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	sessions := []*domain.Session{}
+	query := sessionSelect + " WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2 ORDER BY last_used_at DESC, id"
+	err := r.db.ExecuteQuery(ctx, "select", "user_sessions", func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, userID, at)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			session, err := scanSession(rows)
+			if err != nil {
+				return err
+			}
+			sessions = append(sessions, session)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(sessions)))
+
+	return sessions, nil
+}
+
+// Rotate replaces the refresh token of an active session still holding oldHash
+func (r *sessionRepository) Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash string, expiresAt, usedAt time.Time) error {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.Rotate")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("session.id", id.String()))
+
+	err := r.db.ExecuteQuery(ctx, "update", "user_sessions", func(q querier) error {
+		result, err := q.ExecContext(ctx, `UPDATE user_sessions
+			SET token_hash = $3, rotations = rotations + 1, expires_at = $4, last_used_at = $5
+			WHERE id = $1 AND token_hash = $2 AND revoked_at IS NULL AND expires_at > $5`,
+			id, oldHash, newHash, expiresAt, usedAt)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke ends a session
+func (r *sessionRepository) Revoke(ctx context.Context, id uuid.UUID, reason domain.RevokeReason, at time.Time) error {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.Revoke")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("session.id", id.String()),
+		attribute.String("session.revoked_reason", string(reason)),
+	)
+
+	err := r.db.ExecuteQuery(ctx, "update", "user_sessions", func(q querier) error {
+		result, err := q.ExecContext(ctx,
+			"UPDATE user_sessions SET revoked_at = $2, revoked_reason = $3 WHERE id = $1 AND revoked_at IS NULL",
+			id, at, string(reason))
+		if err != nil {
+			return err
+		}
+		if err := expectRows(result); !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+
+		// Nothing was updated: a session already revoked is left as it was
+		var exists bool
+		return q.QueryRowContext(ctx, "SELECT true FROM user_sessions WHERE id = $1", id).Scan(&exists)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeByUser ends every active session of a user
+func (r *sessionRepository) RevokeByUser(ctx context.Context, userID uuid.UUID, reason domain.RevokeReason, at time.Time) (int, error) {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.RevokeByUser")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("session.revoked_reason", string(reason)),
+	)
+
+	var revoked int64
+	err := r.db.ExecuteQuery(ctx, "update", "user_sessions", func(q querier) error {
+		result, err := q.ExecContext(ctx, `UPDATE user_sessions SET revoked_at = $2, revoked_reason = $3
+			WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2`,
+			userID, at, string(reason))
+		if err != nil {
+			return err
+		}
+		revoked, err = result.RowsAffected()
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("result.count", revoked))
+
+	return int(revoked), nil
+}
+
+func scanSession(s scanner) (*domain.Session, error) {
+	var session domain.Session
+
+	err := s.Scan(
+		&session.ID, &session.UserID, &session.DeviceName, &session.UserAgent, &session.IPAddress, &session.TokenHash,
+		&session.Rotations, &session.ExpiresAt, &session.LastUsedAt, &session.RevokedAt, &session.RevokedReason,
+		&session.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
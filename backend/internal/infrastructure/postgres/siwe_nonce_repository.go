@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"go.opentelemetry.io/otel"
+)
+
+var siweNonceTracer = otel.Tracer("goreal-backend/infrastructure/postgres/siwe_nonce")
+
+var siweNonceColumns = []string{"id", "nonce", "expires_at", "used_at", "created_at"}
+
+type siweNonceRepository struct {
+	db *DB
+}
+
+// NewSIWENonceRepository creates a new SIWE nonce repository
+func NewSIWENonceRepository(db *DB) domain.SIWENonceRepository {
+	return &siweNonceRepository{
+		db: db,
+	}
+}
+
+// Create stores a new nonce
+func (r *siweNonceRepository) Create(ctx context.Context, nonce *domain.SIWENonce) error {
+	ctx, span := siweNonceTracer.Start(ctx, "siweNonceRepository.Create")
+	defer span.End()
+
+	if nonce.CreatedAt.IsZero() {
+		nonce.CreatedAt = timestamp()
+	}
+
+	query := "INSERT INTO siwe_nonces (" + strings.Join(siweNonceColumns, ", ") + ") VALUES (" + placeholders(len(siweNonceColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "siwe_nonces", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, nonce.ID, nonce.Nonce, nonce.ExpiresAt, nonce.UsedAt, nonce.CreatedAt)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create SIWE nonce: %w", err)
+	}
+
+	return nil
+}
+
+// Consume redeems an unused, unexpired nonce in a single guarded update
+func (r *siweNonceRepository) Consume(ctx context.Context, nonce string, at time.Time) error {
+	ctx, span := siweNonceTracer.Start(ctx, "siweNonceRepository.Consume")
+	defer span.End()
+
+	err := r.db.ExecuteQuery(ctx, "update", "siwe_nonces", func(q querier) error {
+		result, err := q.ExecContext(ctx,
+			"UPDATE siwe_nonces SET used_at = $2 WHERE nonce = $1 AND used_at IS NULL AND expires_at > $2",
+			nonce, at)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to consume SIWE nonce: %w", err)
+	}
+
+	return nil
+}
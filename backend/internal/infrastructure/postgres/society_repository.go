@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var societyTracer = otel.Tracer("goreal-backend/infrastructure/postgres/society")
+
+var societyColumns = []string{
+	"id", "organization_id", "name", "developer_name", "location", "address", "latitude", "longitude", "total_area", "total_units",
+	"amenities", "description", "images", "brochure_url", "is_active", "created_by", "created_at", "updated_at",
+}
+
+var societySortable = map[string]bool{
+	"name": true, "location": true, "created_at": true, "updated_at": true,
+}
+
+var societySelect = "SELECT " + strings.Join(societyColumns, ", ") + " FROM societies"
+
+type societyRepository struct {
+	db *DB
+}
+
+// NewSocietyRepository creates a new society repository
+func NewSocietyRepository(db *DB) domain.SocietyRepository {
+	return &societyRepository{
+		db: db,
+	}
+}
+
+// Create creates a new society
+func (r *societyRepository) Create(ctx context.Context, society *domain.Society) error {
+	if err := domain.AssignOrganization(ctx, &society.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := societyTracer.Start(ctx, "societyRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("society.name", society.Name),
+		attribute.String("society.id", society.ID.String()),
+	)
+
+	// Set timestamps unless the caller supplied them
+	now := time.Now()
+	if society.CreatedAt.IsZero() {
+		society.CreatedAt = now
+	}
+	if society.UpdatedAt.IsZero() {
+		society.UpdatedAt = society.CreatedAt
+	}
+
+	query := "INSERT INTO societies (" + strings.Join(societyColumns, ", ") + ") VALUES (" + placeholders(len(societyColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "societies", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, societyArgs(society)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create society: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a society by ID
+func (r *societyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Society, error) {
+	ctx, span := societyTracer.Start(ctx, "societyRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("society.id", id.String()))
+
+	var society *domain.Society
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "select", "societies", func(q querier) error {
+		var err error
+		society, err = scanSociety(q.QueryRowContext(ctx, societySelect+" WHERE id = $1"+s.and(2), s.args(id)...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get society by ID: %w", err)
+	}
+
+	return society, nil
+}
+
+// Update updates an existing society
+func (r *societyRepository) Update(ctx context.Context, society *domain.Society) error {
+	if err := domain.AssignOrganization(ctx, &society.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := societyTracer.Start(ctx, "societyRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("society.id", society.ID.String()),
+		attribute.String("society.name", society.Name),
+	)
+
+	// Update timestamp
+	society.UpdatedAt = time.Now()
+
+	s := scopeOf(ctx)
+	query := "UPDATE societies SET " + assignments(societyColumns[1:], 2) + " WHERE id = $1" + s.and(len(societyColumns)+1)
+	err := r.db.ExecuteQuery(ctx, "update", "societies", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(societyArgs(society)...)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to update society: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a society by ID
+func (r *societyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := societyTracer.Start(ctx, "societyRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("society.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "delete", "societies", func(q querier) error {
+		result, err := q.ExecContext(ctx, "DELETE FROM societies WHERE id = $1"+s.and(2), s.args(id)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete society: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves societies with pagination and filtering
+func (r *societyRepository) List(ctx context.Context, filters domain.SocietyFilters) ([]*domain.Society, error) {
+	ctx, span := societyTracer.Start(ctx, "societyRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	c := societyConditions(ctx, filters)
+	c.after(filters.BaseFilters)
+	query := societySelect + c.where() + orderBy(filters.BaseFilters, societySortable, "created_at DESC") + paginate(c, filters.BaseFilters)
+
+	societies := []*domain.Society{}
+	err := r.db.ExecuteQuery(ctx, "select", "societies", func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, c.args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			society, err := scanSociety(rows)
+			if err != nil {
+				return err
+			}
+			societies = append(societies, society)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list societies: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(societies)))
+
+	return societies, nil
+}
+
+// Count returns the total number of societies matching the filters
+func (r *societyRepository) Count(ctx context.Context, filters domain.SocietyFilters) (int, error) {
+	ctx, span := societyTracer.Start(ctx, "societyRepository.Count")
+	defer span.End()
+
+	c := societyConditions(ctx, filters)
+
+	var count int
+	err := r.db.ExecuteQuery(ctx, "count", "societies", func(q querier) error {
+		return q.QueryRowContext(ctx, "SELECT COUNT(*) FROM societies"+c.where(), c.args...).Scan(&count)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count societies: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+// societyConditions translates society filters into a WHERE clause
+func societyConditions(ctx context.Context, filters domain.SocietyFilters) *conditions {
+	c := &conditions{}
+	c.scoped(ctx)
+	if filters.Location != nil && *filters.Location != "" {
+		c.search(*filters.Location, "location")
+	}
+	if filters.IsActive != nil {
+		c.add("is_active = ?", *filters.IsActive)
+	}
+	if filters.CreatedBy != nil {
+		c.add("created_by = ?", *filters.CreatedBy)
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		c.search(*filters.Search, "name", "developer_name", "location", "description")
+	}
+	return c
+}
+
+func societyArgs(society *domain.Society) []interface{} {
+	return []interface{}{
+		society.ID, society.OrganizationID, society.Name, society.DeveloperName, society.Location, jsonb{society.Address}, society.Latitude,
+		society.Longitude, society.TotalArea, society.TotalUnits, pq.Array(society.Amenities), society.Description,
+		pq.Array(society.Images), society.BrochureURL, society.IsActive, nullUUID(society.CreatedBy), society.CreatedAt,
+		society.UpdatedAt,
+	}
+}
+
+func scanSociety(s scanner) (*domain.Society, error) {
+	var society domain.Society
+	var createdBy uuid.NullUUID
+
+	err := s.Scan(
+		&society.ID, &society.OrganizationID, &society.Name, &society.DeveloperName, &society.Location, jsonb{&society.Address}, &society.Latitude,
+		&society.Longitude, &society.TotalArea, &society.TotalUnits, pq.Array(&society.Amenities), &society.Description,
+		pq.Array(&society.Images), &society.BrochureURL, &society.IsActive, &createdBy, &society.CreatedAt,
+		&society.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	society.CreatedBy = createdBy.UUID
+	return &society, nil
+}
@@ -0,0 +1,441 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var taskTracer = otel.Tracer("goreal-backend/infrastructure/postgres/task")
+
+var taskColumns = []string{
+	"id", "title", "description", "assigned_to", "assigned_by", "related_to_type", "related_to_id",
+	"status", "priority", "due_date", "completed_at", "estimated_hours", "actual_hours", "tags",
+	"attachments", "created_by", "created_at", "updated_at",
+	"deleted_at", "deleted_by", "organization_id",
+}
+
+var taskSortable = map[string]bool{
+	"title": true, "status": true, "priority": true, "due_date": true, "completed_at": true,
+	"created_at": true, "updated_at": true,
+}
+
+var taskSelect = "SELECT " + strings.Join(taskColumns, ", ") + " FROM tasks"
+
+type taskRepository struct {
+	db *DB
+}
+
+// NewTaskRepository creates a new task repository
+func NewTaskRepository(db *DB) domain.TaskRepository {
+	return &taskRepository{
+		db: db,
+	}
+}
+
+// Create creates a new task
+func (r *taskRepository) Create(ctx context.Context, task *domain.Task) error {
+	if err := domain.AssignOrganization(ctx, &task.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := taskTracer.Start(ctx, "taskRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("task.title", task.Title),
+		attribute.String("task.id", task.ID.String()),
+		attribute.String("task.status", string(task.Status)),
+		attribute.String("task.priority", string(task.Priority)),
+	)
+
+	// Set timestamps unless the caller supplied them
+	now := time.Now()
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = now
+	}
+	if task.UpdatedAt.IsZero() {
+		task.UpdatedAt = task.CreatedAt
+	}
+
+	query := "INSERT INTO tasks (" + strings.Join(taskColumns, ", ") + ") VALUES (" + placeholders(len(taskColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "tasks", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, taskArgs(task)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a task by ID
+func (r *taskRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("task.id", id.String()))
+
+	var task *domain.Task
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "select", "tasks", func(q querier) error {
+		var err error
+		task, err = scanTask(q.QueryRowContext(ctx, taskSelect+" WHERE id = $1 AND deleted_at IS NULL"+s.and(2), s.args(id)...))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get task by ID: %w", err)
+	}
+
+	return task, nil
+}
+
+// Update updates an existing task
+func (r *taskRepository) Update(ctx context.Context, task *domain.Task) error {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("task.id", task.ID.String()),
+		attribute.String("task.title", task.Title),
+		attribute.String("task.status", string(task.Status)),
+	)
+
+	// Update timestamp
+	since := task.UpdatedAt
+	task.UpdatedAt = timestamp()
+
+	// Set completion time if status is completed
+	if task.Status == domain.TaskStatusCompleted && task.CompletedAt == nil {
+		now := time.Now()
+		task.CompletedAt = &now
+	}
+
+	if err := domain.AssignOrganization(ctx, &task.OrganizationID); err != nil {
+		return err
+	}
+	s := scopeOf(ctx)
+	query := versionedUpdate("tasks", taskColumns, s)
+	err := r.db.ExecuteQuery(ctx, "update", "tasks", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(append(taskArgs(task), since)...)...)
+		if err != nil {
+			return err
+		}
+		return expectVersion(ctx, q, result, "tasks", task.ID)
+	})
+
+	if err != nil {
+		task.UpdatedAt = since
+		span.RecordError(err)
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return nil
+}
+
+// Delete moves a task to the trash
+func (r *taskRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("task.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "delete", "tasks", func(q querier) error {
+		result, err := q.ExecContext(ctx, softDelete("tasks", s), s.args(id, timestamp(), deletedBy)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	return nil
+}
+
+// Restore takes a task out of the trash
+func (r *taskRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("task.id", id.String()))
+
+	s := scopeOf(ctx)
+	err := r.db.ExecuteQuery(ctx, "restore", "tasks", func(q querier) error {
+		result, err := q.ExecContext(ctx, restore("tasks", s), s.args(id, timestamp())...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	return nil
+}
+
+// Purge permanently removes tasks that were deleted before the cutoff
+func (r *taskRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.Purge")
+	defer span.End()
+
+	var purged int
+	err := r.db.ExecuteQuery(ctx, "purge", "tasks", func(q querier) error {
+		var err error
+		purged, err = purge(ctx, q, "tasks", deletedBefore)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to purge tasks: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", purged))
+
+	return purged, nil
+}
+
+// List retrieves tasks with pagination and filtering
+func (r *taskRepository) List(ctx context.Context, filters domain.TaskFilters) ([]*domain.Task, error) {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	c := taskConditions(ctx, filters)
+	c.after(filters.BaseFilters)
+	query := taskSelect + c.where() + orderBy(filters.BaseFilters, taskSortable, "created_at DESC") + paginate(c, filters.BaseFilters)
+
+	var tasks []*domain.Task
+	err := r.db.ExecuteQuery(ctx, "select", "tasks", func(q querier) error {
+		var err error
+		tasks, err = queryTasks(ctx, q, query, c.args...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(tasks)))
+
+	return tasks, nil
+}
+
+// Count returns the total number of tasks matching the filters
+func (r *taskRepository) Count(ctx context.Context, filters domain.TaskFilters) (int, error) {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.Count")
+	defer span.End()
+
+	c := taskConditions(ctx, filters)
+
+	var count int
+	err := r.db.ExecuteQuery(ctx, "count", "tasks", func(q querier) error {
+		return q.QueryRowContext(ctx, "SELECT COUNT(*) FROM tasks"+c.where(), c.args...).Scan(&count)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+// GetByAssignedUser retrieves tasks assigned to a user
+func (r *taskRepository) GetByAssignedUser(ctx context.Context, userID uuid.UUID) ([]*domain.Task, error) {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.GetByAssignedUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	var tasks []*domain.Task
+	s := scopeOf(ctx)
+	query := taskSelect + " WHERE assigned_to = $1 AND deleted_at IS NULL" + s.and(2) + " ORDER BY created_at DESC, id"
+	err := r.db.ExecuteQuery(ctx, "select", "tasks", func(q querier) error {
+		var err error
+		tasks, err = queryTasks(ctx, q, query, s.args(userID)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get tasks by assigned to: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(tasks)))
+
+	return tasks, nil
+}
+
+// GetOverdueTasks retrieves all overdue tasks
+func (r *taskRepository) GetOverdueTasks(ctx context.Context) ([]*domain.Task, error) {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.GetOverdueTasks")
+	defer span.End()
+
+	s := scopeOf(ctx)
+	query := taskSelect + ` WHERE due_date IS NOT NULL AND due_date < $1 AND status <> $2 AND deleted_at IS NULL` +
+		s.and(3) + ` ORDER BY due_date ASC, id`
+
+	var tasks []*domain.Task
+	err := r.db.ExecuteQuery(ctx, "select", "tasks", func(q querier) error {
+		var err error
+		tasks, err = queryTasks(ctx, q, query, s.args(time.Now(), string(domain.TaskStatusCompleted))...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get overdue tasks: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(tasks)))
+
+	return tasks, nil
+}
+
+// GetTasksByRelatedEntity retrieves tasks related to a specific entity
+func (r *taskRepository) GetTasksByRelatedEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*domain.Task, error) {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.GetTasksByRelatedEntity")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("entity.type", entityType),
+		attribute.String("entity.id", entityID.String()),
+	)
+
+	var tasks []*domain.Task
+	s := scopeOf(ctx)
+	query := taskSelect + " WHERE related_to_type = $1 AND related_to_id = $2 AND deleted_at IS NULL" + s.and(3) +
+		" ORDER BY created_at DESC, id"
+	err := r.db.ExecuteQuery(ctx, "select", "tasks", func(q querier) error {
+		var err error
+		tasks, err = queryTasks(ctx, q, query, s.args(entityType, entityID)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get tasks by related entity: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(tasks)))
+
+	return tasks, nil
+}
+
+// taskConditions translates task filters into a WHERE clause
+func taskConditions(ctx context.Context, filters domain.TaskFilters) *conditions {
+	c := &conditions{}
+	c.scoped(ctx)
+	if !filters.IncludeDeleted {
+		c.add("deleted_at IS NULL")
+	}
+	if filters.AssignedTo != nil {
+		c.add("assigned_to = ?", *filters.AssignedTo)
+	}
+	if filters.AssignedBy != nil {
+		c.add("assigned_by = ?", *filters.AssignedBy)
+	}
+	if filters.Status != nil {
+		c.add("status = ?", string(*filters.Status))
+	}
+	if filters.Priority != nil {
+		c.add("priority = ?", string(*filters.Priority))
+	}
+	if filters.RelatedToType != nil {
+		c.add("related_to_type = ?", *filters.RelatedToType)
+	}
+	if filters.RelatedToID != nil {
+		c.add("related_to_id = ?", *filters.RelatedToID)
+	}
+	if filters.DueDateFrom != nil {
+		c.add("due_date >= ?", *filters.DueDateFrom)
+	}
+	if filters.DueDateTo != nil {
+		c.add("due_date <= ?", *filters.DueDateTo)
+	}
+	if len(filters.Tags) > 0 {
+		c.add("tags @> ?", pq.Array(filters.Tags))
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		c.search(*filters.Search, "title", "description")
+	}
+	return c
+}
+
+func taskArgs(task *domain.Task) []interface{} {
+	return []interface{}{
+		task.ID, task.Title, task.Description, task.AssignedTo, task.AssignedBy, task.RelatedToType,
+		task.RelatedToID, string(task.Status), string(task.Priority), task.DueDate, task.CompletedAt,
+		task.EstimatedHours, task.ActualHours, pq.Array(task.Tags), pq.Array(task.Attachments),
+		nullUUID(task.CreatedBy), task.CreatedAt, task.UpdatedAt,
+		task.DeletedAt, task.DeletedBy, task.OrganizationID,
+	}
+}
+
+func scanTask(s scanner) (*domain.Task, error) {
+	var task domain.Task
+	var status, priority string
+	var createdBy uuid.NullUUID
+
+	err := s.Scan(
+		&task.ID, &task.Title, &task.Description, &task.AssignedTo, &task.AssignedBy, &task.RelatedToType,
+		&task.RelatedToID, &status, &priority, &task.DueDate, &task.CompletedAt,
+		&task.EstimatedHours, &task.ActualHours, pq.Array(&task.Tags), pq.Array(&task.Attachments),
+		&createdBy, &task.CreatedAt, &task.UpdatedAt,
+		&task.DeletedAt, &task.DeletedBy, &task.OrganizationID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Status = domain.TaskStatus(status)
+	task.Priority = domain.TaskPriority(priority)
+	task.CreatedBy = createdBy.UUID
+	return &task, nil
+}
+
+func queryTasks(ctx context.Context, q querier, query string, args ...interface{}) ([]*domain.Task, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []*domain.Task{}
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
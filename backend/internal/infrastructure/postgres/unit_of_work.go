@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"goreal-backend/internal/domain"
+)
+
+// txKey carries the *sql.Tx of a running unit of work in a context
+type txKey struct{}
+
+type unitOfWork struct {
+	db *DB
+}
+
+// NewUnitOfWork creates a unit of work backed by database transactions
+func NewUnitOfWork(db *DB) domain.UnitOfWork {
+	return &unitOfWork{
+		db: db,
+	}
+}
+
+// Do runs fn in a transaction that is committed when fn succeeds and
+// rolled back otherwise
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Nested units join the enclosing transaction
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	ctx, span := postgresTracer.Start(ctx, "postgres.transaction")
+	defer span.End()
+
+	tx, err := u.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	unitCtx, committed := domain.WithCommitHooks(context.WithValue(ctx, txKey{}, tx))
+	if err := fn(unitCtx); err != nil {
+		span.RecordError(err)
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		err = mapError(err)
+		span.RecordError(err)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	committed()
+	return nil
+}
@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var userColumns = []string{
+	"id", "email", "username", "full_name", "role", "bio", "avatar_url", "wallet_address",
+	"password_hash", "is_active", "last_login_at", "created_at", "updated_at", "organization_id",
+	"email_verified_at", "mfa_enabled", "failed_login_attempts", "last_failed_login_at", "locked_until",
+}
+
+var userSortable = map[string]bool{
+	"email": true, "username": true, "full_name": true, "role": true,
+	"last_login_at": true, "created_at": true, "updated_at": true,
+}
+
+var userSelect = "SELECT " + strings.Join(userColumns, ", ") + " FROM profiles"
+
+// userRepository implements domain.UserRepository using PostgreSQL
+type userRepository struct {
+	db *DB
+}
+
+// NewUserRepository creates a new user repository
+func NewUserRepository(db *DB) domain.UserRepository {
+	return &userRepository{
+		db: db,
+	}
+}
+
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	if err := domain.AssignOrganization(ctx, &user.OrganizationID); err != nil {
+		return err
+	}
+	query := "INSERT INTO profiles (" + strings.Join(userColumns, ", ") + ") VALUES (" + placeholders(len(userColumns)) + ")"
+
+	return r.db.ExecuteQuery(ctx, "insert", "profiles", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, userArgs(user)...)
+		return err
+	})
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.getOne(ctx, "select_by_id", "id", id)
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.getOne(ctx, "select_by_email", "email", email)
+}
+
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return r.getOne(ctx, "select_by_username", "username", username)
+}
+
+func (r *userRepository) getOne(ctx context.Context, operation, column string, value interface{}) (*domain.User, error) {
+	var user *domain.User
+	s := scopeOf(ctx)
+
+	err := r.db.ExecuteQuery(ctx, operation, "profiles", func(q querier) error {
+		var err error
+		user, err = scanUser(q.QueryRowContext(ctx, userSelect+" WHERE "+column+" = $1"+s.and(2), s.args(value)...))
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	user.UpdatedAt = time.Now()
+	if err := domain.AssignOrganization(ctx, &user.OrganizationID); err != nil {
+		return err
+	}
+	s := scopeOf(ctx)
+	query := "UPDATE profiles SET " + assignments(userColumns[1:], 2) + " WHERE id = $1" + s.and(len(userColumns)+1)
+
+	return r.db.ExecuteQuery(ctx, "update", "profiles", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(userArgs(user)...)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+}
+
+func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	s := scopeOf(ctx)
+	return r.db.ExecuteQuery(ctx, "delete", "profiles", func(q querier) error {
+		result, err := q.ExecContext(ctx, "DELETE FROM profiles WHERE id = $1"+s.and(2), s.args(id)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+}
+
+// RecordFailedLogin counts a failed login against a user in one statement
+func (r *userRepository) RecordFailedLogin(ctx context.Context, id uuid.UUID, at, since time.Time) (int, error) {
+	s := scopeOf(ctx)
+	query := `UPDATE profiles SET
+		failed_login_attempts = CASE WHEN last_failed_login_at >= $3 THEN failed_login_attempts + 1 ELSE 1 END,
+		last_failed_login_at = $2, updated_at = $4
+		WHERE id = $1` + s.and(5) + " RETURNING failed_login_attempts"
+
+	var attempts int
+	err := r.db.ExecuteQuery(ctx, "update", "profiles", func(q querier) error {
+		return q.QueryRowContext(ctx, query, s.args(id, at, since, timestamp())...).Scan(&attempts)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to record failed login: %w", err)
+	}
+	return attempts, nil
+}
+
+// LockUntil locks a user until a time, unless already locked for longer
+func (r *userRepository) LockUntil(ctx context.Context, id uuid.UUID, until time.Time) error {
+	s := scopeOf(ctx)
+	query := "UPDATE profiles SET locked_until = GREATEST(locked_until, $2), updated_at = $3 WHERE id = $1" + s.and(4)
+	err := r.db.ExecuteQuery(ctx, "update", "profiles", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, s.args(id, until, timestamp())...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+	return nil
+}
+
+func (r *userRepository) List(ctx context.Context, filters domain.UserFilters) ([]*domain.User, error) {
+	c := userConditions(ctx, filters)
+	c.after(filters.BaseFilters)
+	query := userSelect + c.where() + orderBy(filters.BaseFilters, userSortable, "created_at DESC") + paginate(c, filters.BaseFilters)
+
+	var users []*domain.User
+	err := r.db.ExecuteQuery(ctx, "select_list", "profiles", func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, c.args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		users = []*domain.User{}
+		for rows.Next() {
+			user, err := scanUser(rows)
+			if err != nil {
+				return err
+			}
+			users = append(users, user)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *userRepository) Count(ctx context.Context, filters domain.UserFilters) (int, error) {
+	c := userConditions(ctx, filters)
+
+	var count int
+	err := r.db.ExecuteQuery(ctx, "count", "profiles", func(q querier) error {
+		return q.QueryRowContext(ctx, "SELECT COUNT(*) FROM profiles"+c.where(), c.args...).Scan(&count)
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return count, nil
+}
+
+// userConditions translates user filters into a WHERE clause
+func userConditions(ctx context.Context, filters domain.UserFilters) *conditions {
+	c := &conditions{}
+	c.scoped(ctx)
+	if filters.Role != nil {
+		c.add("role = ?", string(*filters.Role))
+	}
+	if filters.IsActive != nil {
+		c.add("is_active = ?", *filters.IsActive)
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		c.search(*filters.Search, "full_name", "email", "username")
+	}
+	return c
+}
+
+func userArgs(user *domain.User) []interface{} {
+	return []interface{}{
+		user.ID, user.Email, user.Username, user.FullName, string(user.Role), user.Bio, user.AvatarURL,
+		user.WalletAddress, user.PasswordHash, user.IsActive, user.LastLoginAt, user.CreatedAt, user.UpdatedAt,
+		user.OrganizationID, user.EmailVerifiedAt, user.MFAEnabled, user.FailedLoginAttempts, user.LastFailedLoginAt,
+		user.LockedUntil,
+	}
+}
+
+func scanUser(s scanner) (*domain.User, error) {
+	var user domain.User
+	var role string
+	var passwordHash *string
+
+	err := s.Scan(
+		&user.ID, &user.Email, &user.Username, &user.FullName, &role, &user.Bio, &user.AvatarURL,
+		&user.WalletAddress, &passwordHash, &user.IsActive, &user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt,
+		&user.OrganizationID, &user.EmailVerifiedAt, &user.MFAEnabled, &user.FailedLoginAttempts, &user.LastFailedLoginAt,
+		&user.LockedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Role = domain.UserRole(role)
+	if passwordHash != nil {
+		user.PasswordHash = *passwordHash
+	}
+	return &user, nil
+}
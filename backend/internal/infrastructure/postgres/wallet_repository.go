@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var walletTracer = otel.Tracer("goreal-backend/infrastructure/postgres/wallet")
+
+var walletColumns = []string{
+	"id", "user_id", "address", "wallet_type", "is_active", "is_verified", "last_used", "created_at", "updated_at",
+}
+
+var walletSelect = "SELECT " + strings.Join(walletColumns, ", ") + " FROM wallets"
+
+type walletRepository struct {
+	db *DB
+}
+
+// NewWalletRepository creates a new wallet repository
+func NewWalletRepository(db *DB) domain.WalletRepository {
+	return &walletRepository{
+		db: db,
+	}
+}
+
+// Create stores a new wallet; addresses must be unique
+func (r *walletRepository) Create(ctx context.Context, wallet *domain.Wallet) error {
+	ctx, span := walletTracer.Start(ctx, "walletRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", wallet.UserID.String()),
+		attribute.String("wallet.address", wallet.Address),
+	)
+
+	if wallet.CreatedAt.IsZero() {
+		wallet.CreatedAt = timestamp()
+	}
+	wallet.UpdatedAt = wallet.CreatedAt
+
+	query := "INSERT INTO wallets (" + strings.Join(walletColumns, ", ") + ") VALUES (" + placeholders(len(walletColumns)) + ")"
+	err := r.db.ExecuteQuery(ctx, "insert", "wallets", func(q querier) error {
+		_, err := q.ExecContext(ctx, query, walletArgs(wallet)...)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create wallet: %w", err)
+	}
+
+	return nil
+}
+
+// GetByAddress retrieves the wallet with an address
+func (r *walletRepository) GetByAddress(ctx context.Context, address string) (*domain.Wallet, error) {
+	ctx, span := walletTracer.Start(ctx, "walletRepository.GetByAddress")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("wallet.address", address))
+
+	var wallet *domain.Wallet
+	err := r.db.ExecuteQuery(ctx, "select_by_address", "wallets", func(q querier) error {
+		var err error
+		wallet, err = scanWallet(q.QueryRowContext(ctx, walletSelect+" WHERE address = $1", address))
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get wallet by address: %w", err)
+	}
+
+	return wallet, nil
+}
+
+// ListByUser returns the wallets of a user, oldest first
+func (r *walletRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Wallet, error) {
+	ctx, span := walletTracer.Start(ctx, "walletRepository.ListByUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	wallets := []*domain.Wallet{}
+	err := r.db.ExecuteQuery(ctx, "select", "wallets", func(q querier) error {
+		rows, err := q.QueryContext(ctx, walletSelect+" WHERE user_id = $1 ORDER BY created_at, id", userID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			wallet, err := scanWallet(rows)
+			if err != nil {
+				return err
+			}
+			wallets = append(wallets, wallet)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list wallets: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(wallets)))
+
+	return wallets, nil
+}
+
+// Update updates an existing wallet
+func (r *walletRepository) Update(ctx context.Context, wallet *domain.Wallet) error {
+	ctx, span := walletTracer.Start(ctx, "walletRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("wallet.id", wallet.ID.String()))
+
+	wallet.UpdatedAt = timestamp()
+	query := "UPDATE wallets SET " + assignments(walletColumns[1:], 2) + " WHERE id = $1"
+	err := r.db.ExecuteQuery(ctx, "update", "wallets", func(q querier) error {
+		result, err := q.ExecContext(ctx, query, walletArgs(wallet)...)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to update wallet: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a wallet
+func (r *walletRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := walletTracer.Start(ctx, "walletRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("wallet.id", id.String()))
+
+	err := r.db.ExecuteQuery(ctx, "delete", "wallets", func(q querier) error {
+		result, err := q.ExecContext(ctx, "DELETE FROM wallets WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+		return expectRows(result)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete wallet: %w", err)
+	}
+
+	return nil
+}
+
+func walletArgs(wallet *domain.Wallet) []interface{} {
+	return []interface{}{
+		wallet.ID, wallet.UserID, wallet.Address, wallet.WalletType, wallet.IsActive, wallet.IsVerified,
+		wallet.LastUsed, wallet.CreatedAt, wallet.UpdatedAt,
+	}
+}
+
+func scanWallet(s scanner) (*domain.Wallet, error) {
+	var wallet domain.Wallet
+
+	err := s.Scan(
+		&wallet.ID, &wallet.UserID, &wallet.Address, &wallet.WalletType, &wallet.IsActive, &wallet.IsVerified,
+		&wallet.LastUsed, &wallet.CreatedAt, &wallet.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
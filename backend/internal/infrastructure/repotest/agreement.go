@@ -0,0 +1,117 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunAgreementRepository tests the agreement template and generated
+// agreement repositories
+func RunAgreementRepository(t *testing.T, newBackend Factory) {
+	t.Run("TemplateVersions", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.AgreementTemplates
+		owner := f.user("Owner", domain.RoleManager)
+		org := f.organization("Alpha Homes")
+		orgCtx := domain.WithOrganization(f.ctx, org.ID)
+		project := f.project("Tower", owner.ID)
+
+		_, err := repo.GetLatest(orgCtx, nil)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "no default template yet: %v", err)
+
+		save := func(projectID *uuid.UUID, version int) *domain.AgreementTemplate {
+			template := &domain.AgreementTemplate{
+				ID:        uuid.New(),
+				ProjectID: projectID,
+				Name:      "Agreement",
+				Version:   version,
+				Body:      "# Agreement",
+				CreatedBy: owner.ID,
+				CreatedAt: f.at(time.Duration(version) * time.Minute),
+			}
+			require.NoError(t, repo.Create(orgCtx, template))
+			return template
+		}
+		save(nil, 1)
+		save(nil, 2)
+		override := save(&project.ID, 1)
+
+		latest, err := repo.GetLatest(orgCtx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, latest.Version)
+		assert.Nil(t, latest.ProjectID)
+		assert.Equal(t, &org.ID, latest.OrganizationID)
+
+		latest, err = repo.GetLatest(orgCtx, &project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, override.ID, latest.ID)
+		assert.Equal(t, "# Agreement", latest.Body)
+
+		versions, err := repo.ListVersions(orgCtx, nil)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		assert.Equal(t, 2, versions[0].Version, "newest first")
+
+		other := domain.WithOrganization(f.ctx, f.organization("Beta Estates").ID)
+		_, err = repo.GetLatest(other, nil)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "templates are scoped to the organization: %v", err)
+	})
+
+	t.Run("Agreements", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Agreements
+		owner := f.user("Owner", domain.RoleManager)
+		org := f.organization("Alpha Homes")
+		orgCtx := domain.WithOrganization(f.ctx, org.ID)
+
+		sale := &domain.Sale{ID: uuid.New(), SaleNumber: "SAL-AGR-1", ClientID: f.client("Buyer", owner.ID).ID,
+			InventoryID: f.unit(f.project("Tower", owner.ID).ID, owner.ID).ID, SaleDate: f.now,
+			Status: domain.SaleStatusPending, TotalAmount: 100000, FinalAmount: 100000, CreatedBy: owner.ID}
+		require.NoError(t, f.b.Sales.Create(orgCtx, sale))
+
+		_, err := repo.GetLatest(orgCtx, sale.ID, domain.AgreementFormatPDF)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "nothing generated yet: %v", err)
+
+		store := func(format domain.AgreementFormat, content string, offset time.Duration) *domain.Agreement {
+			agreement := &domain.Agreement{
+				ID:              uuid.New(),
+				SaleID:          sale.ID,
+				TemplateVersion: 0,
+				Format:          format,
+				FileName:        "SAL-AGR-1-agreement." + string(format),
+				Checksum:        content,
+				Content:         []byte("%PDF-1.4\x00\xff" + content),
+				CreatedBy:       owner.ID,
+				CreatedAt:       f.at(offset),
+			}
+			require.NoError(t, repo.Create(orgCtx, agreement))
+			return agreement
+		}
+		store(domain.AgreementFormatPDF, "first", 0)
+		second := store(domain.AgreementFormatPDF, "second", time.Minute)
+		store(domain.AgreementFormatDOCX, "docx", 2*time.Minute)
+
+		latest, err := repo.GetLatest(orgCtx, sale.ID, domain.AgreementFormatPDF)
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, latest.ID)
+		assert.Equal(t, second.Content, latest.Content, "binary content round-trips")
+		assert.Nil(t, latest.TemplateID)
+
+		found, err := repo.GetByID(orgCtx, second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "second", found.Checksum)
+		assert.Equal(t, domain.AgreementFormatPDF, found.Format)
+		assert.Equal(t, &org.ID, found.OrganizationID)
+
+		other := domain.WithOrganization(f.ctx, f.organization("Beta Estates").ID)
+		_, err = repo.GetByID(other, second.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "agreements are scoped to the organization: %v", err)
+	})
+}
@@ -0,0 +1,86 @@
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunAuthEventRepository checks a domain.AuthEventRepository implementation
+func RunAuthEventRepository(t *testing.T, newBackend Factory) {
+	newEvent := func(f *fixture, userID *uuid.UUID, eventType domain.AuthEventType, ip string, at time.Duration) *domain.AuthEvent {
+		event := &domain.AuthEvent{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Email:     "someone@example.com",
+			Type:      eventType,
+			IPAddress: ptr(ip),
+			UserAgent: ptr("test"),
+			CreatedAt: f.at(at),
+		}
+		require.NoError(t, f.b.AuthEvents.Create(f.ctx, event))
+		return event
+	}
+
+	t.Run("FailuresFromAddress", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.AuthEvents
+		user := f.user("Ana", domain.RoleEmployee)
+
+		newEvent(f, &user.ID, domain.AuthEventLoginFailed, "10.0.0.1", -time.Hour)
+		newEvent(f, &user.ID, domain.AuthEventLoginFailed, "10.0.0.1", -2*time.Minute)
+		newEvent(f, nil, domain.AuthEventLoginFailed, "10.0.0.1", -time.Minute)
+		newEvent(f, &user.ID, domain.AuthEventAccountLocked, "10.0.0.1", -30*time.Second)
+		newEvent(f, nil, domain.AuthEventLoginFailed, "10.0.0.2", 0)
+
+		count, latest, err := repo.FailuresFromAddress(f.ctx, "10.0.0.1", f.at(-10*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 2, count, "failures in the window, of any account")
+		require.NotNil(t, latest)
+		assert.True(t, f.at(-time.Minute).Equal(*latest), "latest failure: %v", latest)
+
+		count, latest, err = repo.FailuresFromAddress(f.ctx, "10.0.0.3", f.at(-10*time.Minute))
+		require.NoError(t, err)
+		assert.Zero(t, count)
+		assert.Nil(t, latest)
+	})
+
+	t.Run("ListByUser", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.AuthEvents
+		user := f.user("Ana", domain.RoleEmployee)
+		admin := f.user("Admin", domain.RoleAdmin)
+
+		newEvent(f, &user.ID, domain.AuthEventLoginFailed, "10.0.0.1", -time.Hour)
+		locked := &domain.AuthEvent{ID: uuid.New(), UserID: &user.ID, Email: user.Email,
+			Type: domain.AuthEventAccountLocked, IPAddress: ptr("10.0.0.1"), LockedUntil: ptr(f.at(time.Hour)),
+			CreatedAt: f.at(-time.Minute)}
+		require.NoError(t, repo.Create(f.ctx, locked))
+		unlocked := &domain.AuthEvent{ID: uuid.New(), UserID: &user.ID, Email: user.Email,
+			Type: domain.AuthEventAccountUnlocked, ActorID: &admin.ID, CreatedAt: f.now}
+		require.NoError(t, repo.Create(f.ctx, unlocked))
+		newEvent(f, &admin.ID, domain.AuthEventLoginFailed, "10.0.0.1", 0)
+
+		events, err := repo.ListByUser(f.ctx, user.ID, 2)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, []uuid.UUID{unlocked.ID, locked.ID}, []uuid.UUID{events[0].ID, events[1].ID}, "newest first")
+		assert.Equal(t, domain.AuthEventAccountUnlocked, events[0].Type)
+		require.NotNil(t, events[0].ActorID)
+		assert.Equal(t, admin.ID, *events[0].ActorID)
+		assert.Nil(t, events[0].IPAddress)
+		require.NotNil(t, events[1].IPAddress)
+		assert.Equal(t, "10.0.0.1", *events[1].IPAddress)
+		require.NotNil(t, events[1].LockedUntil)
+		assert.True(t, f.at(time.Hour).Equal(*events[1].LockedUntil))
+
+		events, err = repo.ListByUser(f.ctx, user.ID, 0)
+		require.NoError(t, err)
+		assert.Len(t, events, 3)
+	})
+}
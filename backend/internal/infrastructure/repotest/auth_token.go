@@ -0,0 +1,75 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunAuthTokenRepository checks a domain.AuthTokenRepository implementation
+func RunAuthTokenRepository(t *testing.T, newBackend Factory) {
+	newToken := func(f *fixture, userID uuid.UUID, purpose domain.TokenPurpose, hash string, expiresIn time.Duration) *domain.AuthToken {
+		token := &domain.AuthToken{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Purpose:   purpose,
+			TokenHash: hash,
+			ExpiresAt: f.at(expiresIn),
+			CreatedAt: f.at(-time.Minute),
+		}
+		require.NoError(t, f.b.AuthTokens.Create(f.ctx, token))
+		return token
+	}
+
+	t.Run("ConsumeOnce", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.AuthTokens
+		user := f.user("Ana", domain.RoleEmployee)
+		reset := newToken(f, user.ID, domain.TokenPurposePasswordReset, "hash-reset", time.Hour)
+		newToken(f, user.ID, domain.TokenPurposeEmailVerification, "hash-verify", -time.Second)
+
+		_, err := repo.Consume(f.ctx, domain.TokenPurposeEmailVerification, "hash-reset", f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "token of another purpose: %v", err)
+		_, err = repo.Consume(f.ctx, domain.TokenPurposeEmailVerification, "hash-verify", f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "expired token: %v", err)
+
+		got, err := repo.Consume(f.ctx, domain.TokenPurposePasswordReset, "hash-reset", f.now)
+		require.NoError(t, err)
+		assert.Equal(t, reset.ID, got.ID)
+		assert.Equal(t, user.ID, got.UserID)
+		assert.Equal(t, domain.TokenPurposePasswordReset, got.Purpose)
+		require.NotNil(t, got.UsedAt)
+		assert.True(t, f.now.Equal(*got.UsedAt))
+
+		_, err = repo.Consume(f.ctx, domain.TokenPurposePasswordReset, "hash-reset", f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "token already used: %v", err)
+	})
+
+	t.Run("InvalidateByUser", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.AuthTokens
+		user := f.user("Ana", domain.RoleEmployee)
+		other := f.user("Ben", domain.RoleEmployee)
+		newToken(f, user.ID, domain.TokenPurposePasswordReset, "hash-a", time.Hour)
+		newToken(f, user.ID, domain.TokenPurposePasswordReset, "hash-b", time.Hour)
+		newToken(f, user.ID, domain.TokenPurposeEmailVerification, "hash-c", time.Hour)
+		newToken(f, other.ID, domain.TokenPurposePasswordReset, "hash-d", time.Hour)
+
+		invalidated, err := repo.InvalidateByUser(f.ctx, user.ID, domain.TokenPurposePasswordReset, f.now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, invalidated)
+
+		_, err = repo.Consume(f.ctx, domain.TokenPurposePasswordReset, "hash-a", f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "invalidated token: %v", err)
+		_, err = repo.Consume(f.ctx, domain.TokenPurposeEmailVerification, "hash-c", f.now)
+		assert.NoError(t, err, "tokens of other purposes are kept")
+		_, err = repo.Consume(f.ctx, domain.TokenPurposePasswordReset, "hash-d", f.now)
+		assert.NoError(t, err, "tokens of other users are kept")
+	})
+}
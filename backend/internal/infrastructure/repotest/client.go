@@ -0,0 +1,152 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunClientRepository checks a domain.ClientRepository implementation
+func RunClientRepository(t *testing.T, newBackend Factory) {
+	t.Run("CRUD", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Clients
+		owner := f.user("Owner", domain.RoleManager)
+		company := f.company("Acme")
+
+		client := &domain.Client{
+			ID:          uuid.New(),
+			CompanyID:   &company.ID,
+			ClientType:  domain.ClientTypeCorporate,
+			Name:        "Acme Procurement",
+			Email:       ptr("procurement@acme.test"),
+			Address:     &domain.Address{Street: "1 Main St", City: "Pune", Country: "India"},
+			CreditLimit: ptr(50000.0),
+			Tags:        []string{"corporate"},
+			CreatedBy:   owner.ID,
+			CreatedAt:   f.now,
+			UpdatedAt:   f.now,
+		}
+		require.NoError(t, repo.Create(f.ctx, client))
+
+		got, err := repo.GetByID(f.ctx, client.ID)
+		require.NoError(t, err)
+		assert.Equal(t, client.Name, got.Name)
+		assert.Equal(t, client.CompanyID, got.CompanyID)
+		assert.Equal(t, client.ClientType, got.ClientType)
+		assert.Equal(t, client.Address, got.Address)
+		assert.Equal(t, client.CreditLimit, got.CreditLimit)
+		assert.Equal(t, client.Tags, got.Tags)
+		assert.False(t, got.IsVerified)
+
+		got, err = repo.GetByEmail(f.ctx, "procurement@acme.test")
+		require.NoError(t, err)
+		assert.Equal(t, client.ID, got.ID)
+
+		got.Name = "Acme Purchasing"
+		require.NoError(t, repo.Update(f.ctx, got))
+		require.NoError(t, repo.UpdateVerificationStatus(f.ctx, client.ID, true))
+
+		got, err = repo.GetByID(f.ctx, client.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Acme Purchasing", got.Name)
+		assert.True(t, got.IsVerified)
+
+		require.NoError(t, repo.Delete(f.ctx, client.ID, client.CreatedBy))
+		_, err = repo.GetByID(f.ctx, client.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID after delete: %v", err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Clients
+		missing := &domain.Client{ID: uuid.New(), Name: "Ghost", ClientType: domain.ClientTypeIndividual,
+			CreatedBy: f.user("Owner", domain.RoleManager).ID}
+
+		_, err := repo.GetByID(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID: %v", err)
+		_, err = repo.GetByEmail(f.ctx, "ghost@example.com")
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByEmail: %v", err)
+		err = repo.Update(f.ctx, missing)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Update: %v", err)
+		err = repo.UpdateVerificationStatus(f.ctx, missing.ID, true)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "UpdateVerificationStatus: %v", err)
+		err = repo.Delete(f.ctx, missing.ID, missing.CreatedBy)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete: %v", err)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Clients
+		manager := f.user("Manager", domain.RoleManager)
+		other := f.user("Other Manager", domain.RoleManager)
+		agent := f.user("Agent", domain.RoleEmployee)
+		company := f.company("Initech")
+
+		for _, client := range []*domain.Client{
+			{Name: "Ann Buyer", Email: ptr("ann@mail.test"), ClientType: domain.ClientTypeIndividual, AssignedTo: &agent.ID,
+				IsVerified: true, Tags: []string{"vip", "repeat"}, CreatedBy: manager.ID, CreatedAt: f.at(-3 * time.Hour)},
+			{Name: "Initech Ltd", Phone: ptr("+91-555-0199"), ClientType: domain.ClientTypeCorporate, CompanyID: &company.ID,
+				Tags: []string{"vip"}, CreatedBy: manager.ID, CreatedAt: f.at(-2 * time.Hour)},
+			{Name: "Ravi Invests", ClientType: domain.ClientTypeInvestor, AssignedTo: &agent.ID,
+				Tags: []string{}, CreatedBy: other.ID, CreatedAt: f.at(-time.Hour)},
+		} {
+			client.ID = uuid.New()
+			client.UpdatedAt = client.CreatedAt
+			require.NoError(t, repo.Create(f.ctx, client))
+		}
+
+		cases := []struct {
+			name    string
+			filters domain.ClientFilters
+			want    []string
+		}{
+			{"none", domain.ClientFilters{}, []string{"Ann Buyer", "Initech Ltd", "Ravi Invests"}},
+			{"client_type", domain.ClientFilters{ClientType: ptr(domain.ClientTypeInvestor)}, []string{"Ravi Invests"}},
+			{"assigned_to", domain.ClientFilters{AssignedTo: &agent.ID}, []string{"Ann Buyer", "Ravi Invests"}},
+			{"created_by", domain.ClientFilters{CreatedBy: &other.ID}, []string{"Ravi Invests"}},
+			{"is_verified", domain.ClientFilters{IsVerified: ptr(false)}, []string{"Initech Ltd", "Ravi Invests"}},
+			{"company_id", domain.ClientFilters{CompanyID: &company.ID}, []string{"Initech Ltd"}},
+			{"tags", domain.ClientFilters{Tags: []string{"vip"}}, []string{"Ann Buyer", "Initech Ltd"}},
+			{"all_tags", domain.ClientFilters{Tags: []string{"vip", "repeat"}}, []string{"Ann Buyer"}},
+			{"search_name", domain.ClientFilters{Search: ptr("invest")}, []string{"Ravi Invests"}},
+			{"search_email", domain.ClientFilters{Search: ptr("ann@")}, []string{"Ann Buyer"}},
+			{"search_phone", domain.ClientFilters{Search: ptr("0199")}, []string{"Initech Ltd"}},
+			{"created_after", domain.ClientFilters{CreatedAfter: ptr(f.at(-150 * time.Minute))}, []string{"Initech Ltd", "Ravi Invests"}},
+			{"created_before", domain.ClientFilters{CreatedBefore: ptr(f.at(-150 * time.Minute))}, []string{"Ann Buyer"}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := repo.List(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.ElementsMatch(t, tc.want, clientNames(got))
+
+				count, err := repo.Count(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.Equal(t, len(tc.want), count)
+			})
+		}
+
+		got, err := repo.List(f.ctx, domain.ClientFilters{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Ravi Invests", "Initech Ltd", "Ann Buyer"}, clientNames(got), "default is newest first")
+
+		got, err = repo.List(f.ctx, domain.ClientFilters{BaseFilters: domain.BaseFilters{SortBy: "name", Limit: 2}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Ann Buyer", "Initech Ltd"}, clientNames(got))
+
+		got, err = repo.GetByAssignedUser(f.ctx, agent.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"Ann Buyer", "Ravi Invests"}, clientNames(got))
+	})
+}
+
+func clientNames(clients []*domain.Client) []string {
+	return namesOf(clients, func(c *domain.Client) string { return c.Name })
+}
@@ -0,0 +1,154 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunCommissionRepository tests the commission and commission plan
+// repositories
+func RunCommissionRepository(t *testing.T, newBackend Factory) {
+	t.Run("Commissions", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Commissions
+		org := f.organization("Alpha Homes")
+		orgCtx := domain.WithOrganization(f.ctx, org.ID)
+		sale := f.sale(orgCtx, "SAL-COM-1")
+		salesperson := f.user("Salesperson", domain.RoleEmployee)
+		manager := f.user("Manager", domain.RoleManager)
+
+		plan := &domain.CommissionPlan{ID: uuid.New(), Name: "Flat", Type: domain.CommissionPlanFlat, Rate: 2,
+			ManagerShare: 25, CreatedBy: manager.ID, CreatedAt: f.now}
+		require.NoError(t, f.b.CommissionPlans.Create(orgCtx, plan))
+
+		commission := func(employee uuid.UUID, kind string, amount float64, created time.Duration) *domain.Commission {
+			c := &domain.Commission{
+				ID:               uuid.New(),
+				SaleID:           sale.ID,
+				PlanID:           &plan.ID,
+				EmployeeID:       &employee,
+				CommissionType:   kind,
+				CommissionRate:   ptr(1.5),
+				CommissionAmount: amount,
+				Status:           domain.PaymentStatusPending,
+				CreatedAt:        f.at(created),
+				UpdatedAt:        f.at(created),
+			}
+			require.NoError(t, repo.Create(orgCtx, c))
+			return c
+		}
+		primary := commission(salesperson.ID, domain.CommissionTypePrimary, 1500, 0)
+		managed := commission(manager.ID, domain.CommissionTypeManager, 500, time.Minute)
+		clawback := commission(salesperson.ID, domain.CommissionTypeClawback, -150, 2*time.Hour)
+
+		found, err := repo.GetByID(orgCtx, primary.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &org.ID, found.OrganizationID)
+		assert.Equal(t, &plan.ID, found.PlanID)
+		assert.Equal(t, domain.CommissionTypePrimary, found.CommissionType)
+		assert.Equal(t, 1500.0, found.CommissionAmount)
+		require.NotNil(t, found.CommissionRate)
+		assert.Equal(t, 1.5, *found.CommissionRate)
+
+		bySale, err := repo.GetBySale(orgCtx, sale.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{clawback.ID, managed.ID, primary.ID}, idsOf(bySale), "newest first")
+
+		byEmployee, err := repo.GetByEmployee(orgCtx, salesperson.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{clawback.ID, primary.ID}, idsOf(byEmployee))
+
+		paid := f.now
+		primary.Status = domain.PaymentStatusPaid
+		primary.PaidDate = &paid
+		primary.PaymentReference = ptr("NEFT-1")
+		require.NoError(t, repo.Update(orgCtx, primary))
+
+		status := domain.PaymentStatusPending
+		pending, err := repo.List(orgCtx, domain.CommissionFilters{Status: &status})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{clawback.ID, managed.ID}, idsOf(pending))
+
+		kind := domain.CommissionTypeClawback
+		clawbacks, err := repo.List(orgCtx, domain.CommissionFilters{CommissionType: &kind})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{clawback.ID}, idsOf(clawbacks))
+
+		from, to := f.at(-time.Minute), f.at(time.Hour)
+		created, err := repo.List(orgCtx, domain.CommissionFilters{CreatedFrom: &from, CreatedTo: &to, EmployeeID: &salesperson.ID})
+		require.NoError(t, err)
+		require.Len(t, created, 1)
+		assert.Equal(t, primary.ID, created[0].ID)
+		assert.Equal(t, domain.PaymentStatusPaid, created[0].Status)
+		require.NotNil(t, created[0].PaymentReference)
+		assert.Equal(t, "NEFT-1", *created[0].PaymentReference)
+
+		other := domain.WithOrganization(f.ctx, f.organization("Beta Estates").ID)
+		_, err = repo.GetByID(other, primary.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "commission of another organization: %v", err)
+		listed, err := repo.List(other, domain.CommissionFilters{})
+		require.NoError(t, err)
+		assert.Empty(t, listed, "commissions are scoped to the organization")
+
+		require.NoError(t, repo.Delete(orgCtx, clawback.ID))
+		err = repo.Delete(orgCtx, clawback.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "deleting twice: %v", err)
+	})
+
+	t.Run("Plans", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.CommissionPlans
+		org := f.organization("Alpha Homes")
+		orgCtx := domain.WithOrganization(f.ctx, org.ID)
+		manager := f.user("Manager", domain.RoleManager)
+
+		_, err := repo.GetLatest(orgCtx)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "no plan yet: %v", err)
+
+		flat := &domain.CommissionPlan{ID: uuid.New(), Name: "Flat", Type: domain.CommissionPlanFlat, Rate: 2,
+			CreatedBy: manager.ID, CreatedAt: f.now}
+		require.NoError(t, repo.Create(orgCtx, flat))
+		tiered := &domain.CommissionPlan{ID: uuid.New(), Name: "Tiered", Type: domain.CommissionPlanTiered,
+			Slabs:        []domain.CommissionSlab{{MinVolume: 0, Rate: 1}, {MinVolume: 1000000, Rate: 2.5}},
+			ManagerShare: 20, Clawback: true, CreatedBy: manager.ID, CreatedAt: f.at(time.Minute)}
+		require.NoError(t, repo.Create(orgCtx, tiered))
+
+		latest, err := repo.GetLatest(orgCtx)
+		require.NoError(t, err)
+		assert.Equal(t, tiered.ID, latest.ID)
+		assert.Equal(t, &org.ID, latest.OrganizationID)
+		assert.Equal(t, domain.CommissionPlanTiered, latest.Type)
+		assert.Equal(t, tiered.Slabs, latest.Slabs)
+		assert.Equal(t, 20.0, latest.ManagerShare)
+		assert.True(t, latest.Clawback)
+
+		plans, err := repo.List(orgCtx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Tiered", "Flat"}, namesOf(plans, func(p *domain.CommissionPlan) string { return p.Name }))
+
+		found, err := repo.GetByID(orgCtx, flat.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2.0, found.Rate)
+		assert.Empty(t, found.Slabs)
+
+		other := domain.WithOrganization(f.ctx, f.organization("Beta Estates").ID)
+		_, err = repo.GetLatest(other)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "plans are scoped to the organization: %v", err)
+	})
+}
+
+// idsOf returns the IDs of commissions in order
+func idsOf(commissions []*domain.Commission) []uuid.UUID {
+	ids := make([]uuid.UUID, len(commissions))
+	for i, c := range commissions {
+		ids[i] = c.ID
+	}
+	return ids
+}
@@ -0,0 +1,111 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunCompanyRepository checks a domain.CompanyRepository implementation
+func RunCompanyRepository(t *testing.T, newBackend Factory) {
+	t.Run("CRUD", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Companies
+
+		company := &domain.Company{
+			ID:        uuid.New(),
+			Name:      "Globex",
+			Industry:  ptr("Energy"),
+			Website:   ptr("https://globex.test"),
+			Address:   &domain.Address{City: "Austin", Country: "USA"},
+			IsActive:  true,
+			CreatedAt: f.now,
+			UpdatedAt: f.now,
+		}
+		require.NoError(t, repo.Create(f.ctx, company))
+
+		got, err := repo.GetByID(f.ctx, company.ID)
+		require.NoError(t, err)
+		assert.Equal(t, company.Name, got.Name)
+		assert.Equal(t, company.Industry, got.Industry)
+		assert.Equal(t, company.Address, got.Address)
+		assert.True(t, got.IsActive)
+
+		got, err = repo.GetByName(f.ctx, "Globex")
+		require.NoError(t, err)
+		assert.Equal(t, company.ID, got.ID)
+
+		got.IsActive = false
+		require.NoError(t, repo.Update(f.ctx, got))
+		got, err = repo.GetByID(f.ctx, company.ID)
+		require.NoError(t, err)
+		assert.False(t, got.IsActive)
+
+		require.NoError(t, repo.Delete(f.ctx, company.ID))
+		_, err = repo.GetByID(f.ctx, company.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID after delete: %v", err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Companies
+		missing := &domain.Company{ID: uuid.New(), Name: "Ghost"}
+
+		_, err := repo.GetByID(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID: %v", err)
+		_, err = repo.GetByName(f.ctx, missing.Name)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByName: %v", err)
+		err = repo.Update(f.ctx, missing)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Update: %v", err)
+		err = repo.Delete(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete: %v", err)
+	})
+
+	t.Run("FiltersSortAndPaginate", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Companies
+
+		for _, company := range []*domain.Company{
+			{Name: "Umbrella", Industry: ptr("Pharma"), Email: ptr("info@umbrella.test"), IsActive: true},
+			{Name: "Acme", Industry: ptr("Manufacturing"), Website: ptr("https://acme.test"), IsActive: true},
+			{Name: "Hooli", Industry: ptr("Technology"), IsActive: false},
+		} {
+			company.ID = uuid.New()
+			company.CreatedAt = f.now
+			company.UpdatedAt = f.now
+			require.NoError(t, repo.Create(f.ctx, company))
+		}
+
+		cases := []struct {
+			name    string
+			filters domain.CompanyFilters
+			want    []string
+		}{
+			{"none", domain.CompanyFilters{}, []string{"Acme", "Hooli", "Umbrella"}},
+			{"industry", domain.CompanyFilters{Industry: ptr("Pharma")}, []string{"Umbrella"}},
+			{"is_active", domain.CompanyFilters{IsActive: ptr(true)}, []string{"Acme", "Umbrella"}},
+			{"search_name", domain.CompanyFilters{Search: ptr("HOO")}, []string{"Hooli"}},
+			{"search_email", domain.CompanyFilters{Search: ptr("info@")}, []string{"Umbrella"}},
+			{"search_website", domain.CompanyFilters{Search: ptr("acme.test")}, []string{"Acme"}},
+			{"sort", domain.CompanyFilters{BaseFilters: domain.BaseFilters{SortBy: "industry", SortOrder: "desc"}},
+				[]string{"Hooli", "Umbrella", "Acme"}},
+			{"page", domain.CompanyFilters{BaseFilters: domain.BaseFilters{Limit: 1, Offset: 1}}, []string{"Hooli"}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := repo.List(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, namesOf(got, func(c *domain.Company) string { return c.Name }))
+			})
+		}
+
+		count, err := repo.Count(f.ctx, domain.CompanyFilters{IsActive: ptr(true), BaseFilters: domain.BaseFilters{Limit: 1}})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+}
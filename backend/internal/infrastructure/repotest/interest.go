@@ -0,0 +1,62 @@
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunPropertyInterestRepository checks a domain.PropertyInterestRepository implementation
+func RunPropertyInterestRepository(t *testing.T, newBackend Factory) {
+	t.Run("CreateAndList", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Interests
+		owner := f.user("Owner", domain.RoleManager)
+		org := f.organization("Alpha Homes")
+		orgCtx := domain.WithOrganization(f.ctx, org.ID)
+
+		project := f.project("Tower", owner.ID)
+		first, second := f.unit(project.ID, owner.ID), f.unit(project.ID, owner.ID)
+		buyer, other := f.client("Buyer", owner.ID), f.client("Other", owner.ID)
+
+		for i, interest := range []*domain.PropertyInterest{
+			{ClientID: buyer.ID, InventoryID: first.ID, Kind: domain.PropertyInterestViewed},
+			{ClientID: buyer.ID, InventoryID: first.ID, Kind: domain.PropertyInterestShortlisted},
+			{ClientID: buyer.ID, InventoryID: second.ID, Kind: domain.PropertyInterestViewed},
+			{ClientID: other.ID, InventoryID: first.ID, Kind: domain.PropertyInterestViewed},
+		} {
+			interest.ID = uuid.New()
+			interest.RecordedBy = owner.ID
+			interest.CreatedAt = f.at(time.Duration(i) * time.Minute)
+			require.NoError(t, repo.Create(orgCtx, interest))
+			assert.Equal(t, &org.ID, interest.OrganizationID)
+		}
+
+		got, err := repo.ListByClient(orgCtx, buyer.ID)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, second.ID, got[0].InventoryID, "newest first")
+		assert.Equal(t, domain.PropertyInterestShortlisted, got[1].Kind)
+		assert.Equal(t, owner.ID, got[1].RecordedBy)
+		assert.True(t, f.at(time.Minute).Equal(got[1].CreatedAt))
+
+		got, err = repo.ListByInventory(orgCtx, first.ID)
+		require.NoError(t, err)
+		assert.Len(t, got, 3)
+		assert.Equal(t, other.ID, got[0].ClientID)
+
+		elsewhere := domain.WithOrganization(f.ctx, f.organization("Beta Estates").ID)
+		got, err = repo.ListByClient(elsewhere, buyer.ID)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		got, err = repo.ListByInventory(orgCtx, uuid.New())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
@@ -0,0 +1,155 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunInventoryRepository checks reservations of a domain.InventoryRepository
+// implementation, the project unit counts derived from them and the filters
+// a property search pushes down
+func RunInventoryRepository(t *testing.T, newBackend Factory) {
+	t.Run("Reservations", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Inventory
+		owner := f.user("Owner", domain.RoleManager)
+		project := f.project("Tower", owner.ID)
+		unit := f.unit(project.ID, owner.ID)
+		buyer, rival := uuid.New(), uuid.New()
+
+		require.NoError(t, repo.Reserve(f.ctx, unit.ID, buyer, f.at(time.Hour)))
+		err := repo.Reserve(f.ctx, unit.ID, rival, f.at(time.Hour))
+		assert.True(t, errors.Is(err, domain.ErrInventoryNotAvailable), "Reserve a reserved unit: %v", err)
+		err = repo.Reserve(f.ctx, uuid.New(), buyer, f.at(time.Hour))
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Reserve a missing unit: %v", err)
+
+		got, err := repo.GetByID(f.ctx, unit.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UnitStatusReserved, got.Status)
+		assert.Equal(t, &buyer, got.ReservedBy)
+		require.NotNil(t, got.ReservedUntil)
+		assert.True(t, f.at(time.Hour).Equal(*got.ReservedUntil))
+
+		err = repo.ReleaseIfExpired(f.ctx, unit.ID, f.at(time.Minute))
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "ReleaseIfExpired before expiry: %v", err)
+		require.NoError(t, repo.ReleaseIfExpired(f.ctx, unit.ID, f.at(time.Hour)))
+		err = repo.ReleaseIfExpired(f.ctx, unit.ID, f.at(time.Hour))
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "ReleaseIfExpired twice: %v", err)
+
+		got, err = repo.GetByID(f.ctx, unit.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UnitStatusAvailable, got.Status)
+		assert.Nil(t, got.ReservedBy)
+		assert.Nil(t, got.ReservedUntil)
+
+		require.NoError(t, repo.Reserve(f.ctx, unit.ID, rival, f.at(time.Hour)))
+		require.NoError(t, repo.Release(f.ctx, unit.ID))
+		got, err = repo.GetByID(f.ctx, unit.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UnitStatusAvailable, got.Status)
+	})
+
+	t.Run("ExpiredFilter", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Inventory
+		owner := f.user("Owner", domain.RoleManager)
+		project := f.project("Tower", owner.ID)
+		lapsed, current, free := f.unit(project.ID, owner.ID), f.unit(project.ID, owner.ID), f.unit(project.ID, owner.ID)
+
+		require.NoError(t, repo.Reserve(f.ctx, lapsed.ID, uuid.New(), f.at(-time.Hour)))
+		require.NoError(t, repo.Reserve(f.ctx, current.ID, uuid.New(), f.at(time.Hour)))
+
+		status := domain.UnitStatusReserved
+		got, err := repo.List(f.ctx, domain.InventoryFilters{Status: &status, ReservedUntilTo: &f.now})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, lapsed.ID, got[0].ID)
+
+		count, err := repo.Count(f.ctx, domain.InventoryFilters{ReservedUntilTo: ptr(f.at(2 * time.Hour))})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count, "unreserved unit %s has no expiry", free.ID)
+	})
+
+	t.Run("SearchFilters", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Inventory
+		owner := f.user("Owner", domain.RoleManager)
+		east, west := f.project("East", owner.ID), f.project("West", owner.ID)
+		f.unit(f.project("North", owner.ID).ID, owner.ID)
+
+		priced := func(projectID uuid.UUID, price float64, parking int) *domain.Inventory {
+			unit := f.unit(projectID, owner.ID)
+			unit.FinalPrice, unit.ParkingSlots = &price, parking
+			require.NoError(t, repo.Update(f.ctx, unit))
+			return unit
+		}
+		dear, cheap, parkless := priced(east.ID, 900, 2), priced(west.ID, 500, 1), priced(west.ID, 100, 0)
+		ids := func(units []*domain.Inventory) []uuid.UUID {
+			out := make([]uuid.UUID, len(units))
+			for i, unit := range units {
+				out[i] = unit.ID
+			}
+			return out
+		}
+
+		got, err := repo.List(f.ctx, domain.InventoryFilters{
+			BaseFilters: domain.BaseFilters{SortBy: "final_price", SortOrder: "asc"},
+			ProjectIDs:  []uuid.UUID{east.ID, west.ID},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{parkless.ID, cheap.ID, dear.ID}, ids(got))
+
+		got, err = repo.List(f.ctx, domain.InventoryFilters{
+			BaseFilters:     domain.BaseFilters{SortBy: "final_price", SortOrder: "asc", Limit: 1, Offset: 1},
+			ProjectIDs:      []uuid.UUID{east.ID, west.ID},
+			MinParkingSlots: ptr(1),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{dear.ID}, ids(got))
+
+		for _, wildcard := range []string{"%", "_", "*"} {
+			got, err = repo.List(f.ctx, domain.InventoryFilters{Search: ptr(wildcard)})
+			require.NoError(t, err)
+			assert.Empty(t, got, "search for %q matches only itself", wildcard)
+		}
+	})
+
+	t.Run("UnitCounts", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Inventory
+		owner := f.user("Owner", domain.RoleManager)
+		project := f.project("Tower", owner.ID)
+		units := []*domain.Inventory{
+			f.unit(project.ID, owner.ID), f.unit(project.ID, owner.ID), f.unit(project.ID, owner.ID), f.unit(project.ID, owner.ID),
+		}
+		f.unit(f.project("Other", owner.ID).ID, owner.ID)
+
+		require.NoError(t, repo.Reserve(f.ctx, units[0].ID, uuid.New(), f.at(time.Hour)))
+		for status, unit := range map[domain.UnitStatus]*domain.Inventory{
+			domain.UnitStatusSold: units[1], domain.UnitStatusBlocked: units[2],
+		} {
+			got, err := repo.GetByID(f.ctx, unit.ID)
+			require.NoError(t, err)
+			got.Status = status
+			require.NoError(t, repo.Update(f.ctx, got))
+		}
+
+		require.NoError(t, f.b.Projects.RefreshUnitCounts(f.ctx, project.ID))
+		got, err := f.b.Projects.GetByID(f.ctx, project.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.AvailableUnits)
+		assert.Equal(t, 1, *got.AvailableUnits)
+		assert.Equal(t, 1, got.SoldUnits)
+		assert.Equal(t, 2, got.BlockedUnits, "reserved units count as blocked")
+
+		err = f.b.Projects.RefreshUnitCounts(f.ctx, uuid.New())
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "RefreshUnitCounts of a missing project: %v", err)
+	})
+}
@@ -0,0 +1,405 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunLeadRepository checks a domain.LeadRepository implementation
+func RunLeadRepository(t *testing.T, newBackend Factory) {
+	t.Run("CRUD", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Leads
+		owner := f.user("Owner", domain.RoleManager)
+
+		lead := &domain.Lead{
+			ID:           uuid.New(),
+			Name:         "Acme Corp",
+			Email:        ptr("buyer@acme.test"),
+			Source:       domain.LeadSourceWebsite,
+			Status:       domain.LeadStatusNew,
+			BudgetMin:    ptr(100000.0),
+			BudgetMax:    ptr(250000.0),
+			Score:        40,
+			Tags:         []string{"vip"},
+			NextFollowUp: ptr(f.at(24 * time.Hour)),
+			CreatedBy:    owner.ID,
+			CreatedAt:    f.now,
+			UpdatedAt:    f.now,
+		}
+		require.NoError(t, repo.Create(f.ctx, lead))
+
+		got, err := repo.GetByID(f.ctx, lead.ID)
+		require.NoError(t, err)
+		assert.Equal(t, lead.Name, got.Name)
+		assert.Equal(t, lead.Email, got.Email)
+		assert.Equal(t, lead.Source, got.Source)
+		assert.Equal(t, lead.Status, got.Status)
+		assert.Equal(t, lead.BudgetMin, got.BudgetMin)
+		assert.Equal(t, lead.BudgetMax, got.BudgetMax)
+		assert.Equal(t, lead.Score, got.Score)
+		assert.Equal(t, lead.Tags, got.Tags)
+		assert.Equal(t, lead.CreatedBy, got.CreatedBy)
+		require.NotNil(t, got.NextFollowUp)
+		assert.True(t, lead.NextFollowUp.Equal(*got.NextFollowUp))
+		assert.True(t, lead.CreatedAt.Equal(got.CreatedAt))
+
+		got.Status = domain.LeadStatusQualified
+		got.Score = 75
+		got.Tags = []string{"vip", "hot"}
+		require.NoError(t, repo.Update(f.ctx, got))
+
+		got, err = repo.GetByID(f.ctx, lead.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.LeadStatusQualified, got.Status)
+		assert.Equal(t, 75, got.Score)
+		assert.Equal(t, []string{"vip", "hot"}, got.Tags)
+
+		require.NoError(t, repo.Delete(f.ctx, lead.ID, lead.CreatedBy))
+		_, err = repo.GetByID(f.ctx, lead.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID after delete: %v", err)
+	})
+
+	t.Run("StaleUpdate", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Leads
+		lead := &domain.Lead{ID: uuid.New(), Name: "Acme Corp", Source: domain.LeadSourceWebsite,
+			Status: domain.LeadStatusNew, CreatedBy: f.user("Owner", domain.RoleManager).ID,
+			CreatedAt: f.now, UpdatedAt: f.now}
+		require.NoError(t, repo.Create(f.ctx, lead))
+
+		first, err := repo.GetByID(f.ctx, lead.ID)
+		require.NoError(t, err)
+		second, err := repo.GetByID(f.ctx, lead.ID)
+		require.NoError(t, err)
+
+		first.Score = 10
+		require.NoError(t, repo.Update(f.ctx, first))
+		second.Score = 20
+		err = repo.Update(f.ctx, second)
+		assert.True(t, errors.Is(err, domain.ErrConflict), "Update with a stale copy: %v", err)
+
+		// The winner's copy carries the new version and can be saved again
+		first.Score = 30
+		require.NoError(t, repo.Update(f.ctx, first))
+		got, err := repo.GetByID(f.ctx, lead.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 30, got.Score)
+		assert.True(t, first.UpdatedAt.Equal(got.UpdatedAt))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Leads
+		missing := &domain.Lead{ID: uuid.New(), Name: "Ghost", Source: domain.LeadSourceOther,
+			Status: domain.LeadStatusNew, CreatedBy: f.user("Owner", domain.RoleManager).ID}
+
+		_, err := repo.GetByID(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID: %v", err)
+		err = repo.Update(f.ctx, missing)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Update: %v", err)
+		err = repo.Delete(f.ctx, missing.ID, missing.CreatedBy)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete: %v", err)
+		err = repo.Restore(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Restore: %v", err)
+	})
+
+	t.Run("SoftDelete", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Leads
+		owner := f.user("Owner", domain.RoleManager)
+
+		kept := &domain.Lead{ID: uuid.New(), Name: "Acme", Source: domain.LeadSourceWebsite,
+			Status: domain.LeadStatusNew, CreatedBy: owner.ID, CreatedAt: f.now, UpdatedAt: f.now}
+		trashed := &domain.Lead{ID: uuid.New(), Name: "Globex", Source: domain.LeadSourceWebsite,
+			Status: domain.LeadStatusNew, CreatedBy: owner.ID, CreatedAt: f.now, UpdatedAt: f.now}
+		require.NoError(t, repo.Create(f.ctx, kept))
+		require.NoError(t, repo.Create(f.ctx, trashed))
+
+		require.NoError(t, repo.Delete(f.ctx, trashed.ID, owner.ID))
+		_, err := repo.GetByID(f.ctx, trashed.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID in trash: %v", err)
+		err = repo.Delete(f.ctx, trashed.ID, owner.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete twice: %v", err)
+		err = repo.Restore(f.ctx, kept.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Restore a live lead: %v", err)
+
+		live, err := repo.List(f.ctx, domain.LeadFilters{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Acme"}, leadNames(live))
+		count, err := repo.Count(f.ctx, domain.LeadFilters{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		all, err := repo.List(f.ctx, domain.LeadFilters{IncludeDeleted: true,
+			BaseFilters: domain.BaseFilters{SortBy: "name", SortOrder: "asc"}})
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		require.NotNil(t, all[1].DeletedAt)
+		require.NotNil(t, all[1].DeletedBy)
+		assert.Equal(t, owner.ID, *all[1].DeletedBy)
+		assert.Nil(t, all[0].DeletedAt)
+
+		require.NoError(t, repo.Restore(f.ctx, trashed.ID))
+		got, err := repo.GetByID(f.ctx, trashed.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got.DeletedAt)
+		assert.Nil(t, got.DeletedBy)
+
+		// Only leads trashed before the cutoff are purged
+		require.NoError(t, repo.Delete(f.ctx, trashed.ID, owner.ID))
+		purged, err := repo.Purge(f.ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 0, purged)
+		purged, err = repo.Purge(f.ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		all, err = repo.List(f.ctx, domain.LeadFilters{IncludeDeleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Acme"}, leadNames(all))
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Leads
+		manager := f.user("Manager", domain.RoleManager)
+		other := f.user("Other Manager", domain.RoleManager)
+		agent1 := f.user("Agent One", domain.RoleEmployee)
+		agent2 := f.user("Agent Two", domain.RoleEmployee)
+
+		leads := []*domain.Lead{
+			{Name: "Acme", Email: ptr("deals@acme.test"), Source: domain.LeadSourceWebsite, Status: domain.LeadStatusNew,
+				AssignedTo: &agent1.ID, BudgetMin: ptr(100000.0), BudgetMax: ptr(300000.0), Score: 80,
+				Tags: []string{"vip", "hot"}, CreatedBy: manager.ID, CreatedAt: f.at(-3 * time.Hour)},
+			{Name: "Globex", CompanyName: ptr("Globex Holdings"), Source: domain.LeadSourceReferral, Status: domain.LeadStatusQualified,
+				AssignedTo: &agent2.ID, BudgetMin: ptr(400000.0), BudgetMax: ptr(600000.0), Score: 20,
+				Tags: []string{"vip"}, CreatedBy: manager.ID, CreatedAt: f.at(-2 * time.Hour)},
+			{Name: "Initech", Requirements: ptr("Office near the metro"), Source: domain.LeadSourceWebsite, Status: domain.LeadStatusLost,
+				Score: 60, Tags: []string{"hot"}, CreatedBy: other.ID, CreatedAt: f.at(-time.Hour)},
+		}
+		for _, lead := range leads {
+			lead.ID = uuid.New()
+			lead.UpdatedAt = lead.CreatedAt
+			require.NoError(t, repo.Create(f.ctx, lead))
+		}
+
+		cases := []struct {
+			name    string
+			filters domain.LeadFilters
+			want    []string
+		}{
+			{"none", domain.LeadFilters{}, []string{"Acme", "Globex", "Initech"}},
+			{"status", domain.LeadFilters{Status: ptr(domain.LeadStatusNew)}, []string{"Acme"}},
+			{"source", domain.LeadFilters{Source: ptr(domain.LeadSourceWebsite)}, []string{"Acme", "Initech"}},
+			{"assigned_to", domain.LeadFilters{AssignedTo: &agent1.ID}, []string{"Acme"}},
+			{"created_by", domain.LeadFilters{CreatedBy: &other.ID}, []string{"Initech"}},
+			{"budget_min", domain.LeadFilters{BudgetMin: ptr(200000.0)}, []string{"Globex"}},
+			{"budget_max", domain.LeadFilters{BudgetMax: ptr(500000.0)}, []string{"Acme"}},
+			{"score_min", domain.LeadFilters{ScoreMin: ptr(50)}, []string{"Acme", "Initech"}},
+			{"score_max", domain.LeadFilters{ScoreMax: ptr(50)}, []string{"Globex"}},
+			{"score_range", domain.LeadFilters{ScoreMin: ptr(50), ScoreMax: ptr(70)}, []string{"Initech"}},
+			{"tag", domain.LeadFilters{Tags: []string{"vip"}}, []string{"Acme", "Globex"}},
+			{"all_tags", domain.LeadFilters{Tags: []string{"vip", "hot"}}, []string{"Acme"}},
+			{"search_name", domain.LeadFilters{Search: ptr("GLOB")}, []string{"Globex"}},
+			{"search_email", domain.LeadFilters{Search: ptr("deals@")}, []string{"Acme"}},
+			{"search_company", domain.LeadFilters{Search: ptr("holdings")}, []string{"Globex"}},
+			{"search_requirements", domain.LeadFilters{Search: ptr("metro")}, []string{"Initech"}},
+			{"created_after", domain.LeadFilters{CreatedAfter: ptr(f.at(-150 * time.Minute))}, []string{"Globex", "Initech"}},
+			{"created_before", domain.LeadFilters{CreatedBefore: ptr(f.at(-150 * time.Minute))}, []string{"Acme"}},
+			{"combined", domain.LeadFilters{Status: ptr(domain.LeadStatusNew), Tags: []string{"hot"}}, []string{"Acme"}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := repo.List(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.ElementsMatch(t, tc.want, leadNames(got))
+
+				count, err := repo.Count(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.Equal(t, len(tc.want), count)
+			})
+		}
+	})
+
+	t.Run("SortAndPaginate", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Leads
+		owner := f.user("Owner", domain.RoleManager)
+
+		for i, lead := range []*domain.Lead{
+			{Name: "Bravo", Score: 80, BudgetMax: ptr(300000.0)},
+			{Name: "Alpha", Score: 20, BudgetMax: ptr(600000.0)},
+			{Name: "Charlie", Score: 60},
+		} {
+			lead.ID = uuid.New()
+			lead.Source = domain.LeadSourceWebsite
+			lead.Status = domain.LeadStatusNew
+			lead.CreatedBy = owner.ID
+			lead.CreatedAt = f.at(time.Duration(i) * time.Hour)
+			lead.UpdatedAt = lead.CreatedAt
+			require.NoError(t, repo.Create(f.ctx, lead))
+		}
+
+		list := func(filters domain.LeadFilters) []string {
+			t.Helper()
+			got, err := repo.List(f.ctx, filters)
+			require.NoError(t, err)
+			return leadNames(got)
+		}
+		base := func(sortBy, sortOrder string, limit, offset int) domain.LeadFilters {
+			return domain.LeadFilters{BaseFilters: domain.BaseFilters{
+				SortBy: sortBy, SortOrder: sortOrder, Limit: limit, Offset: offset,
+			}}
+		}
+
+		assert.Equal(t, []string{"Charlie", "Alpha", "Bravo"}, list(base("", "", 0, 0)), "default is newest first")
+		assert.Equal(t, []string{"Charlie", "Alpha", "Bravo"}, list(base("name; DROP TABLE leads", "", 0, 0)),
+			"unknown sort columns fall back to the default")
+		assert.Equal(t, []string{"Alpha", "Bravo", "Charlie"}, list(base("name", "asc", 0, 0)))
+		assert.Equal(t, []string{"Alpha", "Bravo", "Charlie"}, list(base("name", "", 0, 0)), "ascending by default")
+		assert.Equal(t, []string{"Bravo", "Charlie", "Alpha"}, list(base("score", "desc", 0, 0)))
+		assert.Equal(t, []string{"Bravo", "Alpha", "Charlie"}, list(base("budget_max", "asc", 0, 0)), "nulls last")
+		assert.Equal(t, []string{"Alpha", "Bravo", "Charlie"}, list(base("budget_max", "desc", 0, 0)), "nulls last")
+
+		assert.Equal(t, []string{"Bravo", "Charlie"}, list(base("score", "desc", 2, 0)))
+		assert.Equal(t, []string{"Alpha"}, list(base("score", "desc", 2, 2)))
+		assert.Empty(t, list(base("score", "desc", 2, 10)))
+
+		count, err := repo.Count(f.ctx, base("score", "desc", 1, 1))
+		require.NoError(t, err)
+		assert.Equal(t, 3, count, "Count ignores limit and offset")
+	})
+
+	t.Run("CursorPagination", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Leads
+		owner := f.user("Owner", domain.RoleManager)
+
+		create := func(name string, createdAt time.Time) {
+			t.Helper()
+			require.NoError(t, repo.Create(f.ctx, &domain.Lead{ID: uuid.New(), Name: name,
+				Source: domain.LeadSourceWebsite, Status: domain.LeadStatusNew, CreatedBy: owner.ID,
+				CreatedAt: createdAt, UpdatedAt: createdAt}))
+		}
+		// Two leads share a timestamp so a page boundary falls between them
+		create("Alpha", f.at(-4*time.Hour))
+		create("Bravo", f.at(-3*time.Hour))
+		create("Charlie", f.at(-2*time.Hour))
+		create("Delta", f.at(-2*time.Hour))
+		create("Echo", f.at(-time.Hour))
+
+		page := func(base domain.BaseFilters) ([]string, *domain.Cursor) {
+			t.Helper()
+			got, err := repo.List(f.ctx, domain.LeadFilters{BaseFilters: base})
+			require.NoError(t, err)
+			if len(got) == 0 {
+				return nil, nil
+			}
+			cursor := got[len(got)-1].Cursor()
+			return leadNames(got), &cursor
+		}
+		walk := func(sortBy, sortOrder string, during func()) []string {
+			t.Helper()
+			var names []string
+			base := domain.BaseFilters{Limit: 2, SortBy: sortBy, SortOrder: sortOrder}
+			for {
+				got, next := page(base)
+				names = append(names, got...)
+				if len(got) < base.Limit {
+					return names
+				}
+				if during != nil {
+					during()
+					during = nil
+				}
+				base.Cursor = next
+			}
+		}
+
+		newestFirst, _ := page(domain.BaseFilters{})
+		oldestFirst, _ := page(domain.BaseFilters{SortBy: "created_at", SortOrder: "asc"})
+		require.Len(t, newestFirst, 5)
+		assert.Equal(t, "Echo", newestFirst[0])
+		assert.Equal(t, "Alpha", oldestFirst[0])
+
+		// A lead added while paging does not shift the remaining pages
+		assert.Equal(t, newestFirst, walk("", "", func() { create("Newcomer", f.now) }))
+		assert.Equal(t, append(oldestFirst, "Newcomer"), walk("created_at", "asc", nil))
+
+		// The cursor replaces the offset
+		_, after := page(domain.BaseFilters{Limit: 3})
+		got, _ := page(domain.BaseFilters{Limit: 2, Offset: 10, Cursor: after})
+		assert.Equal(t, newestFirst[2:4], got)
+
+		count, err := repo.Count(f.ctx, domain.LeadFilters{BaseFilters: domain.BaseFilters{Cursor: after}})
+		require.NoError(t, err)
+		assert.Equal(t, 6, count, "Count ignores the cursor")
+	})
+
+	t.Run("GetByAssignedUser", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Leads
+		owner := f.user("Owner", domain.RoleManager)
+		agent := f.user("Agent", domain.RoleEmployee)
+
+		for _, lead := range []*domain.Lead{
+			{Name: "Mine", AssignedTo: &agent.ID},
+			{Name: "Unassigned"},
+			{Name: "Also mine", AssignedTo: &agent.ID},
+		} {
+			lead.ID = uuid.New()
+			lead.Source = domain.LeadSourceWebsite
+			lead.Status = domain.LeadStatusNew
+			lead.CreatedBy = owner.ID
+			lead.CreatedAt = f.now
+			lead.UpdatedAt = f.now
+			require.NoError(t, repo.Create(f.ctx, lead))
+		}
+
+		got, err := repo.GetByAssignedUser(f.ctx, agent.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"Mine", "Also mine"}, leadNames(got))
+
+		got, err = repo.GetByAssignedUser(f.ctx, uuid.New())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("GetOverdueFollowUps", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Leads
+		owner := f.user("Owner", domain.RoleManager)
+
+		for _, lead := range []*domain.Lead{
+			{Name: "Overdue", Status: domain.LeadStatusContacted, NextFollowUp: ptr(f.at(-time.Hour))},
+			{Name: "Most overdue", Status: domain.LeadStatusNew, NextFollowUp: ptr(f.at(-48 * time.Hour))},
+			{Name: "Upcoming", Status: domain.LeadStatusNew, NextFollowUp: ptr(f.at(24 * time.Hour))},
+			{Name: "Unscheduled", Status: domain.LeadStatusNew},
+			{Name: "Converted", Status: domain.LeadStatusConverted, NextFollowUp: ptr(f.at(-time.Hour))},
+			{Name: "Lost", Status: domain.LeadStatusLost, NextFollowUp: ptr(f.at(-time.Hour))},
+		} {
+			lead.ID = uuid.New()
+			lead.Source = domain.LeadSourceWebsite
+			lead.CreatedBy = owner.ID
+			lead.CreatedAt = f.now
+			lead.UpdatedAt = f.now
+			require.NoError(t, repo.Create(f.ctx, lead))
+		}
+
+		got, err := repo.GetOverdueFollowUps(f.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Most overdue", "Overdue"}, leadNames(got))
+	})
+}
+
+func leadNames(leads []*domain.Lead) []string {
+	return namesOf(leads, func(l *domain.Lead) string { return l.Name })
+}
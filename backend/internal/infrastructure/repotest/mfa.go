@@ -0,0 +1,93 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunMFARepository checks a domain.MFARepository implementation
+func RunMFARepository(t *testing.T, newBackend Factory) {
+	t.Run("Enrollment", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.MFA
+		user := f.user("Ana", domain.RoleManager)
+
+		_, err := repo.GetByUser(f.ctx, user.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "no authenticator yet: %v", err)
+
+		require.NoError(t, repo.Save(f.ctx, &domain.MFAEnrollment{UserID: user.ID, Secret: "FIRSTSECRET"}))
+		require.NoError(t, repo.Save(f.ctx, &domain.MFAEnrollment{UserID: user.ID, Secret: "SECONDSECRET"}))
+		got, err := repo.GetByUser(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "SECONDSECRET", got.Secret, "saving again replaces the authenticator")
+		assert.Nil(t, got.ConfirmedAt)
+
+		got.ConfirmedAt = ptr(f.now)
+		got.LastStep = 100
+		require.NoError(t, repo.Save(f.ctx, got))
+		got, err = repo.GetByUser(f.ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.ConfirmedAt)
+		assert.True(t, f.now.Equal(*got.ConfirmedAt))
+		assert.Equal(t, int64(100), got.LastStep)
+
+		err = repo.UseStep(f.ctx, user.ID, 100, f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "step already used: %v", err)
+		err = repo.UseStep(f.ctx, user.ID, 99, f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "earlier step: %v", err)
+		require.NoError(t, repo.UseStep(f.ctx, user.ID, 101, f.at(time.Minute)))
+		got, err = repo.GetByUser(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(101), got.LastStep)
+
+		require.NoError(t, repo.Delete(f.ctx, user.ID))
+		_, err = repo.GetByUser(f.ctx, user.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByUser after delete: %v", err)
+		err = repo.Delete(f.ctx, user.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete twice: %v", err)
+	})
+
+	t.Run("RecoveryCodes", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.MFA
+		user := f.user("Ana", domain.RoleManager)
+		other := f.user("Ben", domain.RoleManager)
+		require.NoError(t, repo.Save(f.ctx, &domain.MFAEnrollment{UserID: user.ID, Secret: "SECRET"}))
+
+		require.NoError(t, repo.ReplaceRecoveryCodes(f.ctx, user.ID, []string{"hash-a", "hash-b"}, f.now))
+		require.NoError(t, repo.ReplaceRecoveryCodes(f.ctx, other.ID, []string{"hash-c"}, f.now))
+		count, err := repo.CountRecoveryCodes(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		err = repo.UseRecoveryCode(f.ctx, user.ID, "hash-c", f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "another user's code: %v", err)
+		require.NoError(t, repo.UseRecoveryCode(f.ctx, user.ID, "hash-a", f.now))
+		err = repo.UseRecoveryCode(f.ctx, user.ID, "hash-a", f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "code already used: %v", err)
+		count, err = repo.CountRecoveryCodes(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		require.NoError(t, repo.ReplaceRecoveryCodes(f.ctx, user.ID, []string{"hash-d", "hash-e", "hash-f"}, f.now))
+		err = repo.UseRecoveryCode(f.ctx, user.ID, "hash-b", f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "replaced code: %v", err)
+		count, err = repo.CountRecoveryCodes(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		require.NoError(t, repo.Delete(f.ctx, user.ID))
+		count, err = repo.CountRecoveryCodes(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Zero(t, count, "deleting the authenticator removes its codes")
+		count, err = repo.CountRecoveryCodes(f.ctx, other.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
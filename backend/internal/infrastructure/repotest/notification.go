@@ -0,0 +1,107 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunNotificationRepository checks a domain.NotificationRepository implementation
+func RunNotificationRepository(t *testing.T, newBackend Factory) {
+	t.Run("CRUD", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Notifications
+		user := f.user("Recipient", domain.RoleEmployee)
+
+		notification := &domain.Notification{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			Type:      domain.NotificationTypeSystem,
+			Title:     "Welcome",
+			Message:   "Your account is ready",
+			Data:      map[string]interface{}{"source": "signup"},
+			CreatedAt: f.now,
+		}
+		require.NoError(t, repo.Create(f.ctx, notification))
+
+		got, err := repo.GetByID(f.ctx, notification.ID)
+		require.NoError(t, err)
+		assert.Equal(t, notification.UserID, got.UserID)
+		assert.Equal(t, notification.Type, got.Type)
+		assert.Equal(t, notification.Title, got.Title)
+		assert.Equal(t, notification.Data, got.Data)
+		assert.False(t, got.IsRead)
+
+		got.Message = "Your account is ready to use"
+		require.NoError(t, repo.Update(f.ctx, got))
+		got, err = repo.GetByID(f.ctx, notification.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Your account is ready to use", got.Message)
+
+		require.NoError(t, repo.Delete(f.ctx, notification.ID))
+		_, err = repo.GetByID(f.ctx, notification.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID after delete: %v", err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Notifications
+		missing := &domain.Notification{ID: uuid.New(), UserID: f.user("Recipient", domain.RoleUser).ID,
+			Type: domain.NotificationTypeSystem, Title: "Ghost"}
+
+		_, err := repo.GetByID(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID: %v", err)
+		err = repo.Update(f.ctx, missing)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Update: %v", err)
+		err = repo.MarkAsRead(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "MarkAsRead: %v", err)
+		err = repo.Delete(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete: %v", err)
+	})
+
+	t.Run("ReadState", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Notifications
+		user := f.user("Recipient", domain.RoleEmployee)
+		other := f.user("Someone else", domain.RoleEmployee)
+
+		var ids []uuid.UUID
+		for i, title := range []string{"First", "Second", "Third"} {
+			notification := &domain.Notification{ID: uuid.New(), UserID: user.ID, Type: domain.NotificationTypeSystem,
+				Title: title, CreatedAt: f.at(time.Duration(i) * time.Minute)}
+			require.NoError(t, repo.Create(f.ctx, notification))
+			ids = append(ids, notification.ID)
+		}
+		require.NoError(t, repo.Create(f.ctx, &domain.Notification{ID: uuid.New(), UserID: other.ID,
+			Type: domain.NotificationTypeSystem, Title: "Other", CreatedAt: f.now}))
+
+		got, err := repo.GetByUser(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Third", "Second", "First"},
+			namesOf(got, func(n *domain.Notification) string { return n.Title }), "newest first")
+
+		count, err := repo.GetUnreadCount(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		require.NoError(t, repo.MarkAsRead(f.ctx, ids[0]))
+		count, err = repo.GetUnreadCount(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		require.NoError(t, repo.MarkAllAsRead(f.ctx, user.ID))
+		count, err = repo.GetUnreadCount(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		count, err = repo.GetUnreadCount(f.ctx, other.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count, "MarkAllAsRead only touches the given user")
+	})
+}
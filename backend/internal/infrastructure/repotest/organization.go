@@ -0,0 +1,175 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunOrganizationRepository checks a domain.OrganizationRepository
+// implementation and the tenant scoping of the CRM repositories
+func RunOrganizationRepository(t *testing.T, newBackend Factory) {
+	t.Run("CRUD", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Organizations
+
+		org := f.organization("Acme Realty")
+
+		got, err := repo.GetBySlug(f.ctx, org.Slug)
+		require.NoError(t, err)
+		assert.Equal(t, org.ID, got.ID)
+		assert.Equal(t, "Acme Realty", got.Name)
+		assert.True(t, got.IsActive)
+
+		got.Name = "Acme Realty Group"
+		require.NoError(t, repo.Update(f.ctx, got))
+
+		got, err = repo.GetByID(f.ctx, org.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Acme Realty Group", got.Name)
+	})
+
+	t.Run("TenantIsolation", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		owner := f.user("Owner", domain.RoleManager)
+		orgA := f.organization("Alpha Homes")
+		orgB := f.organization("Beta Estates")
+		ctxA := domain.WithOrganization(f.ctx, orgA.ID)
+		ctxB := domain.WithOrganization(f.ctx, orgB.ID)
+
+		lead := &domain.Lead{
+			ID:        uuid.New(),
+			Name:      "Alpha Buyer",
+			Source:    domain.LeadSourceWebsite,
+			Status:    domain.LeadStatusNew,
+			CreatedBy: owner.ID,
+			CreatedAt: f.now,
+			UpdatedAt: f.now,
+		}
+		require.NoError(t, f.b.Leads.Create(ctxA, lead))
+		require.NotNil(t, lead.OrganizationID)
+		assert.Equal(t, orgA.ID, *lead.OrganizationID)
+
+		got, err := f.b.Leads.GetByID(ctxA, lead.ID)
+		require.NoError(t, err)
+		assert.Equal(t, lead.Name, got.Name)
+
+		_, err = f.b.Leads.GetByID(ctxB, lead.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID from another tenant: %v", err)
+
+		leads, err := f.b.Leads.List(ctxB, domain.LeadFilters{})
+		require.NoError(t, err)
+		assert.Empty(t, leads)
+
+		got.Name = "Hijacked"
+		err = f.b.Leads.Update(ctxB, got)
+		assert.Error(t, err, "Update from another tenant")
+
+		err = f.b.Leads.Delete(ctxB, lead.ID, owner.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete from another tenant: %v", err)
+
+		// A context lifted out of tenancy, such as a background job's, sees
+		// every tenant
+		got, err = f.b.Leads.GetByID(f.ctx, lead.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Alpha Buyer", got.Name)
+
+		// A context that lost its tenant sees nothing and writes nothing
+		lost := context.Background()
+		_, err = f.b.Leads.GetByID(lost, lead.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID without an organization: %v", err)
+		leads, err = f.b.Leads.List(lost, domain.LeadFilters{})
+		require.NoError(t, err)
+		assert.Empty(t, leads)
+		stray := &domain.Lead{ID: uuid.New(), Name: "Stray", Source: domain.LeadSourceWebsite,
+			Status: domain.LeadStatusNew, CreatedBy: owner.ID, CreatedAt: f.now, UpdatedAt: f.now}
+		err = f.b.Leads.Create(lost, stray)
+		assert.True(t, errors.Is(err, domain.ErrNoOrganization), "Create without an organization: %v", err)
+		_, err = f.b.Organizations.GetByID(lost, orgA.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID of an organization without one: %v", err)
+
+		_, err = f.b.Organizations.GetByID(ctxB, orgA.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID of another organization: %v", err)
+		orgs, err := f.b.Organizations.List(ctxA, domain.OrganizationFilters{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Alpha Homes"}, namesOf(orgs, func(o *domain.Organization) string { return o.Name }))
+	})
+
+	t.Run("PropertyTenantIsolation", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		owner := f.user("Owner", domain.RoleManager)
+		orgA := f.organization("Alpha Homes")
+		orgB := f.organization("Beta Estates")
+		ctxA := domain.WithOrganization(f.ctx, orgA.ID)
+		ctxB := domain.WithOrganization(f.ctx, orgB.ID)
+
+		company := &domain.Company{ID: uuid.New(), Name: "Alpha Builders", IsActive: true, CreatedAt: f.now, UpdatedAt: f.now}
+		require.NoError(t, f.b.Companies.Create(ctxA, company))
+		society := &domain.Society{ID: uuid.New(), Name: "Alpha Gardens", Location: "Pune", Amenities: []string{},
+			Images: []string{}, IsActive: true, CreatedBy: owner.ID, CreatedAt: f.now, UpdatedAt: f.now}
+		require.NoError(t, f.b.Societies.Create(ctxA, society))
+		project := &domain.Project{ID: uuid.New(), SocietyID: society.ID, Name: "Alpha Tower",
+			Status: domain.ProjectStatusPlanning, CreatedBy: owner.ID, CreatedAt: f.now, UpdatedAt: f.now}
+		require.NoError(t, f.b.Projects.Create(ctxA, project))
+		schedule := &domain.PaymentSchedule{ID: uuid.New(), SaleID: f.sale(ctxA, "SAL-ORG-1").ID, InstallmentNumber: 1,
+			DueDate: date(f.now.AddDate(0, 0, -1)), Amount: 1000, Status: domain.PaymentStatusPending,
+			CreatedAt: f.now, UpdatedAt: f.now}
+		require.NoError(t, f.b.PaymentSchedules.Create(ctxA, schedule))
+		for _, id := range []*uuid.UUID{company.OrganizationID, society.OrganizationID, project.OrganizationID, schedule.OrganizationID} {
+			require.NotNil(t, id)
+			assert.Equal(t, orgA.ID, *id)
+		}
+
+		_, err := f.b.Companies.GetByID(ctxB, company.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "company from another tenant: %v", err)
+		_, err = f.b.Companies.GetByName(ctxB, company.Name)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "company by name from another tenant: %v", err)
+		companies, err := f.b.Companies.List(ctxB, domain.CompanyFilters{})
+		require.NoError(t, err)
+		assert.Empty(t, companies)
+		stolen := *company
+		stolen.Name = "Hijacked"
+		assert.Error(t, f.b.Companies.Update(ctxB, &stolen), "company Update from another tenant")
+
+		_, err = f.b.Societies.GetByID(ctxB, society.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "society from another tenant: %v", err)
+		societies, err := f.b.Societies.List(ctxB, domain.SocietyFilters{})
+		require.NoError(t, err)
+		assert.Empty(t, societies)
+		err = f.b.Societies.Delete(ctxB, society.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "society Delete from another tenant: %v", err)
+
+		_, err = f.b.Projects.GetByID(ctxB, project.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "project from another tenant: %v", err)
+		projects, err := f.b.Projects.GetBySociety(ctxB, society.ID)
+		require.NoError(t, err)
+		assert.Empty(t, projects)
+		count, err := f.b.Projects.Count(ctxB, domain.ProjectFilters{})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+		err = f.b.Projects.Delete(ctxB, project.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "project Delete from another tenant: %v", err)
+
+		_, err = f.b.PaymentSchedules.GetByID(ctxB, schedule.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "installment from another tenant: %v", err)
+		schedules, err := f.b.PaymentSchedules.GetBySale(ctxB, schedule.SaleID)
+		require.NoError(t, err)
+		assert.Empty(t, schedules)
+		overdue, err := f.b.PaymentSchedules.GetOverduePayments(ctxB)
+		require.NoError(t, err)
+		assert.Empty(t, overdue)
+
+		got, err := f.b.Projects.GetByID(ctxA, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Alpha Tower", got.Name)
+		overdue, err = f.b.PaymentSchedules.GetOverduePayments(ctxA)
+		require.NoError(t, err)
+		assert.Len(t, overdue, 1)
+	})
+}
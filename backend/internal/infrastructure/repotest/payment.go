@@ -0,0 +1,132 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunPaymentScheduleRepository tests the payment schedule and schedule
+// revision repositories
+func RunPaymentScheduleRepository(t *testing.T, newBackend Factory) {
+	t.Run("Installments", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.PaymentSchedules
+		sale := f.sale(f.ctx, "SAL-PAY-1")
+
+		installment := func(number, dueInDays int, amount float64) *domain.PaymentSchedule {
+			schedule := &domain.PaymentSchedule{
+				ID:                uuid.New(),
+				SaleID:            sale.ID,
+				InstallmentNumber: number,
+				DueDate:           date(f.now.AddDate(0, 0, dueInDays)),
+				Amount:            amount,
+				Description:       ptr("Installment"),
+				Status:            domain.PaymentStatusPending,
+				CreatedAt:         f.now,
+				UpdatedAt:         f.now,
+			}
+			require.NoError(t, repo.Create(f.ctx, schedule))
+			return schedule
+		}
+		second := installment(2, 60, 25000)
+		first := installment(1, -30, 75000)
+
+		schedules, err := repo.GetBySale(f.ctx, sale.ID)
+		require.NoError(t, err)
+		require.Len(t, schedules, 2)
+		assert.Equal(t, first.ID, schedules[0].ID, "installment order")
+		assert.Equal(t, 75000.0, schedules[0].Amount)
+		assert.Equal(t, "Installment", *schedules[0].Description)
+
+		overdue, err := repo.GetOverduePayments(f.ctx)
+		require.NoError(t, err)
+		require.Len(t, overdue, 1)
+		assert.Equal(t, first.ID, overdue[0].ID)
+
+		first.Status = domain.PaymentStatusPaid
+		first.PaidAmount = first.Amount
+		require.NoError(t, repo.Update(f.ctx, first))
+		found, err := repo.GetByID(f.ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PaymentStatusPaid, found.Status)
+		assert.True(t, found.IsSettled())
+
+		overdue, err = repo.GetOverduePayments(f.ctx)
+		require.NoError(t, err)
+		assert.Empty(t, overdue, "paid installments are not overdue")
+
+		status := domain.PaymentStatusPending
+		pending, err := repo.List(f.ctx, domain.PaymentScheduleFilters{SaleID: &sale.ID, Status: &status})
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, second.ID, pending[0].ID)
+
+		require.NoError(t, repo.Delete(f.ctx, second.ID))
+		_, err = repo.GetByID(f.ctx, second.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "deleted installment: %v", err)
+		err = repo.Delete(f.ctx, second.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "deleting twice: %v", err)
+	})
+
+	t.Run("Revisions", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.PaymentRevisions
+		org := f.organization("Alpha Homes")
+		orgCtx := domain.WithOrganization(f.ctx, org.ID)
+		sale := f.sale(orgCtx, "SAL-PAY-2")
+
+		record := func(revision int, reason *string, previous []domain.PaymentInstallment) *domain.PaymentScheduleRevision {
+			entry := &domain.PaymentScheduleRevision{
+				ID:       uuid.New(),
+				SaleID:   sale.ID,
+				Revision: revision,
+				Plan:     domain.PaymentPlanTimeBased,
+				Reason:   reason,
+				Previous: previous,
+				Installments: []domain.PaymentInstallment{
+					{InstallmentNumber: revision, DueDate: date(f.now), Amount: 100000, Description: ptr("Installment 1 of 1")},
+				},
+				ChangedBy: sale.CreatedBy,
+				CreatedAt: f.at(0),
+			}
+			require.NoError(t, repo.Create(orgCtx, entry))
+			return entry
+		}
+		record(1, nil, nil)
+		second := record(2, ptr("Buyer asked for more time"), []domain.PaymentInstallment{
+			{InstallmentNumber: 1, DueDate: date(f.now), Amount: 100000},
+		})
+
+		revisions, err := repo.ListBySale(orgCtx, sale.ID)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.Equal(t, 1, revisions[0].Revision)
+		assert.Empty(t, revisions[0].Previous)
+		assert.Equal(t, second.ID, revisions[1].ID)
+		assert.Equal(t, &org.ID, revisions[1].OrganizationID)
+		assert.Equal(t, domain.PaymentPlanTimeBased, revisions[1].Plan)
+		require.NotNil(t, revisions[1].Reason)
+		assert.Equal(t, "Buyer asked for more time", *revisions[1].Reason)
+		require.Len(t, revisions[1].Previous, 1)
+		assert.Equal(t, 100000.0, revisions[1].Previous[0].Amount)
+		require.Len(t, revisions[1].Installments, 1)
+		assert.Equal(t, 2, revisions[1].Installments[0].InstallmentNumber)
+
+		other := domain.WithOrganization(f.ctx, f.organization("Beta Estates").ID)
+		revisions, err = repo.ListBySale(other, sale.ID)
+		require.NoError(t, err)
+		assert.Empty(t, revisions, "revisions are scoped to the organization")
+	})
+}
+
+// date drops the time of day, as due dates are stored as dates
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
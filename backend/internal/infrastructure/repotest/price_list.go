@@ -0,0 +1,85 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunPriceListRepository checks a domain.PriceListRepository implementation
+func RunPriceListRepository(t *testing.T, newBackend Factory) {
+	t.Run("VersionsAndEffectiveDating", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.PriceLists
+		owner := f.user("Owner", domain.RoleManager)
+		org := f.organization("Alpha Homes")
+		orgCtx := domain.WithOrganization(f.ctx, org.ID)
+		project := f.project("Tower", owner.ID)
+
+		notes := "launch pricing"
+		for i, priceList := range []*domain.PriceList{
+			{EffectiveFrom: f.at(-48 * time.Hour), BaseRate: 5000, Notes: &notes},
+			{EffectiveFrom: f.at(-24 * time.Hour), BaseRate: 5200, FloorRise: 25, FloorRiseFrom: 3,
+				FacingPremiums: map[string]float64{"East": 100}, PLCs: map[string]float64{"Corner": 150}},
+			// Announced ahead of time
+			{EffectiveFrom: f.at(24 * time.Hour), BaseRate: 5500},
+		} {
+			priceList.ID = uuid.New()
+			priceList.ProjectID = project.ID
+			priceList.Version = i + 1
+			priceList.CreatedBy = owner.ID
+			priceList.CreatedAt = f.at(time.Duration(i) * time.Minute)
+			require.NoError(t, repo.Create(orgCtx, priceList))
+			assert.Equal(t, &org.ID, priceList.OrganizationID)
+		}
+
+		got, err := repo.GetVersion(orgCtx, project.ID, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 5200.0, got.BaseRate)
+		assert.Equal(t, 25.0, got.FloorRise)
+		assert.Equal(t, 3, got.FloorRiseFrom)
+		assert.Equal(t, map[string]float64{"East": 100}, got.FacingPremiums)
+		assert.Equal(t, map[string]float64{"Corner": 150}, got.PLCs)
+		assert.True(t, f.at(-24*time.Hour).Equal(got.EffectiveFrom))
+		assert.Equal(t, owner.ID, got.CreatedBy)
+
+		got, err = repo.GetVersion(orgCtx, project.ID, 1)
+		require.NoError(t, err)
+		require.NotNil(t, got.Notes)
+		assert.Equal(t, notes, *got.Notes)
+		assert.Empty(t, got.FacingPremiums)
+
+		got, err = repo.GetEffective(orgCtx, project.ID, f.now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, got.Version, "version 3 is not in effect yet")
+		got, err = repo.GetEffective(orgCtx, project.ID, f.at(-36*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, got.Version)
+		got, err = repo.GetEffective(orgCtx, project.ID, f.at(48*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 3, got.Version)
+
+		_, err = repo.GetEffective(orgCtx, project.ID, f.at(-72*time.Hour))
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "before the first version: %v", err)
+		_, err = repo.GetVersion(orgCtx, project.ID, 4)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "missing version: %v", err)
+
+		all, err := repo.ListVersions(orgCtx, project.ID)
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		assert.Equal(t, []int{3, 2, 1}, []int{all[0].Version, all[1].Version, all[2].Version}, "newest version first")
+
+		elsewhere := domain.WithOrganization(f.ctx, f.organization("Beta Estates").ID)
+		all, err = repo.ListVersions(elsewhere, project.ID)
+		require.NoError(t, err)
+		assert.Empty(t, all)
+		_, err = repo.GetEffective(elsewhere, project.ID, f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "another organization's price list: %v", err)
+	})
+}
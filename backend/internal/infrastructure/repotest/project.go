@@ -0,0 +1,146 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunProjectRepository checks a domain.ProjectRepository implementation
+func RunProjectRepository(t *testing.T, newBackend Factory) {
+	t.Run("CRUD", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Projects
+		owner := f.user("Owner", domain.RoleManager)
+		society := f.society(owner.ID)
+
+		project := &domain.Project{
+			ID:          uuid.New(),
+			SocietyID:   society.ID,
+			Name:        "Tower A",
+			ProjectType: ptr("residential"),
+			Status:      domain.ProjectStatusPlanning,
+			StartDate:   ptr(f.at(-30 * 24 * time.Hour)),
+			TotalUnits:  ptr(120),
+			BasePrice:   ptr(4500000.0),
+			Amenities:   []string{"Pool"},
+			NearbyFacilities: []domain.NearbyFacility{
+				{Name: "Metro", Category: "transport", Latitude: ptr(18.52), Longitude: ptr(73.85), DistanceKm: ptr(0.7)},
+			},
+			CreatedBy: owner.ID,
+			CreatedAt: f.now,
+			UpdatedAt: f.now,
+		}
+		require.NoError(t, repo.Create(f.ctx, project))
+
+		got, err := repo.GetByID(f.ctx, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, project.SocietyID, got.SocietyID)
+		assert.Equal(t, project.Name, got.Name)
+		assert.Equal(t, project.ProjectType, got.ProjectType)
+		assert.Equal(t, project.Status, got.Status)
+		assert.Equal(t, project.TotalUnits, got.TotalUnits)
+		assert.Equal(t, project.BasePrice, got.BasePrice)
+		assert.Equal(t, project.Amenities, got.Amenities)
+		assert.Equal(t, project.NearbyFacilities, got.NearbyFacilities)
+		require.NotNil(t, got.StartDate)
+		assert.True(t, project.StartDate.Equal(*got.StartDate))
+
+		got.Status = domain.ProjectStatusUnderConstruction
+		require.NoError(t, repo.Update(f.ctx, got))
+		got, err = repo.GetByID(f.ctx, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProjectStatusUnderConstruction, got.Status)
+
+		require.NoError(t, repo.Delete(f.ctx, project.ID))
+		_, err = repo.GetByID(f.ctx, project.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID after delete: %v", err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Projects
+		owner := f.user("Owner", domain.RoleManager)
+		missing := &domain.Project{ID: uuid.New(), SocietyID: f.society(owner.ID).ID, Name: "Ghost",
+			Status: domain.ProjectStatusPlanning, CreatedBy: owner.ID}
+
+		_, err := repo.GetByID(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID: %v", err)
+		err = repo.Update(f.ctx, missing)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Update: %v", err)
+		err = repo.Delete(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete: %v", err)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Projects
+		owner := f.user("Owner", domain.RoleManager)
+		other := f.user("Other", domain.RoleManager)
+		north := f.society(owner.ID)
+		south := f.society(owner.ID)
+
+		for i, project := range []*domain.Project{
+			{Name: "North Towers", SocietyID: north.ID, ProjectType: ptr("residential"), Status: domain.ProjectStatusPlanning,
+				BasePrice: ptr(3000000.0), Description: ptr("Affordable homes"), CreatedBy: owner.ID},
+			{Name: "North Villas", SocietyID: north.ID, ProjectType: ptr("residential"), Status: domain.ProjectStatusCompleted,
+				BasePrice: ptr(9000000.0), CreatedBy: other.ID},
+			{Name: "South Plaza", SocietyID: south.ID, ProjectType: ptr("commercial"), Status: domain.ProjectStatusPlanning,
+				CreatedBy: owner.ID},
+		} {
+			project.ID = uuid.New()
+			project.CreatedAt = f.at(time.Duration(i) * time.Hour)
+			project.UpdatedAt = project.CreatedAt
+			require.NoError(t, repo.Create(f.ctx, project))
+		}
+
+		cases := []struct {
+			name    string
+			filters domain.ProjectFilters
+			want    []string
+		}{
+			{"none", domain.ProjectFilters{}, []string{"North Towers", "North Villas", "South Plaza"}},
+			{"society_id", domain.ProjectFilters{SocietyID: &north.ID}, []string{"North Towers", "North Villas"}},
+			{"project_type", domain.ProjectFilters{ProjectType: ptr("commercial")}, []string{"South Plaza"}},
+			{"status", domain.ProjectFilters{Status: ptr(domain.ProjectStatusPlanning)}, []string{"North Towers", "South Plaza"}},
+			{"created_by", domain.ProjectFilters{CreatedBy: &other.ID}, []string{"North Villas"}},
+			{"price_min", domain.ProjectFilters{PriceMin: ptr(5000000.0)}, []string{"North Villas"}},
+			{"price_max", domain.ProjectFilters{PriceMax: ptr(5000000.0)}, []string{"North Towers"}},
+			{"search_name", domain.ProjectFilters{Search: ptr("plaza")}, []string{"South Plaza"}},
+			{"search_description", domain.ProjectFilters{Search: ptr("affordable")}, []string{"North Towers"}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := repo.List(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.ElementsMatch(t, tc.want, projectNames(got))
+
+				count, err := repo.Count(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.Equal(t, len(tc.want), count)
+			})
+		}
+
+		got, err := repo.List(f.ctx, domain.ProjectFilters{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"South Plaza", "North Villas", "North Towers"}, projectNames(got), "default is newest first")
+
+		got, err = repo.List(f.ctx, domain.ProjectFilters{BaseFilters: domain.BaseFilters{SortBy: "base_price", SortOrder: "desc", Limit: 2}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"North Villas", "North Towers"}, projectNames(got))
+
+		got, err = repo.GetBySociety(f.ctx, north.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"North Towers", "North Villas"}, projectNames(got))
+	})
+}
+
+func projectNames(projects []*domain.Project) []string {
+	return namesOf(projects, func(p *domain.Project) string { return p.Name })
+}
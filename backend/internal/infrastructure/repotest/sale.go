@@ -0,0 +1,267 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunSaleRepository checks a domain.SaleRepository implementation
+func RunSaleRepository(t *testing.T, newBackend Factory) {
+	t.Run("CRUD", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Sales
+		owner := f.user("Owner", domain.RoleManager)
+		client := f.client("Buyer", owner.ID)
+		unit := f.unit(f.project("Tower A", owner.ID).ID, owner.ID)
+
+		sale := &domain.Sale{
+			ID:            uuid.New(),
+			SaleNumber:    "SAL-CRUD-1",
+			ClientID:      client.ID,
+			InventoryID:   unit.ID,
+			SalespersonID: &owner.ID,
+			SaleDate:      f.now,
+			Status:        domain.SaleStatusDraft,
+			TotalAmount:   500000,
+			FinalAmount:   480000,
+			BookingAmount: ptr(50000.0),
+			PaymentPlan:   domain.CustomFields{"installments": float64(3)},
+			CreatedBy:     owner.ID,
+			CreatedAt:     f.now,
+			UpdatedAt:     f.now,
+		}
+		require.NoError(t, repo.Create(f.ctx, sale))
+		err := repo.Create(f.ctx, sale)
+		assert.True(t, errors.Is(err, domain.ErrAlreadyExists), "duplicate Create: %v", err)
+
+		got, err := repo.GetByID(f.ctx, sale.ID)
+		require.NoError(t, err)
+		assert.Equal(t, sale.SaleNumber, got.SaleNumber)
+		assert.Equal(t, sale.ClientID, got.ClientID)
+		assert.Equal(t, sale.InventoryID, got.InventoryID)
+		assert.Equal(t, sale.SalespersonID, got.SalespersonID)
+		assert.Equal(t, sale.Status, got.Status)
+		assert.Equal(t, sale.TotalAmount, got.TotalAmount)
+		assert.Equal(t, sale.FinalAmount, got.FinalAmount)
+		assert.Equal(t, sale.BookingAmount, got.BookingAmount)
+		assert.Equal(t, sale.PaymentPlan, got.PaymentPlan)
+		assert.True(t, sale.SaleDate.Equal(got.SaleDate))
+
+		got.DiscountAmount = 30000
+		got.FinalAmount = 470000
+		require.NoError(t, repo.Update(f.ctx, got))
+		require.NoError(t, repo.UpdateStatus(f.ctx, sale.ID, domain.SaleStatusDraft, domain.SaleStatusApproved))
+		err = repo.UpdateStatus(f.ctx, sale.ID, domain.SaleStatusDraft, domain.SaleStatusPending)
+		assert.True(t, errors.Is(err, domain.ErrConflict), "UpdateStatus from a stale status: %v", err)
+
+		got, err = repo.GetByID(f.ctx, sale.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 470000.0, got.FinalAmount)
+		assert.Equal(t, domain.SaleStatusApproved, got.Status)
+
+		require.NoError(t, repo.Delete(f.ctx, sale.ID, sale.CreatedBy))
+		_, err = repo.GetByID(f.ctx, sale.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID after delete: %v", err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Sales
+		owner := f.user("Owner", domain.RoleManager)
+		missing := &domain.Sale{ID: uuid.New(), SaleNumber: "SAL-GHOST", ClientID: f.client("Buyer", owner.ID).ID,
+			InventoryID: f.unit(f.project("Tower", owner.ID).ID, owner.ID).ID, SaleDate: f.now,
+			Status: domain.SaleStatusDraft, CreatedBy: owner.ID}
+
+		_, err := repo.GetByID(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID: %v", err)
+		err = repo.Update(f.ctx, missing)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Update: %v", err)
+		err = repo.UpdateStatus(f.ctx, missing.ID, domain.SaleStatusDraft, domain.SaleStatusApproved)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "UpdateStatus: %v", err)
+		err = repo.Delete(f.ctx, missing.ID, missing.CreatedBy)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete: %v", err)
+	})
+
+	t.Run("StatusHistory", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.SaleHistory
+		owner := f.user("Owner", domain.RoleManager)
+		org := f.organization("Alpha Homes")
+		orgCtx := domain.WithOrganization(f.ctx, org.ID)
+
+		sale := &domain.Sale{ID: uuid.New(), SaleNumber: "SAL-HIST-1", ClientID: f.client("Buyer", owner.ID).ID,
+			InventoryID: f.unit(f.project("Tower", owner.ID).ID, owner.ID).ID, SaleDate: f.now,
+			Status: domain.SaleStatusDraft, TotalAmount: 100000, FinalAmount: 100000, CreatedBy: owner.ID}
+		require.NoError(t, f.b.Sales.Create(orgCtx, sale))
+
+		for i, step := range []struct {
+			from, to domain.SaleStatus
+			reason   *string
+		}{
+			{domain.SaleStatusDraft, domain.SaleStatusPending, nil},
+			{domain.SaleStatusPending, domain.SaleStatusCancelled, ptr("Buyer withdrew")},
+		} {
+			require.NoError(t, repo.Create(orgCtx, &domain.SaleStatusHistory{
+				ID:         uuid.New(),
+				SaleID:     sale.ID,
+				FromStatus: step.from,
+				ToStatus:   step.to,
+				ChangedBy:  owner.ID,
+				Reason:     step.reason,
+				CreatedAt:  f.at(time.Duration(i) * time.Minute),
+			}))
+		}
+
+		history, err := repo.ListBySale(orgCtx, sale.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, domain.SaleStatusDraft, history[0].FromStatus, "oldest first")
+		assert.Equal(t, domain.SaleStatusPending, history[0].ToStatus)
+		assert.Nil(t, history[0].Reason)
+		assert.Equal(t, domain.SaleStatusCancelled, history[1].ToStatus)
+		assert.Equal(t, ptr("Buyer withdrew"), history[1].Reason)
+		assert.Equal(t, owner.ID, history[1].ChangedBy)
+		assert.Equal(t, &org.ID, history[1].OrganizationID)
+		assert.True(t, f.at(time.Minute).Equal(history[1].CreatedAt))
+
+		other := domain.WithOrganization(f.ctx, f.organization("Beta Estates").ID)
+		history, err = repo.ListBySale(other, sale.ID)
+		require.NoError(t, err)
+		assert.Empty(t, history)
+
+		history, err = repo.ListBySale(orgCtx, uuid.New())
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+
+	t.Run("QueriesAndStats", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Sales
+		manager := f.user("Manager", domain.RoleManager)
+		agent1 := f.user("Agent One", domain.RoleEmployee)
+		agent2 := f.user("Agent Two", domain.RoleEmployee)
+		client1 := f.client("Client One", manager.ID)
+		client2 := f.client("Client Two", manager.ID)
+		project1 := f.project("Tower A", manager.ID)
+		project2 := f.project("Tower B", manager.ID)
+
+		// Stats bucket revenue by the start of the current month and year in
+		// local time; keep sale dates well clear of those boundaries.
+		local := time.Now()
+		monthStart := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, local.Location())
+		yearStart := time.Date(local.Year(), 1, 1, 0, 0, 0, 0, local.Location())
+		lastYear := yearStart.Add(-24 * time.Hour)
+
+		for i, sale := range []*domain.Sale{
+			{SaleNumber: "SAL-001", ClientID: client1.ID, InventoryID: f.unit(project1.ID, manager.ID).ID,
+				SalespersonID: &agent1.ID, ManagerID: &manager.ID, Status: domain.SaleStatusPending,
+				TotalAmount: 100000, FinalAmount: 95000, SaleDate: lastYear.Add(-10 * 24 * time.Hour)},
+			{SaleNumber: "SAL-002", ClientID: client2.ID, InventoryID: f.unit(project1.ID, manager.ID).ID,
+				SalespersonID: &agent2.ID, ManagerID: &manager.ID, Status: domain.SaleStatusApproved,
+				TotalAmount: 250000, FinalAmount: 250000, SaleDate: lastYear},
+			{SaleNumber: "SAL-003", ClientID: client1.ID, InventoryID: f.unit(project2.ID, manager.ID).ID,
+				SalespersonID: &agent1.ID, Status: domain.SaleStatusCompleted,
+				TotalAmount: 400000, FinalAmount: 380000, SaleDate: monthStart.Add(time.Minute)},
+			{SaleNumber: "SAL-004", ClientID: client2.ID, InventoryID: f.unit(project2.ID, manager.ID).ID,
+				SalespersonID: &agent2.ID, Status: domain.SaleStatusCancelled,
+				TotalAmount: 50000, FinalAmount: 50000, SaleDate: monthStart.Add(2 * time.Minute)},
+		} {
+			sale.ID = uuid.New()
+			sale.SaleDate = sale.SaleDate.UTC()
+			sale.CreatedBy = manager.ID
+			sale.CreatedAt = f.at(time.Duration(i) * time.Hour)
+			sale.UpdatedAt = sale.CreatedAt
+			require.NoError(t, repo.Create(f.ctx, sale))
+		}
+
+		cases := []struct {
+			name    string
+			filters domain.SaleFilters
+			want    []string
+		}{
+			{"none", domain.SaleFilters{}, []string{"SAL-001", "SAL-002", "SAL-003", "SAL-004"}},
+			{"client_id", domain.SaleFilters{ClientID: &client1.ID}, []string{"SAL-001", "SAL-003"}},
+			{"salesperson_id", domain.SaleFilters{SalespersonID: &agent2.ID}, []string{"SAL-002", "SAL-004"}},
+			{"manager_id", domain.SaleFilters{ManagerID: &manager.ID}, []string{"SAL-001", "SAL-002"}},
+			{"status", domain.SaleFilters{Status: ptr(domain.SaleStatusCompleted)}, []string{"SAL-003"}},
+			{"amount_min", domain.SaleFilters{AmountMin: ptr(200000.0)}, []string{"SAL-002", "SAL-003"}},
+			{"amount_max", domain.SaleFilters{AmountMax: ptr(100000.0)}, []string{"SAL-001", "SAL-004"}},
+			{"sale_date_from", domain.SaleFilters{SaleDateFrom: &yearStart}, []string{"SAL-003", "SAL-004"}},
+			{"sale_date_to", domain.SaleFilters{SaleDateTo: &lastYear}, []string{"SAL-001", "SAL-002"}},
+			{"project_id", domain.SaleFilters{ProjectID: &project2.ID}, []string{"SAL-003", "SAL-004"}},
+			{"search", domain.SaleFilters{Search: ptr("003")}, []string{"SAL-003"}},
+			{"combined", domain.SaleFilters{ClientID: &client1.ID, ProjectID: &project1.ID}, []string{"SAL-001"}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := repo.List(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.ElementsMatch(t, tc.want, saleNumbers(got))
+
+				count, err := repo.Count(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.Equal(t, len(tc.want), count)
+			})
+		}
+
+		list := func(filters domain.SaleFilters) []string {
+			t.Helper()
+			got, err := repo.List(f.ctx, filters)
+			require.NoError(t, err)
+			return saleNumbers(got)
+		}
+		assert.Equal(t, []string{"SAL-004", "SAL-003", "SAL-002", "SAL-001"}, list(domain.SaleFilters{}), "default is newest first")
+		assert.Equal(t, []string{"SAL-003", "SAL-002", "SAL-001", "SAL-004"},
+			list(domain.SaleFilters{BaseFilters: domain.BaseFilters{SortBy: "total_amount", SortOrder: "desc"}}))
+		assert.Equal(t, []string{"SAL-002", "SAL-003"},
+			list(domain.SaleFilters{BaseFilters: domain.BaseFilters{SortBy: "sale_number", Limit: 2, Offset: 1}}))
+
+		got, err := repo.GetByClient(f.ctx, client1.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"SAL-001", "SAL-003"}, saleNumbers(got))
+
+		got, err = repo.GetBySalesperson(f.ctx, agent1.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"SAL-001", "SAL-003"}, saleNumbers(got))
+
+		stats, err := repo.GetSalesStats(f.ctx, domain.SaleFilters{})
+		require.NoError(t, err)
+		assert.Equal(t, 4, stats.TotalSales)
+		assert.InDelta(t, 775000, stats.TotalRevenue, 0.01)
+		assert.InDelta(t, 193750, stats.AverageValue, 0.01)
+		assert.Equal(t, 1, stats.PendingSales)
+		assert.Equal(t, 1, stats.ApprovedSales)
+		assert.Equal(t, 1, stats.CompletedSales)
+		assert.Equal(t, 1, stats.CancelledSales)
+		assert.InDelta(t, 430000, stats.MonthlyRevenue, 0.01)
+		assert.InDelta(t, 430000, stats.YearlyRevenue, 0.01)
+
+		stats, err = repo.GetSalesStats(f.ctx, domain.SaleFilters{
+			SalespersonID: &agent1.ID,
+			BaseFilters:   domain.BaseFilters{Limit: 1},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.TotalSales, "stats ignore pagination")
+		assert.InDelta(t, 475000, stats.TotalRevenue, 0.01)
+		assert.InDelta(t, 237500, stats.AverageValue, 0.01)
+		assert.Equal(t, 1, stats.PendingSales)
+		assert.Equal(t, 1, stats.CompletedSales)
+		assert.InDelta(t, 380000, stats.MonthlyRevenue, 0.01)
+
+		stats, err = repo.GetSalesStats(f.ctx, domain.SaleFilters{ClientID: ptr(uuid.New())})
+		require.NoError(t, err)
+		assert.Equal(t, 0, stats.TotalSales)
+		assert.Zero(t, stats.AverageValue)
+	})
+}
+
+func saleNumbers(sales []*domain.Sale) []string {
+	return namesOf(sales, func(s *domain.Sale) string { return s.SaleNumber })
+}
@@ -0,0 +1,161 @@
+package repotest
+
+import (
+	"testing"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// searchFixture holds the records the search tests look for
+type searchFixture struct {
+	*fixture
+	priya   *domain.Lead
+	rahul   *domain.Lead
+	client  *domain.Client
+	company *domain.Company
+	unit    *domain.Inventory
+}
+
+func newSearchFixture(t *testing.T, newBackend Factory) *searchFixture {
+	t.Helper()
+	f := &searchFixture{fixture: newFixture(t, newBackend)}
+	if f.b.Search == nil {
+		t.Skip("backend has no search repository")
+	}
+	owner := f.user("Owner", domain.RoleManager)
+
+	lead := func(name string, phone, notes *string) *domain.Lead {
+		lead := &domain.Lead{
+			ID:        uuid.New(),
+			Name:      name,
+			Phone:     phone,
+			Notes:     notes,
+			Source:    domain.LeadSourceWebsite,
+			Status:    domain.LeadStatusNew,
+			CreatedBy: owner.ID,
+			CreatedAt: f.now,
+			UpdatedAt: f.now,
+		}
+		require.NoError(t, f.b.Leads.Create(f.ctx, lead))
+		return lead
+	}
+	f.priya = lead("Priya Sharma", ptr("+91 98765 43210"), ptr("Wants a sea facing flat"))
+	f.rahul = lead("Rahul Verma", nil, ptr("Referred by Priya"))
+	f.client = f.fixture.client("Priya Kapoor", owner.ID)
+	f.company = f.fixture.company("Sharma Builders")
+
+	f.unit = &domain.Inventory{
+		ID:         uuid.New(),
+		ProjectID:  f.project("Sea Breeze", owner.ID).ID,
+		UnitNumber: "PH1204",
+		Status:     domain.UnitStatusAvailable,
+		Features:   []string{},
+		CreatedBy:  owner.ID,
+		CreatedAt:  f.now,
+		UpdatedAt:  f.now,
+	}
+	require.NoError(t, f.b.Inventory.Create(f.ctx, f.unit))
+	return f
+}
+
+// search runs a search and returns the hits by ID
+func (f *searchFixture) search(query domain.SearchQuery) ([]*domain.SearchHit, map[uuid.UUID]*domain.SearchHit) {
+	f.t.Helper()
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+	hits, err := f.b.Search.Search(f.ctx, query)
+	require.NoError(f.t, err)
+	byID := make(map[uuid.UUID]*domain.SearchHit, len(hits))
+	for _, hit := range hits {
+		byID[hit.ID] = hit
+	}
+	return hits, byID
+}
+
+// RunSearchRepository checks a domain.SearchRepository implementation
+func RunSearchRepository(t *testing.T, newBackend Factory) {
+	t.Run("FullText", func(t *testing.T) {
+		f := newSearchFixture(t, newBackend)
+
+		hits, byID := f.search(domain.SearchQuery{Text: "priya"})
+		require.Contains(t, byID, f.priya.ID)
+		require.Contains(t, byID, f.client.ID)
+		require.Contains(t, byID, f.rahul.ID)
+		assert.NotContains(t, byID, f.company.ID)
+
+		assert.Equal(t, domain.SearchEntityLead, byID[f.priya.ID].Type)
+		assert.Equal(t, "Priya Sharma", byID[f.priya.ID].Title)
+		assert.Equal(t, domain.SearchEntityClient, byID[f.client.ID].Type)
+		require.NotNil(t, byID[f.priya.ID].Highlight)
+		assert.Contains(t, *byID[f.priya.ID].Highlight, "<b>Priya</b>")
+
+		// A match in the name outranks one in the notes
+		assert.Greater(t, byID[f.priya.ID].Score, byID[f.rahul.ID].Score)
+		for i := 1; i < len(hits); i++ {
+			assert.GreaterOrEqual(t, hits[i-1].Score, hits[i].Score, "hits are ordered by score")
+		}
+	})
+
+	t.Run("Fuzzy", func(t *testing.T) {
+		f := newSearchFixture(t, newBackend)
+
+		_, byID := f.search(domain.SearchQuery{Text: "sharme"})
+		assert.Contains(t, byID, f.priya.ID)
+		assert.Contains(t, byID, f.company.ID)
+		assert.NotContains(t, byID, f.rahul.ID)
+	})
+
+	t.Run("Phone", func(t *testing.T) {
+		f := newSearchFixture(t, newBackend)
+
+		_, byID := f.search(domain.SearchQuery{Text: "98765 432"})
+		assert.Contains(t, byID, f.priya.ID)
+		assert.Len(t, byID, 1)
+	})
+
+	t.Run("Inventory", func(t *testing.T) {
+		f := newSearchFixture(t, newBackend)
+
+		hits, _ := f.search(domain.SearchQuery{Text: "ph1204"})
+		require.Len(t, hits, 1)
+		assert.Equal(t, domain.SearchEntityInventory, hits[0].Type)
+		assert.Equal(t, f.unit.ID, hits[0].ID)
+		assert.Equal(t, "PH1204", hits[0].Title)
+		require.NotNil(t, hits[0].Subtitle)
+		assert.Contains(t, *hits[0].Subtitle, "Sea Breeze")
+	})
+
+	t.Run("TypesAndLimit", func(t *testing.T) {
+		f := newSearchFixture(t, newBackend)
+
+		hits, _ := f.search(domain.SearchQuery{Text: "priya", Types: []domain.SearchEntityType{domain.SearchEntityClient}})
+		require.Len(t, hits, 1)
+		assert.Equal(t, f.client.ID, hits[0].ID)
+
+		// Both Priyas match by name and outrank the lead mentioning her
+		hits, _ = f.search(domain.SearchQuery{Text: "priya", Limit: 2})
+		require.Len(t, hits, 2)
+		assert.ElementsMatch(t, []uuid.UUID{f.priya.ID, f.client.ID}, []uuid.UUID{hits[0].ID, hits[1].ID})
+	})
+
+	t.Run("SkipsDeletedAndOtherTenants", func(t *testing.T) {
+		f := newSearchFixture(t, newBackend)
+
+		require.NoError(t, f.b.Leads.Delete(f.ctx, f.rahul.ID, f.rahul.CreatedBy))
+		_, byID := f.search(domain.SearchQuery{Text: "priya"})
+		assert.NotContains(t, byID, f.rahul.ID)
+
+		org := f.organization("Other Realty")
+		f.ctx = domain.WithOrganization(f.ctx, org.ID)
+		_, byID = f.search(domain.SearchQuery{Text: "priya"})
+		assert.NotContains(t, byID, f.priya.ID)
+		assert.NotContains(t, byID, f.client.ID)
+		_, byID = f.search(domain.SearchQuery{Text: "sharma builders"})
+		assert.NotContains(t, byID, f.company.ID)
+	})
+}
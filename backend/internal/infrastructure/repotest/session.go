@@ -0,0 +1,119 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunSessionRepository checks a domain.SessionRepository implementation
+func RunSessionRepository(t *testing.T, newBackend Factory) {
+	newSession := func(f *fixture, userID uuid.UUID, hash string, lastUsed time.Duration) *domain.Session {
+		device := "Laptop"
+		session := &domain.Session{
+			ID:         uuid.New(),
+			UserID:     userID,
+			DeviceName: &device,
+			TokenHash:  hash,
+			ExpiresAt:  f.at(24 * time.Hour),
+			LastUsedAt: f.at(lastUsed),
+			CreatedAt:  f.at(-time.Hour),
+		}
+		require.NoError(t, f.b.Sessions.Create(f.ctx, session))
+		return session
+	}
+
+	t.Run("RotateAndList", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Sessions
+		user := f.user("Ana", domain.RoleEmployee)
+		older := newSession(f, user.ID, "hash-a", -30*time.Minute)
+		newer := newSession(f, user.ID, "hash-b", -10*time.Minute)
+		newSession(f, f.user("Ben", domain.RoleEmployee).ID, "hash-c", 0)
+
+		got, err := repo.GetByID(f.ctx, older.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, got.UserID)
+		assert.Equal(t, "hash-a", got.TokenHash)
+		require.NotNil(t, got.DeviceName)
+		assert.Equal(t, "Laptop", *got.DeviceName)
+		assert.Nil(t, got.RevokedAt)
+
+		active, err := repo.ListActive(f.ctx, user.ID, f.now)
+		require.NoError(t, err)
+		require.Len(t, active, 2)
+		assert.Equal(t, newer.ID, active[0].ID, "most recently used first")
+
+		require.NoError(t, repo.Rotate(f.ctx, older.ID, "hash-a", "hash-a2", f.at(48*time.Hour), f.now))
+		got, err = repo.GetByID(f.ctx, older.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "hash-a2", got.TokenHash)
+		assert.Equal(t, 1, got.Rotations)
+		assert.True(t, f.at(48*time.Hour).Equal(got.ExpiresAt))
+		assert.True(t, f.now.Equal(got.LastUsedAt))
+
+		err = repo.Rotate(f.ctx, older.ID, "hash-a", "hash-a3", f.at(48*time.Hour), f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "replaced token: %v", err)
+		err = repo.Rotate(f.ctx, newer.ID, "hash-b", "hash-b2", f.at(48*time.Hour), f.at(25*time.Hour))
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "expired session: %v", err)
+		_, err = repo.GetByID(f.ctx, uuid.New())
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "missing session: %v", err)
+
+		active, err = repo.ListActive(f.ctx, user.ID, f.now)
+		require.NoError(t, err)
+		require.Len(t, active, 2)
+		assert.Equal(t, older.ID, active[0].ID, "rotation marks the session used")
+
+		active, err = repo.ListActive(f.ctx, user.ID, f.at(36*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, active, 1, "the other session has expired")
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Sessions
+		user := f.user("Ana", domain.RoleEmployee)
+		other := f.user("Ben", domain.RoleEmployee)
+		first := newSession(f, user.ID, "hash-a", 0)
+		newSession(f, user.ID, "hash-b", 0)
+		kept := newSession(f, other.ID, "hash-c", 0)
+
+		require.NoError(t, repo.Revoke(f.ctx, first.ID, domain.RevokeReasonLogout, f.now))
+		got, err := repo.GetByID(f.ctx, first.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.RevokedAt)
+		require.NotNil(t, got.RevokedReason)
+		assert.Equal(t, domain.RevokeReasonLogout, *got.RevokedReason)
+		assert.False(t, got.Active(f.now))
+
+		require.NoError(t, repo.Revoke(f.ctx, first.ID, domain.RevokeReasonAdmin, f.at(time.Minute)))
+		got, err = repo.GetByID(f.ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RevokeReasonLogout, *got.RevokedReason, "a revoked session keeps its reason")
+		assert.True(t, f.now.Equal(*got.RevokedAt))
+
+		err = repo.Revoke(f.ctx, uuid.New(), domain.RevokeReasonLogout, f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "missing session: %v", err)
+
+		err = repo.Rotate(f.ctx, first.ID, "hash-a", "hash-a2", f.at(48*time.Hour), f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "revoked session: %v", err)
+
+		revoked, err := repo.RevokeByUser(f.ctx, user.ID, domain.RevokeReasonAdmin, f.now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, revoked, "only the active session is revoked")
+
+		active, err := repo.ListActive(f.ctx, user.ID, f.now)
+		require.NoError(t, err)
+		assert.Empty(t, active)
+		active, err = repo.ListActive(f.ctx, other.ID, f.now)
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, kept.ID, active[0].ID)
+	})
+}
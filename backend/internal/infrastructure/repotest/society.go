@@ -0,0 +1,134 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunSocietyRepository checks a domain.SocietyRepository implementation
+func RunSocietyRepository(t *testing.T, newBackend Factory) {
+	t.Run("CRUD", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Societies
+		owner := f.user("Owner", domain.RoleManager)
+
+		society := &domain.Society{
+			ID:            uuid.New(),
+			Name:          "Green Acres",
+			DeveloperName: ptr("Acme Builders"),
+			Location:      "Pune",
+			Address:       &domain.Address{City: "Pune", Country: "India"},
+			Latitude:      ptr(18.5204),
+			Longitude:     ptr(73.8567),
+			TotalArea:     ptr(12.5),
+			TotalUnits:    ptr(400),
+			Amenities:     []string{"Clubhouse", "Pool"},
+			Images:        []string{},
+			IsActive:      true,
+			CreatedBy:     owner.ID,
+			CreatedAt:     f.now,
+			UpdatedAt:     f.now,
+		}
+		require.NoError(t, repo.Create(f.ctx, society))
+
+		got, err := repo.GetByID(f.ctx, society.ID)
+		require.NoError(t, err)
+		assert.Equal(t, society.Name, got.Name)
+		assert.Equal(t, society.DeveloperName, got.DeveloperName)
+		assert.Equal(t, society.Location, got.Location)
+		assert.Equal(t, society.Address, got.Address)
+		assert.Equal(t, society.Latitude, got.Latitude)
+		assert.Equal(t, society.Longitude, got.Longitude)
+		assert.Equal(t, society.TotalArea, got.TotalArea)
+		assert.Equal(t, society.TotalUnits, got.TotalUnits)
+		assert.Equal(t, society.Amenities, got.Amenities)
+		assert.Equal(t, society.CreatedBy, got.CreatedBy)
+
+		got.IsActive = false
+		require.NoError(t, repo.Update(f.ctx, got))
+		got, err = repo.GetByID(f.ctx, society.ID)
+		require.NoError(t, err)
+		assert.False(t, got.IsActive)
+
+		require.NoError(t, repo.Delete(f.ctx, society.ID))
+		_, err = repo.GetByID(f.ctx, society.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID after delete: %v", err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Societies
+		owner := f.user("Owner", domain.RoleManager)
+		missing := &domain.Society{ID: uuid.New(), Name: "Ghost", Location: "Nowhere", CreatedBy: owner.ID}
+
+		_, err := repo.GetByID(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID: %v", err)
+		err = repo.Update(f.ctx, missing)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Update: %v", err)
+		err = repo.Delete(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete: %v", err)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Societies
+		owner := f.user("Owner", domain.RoleManager)
+		other := f.user("Other", domain.RoleManager)
+
+		for i, society := range []*domain.Society{
+			{Name: "Green Acres", Location: "Pune West", DeveloperName: ptr("Acme Builders"), IsActive: true, CreatedBy: owner.ID},
+			{Name: "Blue Bay", Location: "Mumbai", Description: ptr("Sea facing towers"), IsActive: true, CreatedBy: other.ID},
+			{Name: "Old Mill", Location: "Pune East", IsActive: false, CreatedBy: owner.ID},
+		} {
+			society.ID = uuid.New()
+			society.Amenities = []string{}
+			society.Images = []string{}
+			society.CreatedAt = f.at(time.Duration(i) * time.Hour)
+			society.UpdatedAt = society.CreatedAt
+			require.NoError(t, repo.Create(f.ctx, society))
+		}
+
+		cases := []struct {
+			name    string
+			filters domain.SocietyFilters
+			want    []string
+		}{
+			{"none", domain.SocietyFilters{}, []string{"Green Acres", "Blue Bay", "Old Mill"}},
+			{"location", domain.SocietyFilters{Location: ptr("pune")}, []string{"Green Acres", "Old Mill"}},
+			{"is_active", domain.SocietyFilters{IsActive: ptr(false)}, []string{"Old Mill"}},
+			{"created_by", domain.SocietyFilters{CreatedBy: &other.ID}, []string{"Blue Bay"}},
+			{"search_developer", domain.SocietyFilters{Search: ptr("acme")}, []string{"Green Acres"}},
+			{"search_description", domain.SocietyFilters{Search: ptr("sea facing")}, []string{"Blue Bay"}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := repo.List(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.ElementsMatch(t, tc.want, societyNames(got))
+
+				count, err := repo.Count(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.Equal(t, len(tc.want), count)
+			})
+		}
+
+		got, err := repo.List(f.ctx, domain.SocietyFilters{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Old Mill", "Blue Bay", "Green Acres"}, societyNames(got), "default is newest first")
+
+		got, err = repo.List(f.ctx, domain.SocietyFilters{BaseFilters: domain.BaseFilters{SortBy: "name", SortOrder: "asc", Limit: 2}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Blue Bay", "Green Acres"}, societyNames(got))
+	})
+}
+
+func societyNames(societies []*domain.Society) []string {
+	return namesOf(societies, func(s *domain.Society) string { return s.Name })
+}
@@ -0,0 +1,250 @@
+// Package repotest is a conformance suite for implementations of the
+// repository interfaces in the domain package. Every data backend runs the
+// same tests so that filters, sorting and error mapping behave identically
+// whichever backend the API is configured with.
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// Backend bundles the repositories of one data backend under test
+type Backend struct {
+	Users              domain.UserRepository
+	Sessions           domain.SessionRepository
+	AuthTokens         domain.AuthTokenRepository
+	MFA                domain.MFARepository
+	AuthEvents         domain.AuthEventRepository
+	Wallets            domain.WalletRepository
+	SIWENonces         domain.SIWENonceRepository
+	Leads              domain.LeadRepository
+	Clients            domain.ClientRepository
+	Companies          domain.CompanyRepository
+	Societies          domain.SocietyRepository
+	Projects           domain.ProjectRepository
+	Inventory          domain.InventoryRepository
+	Interests          domain.PropertyInterestRepository
+	PriceLists         domain.PriceListRepository
+	Sales              domain.SaleRepository
+	SaleHistory        domain.SaleStatusHistoryRepository
+	AgreementTemplates domain.AgreementTemplateRepository
+	Agreements         domain.AgreementRepository
+	PaymentSchedules   domain.PaymentScheduleRepository
+	PaymentRevisions   domain.PaymentScheduleRevisionRepository
+	Commissions        domain.CommissionRepository
+	CommissionPlans    domain.CommissionPlanRepository
+	Tasks              domain.TaskRepository
+	Notifications      domain.NotificationRepository
+	Organizations      domain.OrganizationRepository
+
+	// Search is optional: backends whose search runs in a database function
+	// the suite cannot reach leave it nil and skip the search tests.
+	Search domain.SearchRepository
+}
+
+// Factory returns a backend with no data. It is called once per test.
+type Factory func(t *testing.T) *Backend
+
+// Run runs the whole conformance suite against backends built by newBackend
+func Run(t *testing.T, newBackend Factory) {
+	t.Run("UserRepository", func(t *testing.T) { RunUserRepository(t, newBackend) })
+	t.Run("SessionRepository", func(t *testing.T) { RunSessionRepository(t, newBackend) })
+	t.Run("AuthTokenRepository", func(t *testing.T) { RunAuthTokenRepository(t, newBackend) })
+	t.Run("MFARepository", func(t *testing.T) { RunMFARepository(t, newBackend) })
+	t.Run("AuthEventRepository", func(t *testing.T) { RunAuthEventRepository(t, newBackend) })
+	t.Run("WalletRepository", func(t *testing.T) { RunWalletRepository(t, newBackend) })
+	t.Run("SIWENonceRepository", func(t *testing.T) { RunSIWENonceRepository(t, newBackend) })
+	t.Run("LeadRepository", func(t *testing.T) { RunLeadRepository(t, newBackend) })
+	t.Run("ClientRepository", func(t *testing.T) { RunClientRepository(t, newBackend) })
+	t.Run("CompanyRepository", func(t *testing.T) { RunCompanyRepository(t, newBackend) })
+	t.Run("SocietyRepository", func(t *testing.T) { RunSocietyRepository(t, newBackend) })
+	t.Run("ProjectRepository", func(t *testing.T) { RunProjectRepository(t, newBackend) })
+	t.Run("InventoryRepository", func(t *testing.T) { RunInventoryRepository(t, newBackend) })
+	t.Run("PropertyInterestRepository", func(t *testing.T) { RunPropertyInterestRepository(t, newBackend) })
+	t.Run("PriceListRepository", func(t *testing.T) { RunPriceListRepository(t, newBackend) })
+	t.Run("SaleRepository", func(t *testing.T) { RunSaleRepository(t, newBackend) })
+	t.Run("AgreementRepository", func(t *testing.T) { RunAgreementRepository(t, newBackend) })
+	t.Run("PaymentScheduleRepository", func(t *testing.T) { RunPaymentScheduleRepository(t, newBackend) })
+	t.Run("CommissionRepository", func(t *testing.T) { RunCommissionRepository(t, newBackend) })
+	t.Run("TaskRepository", func(t *testing.T) { RunTaskRepository(t, newBackend) })
+	t.Run("NotificationRepository", func(t *testing.T) { RunNotificationRepository(t, newBackend) })
+	t.Run("OrganizationRepository", func(t *testing.T) { RunOrganizationRepository(t, newBackend) })
+	t.Run("SearchRepository", func(t *testing.T) { RunSearchRepository(t, newBackend) })
+}
+
+// fixture creates related rows for a test. Its context sees every
+// organization, and timestamps are truncated to whole seconds so they
+// survive every backend's storage precision.
+type fixture struct {
+	t   *testing.T
+	ctx context.Context
+	b   *Backend
+	now time.Time
+	seq int
+}
+
+func newFixture(t *testing.T, newBackend Factory) *fixture {
+	t.Helper()
+	return &fixture{
+		t:   t,
+		ctx: domain.AcrossOrganizations(context.Background()),
+		b:   newBackend(t),
+		now: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// at returns a time offset from the fixture's reference time
+func (f *fixture) at(d time.Duration) time.Time {
+	return f.now.Add(d)
+}
+
+// user creates an active user with a unique email and username
+func (f *fixture) user(name string, role domain.UserRole) *domain.User {
+	f.t.Helper()
+	f.seq++
+
+	user := &domain.User{
+		ID:        uuid.New(),
+		Email:     uuid.NewString()[:8] + "@example.com",
+		Username:  uuid.NewString()[:8],
+		FullName:  name,
+		Role:      role,
+		IsActive:  true,
+		CreatedAt: f.at(time.Duration(f.seq) * time.Minute),
+		UpdatedAt: f.at(time.Duration(f.seq) * time.Minute),
+	}
+	require.NoError(f.t, f.b.Users.Create(f.ctx, user))
+	return user
+}
+
+// organization creates an active organization with a unique slug
+func (f *fixture) organization(name string) *domain.Organization {
+	f.t.Helper()
+
+	organization := &domain.Organization{
+		ID:        uuid.New(),
+		Name:      name,
+		Slug:      uuid.NewString()[:8],
+		IsActive:  true,
+		CreatedAt: f.now,
+		UpdatedAt: f.now,
+	}
+	require.NoError(f.t, f.b.Organizations.Create(f.ctx, organization))
+	return organization
+}
+
+// company creates an active company
+func (f *fixture) company(name string) *domain.Company {
+	f.t.Helper()
+
+	company := &domain.Company{
+		ID:        uuid.New(),
+		Name:      name,
+		IsActive:  true,
+		CreatedAt: f.now,
+		UpdatedAt: f.now,
+	}
+	require.NoError(f.t, f.b.Companies.Create(f.ctx, company))
+	return company
+}
+
+// society creates an active society
+func (f *fixture) society(createdBy uuid.UUID) *domain.Society {
+	f.t.Helper()
+
+	society := &domain.Society{
+		ID:        uuid.New(),
+		Name:      "Society " + uuid.NewString()[:8],
+		Location:  "Pune",
+		Amenities: []string{},
+		Images:    []string{},
+		IsActive:  true,
+		CreatedBy: createdBy,
+		CreatedAt: f.now,
+		UpdatedAt: f.now,
+	}
+	require.NoError(f.t, f.b.Societies.Create(f.ctx, society))
+	return society
+}
+
+// project creates a project in a new society
+func (f *fixture) project(name string, createdBy uuid.UUID) *domain.Project {
+	f.t.Helper()
+
+	project := &domain.Project{
+		ID:        uuid.New(),
+		SocietyID: f.society(createdBy).ID,
+		Name:      name,
+		Status:    domain.ProjectStatusPlanning,
+		CreatedBy: createdBy,
+		CreatedAt: f.now,
+		UpdatedAt: f.now,
+	}
+	require.NoError(f.t, f.b.Projects.Create(f.ctx, project))
+	return project
+}
+
+// unit creates an available inventory unit
+func (f *fixture) unit(projectID, createdBy uuid.UUID) *domain.Inventory {
+	f.t.Helper()
+
+	unit := &domain.Inventory{
+		ID:         uuid.New(),
+		ProjectID:  projectID,
+		UnitNumber: uuid.NewString()[:8],
+		Status:     domain.UnitStatusAvailable,
+		Features:   []string{},
+		CreatedBy:  createdBy,
+		CreatedAt:  f.now,
+		UpdatedAt:  f.now,
+	}
+	require.NoError(f.t, f.b.Inventory.Create(f.ctx, unit))
+	return unit
+}
+
+// client creates an individual client
+func (f *fixture) client(name string, createdBy uuid.UUID) *domain.Client {
+	f.t.Helper()
+
+	client := &domain.Client{
+		ID:         uuid.New(),
+		ClientType: domain.ClientTypeIndividual,
+		Name:       name,
+		CreatedBy:  createdBy,
+		CreatedAt:  f.now,
+		UpdatedAt:  f.now,
+	}
+	require.NoError(f.t, f.b.Clients.Create(f.ctx, client))
+	return client
+}
+
+// sale creates a pending sale of a new unit in the organization of ctx
+func (f *fixture) sale(ctx context.Context, number string) *domain.Sale {
+	f.t.Helper()
+	owner := f.user("Owner", domain.RoleManager)
+	sale := &domain.Sale{ID: uuid.New(), SaleNumber: number, ClientID: f.client("Buyer", owner.ID).ID,
+		InventoryID: f.unit(f.project("Tower", owner.ID).ID, owner.ID).ID, SaleDate: f.now,
+		Status: domain.SaleStatusPending, TotalAmount: 100000, FinalAmount: 100000, CreatedBy: owner.ID}
+	require.NoError(f.t, f.b.Sales.Create(ctx, sale))
+	return sale
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// namesOf maps rows to a comparable key, preserving order
+func namesOf[T any](rows []*T, name func(*T) string) []string {
+	names := make([]string, len(rows))
+	for i, row := range rows {
+		names[i] = name(row)
+	}
+	return names
+}
@@ -0,0 +1,194 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunTaskRepository checks a domain.TaskRepository implementation
+func RunTaskRepository(t *testing.T, newBackend Factory) {
+	t.Run("CRUD", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Tasks
+		owner := f.user("Owner", domain.RoleManager)
+
+		task := &domain.Task{
+			ID:             uuid.New(),
+			Title:          "Call back",
+			Description:    ptr("Discuss pricing"),
+			AssignedTo:     &owner.ID,
+			Status:         domain.TaskStatusPending,
+			Priority:       domain.TaskPriorityHigh,
+			DueDate:        ptr(f.at(48 * time.Hour)),
+			EstimatedHours: ptr(1.5),
+			Tags:           []string{"phone"},
+			CreatedBy:      owner.ID,
+			CreatedAt:      f.now,
+			UpdatedAt:      f.now,
+		}
+		require.NoError(t, repo.Create(f.ctx, task))
+
+		got, err := repo.GetByID(f.ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, task.Title, got.Title)
+		assert.Equal(t, task.Description, got.Description)
+		assert.Equal(t, task.AssignedTo, got.AssignedTo)
+		assert.Equal(t, task.Status, got.Status)
+		assert.Equal(t, task.Priority, got.Priority)
+		assert.Equal(t, task.EstimatedHours, got.EstimatedHours)
+		assert.Equal(t, task.Tags, got.Tags)
+		require.NotNil(t, got.DueDate)
+		assert.True(t, task.DueDate.Equal(*got.DueDate))
+
+		got.Status = domain.TaskStatusCompleted
+		got.CompletedAt = ptr(f.at(time.Hour))
+		require.NoError(t, repo.Update(f.ctx, got))
+
+		got, err = repo.GetByID(f.ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusCompleted, got.Status)
+		require.NotNil(t, got.CompletedAt)
+		assert.True(t, f.at(time.Hour).Equal(*got.CompletedAt))
+
+		require.NoError(t, repo.Delete(f.ctx, task.ID, task.CreatedBy))
+		_, err = repo.GetByID(f.ctx, task.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID after delete: %v", err)
+	})
+
+	t.Run("StaleUpdate", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Tasks
+		owner := f.user("Owner", domain.RoleManager)
+		task := &domain.Task{ID: uuid.New(), Title: "Call back", Status: domain.TaskStatusPending,
+			Priority: domain.TaskPriorityMedium, CreatedBy: owner.ID, CreatedAt: f.now, UpdatedAt: f.now}
+		require.NoError(t, repo.Create(f.ctx, task))
+
+		first, err := repo.GetByID(f.ctx, task.ID)
+		require.NoError(t, err)
+		second, err := repo.GetByID(f.ctx, task.ID)
+		require.NoError(t, err)
+
+		first.Title = "Call back today"
+		require.NoError(t, repo.Update(f.ctx, first))
+		second.Title = "Email instead"
+		err = repo.Update(f.ctx, second)
+		assert.True(t, errors.Is(err, domain.ErrConflict), "Update with a stale copy: %v", err)
+
+		got, err := repo.GetByID(f.ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Call back today", got.Title)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Tasks
+		missing := &domain.Task{ID: uuid.New(), Title: "Ghost", Status: domain.TaskStatusPending,
+			Priority: domain.TaskPriorityLow, CreatedBy: f.user("Owner", domain.RoleManager).ID}
+
+		_, err := repo.GetByID(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID: %v", err)
+		err = repo.Update(f.ctx, missing)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Update: %v", err)
+		err = repo.Delete(f.ctx, missing.ID, missing.CreatedBy)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete: %v", err)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Tasks
+		manager := f.user("Manager", domain.RoleManager)
+		lead := uuid.New()
+		agent1 := f.user("Agent One", domain.RoleEmployee)
+		agent2 := f.user("Agent Two", domain.RoleEmployee)
+
+		for i, task := range []*domain.Task{
+			{Title: "Call Acme", Description: ptr("Confirm the site visit"), AssignedTo: &agent1.ID, AssignedBy: &manager.ID,
+				Status: domain.TaskStatusPending, Priority: domain.TaskPriorityHigh, RelatedToType: ptr("lead"), RelatedToID: &lead,
+				DueDate: ptr(f.at(-24 * time.Hour)), Tags: []string{"phone", "urgent"}},
+			{Title: "Draft agreement", AssignedTo: &agent2.ID, AssignedBy: &manager.ID,
+				Status: domain.TaskStatusInProgress, Priority: domain.TaskPriorityUrgent, RelatedToType: ptr("sale"), RelatedToID: ptr(uuid.New()),
+				DueDate: ptr(f.at(24 * time.Hour)), Tags: []string{"paperwork"}},
+			{Title: "Email brochure", AssignedTo: &agent1.ID,
+				Status: domain.TaskStatusCompleted, Priority: domain.TaskPriorityLow, RelatedToType: ptr("lead"), RelatedToID: &lead,
+				DueDate: ptr(f.at(-48 * time.Hour)), Tags: []string{"urgent"}},
+			{Title: "Clean up CRM", Status: domain.TaskStatusPending, Priority: domain.TaskPriorityLow, Tags: []string{}},
+		} {
+			task.ID = uuid.New()
+			task.CreatedBy = manager.ID
+			task.CreatedAt = f.at(time.Duration(i) * time.Hour)
+			task.UpdatedAt = task.CreatedAt
+			require.NoError(t, repo.Create(f.ctx, task))
+		}
+
+		cases := []struct {
+			name    string
+			filters domain.TaskFilters
+			want    []string
+		}{
+			{"none", domain.TaskFilters{}, []string{"Call Acme", "Draft agreement", "Email brochure", "Clean up CRM"}},
+			{"assigned_to", domain.TaskFilters{AssignedTo: &agent1.ID}, []string{"Call Acme", "Email brochure"}},
+			{"assigned_by", domain.TaskFilters{AssignedBy: &manager.ID}, []string{"Call Acme", "Draft agreement"}},
+			{"status", domain.TaskFilters{Status: ptr(domain.TaskStatusPending)}, []string{"Call Acme", "Clean up CRM"}},
+			{"priority", domain.TaskFilters{Priority: ptr(domain.TaskPriorityLow)}, []string{"Email brochure", "Clean up CRM"}},
+			{"related_to_type", domain.TaskFilters{RelatedToType: ptr("sale")}, []string{"Draft agreement"}},
+			{"related_to_id", domain.TaskFilters{RelatedToID: &lead}, []string{"Call Acme", "Email brochure"}},
+			{"due_date_from", domain.TaskFilters{DueDateFrom: ptr(f.at(-36 * time.Hour))}, []string{"Call Acme", "Draft agreement"}},
+			{"due_date_to", domain.TaskFilters{DueDateTo: ptr(f.at(-24 * time.Hour))}, []string{"Call Acme", "Email brochure"}},
+			{"tags", domain.TaskFilters{Tags: []string{"urgent"}}, []string{"Call Acme", "Email brochure"}},
+			{"all_tags", domain.TaskFilters{Tags: []string{"urgent", "phone"}}, []string{"Call Acme"}},
+			{"search_title", domain.TaskFilters{Search: ptr("AGREEMENT")}, []string{"Draft agreement"}},
+			{"search_description", domain.TaskFilters{Search: ptr("site visit")}, []string{"Call Acme"}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := repo.List(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.ElementsMatch(t, tc.want, taskTitles(got))
+
+				count, err := repo.Count(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.Equal(t, len(tc.want), count)
+			})
+		}
+
+		list := func(filters domain.TaskFilters) []string {
+			t.Helper()
+			got, err := repo.List(f.ctx, filters)
+			require.NoError(t, err)
+			return taskTitles(got)
+		}
+		assert.Equal(t, []string{"Clean up CRM", "Email brochure", "Draft agreement", "Call Acme"}, list(domain.TaskFilters{}),
+			"default is newest first")
+		assert.Equal(t, []string{"Email brochure", "Call Acme", "Draft agreement", "Clean up CRM"},
+			list(domain.TaskFilters{BaseFilters: domain.BaseFilters{SortBy: "due_date"}}), "nulls last")
+		assert.Equal(t, []string{"Draft agreement"},
+			list(domain.TaskFilters{BaseFilters: domain.BaseFilters{SortBy: "title", SortOrder: "desc", Limit: 1, Offset: 1}}))
+
+		got, err := repo.GetByAssignedUser(f.ctx, agent1.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"Call Acme", "Email brochure"}, taskTitles(got))
+
+		got, err = repo.GetTasksByRelatedEntity(f.ctx, "lead", lead)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"Call Acme", "Email brochure"}, taskTitles(got))
+
+		got, err = repo.GetTasksByRelatedEntity(f.ctx, "sale", lead)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		got, err = repo.GetOverdueTasks(f.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Call Acme"}, taskTitles(got), "completed and undated tasks are never overdue")
+	})
+}
+
+func taskTitles(tasks []*domain.Task) []string {
+	return namesOf(tasks, func(t *domain.Task) string { return t.Title })
+}
@@ -0,0 +1,187 @@
+package repotest
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunUserRepository checks a domain.UserRepository implementation
+func RunUserRepository(t *testing.T, newBackend Factory) {
+	t.Run("CRUD", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Users
+
+		user := &domain.User{
+			ID:           uuid.New(),
+			Email:        "jane@example.com",
+			Username:     "jane",
+			FullName:     "Jane Doe",
+			Bio:          ptr("Buyer"),
+			Role:         domain.RoleClient,
+			PasswordHash: "hash",
+			IsActive:     true,
+			CreatedAt:    f.now,
+			UpdatedAt:    f.now,
+		}
+		require.NoError(t, repo.Create(f.ctx, user))
+
+		got, err := repo.GetByID(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.Email, got.Email)
+		assert.Equal(t, user.Username, got.Username)
+		assert.Equal(t, user.FullName, got.FullName)
+		assert.Equal(t, user.Bio, got.Bio)
+		assert.Equal(t, user.Role, got.Role)
+		assert.Equal(t, user.PasswordHash, got.PasswordHash)
+		assert.True(t, got.IsActive)
+		assert.Nil(t, got.EmailVerifiedAt)
+		assert.False(t, got.MFAEnabled)
+		assert.Zero(t, got.FailedLoginAttempts)
+		assert.Nil(t, got.LockedUntil)
+
+		got, err = repo.GetByEmail(f.ctx, "jane@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, got.ID)
+
+		got, err = repo.GetByUsername(f.ctx, "jane")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, got.ID)
+
+		got.FullName = "Jane Smith"
+		got.LastLoginAt = ptr(f.at(time.Minute))
+		got.EmailVerifiedAt = ptr(f.at(30 * time.Second))
+		got.MFAEnabled = true
+		got.FailedLoginAttempts = 5
+		got.LastFailedLoginAt = ptr(f.at(10 * time.Second))
+		got.LockedUntil = ptr(f.at(time.Hour))
+		require.NoError(t, repo.Update(f.ctx, got))
+
+		got, err = repo.GetByID(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Jane Smith", got.FullName)
+		require.NotNil(t, got.LastLoginAt)
+		assert.True(t, f.at(time.Minute).Equal(*got.LastLoginAt))
+		require.NotNil(t, got.EmailVerifiedAt)
+		assert.True(t, f.at(30*time.Second).Equal(*got.EmailVerifiedAt))
+		assert.True(t, got.MFAEnabled)
+		assert.Equal(t, 5, got.FailedLoginAttempts)
+		require.NotNil(t, got.LastFailedLoginAt)
+		assert.True(t, f.at(10*time.Second).Equal(*got.LastFailedLoginAt))
+		require.NotNil(t, got.LockedUntil)
+		assert.True(t, f.at(time.Hour).Equal(*got.LockedUntil))
+
+		require.NoError(t, repo.Delete(f.ctx, user.ID))
+		_, err = repo.GetByID(f.ctx, user.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID after delete: %v", err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Users
+		missing := &domain.User{ID: uuid.New(), Email: "ghost@example.com", Username: "ghost", Role: domain.RoleUser}
+
+		_, err := repo.GetByID(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByID: %v", err)
+		_, err = repo.GetByEmail(f.ctx, missing.Email)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByEmail: %v", err)
+		_, err = repo.GetByUsername(f.ctx, missing.Username)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "GetByUsername: %v", err)
+		err = repo.Update(f.ctx, missing)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Update: %v", err)
+		err = repo.Delete(f.ctx, missing.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Delete: %v", err)
+	})
+
+	t.Run("FiltersSortAndPaginate", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Users
+
+		for i, user := range []*domain.User{
+			{FullName: "Carol Admin", Email: "carol@corp.test", Username: "carol", Role: domain.RoleAdmin, IsActive: true},
+			{FullName: "Alice Agent", Email: "alice@corp.test", Username: "alice", Role: domain.RoleEmployee, IsActive: true},
+			{FullName: "Bob Agent", Email: "bob@mail.test", Username: "bobby", Role: domain.RoleEmployee, IsActive: false},
+		} {
+			user.ID = uuid.New()
+			user.CreatedAt = f.at(time.Duration(i) * time.Hour)
+			user.UpdatedAt = user.CreatedAt
+			require.NoError(t, repo.Create(f.ctx, user))
+		}
+
+		cases := []struct {
+			name    string
+			filters domain.UserFilters
+			want    []string
+		}{
+			{"none", domain.UserFilters{}, []string{"Bob Agent", "Alice Agent", "Carol Admin"}},
+			{"role", domain.UserFilters{Role: ptr(domain.RoleEmployee)}, []string{"Bob Agent", "Alice Agent"}},
+			{"is_active", domain.UserFilters{IsActive: ptr(false)}, []string{"Bob Agent"}},
+			{"search_name", domain.UserFilters{Search: ptr("agent")}, []string{"Bob Agent", "Alice Agent"}},
+			{"search_email", domain.UserFilters{Search: ptr("@corp")}, []string{"Alice Agent", "Carol Admin"}},
+			{"search_username", domain.UserFilters{Search: ptr("bobby")}, []string{"Bob Agent"}},
+			{"sort", domain.UserFilters{BaseFilters: domain.BaseFilters{SortBy: "email"}}, []string{"Alice Agent", "Bob Agent", "Carol Admin"}},
+			{"page", domain.UserFilters{BaseFilters: domain.BaseFilters{SortBy: "username", SortOrder: "desc", Limit: 1, Offset: 1}},
+				[]string{"Bob Agent"}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := repo.List(f.ctx, tc.filters)
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, namesOf(got, func(u *domain.User) string { return u.FullName }))
+			})
+		}
+
+		count, err := repo.Count(f.ctx, domain.UserFilters{Role: ptr(domain.RoleEmployee), BaseFilters: domain.BaseFilters{Limit: 1}})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+	t.Run("FailedLogins", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Users
+		user := f.user("Guesser", domain.RoleUser)
+
+		// Concurrent failures are all counted
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.RecordFailedLogin(f.ctx, user.ID, f.now, f.at(-time.Minute))
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		got, err := repo.GetByID(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, got.FailedLoginAttempts)
+		require.NotNil(t, got.LastFailedLoginAt)
+		assert.True(t, f.now.Equal(*got.LastFailedLoginAt))
+
+		attempts, err := repo.RecordFailedLogin(f.ctx, user.ID, f.at(time.Second), f.at(-time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 6, attempts)
+		attempts, err = repo.RecordFailedLogin(f.ctx, user.ID, f.at(time.Hour), f.at(time.Hour-time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, attempts, "a failure after the window starts again")
+
+		require.NoError(t, repo.LockUntil(f.ctx, user.ID, f.at(2*time.Hour)))
+		require.NoError(t, repo.LockUntil(f.ctx, user.ID, f.at(time.Hour)))
+		got, err = repo.GetByID(f.ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.LockedUntil)
+		assert.True(t, f.at(2*time.Hour).Equal(*got.LockedUntil), "a shorter lock keeps the longer one")
+
+		_, err = repo.RecordFailedLogin(f.ctx, uuid.New(), f.now, f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "RecordFailedLogin of a missing user: %v", err)
+		err = repo.LockUntil(f.ctx, uuid.New(), f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "LockUntil of a missing user: %v", err)
+	})
+}
@@ -0,0 +1,104 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunWalletRepository checks a domain.WalletRepository implementation
+func RunWalletRepository(t *testing.T, newBackend Factory) {
+	newWallet := func(f *fixture, userID uuid.UUID, address string, createdAt time.Duration) *domain.Wallet {
+		wallet := &domain.Wallet{
+			ID:         uuid.New(),
+			UserID:     userID,
+			Address:    address,
+			WalletType: "metamask",
+			IsActive:   true,
+			CreatedAt:  f.at(createdAt),
+		}
+		require.NoError(t, f.b.Wallets.Create(f.ctx, wallet))
+		return wallet
+	}
+
+	t.Run("CRUD", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.Wallets
+		user := f.user("Ana", domain.RoleClient)
+		other := f.user("Ben", domain.RoleClient)
+
+		second := newWallet(f, user.ID, "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", -time.Minute)
+		first := newWallet(f, user.ID, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", -time.Hour)
+		newWallet(f, other.ID, "0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB", 0)
+
+		got, err := repo.GetByAddress(f.ctx, first.Address)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, got.ID)
+		assert.Equal(t, user.ID, got.UserID)
+		assert.Equal(t, "metamask", got.WalletType)
+		assert.True(t, got.IsActive)
+		assert.False(t, got.IsVerified)
+		assert.Nil(t, got.LastUsed)
+
+		_, err = repo.GetByAddress(f.ctx, "0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb")
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "unknown address: %v", err)
+
+		wallets, err := repo.ListByUser(f.ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, wallets, 2)
+		assert.Equal(t, []uuid.UUID{first.ID, second.ID}, []uuid.UUID{wallets[0].ID, wallets[1].ID}, "oldest first")
+
+		got.IsVerified = true
+		got.LastUsed = ptr(f.now)
+		require.NoError(t, repo.Update(f.ctx, got))
+		got, err = repo.GetByAddress(f.ctx, first.Address)
+		require.NoError(t, err)
+		assert.True(t, got.IsVerified)
+		require.NotNil(t, got.LastUsed)
+		assert.True(t, f.now.Equal(*got.LastUsed))
+
+		require.NoError(t, repo.Delete(f.ctx, second.ID))
+		wallets, err = repo.ListByUser(f.ctx, user.ID)
+		require.NoError(t, err)
+		assert.Len(t, wallets, 1)
+
+		err = repo.Delete(f.ctx, second.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "deleted twice: %v", err)
+		err = repo.Update(f.ctx, second)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "update of a deleted wallet: %v", err)
+	})
+}
+
+// RunSIWENonceRepository checks a domain.SIWENonceRepository implementation
+func RunSIWENonceRepository(t *testing.T, newBackend Factory) {
+	t.Run("ConsumeOnce", func(t *testing.T) {
+		f := newFixture(t, newBackend)
+		repo := f.b.SIWENonces
+
+		fresh := uuid.NewString()[:18]
+		stale := uuid.NewString()[:18]
+		for nonce, expiresIn := range map[string]time.Duration{fresh: time.Minute, stale: -time.Second} {
+			require.NoError(t, repo.Create(f.ctx, &domain.SIWENonce{
+				ID:        uuid.New(),
+				Nonce:     nonce,
+				ExpiresAt: f.at(expiresIn),
+				CreatedAt: f.at(-time.Minute),
+			}))
+		}
+
+		err := repo.Consume(f.ctx, stale, f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "expired nonce: %v", err)
+		err = repo.Consume(f.ctx, "never-issued", f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "unknown nonce: %v", err)
+
+		require.NoError(t, repo.Consume(f.ctx, fresh, f.now))
+		err = repo.Consume(f.ctx, fresh, f.now)
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "nonce already used: %v", err)
+	})
+}
@@ -0,0 +1,130 @@
+package supabase
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var agreementTracer = otel.Tracer("goreal-backend/infrastructure/supabase/agreement")
+
+// agreementRepository implements domain.AgreementRepository using Supabase
+type agreementRepository struct {
+	client *Client
+}
+
+// NewAgreementRepository creates a new agreement repository
+func NewAgreementRepository(client *Client) domain.AgreementRepository {
+	return &agreementRepository{
+		client: client,
+	}
+}
+
+// dbAgreement is a sale_agreements row. PostgREST exchanges bytea columns
+// as hex strings prefixed with "\x".
+type dbAgreement struct {
+	domain.Agreement
+	Content string `json:"content"`
+}
+
+func (a *dbAgreement) toDomain() (*domain.Agreement, error) {
+	content, err := hex.DecodeString(strings.TrimPrefix(a.Content, `\x`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode agreement content: %w", err)
+	}
+	agreement := a.Agreement
+	agreement.Content = content
+	return &agreement, nil
+}
+
+// Create stores a generated agreement
+func (r *agreementRepository) Create(ctx context.Context, agreement *domain.Agreement) error {
+	if err := domain.AssignOrganization(ctx, &agreement.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := agreementTracer.Start(ctx, "agreementRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", agreement.SaleID.String()),
+		attribute.String("agreement.format", string(agreement.Format)),
+		attribute.Int("agreement.size", len(agreement.Content)),
+	)
+
+	if agreement.CreatedAt.IsZero() {
+		agreement.CreatedAt = time.Now()
+	}
+
+	row := &dbAgreement{Agreement: *agreement, Content: `\x` + hex.EncodeToString(agreement.Content)}
+	err := r.client.ExecuteQuery(ctx, "insert", "sale_agreements", func() error {
+		return r.client.From("sale_agreements").Insert(row).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create agreement: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an agreement with its content
+func (r *agreementRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Agreement, error) {
+	ctx, span := agreementTracer.Start(ctx, "agreementRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("agreement.id", id.String()))
+
+	var row dbAgreement
+	err := r.client.ExecuteQuery(ctx, "select", "sale_agreements", func() error {
+		return r.client.scoped(ctx, "sale_agreements").
+			Select("*").
+			Eq("id", id).
+			Single(ctx, &row)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get agreement by ID: %w", err)
+	}
+
+	return row.toDomain()
+}
+
+// GetLatest returns the most recently generated agreement of a sale in a format
+func (r *agreementRepository) GetLatest(ctx context.Context, saleID uuid.UUID, format domain.AgreementFormat) (*domain.Agreement, error) {
+	ctx, span := agreementTracer.Start(ctx, "agreementRepository.GetLatest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", saleID.String()),
+		attribute.String("agreement.format", string(format)),
+	)
+
+	var row dbAgreement
+	err := r.client.ExecuteQuery(ctx, "select", "sale_agreements", func() error {
+		return r.client.scoped(ctx, "sale_agreements").
+			Select("*").
+			Eq("sale_id", saleID).
+			Eq("format", string(format)).
+			Order("created_at", false).
+			Order("id", false).
+			Limit(1).
+			Single(ctx, &row)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get latest agreement: %w", err)
+	}
+
+	return row.toDomain()
+}
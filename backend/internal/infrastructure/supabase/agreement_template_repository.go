@@ -0,0 +1,112 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var agreementTemplateTracer = otel.Tracer("goreal-backend/infrastructure/supabase/agreement_template")
+
+// agreementTemplateRepository implements domain.AgreementTemplateRepository using Supabase
+type agreementTemplateRepository struct {
+	client *Client
+}
+
+// NewAgreementTemplateRepository creates a new agreement template repository
+func NewAgreementTemplateRepository(client *Client) domain.AgreementTemplateRepository {
+	return &agreementTemplateRepository{
+		client: client,
+	}
+}
+
+// Create stores a template version; versions are unique per organization
+// and project
+func (r *agreementTemplateRepository) Create(ctx context.Context, template *domain.AgreementTemplate) error {
+	if err := domain.AssignOrganization(ctx, &template.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := agreementTemplateTracer.Start(ctx, "agreementTemplateRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("template.name", template.Name),
+		attribute.Int("template.version", template.Version),
+	)
+
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = time.Now()
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "agreement_templates", func() error {
+		return r.client.From("agreement_templates").Insert(template).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create agreement template: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatest returns the newest version of a project's template, or of the
+// organization default when projectID is nil
+func (r *agreementTemplateRepository) GetLatest(ctx context.Context, projectID *uuid.UUID) (*domain.AgreementTemplate, error) {
+	ctx, span := agreementTemplateTracer.Start(ctx, "agreementTemplateRepository.GetLatest")
+	defer span.End()
+
+	var template domain.AgreementTemplate
+	err := r.client.ExecuteQuery(ctx, "select", "agreement_templates", func() error {
+		return r.query(ctx, projectID).
+			Order("version", false).
+			Limit(1).
+			Single(ctx, &template)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get agreement template: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("template.version", template.Version))
+
+	return &template, nil
+}
+
+// ListVersions returns every version of a project's template, newest first
+func (r *agreementTemplateRepository) ListVersions(ctx context.Context, projectID *uuid.UUID) ([]*domain.AgreementTemplate, error) {
+	ctx, span := agreementTemplateTracer.Start(ctx, "agreementTemplateRepository.ListVersions")
+	defer span.End()
+
+	templates := []*domain.AgreementTemplate{}
+	err := r.client.ExecuteQuery(ctx, "select", "agreement_templates", func() error {
+		return r.query(ctx, projectID).
+			Order("version", false).
+			Execute(ctx, &templates)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list agreement templates: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(templates)))
+
+	return templates, nil
+}
+
+// query selects a project's templates, or the defaults when projectID is nil
+func (r *agreementTemplateRepository) query(ctx context.Context, projectID *uuid.UUID) *QueryBuilder {
+	query := r.client.scoped(ctx, "agreement_templates").Select("*")
+	if projectID == nil {
+		return query.IsNull("project_id")
+	}
+	return query.Eq("project_id", *projectID)
+}
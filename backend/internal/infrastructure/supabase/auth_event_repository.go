@@ -0,0 +1,111 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var authEventTracer = otel.Tracer("goreal-backend/infrastructure/supabase/auth_event")
+
+// authEventRepository implements domain.AuthEventRepository using Supabase
+type authEventRepository struct {
+	client *Client
+}
+
+// NewAuthEventRepository creates a new auth event repository
+func NewAuthEventRepository(client *Client) domain.AuthEventRepository {
+	return &authEventRepository{
+		client: client,
+	}
+}
+
+// Create records an event
+func (r *authEventRepository) Create(ctx context.Context, event *domain.AuthEvent) error {
+	ctx, span := authEventTracer.Start(ctx, "authEventRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event.type", string(event.Type)))
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "auth_events", func() error {
+		return r.client.From("auth_events").Insert(event).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create auth event: %w", err)
+	}
+
+	return nil
+}
+
+// FailuresFromAddress counts the failed logins from an IP address since a time
+func (r *authEventRepository) FailuresFromAddress(ctx context.Context, ipAddress string, since time.Time) (int, *time.Time, error) {
+	ctx, span := authEventTracer.Start(ctx, "authEventRepository.FailuresFromAddress")
+	defer span.End()
+
+	rows := []struct {
+		CreatedAt time.Time `json:"created_at"`
+	}{}
+	err := r.client.ExecuteQuery(ctx, "select", "auth_events", func() error {
+		return r.client.From("auth_events").
+			Select("created_at").
+			Eq("type", string(domain.AuthEventLoginFailed)).
+			Eq("ip_address", ipAddress).
+			Gte("created_at", since).
+			Order("created_at", false).
+			Execute(ctx, &rows)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, nil, fmt.Errorf("failed to count failed logins: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(rows)))
+
+	if len(rows) == 0 {
+		return 0, nil, nil
+	}
+	return len(rows), &rows[0].CreatedAt, nil
+}
+
+// ListByUser returns the latest events of a user, newest first
+func (r *authEventRepository) ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.AuthEvent, error) {
+	ctx, span := authEventTracer.Start(ctx, "authEventRepository.ListByUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	events := []*domain.AuthEvent{}
+	err := r.client.ExecuteQuery(ctx, "select", "auth_events", func() error {
+		query := r.client.From("auth_events").
+			Select("*").
+			Eq("user_id", userID).
+			Order("created_at", false).
+			Order("id", true)
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+		return query.Execute(ctx, &events)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list auth events: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(events)))
+
+	return events, nil
+}
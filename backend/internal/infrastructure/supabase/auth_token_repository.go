@@ -0,0 +1,154 @@
+package supabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var authTokenTracer = otel.Tracer("goreal-backend/infrastructure/supabase/auth_token")
+
+// authTokenRepository implements domain.AuthTokenRepository using Supabase
+type authTokenRepository struct {
+	client *Client
+}
+
+// NewAuthTokenRepository creates a new auth token repository
+func NewAuthTokenRepository(client *Client) domain.AuthTokenRepository {
+	return &authTokenRepository{
+		client: client,
+	}
+}
+
+// Create stores a new token
+func (r *authTokenRepository) Create(ctx context.Context, token *domain.AuthToken) error {
+	ctx, span := authTokenTracer.Start(ctx, "authTokenRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", token.UserID.String()),
+		attribute.String("token.purpose", string(token.Purpose)),
+	)
+
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "auth_tokens", func() error {
+		return r.client.From("auth_tokens").Insert(toDBAuthToken(token)).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create auth token: %w", err)
+	}
+
+	return nil
+}
+
+// Consume redeems an unused, unexpired token in a single guarded update
+func (r *authTokenRepository) Consume(ctx context.Context, purpose domain.TokenPurpose, tokenHash string, at time.Time) (*domain.AuthToken, error) {
+	ctx, span := authTokenTracer.Start(ctx, "authTokenRepository.Consume")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("token.purpose", string(purpose)))
+
+	var rows []dbAuthToken
+	err := r.client.ExecuteQuery(ctx, "update", "auth_tokens", func() error {
+		return r.client.From("auth_tokens").
+			Select("*").
+			Update(map[string]interface{}{"used_at": at}).
+			Eq("purpose", purpose).
+			Eq("token_hash", tokenHash).
+			IsNull("used_at").
+			Gt("expires_at", at).
+			Execute(ctx, &rows)
+	})
+	if err == nil && len(rows) == 0 {
+		err = fmt.Errorf("no usable auth token: %w", domain.ErrNotFound)
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to consume auth token: %w", err)
+	}
+
+	token := rows[0].toDomain()
+	span.SetAttributes(attribute.String("user.id", token.UserID.String()))
+
+	return token, nil
+}
+
+// InvalidateByUser marks a user's outstanding tokens of a purpose as used
+func (r *authTokenRepository) InvalidateByUser(ctx context.Context, userID uuid.UUID, purpose domain.TokenPurpose, at time.Time) (int, error) {
+	ctx, span := authTokenTracer.Start(ctx, "authTokenRepository.InvalidateByUser")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("token.purpose", string(purpose)),
+	)
+
+	var rows []json.RawMessage
+	err := r.client.ExecuteQuery(ctx, "update", "auth_tokens", func() error {
+		return r.client.From("auth_tokens").
+			Select("id").
+			Update(map[string]interface{}{"used_at": at}).
+			Eq("user_id", userID).
+			Eq("purpose", purpose).
+			IsNull("used_at").
+			Execute(ctx, &rows)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to invalidate auth tokens: %w", err)
+	}
+
+	return len(rows), nil
+}
+
+// dbAuthToken represents the database model for auth tokens; unlike
+// domain.AuthToken it carries the token hash in its JSON
+type dbAuthToken struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	Purpose   string     `json:"purpose" db:"purpose"`
+	TokenHash string     `json:"token_hash" db:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// toDBAuthToken converts a domain auth token to its database model
+func toDBAuthToken(token *domain.AuthToken) *dbAuthToken {
+	return &dbAuthToken{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		Purpose:   string(token.Purpose),
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    token.UsedAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+// toDomain converts a database auth token model to the domain model
+func (t *dbAuthToken) toDomain() *domain.AuthToken {
+	return &domain.AuthToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Purpose:   domain.TokenPurpose(t.Purpose),
+		TokenHash: t.TokenHash,
+		ExpiresAt: t.ExpiresAt,
+		UsedAt:    t.UsedAt,
+		CreatedAt: t.CreatedAt,
+	}
+}
@@ -1,10 +1,24 @@
 package supabase
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"goreal-backend/internal/config"
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
 
 	"github.com/supabase-community/supabase-go"
 	"go.opentelemetry.io/otel"
@@ -13,10 +27,21 @@ import (
 
 var supabaseTracer = otel.Tracer("goreal-backend/infrastructure/supabase")
 
+// restPath is the PostgREST mount point below the Supabase project URL
+const restPath = "/rest/v1"
+
 // Client wraps the Supabase client with additional functionality
 type Client struct {
 	client *supabase.Client
 	config *config.Config
+
+	// PostgREST access used by QueryBuilder
+	httpClient *http.Client
+	restURL    string
+	apiKey     string
+
+	mu        sync.RWMutex
+	authToken string
 }
 
 // NewClient creates a new Supabase client
@@ -37,10 +62,18 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		return nil, fmt.Errorf("failed to create Supabase client: %w", err)
 	}
 
+	return newClient(client, cfg, cfg.SupabaseKey), nil
+}
+
+// newClient assembles a Client that talks to PostgREST with the given API key
+func newClient(client *supabase.Client, cfg *config.Config, apiKey string) *Client {
 	return &Client{
-		client: client,
-		config: cfg,
-	}, nil
+		client:     client,
+		config:     cfg,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		restURL:    strings.TrimRight(cfg.SupabaseURL, "/") + restPath,
+		apiKey:     apiKey,
+	}
 }
 
 // GetClient returns the underlying Supabase client
@@ -119,21 +152,33 @@ func (c *Client) WithServiceRole() (*Client, error) {
 		return nil, fmt.Errorf("failed to create Supabase service role client: %w", err)
 	}
 
-	return &Client{
-		client: client,
-		config: c.config,
-	}, nil
+	return newClient(client, c.config, c.config.SupabaseSecretKey), nil
 }
 
-// SetAuth sets the authentication token for the client
+// SetAuth sets the authentication token for the client.
+// Subsequent queries are sent with this token instead of the API key,
+// so PostgREST evaluates row level security as the token's user.
 func (c *Client) SetAuth(token string) {
-	// TODO: Implement auth token setting when needed
-	// The supabase-go library API might be different
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authToken = token
 }
 
 // ClearAuth clears the authentication token
 func (c *Client) ClearAuth() {
-	// TODO: Implement auth clearing when needed
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authToken = ""
+}
+
+// bearerToken returns the token used for the Authorization header
+func (c *Client) bearerToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.authToken != "" {
+		return c.authToken
+	}
+	return c.apiKey
 }
 
 // From creates a new query builder for a table
@@ -141,121 +186,549 @@ func (c *Client) From(table string) *QueryBuilder {
 	return &QueryBuilder{
 		client: c,
 		table:  table,
-		query:  c.client.From(table),
+		method: http.MethodGet,
+		params: url.Values{},
+	}
+}
+
+// RPC creates a request calling the Postgres function with args as its
+// named parameters. Execute decodes the rows the function returns.
+func (c *Client) RPC(function string, args interface{}) *QueryBuilder {
+	return &QueryBuilder{
+		client: c,
+		table:  function,
+		method: http.MethodPost,
+		params: url.Values{},
+		body:   args,
+		rpc:    true,
 	}
 }
 
-// BuildQuery helps build complex queries with filters
+// QueryBuilder builds PostgREST requests for a single table.
+// Filters are accumulated as query parameters and the request is sent
+// by one of the terminal methods: Execute, Single or ExecuteWithCount.
 type QueryBuilder struct {
 	client *Client
 	table  string
-	query  interface{} // Using interface{} for now to avoid API issues
+	method string
+	params url.Values
+	order  []string
+	body   interface{}
+	rpc    bool
 }
 
-// Select adds select clause
+// Select adds select clause. Embedded resources such as
+// "assigned_user:assigned_to(id, full_name)" are passed through as-is.
 func (qb *QueryBuilder) Select(columns string) *QueryBuilder {
-	// TODO: Implement proper query building when Supabase API is clarified
+	qb.params.Set("select", compactSelect(columns))
 	return qb
 }
 
 // Insert adds insert operation
 func (qb *QueryBuilder) Insert(data interface{}) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
+	qb.method = http.MethodPost
+	qb.body = data
 	return qb
 }
 
 // Update adds update operation
 func (qb *QueryBuilder) Update(data interface{}) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
+	qb.method = http.MethodPatch
+	qb.body = data
 	return qb
 }
 
 // Delete adds delete operation
 func (qb *QueryBuilder) Delete() *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
+	qb.method = http.MethodDelete
 	return qb
 }
 
 // Eq adds equality filter
 func (qb *QueryBuilder) Eq(column string, value interface{}) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
-	return qb
+	return qb.filter(column, "eq", formatValue(value))
+}
+
+// Neq adds not equal filter
+func (qb *QueryBuilder) Neq(column string, value interface{}) *QueryBuilder {
+	return qb.filter(column, "neq", formatValue(value))
+}
+
+// Gt adds greater than filter
+func (qb *QueryBuilder) Gt(column string, value interface{}) *QueryBuilder {
+	return qb.filter(column, "gt", formatValue(value))
 }
 
 // Gte adds greater than or equal filter
 func (qb *QueryBuilder) Gte(column string, value interface{}) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
-	return qb
+	return qb.filter(column, "gte", formatValue(value))
+}
+
+// Lt adds less than filter
+func (qb *QueryBuilder) Lt(column string, value interface{}) *QueryBuilder {
+	return qb.filter(column, "lt", formatValue(value))
 }
 
 // Lte adds less than or equal filter
 func (qb *QueryBuilder) Lte(column string, value interface{}) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
+	return qb.filter(column, "lte", formatValue(value))
+}
+
+// Ilike adds a case-insensitive pattern filter, e.g. "%foo%"
+func (qb *QueryBuilder) Ilike(column, pattern string) *QueryBuilder {
+	return qb.filter(column, "ilike", pattern)
+}
+
+// In adds a filter matching any of the given values
+func (qb *QueryBuilder) In(column string, values interface{}) *QueryBuilder {
+	return qb.filter(column, "in", "("+strings.Join(formatList(values), ",")+")")
+}
+
+// IsNull adds is null filter
+func (qb *QueryBuilder) IsNull(column string) *QueryBuilder {
+	return qb.filter(column, "is", "null")
+}
+
+// IsNotNull adds is not null filter
+func (qb *QueryBuilder) IsNotNull(column string) *QueryBuilder {
+	return qb.filter(column, "not.is", "null")
+}
+
+// Or adds OR condition, e.g. "name.ilike.%foo%,email.ilike.%foo%"
+func (qb *QueryBuilder) Or(conditions string) *QueryBuilder {
+	qb.params.Add("or", "("+conditions+")")
 	return qb
 }
 
-// Order adds order by clause
+// IlikeAny adds an OR condition matching text anywhere in any of the
+// columns, ignoring case. The text is quoted, so commas, parentheses or
+// quotes cannot break out of the condition, and matched as an escaped
+// regular expression, since PostgREST reads every * in an ilike pattern
+// as % and the text must match only itself.
+func (qb *QueryBuilder) IlikeAny(text string, columns ...string) *QueryBuilder {
+	pattern := quoteValue(regexp.QuoteMeta(text))
+	conditions := make([]string, len(columns))
+	for i, column := range columns {
+		conditions[i] = column + ".imatch." + pattern
+	}
+	return qb.Or(strings.Join(conditions, ","))
+}
+
+// Contains adds contains filter. Slices are sent as Postgres array
+// literals for array columns; maps and structs as JSON for jsonb columns.
+func (qb *QueryBuilder) Contains(column string, value interface{}) *QueryBuilder {
+	if s, ok := value.(string); ok {
+		return qb.filter(column, "cs", s)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		return qb.filter(column, "cs", "{"+strings.Join(formatList(value), ",")+"}")
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return qb.filter(column, "cs", formatValue(value))
+	}
+	return qb.filter(column, "cs", string(data))
+}
+
+// Order adds order by clause. Repeated calls add secondary sort keys.
 func (qb *QueryBuilder) Order(column string, ascending bool) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
+	direction := "desc"
+	if ascending {
+		direction = "asc"
+	}
+	qb.order = append(qb.order, column+"."+direction)
+	return qb
+}
+
+// OrderNullsLast adds an order by clause that sorts NULLs after all
+// other values in either direction, matching the postgres backend.
+func (qb *QueryBuilder) OrderNullsLast(column string, ascending bool) *QueryBuilder {
+	direction := "desc"
+	if ascending {
+		direction = "asc"
+	}
+	qb.order = append(qb.order, column+"."+direction+".nullslast")
 	return qb
 }
 
 // Limit adds limit clause
 func (qb *QueryBuilder) Limit(count int) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
+	qb.params.Set("limit", strconv.Itoa(count))
 	return qb
 }
 
 // Offset adds offset clause
 func (qb *QueryBuilder) Offset(count int) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
+	qb.params.Set("offset", strconv.Itoa(count))
 	return qb
 }
 
-// Single executes query and returns single result
+// Execute executes query and returns multiple results.
+// dest may be nil when the response body is not needed.
+func (qb *QueryBuilder) Execute(ctx context.Context, dest interface{}) error {
+	_, err := qb.send(ctx, dest, false, false)
+	return err
+}
+
+// Single executes query and returns single result.
+// It returns an error wrapping domain.ErrNotFound when no row matches.
 func (qb *QueryBuilder) Single(ctx context.Context, dest interface{}) error {
-	// TODO: Implement when Supabase API is clarified
-	return fmt.Errorf("single query execution not yet implemented")
+	_, err := qb.send(ctx, dest, true, false)
+	return err
 }
 
-// Or adds OR condition
-func (qb *QueryBuilder) Or(conditions string) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
-	return qb
+// ExecuteAffected executes an update or delete and returns an error
+// wrapping domain.ErrNotFound when no row matched the filters.
+func (qb *QueryBuilder) ExecuteAffected(ctx context.Context) error {
+	qb.params.Set("select", "id")
+
+	var rows []json.RawMessage
+	if _, err := qb.send(ctx, &rows, false, false); err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no %s row matched: %w", qb.table, domain.ErrNotFound)
+	}
+	return nil
 }
 
-// Contains adds contains filter
-func (qb *QueryBuilder) Contains(column string, value interface{}) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
-	return qb
+// ExecuteWithCount executes query and returns results with count.
+// The count is the exact number of rows matching the filters,
+// regardless of Limit and Offset.
+func (qb *QueryBuilder) ExecuteWithCount(ctx context.Context, dest interface{}) (int, error) {
+	return qb.send(ctx, dest, false, true)
 }
 
-// Lt adds less than filter
-func (qb *QueryBuilder) Lt(column string, value interface{}) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
+// filter appends a "column=operator.value" query parameter
+func (qb *QueryBuilder) filter(column, operator, value string) *QueryBuilder {
+	qb.params.Add(column, operator+"."+value)
 	return qb
 }
 
-// IsNotNull adds is not null filter
-func (qb *QueryBuilder) IsNotNull(column string) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
-	return qb
+// send builds the HTTP request, performs it and decodes the response.
+// Writes made inside a unit of work are recorded so they can be undone.
+func (qb *QueryBuilder) send(ctx context.Context, dest interface{}, single, count bool) (int, error) {
+	j := journalFrom(ctx)
+	if qb.method == http.MethodGet || qb.rpc {
+		j = nil
+	}
+
+	var before []map[string]interface{}
+	if j != nil && qb.method != http.MethodPost {
+		var err error
+		if before, err = qb.current(ctx); err != nil {
+			return 0, fmt.Errorf("failed to read %s rows before write: %w", qb.table, err)
+		}
+	}
+
+	params := url.Values{}
+	for k, v := range qb.params {
+		params[k] = v
+	}
+	if len(qb.order) > 0 {
+		params.Set("order", strings.Join(qb.order, ","))
+	}
+
+	endpoint := qb.client.restURL + "/" + url.PathEscape(qb.table)
+	if qb.rpc {
+		endpoint = qb.client.restURL + "/rpc/" + url.PathEscape(qb.table)
+	}
+	if encoded := params.Encode(); encoded != "" {
+		endpoint += "?" + encoded
+	}
+
+	var body io.Reader
+	if qb.body != nil {
+		data, err := json.Marshal(qb.body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, qb.method, endpoint, body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("apikey", qb.client.apiKey)
+	req.Header.Set("Authorization", "Bearer "+qb.client.bearerToken())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if single {
+		req.Header.Set("Accept", "application/vnd.pgrst.object+json")
+	} else {
+		req.Header.Set("Accept", "application/json")
+	}
+
+	var prefer []string
+	if qb.method != http.MethodGet && !qb.rpc {
+		if dest != nil || j != nil {
+			prefer = append(prefer, "return=representation")
+		} else {
+			prefer = append(prefer, "return=minimal")
+		}
+	}
+	if count {
+		prefer = append(prefer, "count=exact")
+	}
+	if len(prefer) > 0 {
+		req.Header.Set("Prefer", strings.Join(prefer, ","))
+	}
+
+	resp, err := qb.client.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request to %s failed: %w", qb.table, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return 0, newAPIError(resp.StatusCode, data)
+	}
+
+	total := 0
+	if count {
+		total, err = parseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if dest != nil && len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, dest); err != nil {
+			return 0, fmt.Errorf("failed to decode %s response: %w", qb.table, err)
+		}
+	}
+
+	if j != nil {
+		if err := qb.recordUndo(j, before, data); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
 }
 
-// Neq adds not equal filter
-func (qb *QueryBuilder) Neq(column string, value interface{}) *QueryBuilder {
-	// TODO: Implement when Supabase API is clarified
-	return qb
+// current fetches the full rows matched by the builder's filters
+func (qb *QueryBuilder) current(ctx context.Context) ([]map[string]interface{}, error) {
+	read := qb.client.From(qb.table)
+	for k, v := range qb.params {
+		switch k {
+		case "select", "order", "limit", "offset":
+			continue
+		}
+		read.params[k] = v
+	}
+
+	var rows []map[string]interface{}
+	if err := read.Execute(ctx, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
 }
 
-// ExecuteWithCount executes query and returns results with count
-func (qb *QueryBuilder) ExecuteWithCount(ctx context.Context, dest interface{}) (int, error) {
-	// TODO: Implement when Supabase API is clarified
-	return 0, fmt.Errorf("query execution with count not yet implemented")
+// recordUndo adds the inverse of a completed write to the journal:
+// inserted rows are deleted, updated rows are patched back and deleted
+// rows are inserted again.
+func (qb *QueryBuilder) recordUndo(j *journal, before []map[string]interface{}, response []byte) error {
+	c, table := qb.client, qb.table
+
+	switch qb.method {
+	case http.MethodPost:
+		var created []map[string]interface{}
+		if len(bytes.TrimSpace(response)) > 0 {
+			if err := json.Unmarshal(response, &created); err != nil {
+				return fmt.Errorf("failed to decode %s response: %w", table, err)
+			}
+		}
+		ids := rowIDs(created)
+		if len(ids) == 0 {
+			return nil
+		}
+		j.record(func(ctx context.Context) error {
+			return c.From(table).Delete().In("id", ids).Execute(ctx, nil)
+		})
+
+	case http.MethodPatch:
+		for _, row := range before {
+			if row["id"] == nil {
+				continue
+			}
+			j.record(func(ctx context.Context) error {
+				return c.From(table).Update(row).Eq("id", row["id"]).Execute(ctx, nil)
+			})
+		}
+
+	case http.MethodDelete:
+		if len(before) == 0 {
+			return nil
+		}
+		j.record(func(ctx context.Context) error {
+			return c.From(table).Insert(before).Execute(ctx, nil)
+		})
+	}
+
+	return nil
 }
 
-// Execute executes query and returns multiple results
-func (qb *QueryBuilder) Execute(ctx context.Context, dest interface{}) error {
-	// TODO: Implement when Supabase API is clarified
-	return fmt.Errorf("query execution not yet implemented")
+// rowIDs returns the id column of each row that has one
+func rowIDs(rows []map[string]interface{}) []string {
+	var ids []string
+	for _, row := range rows {
+		if id, ok := row["id"]; ok && id != nil {
+			ids = append(ids, formatValue(id))
+		}
+	}
+	return ids
+}
+
+// APIError is an error response returned by PostgREST
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Details    string `json:"details"`
+	Hint       string `json:"hint"`
+}
+
+func newAPIError(status int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: status}
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(body))
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(status)
+		}
+	}
+	return apiErr
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("postgrest error %d (%s): %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("postgrest error %d: %s", e.StatusCode, e.Message)
+}
+
+// Unwrap maps PostgREST and Postgres error codes to domain errors
+// so callers can use errors.Is(err, domain.ErrNotFound) and friends.
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.Code == "PGRST116":
+		return domain.ErrNotFound
+	case e.Code == "23505":
+		return domain.ErrAlreadyExists
+	case e.Code == "23502", e.Code == "23503", e.Code == "23514", e.Code == "22P02":
+		return domain.ErrInvalidInput
+	case e.StatusCode == http.StatusNotFound:
+		return domain.ErrNotFound
+	case e.StatusCode == http.StatusUnauthorized:
+		return domain.ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return domain.ErrForbidden
+	case e.StatusCode == http.StatusConflict:
+		return domain.ErrAlreadyExists
+	}
+	return nil
+}
+
+// parseContentRange extracts the total from a "0-24/3573" or "*/0" header
+func parseContentRange(header string) (int, error) {
+	idx := strings.LastIndex(header, "/")
+	if idx < 0 {
+		return 0, fmt.Errorf("missing count in Content-Range header %q", header)
+	}
+	total := header[idx+1:]
+	if total == "*" {
+		return 0, fmt.Errorf("count not available in Content-Range header %q", header)
+	}
+	n, err := strconv.Atoi(total)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Content-Range header %q: %w", header, err)
+	}
+	return n, nil
+}
+
+// compactSelect strips the whitespace used to lay out multi-line select clauses
+func compactSelect(columns string) string {
+	return strings.Join(strings.Fields(columns), "")
+}
+
+// formatValue renders a Go value the way PostgREST expects it in a filter
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case uuid.UUID:
+		return v.String()
+	case *uuid.UUID:
+		if v == nil {
+			return "null"
+		}
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return "null"
+		}
+		return v.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "null"
+		}
+		return formatValue(rv.Elem().Interface())
+	}
+	if rv.Kind() == reflect.String {
+		return rv.String()
+	}
+	return fmt.Sprint(value)
+}
+
+// formatList renders slice elements for in.(...) and cs.{...} filters,
+// quoting elements that contain PostgREST reserved characters
+func formatList(values interface{}) []string {
+	rv := reflect.ValueOf(values)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []string{quoteListItem(formatValue(values))}
+	}
+
+	items := make([]string, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		items[i] = quoteListItem(formatValue(rv.Index(i).Interface()))
+	}
+	return items
+}
+
+func quoteListItem(s string) string {
+	if strings.ContainsAny(s, `,(){}" `+"\\") {
+		return quoteValue(s)
+	}
+	return s
+}
+
+// quoteValue double quotes a value for a list or logical condition, where
+// PostgREST reserves commas, dots, parentheses and quotes
+func quoteValue(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
 }
@@ -27,6 +27,9 @@ func NewClientRepository(client *Client) domain.ClientRepository {
 
 // Create creates a new client
 func (r *clientRepository) Create(ctx context.Context, client *domain.Client) error {
+	if err := domain.AssignOrganization(ctx, &client.OrganizationID); err != nil {
+		return err
+	}
 	ctx, span := clientTracer.Start(ctx, "clientRepository.Create")
 	defer span.End()
 
@@ -36,10 +39,14 @@ func (r *clientRepository) Create(ctx context.Context, client *domain.Client) er
 		attribute.String("client.client_type", string(client.ClientType)),
 	)
 
-	// Set timestamps
+	// Set timestamps unless the caller supplied them
 	now := time.Now()
-	client.CreatedAt = now
-	client.UpdatedAt = now
+	if client.CreatedAt.IsZero() {
+		client.CreatedAt = now
+	}
+	if client.UpdatedAt.IsZero() {
+		client.UpdatedAt = client.CreatedAt
+	}
 
 	// Execute insert query
 	err := r.client.ExecuteQuery(ctx, "insert", "clients", func() error {
@@ -63,13 +70,14 @@ func (r *clientRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.C
 
 	var client domain.Client
 	err := r.client.ExecuteQuery(ctx, "select", "clients", func() error {
-		return r.client.From("clients").
+		return r.client.scoped(ctx, "clients").
 			Select(`*, 
 				profile:users!profile_id(*), 
 				lead:leads!lead_id(*), 
 				company:companies!company_id(*), 
 				assigned_user:users!assigned_to(*)`).
 			Eq("id", id).
+			IsNull("deleted_at").
 			Single(ctx, &client)
 	})
 
@@ -90,13 +98,14 @@ func (r *clientRepository) GetByEmail(ctx context.Context, email string) (*domai
 
 	var client domain.Client
 	err := r.client.ExecuteQuery(ctx, "select", "clients", func() error {
-		return r.client.From("clients").
+		return r.client.scoped(ctx, "clients").
 			Select(`*, 
 				profile:users!profile_id(*), 
 				lead:leads!lead_id(*), 
 				company:companies!company_id(*), 
 				assigned_user:users!assigned_to(*)`).
 			Eq("email", email).
+			IsNull("deleted_at").
 			Single(ctx, &client)
 	})
 
@@ -110,6 +119,9 @@ func (r *clientRepository) GetByEmail(ctx context.Context, email string) (*domai
 
 // Update updates an existing client
 func (r *clientRepository) Update(ctx context.Context, client *domain.Client) error {
+	if err := domain.AssignOrganization(ctx, &client.OrganizationID); err != nil {
+		return err
+	}
 	ctx, span := clientTracer.Start(ctx, "clientRepository.Update")
 	defer span.End()
 
@@ -119,16 +131,21 @@ func (r *clientRepository) Update(ctx context.Context, client *domain.Client) er
 	)
 
 	// Update timestamp
-	client.UpdatedAt = time.Now()
+	since := client.UpdatedAt
+	client.UpdatedAt = timestamp()
 
 	err := r.client.ExecuteQuery(ctx, "update", "clients", func() error {
-		return r.client.From("clients").
+		err := r.client.scoped(ctx, "clients").
 			Update(client).
 			Eq("id", client.ID).
-			Execute(ctx, nil)
+			Eq("updated_at", since).
+			IsNull("deleted_at").
+			ExecuteAffected(ctx)
+		return r.client.expectVersion(ctx, "clients", client.ID, err)
 	})
 
 	if err != nil {
+		client.UpdatedAt = since
 		span.RecordError(err)
 		return fmt.Errorf("failed to update client: %w", err)
 	}
@@ -136,18 +153,15 @@ func (r *clientRepository) Update(ctx context.Context, client *domain.Client) er
 	return nil
 }
 
-// Delete deletes a client by ID
-func (r *clientRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete moves a client to the trash
+func (r *clientRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
 	ctx, span := clientTracer.Start(ctx, "clientRepository.Delete")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("client.id", id.String()))
 
 	err := r.client.ExecuteQuery(ctx, "delete", "clients", func() error {
-		return r.client.From("clients").
-			Delete().
-			Eq("id", id).
-			Execute(ctx, nil)
+		return r.client.softDelete(ctx, "clients", id, deletedBy)
 	})
 
 	if err != nil {
@@ -158,6 +172,47 @@ func (r *clientRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// Restore takes a client out of the trash
+func (r *clientRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("client.id", id.String()))
+
+	err := r.client.ExecuteQuery(ctx, "restore", "clients", func() error {
+		return r.client.restore(ctx, "clients", id)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to restore client: %w", err)
+	}
+
+	return nil
+}
+
+// Purge permanently removes clients that were deleted before the cutoff
+func (r *clientRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, span := clientTracer.Start(ctx, "clientRepository.Purge")
+	defer span.End()
+
+	var purged int
+	err := r.client.ExecuteQuery(ctx, "purge", "clients", func() error {
+		var err error
+		purged, err = r.client.purge(ctx, "clients", deletedBefore)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to purge clients: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", purged))
+
+	return purged, nil
+}
+
 // List retrieves clients with pagination and filtering
 func (r *clientRepository) List(ctx context.Context, filters domain.ClientFilters) ([]*domain.Client, error) {
 	ctx, span := clientTracer.Start(ctx, "clientRepository.List")
@@ -168,59 +223,16 @@ func (r *clientRepository) List(ctx context.Context, filters domain.ClientFilter
 		attribute.Int("filters.offset", filters.Offset),
 	)
 
-	query := r.client.From("clients").
+	query := r.client.scoped(ctx, "clients").
 		Select(`*,
 			profile:users!profile_id(*),
 			lead:leads!lead_id(*),
 			company:companies!company_id(*),
 			assigned_user:users!assigned_to(*)`)
 
-	// Apply filters
-	if filters.ClientType != nil {
-		query = query.Eq("client_type", string(*filters.ClientType))
-		span.SetAttributes(attribute.String("filters.client_type", string(*filters.ClientType)))
-	}
-
-	if filters.AssignedTo != nil {
-		query = query.Eq("assigned_to", *filters.AssignedTo)
-		span.SetAttributes(attribute.String("filters.assigned_to", filters.AssignedTo.String()))
-	}
-
-	if filters.CompanyID != nil {
-		query = query.Eq("company_id", *filters.CompanyID)
-		span.SetAttributes(attribute.String("filters.company_id", filters.CompanyID.String()))
-	}
-
-	if filters.IsVerified != nil {
-		query = query.Eq("is_verified", *filters.IsVerified)
-		span.SetAttributes(attribute.Bool("filters.is_verified", *filters.IsVerified))
-	}
-
-	if filters.Search != nil {
-		// Search in name, email, or phone
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("name.ilike.%s,email.ilike.%s,phone.ilike.%s",
-			searchPattern, searchPattern, searchPattern))
-		span.SetAttributes(attribute.String("filters.search", *filters.Search))
-	}
-
-	// Apply sorting
-	if filters.SortBy != "" {
-		ascending := filters.SortOrder == "" || filters.SortOrder == "asc"
-		query = query.Order(filters.SortBy, ascending)
-		span.SetAttributes(
-			attribute.String("filters.sort_by", filters.SortBy),
-			attribute.Bool("filters.ascending", ascending),
-		)
-	}
-
-	// Apply pagination
-	if filters.Limit > 0 {
-		query = query.Limit(filters.Limit)
-	}
-	if filters.Offset > 0 {
-		query = query.Offset(filters.Offset)
-	}
+	query = applyClientFilters(query, filters)
+	query = applySort(query, filters.BaseFilters, clientSortable, "created_at", false)
+	query = applyPagination(query, filters.BaseFilters)
 
 	var clients []*domain.Client
 	err := r.client.ExecuteQuery(ctx, "select", "clients", func() error {
@@ -242,45 +254,62 @@ func (r *clientRepository) Count(ctx context.Context, filters domain.ClientFilte
 	ctx, span := clientTracer.Start(ctx, "clientRepository.Count")
 	defer span.End()
 
-	query := r.client.From("clients").Select("id")
+	query := r.client.scoped(ctx, "clients").Select("id")
+
+	query = applyClientFilters(query, filters)
+
+	var count int
+	err := r.client.ExecuteQuery(ctx, "select", "clients", func() error {
+		var rows []map[string]interface{}
+		var err error
+		count, err = query.ExecuteWithCount(ctx, &rows)
+		return err
+	})
 
-	// Apply filters (same as List method)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count clients: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+// applyClientFilters adds the ClientFilters conditions shared by List and Count
+func applyClientFilters(query *QueryBuilder, filters domain.ClientFilters) *QueryBuilder {
+	if !filters.IncludeDeleted {
+		query = query.IsNull("deleted_at")
+	}
 	if filters.ClientType != nil {
 		query = query.Eq("client_type", string(*filters.ClientType))
 	}
-
 	if filters.AssignedTo != nil {
 		query = query.Eq("assigned_to", *filters.AssignedTo)
 	}
-
+	if filters.CreatedBy != nil {
+		query = query.Eq("created_by", *filters.CreatedBy)
+	}
 	if filters.CompanyID != nil {
 		query = query.Eq("company_id", *filters.CompanyID)
 	}
-
 	if filters.IsVerified != nil {
 		query = query.Eq("is_verified", *filters.IsVerified)
 	}
-
-	if filters.Search != nil {
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("name.ilike.%s,email.ilike.%s,phone.ilike.%s",
-			searchPattern, searchPattern, searchPattern))
+	if len(filters.Tags) > 0 {
+		query = query.Contains("tags", filters.Tags)
 	}
-
-	var clients []*domain.Client
-	err := r.client.ExecuteQuery(ctx, "select", "clients", func() error {
-		return query.Execute(ctx, &clients)
-	})
-
-	if err != nil {
-		span.RecordError(err)
-		return 0, fmt.Errorf("failed to count clients: %w", err)
+	if filters.CreatedAfter != nil {
+		query = query.Gte("created_at", *filters.CreatedAfter)
 	}
-
-	count := len(clients)
-	span.SetAttributes(attribute.Int("result.count", count))
-
-	return count, nil
+	if filters.CreatedBefore != nil {
+		query = query.Lte("created_at", *filters.CreatedBefore)
+	}
+	if filters.Search != nil {
+		// Search in name, email, or phone
+		query = query.IlikeAny(*filters.Search, "name", "email", "phone")
+	}
+	return query
 }
 
 // GetByAssignedUser retrieves clients assigned to a user
@@ -292,13 +321,14 @@ func (r *clientRepository) GetByAssignedUser(ctx context.Context, userID uuid.UU
 
 	var clients []*domain.Client
 	err := r.client.ExecuteQuery(ctx, "select", "clients", func() error {
-		return r.client.From("clients").
+		return r.client.scoped(ctx, "clients").
 			Select(`*, 
 				profile:users!profile_id(*), 
 				lead:leads!lead_id(*), 
 				company:companies!company_id(*), 
 				assigned_user:users!assigned_to(*)`).
 			Eq("assigned_to", userID).
+			IsNull("deleted_at").
 			Execute(ctx, &clients)
 	})
 
@@ -321,7 +351,7 @@ func (r *clientRepository) GetByCompanyID(ctx context.Context, companyID uuid.UU
 
 	var clients []*domain.Client
 	err := r.client.ExecuteQuery(ctx, "select", "clients", func() error {
-		return r.client.From("clients").
+		return r.client.scoped(ctx, "clients").
 			Select(`*, 
 				profile:users!profile_id(*), 
 				lead:leads!lead_id(*), 
@@ -350,7 +380,7 @@ func (r *clientRepository) GetByClientType(ctx context.Context, clientType domai
 
 	var clients []*domain.Client
 	err := r.client.ExecuteQuery(ctx, "select", "clients", func() error {
-		return r.client.From("clients").
+		return r.client.scoped(ctx, "clients").
 			Select(`*, 
 				profile:users!profile_id(*), 
 				lead:leads!lead_id(*), 
@@ -386,10 +416,10 @@ func (r *clientRepository) UpdateVerificationStatus(ctx context.Context, id uuid
 	}
 
 	err := r.client.ExecuteQuery(ctx, "update", "clients", func() error {
-		return r.client.From("clients").
+		return r.client.scoped(ctx, "clients").
 			Update(updateData).
 			Eq("id", id).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 
 	if err != nil {
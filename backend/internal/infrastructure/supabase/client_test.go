@@ -0,0 +1,181 @@
+package supabase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/config"
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/infrastructure/supabase/supabasetest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) (*Client, *supabasetest.Server) {
+	t.Helper()
+
+	srv := supabasetest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient(&config.Config{
+		SupabaseURL: srv.URL(),
+		SupabaseKey: "test-anon-key",
+	})
+	require.NoError(t, err)
+
+	return client, srv
+}
+
+func TestQueryBuilder_BuildsPostgRESTRequest(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	score := 10
+
+	var rows []map[string]interface{}
+	err := client.From("leads").
+		Select(`
+			*,
+			assigned_user:assigned_to(id, full_name)
+		`).
+		Eq("assigned_to", id).
+		Gte("created_at", since).
+		Lte("score", &score).
+		Neq("status", "lost").
+		In("source", []string{"website", "walk in"}).
+		Contains("tags", []string{"vip", "hot"}).
+		IsNotNull("next_follow_up").
+		Or("name.ilike.%acme%,email.ilike.%acme%").
+		Order("score", false).
+		Order("created_at", true).
+		Limit(20).
+		Offset(40).
+		Execute(ctx, &rows)
+	require.NoError(t, err)
+
+	req := srv.LastRequest()
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, "leads", req.Table)
+	assert.Equal(t, "*,assigned_user:assigned_to(id,full_name)", req.Query.Get("select"))
+	assert.Equal(t, "eq."+id.String(), req.Query.Get("assigned_to"))
+	assert.Equal(t, "gte.2024-01-02T03:04:05Z", req.Query.Get("created_at"))
+	assert.Equal(t, "lte.10", req.Query.Get("score"))
+	assert.Equal(t, "neq.lost", req.Query.Get("status"))
+	assert.Equal(t, `in.(website,"walk in")`, req.Query.Get("source"))
+	assert.Equal(t, "cs.{vip,hot}", req.Query.Get("tags"))
+	assert.Equal(t, "not.is.null", req.Query.Get("next_follow_up"))
+	assert.Equal(t, "(name.ilike.%acme%,email.ilike.%acme%)", req.Query.Get("or"))
+	assert.Equal(t, "score.desc,created_at.asc", req.Query.Get("order"))
+	assert.Equal(t, "20", req.Query.Get("limit"))
+	assert.Equal(t, "40", req.Query.Get("offset"))
+
+	assert.Equal(t, "test-anon-key", req.Header.Get("apikey"))
+	assert.Equal(t, "Bearer test-anon-key", req.Header.Get("Authorization"))
+}
+
+func TestQueryBuilder_IlikeAnyQuotesText(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	var rows []map[string]interface{}
+	err := client.From("leads").IlikeAny(`a,b) "x" \`, "name", "email").Execute(context.Background(), &rows)
+	require.NoError(t, err)
+	assert.Equal(t, `(name.imatch."a,b\\) \"x\" \\\\",email.imatch."a,b\\) \"x\" \\\\")`, srv.LastRequest().Query.Get("or"))
+}
+
+func TestQueryBuilder_IlikeAnyEscapesWildcards(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	var rows []map[string]interface{}
+	err := client.From("leads").IlikeAny("50%_off*", "name").Execute(context.Background(), &rows)
+	require.NoError(t, err)
+	assert.Equal(t, `(name.imatch."50%_off\\*")`, srv.LastRequest().Query.Get("or"))
+}
+
+func TestQueryBuilder_SetAuth(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+
+	client.SetAuth("user-jwt")
+	require.NoError(t, client.From("users").Select("*").Execute(ctx, nil))
+	assert.Equal(t, "Bearer user-jwt", srv.LastRequest().Header.Get("Authorization"))
+
+	client.ClearAuth()
+	require.NoError(t, client.From("users").Select("*").Execute(ctx, nil))
+	assert.Equal(t, "Bearer test-anon-key", srv.LastRequest().Header.Get("Authorization"))
+}
+
+func TestQueryBuilder_ExecuteWithCount(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		srv.Seed("tasks", supabasetest.Row{"id": uuid.NewString(), "priority": "high"})
+	}
+	srv.Seed("tasks", supabasetest.Row{"id": uuid.NewString(), "priority": "low"})
+
+	var rows []map[string]interface{}
+	count, err := client.From("tasks").Select("id").Eq("priority", "high").Limit(2).ExecuteWithCount(ctx, &rows)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+	assert.Len(t, rows, 2)
+	assert.Contains(t, srv.LastRequest().Header.Get("Prefer"), "count=exact")
+
+	count, err = client.From("tasks").Select("id").Eq("priority", "none").ExecuteWithCount(ctx, &rows)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestQueryBuilder_Single(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	srv.Seed("users", supabasetest.Row{"id": id.String(), "email": "a@example.com"})
+
+	var user struct {
+		ID    uuid.UUID `json:"id"`
+		Email string    `json:"email"`
+	}
+	require.NoError(t, client.From("users").Select("*").Eq("id", id).Single(ctx, &user))
+	assert.Equal(t, id, user.ID)
+	assert.Equal(t, "application/vnd.pgrst.object+json", srv.LastRequest().Header.Get("Accept"))
+
+	err := client.From("users").Select("*").Eq("id", uuid.New()).Single(ctx, &user)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "PGRST116", apiErr.Code)
+}
+
+func TestQueryBuilder_Writes(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	row := map[string]interface{}{"id": id, "title": "Call back"}
+
+	require.NoError(t, client.From("tasks").Insert(row).Execute(ctx, nil))
+	assert.Equal(t, http.MethodPost, srv.LastRequest().Method)
+	assert.Equal(t, "return=minimal", srv.LastRequest().Header.Get("Prefer"))
+
+	err := client.From("tasks").Insert(row).Execute(ctx, nil)
+	assert.True(t, errors.Is(err, domain.ErrAlreadyExists))
+
+	var updated []map[string]interface{}
+	require.NoError(t, client.From("tasks").Update(map[string]interface{}{"title": "Done"}).Eq("id", id).Execute(ctx, &updated))
+	assert.Equal(t, "return=representation", srv.LastRequest().Header.Get("Prefer"))
+	require.Len(t, updated, 1)
+	assert.Equal(t, "Done", updated[0]["title"])
+
+	require.NoError(t, client.From("tasks").Delete().Eq("id", id).Execute(ctx, nil))
+	assert.Empty(t, srv.Rows("tasks"))
+}
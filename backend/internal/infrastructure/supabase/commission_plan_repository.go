@@ -0,0 +1,131 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var commissionPlanTracer = otel.Tracer("goreal-backend/infrastructure/supabase/commission_plan")
+
+// commissionPlanRepository implements domain.CommissionPlanRepository using Supabase
+type commissionPlanRepository struct {
+	client *Client
+}
+
+// NewCommissionPlanRepository creates a new commission plan repository
+func NewCommissionPlanRepository(client *Client) domain.CommissionPlanRepository {
+	return &commissionPlanRepository{
+		client: client,
+	}
+}
+
+// Create stores a new commission plan
+func (r *commissionPlanRepository) Create(ctx context.Context, plan *domain.CommissionPlan) error {
+	if err := domain.AssignOrganization(ctx, &plan.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := commissionPlanTracer.Start(ctx, "commissionPlanRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("commission_plan.id", plan.ID.String()),
+		attribute.String("commission_plan.type", string(plan.Type)),
+	)
+
+	if plan.CreatedAt.IsZero() {
+		plan.CreatedAt = timestamp()
+	}
+
+	// The slabs column is NOT NULL, so flat plans send []
+	row := *plan
+	if row.Slabs == nil {
+		row.Slabs = []domain.CommissionSlab{}
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "commission_plans", func() error {
+		return r.client.From("commission_plans").Insert(&row).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create commission plan: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a commission plan by ID
+func (r *commissionPlanRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CommissionPlan, error) {
+	ctx, span := commissionPlanTracer.Start(ctx, "commissionPlanRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("commission_plan.id", id.String()))
+
+	var plan domain.CommissionPlan
+	err := r.client.ExecuteQuery(ctx, "select", "commission_plans", func() error {
+		return r.client.scoped(ctx, "commission_plans").
+			Select("*").
+			Eq("id", id).
+			Single(ctx, &plan)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get commission plan by ID: %w", err)
+	}
+
+	return &plan, nil
+}
+
+// GetLatest returns the plan in effect: the most recently created one
+func (r *commissionPlanRepository) GetLatest(ctx context.Context) (*domain.CommissionPlan, error) {
+	ctx, span := commissionPlanTracer.Start(ctx, "commissionPlanRepository.GetLatest")
+	defer span.End()
+
+	var plan domain.CommissionPlan
+	err := r.client.ExecuteQuery(ctx, "select", "commission_plans", func() error {
+		return r.client.scoped(ctx, "commission_plans").
+			Select("*").
+			Order("created_at", false).
+			Order("id", true).
+			Limit(1).
+			Single(ctx, &plan)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get latest commission plan: %w", err)
+	}
+
+	return &plan, nil
+}
+
+// List returns the commission plans, newest first
+func (r *commissionPlanRepository) List(ctx context.Context) ([]*domain.CommissionPlan, error) {
+	ctx, span := commissionPlanTracer.Start(ctx, "commissionPlanRepository.List")
+	defer span.End()
+
+	plans := []*domain.CommissionPlan{}
+	err := r.client.ExecuteQuery(ctx, "select", "commission_plans", func() error {
+		return r.client.scoped(ctx, "commission_plans").
+			Select("*").
+			Order("created_at", false).
+			Order("id", true).
+			Execute(ctx, &plans)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list commission plans: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(plans)))
+
+	return plans, nil
+}
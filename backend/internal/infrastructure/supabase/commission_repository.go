@@ -0,0 +1,245 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var commissionTracer = otel.Tracer("goreal-backend/infrastructure/supabase/commission")
+
+var commissionSortable = map[string]bool{
+	"commission_type": true, "commission_amount": true, "status": true, "paid_date": true,
+	"created_at": true, "updated_at": true,
+}
+
+// commissionRepository implements domain.CommissionRepository using Supabase
+type commissionRepository struct {
+	client *Client
+}
+
+// NewCommissionRepository creates a new commission repository
+func NewCommissionRepository(client *Client) domain.CommissionRepository {
+	return &commissionRepository{
+		client: client,
+	}
+}
+
+// Create stores a new commission
+func (r *commissionRepository) Create(ctx context.Context, commission *domain.Commission) error {
+	if err := domain.AssignOrganization(ctx, &commission.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("commission.id", commission.ID.String()),
+		attribute.String("sale.id", commission.SaleID.String()),
+		attribute.String("commission.type", commission.CommissionType),
+	)
+
+	now := timestamp()
+	if commission.CreatedAt.IsZero() {
+		commission.CreatedAt = now
+	}
+	if commission.UpdatedAt.IsZero() {
+		commission.UpdatedAt = commission.CreatedAt
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "commissions", func() error {
+		return r.client.From("commissions").Insert(commission).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create commission: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a commission by ID
+func (r *commissionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Commission, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("commission.id", id.String()))
+
+	var commission domain.Commission
+	err := r.client.ExecuteQuery(ctx, "select", "commissions", func() error {
+		return r.client.scoped(ctx, "commissions").
+			Select("*").
+			Eq("id", id).
+			Single(ctx, &commission)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get commission by ID: %w", err)
+	}
+
+	return &commission, nil
+}
+
+// GetByEmployee retrieves the commissions earned by an employee
+func (r *commissionRepository) GetByEmployee(ctx context.Context, employeeID uuid.UUID) ([]*domain.Commission, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.GetByEmployee")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("employee.id", employeeID.String()))
+
+	commissions, err := r.find(ctx, "employee_id", employeeID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get commissions by employee: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(commissions)))
+
+	return commissions, nil
+}
+
+// GetBySale retrieves the commissions for a sale
+func (r *commissionRepository) GetBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.Commission, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.GetBySale")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	commissions, err := r.find(ctx, "sale_id", saleID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get commissions by sale: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(commissions)))
+
+	return commissions, nil
+}
+
+// find returns the commissions whose column equals id, newest first
+func (r *commissionRepository) find(ctx context.Context, column string, id uuid.UUID) ([]*domain.Commission, error) {
+	commissions := []*domain.Commission{}
+	err := r.client.ExecuteQuery(ctx, "select", "commissions", func() error {
+		return r.client.scoped(ctx, "commissions").
+			Select("*").
+			Eq(column, id).
+			Order("created_at", false).
+			Order("id", true).
+			Execute(ctx, &commissions)
+	})
+	return commissions, err
+}
+
+// Update updates an existing commission
+func (r *commissionRepository) Update(ctx context.Context, commission *domain.Commission) error {
+	if err := domain.AssignOrganization(ctx, &commission.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("commission.id", commission.ID.String()),
+		attribute.String("commission.status", string(commission.Status)),
+	)
+
+	since := commission.UpdatedAt
+	commission.UpdatedAt = timestamp()
+
+	err := r.client.ExecuteQuery(ctx, "update", "commissions", func() error {
+		return r.client.scoped(ctx, "commissions").
+			Update(commission).
+			Eq("id", commission.ID).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		commission.UpdatedAt = since
+		span.RecordError(err)
+		return fmt.Errorf("failed to update commission: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a commission by ID
+func (r *commissionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("commission.id", id.String()))
+
+	err := r.client.ExecuteQuery(ctx, "delete", "commissions", func() error {
+		return r.client.scoped(ctx, "commissions").
+			Delete().
+			Eq("id", id).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete commission: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves commissions with pagination and filtering
+func (r *commissionRepository) List(ctx context.Context, filters domain.CommissionFilters) ([]*domain.Commission, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	query := r.client.scoped(ctx, "commissions").Select("*")
+	if filters.SaleID != nil {
+		query = query.Eq("sale_id", *filters.SaleID)
+	}
+	if filters.EmployeeID != nil {
+		query = query.Eq("employee_id", *filters.EmployeeID)
+	}
+	if filters.CommissionType != nil {
+		query = query.Eq("commission_type", *filters.CommissionType)
+	}
+	if filters.Status != nil {
+		query = query.Eq("status", string(*filters.Status))
+	}
+	if filters.AmountMin != nil {
+		query = query.Gte("commission_amount", *filters.AmountMin)
+	}
+	if filters.AmountMax != nil {
+		query = query.Lte("commission_amount", *filters.AmountMax)
+	}
+	if filters.CreatedFrom != nil {
+		query = query.Gte("created_at", *filters.CreatedFrom)
+	}
+	if filters.CreatedTo != nil {
+		query = query.Lte("created_at", *filters.CreatedTo)
+	}
+	query = applySort(query, filters.BaseFilters, commissionSortable, "created_at", false)
+	query = applyPagination(query, filters.BaseFilters)
+
+	commissions := []*domain.Commission{}
+	err := r.client.ExecuteQuery(ctx, "select", "commissions", func() error {
+		return query.Execute(ctx, &commissions)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list commissions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(commissions)))
+
+	return commissions, nil
+}
@@ -27,6 +27,9 @@ func NewCompanyRepository(client *Client) domain.CompanyRepository {
 
 // Create creates a new company
 func (r *companyRepository) Create(ctx context.Context, company *domain.Company) error {
+	if err := domain.AssignOrganization(ctx, &company.OrganizationID); err != nil {
+		return err
+	}
 	ctx, span := companyTracer.Start(ctx, "companyRepository.Create")
 	defer span.End()
 
@@ -35,10 +38,14 @@ func (r *companyRepository) Create(ctx context.Context, company *domain.Company)
 		attribute.String("company.id", company.ID.String()),
 	)
 
-	// Set timestamps
+	// Set timestamps unless the caller supplied them
 	now := time.Now()
-	company.CreatedAt = now
-	company.UpdatedAt = now
+	if company.CreatedAt.IsZero() {
+		company.CreatedAt = now
+	}
+	if company.UpdatedAt.IsZero() {
+		company.UpdatedAt = company.CreatedAt
+	}
 
 	// Execute insert query
 	err := r.client.ExecuteQuery(ctx, "insert", "companies", func() error {
@@ -62,7 +69,7 @@ func (r *companyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 
 	var company domain.Company
 	err := r.client.ExecuteQuery(ctx, "select", "companies", func() error {
-		return r.client.From("companies").
+		return r.client.scoped(ctx, "companies").
 			Select("*").
 			Eq("id", id).
 			Single(ctx, &company)
@@ -85,7 +92,7 @@ func (r *companyRepository) GetByName(ctx context.Context, name string) (*domain
 
 	var company domain.Company
 	err := r.client.ExecuteQuery(ctx, "select", "companies", func() error {
-		return r.client.From("companies").
+		return r.client.scoped(ctx, "companies").
 			Select("*").
 			Eq("name", name).
 			Single(ctx, &company)
@@ -101,6 +108,9 @@ func (r *companyRepository) GetByName(ctx context.Context, name string) (*domain
 
 // Update updates an existing company
 func (r *companyRepository) Update(ctx context.Context, company *domain.Company) error {
+	if err := domain.AssignOrganization(ctx, &company.OrganizationID); err != nil {
+		return err
+	}
 	ctx, span := companyTracer.Start(ctx, "companyRepository.Update")
 	defer span.End()
 
@@ -113,10 +123,10 @@ func (r *companyRepository) Update(ctx context.Context, company *domain.Company)
 	company.UpdatedAt = time.Now()
 
 	err := r.client.ExecuteQuery(ctx, "update", "companies", func() error {
-		return r.client.From("companies").
+		return r.client.scoped(ctx, "companies").
 			Update(company).
 			Eq("id", company.ID).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 
 	if err != nil {
@@ -135,10 +145,10 @@ func (r *companyRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	span.SetAttributes(attribute.String("company.id", id.String()))
 
 	err := r.client.ExecuteQuery(ctx, "delete", "companies", func() error {
-		return r.client.From("companies").
+		return r.client.scoped(ctx, "companies").
 			Delete().
 			Eq("id", id).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 
 	if err != nil {
@@ -159,39 +169,11 @@ func (r *companyRepository) List(ctx context.Context, filters domain.CompanyFilt
 		attribute.Int("filters.offset", filters.Offset),
 	)
 
-	query := r.client.From("companies").Select("*")
+	query := r.client.scoped(ctx, "companies").Select("*")
 
-	// Apply filters
-	if filters.Industry != nil {
-		query = query.Eq("industry", *filters.Industry)
-		span.SetAttributes(attribute.String("filters.industry", *filters.Industry))
-	}
-
-	if filters.Search != nil {
-		// Search in name, email, or website
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("name.ilike.%s,email.ilike.%s,website.ilike.%s",
-			searchPattern, searchPattern, searchPattern))
-		span.SetAttributes(attribute.String("filters.search", *filters.Search))
-	}
-
-	// Apply sorting
-	if filters.SortBy != "" {
-		ascending := filters.SortOrder == "" || filters.SortOrder == "asc"
-		query = query.Order(filters.SortBy, ascending)
-		span.SetAttributes(
-			attribute.String("filters.sort_by", filters.SortBy),
-			attribute.Bool("filters.ascending", ascending),
-		)
-	}
-
-	// Apply pagination
-	if filters.Limit > 0 {
-		query = query.Limit(filters.Limit)
-	}
-	if filters.Offset > 0 {
-		query = query.Offset(filters.Offset)
-	}
+	query = applyCompanyFilters(query, filters)
+	query = applySort(query, filters.BaseFilters, companySortable, "name", true)
+	query = applyPagination(query, filters.BaseFilters)
 
 	var companies []*domain.Company
 	err := r.client.ExecuteQuery(ctx, "select", "companies", func() error {
@@ -213,22 +195,16 @@ func (r *companyRepository) Count(ctx context.Context, filters domain.CompanyFil
 	ctx, span := companyTracer.Start(ctx, "companyRepository.Count")
 	defer span.End()
 
-	query := r.client.From("companies").Select("id")
+	query := r.client.scoped(ctx, "companies").Select("id")
 
-	// Apply filters (same as List method)
-	if filters.Industry != nil {
-		query = query.Eq("industry", *filters.Industry)
-	}
-
-	if filters.Search != nil {
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("name.ilike.%s,email.ilike.%s,website.ilike.%s",
-			searchPattern, searchPattern, searchPattern))
-	}
+	query = applyCompanyFilters(query, filters)
 
-	var companies []*domain.Company
+	var count int
 	err := r.client.ExecuteQuery(ctx, "select", "companies", func() error {
-		return query.Execute(ctx, &companies)
+		var rows []map[string]interface{}
+		var err error
+		count, err = query.ExecuteWithCount(ctx, &rows)
+		return err
 	})
 
 	if err != nil {
@@ -236,12 +212,26 @@ func (r *companyRepository) Count(ctx context.Context, filters domain.CompanyFil
 		return 0, fmt.Errorf("failed to count companies: %w", err)
 	}
 
-	count := len(companies)
 	span.SetAttributes(attribute.Int("result.count", count))
 
 	return count, nil
 }
 
+// applyCompanyFilters adds the CompanyFilters conditions shared by List and Count
+func applyCompanyFilters(query *QueryBuilder, filters domain.CompanyFilters) *QueryBuilder {
+	if filters.Industry != nil {
+		query = query.Eq("industry", *filters.Industry)
+	}
+	if filters.IsActive != nil {
+		query = query.Eq("is_active", *filters.IsActive)
+	}
+	if filters.Search != nil {
+		// Search in name, email, or website
+		query = query.IlikeAny(*filters.Search, "name", "email", "website")
+	}
+	return query
+}
+
 // GetByRegistrationNumber retrieves a company by registration number
 func (r *companyRepository) GetByRegistrationNumber(ctx context.Context, regNumber string) (*domain.Company, error) {
 	ctx, span := companyTracer.Start(ctx, "companyRepository.GetByRegistrationNumber")
@@ -251,7 +241,7 @@ func (r *companyRepository) GetByRegistrationNumber(ctx context.Context, regNumb
 
 	var company domain.Company
 	err := r.client.ExecuteQuery(ctx, "select", "companies", func() error {
-		return r.client.From("companies").
+		return r.client.scoped(ctx, "companies").
 			Select("*").
 			Eq("registration_number", regNumber).
 			Single(ctx, &company)
@@ -274,7 +264,7 @@ func (r *companyRepository) GetByTaxID(ctx context.Context, taxID string) (*doma
 
 	var company domain.Company
 	err := r.client.ExecuteQuery(ctx, "select", "companies", func() error {
-		return r.client.From("companies").
+		return r.client.scoped(ctx, "companies").
 			Select("*").
 			Eq("tax_id", taxID).
 			Single(ctx, &company)
@@ -0,0 +1,41 @@
+package supabase
+
+import (
+	"testing"
+
+	"goreal-backend/internal/infrastructure/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) *repotest.Backend {
+		client, _ := newTestClient(t)
+		return &repotest.Backend{
+			Users:              NewUserRepository(client),
+			Sessions:           NewSessionRepository(client),
+			AuthTokens:         NewAuthTokenRepository(client),
+			MFA:                NewMFARepository(client),
+			AuthEvents:         NewAuthEventRepository(client),
+			Wallets:            NewWalletRepository(client),
+			SIWENonces:         NewSIWENonceRepository(client),
+			Leads:              NewLeadRepository(client),
+			Clients:            NewClientRepository(client),
+			Companies:          NewCompanyRepository(client),
+			Societies:          NewSocietyRepository(client),
+			Projects:           NewProjectRepository(client),
+			Inventory:          NewInventoryRepository(client),
+			Interests:          NewPropertyInterestRepository(client),
+			PriceLists:         NewPriceListRepository(client),
+			Sales:              NewSaleRepository(client),
+			SaleHistory:        NewSaleStatusHistoryRepository(client),
+			AgreementTemplates: NewAgreementTemplateRepository(client),
+			Agreements:         NewAgreementRepository(client),
+			PaymentSchedules:   NewPaymentScheduleRepository(client),
+			PaymentRevisions:   NewPaymentScheduleRevisionRepository(client),
+			Commissions:        NewCommissionRepository(client),
+			CommissionPlans:    NewCommissionPlanRepository(client),
+			Tasks:              NewTaskRepository(client),
+			Notifications:      NewNotificationRepository(client),
+			Organizations:      NewOrganizationRepository(client),
+		}
+	})
+}
@@ -0,0 +1,404 @@
+package supabase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var inventoryTracer = otel.Tracer("goreal-backend/infrastructure/supabase/inventory")
+
+type inventoryRepository struct {
+	client *Client
+}
+
+// NewInventoryRepository creates a new inventory repository
+func NewInventoryRepository(client *Client) domain.InventoryRepository {
+	return &inventoryRepository{
+		client: client,
+	}
+}
+
+// Create creates a new inventory unit
+func (r *inventoryRepository) Create(ctx context.Context, inventory *domain.Inventory) error {
+	if err := domain.AssignOrganization(ctx, &inventory.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("inventory.id", inventory.ID.String()),
+		attribute.String("inventory.project_id", inventory.ProjectID.String()),
+		attribute.String("inventory.unit_number", inventory.UnitNumber),
+	)
+
+	// Set timestamps unless the caller supplied them
+	now := time.Now()
+	if inventory.CreatedAt.IsZero() {
+		inventory.CreatedAt = now
+	}
+	if inventory.UpdatedAt.IsZero() {
+		inventory.UpdatedAt = inventory.CreatedAt
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "inventory", func() error {
+		return r.client.From("inventory").Insert(inventory).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create inventory: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an inventory unit by ID
+func (r *inventoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Inventory, error) {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	var inventory domain.Inventory
+	err := r.client.ExecuteQuery(ctx, "select", "inventory", func() error {
+		return r.client.scoped(ctx, "inventory").
+			Select("*").
+			Eq("id", id).
+			Single(ctx, &inventory)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get inventory by ID: %w", err)
+	}
+
+	return &inventory, nil
+}
+
+// GetByProject retrieves the inventory of a project ordered by unit number
+func (r *inventoryRepository) GetByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Inventory, error) {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.GetByProject")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	var units []*domain.Inventory
+	err := r.client.ExecuteQuery(ctx, "select", "inventory", func() error {
+		return r.client.scoped(ctx, "inventory").
+			Select("*").
+			Eq("project_id", projectID).
+			Order("unit_number", true).
+			Order("id", true).
+			Execute(ctx, &units)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get inventory by project: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(units)))
+
+	return units, nil
+}
+
+// Update updates an existing inventory unit
+func (r *inventoryRepository) Update(ctx context.Context, inventory *domain.Inventory) error {
+	if err := domain.AssignOrganization(ctx, &inventory.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", inventory.ID.String()))
+
+	// Update timestamp
+	since := inventory.UpdatedAt
+	inventory.UpdatedAt = timestamp()
+
+	err := r.client.ExecuteQuery(ctx, "update", "inventory", func() error {
+		err := r.client.scoped(ctx, "inventory").
+			Update(inventory).
+			Eq("id", inventory.ID).
+			Eq("updated_at", since).
+			ExecuteAffected(ctx)
+		return r.client.expectVersion(ctx, "inventory", inventory.ID, err)
+	})
+
+	if err != nil {
+		inventory.UpdatedAt = since
+		span.RecordError(err)
+		return fmt.Errorf("failed to update inventory: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes an inventory unit by ID
+func (r *inventoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	err := r.client.ExecuteQuery(ctx, "delete", "inventory", func() error {
+		return r.client.scoped(ctx, "inventory").
+			Delete().
+			Eq("id", id).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete inventory: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves inventory with pagination and filtering
+func (r *inventoryRepository) List(ctx context.Context, filters domain.InventoryFilters) ([]*domain.Inventory, error) {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	query := r.client.scoped(ctx, "inventory").Select("*")
+	query = applyInventoryFilters(query, filters)
+	query = applySort(query, filters.BaseFilters, inventorySortable, "created_at", false)
+	query = applyPagination(query, filters.BaseFilters)
+
+	var units []*domain.Inventory
+	err := r.client.ExecuteQuery(ctx, "select", "inventory", func() error {
+		return query.Execute(ctx, &units)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list inventory: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(units)))
+
+	return units, nil
+}
+
+// Count returns the total number of inventory units matching the filters
+func (r *inventoryRepository) Count(ctx context.Context, filters domain.InventoryFilters) (int, error) {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Count")
+	defer span.End()
+
+	query := r.client.scoped(ctx, "inventory").Select("id")
+	query = applyInventoryFilters(query, filters)
+
+	var count int
+	err := r.client.ExecuteQuery(ctx, "select", "inventory", func() error {
+		var rows []map[string]interface{}
+		var err error
+		count, err = query.ExecuteWithCount(ctx, &rows)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count inventory: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+// GetAvailable retrieves the available units of a project
+func (r *inventoryRepository) GetAvailable(ctx context.Context, projectID uuid.UUID) ([]*domain.Inventory, error) {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.GetAvailable")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	var units []*domain.Inventory
+	err := r.client.ExecuteQuery(ctx, "select", "inventory", func() error {
+		return r.client.scoped(ctx, "inventory").
+			Select("*").
+			Eq("project_id", projectID).
+			Eq("status", string(domain.UnitStatusAvailable)).
+			Order("unit_number", true).
+			Order("id", true).
+			Execute(ctx, &units)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get available inventory: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(units)))
+
+	return units, nil
+}
+
+// Reserve marks an available unit as reserved for a client until the given
+// time. The status check is part of the update filter, so two callers can
+// never reserve the same unit.
+func (r *inventoryRepository) Reserve(ctx context.Context, id uuid.UUID, clientID uuid.UUID, until time.Time) error {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Reserve")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("inventory.id", id.String()),
+		attribute.String("client.id", clientID.String()),
+	)
+
+	updateData := map[string]interface{}{
+		"status":         string(domain.UnitStatusReserved),
+		"reserved_by":    clientID,
+		"reserved_until": until,
+		"updated_at":     time.Now(),
+	}
+
+	err := r.client.ExecuteQuery(ctx, "update", "inventory", func() error {
+		return r.client.scoped(ctx, "inventory").
+			Update(updateData).
+			Eq("id", id).
+			Eq("status", string(domain.UnitStatusAvailable)).
+			ExecuteAffected(ctx)
+	})
+
+	if errors.Is(err, domain.ErrNotFound) {
+		// Nothing was updated: tell a missing unit from an unavailable one
+		if _, getErr := r.GetByID(ctx, id); getErr == nil {
+			err = domain.ErrInventoryNotAvailable
+		}
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to reserve inventory: %w", err)
+	}
+
+	return nil
+}
+
+// Release returns a reserved unit to the available pool
+func (r *inventoryRepository) Release(ctx context.Context, id uuid.UUID) error {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.Release")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	updateData := map[string]interface{}{
+		"status":         string(domain.UnitStatusAvailable),
+		"reserved_by":    nil,
+		"reserved_until": nil,
+		"updated_at":     time.Now(),
+	}
+
+	err := r.client.ExecuteQuery(ctx, "update", "inventory", func() error {
+		return r.client.scoped(ctx, "inventory").
+			Update(updateData).
+			Eq("id", id).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to release inventory: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseIfExpired releases a unit only if its reservation ran out by at.
+// The expiry check is part of the update filter, so a reservation extended
+// or converted to a sale in the meantime is left alone.
+func (r *inventoryRepository) ReleaseIfExpired(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, span := inventoryTracer.Start(ctx, "inventoryRepository.ReleaseIfExpired")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	updateData := map[string]interface{}{
+		"status":         string(domain.UnitStatusAvailable),
+		"reserved_by":    nil,
+		"reserved_until": nil,
+		"updated_at":     time.Now(),
+	}
+
+	err := r.client.ExecuteQuery(ctx, "update", "inventory", func() error {
+		return r.client.scoped(ctx, "inventory").
+			Update(updateData).
+			Eq("id", id).
+			Eq("status", string(domain.UnitStatusReserved)).
+			Lte("reserved_until", at).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to release expired inventory: %w", err)
+	}
+
+	return nil
+}
+
+// applyInventoryFilters adds the InventoryFilters conditions shared by List and Count
+func applyInventoryFilters(query *QueryBuilder, filters domain.InventoryFilters) *QueryBuilder {
+	if filters.ProjectID != nil {
+		query = query.Eq("project_id", *filters.ProjectID)
+	}
+	if len(filters.ProjectIDs) > 0 {
+		query = query.In("project_id", filters.ProjectIDs)
+	}
+	if filters.UnitType != nil {
+		query = query.Eq("unit_type", *filters.UnitType)
+	}
+	if filters.Status != nil {
+		query = query.Eq("status", string(*filters.Status))
+	}
+	if filters.FloorNumber != nil {
+		query = query.Eq("floor_number", *filters.FloorNumber)
+	}
+	if filters.Facing != nil {
+		query = query.Eq("facing", *filters.Facing)
+	}
+	if filters.PriceMin != nil {
+		query = query.Gte("final_price", *filters.PriceMin)
+	}
+	if filters.PriceMax != nil {
+		query = query.Lte("final_price", *filters.PriceMax)
+	}
+	if filters.AreaMin != nil {
+		query = query.Gte("carpet_area", *filters.AreaMin)
+	}
+	if filters.AreaMax != nil {
+		query = query.Lte("carpet_area", *filters.AreaMax)
+	}
+	if filters.ParkingSlots != nil {
+		query = query.Eq("parking_slots", *filters.ParkingSlots)
+	}
+	if filters.MinParkingSlots != nil {
+		query = query.Gte("parking_slots", *filters.MinParkingSlots)
+	}
+	if filters.ReservedBy != nil {
+		query = query.Eq("reserved_by", *filters.ReservedBy)
+	}
+	if filters.ReservedUntilTo != nil {
+		query = query.Lte("reserved_until", *filters.ReservedUntilTo)
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		query = query.IlikeAny(*filters.Search, "unit_number", "unit_type", "tower_block")
+	}
+	return query
+}
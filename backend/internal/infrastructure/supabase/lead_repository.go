@@ -24,6 +24,9 @@ func NewLeadRepository(client *Client) domain.LeadRepository {
 }
 
 func (r *leadRepository) Create(ctx context.Context, lead *domain.Lead) error {
+	if err := domain.AssignOrganization(ctx, &lead.OrganizationID); err != nil {
+		return err
+	}
 	dbLead := r.domainToDBLead(lead)
 
 	return r.client.ExecuteQuery(ctx, "insert", "leads", func() error {
@@ -33,17 +36,18 @@ func (r *leadRepository) Create(ctx context.Context, lead *domain.Lead) error {
 
 func (r *leadRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Lead, error) {
 	var dbLead dbLead
-	
+
 	err := r.client.ExecuteQuery(ctx, "select_by_id", "leads", func() error {
-		return r.client.From("leads").
+		return r.client.scoped(ctx, "leads").
 			Select(`
 				*,
 				assigned_user:assigned_to(id, username, full_name, email, role)
 			`).
 			Eq("id", id).
+			IsNull("deleted_at").
 			Single(ctx, &dbLead)
 	})
-	
+
 	if err != nil {
 		return nil, fmt.Errorf("lead not found: %w", err)
 	}
@@ -52,96 +56,66 @@ func (r *leadRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Lea
 }
 
 func (r *leadRepository) Update(ctx context.Context, lead *domain.Lead) error {
+	if err := domain.AssignOrganization(ctx, &lead.OrganizationID); err != nil {
+		return err
+	}
 	dbLead := r.domainToDBLead(lead)
-	dbLead.UpdatedAt = time.Now()
+	dbLead.UpdatedAt = timestamp()
 
-	return r.client.ExecuteQuery(ctx, "update", "leads", func() error {
-		return r.client.From("leads").
+	err := r.client.ExecuteQuery(ctx, "update", "leads", func() error {
+		err := r.client.scoped(ctx, "leads").
 			Update(dbLead).
 			Eq("id", lead.ID).
-			Execute(ctx, nil)
+			Eq("updated_at", lead.UpdatedAt).
+			IsNull("deleted_at").
+			ExecuteAffected(ctx)
+		return r.client.expectVersion(ctx, "leads", lead.ID, err)
 	})
+	if err != nil {
+		return err
+	}
+
+	lead.UpdatedAt = dbLead.UpdatedAt
+	return nil
 }
 
-func (r *leadRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (r *leadRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
 	return r.client.ExecuteQuery(ctx, "delete", "leads", func() error {
-		return r.client.From("leads").
-			Delete().
-			Eq("id", id).
-			Execute(ctx, nil)
+		return r.client.softDelete(ctx, "leads", id, deletedBy)
 	})
 }
 
+func (r *leadRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.client.ExecuteQuery(ctx, "restore", "leads", func() error {
+		return r.client.restore(ctx, "leads", id)
+	})
+}
+
+func (r *leadRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var purged int
+	err := r.client.ExecuteQuery(ctx, "purge", "leads", func() error {
+		var err error
+		purged, err = r.client.purge(ctx, "leads", deletedBefore)
+		return err
+	})
+	return purged, err
+}
+
 func (r *leadRepository) List(ctx context.Context, filters domain.LeadFilters) ([]*domain.Lead, error) {
-	query := r.client.From("leads").Select(`
+	query := r.client.scoped(ctx, "leads").Select(`
 		*,
 		assigned_user:assigned_to(id, username, full_name, email, role)
 	`)
 
-	// Apply filters
-	if filters.Status != nil {
-		query = query.Eq("status", string(*filters.Status))
-	}
-	if filters.Source != nil {
-		query = query.Eq("source", string(*filters.Source))
-	}
-	if filters.AssignedTo != nil {
-		query = query.Eq("assigned_to", *filters.AssignedTo)
-	}
-	if filters.CreatedBy != nil {
-		query = query.Eq("created_by", *filters.CreatedBy)
-	}
-	if filters.BudgetMin != nil {
-		query = query.Gte("budget_min", *filters.BudgetMin)
-	}
-	if filters.BudgetMax != nil {
-		query = query.Lte("budget_max", *filters.BudgetMax)
-	}
-	if filters.ScoreMin != nil {
-		query = query.Gte("score", *filters.ScoreMin)
-	}
-	if filters.ScoreMax != nil {
-		query = query.Lte("score", *filters.ScoreMax)
-	}
-	if filters.CreatedAfter != nil {
-		query = query.Gte("created_at", filters.CreatedAfter.Format(time.RFC3339))
-	}
-	if filters.CreatedBefore != nil {
-		query = query.Lte("created_at", filters.CreatedBefore.Format(time.RFC3339))
-	}
-	if filters.Search != nil && *filters.Search != "" {
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("name.ilike.%s,email.ilike.%s,company_name.ilike.%s,requirements.ilike.%s", 
-			searchPattern, searchPattern, searchPattern, searchPattern))
-	}
-	if len(filters.Tags) > 0 {
-		// PostgreSQL array contains operator
-		for _, tag := range filters.Tags {
-			query = query.Contains("tags", fmt.Sprintf(`["%s"]`, tag))
-		}
-	}
-
-	// Apply sorting
-	if filters.SortBy != "" {
-		ascending := filters.SortOrder != "desc"
-		query = query.Order(filters.SortBy, ascending)
-	} else {
-		query = query.Order("created_at", false) // Default sort by created_at desc
-	}
-
-	// Apply pagination
-	if filters.Limit > 0 {
-		query = query.Limit(filters.Limit)
-	}
-	if filters.Offset > 0 {
-		query = query.Offset(filters.Offset)
-	}
+	query = applyLeadFilters(query, filters)
+	query = applySort(query, filters.BaseFilters, leadSortable, "created_at", false)
+	query = applyPagination(query, filters.BaseFilters)
 
 	var dbLeads []dbLead
 	err := r.client.ExecuteQuery(ctx, "select_list", "leads", func() error {
 		return query.Execute(ctx, &dbLeads)
 	})
-	
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to list leads: %w", err)
 	}
@@ -156,9 +130,24 @@ func (r *leadRepository) List(ctx context.Context, filters domain.LeadFilters) (
 }
 
 func (r *leadRepository) Count(ctx context.Context, filters domain.LeadFilters) (int, error) {
-	query := r.client.From("leads").Select("id")
+	query := r.client.scoped(ctx, "leads").Select("id")
+
+	query = applyLeadFilters(query, filters)
+
+	var result []map[string]interface{}
+	count, err := query.ExecuteWithCount(ctx, &result)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count leads: %w", err)
+	}
+
+	return count, nil
+}
 
-	// Apply same filters as List method
+// applyLeadFilters adds the LeadFilters conditions shared by List and Count
+func applyLeadFilters(query *QueryBuilder, filters domain.LeadFilters) *QueryBuilder {
+	if !filters.IncludeDeleted {
+		query = query.IsNull("deleted_at")
+	}
 	if filters.Status != nil {
 		query = query.Eq("status", string(*filters.Status))
 	}
@@ -184,40 +173,36 @@ func (r *leadRepository) Count(ctx context.Context, filters domain.LeadFilters)
 		query = query.Lte("score", *filters.ScoreMax)
 	}
 	if filters.CreatedAfter != nil {
-		query = query.Gte("created_at", filters.CreatedAfter.Format(time.RFC3339))
+		query = query.Gte("created_at", *filters.CreatedAfter)
 	}
 	if filters.CreatedBefore != nil {
-		query = query.Lte("created_at", filters.CreatedBefore.Format(time.RFC3339))
+		query = query.Lte("created_at", *filters.CreatedBefore)
 	}
 	if filters.Search != nil && *filters.Search != "" {
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("name.ilike.%s,email.ilike.%s,company_name.ilike.%s,requirements.ilike.%s", 
-			searchPattern, searchPattern, searchPattern, searchPattern))
+		query = query.IlikeAny(*filters.Search, "name", "email", "company_name", "requirements")
 	}
-
-	var result []map[string]interface{}
-	count, err := query.ExecuteWithCount(ctx, &result)
-	if err != nil {
-		return 0, fmt.Errorf("failed to count leads: %w", err)
+	if len(filters.Tags) > 0 {
+		// PostgreSQL array contains operator: tags @> '{a,b}'
+		query = query.Contains("tags", filters.Tags)
 	}
-
-	return count, nil
+	return query
 }
 
 func (r *leadRepository) GetByAssignedUser(ctx context.Context, userID uuid.UUID) ([]*domain.Lead, error) {
 	var dbLeads []dbLead
-	
+
 	err := r.client.ExecuteQuery(ctx, "select_by_assigned_user", "leads", func() error {
-		return r.client.From("leads").
+		return r.client.scoped(ctx, "leads").
 			Select(`
 				*,
 				assigned_user:assigned_to(id, username, full_name, email, role)
 			`).
 			Eq("assigned_to", userID).
+			IsNull("deleted_at").
 			Order("created_at", false).
 			Execute(ctx, &dbLeads)
 	})
-	
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to get leads by assigned user: %w", err)
 	}
@@ -233,21 +218,22 @@ func (r *leadRepository) GetByAssignedUser(ctx context.Context, userID uuid.UUID
 
 func (r *leadRepository) GetOverdueFollowUps(ctx context.Context) ([]*domain.Lead, error) {
 	var dbLeads []dbLead
-	
+
 	err := r.client.ExecuteQuery(ctx, "select_overdue_followups", "leads", func() error {
-		return r.client.From("leads").
+		return r.client.scoped(ctx, "leads").
 			Select(`
 				*,
 				assigned_user:assigned_to(id, username, full_name, email, role)
 			`).
 			Lt("next_follow_up", time.Now().Format(time.RFC3339)).
 			IsNotNull("next_follow_up").
+			IsNull("deleted_at").
 			Neq("status", string(domain.LeadStatusConverted)).
 			Neq("status", string(domain.LeadStatusLost)).
 			Order("next_follow_up", true).
 			Execute(ctx, &dbLeads)
 	})
-	
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to get overdue follow-ups: %w", err)
 	}
@@ -263,34 +249,38 @@ func (r *leadRepository) GetOverdueFollowUps(ctx context.Context) ([]*domain.Lea
 
 // dbLead represents the database model for leads
 type dbLead struct {
-	ID               uuid.UUID              `json:"id" db:"id"`
-	Name             string                 `json:"name" db:"name"`
-	Email            *string                `json:"email" db:"email"`
-	Phone            *string                `json:"phone" db:"phone"`
-	CompanyName      *string                `json:"company_name" db:"company_name"`
-	Designation      *string                `json:"designation" db:"designation"`
-	Source           string                 `json:"source" db:"source"`
-	Status           string                 `json:"status" db:"status"`
-	AssignedTo       *uuid.UUID             `json:"assigned_to" db:"assigned_to"`
-	AssignedUser     *dbUser                `json:"assigned_user,omitempty"`
-	BudgetMin        *float64               `json:"budget_min" db:"budget_min"`
-	BudgetMax        *float64               `json:"budget_max" db:"budget_max"`
-	Requirements     *string                `json:"requirements" db:"requirements"`
-	Notes            *string                `json:"notes" db:"notes"`
-	LastContactDate  *time.Time             `json:"last_contact_date" db:"last_contact_date"`
-	NextFollowUp     *time.Time             `json:"next_follow_up" db:"next_follow_up"`
-	Score            int                    `json:"score" db:"score"`
-	Tags             pq.StringArray         `json:"tags" db:"tags"`
-	CustomFields     map[string]interface{} `json:"custom_fields" db:"custom_fields"`
-	CreatedBy        uuid.UUID              `json:"created_by" db:"created_by"`
-	CreatedAt        time.Time              `json:"created_at" db:"created_at"`
-	UpdatedAt        time.Time              `json:"updated_at" db:"updated_at"`
+	ID              uuid.UUID              `json:"id" db:"id"`
+	OrganizationID  *uuid.UUID             `json:"organization_id" db:"organization_id"`
+	Name            string                 `json:"name" db:"name"`
+	Email           *string                `json:"email" db:"email"`
+	Phone           *string                `json:"phone" db:"phone"`
+	CompanyName     *string                `json:"company_name" db:"company_name"`
+	Designation     *string                `json:"designation" db:"designation"`
+	Source          string                 `json:"source" db:"source"`
+	Status          string                 `json:"status" db:"status"`
+	AssignedTo      *uuid.UUID             `json:"assigned_to" db:"assigned_to"`
+	AssignedUser    *dbUser                `json:"assigned_user,omitempty"`
+	BudgetMin       *float64               `json:"budget_min" db:"budget_min"`
+	BudgetMax       *float64               `json:"budget_max" db:"budget_max"`
+	Requirements    *string                `json:"requirements" db:"requirements"`
+	Notes           *string                `json:"notes" db:"notes"`
+	LastContactDate *time.Time             `json:"last_contact_date" db:"last_contact_date"`
+	NextFollowUp    *time.Time             `json:"next_follow_up" db:"next_follow_up"`
+	Score           int                    `json:"score" db:"score"`
+	Tags            pq.StringArray         `json:"tags" db:"tags"`
+	CustomFields    map[string]interface{} `json:"custom_fields" db:"custom_fields"`
+	CreatedBy       uuid.UUID              `json:"created_by" db:"created_by"`
+	CreatedAt       time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at" db:"updated_at"`
+	DeletedAt       *time.Time             `json:"deleted_at,omitempty" db:"deleted_at"`
+	DeletedBy       *uuid.UUID             `json:"deleted_by,omitempty" db:"deleted_by"`
 }
 
 // domainToDBLead converts domain lead to database model
 func (r *leadRepository) domainToDBLead(lead *domain.Lead) *dbLead {
 	return &dbLead{
 		ID:              lead.ID,
+		OrganizationID:  lead.OrganizationID,
 		Name:            lead.Name,
 		Email:           lead.Email,
 		Phone:           lead.Phone,
@@ -311,6 +301,8 @@ func (r *leadRepository) domainToDBLead(lead *domain.Lead) *dbLead {
 		CreatedBy:       lead.CreatedBy,
 		CreatedAt:       lead.CreatedAt,
 		UpdatedAt:       lead.UpdatedAt,
+		DeletedAt:       lead.DeletedAt,
+		DeletedBy:       lead.DeletedBy,
 	}
 }
 
@@ -318,6 +310,7 @@ func (r *leadRepository) domainToDBLead(lead *domain.Lead) *dbLead {
 func (r *leadRepository) dbToDomainLead(dbLead *dbLead) *domain.Lead {
 	lead := &domain.Lead{
 		ID:              dbLead.ID,
+		OrganizationID:  dbLead.OrganizationID,
 		Name:            dbLead.Name,
 		Email:           dbLead.Email,
 		Phone:           dbLead.Phone,
@@ -338,6 +331,8 @@ func (r *leadRepository) dbToDomainLead(dbLead *dbLead) *domain.Lead {
 		CreatedBy:       dbLead.CreatedBy,
 		CreatedAt:       dbLead.CreatedAt,
 		UpdatedAt:       dbLead.UpdatedAt,
+		DeletedAt:       dbLead.DeletedAt,
+		DeletedBy:       dbLead.DeletedBy,
 	}
 
 	// Convert assigned user if present
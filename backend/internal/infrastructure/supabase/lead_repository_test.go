@@ -0,0 +1,113 @@
+package supabase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLead(name string, status domain.LeadStatus, score int, tags ...string) *domain.Lead {
+	now := time.Now().UTC().Truncate(time.Second)
+	return &domain.Lead{
+		ID:        uuid.New(),
+		Name:      name,
+		Source:    domain.LeadSourceWebsite,
+		Status:    status,
+		Score:     score,
+		Tags:      tags,
+		CreatedBy: uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func TestLeadRepository_CRUD(t *testing.T) {
+	client, _ := newTestClient(t)
+	repo := NewLeadRepository(client)
+	ctx := domain.AcrossOrganizations(context.Background())
+
+	lead := newTestLead("Acme Corp", domain.LeadStatusNew, 40, "vip")
+	require.NoError(t, repo.Create(ctx, lead))
+
+	got, err := repo.GetByID(ctx, lead.ID)
+	require.NoError(t, err)
+	assert.Equal(t, lead.Name, got.Name)
+	assert.Equal(t, lead.Tags, got.Tags)
+	assert.True(t, lead.CreatedAt.Equal(got.CreatedAt))
+
+	got.Status = domain.LeadStatusQualified
+	require.NoError(t, repo.Update(ctx, got))
+
+	got, err = repo.GetByID(ctx, lead.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LeadStatusQualified, got.Status)
+
+	require.NoError(t, repo.Delete(ctx, lead.ID, lead.CreatedBy))
+
+	_, err = repo.GetByID(ctx, lead.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestLeadRepository_ListFilters(t *testing.T) {
+	client, _ := newTestClient(t)
+	repo := NewLeadRepository(client)
+	ctx := domain.AcrossOrganizations(context.Background())
+
+	leads := []*domain.Lead{
+		newTestLead("Acme Corp", domain.LeadStatusNew, 80, "vip", "hot"),
+		newTestLead("Globex", domain.LeadStatusNew, 20, "vip"),
+		newTestLead("Initech", domain.LeadStatusLost, 60, "hot"),
+		newTestLead(`Hale "and" Sons (a,b)`, domain.LeadStatusLost, 10),
+	}
+	for _, lead := range leads {
+		require.NoError(t, repo.Create(ctx, lead))
+	}
+
+	status := domain.LeadStatusNew
+	got, err := repo.List(ctx, domain.LeadFilters{Status: &status})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	got, err = repo.List(ctx, domain.LeadFilters{Tags: []string{"vip", "hot"}})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "Acme Corp", got[0].Name)
+
+	search := "glob"
+	got, err = repo.List(ctx, domain.LeadFilters{Search: &search})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "Globex", got[0].Name)
+
+	// Reserved characters in search text are matched, not parsed
+	for _, search := range []string{"a,b)", `"and"`} {
+		got, err = repo.List(ctx, domain.LeadFilters{Search: &search})
+		require.NoError(t, err, search)
+		require.Len(t, got, 1, search)
+		assert.Equal(t, `Hale "and" Sons (a,b)`, got[0].Name)
+	}
+	injected := "x%,name.ilike.%"
+	got, err = repo.List(ctx, domain.LeadFilters{Search: &injected})
+	require.NoError(t, err)
+	assert.Empty(t, got, "search text cannot add conditions")
+
+	got, err = repo.List(ctx, domain.LeadFilters{
+		BaseFilters: domain.BaseFilters{SortBy: "score", SortOrder: "desc", Limit: 2},
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "Acme Corp", got[0].Name)
+	assert.Equal(t, "Initech", got[1].Name)
+
+	scoreMin := 50
+	count, err := repo.Count(ctx, domain.LeadFilters{ScoreMin: &scoreMin})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
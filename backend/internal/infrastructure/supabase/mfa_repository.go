@@ -0,0 +1,267 @@
+package supabase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var mfaTracer = otel.Tracer("goreal-backend/infrastructure/supabase/mfa")
+
+// mfaRepository implements domain.MFARepository using Supabase
+type mfaRepository struct {
+	client *Client
+}
+
+// NewMFARepository creates a new MFA repository
+func NewMFARepository(client *Client) domain.MFARepository {
+	return &mfaRepository{
+		client: client,
+	}
+}
+
+// Save creates or replaces the authenticator of a user
+func (r *mfaRepository) Save(ctx context.Context, enrollment *domain.MFAEnrollment) error {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.Save")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", enrollment.UserID.String()))
+
+	now := time.Now()
+	if enrollment.CreatedAt.IsZero() {
+		enrollment.CreatedAt = now
+	}
+	enrollment.UpdatedAt = now
+
+	row := toDBMFAEnrollment(enrollment)
+	err := r.client.ExecuteQuery(ctx, "upsert", "user_mfa", func() error {
+		err := r.client.From("user_mfa").
+			Update(row).
+			Eq("user_id", enrollment.UserID).
+			ExecuteAffected(ctx)
+		if !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		return r.client.From("user_mfa").Insert(row).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to save MFA enrollment: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUser retrieves the authenticator of a user
+func (r *mfaRepository) GetByUser(ctx context.Context, userID uuid.UUID) (*domain.MFAEnrollment, error) {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.GetByUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	var row dbMFAEnrollment
+	err := r.client.ExecuteQuery(ctx, "select", "user_mfa", func() error {
+		return r.client.From("user_mfa").
+			Select("*").
+			Eq("user_id", userID).
+			Single(ctx, &row)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get MFA enrollment: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// UseStep records an accepted code in an update guarded by the last step,
+// so of two logins racing with one code only the first succeeds
+func (r *mfaRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64, at time.Time) error {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.UseStep")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	err := r.client.ExecuteQuery(ctx, "update", "user_mfa", func() error {
+		return r.client.From("user_mfa").
+			Update(map[string]interface{}{
+				"last_step":  step,
+				"updated_at": at,
+			}).
+			Eq("user_id", userID).
+			Lt("last_step", step).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to use MFA code: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the authenticator and recovery codes of a user
+func (r *mfaRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	err := r.client.ExecuteQuery(ctx, "delete", "user_mfa", func() error {
+		if err := r.client.From("mfa_recovery_codes").Delete().Eq("user_id", userID).Execute(ctx, nil); err != nil {
+			return err
+		}
+
+		var rows []json.RawMessage
+		if err := r.client.From("user_mfa").Select("user_id").Delete().Eq("user_id", userID).Execute(ctx, &rows); err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("no MFA enrollment for user %s: %w", userID, domain.ErrNotFound)
+		}
+		return nil
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete MFA enrollment: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes swaps a user's recovery codes for new ones
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string, at time.Time) error {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.ReplaceRecoveryCodes")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.Int("codes.count", len(codeHashes)),
+	)
+
+	rows := make([]dbRecoveryCode, len(codeHashes))
+	for i, hash := range codeHashes {
+		rows[i] = dbRecoveryCode{ID: uuid.New(), UserID: userID, CodeHash: hash, CreatedAt: at}
+	}
+
+	err := r.client.ExecuteQuery(ctx, "replace", "mfa_recovery_codes", func() error {
+		if err := r.client.From("mfa_recovery_codes").Delete().Eq("user_id", userID).Execute(ctx, nil); err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return r.client.From("mfa_recovery_codes").Insert(rows).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode redeems an unused recovery code in a single guarded update
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, at time.Time) error {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.UseRecoveryCode")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	err := r.client.ExecuteQuery(ctx, "update", "mfa_recovery_codes", func() error {
+		return r.client.From("mfa_recovery_codes").
+			Update(map[string]interface{}{"used_at": at}).
+			Eq("user_id", userID).
+			Eq("code_hash", codeHash).
+			IsNull("used_at").
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	return nil
+}
+
+// CountRecoveryCodes returns how many unused recovery codes a user has
+func (r *mfaRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, span := mfaTracer.Start(ctx, "mfaRepository.CountRecoveryCodes")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	var rows []json.RawMessage
+	err := r.client.ExecuteQuery(ctx, "count", "mfa_recovery_codes", func() error {
+		return r.client.From("mfa_recovery_codes").
+			Select("id").
+			Eq("user_id", userID).
+			IsNull("used_at").
+			Execute(ctx, &rows)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return len(rows), nil
+}
+
+// dbMFAEnrollment represents the database model for authenticators; unlike
+// domain.MFAEnrollment it carries the secret and last step in its JSON
+type dbMFAEnrollment struct {
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	Secret      string     `json:"secret" db:"secret"`
+	ConfirmedAt *time.Time `json:"confirmed_at" db:"confirmed_at"`
+	LastStep    int64      `json:"last_step" db:"last_step"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// toDBMFAEnrollment converts a domain authenticator to its database model
+func toDBMFAEnrollment(enrollment *domain.MFAEnrollment) *dbMFAEnrollment {
+	return &dbMFAEnrollment{
+		UserID:      enrollment.UserID,
+		Secret:      enrollment.Secret,
+		ConfirmedAt: enrollment.ConfirmedAt,
+		LastStep:    enrollment.LastStep,
+		CreatedAt:   enrollment.CreatedAt,
+		UpdatedAt:   enrollment.UpdatedAt,
+	}
+}
+
+// toDomain converts a database authenticator model to the domain model
+func (e *dbMFAEnrollment) toDomain() *domain.MFAEnrollment {
+	return &domain.MFAEnrollment{
+		UserID:      e.UserID,
+		Secret:      e.Secret,
+		ConfirmedAt: e.ConfirmedAt,
+		LastStep:    e.LastStep,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+// dbRecoveryCode represents the database model for recovery codes
+type dbRecoveryCode struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	CodeHash  string     `json:"code_hash" db:"code_hash"`
+	UsedAt    *time.Time `json:"used_at" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
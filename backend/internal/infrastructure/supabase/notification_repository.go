@@ -37,8 +37,10 @@ func (r *notificationRepository) Create(ctx context.Context, notification *domai
 		attribute.String("notification.title", notification.Title),
 	)
 
-	// Set timestamp
-	notification.CreatedAt = time.Now()
+	// Set timestamp unless the caller supplied one
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
 
 	// Execute insert query
 	err := r.client.ExecuteQuery(ctx, "insert", "notifications", func() error {
@@ -90,7 +92,7 @@ func (r *notificationRepository) Update(ctx context.Context, notification *domai
 		return r.client.From("notifications").
 			Update(notification).
 			Eq("id", notification.ID).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 
 	if err != nil {
@@ -112,7 +114,7 @@ func (r *notificationRepository) Delete(ctx context.Context, id uuid.UUID) error
 		return r.client.From("notifications").
 			Delete().
 			Eq("id", id).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 
 	if err != nil {
@@ -222,7 +224,7 @@ func (r *notificationRepository) MarkAsRead(ctx context.Context, id uuid.UUID) e
 		return r.client.From("notifications").
 			Update(updateData).
 			Eq("id", id).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 
 	if err != nil {
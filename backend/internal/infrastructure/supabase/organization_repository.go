@@ -0,0 +1,174 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var organizationTracer = otel.Tracer("goreal-backend/infrastructure/supabase/organization")
+
+// organizationRepository implements domain.OrganizationRepository using
+// Supabase. A tenant scoped context only ever sees its own organization.
+type organizationRepository struct {
+	client *Client
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(client *Client) domain.OrganizationRepository {
+	return &organizationRepository{
+		client: client,
+	}
+}
+
+// Create creates a new organization
+func (r *organizationRepository) Create(ctx context.Context, organization *domain.Organization) error {
+	ctx, span := organizationTracer.Start(ctx, "organizationRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("organization.id", organization.ID.String()),
+		attribute.String("organization.slug", organization.Slug),
+	)
+
+	// Set timestamps unless the caller supplied them
+	now := time.Now()
+	if organization.CreatedAt.IsZero() {
+		organization.CreatedAt = now
+	}
+	if organization.UpdatedAt.IsZero() {
+		organization.UpdatedAt = organization.CreatedAt
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "organizations", func() error {
+		return r.client.From("organizations").Insert(organization).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an organization by ID
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	ctx, span := organizationTracer.Start(ctx, "organizationRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("organization.id", id.String()))
+
+	var organization domain.Organization
+	err := r.client.ExecuteQuery(ctx, "select", "organizations", func() error {
+		return r.scoped(ctx).
+			Select("*").
+			Eq("id", id).
+			Single(ctx, &organization)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get organization by ID: %w", err)
+	}
+
+	return &organization, nil
+}
+
+// GetBySlug retrieves an organization by slug
+func (r *organizationRepository) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	ctx, span := organizationTracer.Start(ctx, "organizationRepository.GetBySlug")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("organization.slug", slug))
+
+	var organization domain.Organization
+	err := r.client.ExecuteQuery(ctx, "select", "organizations", func() error {
+		return r.scoped(ctx).
+			Select("*").
+			Eq("slug", slug).
+			Single(ctx, &organization)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get organization by slug: %w", err)
+	}
+
+	return &organization, nil
+}
+
+// Update updates an existing organization
+func (r *organizationRepository) Update(ctx context.Context, organization *domain.Organization) error {
+	ctx, span := organizationTracer.Start(ctx, "organizationRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("organization.id", organization.ID.String()))
+
+	organization.UpdatedAt = time.Now()
+
+	err := r.client.ExecuteQuery(ctx, "update", "organizations", func() error {
+		return r.scoped(ctx).
+			Update(organization).
+			Eq("id", organization.ID).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves organizations with pagination and filtering
+func (r *organizationRepository) List(ctx context.Context, filters domain.OrganizationFilters) ([]*domain.Organization, error) {
+	ctx, span := organizationTracer.Start(ctx, "organizationRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	query := r.scoped(ctx).Select("*")
+
+	if filters.IsActive != nil {
+		query = query.Eq("is_active", *filters.IsActive)
+	}
+	if filters.Search != nil {
+		query = query.IlikeAny(*filters.Search, "name", "slug")
+	}
+	query = applySort(query, filters.BaseFilters, organizationSortable, "name", true)
+	query = applyPagination(query, filters.BaseFilters)
+
+	var organizations []*domain.Organization
+	err := r.client.ExecuteQuery(ctx, "select", "organizations", func() error {
+		return query.Execute(ctx, &organizations)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(organizations)))
+
+	return organizations, nil
+}
+
+// scoped limits a tenant scoped context to its own organization
+func (r *organizationRepository) scoped(ctx context.Context) *QueryBuilder {
+	query := r.client.From("organizations")
+	if id, ok := domain.OrganizationFromContext(ctx); ok {
+		query = query.Eq("id", id)
+	}
+	return query
+}
@@ -0,0 +1,242 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var paymentScheduleTracer = otel.Tracer("goreal-backend/infrastructure/supabase/payment_schedule")
+
+var paymentScheduleSortable = map[string]bool{
+	"installment_number": true, "due_date": true, "amount": true, "status": true,
+	"created_at": true, "updated_at": true,
+}
+
+// paymentScheduleRepository implements domain.PaymentScheduleRepository using Supabase
+type paymentScheduleRepository struct {
+	client *Client
+}
+
+// NewPaymentScheduleRepository creates a new payment schedule repository
+func NewPaymentScheduleRepository(client *Client) domain.PaymentScheduleRepository {
+	return &paymentScheduleRepository{
+		client: client,
+	}
+}
+
+// Create stores a new installment
+func (r *paymentScheduleRepository) Create(ctx context.Context, schedule *domain.PaymentSchedule) error {
+	if err := domain.AssignOrganization(ctx, &schedule.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("payment.id", schedule.ID.String()),
+		attribute.String("sale.id", schedule.SaleID.String()),
+		attribute.Int("payment.installment_number", schedule.InstallmentNumber),
+	)
+
+	now := timestamp()
+	if schedule.CreatedAt.IsZero() {
+		schedule.CreatedAt = now
+	}
+	if schedule.UpdatedAt.IsZero() {
+		schedule.UpdatedAt = schedule.CreatedAt
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "payment_schedules", func() error {
+		return r.client.From("payment_schedules").Insert(schedule).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create payment schedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an installment by ID
+func (r *paymentScheduleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PaymentSchedule, error) {
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("payment.id", id.String()))
+
+	var schedule domain.PaymentSchedule
+	err := r.client.ExecuteQuery(ctx, "select", "payment_schedules", func() error {
+		return r.client.scoped(ctx, "payment_schedules").
+			Select("*").
+			Eq("id", id).
+			Single(ctx, &schedule)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get payment schedule by ID: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// GetBySale retrieves the installments of a sale in order
+func (r *paymentScheduleRepository) GetBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.PaymentSchedule, error) {
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.GetBySale")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	schedules := []*domain.PaymentSchedule{}
+	err := r.client.ExecuteQuery(ctx, "select", "payment_schedules", func() error {
+		return r.client.scoped(ctx, "payment_schedules").
+			Select("*").
+			Eq("sale_id", saleID).
+			Order("installment_number", true).
+			Order("id", true).
+			Execute(ctx, &schedules)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get payment schedules by sale: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(schedules)))
+
+	return schedules, nil
+}
+
+// Update updates an existing installment
+func (r *paymentScheduleRepository) Update(ctx context.Context, schedule *domain.PaymentSchedule) error {
+	if err := domain.AssignOrganization(ctx, &schedule.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("payment.id", schedule.ID.String()),
+		attribute.String("payment.status", string(schedule.Status)),
+	)
+
+	since := schedule.UpdatedAt
+	schedule.UpdatedAt = timestamp()
+
+	err := r.client.ExecuteQuery(ctx, "update", "payment_schedules", func() error {
+		return r.client.scoped(ctx, "payment_schedules").
+			Update(schedule).
+			Eq("id", schedule.ID).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		schedule.UpdatedAt = since
+		span.RecordError(err)
+		return fmt.Errorf("failed to update payment schedule: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes an installment by ID
+func (r *paymentScheduleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("payment.id", id.String()))
+
+	err := r.client.ExecuteQuery(ctx, "delete", "payment_schedules", func() error {
+		return r.client.scoped(ctx, "payment_schedules").
+			Delete().
+			Eq("id", id).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete payment schedule: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves installments with pagination and filtering
+func (r *paymentScheduleRepository) List(ctx context.Context, filters domain.PaymentScheduleFilters) ([]*domain.PaymentSchedule, error) {
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	query := r.client.scoped(ctx, "payment_schedules").Select("*")
+	if filters.SaleID != nil {
+		query = query.Eq("sale_id", *filters.SaleID)
+	}
+	if filters.Status != nil {
+		query = query.Eq("status", string(*filters.Status))
+	}
+	if filters.DueDateFrom != nil {
+		query = query.Gte("due_date", *filters.DueDateFrom)
+	}
+	if filters.DueDateTo != nil {
+		query = query.Lte("due_date", *filters.DueDateTo)
+	}
+	if filters.AmountMin != nil {
+		query = query.Gte("amount", *filters.AmountMin)
+	}
+	if filters.AmountMax != nil {
+		query = query.Lte("amount", *filters.AmountMax)
+	}
+	query = applySort(query, filters.BaseFilters, paymentScheduleSortable, "due_date", true)
+	query = applyPagination(query, filters.BaseFilters)
+
+	var schedules []*domain.PaymentSchedule
+	err := r.client.ExecuteQuery(ctx, "select", "payment_schedules", func() error {
+		return query.Execute(ctx, &schedules)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list payment schedules: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(schedules)))
+
+	return schedules, nil
+}
+
+// GetOverduePayments retrieves unpaid installments whose due date has passed
+func (r *paymentScheduleRepository) GetOverduePayments(ctx context.Context) ([]*domain.PaymentSchedule, error) {
+	ctx, span := paymentScheduleTracer.Start(ctx, "paymentScheduleRepository.GetOverduePayments")
+	defer span.End()
+
+	var schedules []*domain.PaymentSchedule
+	err := r.client.ExecuteQuery(ctx, "select", "payment_schedules", func() error {
+		return r.client.scoped(ctx, "payment_schedules").
+			Select("*").
+			Lt("due_date", timestamp()).
+			In("status", []string{string(domain.PaymentStatusPending), string(domain.PaymentStatusOverdue)}).
+			Order("due_date", true).
+			Order("id", true).
+			Execute(ctx, &schedules)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get overdue payments: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(schedules)))
+
+	return schedules, nil
+}
@@ -0,0 +1,92 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var paymentRevisionTracer = otel.Tracer("goreal-backend/infrastructure/supabase/payment_schedule_revision")
+
+// paymentScheduleRevisionRepository implements domain.PaymentScheduleRevisionRepository using Supabase
+type paymentScheduleRevisionRepository struct {
+	client *Client
+}
+
+// NewPaymentScheduleRevisionRepository creates a new payment schedule revision repository
+func NewPaymentScheduleRevisionRepository(client *Client) domain.PaymentScheduleRevisionRepository {
+	return &paymentScheduleRevisionRepository{
+		client: client,
+	}
+}
+
+// Create records a schedule revision; revision numbers are unique per sale
+func (r *paymentScheduleRevisionRepository) Create(ctx context.Context, revision *domain.PaymentScheduleRevision) error {
+	if err := domain.AssignOrganization(ctx, &revision.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := paymentRevisionTracer.Start(ctx, "paymentScheduleRevisionRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", revision.SaleID.String()),
+		attribute.Int("payment.revision", revision.Revision),
+		attribute.String("payment.plan", string(revision.Plan)),
+	)
+
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now()
+	}
+
+	// The installment columns are NOT NULL, so empty lists are sent as []
+	row := *revision
+	if row.Previous == nil {
+		row.Previous = []domain.PaymentInstallment{}
+	}
+	if row.Installments == nil {
+		row.Installments = []domain.PaymentInstallment{}
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "payment_schedule_revisions", func() error {
+		return r.client.From("payment_schedule_revisions").Insert(&row).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create payment schedule revision: %w", err)
+	}
+
+	return nil
+}
+
+// ListBySale returns the revisions of a sale's schedule, oldest first
+func (r *paymentScheduleRevisionRepository) ListBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.PaymentScheduleRevision, error) {
+	ctx, span := paymentRevisionTracer.Start(ctx, "paymentScheduleRevisionRepository.ListBySale")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	revisions := []*domain.PaymentScheduleRevision{}
+	err := r.client.ExecuteQuery(ctx, "select", "payment_schedule_revisions", func() error {
+		return r.client.scoped(ctx, "payment_schedule_revisions").
+			Select("*").
+			Eq("sale_id", saleID).
+			Order("revision", true).
+			Execute(ctx, &revisions)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list payment schedule revisions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(revisions)))
+
+	return revisions, nil
+}
@@ -0,0 +1,144 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var priceListTracer = otel.Tracer("goreal-backend/infrastructure/supabase/price_list")
+
+// priceListRepository implements domain.PriceListRepository using Supabase
+type priceListRepository struct {
+	client *Client
+}
+
+// NewPriceListRepository creates a new price list repository
+func NewPriceListRepository(client *Client) domain.PriceListRepository {
+	return &priceListRepository{
+		client: client,
+	}
+}
+
+// Create stores a price list version; versions are unique per
+// organization and project
+func (r *priceListRepository) Create(ctx context.Context, priceList *domain.PriceList) error {
+	if err := domain.AssignOrganization(ctx, &priceList.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := priceListTracer.Start(ctx, "priceListRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project.id", priceList.ProjectID.String()),
+		attribute.Int("price_list.version", priceList.Version),
+	)
+
+	if priceList.CreatedAt.IsZero() {
+		priceList.CreatedAt = time.Now()
+	}
+	if priceList.FacingPremiums == nil {
+		priceList.FacingPremiums = map[string]float64{}
+	}
+	if priceList.PLCs == nil {
+		priceList.PLCs = map[string]float64{}
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "price_lists", func() error {
+		return r.client.From("price_lists").Insert(priceList).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create price list: %w", err)
+	}
+
+	return nil
+}
+
+// GetVersion returns one version of a project's price list
+func (r *priceListRepository) GetVersion(ctx context.Context, projectID uuid.UUID, version int) (*domain.PriceList, error) {
+	ctx, span := priceListTracer.Start(ctx, "priceListRepository.GetVersion")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project.id", projectID.String()),
+		attribute.Int("price_list.version", version),
+	)
+
+	var priceList domain.PriceList
+	err := r.client.ExecuteQuery(ctx, "select", "price_lists", func() error {
+		return r.query(ctx, projectID).
+			Eq("version", version).
+			Single(ctx, &priceList)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get price list: %w", err)
+	}
+
+	return &priceList, nil
+}
+
+// GetEffective returns the version of a project's price list in effect at a time
+func (r *priceListRepository) GetEffective(ctx context.Context, projectID uuid.UUID, at time.Time) (*domain.PriceList, error) {
+	ctx, span := priceListTracer.Start(ctx, "priceListRepository.GetEffective")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	var priceList domain.PriceList
+	err := r.client.ExecuteQuery(ctx, "select", "price_lists", func() error {
+		return r.query(ctx, projectID).
+			Lte("effective_from", at).
+			Order("effective_from", false).
+			Order("version", false).
+			Limit(1).
+			Single(ctx, &priceList)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get price list: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("price_list.version", priceList.Version))
+
+	return &priceList, nil
+}
+
+// ListVersions returns every version of a project's price list, newest first
+func (r *priceListRepository) ListVersions(ctx context.Context, projectID uuid.UUID) ([]*domain.PriceList, error) {
+	ctx, span := priceListTracer.Start(ctx, "priceListRepository.ListVersions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	priceLists := []*domain.PriceList{}
+	err := r.client.ExecuteQuery(ctx, "select", "price_lists", func() error {
+		return r.query(ctx, projectID).
+			Order("version", false).
+			Execute(ctx, &priceLists)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list price lists: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(priceLists)))
+
+	return priceLists, nil
+}
+
+// query selects the price lists of a project
+func (r *priceListRepository) query(ctx context.Context, projectID uuid.UUID) *QueryBuilder {
+	return r.client.scoped(ctx, "price_lists").Select("*").Eq("project_id", projectID)
+}
@@ -27,6 +27,9 @@ func NewProjectRepository(client *Client) domain.ProjectRepository {
 
 // Create creates a new project
 func (r *projectRepository) Create(ctx context.Context, project *domain.Project) error {
+	if err := domain.AssignOrganization(ctx, &project.OrganizationID); err != nil {
+		return err
+	}
 	ctx, span := projectTracer.Start(ctx, "projectRepository.Create")
 	defer span.End()
 
@@ -36,10 +39,14 @@ func (r *projectRepository) Create(ctx context.Context, project *domain.Project)
 		attribute.String("project.society_id", project.SocietyID.String()),
 	)
 
-	// Set timestamps
+	// Set timestamps unless the caller supplied them
 	now := time.Now()
-	project.CreatedAt = now
-	project.UpdatedAt = now
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = now
+	}
+	if project.UpdatedAt.IsZero() {
+		project.UpdatedAt = project.CreatedAt
+	}
 
 	// Execute insert query
 	err := r.client.ExecuteQuery(ctx, "insert", "projects", func() error {
@@ -63,7 +70,7 @@ func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 
 	var project domain.Project
 	err := r.client.ExecuteQuery(ctx, "select", "projects", func() error {
-		return r.client.From("projects").
+		return r.client.scoped(ctx, "projects").
 			Select("*, society:societies(*)").
 			Eq("id", id).
 			Single(ctx, &project)
@@ -79,6 +86,9 @@ func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 
 // Update updates an existing project
 func (r *projectRepository) Update(ctx context.Context, project *domain.Project) error {
+	if err := domain.AssignOrganization(ctx, &project.OrganizationID); err != nil {
+		return err
+	}
 	ctx, span := projectTracer.Start(ctx, "projectRepository.Update")
 	defer span.End()
 
@@ -91,10 +101,10 @@ func (r *projectRepository) Update(ctx context.Context, project *domain.Project)
 	project.UpdatedAt = time.Now()
 
 	err := r.client.ExecuteQuery(ctx, "update", "projects", func() error {
-		return r.client.From("projects").
+		return r.client.scoped(ctx, "projects").
 			Update(project).
 			Eq("id", project.ID).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 
 	if err != nil {
@@ -113,10 +123,10 @@ func (r *projectRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	span.SetAttributes(attribute.String("project.id", id.String()))
 
 	err := r.client.ExecuteQuery(ctx, "delete", "projects", func() error {
-		return r.client.From("projects").
+		return r.client.scoped(ctx, "projects").
 			Delete().
 			Eq("id", id).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 
 	if err != nil {
@@ -137,49 +147,11 @@ func (r *projectRepository) List(ctx context.Context, filters domain.ProjectFilt
 		attribute.Int("filters.offset", filters.Offset),
 	)
 
-	query := r.client.From("projects").Select("*, society:societies(*)")
-
-	// Apply filters
-	if filters.SocietyID != nil {
-		query = query.Eq("society_id", *filters.SocietyID)
-		span.SetAttributes(attribute.String("filters.society_id", filters.SocietyID.String()))
-	}
+	query := r.client.scoped(ctx, "projects").Select("*, society:societies(*)")
 
-	if filters.Status != nil {
-		query = query.Eq("status", string(*filters.Status))
-		span.SetAttributes(attribute.String("filters.status", string(*filters.Status)))
-	}
-
-	if filters.ProjectType != nil {
-		query = query.Eq("project_type", *filters.ProjectType)
-		span.SetAttributes(attribute.String("filters.project_type", *filters.ProjectType))
-	}
-
-	if filters.Search != nil {
-		// Search in name or description
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("name.ilike.%s,description.ilike.%s",
-			searchPattern, searchPattern))
-		span.SetAttributes(attribute.String("filters.search", *filters.Search))
-	}
-
-	// Apply sorting
-	if filters.SortBy != "" {
-		ascending := filters.SortOrder == "" || filters.SortOrder == "asc"
-		query = query.Order(filters.SortBy, ascending)
-		span.SetAttributes(
-			attribute.String("filters.sort_by", filters.SortBy),
-			attribute.Bool("filters.ascending", ascending),
-		)
-	}
-
-	// Apply pagination
-	if filters.Limit > 0 {
-		query = query.Limit(filters.Limit)
-	}
-	if filters.Offset > 0 {
-		query = query.Offset(filters.Offset)
-	}
+	query = applyProjectFilters(query, filters)
+	query = applySort(query, filters.BaseFilters, projectSortable, "created_at", false)
+	query = applyPagination(query, filters.BaseFilters)
 
 	var projects []*domain.Project
 	err := r.client.ExecuteQuery(ctx, "select", "projects", func() error {
@@ -201,30 +173,16 @@ func (r *projectRepository) Count(ctx context.Context, filters domain.ProjectFil
 	ctx, span := projectTracer.Start(ctx, "projectRepository.Count")
 	defer span.End()
 
-	query := r.client.From("projects").Select("id")
-
-	// Apply filters (same as List method)
-	if filters.SocietyID != nil {
-		query = query.Eq("society_id", *filters.SocietyID)
-	}
-
-	if filters.Status != nil {
-		query = query.Eq("status", string(*filters.Status))
-	}
+	query := r.client.scoped(ctx, "projects").Select("id")
 
-	if filters.ProjectType != nil {
-		query = query.Eq("project_type", *filters.ProjectType)
-	}
+	query = applyProjectFilters(query, filters)
 
-	if filters.Search != nil {
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("name.ilike.%s,description.ilike.%s",
-			searchPattern, searchPattern))
-	}
-
-	var projects []*domain.Project
+	var count int
 	err := r.client.ExecuteQuery(ctx, "select", "projects", func() error {
-		return query.Execute(ctx, &projects)
+		var rows []map[string]interface{}
+		var err error
+		count, err = query.ExecuteWithCount(ctx, &rows)
+		return err
 	})
 
 	if err != nil {
@@ -232,12 +190,78 @@ func (r *projectRepository) Count(ctx context.Context, filters domain.ProjectFil
 		return 0, fmt.Errorf("failed to count projects: %w", err)
 	}
 
-	count := len(projects)
 	span.SetAttributes(attribute.Int("result.count", count))
 
 	return count, nil
 }
 
+// RefreshUnitCounts recounts the units of a project from its inventory.
+// PostgREST cannot update from a subquery, so the counts are read first;
+// every status change refreshes them again, which corrects a stale write.
+func (r *projectRepository) RefreshUnitCounts(ctx context.Context, id uuid.UUID) error {
+	ctx, span := projectTracer.Start(ctx, "projectRepository.RefreshUnitCounts")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", id.String()))
+
+	err := r.client.ExecuteQuery(ctx, "update", "projects", func() error {
+		var units []struct {
+			Status domain.UnitStatus `json:"status"`
+		}
+		if err := r.client.scoped(ctx, "inventory").Select("status").Eq("project_id", id).Execute(ctx, &units); err != nil {
+			return err
+		}
+
+		counts := map[domain.UnitStatus]int{}
+		for _, unit := range units {
+			counts[unit.Status]++
+		}
+		updateData := map[string]interface{}{
+			"available_units": counts[domain.UnitStatusAvailable],
+			"sold_units":      counts[domain.UnitStatusSold],
+			"blocked_units":   counts[domain.UnitStatusReserved] + counts[domain.UnitStatusBlocked],
+		}
+		return r.client.scoped(ctx, "projects").
+			Update(updateData).
+			Eq("id", id).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to refresh project unit counts: %w", err)
+	}
+
+	return nil
+}
+
+// applyProjectFilters adds the ProjectFilters conditions shared by List and Count
+func applyProjectFilters(query *QueryBuilder, filters domain.ProjectFilters) *QueryBuilder {
+	if filters.SocietyID != nil {
+		query = query.Eq("society_id", *filters.SocietyID)
+	}
+	if filters.Status != nil {
+		query = query.Eq("status", string(*filters.Status))
+	}
+	if filters.ProjectType != nil {
+		query = query.Eq("project_type", *filters.ProjectType)
+	}
+	if filters.CreatedBy != nil {
+		query = query.Eq("created_by", *filters.CreatedBy)
+	}
+	if filters.PriceMin != nil {
+		query = query.Gte("base_price", *filters.PriceMin)
+	}
+	if filters.PriceMax != nil {
+		query = query.Lte("base_price", *filters.PriceMax)
+	}
+	if filters.Search != nil {
+		// Search in name or description
+		query = query.IlikeAny(*filters.Search, "name", "description")
+	}
+	return query
+}
+
 // GetBySociety retrieves projects by society ID
 func (r *projectRepository) GetBySociety(ctx context.Context, societyID uuid.UUID) ([]*domain.Project, error) {
 	ctx, span := projectTracer.Start(ctx, "projectRepository.GetBySocietyID")
@@ -247,7 +271,7 @@ func (r *projectRepository) GetBySociety(ctx context.Context, societyID uuid.UUI
 
 	var projects []*domain.Project
 	err := r.client.ExecuteQuery(ctx, "select", "projects", func() error {
-		return r.client.From("projects").
+		return r.client.scoped(ctx, "projects").
 			Select("*, society:societies(*)").
 			Eq("society_id", societyID).
 			Execute(ctx, &projects)
@@ -272,7 +296,7 @@ func (r *projectRepository) GetByStatus(ctx context.Context, status domain.Proje
 
 	var projects []*domain.Project
 	err := r.client.ExecuteQuery(ctx, "select", "projects", func() error {
-		return r.client.From("projects").
+		return r.client.scoped(ctx, "projects").
 			Select("*, society:societies(*)").
 			Eq("status", string(status)).
 			Execute(ctx, &projects)
@@ -309,10 +333,10 @@ func (r *projectRepository) UpdateStatus(ctx context.Context, id uuid.UUID, stat
 	}
 
 	err := r.client.ExecuteQuery(ctx, "update", "projects", func() error {
-		return r.client.From("projects").
+		return r.client.scoped(ctx, "projects").
 			Update(updateData).
 			Eq("id", id).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 
 	if err != nil {
@@ -0,0 +1,112 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var interestTracer = otel.Tracer("goreal-backend/infrastructure/supabase/property_interest")
+
+// propertyInterestRepository implements domain.PropertyInterestRepository using Supabase
+type propertyInterestRepository struct {
+	client *Client
+}
+
+// NewPropertyInterestRepository creates a new property interest repository
+func NewPropertyInterestRepository(client *Client) domain.PropertyInterestRepository {
+	return &propertyInterestRepository{
+		client: client,
+	}
+}
+
+// Create records a client's interest in a unit
+func (r *propertyInterestRepository) Create(ctx context.Context, interest *domain.PropertyInterest) error {
+	if err := domain.AssignOrganization(ctx, &interest.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := interestTracer.Start(ctx, "propertyInterestRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("client.id", interest.ClientID.String()),
+		attribute.String("inventory.id", interest.InventoryID.String()),
+		attribute.String("interest.kind", string(interest.Kind)),
+	)
+
+	if interest.CreatedAt.IsZero() {
+		interest.CreatedAt = time.Now()
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "property_interests", func() error {
+		return r.client.From("property_interests").Insert(interest).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create property interest: %w", err)
+	}
+
+	return nil
+}
+
+// ListByClient returns the interests of a client, newest first
+func (r *propertyInterestRepository) ListByClient(ctx context.Context, clientID uuid.UUID) ([]*domain.PropertyInterest, error) {
+	ctx, span := interestTracer.Start(ctx, "propertyInterestRepository.ListByClient")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("client.id", clientID.String()))
+
+	interests, err := r.list(ctx, "client_id", clientID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(interests)))
+
+	return interests, nil
+}
+
+// ListByInventory returns the interests shown in a unit, newest first
+func (r *propertyInterestRepository) ListByInventory(ctx context.Context, inventoryID uuid.UUID) ([]*domain.PropertyInterest, error) {
+	ctx, span := interestTracer.Start(ctx, "propertyInterestRepository.ListByInventory")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", inventoryID.String()))
+
+	interests, err := r.list(ctx, "inventory_id", inventoryID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(interests)))
+
+	return interests, nil
+}
+
+// list returns the interests whose column equals id, newest first
+func (r *propertyInterestRepository) list(ctx context.Context, column string, id uuid.UUID) ([]*domain.PropertyInterest, error) {
+	interests := []*domain.PropertyInterest{}
+	err := r.client.ExecuteQuery(ctx, "select", "property_interests", func() error {
+		return r.client.scoped(ctx, "property_interests").
+			Select("*").
+			Eq(column, id).
+			Order("created_at", false).
+			Order("id", false).
+			Execute(ctx, &interests)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list property interests: %w", err)
+	}
+
+	return interests, nil
+}
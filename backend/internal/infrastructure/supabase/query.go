@@ -0,0 +1,182 @@
+package supabase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// Columns callers may sort by. Anything else falls back to the
+// repository default, so SortBy never reaches PostgREST unchecked.
+var (
+	leadSortable = map[string]bool{
+		"name": true, "status": true, "source": true, "score": true, "budget_min": true, "budget_max": true,
+		"next_follow_up": true, "last_contact_date": true, "created_at": true, "updated_at": true,
+	}
+	clientSortable = map[string]bool{
+		"name": true, "email": true, "client_type": true, "is_verified": true, "credit_limit": true,
+		"created_at": true, "updated_at": true,
+	}
+	companySortable = map[string]bool{
+		"name": true, "industry": true, "created_at": true, "updated_at": true,
+	}
+	inventorySortable = map[string]bool{
+		"unit_number": true, "floor_number": true, "unit_type": true, "status": true, "carpet_area": true,
+		"base_price": true, "final_price": true, "price_per_sqft": true, "created_at": true, "updated_at": true,
+	}
+	organizationSortable = map[string]bool{
+		"name": true, "slug": true, "created_at": true, "updated_at": true,
+	}
+	projectSortable = map[string]bool{
+		"name": true, "status": true, "project_type": true, "start_date": true, "expected_completion": true,
+		"base_price": true, "price_per_sqft": true, "available_units": true, "created_at": true, "updated_at": true,
+	}
+	saleSortable = map[string]bool{
+		"sale_number": true, "sale_date": true, "status": true, "total_amount": true, "final_amount": true,
+		"created_at": true, "updated_at": true,
+	}
+	societySortable = map[string]bool{
+		"name": true, "location": true, "created_at": true, "updated_at": true,
+	}
+	taskSortable = map[string]bool{
+		"title": true, "status": true, "priority": true, "due_date": true, "completed_at": true,
+		"created_at": true, "updated_at": true,
+	}
+	userSortable = map[string]bool{
+		"email": true, "username": true, "full_name": true, "role": true, "last_login_at": true,
+		"created_at": true, "updated_at": true,
+	}
+)
+
+// applySort orders by filters.SortBy when it is whitelisted, otherwise by
+// the fallback column, with id as a tiebreak so pages are stable. Cursor
+// pages are always in keyset order.
+func applySort(query *QueryBuilder, filters domain.BaseFilters, sortable map[string]bool, fallback string, fallbackAscending bool) *QueryBuilder {
+	if filters.Cursor != nil {
+		desc, _ := filters.KeysetOrder()
+		query = query.Order("created_at", !desc)
+	} else if filters.SortBy == "" || !sortable[filters.SortBy] {
+		query = query.Order(fallback, fallbackAscending)
+	} else {
+		query = query.OrderNullsLast(filters.SortBy, !strings.EqualFold(filters.SortOrder, "desc"))
+	}
+	return query.Order("id", true)
+}
+
+// applyPagination sets limit and offset when given. A cursor replaces the
+// offset with a keyset condition on created_at and id.
+func applyPagination(query *QueryBuilder, filters domain.BaseFilters) *QueryBuilder {
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Cursor != nil {
+		desc, _ := filters.KeysetOrder()
+		op := "gt"
+		if desc {
+			op = "lt"
+		}
+		at := quoteValue(formatValue(filters.Cursor.CreatedAt.UTC()))
+		return query.Or(fmt.Sprintf("created_at.%s.%s,and(created_at.eq.%s,id.gt.%s)", op, at, at, filters.Cursor.ID))
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+	return query
+}
+
+// trashTables are the tables whose rows are soft deleted. Rows in the trash
+// can only be restored, so guarded updates treat them as missing.
+var trashTables = map[string]bool{"leads": true, "clients": true, "sales": true, "tasks": true}
+
+// tenantTables are the tables whose rows belong to an organization
+var tenantTables = map[string]bool{
+	"profiles": true, "leads": true, "clients": true, "inventory": true, "sales": true, "tasks": true,
+	"sale_status_history": true, "agreement_templates": true, "sale_agreements": true, "payment_schedule_revisions": true,
+	"commissions": true, "commission_plans": true, "property_interests": true, "price_lists": true,
+	"companies": true, "societies": true, "projects": true, "payment_schedules": true,
+}
+
+// scoped starts a query on table limited to the organization carried by
+// ctx. Contexts lifted out of tenancy and tables without tenants see every
+// row.
+func (c *Client) scoped(ctx context.Context, table string) *QueryBuilder {
+	query := c.From(table)
+	if id, ok := domain.OrganizationFromContext(ctx); ok && tenantTables[table] {
+		query = query.Eq("organization_id", id)
+	}
+	return query
+}
+
+// timestamp returns the current time at the microsecond precision Postgres
+// keeps, so the value a write sets is the one the next guarded write sees
+func timestamp() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
+
+// expectVersion inspects the error of an update filtered on updated_at:
+// when no row matched it returns domain.ErrConflict if the row still exists
+func (c *Client) expectVersion(ctx context.Context, table string, id uuid.UUID, err error) error {
+	if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	lookup := c.scoped(ctx, table).Select("id").Eq("id", id)
+	if trashTables[table] {
+		lookup = lookup.IsNull("deleted_at")
+	}
+
+	var rows []json.RawMessage
+	if lookupErr := lookup.Execute(ctx, &rows); lookupErr != nil {
+		return lookupErr
+	}
+	if len(rows) > 0 {
+		return fmt.Errorf("%s row was changed since it was read: %w", table, domain.ErrConflict)
+	}
+	return err
+}
+
+// softDelete moves the live row with the given id to the trash
+func (c *Client) softDelete(ctx context.Context, table string, id, deletedBy uuid.UUID) error {
+	now := timestamp()
+	return c.scoped(ctx, table).
+		Update(map[string]interface{}{
+			"deleted_at": now,
+			"deleted_by": deletedBy,
+			"updated_at": now,
+		}).
+		Eq("id", id).
+		IsNull("deleted_at").
+		ExecuteAffected(ctx)
+}
+
+// restore takes the trashed row with the given id out of the trash
+func (c *Client) restore(ctx context.Context, table string, id uuid.UUID) error {
+	return c.scoped(ctx, table).
+		Update(map[string]interface{}{
+			"deleted_at": nil,
+			"deleted_by": nil,
+			"updated_at": timestamp(),
+		}).
+		Eq("id", id).
+		IsNotNull("deleted_at").
+		ExecuteAffected(ctx)
+}
+
+// purge permanently deletes the rows trashed before the cutoff and
+// reports how many were removed
+func (c *Client) purge(ctx context.Context, table string, deletedBefore time.Time) (int, error) {
+	var rows []json.RawMessage
+	err := c.scoped(ctx, table).
+		Select("id").
+		Delete().
+		Lt("deleted_at", deletedBefore).
+		Execute(ctx, &rows)
+	return len(rows), err
+}
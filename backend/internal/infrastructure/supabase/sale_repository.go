@@ -27,6 +27,9 @@ func NewSaleRepository(client *Client) domain.SaleRepository {
 
 // Create creates a new sale
 func (r *saleRepository) Create(ctx context.Context, sale *domain.Sale) error {
+	if err := domain.AssignOrganization(ctx, &sale.OrganizationID); err != nil {
+		return err
+	}
 	ctx, span := saleTracer.Start(ctx, "saleRepository.Create")
 	defer span.End()
 
@@ -37,10 +40,14 @@ func (r *saleRepository) Create(ctx context.Context, sale *domain.Sale) error {
 		attribute.Float64("sale.total_amount", sale.TotalAmount),
 	)
 
-	// Set timestamps
+	// Set timestamps unless the caller supplied them
 	now := time.Now()
-	sale.CreatedAt = now
-	sale.UpdatedAt = now
+	if sale.CreatedAt.IsZero() {
+		sale.CreatedAt = now
+	}
+	if sale.UpdatedAt.IsZero() {
+		sale.UpdatedAt = sale.CreatedAt
+	}
 
 	// Execute insert query
 	err := r.client.ExecuteQuery(ctx, "insert", "sales", func() error {
@@ -64,13 +71,14 @@ func (r *saleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Sal
 
 	var sale domain.Sale
 	err := r.client.ExecuteQuery(ctx, "select", "sales", func() error {
-		return r.client.From("sales").
+		return r.client.scoped(ctx, "sales").
 			Select(`*, 
 				client:clients(*), 
 				inventory:inventories(*), 
 				salesperson:users!salesperson_id(*), 
 				manager:users!manager_id(*)`).
 			Eq("id", id).
+			IsNull("deleted_at").
 			Single(ctx, &sale)
 	})
 
@@ -91,13 +99,14 @@ func (r *saleRepository) GetBySaleNumber(ctx context.Context, saleNumber string)
 
 	var sale domain.Sale
 	err := r.client.ExecuteQuery(ctx, "select", "sales", func() error {
-		return r.client.From("sales").
+		return r.client.scoped(ctx, "sales").
 			Select(`*, 
 				client:clients(*), 
 				inventory:inventories(*), 
 				salesperson:users!salesperson_id(*), 
 				manager:users!manager_id(*)`).
 			Eq("sale_number", saleNumber).
+			IsNull("deleted_at").
 			Single(ctx, &sale)
 	})
 
@@ -111,6 +120,9 @@ func (r *saleRepository) GetBySaleNumber(ctx context.Context, saleNumber string)
 
 // Update updates an existing sale
 func (r *saleRepository) Update(ctx context.Context, sale *domain.Sale) error {
+	if err := domain.AssignOrganization(ctx, &sale.OrganizationID); err != nil {
+		return err
+	}
 	ctx, span := saleTracer.Start(ctx, "saleRepository.Update")
 	defer span.End()
 
@@ -121,16 +133,21 @@ func (r *saleRepository) Update(ctx context.Context, sale *domain.Sale) error {
 	)
 
 	// Update timestamp
-	sale.UpdatedAt = time.Now()
+	since := sale.UpdatedAt
+	sale.UpdatedAt = timestamp()
 
 	err := r.client.ExecuteQuery(ctx, "update", "sales", func() error {
-		return r.client.From("sales").
+		err := r.client.scoped(ctx, "sales").
 			Update(sale).
 			Eq("id", sale.ID).
-			Execute(ctx, nil)
+			Eq("updated_at", since).
+			IsNull("deleted_at").
+			ExecuteAffected(ctx)
+		return r.client.expectVersion(ctx, "sales", sale.ID, err)
 	})
 
 	if err != nil {
+		sale.UpdatedAt = since
 		span.RecordError(err)
 		return fmt.Errorf("failed to update sale: %w", err)
 	}
@@ -138,18 +155,15 @@ func (r *saleRepository) Update(ctx context.Context, sale *domain.Sale) error {
 	return nil
 }
 
-// Delete deletes a sale by ID
-func (r *saleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete moves a sale to the trash
+func (r *saleRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
 	ctx, span := saleTracer.Start(ctx, "saleRepository.Delete")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("sale.id", id.String()))
 
 	err := r.client.ExecuteQuery(ctx, "delete", "sales", func() error {
-		return r.client.From("sales").
-			Delete().
-			Eq("id", id).
-			Execute(ctx, nil)
+		return r.client.softDelete(ctx, "sales", id, deletedBy)
 	})
 
 	if err != nil {
@@ -160,91 +174,74 @@ func (r *saleRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// List retrieves sales with pagination and filtering
-func (r *saleRepository) List(ctx context.Context, filters domain.SaleFilters) ([]*domain.Sale, error) {
-	ctx, span := saleTracer.Start(ctx, "saleRepository.List")
+// Restore takes a sale out of the trash
+func (r *saleRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.Restore")
 	defer span.End()
 
-	span.SetAttributes(
-		attribute.Int("filters.limit", filters.Limit),
-		attribute.Int("filters.offset", filters.Offset),
-	)
+	span.SetAttributes(attribute.String("sale.id", id.String()))
 
-	query := r.client.From("sales").
-		Select(`*,
-			client:clients(*),
-			inventory:inventories(*),
-			salesperson:users!salesperson_id(*),
-			manager:users!manager_id(*)`)
+	err := r.client.ExecuteQuery(ctx, "restore", "sales", func() error {
+		return r.client.restore(ctx, "sales", id)
+	})
 
-	// Apply filters
-	if filters.ClientID != nil {
-		query = query.Eq("client_id", *filters.ClientID)
-		span.SetAttributes(attribute.String("filters.client_id", filters.ClientID.String()))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to restore sale: %w", err)
 	}
 
-	if filters.SalespersonID != nil {
-		query = query.Eq("salesperson_id", *filters.SalespersonID)
-		span.SetAttributes(attribute.String("filters.salesperson_id", filters.SalespersonID.String()))
-	}
+	return nil
+}
 
-	if filters.ManagerID != nil {
-		query = query.Eq("manager_id", *filters.ManagerID)
-		span.SetAttributes(attribute.String("filters.manager_id", filters.ManagerID.String()))
-	}
+// Purge permanently removes sales that were deleted before the cutoff
+func (r *saleRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.Purge")
+	defer span.End()
 
-	if filters.Status != nil {
-		query = query.Eq("status", string(*filters.Status))
-		span.SetAttributes(attribute.String("filters.status", string(*filters.Status)))
-	}
+	var purged int
+	err := r.client.ExecuteQuery(ctx, "purge", "sales", func() error {
+		var err error
+		purged, err = r.client.purge(ctx, "sales", deletedBefore)
+		return err
+	})
 
-	if filters.Search != nil {
-		// Search in sale number or client name
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("sale_number.ilike.%s", searchPattern))
-		span.SetAttributes(attribute.String("filters.search", *filters.Search))
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to purge sales: %w", err)
 	}
 
-	if filters.SaleDateFrom != nil {
-		query = query.Gte("sale_date", filters.SaleDateFrom.Format(time.RFC3339))
-		span.SetAttributes(attribute.String("filters.sale_date_from", filters.SaleDateFrom.Format(time.RFC3339)))
-	}
+	span.SetAttributes(attribute.Int("result.count", purged))
 
-	if filters.SaleDateTo != nil {
-		query = query.Lte("sale_date", filters.SaleDateTo.Format(time.RFC3339))
-		span.SetAttributes(attribute.String("filters.sale_date_to", filters.SaleDateTo.Format(time.RFC3339)))
-	}
+	return purged, nil
+}
 
-	if filters.AmountMin != nil {
-		query = query.Gte("total_amount", *filters.AmountMin)
-		span.SetAttributes(attribute.Float64("filters.amount_min", *filters.AmountMin))
-	}
+// List retrieves sales with pagination and filtering
+func (r *saleRepository) List(ctx context.Context, filters domain.SaleFilters) ([]*domain.Sale, error) {
+	ctx, span := saleTracer.Start(ctx, "saleRepository.List")
+	defer span.End()
 
-	if filters.AmountMax != nil {
-		query = query.Lte("total_amount", *filters.AmountMax)
-		span.SetAttributes(attribute.Float64("filters.amount_max", *filters.AmountMax))
-	}
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
 
-	// Apply sorting
-	if filters.SortBy != "" {
-		ascending := filters.SortOrder == "" || filters.SortOrder == "asc"
-		query = query.Order(filters.SortBy, ascending)
-		span.SetAttributes(
-			attribute.String("filters.sort_by", filters.SortBy),
-			attribute.Bool("filters.ascending", ascending),
-		)
-	}
+	query := r.client.scoped(ctx, "sales").
+		Select(`*,
+			client:clients(*),
+			inventory:inventories(*),
+			salesperson:users!salesperson_id(*),
+			manager:users!manager_id(*)`)
 
-	// Apply pagination
-	if filters.Limit > 0 {
-		query = query.Limit(filters.Limit)
-	}
-	if filters.Offset > 0 {
-		query = query.Offset(filters.Offset)
+	query, err := r.applyFilters(ctx, query, filters)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list sales: %w", err)
 	}
+	query = applySort(query, filters.BaseFilters, saleSortable, "created_at", false)
+	query = applyPagination(query, filters.BaseFilters)
 
 	var sales []*domain.Sale
-	err := r.client.ExecuteQuery(ctx, "select", "sales", func() error {
+	err = r.client.ExecuteQuery(ctx, "select", "sales", func() error {
 		return query.Execute(ctx, &sales)
 	})
 
@@ -263,60 +260,88 @@ func (r *saleRepository) Count(ctx context.Context, filters domain.SaleFilters)
 	ctx, span := saleTracer.Start(ctx, "saleRepository.Count")
 	defer span.End()
 
-	query := r.client.From("sales").Select("id")
+	query := r.client.scoped(ctx, "sales").Select("id")
+
+	query, err := r.applyFilters(ctx, query, filters)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count sales: %w", err)
+	}
+
+	var count int
+	err = r.client.ExecuteQuery(ctx, "select", "sales", func() error {
+		var rows []map[string]interface{}
+		var err error
+		count, err = query.ExecuteWithCount(ctx, &rows)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count sales: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
 
-	// Apply filters (same as List method)
+// applyFilters adds the SaleFilters conditions shared by List, Count and
+// GetSalesStats. A project filter is resolved to the project's inventory
+// units first, since sales only reference inventory.
+func (r *saleRepository) applyFilters(ctx context.Context, query *QueryBuilder, filters domain.SaleFilters) (*QueryBuilder, error) {
+	if !filters.IncludeDeleted {
+		query = query.IsNull("deleted_at")
+	}
 	if filters.ClientID != nil {
 		query = query.Eq("client_id", *filters.ClientID)
 	}
-
 	if filters.SalespersonID != nil {
 		query = query.Eq("salesperson_id", *filters.SalespersonID)
 	}
-
 	if filters.ManagerID != nil {
 		query = query.Eq("manager_id", *filters.ManagerID)
 	}
-
 	if filters.Status != nil {
 		query = query.Eq("status", string(*filters.Status))
 	}
-
 	if filters.Search != nil {
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("sale_number.ilike.%s", searchPattern))
+		// Search in sale number
+		query = query.IlikeAny(*filters.Search, "sale_number")
 	}
-
 	if filters.SaleDateFrom != nil {
-		query = query.Gte("sale_date", filters.SaleDateFrom.Format(time.RFC3339))
+		query = query.Gte("sale_date", *filters.SaleDateFrom)
 	}
-
 	if filters.SaleDateTo != nil {
-		query = query.Lte("sale_date", filters.SaleDateTo.Format(time.RFC3339))
+		query = query.Lte("sale_date", *filters.SaleDateTo)
 	}
-
 	if filters.AmountMin != nil {
 		query = query.Gte("total_amount", *filters.AmountMin)
 	}
-
 	if filters.AmountMax != nil {
 		query = query.Lte("total_amount", *filters.AmountMax)
 	}
+	if filters.ProjectID != nil {
+		var units []struct {
+			ID uuid.UUID `json:"id"`
+		}
+		err := r.client.ExecuteQuery(ctx, "select", "inventory", func() error {
+			return r.client.scoped(ctx, "inventory").
+				Select("id").
+				Eq("project_id", *filters.ProjectID).
+				Execute(ctx, &units)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve project inventory: %w", err)
+		}
 
-	var sales []*domain.Sale
-	err := r.client.ExecuteQuery(ctx, "select", "sales", func() error {
-		return query.Execute(ctx, &sales)
-	})
-
-	if err != nil {
-		span.RecordError(err)
-		return 0, fmt.Errorf("failed to count sales: %w", err)
+		ids := make([]uuid.UUID, len(units))
+		for i, unit := range units {
+			ids[i] = unit.ID
+		}
+		query = query.In("inventory_id", ids)
 	}
-
-	count := len(sales)
-	span.SetAttributes(attribute.Int("result.count", count))
-
-	return count, nil
+	return query, nil
 }
 
 // GetByClient retrieves sales by client ID
@@ -328,13 +353,14 @@ func (r *saleRepository) GetByClient(ctx context.Context, clientID uuid.UUID) ([
 
 	var sales []*domain.Sale
 	err := r.client.ExecuteQuery(ctx, "select", "sales", func() error {
-		return r.client.From("sales").
+		return r.client.scoped(ctx, "sales").
 			Select(`*, 
 				client:clients(*), 
 				inventory:inventories(*), 
 				salesperson:users!salesperson_id(*), 
 				manager:users!manager_id(*)`).
 			Eq("client_id", clientID).
+			IsNull("deleted_at").
 			Execute(ctx, &sales)
 	})
 
@@ -357,13 +383,14 @@ func (r *saleRepository) GetBySalesperson(ctx context.Context, salespersonID uui
 
 	var sales []*domain.Sale
 	err := r.client.ExecuteQuery(ctx, "select", "sales", func() error {
-		return r.client.From("sales").
+		return r.client.scoped(ctx, "sales").
 			Select(`*, 
 				client:clients(*), 
 				inventory:inventories(*), 
 				salesperson:users!salesperson_id(*), 
 				manager:users!manager_id(*)`).
 			Eq("salesperson_id", salespersonID).
+			IsNull("deleted_at").
 			Execute(ctx, &sales)
 	})
 
@@ -377,26 +404,30 @@ func (r *saleRepository) GetBySalesperson(ctx context.Context, salespersonID uui
 	return sales, nil
 }
 
-// UpdateStatus updates sale status
-func (r *saleRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.SaleStatus) error {
+// UpdateStatus moves a live sale from one status to another
+func (r *saleRepository) UpdateStatus(ctx context.Context, id uuid.UUID, from, to domain.SaleStatus) error {
 	ctx, span := saleTracer.Start(ctx, "saleRepository.UpdateStatus")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("sale.id", id.String()),
-		attribute.String("sale.status", string(status)),
+		attribute.String("sale.from_status", string(from)),
+		attribute.String("sale.status", string(to)),
 	)
 
 	updateData := map[string]interface{}{
-		"status":     string(status),
-		"updated_at": time.Now(),
+		"status":     string(to),
+		"updated_at": timestamp(),
 	}
 
 	err := r.client.ExecuteQuery(ctx, "update", "sales", func() error {
-		return r.client.From("sales").
+		err := r.client.scoped(ctx, "sales").
 			Update(updateData).
 			Eq("id", id).
-			Execute(ctx, nil)
+			Eq("status", string(from)).
+			IsNull("deleted_at").
+			ExecuteAffected(ctx)
+		return r.client.expectVersion(ctx, "sales", id, err)
 	})
 
 	if err != nil {
@@ -412,11 +443,12 @@ func (r *saleRepository) GetSalesStats(ctx context.Context, filters domain.SaleF
 	ctx, span := saleTracer.Start(ctx, "saleRepository.GetSalesStats")
 	defer span.End()
 
-	// For now, return a basic implementation
-	// In a real implementation, this would calculate various statistics
-	// like total sales, average sale amount, sales by period, etc.
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	yearStart := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
 
-	// Get all sales matching the filters
+	// Stats cover every matching sale, not just the requested page
+	filters.Limit, filters.Offset = 0, 0
 	sales, err := r.List(ctx, filters)
 	if err != nil {
 		span.RecordError(err)
@@ -450,6 +482,13 @@ func (r *saleRepository) GetSalesStats(ctx context.Context, filters domain.SaleF
 		case domain.SaleStatusCancelled:
 			stats.CancelledSales++
 		}
+
+		if !sale.SaleDate.Before(monthStart) {
+			stats.MonthlyRevenue += sale.FinalAmount
+		}
+		if !sale.SaleDate.Before(yearStart) {
+			stats.YearlyRevenue += sale.FinalAmount
+		}
 	}
 
 	if stats.TotalSales > 0 {
@@ -0,0 +1,84 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var saleHistoryTracer = otel.Tracer("goreal-backend/infrastructure/supabase/sale_status_history")
+
+// saleStatusHistoryRepository implements domain.SaleStatusHistoryRepository using Supabase
+type saleStatusHistoryRepository struct {
+	client *Client
+}
+
+// NewSaleStatusHistoryRepository creates a new sale status history repository
+func NewSaleStatusHistoryRepository(client *Client) domain.SaleStatusHistoryRepository {
+	return &saleStatusHistoryRepository{
+		client: client,
+	}
+}
+
+// Create records a sale transition
+func (r *saleStatusHistoryRepository) Create(ctx context.Context, entry *domain.SaleStatusHistory) error {
+	if err := domain.AssignOrganization(ctx, &entry.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := saleHistoryTracer.Start(ctx, "saleStatusHistoryRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", entry.SaleID.String()),
+		attribute.String("sale.from_status", string(entry.FromStatus)),
+		attribute.String("sale.status", string(entry.ToStatus)),
+	)
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "sale_status_history", func() error {
+		return r.client.From("sale_status_history").Insert(entry).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create sale status history: %w", err)
+	}
+
+	return nil
+}
+
+// ListBySale returns the transitions of a sale, oldest first
+func (r *saleStatusHistoryRepository) ListBySale(ctx context.Context, saleID uuid.UUID) ([]*domain.SaleStatusHistory, error) {
+	ctx, span := saleHistoryTracer.Start(ctx, "saleStatusHistoryRepository.ListBySale")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	entries := []*domain.SaleStatusHistory{}
+	err := r.client.ExecuteQuery(ctx, "select", "sale_status_history", func() error {
+		return r.client.scoped(ctx, "sale_status_history").
+			Select("*").
+			Eq("sale_id", saleID).
+			Order("created_at", true).
+			Order("id", true).
+			Execute(ctx, &entries)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list sale status history: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(entries)))
+
+	return entries, nil
+}
@@ -0,0 +1,92 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var searchTracer = otel.Tracer("goreal-backend/infrastructure/supabase/search")
+
+// searchRepository implements domain.SearchRepository by calling the
+// search_crm function from 014_crm_search.sql over RPC
+type searchRepository struct {
+	client *Client
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(client *Client) domain.SearchRepository {
+	return &searchRepository{
+		client: client,
+	}
+}
+
+// searchArgs are the named parameters of search_crm
+type searchArgs struct {
+	SearchQuery  string     `json:"search_query"`
+	EntityTypes  []string   `json:"entity_types"`
+	Organization *uuid.UUID `json:"organization"`
+	MaxResults   int        `json:"max_results"`
+}
+
+// dbSearchHit is a row returned by search_crm
+type dbSearchHit struct {
+	EntityType string    `json:"entity_type"`
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	Subtitle   *string   `json:"subtitle"`
+	Score      float64   `json:"score"`
+	Highlight  *string   `json:"highlight"`
+}
+
+// Search returns the records matching query, best match first
+func (r *searchRepository) Search(ctx context.Context, query domain.SearchQuery) ([]*domain.SearchHit, error) {
+	ctx, span := searchTracer.Start(ctx, "searchRepository.Search")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("search.query", query.Text),
+		attribute.Int("search.limit", query.Limit),
+	)
+
+	args := searchArgs{
+		SearchQuery: query.Text,
+		MaxResults:  query.Limit,
+	}
+	for _, t := range query.Types {
+		args.EntityTypes = append(args.EntityTypes, string(t))
+	}
+	if id, ok := domain.OrganizationFromContext(ctx); ok {
+		args.Organization = &id
+	}
+
+	var rows []dbSearchHit
+	err := r.client.ExecuteQuery(ctx, "rpc", "search_crm", func() error {
+		return r.client.RPC("search_crm", args).Execute(ctx, &rows)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	hits := make([]*domain.SearchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = &domain.SearchHit{
+			Type:      domain.SearchEntityType(row.EntityType),
+			ID:        row.ID,
+			Title:     row.Title,
+			Subtitle:  row.Subtitle,
+			Score:     row.Score,
+			Highlight: row.Highlight,
+		}
+	}
+	span.SetAttributes(attribute.Int("result.count", len(hits)))
+
+	return hits, nil
+}
@@ -0,0 +1,59 @@
+package supabase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/infrastructure/supabase/supabasetest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRepository_CallsSearchFunction(t *testing.T) {
+	client, srv := newTestClient(t)
+	repo := NewSearchRepository(client)
+
+	leadID := uuid.New()
+	var args map[string]interface{}
+	srv.Define("search_crm", func(a map[string]interface{}) ([]supabasetest.Row, error) {
+		args = a
+		return []supabasetest.Row{{
+			"entity_type": "lead",
+			"id":          leadID.String(),
+			"title":       "Priya Sharma",
+			"subtitle":    nil,
+			"score":       1.6,
+			"highlight":   "<b>Priya</b> Sharma",
+		}}, nil
+	})
+
+	org := uuid.New()
+	ctx := domain.WithOrganization(context.Background(), org)
+	hits, err := repo.Search(ctx, domain.SearchQuery{
+		Text:  "priya",
+		Types: []domain.SearchEntityType{domain.SearchEntityLead},
+		Limit: 10,
+	})
+	require.NoError(t, err)
+
+	req := srv.LastRequest()
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "rpc/search_crm", req.Table)
+	assert.Equal(t, "priya", args["search_query"])
+	assert.Equal(t, []interface{}{"lead"}, args["entity_types"])
+	assert.Equal(t, org.String(), args["organization"])
+	assert.Equal(t, float64(10), args["max_results"])
+
+	require.Len(t, hits, 1)
+	assert.Equal(t, domain.SearchEntityLead, hits[0].Type)
+	assert.Equal(t, leadID, hits[0].ID)
+	assert.Equal(t, "Priya Sharma", hits[0].Title)
+	assert.Nil(t, hits[0].Subtitle)
+	assert.Equal(t, 1.6, hits[0].Score)
+	require.NotNil(t, hits[0].Highlight)
+	assert.Equal(t, "<b>Priya</b> Sharma", *hits[0].Highlight)
+}
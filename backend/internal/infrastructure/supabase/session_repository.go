@@ -0,0 +1,293 @@
+package supabase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var sessionTracer = otel.Tracer("goreal-backend/infrastructure/supabase/session")
+
+// sessionRepository implements domain.SessionRepository using Supabase
+type sessionRepository struct {
+	client *Client
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(client *Client) domain.SessionRepository {
+	return &sessionRepository{
+		client: client,
+	}
+}
+
+// Create stores a new session
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", session.UserID.String()))
+
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = time.Now()
+	}
+	if session.LastUsedAt.IsZero() {
+		session.LastUsedAt = session.CreatedAt
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "user_sessions", func() error {
+		return r.client.From("user_sessions").Insert(toDBSession(session)).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a session by ID
+func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("session.id", id.String()))
+
+	var row dbSession
+	err := r.client.ExecuteQuery(ctx, "select", "user_sessions", func() error {
+		return r.client.From("user_sessions").
+			Select("*").
+			Eq("id", id).
+			Single(ctx, &row)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// ListActive returns the sessions of a user still usable at a time, most
+// recently used first
+func (r *sessionRepository) ListActive(ctx context.Context, userID uuid.UUID, at time.Time) ([]*domain.Session, error) {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.ListActive")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	rows := []dbSession{}
+	err := r.client.ExecuteQuery(ctx, "select", "user_sessions", func() error {
+		return r.client.From("user_sessions").
+			Select("*").
+			Eq("user_id", userID).
+			IsNull("revoked_at").
+			Gt("expires_at", at).
+			Order("last_used_at", false).
+			Order("id", true).
+			Execute(ctx, &rows)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*domain.Session, len(rows))
+	for i := range rows {
+		sessions[i] = rows[i].toDomain()
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(sessions)))
+
+	return sessions, nil
+}
+
+// Rotate replaces the refresh token of an active session still holding
+// oldHash. The update is guarded by the old hash, so of two refreshes racing
+// with the same token only one succeeds.
+func (r *sessionRepository) Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash string, expiresAt, usedAt time.Time) error {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.Rotate")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("session.id", id.String()))
+
+	err := r.client.ExecuteQuery(ctx, "update", "user_sessions", func() error {
+		var current dbSession
+		err := r.client.From("user_sessions").
+			Select("*").
+			Eq("id", id).
+			Single(ctx, &current)
+		if err != nil {
+			return err
+		}
+
+		return r.client.From("user_sessions").
+			Update(map[string]interface{}{
+				"token_hash":   newHash,
+				"rotations":    current.Rotations + 1,
+				"expires_at":   expiresAt,
+				"last_used_at": usedAt,
+			}).
+			Eq("id", id).
+			Eq("token_hash", oldHash).
+			IsNull("revoked_at").
+			Gt("expires_at", usedAt).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke ends a session
+func (r *sessionRepository) Revoke(ctx context.Context, id uuid.UUID, reason domain.RevokeReason, at time.Time) error {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.Revoke")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("session.id", id.String()),
+		attribute.String("session.revoked_reason", string(reason)),
+	)
+
+	err := r.client.ExecuteQuery(ctx, "update", "user_sessions", func() error {
+		err := r.client.From("user_sessions").
+			Update(map[string]interface{}{
+				"revoked_at":     at,
+				"revoked_reason": reason,
+			}).
+			Eq("id", id).
+			IsNull("revoked_at").
+			ExecuteAffected(ctx)
+		if !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+
+		// Nothing was updated: a session already revoked is left as it was
+		var rows []json.RawMessage
+		if err := r.client.From("user_sessions").Select("id").Eq("id", id).Execute(ctx, &rows); err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("session %s: %w", id, domain.ErrNotFound)
+		}
+		return nil
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeByUser ends every active session of a user
+func (r *sessionRepository) RevokeByUser(ctx context.Context, userID uuid.UUID, reason domain.RevokeReason, at time.Time) (int, error) {
+	ctx, span := sessionTracer.Start(ctx, "sessionRepository.RevokeByUser")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("session.revoked_reason", string(reason)),
+	)
+
+	var rows []json.RawMessage
+	err := r.client.ExecuteQuery(ctx, "update", "user_sessions", func() error {
+		return r.client.From("user_sessions").
+			Select("id").
+			Update(map[string]interface{}{
+				"revoked_at":     at,
+				"revoked_reason": reason,
+			}).
+			Eq("user_id", userID).
+			IsNull("revoked_at").
+			Gt("expires_at", at).
+			Execute(ctx, &rows)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(rows)))
+
+	return len(rows), nil
+}
+
+// dbSession represents the database model for sessions; unlike
+// domain.Session it carries the token hash in its JSON
+type dbSession struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	UserID        uuid.UUID  `json:"user_id" db:"user_id"`
+	DeviceName    *string    `json:"device_name" db:"device_name"`
+	UserAgent     *string    `json:"user_agent" db:"user_agent"`
+	IPAddress     *string    `json:"ip_address" db:"ip_address"`
+	TokenHash     string     `json:"token_hash" db:"token_hash"`
+	Rotations     int        `json:"rotations" db:"rotations"`
+	ExpiresAt     time.Time  `json:"expires_at" db:"expires_at"`
+	LastUsedAt    time.Time  `json:"last_used_at" db:"last_used_at"`
+	RevokedAt     *time.Time `json:"revoked_at" db:"revoked_at"`
+	RevokedReason *string    `json:"revoked_reason" db:"revoked_reason"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// toDBSession converts a domain session to its database model
+func toDBSession(session *domain.Session) *dbSession {
+	var reason *string
+	if session.RevokedReason != nil {
+		r := string(*session.RevokedReason)
+		reason = &r
+	}
+	return &dbSession{
+		ID:            session.ID,
+		UserID:        session.UserID,
+		DeviceName:    session.DeviceName,
+		UserAgent:     session.UserAgent,
+		IPAddress:     session.IPAddress,
+		TokenHash:     session.TokenHash,
+		Rotations:     session.Rotations,
+		ExpiresAt:     session.ExpiresAt,
+		LastUsedAt:    session.LastUsedAt,
+		RevokedAt:     session.RevokedAt,
+		RevokedReason: reason,
+		CreatedAt:     session.CreatedAt,
+	}
+}
+
+// toDomain converts a database session model to the domain model
+func (s *dbSession) toDomain() *domain.Session {
+	var reason *domain.RevokeReason
+	if s.RevokedReason != nil {
+		r := domain.RevokeReason(*s.RevokedReason)
+		reason = &r
+	}
+	return &domain.Session{
+		ID:            s.ID,
+		UserID:        s.UserID,
+		DeviceName:    s.DeviceName,
+		UserAgent:     s.UserAgent,
+		IPAddress:     s.IPAddress,
+		TokenHash:     s.TokenHash,
+		Rotations:     s.Rotations,
+		ExpiresAt:     s.ExpiresAt,
+		LastUsedAt:    s.LastUsedAt,
+		RevokedAt:     s.RevokedAt,
+		RevokedReason: reason,
+		CreatedAt:     s.CreatedAt,
+	}
+}
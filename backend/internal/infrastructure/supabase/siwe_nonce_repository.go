@@ -0,0 +1,68 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"go.opentelemetry.io/otel"
+)
+
+var siweNonceTracer = otel.Tracer("goreal-backend/infrastructure/supabase/siwe_nonce")
+
+// siweNonceRepository implements domain.SIWENonceRepository using Supabase
+type siweNonceRepository struct {
+	client *Client
+}
+
+// NewSIWENonceRepository creates a new SIWE nonce repository
+func NewSIWENonceRepository(client *Client) domain.SIWENonceRepository {
+	return &siweNonceRepository{
+		client: client,
+	}
+}
+
+// Create stores a new nonce
+func (r *siweNonceRepository) Create(ctx context.Context, nonce *domain.SIWENonce) error {
+	ctx, span := siweNonceTracer.Start(ctx, "siweNonceRepository.Create")
+	defer span.End()
+
+	if nonce.CreatedAt.IsZero() {
+		nonce.CreatedAt = time.Now()
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "siwe_nonces", func() error {
+		return r.client.From("siwe_nonces").Insert(nonce).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create SIWE nonce: %w", err)
+	}
+
+	return nil
+}
+
+// Consume redeems an unused, unexpired nonce in a single guarded update
+func (r *siweNonceRepository) Consume(ctx context.Context, nonce string, at time.Time) error {
+	ctx, span := siweNonceTracer.Start(ctx, "siweNonceRepository.Consume")
+	defer span.End()
+
+	err := r.client.ExecuteQuery(ctx, "update", "siwe_nonces", func() error {
+		return r.client.From("siwe_nonces").
+			Update(map[string]interface{}{"used_at": at}).
+			Eq("nonce", nonce).
+			IsNull("used_at").
+			Gt("expires_at", at).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to consume SIWE nonce: %w", err)
+	}
+
+	return nil
+}
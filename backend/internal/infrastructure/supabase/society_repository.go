@@ -0,0 +1,212 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var societyTracer = otel.Tracer("goreal-backend/infrastructure/supabase/society")
+
+type societyRepository struct {
+	client *Client
+}
+
+// NewSocietyRepository creates a new society repository
+func NewSocietyRepository(client *Client) domain.SocietyRepository {
+	return &societyRepository{
+		client: client,
+	}
+}
+
+// Create creates a new society
+func (r *societyRepository) Create(ctx context.Context, society *domain.Society) error {
+	if err := domain.AssignOrganization(ctx, &society.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := societyTracer.Start(ctx, "societyRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("society.name", society.Name),
+		attribute.String("society.id", society.ID.String()),
+	)
+
+	// Set timestamps unless the caller supplied them
+	now := time.Now()
+	if society.CreatedAt.IsZero() {
+		society.CreatedAt = now
+	}
+	if society.UpdatedAt.IsZero() {
+		society.UpdatedAt = society.CreatedAt
+	}
+
+	err := r.client.ExecuteQuery(ctx, "insert", "societies", func() error {
+		return r.client.From("societies").Insert(society).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create society: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a society by ID
+func (r *societyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Society, error) {
+	ctx, span := societyTracer.Start(ctx, "societyRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("society.id", id.String()))
+
+	var society domain.Society
+	err := r.client.ExecuteQuery(ctx, "select", "societies", func() error {
+		return r.client.scoped(ctx, "societies").
+			Select("*").
+			Eq("id", id).
+			Single(ctx, &society)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get society by ID: %w", err)
+	}
+
+	return &society, nil
+}
+
+// Update updates an existing society
+func (r *societyRepository) Update(ctx context.Context, society *domain.Society) error {
+	if err := domain.AssignOrganization(ctx, &society.OrganizationID); err != nil {
+		return err
+	}
+	ctx, span := societyTracer.Start(ctx, "societyRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("society.id", society.ID.String()),
+		attribute.String("society.name", society.Name),
+	)
+
+	// Update timestamp
+	society.UpdatedAt = time.Now()
+
+	err := r.client.ExecuteQuery(ctx, "update", "societies", func() error {
+		return r.client.scoped(ctx, "societies").
+			Update(society).
+			Eq("id", society.ID).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to update society: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a society by ID
+func (r *societyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := societyTracer.Start(ctx, "societyRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("society.id", id.String()))
+
+	err := r.client.ExecuteQuery(ctx, "delete", "societies", func() error {
+		return r.client.scoped(ctx, "societies").
+			Delete().
+			Eq("id", id).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete society: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves societies with pagination and filtering
+func (r *societyRepository) List(ctx context.Context, filters domain.SocietyFilters) ([]*domain.Society, error) {
+	ctx, span := societyTracer.Start(ctx, "societyRepository.List")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
+
+	query := r.client.scoped(ctx, "societies").Select("*")
+
+	query = applySocietyFilters(query, filters)
+	query = applySort(query, filters.BaseFilters, societySortable, "created_at", false)
+	query = applyPagination(query, filters.BaseFilters)
+
+	var societies []*domain.Society
+	err := r.client.ExecuteQuery(ctx, "select", "societies", func() error {
+		return query.Execute(ctx, &societies)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list societies: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(societies)))
+
+	return societies, nil
+}
+
+// Count returns the total number of societies matching the filters
+func (r *societyRepository) Count(ctx context.Context, filters domain.SocietyFilters) (int, error) {
+	ctx, span := societyTracer.Start(ctx, "societyRepository.Count")
+	defer span.End()
+
+	query := r.client.scoped(ctx, "societies").Select("id")
+
+	query = applySocietyFilters(query, filters)
+
+	var count int
+	err := r.client.ExecuteQuery(ctx, "select", "societies", func() error {
+		var rows []map[string]interface{}
+		var err error
+		count, err = query.ExecuteWithCount(ctx, &rows)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count societies: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+// applySocietyFilters adds the SocietyFilters conditions shared by List and Count
+func applySocietyFilters(query *QueryBuilder, filters domain.SocietyFilters) *QueryBuilder {
+	if filters.Location != nil && *filters.Location != "" {
+		query = query.Ilike("location", fmt.Sprintf("%%%s%%", *filters.Location))
+	}
+	if filters.IsActive != nil {
+		query = query.Eq("is_active", *filters.IsActive)
+	}
+	if filters.CreatedBy != nil {
+		query = query.Eq("created_by", *filters.CreatedBy)
+	}
+	if filters.Search != nil {
+		// Search in name, developer, location or description
+		query = query.IlikeAny(*filters.Search, "name", "developer_name", "location", "description")
+	}
+	return query
+}
@@ -0,0 +1,784 @@
+// Package supabasetest provides an in-process fake of the PostgREST API
+// for exercising the Supabase repositories without a live project.
+package supabasetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Row is a single table row as decoded from JSON
+type Row map[string]interface{}
+
+// Request is a request recorded by the fake server
+type Request struct {
+	Method string
+	Table  string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Server is a fake PostgREST server mounted at /rest/v1
+type Server struct {
+	srv *httptest.Server
+
+	mu        sync.Mutex
+	tables    map[string][]Row
+	functions map[string]Function
+	requests  []Request
+}
+
+// Function is a fake Postgres function called over RPC. It receives the
+// named arguments of the call and returns the rows of its result.
+type Function func(args map[string]interface{}) ([]Row, error)
+
+// NewServer starts a fake PostgREST server. Callers must Close it.
+func NewServer() *Server {
+	s := &Server{tables: make(map[string][]Row), functions: make(map[string]Function)}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// URL returns the project URL to use as SUPABASE_URL
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Seed appends rows to a table
+func (s *Server) Seed(table string, rows ...Row) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, row := range rows {
+		s.tables[table] = append(s.tables[table], copyRow(row))
+	}
+}
+
+// Define registers a function callable at /rest/v1/rpc/name
+func (s *Server) Define(name string, fn Function) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.functions[name] = fn
+}
+
+// Rows returns a copy of the rows currently stored in a table
+func (s *Server) Rows(table string) []Row {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rows := make([]Row, len(s.tables[table]))
+	for i, row := range s.tables[table] {
+		rows[i] = copyRow(row)
+	}
+	return rows
+}
+
+// Requests returns the requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// LastRequest returns the most recent request
+func (s *Server) LastRequest() Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		return Request{}
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/rest/v1/") {
+		writeError(w, http.StatusNotFound, "PGRST000", "unknown path "+r.URL.Path)
+		return
+	}
+	table := strings.TrimPrefix(r.URL.Path, "/rest/v1/")
+
+	body, _ := io.ReadAll(r.Body)
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Table:  table,
+		Query:  query,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	if r.Header.Get("apikey") == "" {
+		writeError(w, http.StatusUnauthorized, "PGRST301", "missing apikey")
+		return
+	}
+
+	if name, ok := strings.CutPrefix(table, "rpc/"); ok {
+		s.call(w, r.Method, name, body)
+		return
+	}
+
+	preferRepresentation := strings.Contains(r.Header.Get("Prefer"), "return=representation")
+
+	var result []Row
+	status := http.StatusOK
+	switch r.Method {
+	case http.MethodGet:
+		matched, offset, total, err := s.match(table, query)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "PGRST100", err.Error())
+			return
+		}
+		result = matched
+		if len(matched) == 0 {
+			w.Header().Set("Content-Range", fmt.Sprintf("*/%d", total))
+		} else {
+			w.Header().Set("Content-Range", fmt.Sprintf("%d-%d/%d", offset, offset+len(matched)-1, total))
+		}
+
+	case http.MethodPost:
+		rows, err := decodeRows(body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "PGRST102", err.Error())
+			return
+		}
+		for _, row := range rows {
+			if id, ok := row["id"]; ok && s.indexOf(table, id) >= 0 {
+				writeError(w, http.StatusConflict, "23505", fmt.Sprintf("duplicate key value violates unique constraint \"%s_pkey\"", table))
+				return
+			}
+		}
+		for _, row := range rows {
+			s.tables[table] = append(s.tables[table], copyRow(row))
+			result = append(result, copyRow(row))
+		}
+		status = http.StatusCreated
+
+	case http.MethodPatch:
+		patch := Row{}
+		if err := json.Unmarshal(body, &patch); err != nil {
+			writeError(w, http.StatusBadRequest, "PGRST102", err.Error())
+			return
+		}
+		matched, err := s.filterRows(s.tables[table], query)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "PGRST100", err.Error())
+			return
+		}
+		for _, row := range matched {
+			for k, v := range patch {
+				row[k] = v
+			}
+			result = append(result, copyRow(row))
+		}
+
+	case http.MethodDelete:
+		matched, err := s.filterRows(s.tables[table], query)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "PGRST100", err.Error())
+			return
+		}
+		kept := s.tables[table][:0]
+		for _, row := range s.tables[table] {
+			if containsRow(matched, row) {
+				result = append(result, copyRow(row))
+				continue
+			}
+			kept = append(kept, row)
+		}
+		s.tables[table] = kept
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "PGRST000", "method not allowed")
+		return
+	}
+
+	if r.Method != http.MethodGet && !preferRepresentation {
+		if status == http.StatusOK {
+			status = http.StatusNoContent
+		}
+		w.WriteHeader(status)
+		return
+	}
+
+	result = project(result, query.Get("select"))
+
+	if strings.Contains(r.Header.Get("Accept"), "vnd.pgrst.object") {
+		if len(result) != 1 {
+			writeError(w, http.StatusNotAcceptable, "PGRST116",
+				fmt.Sprintf("JSON object requested, multiple (or no) rows returned: %d rows", len(result)))
+			return
+		}
+		writeJSON(w, status, result[0])
+		return
+	}
+
+	if result == nil {
+		result = []Row{}
+	}
+	writeJSON(w, status, result)
+}
+
+// call runs a function registered with Define
+func (s *Server) call(w http.ResponseWriter, method, name string, body []byte) {
+	fn, ok := s.functions[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "PGRST202", "could not find the function "+name)
+		return
+	}
+	if method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "PGRST000", "method not allowed")
+		return
+	}
+
+	args := map[string]interface{}{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &args); err != nil {
+			writeError(w, http.StatusBadRequest, "PGRST102", err.Error())
+			return
+		}
+	}
+
+	rows, err := fn(args)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "P0001", err.Error())
+		return
+	}
+	if rows == nil {
+		rows = []Row{}
+	}
+	writeJSON(w, http.StatusOK, rows)
+}
+
+// match applies filters, ordering and pagination for a GET request.
+// It returns the page, its offset and the total number of matching rows.
+func (s *Server) match(table string, query url.Values) ([]Row, int, int, error) {
+	filtered, err := s.filterRows(s.tables[table], query)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	rows := make([]Row, len(filtered))
+	for i, row := range filtered {
+		rows[i] = copyRow(row)
+	}
+
+	if order := query.Get("order"); order != "" {
+		sortRows(rows, order)
+	}
+
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if offset > len(rows) {
+		offset = len(rows)
+	}
+	end := len(rows)
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("invalid limit %q", limit)
+		}
+		if offset+n < end {
+			end = offset + n
+		}
+	}
+
+	return rows[offset:end], offset, len(rows), nil
+}
+
+// filterRows returns the stored rows (not copies) matching all filters
+func (s *Server) filterRows(rows []Row, query url.Values) ([]Row, error) {
+	var out []Row
+	for _, row := range rows {
+		ok, err := matchesAll(row, query)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, row)
+		}
+	}
+	return out, nil
+}
+
+func (s *Server) indexOf(table string, id interface{}) int {
+	for i, row := range s.tables[table] {
+		if fmt.Sprint(row["id"]) == fmt.Sprint(id) {
+			return i
+		}
+	}
+	return -1
+}
+
+var reservedParams = map[string]bool{
+	"select": true, "order": true, "limit": true, "offset": true, "on_conflict": true, "columns": true,
+}
+
+func matchesAll(row Row, query url.Values) (bool, error) {
+	for column, conditions := range query {
+		if reservedParams[column] {
+			continue
+		}
+		for _, condition := range conditions {
+			var ok bool
+			var err error
+			if column == "or" {
+				ok, err = matchesOr(row, condition)
+			} else {
+				ok, err = matches(row, column, condition)
+			}
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// matchesOr evaluates "(a.eq.1,b.ilike.%x%)"; parts may nest "and(...)"
+func matchesOr(row Row, condition string) (bool, error) {
+	return matchesGroup(row, condition, false)
+}
+
+// matchesGroup evaluates a parenthesised list of conditions, requiring all
+// of them to hold when all is set and any of them otherwise
+func matchesGroup(row Row, condition string, all bool) (bool, error) {
+	condition = strings.TrimSuffix(strings.TrimPrefix(condition, "("), ")")
+	for _, part := range splitTopLevel(condition) {
+		var ok bool
+		var err error
+		switch {
+		case strings.HasPrefix(part, "and("):
+			ok, err = matchesGroup(row, strings.TrimPrefix(part, "and"), true)
+		case strings.HasPrefix(part, "or("):
+			ok, err = matchesGroup(row, strings.TrimPrefix(part, "or"), false)
+		default:
+			idx := strings.Index(part, ".")
+			if idx < 0 {
+				return false, fmt.Errorf("invalid logical condition %q", part)
+			}
+			ok, err = matches(row, part[:idx], part[idx+1:])
+		}
+		if err != nil {
+			return false, err
+		}
+		if ok != all {
+			return ok, nil
+		}
+	}
+	return all, nil
+}
+
+// matches evaluates a single "operator.value" condition against a column
+func matches(row Row, column, condition string) (bool, error) {
+	negate := false
+	if strings.HasPrefix(condition, "not.") {
+		negate = true
+		condition = strings.TrimPrefix(condition, "not.")
+	}
+
+	idx := strings.Index(condition, ".")
+	if idx < 0 {
+		return false, fmt.Errorf("invalid filter %s=%s", column, condition)
+	}
+	op, operand := condition[:idx], condition[idx+1:]
+	if unquoted, ok := unquote(operand); ok {
+		operand = unquoted
+	}
+	value, present := row[column]
+
+	var ok bool
+	switch op {
+	case "eq":
+		ok = present && compare(value, operand) == 0
+	case "neq":
+		ok = present && value != nil && compare(value, operand) != 0
+	case "gt":
+		ok = present && value != nil && compare(value, operand) > 0
+	case "gte":
+		ok = present && value != nil && compare(value, operand) >= 0
+	case "lt":
+		ok = present && value != nil && compare(value, operand) < 0
+	case "lte":
+		ok = present && value != nil && compare(value, operand) <= 0
+	case "like", "ilike":
+		s, isString := value.(string)
+		ok = isString && like(s, operand, op == "ilike")
+	case "match", "imatch":
+		if op == "imatch" {
+			operand = "(?i)" + operand
+		}
+		re, err := regexp.Compile(operand)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s pattern %q: %w", op, operand, err)
+		}
+		s, isString := value.(string)
+		ok = isString && re.MatchString(s)
+	case "is":
+		switch operand {
+		case "null":
+			ok = value == nil
+		case "true", "false":
+			b, isBool := value.(bool)
+			ok = isBool && strconv.FormatBool(b) == operand
+		default:
+			return false, fmt.Errorf("invalid is operand %q", operand)
+		}
+	case "in":
+		for _, item := range parseList(operand, "(", ")") {
+			if value != nil && compare(value, item) == 0 {
+				ok = true
+				break
+			}
+		}
+	case "cs":
+		ok = containsAll(value, operand)
+	default:
+		return false, fmt.Errorf("unsupported operator %q", op)
+	}
+
+	if negate {
+		return !ok, nil
+	}
+	return ok, nil
+}
+
+// compare orders a stored JSON value against a filter operand
+func compare(value interface{}, operand string) int {
+	switch v := value.(type) {
+	case float64:
+		f, err := strconv.ParseFloat(operand, 64)
+		if err != nil {
+			return strings.Compare(fmt.Sprint(v), operand)
+		}
+		switch {
+		case v < f:
+			return -1
+		case v > f:
+			return 1
+		}
+		return 0
+	case bool:
+		return strings.Compare(strconv.FormatBool(v), operand)
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			if o, err := time.Parse(time.RFC3339Nano, operand); err == nil {
+				return t.Compare(o)
+			}
+		}
+		return strings.Compare(v, operand)
+	case nil:
+		if operand == "null" {
+			return 0
+		}
+		return -1
+	}
+	return strings.Compare(fmt.Sprint(value), operand)
+}
+
+func compareValues(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	if bs, ok := b.(string); ok {
+		return compare(a, bs)
+	}
+	if bf, ok := b.(float64); ok {
+		return compare(a, strconv.FormatFloat(bf, 'f', -1, 64))
+	}
+	return compare(a, fmt.Sprint(b))
+}
+
+// sortRows applies "col.asc,col2.desc.nullsfirst"; nulls sort last by default
+func sortRows(rows []Row, order string) {
+	keys := strings.Split(order, ",")
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, key := range keys {
+			parts := strings.Split(key, ".")
+			column := parts[0]
+			desc := len(parts) > 1 && parts[1] == "desc"
+			c := compareValues(rows[i][column], rows[j][column])
+			if c == 0 {
+				continue
+			}
+			if rows[i][column] == nil || rows[j][column] == nil {
+				return c < 0
+			}
+			if desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+// containsAll implements the cs operator for array ("{a,b}") and jsonb operands
+func containsAll(value interface{}, operand string) bool {
+	if strings.HasPrefix(operand, "{") && strings.HasSuffix(operand, "}") {
+		items, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, want := range parseList(operand, "{", "}") {
+			found := false
+			for _, item := range items {
+				if fmt.Sprint(item) == want {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	var want interface{}
+	if err := json.Unmarshal([]byte(operand), &want); err != nil {
+		return false
+	}
+	return jsonContains(value, want)
+}
+
+func jsonContains(have, want interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		h, ok := have.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range w {
+			if !jsonContains(h[k], v) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		h, ok := have.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, wv := range w {
+			found := false
+			for _, hv := range h {
+				if jsonContains(hv, wv) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+	return fmt.Sprint(have) == fmt.Sprint(want)
+}
+
+// parseList splits "(a,"b,c")" style lists, honouring double quotes
+func parseList(s, open, close string) []string {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, open), close)
+	if s == "" {
+		return nil
+	}
+	var items []string
+	var current strings.Builder
+	quoted, escaped := false, false
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			items = append(items, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(items, current.String())
+}
+
+// splitTopLevel splits on commas that are not nested in parentheses or
+// double quotes
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	quoted, escaped := false, false
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unquote reads a double-quoted value, where a backslash escapes the
+// character after it
+func unquote(s string) (string, bool) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s, false
+	}
+	var out strings.Builder
+	escaped := false
+	for _, r := range s[1 : len(s)-1] {
+		if !escaped && r == '\\' {
+			escaped = true
+			continue
+		}
+		escaped = false
+		out.WriteRune(r)
+	}
+	return out.String(), true
+}
+
+// like matches SQL LIKE patterns where % is any run of characters.
+// PostgREST also accepts * in place of %.
+func like(s, pattern string, insensitive bool) bool {
+	pattern = strings.ReplaceAll(pattern, "*", "%")
+	if insensitive {
+		s, pattern = strings.ToLower(s), strings.ToLower(pattern)
+	}
+	parts := strings.Split(pattern, "%")
+	if len(parts) == 1 {
+		return s == pattern
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(s, part)
+		if idx < 0 {
+			return false
+		}
+		s = s[idx+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
+
+// project keeps the top-level columns listed in a select clause.
+// Embedded resources are not joined; the fake only serves flat rows.
+func project(rows []Row, selectClause string) []Row {
+	if selectClause == "" {
+		return rows
+	}
+	var columns []string
+	for _, part := range splitTopLevel(selectClause) {
+		if part == "*" {
+			return rows
+		}
+		if strings.Contains(part, "(") {
+			continue
+		}
+		if idx := strings.Index(part, ":"); idx >= 0 {
+			part = part[idx+1:]
+		}
+		columns = append(columns, part)
+	}
+	out := make([]Row, len(rows))
+	for i, row := range rows {
+		projected := Row{}
+		for _, column := range columns {
+			if v, ok := row[column]; ok {
+				projected[column] = v
+			}
+		}
+		out[i] = projected
+	}
+	return out
+}
+
+func decodeRows(body []byte) ([]Row, error) {
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		var rows []Row
+		err := json.Unmarshal(body, &rows)
+		return rows, err
+	}
+	row := Row{}
+	if err := json.Unmarshal(body, &row); err != nil {
+		return nil, err
+	}
+	return []Row{row}, nil
+}
+
+// containsRow reports whether row is one of rows: the same stored row, not
+// one with the same id, so that tables keyed by other columns work too
+func containsRow(rows []Row, row Row) bool {
+	for _, r := range rows {
+		if reflect.ValueOf(r).Pointer() == reflect.ValueOf(row).Pointer() {
+			return true
+		}
+	}
+	return false
+}
+
+func copyRow(row Row) Row {
+	// Round-trip through JSON so seeded Go values look like decoded ones
+	data, err := json.Marshal(row)
+	if err != nil {
+		panic(fmt.Sprintf("supabasetest: cannot encode row: %v", err))
+	}
+	out := Row{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic(fmt.Sprintf("supabasetest: cannot decode row: %v", err))
+	}
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"code":    code,
+		"message": message,
+	})
+}
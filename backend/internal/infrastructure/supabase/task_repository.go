@@ -27,6 +27,9 @@ func NewTaskRepository(client *Client) domain.TaskRepository {
 
 // Create creates a new task
 func (r *taskRepository) Create(ctx context.Context, task *domain.Task) error {
+	if err := domain.AssignOrganization(ctx, &task.OrganizationID); err != nil {
+		return err
+	}
 	ctx, span := taskTracer.Start(ctx, "taskRepository.Create")
 	defer span.End()
 
@@ -37,10 +40,14 @@ func (r *taskRepository) Create(ctx context.Context, task *domain.Task) error {
 		attribute.String("task.priority", string(task.Priority)),
 	)
 
-	// Set timestamps
+	// Set timestamps unless the caller supplied them
 	now := time.Now()
-	task.CreatedAt = now
-	task.UpdatedAt = now
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = now
+	}
+	if task.UpdatedAt.IsZero() {
+		task.UpdatedAt = task.CreatedAt
+	}
 
 	// Execute insert query
 	err := r.client.ExecuteQuery(ctx, "insert", "tasks", func() error {
@@ -64,9 +71,10 @@ func (r *taskRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Tas
 
 	var task domain.Task
 	err := r.client.ExecuteQuery(ctx, "select", "tasks", func() error {
-		return r.client.From("tasks").
+		return r.client.scoped(ctx, "tasks").
 			Select("*, assigned_user:users!assigned_to(*), assigned_by_user:users!assigned_by(*)").
 			Eq("id", id).
+			IsNull("deleted_at").
 			Single(ctx, &task)
 	})
 
@@ -80,6 +88,9 @@ func (r *taskRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Tas
 
 // Update updates an existing task
 func (r *taskRepository) Update(ctx context.Context, task *domain.Task) error {
+	if err := domain.AssignOrganization(ctx, &task.OrganizationID); err != nil {
+		return err
+	}
 	ctx, span := taskTracer.Start(ctx, "taskRepository.Update")
 	defer span.End()
 
@@ -90,7 +101,8 @@ func (r *taskRepository) Update(ctx context.Context, task *domain.Task) error {
 	)
 
 	// Update timestamp
-	task.UpdatedAt = time.Now()
+	since := task.UpdatedAt
+	task.UpdatedAt = timestamp()
 
 	// Set completion time if status is completed
 	if task.Status == domain.TaskStatusCompleted && task.CompletedAt == nil {
@@ -99,13 +111,17 @@ func (r *taskRepository) Update(ctx context.Context, task *domain.Task) error {
 	}
 
 	err := r.client.ExecuteQuery(ctx, "update", "tasks", func() error {
-		return r.client.From("tasks").
+		err := r.client.scoped(ctx, "tasks").
 			Update(task).
 			Eq("id", task.ID).
-			Execute(ctx, nil)
+			Eq("updated_at", since).
+			IsNull("deleted_at").
+			ExecuteAffected(ctx)
+		return r.client.expectVersion(ctx, "tasks", task.ID, err)
 	})
 
 	if err != nil {
+		task.UpdatedAt = since
 		span.RecordError(err)
 		return fmt.Errorf("failed to update task: %w", err)
 	}
@@ -113,18 +129,15 @@ func (r *taskRepository) Update(ctx context.Context, task *domain.Task) error {
 	return nil
 }
 
-// Delete deletes a task by ID
-func (r *taskRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete moves a task to the trash
+func (r *taskRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
 	ctx, span := taskTracer.Start(ctx, "taskRepository.Delete")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("task.id", id.String()))
 
 	err := r.client.ExecuteQuery(ctx, "delete", "tasks", func() error {
-		return r.client.From("tasks").
-			Delete().
-			Eq("id", id).
-			Execute(ctx, nil)
+		return r.client.softDelete(ctx, "tasks", id, deletedBy)
 	})
 
 	if err != nil {
@@ -135,85 +148,63 @@ func (r *taskRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// List retrieves tasks with pagination and filtering
-func (r *taskRepository) List(ctx context.Context, filters domain.TaskFilters) ([]*domain.Task, error) {
-	ctx, span := taskTracer.Start(ctx, "taskRepository.List")
+// Restore takes a task out of the trash
+func (r *taskRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.Restore")
 	defer span.End()
 
-	span.SetAttributes(
-		attribute.Int("filters.limit", filters.Limit),
-		attribute.Int("filters.offset", filters.Offset),
-	)
+	span.SetAttributes(attribute.String("task.id", id.String()))
 
-	query := r.client.From("tasks").
-		Select("*, assigned_user:users!assigned_to(*), assigned_by_user:users!assigned_by(*)")
+	err := r.client.ExecuteQuery(ctx, "restore", "tasks", func() error {
+		return r.client.restore(ctx, "tasks", id)
+	})
 
-	// Apply filters
-	if filters.AssignedTo != nil {
-		query = query.Eq("assigned_to", *filters.AssignedTo)
-		span.SetAttributes(attribute.String("filters.assigned_to", filters.AssignedTo.String()))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to restore task: %w", err)
 	}
 
-	if filters.AssignedBy != nil {
-		query = query.Eq("assigned_by", *filters.AssignedBy)
-		span.SetAttributes(attribute.String("filters.assigned_by", filters.AssignedBy.String()))
-	}
+	return nil
+}
 
-	if filters.Status != nil {
-		query = query.Eq("status", string(*filters.Status))
-		span.SetAttributes(attribute.String("filters.status", string(*filters.Status)))
-	}
+// Purge permanently removes tasks that were deleted before the cutoff
+func (r *taskRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.Purge")
+	defer span.End()
 
-	if filters.Priority != nil {
-		query = query.Eq("priority", string(*filters.Priority))
-		span.SetAttributes(attribute.String("filters.priority", string(*filters.Priority)))
-	}
+	var purged int
+	err := r.client.ExecuteQuery(ctx, "purge", "tasks", func() error {
+		var err error
+		purged, err = r.client.purge(ctx, "tasks", deletedBefore)
+		return err
+	})
 
-	if filters.RelatedToType != nil {
-		query = query.Eq("related_to_type", *filters.RelatedToType)
-		span.SetAttributes(attribute.String("filters.related_to_type", *filters.RelatedToType))
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to purge tasks: %w", err)
 	}
 
-	if filters.RelatedToID != nil {
-		query = query.Eq("related_to_id", *filters.RelatedToID)
-		span.SetAttributes(attribute.String("filters.related_to_id", filters.RelatedToID.String()))
-	}
+	span.SetAttributes(attribute.Int("result.count", purged))
 
-	if filters.Search != nil {
-		// Search in title or description
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("title.ilike.%s,description.ilike.%s",
-			searchPattern, searchPattern))
-		span.SetAttributes(attribute.String("filters.search", *filters.Search))
-	}
+	return purged, nil
+}
 
-	if filters.DueDateFrom != nil {
-		query = query.Gte("due_date", filters.DueDateFrom.Format(time.RFC3339))
-		span.SetAttributes(attribute.String("filters.due_date_from", filters.DueDateFrom.Format(time.RFC3339)))
-	}
+// List retrieves tasks with pagination and filtering
+func (r *taskRepository) List(ctx context.Context, filters domain.TaskFilters) ([]*domain.Task, error) {
+	ctx, span := taskTracer.Start(ctx, "taskRepository.List")
+	defer span.End()
 
-	if filters.DueDateTo != nil {
-		query = query.Lte("due_date", filters.DueDateTo.Format(time.RFC3339))
-		span.SetAttributes(attribute.String("filters.due_date_to", filters.DueDateTo.Format(time.RFC3339)))
-	}
+	span.SetAttributes(
+		attribute.Int("filters.limit", filters.Limit),
+		attribute.Int("filters.offset", filters.Offset),
+	)
 
-	// Apply sorting
-	if filters.SortBy != "" {
-		ascending := filters.SortOrder == "" || filters.SortOrder == "asc"
-		query = query.Order(filters.SortBy, ascending)
-		span.SetAttributes(
-			attribute.String("filters.sort_by", filters.SortBy),
-			attribute.Bool("filters.ascending", ascending),
-		)
-	}
+	query := r.client.scoped(ctx, "tasks").
+		Select("*, assigned_user:users!assigned_to(*), assigned_by_user:users!assigned_by(*)")
 
-	// Apply pagination
-	if filters.Limit > 0 {
-		query = query.Limit(filters.Limit)
-	}
-	if filters.Offset > 0 {
-		query = query.Offset(filters.Offset)
-	}
+	query = applyTaskFilters(query, filters)
+	query = applySort(query, filters.BaseFilters, taskSortable, "created_at", false)
+	query = applyPagination(query, filters.BaseFilters)
 
 	var tasks []*domain.Task
 	err := r.client.ExecuteQuery(ctx, "select", "tasks", func() error {
@@ -235,61 +226,65 @@ func (r *taskRepository) Count(ctx context.Context, filters domain.TaskFilters)
 	ctx, span := taskTracer.Start(ctx, "taskRepository.Count")
 	defer span.End()
 
-	query := r.client.From("tasks").Select("id")
+	query := r.client.scoped(ctx, "tasks").Select("id")
+
+	query = applyTaskFilters(query, filters)
+
+	var count int
+	err := r.client.ExecuteQuery(ctx, "select", "tasks", func() error {
+		var rows []map[string]interface{}
+		var err error
+		count, err = query.ExecuteWithCount(ctx, &rows)
+		return err
+	})
 
-	// Apply filters (same as List method)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+
+	return count, nil
+}
+
+// applyTaskFilters adds the TaskFilters conditions shared by List and Count
+func applyTaskFilters(query *QueryBuilder, filters domain.TaskFilters) *QueryBuilder {
+	if !filters.IncludeDeleted {
+		query = query.IsNull("deleted_at")
+	}
 	if filters.AssignedTo != nil {
 		query = query.Eq("assigned_to", *filters.AssignedTo)
 	}
-
 	if filters.AssignedBy != nil {
 		query = query.Eq("assigned_by", *filters.AssignedBy)
 	}
-
 	if filters.Status != nil {
 		query = query.Eq("status", string(*filters.Status))
 	}
-
 	if filters.Priority != nil {
 		query = query.Eq("priority", string(*filters.Priority))
 	}
-
 	if filters.RelatedToType != nil {
 		query = query.Eq("related_to_type", *filters.RelatedToType)
 	}
-
 	if filters.RelatedToID != nil {
 		query = query.Eq("related_to_id", *filters.RelatedToID)
 	}
-
-	if filters.Search != nil {
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("title.ilike.%s,description.ilike.%s",
-			searchPattern, searchPattern))
-	}
-
 	if filters.DueDateFrom != nil {
-		query = query.Gte("due_date", filters.DueDateFrom.Format(time.RFC3339))
+		query = query.Gte("due_date", *filters.DueDateFrom)
 	}
-
 	if filters.DueDateTo != nil {
-		query = query.Lte("due_date", filters.DueDateTo.Format(time.RFC3339))
+		query = query.Lte("due_date", *filters.DueDateTo)
 	}
-
-	var tasks []*domain.Task
-	err := r.client.ExecuteQuery(ctx, "select", "tasks", func() error {
-		return query.Execute(ctx, &tasks)
-	})
-
-	if err != nil {
-		span.RecordError(err)
-		return 0, fmt.Errorf("failed to count tasks: %w", err)
+	if len(filters.Tags) > 0 {
+		query = query.Contains("tags", filters.Tags)
 	}
-
-	count := len(tasks)
-	span.SetAttributes(attribute.Int("result.count", count))
-
-	return count, nil
+	if filters.Search != nil {
+		// Search in title or description
+		query = query.IlikeAny(*filters.Search, "title", "description")
+	}
+	return query
 }
 
 // GetByAssignedUser retrieves tasks assigned to a user
@@ -301,9 +296,10 @@ func (r *taskRepository) GetByAssignedUser(ctx context.Context, userID uuid.UUID
 
 	var tasks []*domain.Task
 	err := r.client.ExecuteQuery(ctx, "select", "tasks", func() error {
-		return r.client.From("tasks").
+		return r.client.scoped(ctx, "tasks").
 			Select("*, assigned_user:users!assigned_to(*), assigned_by_user:users!assigned_by(*)").
 			Eq("assigned_to", userID).
+			IsNull("deleted_at").
 			Execute(ctx, &tasks)
 	})
 
@@ -326,7 +322,7 @@ func (r *taskRepository) GetByStatus(ctx context.Context, status domain.TaskStat
 
 	var tasks []*domain.Task
 	err := r.client.ExecuteQuery(ctx, "select", "tasks", func() error {
-		return r.client.From("tasks").
+		return r.client.scoped(ctx, "tasks").
 			Select("*, assigned_user:users!assigned_to(*), assigned_by_user:users!assigned_by(*)").
 			Eq("status", string(status)).
 			Execute(ctx, &tasks)
@@ -363,10 +359,10 @@ func (r *taskRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status
 	}
 
 	err := r.client.ExecuteQuery(ctx, "update", "tasks", func() error {
-		return r.client.From("tasks").
+		return r.client.scoped(ctx, "tasks").
 			Update(updateData).
 			Eq("id", id).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 
 	if err != nil {
@@ -384,11 +380,13 @@ func (r *taskRepository) GetOverdueTasks(ctx context.Context) ([]*domain.Task, e
 
 	var tasks []*domain.Task
 	err := r.client.ExecuteQuery(ctx, "select", "tasks", func() error {
-		return r.client.From("tasks").
+		return r.client.scoped(ctx, "tasks").
 			Select("*, assigned_user:users!assigned_to(*), assigned_by_user:users!assigned_by(*)").
 			Lt("due_date", time.Now().Format(time.RFC3339)).
+			IsNull("deleted_at").
 			Neq("status", string(domain.TaskStatusCompleted)).
 			IsNotNull("due_date").
+			Order("due_date", true).
 			Execute(ctx, &tasks)
 	})
 
@@ -414,10 +412,11 @@ func (r *taskRepository) GetTasksByRelatedEntity(ctx context.Context, entityType
 
 	var tasks []*domain.Task
 	err := r.client.ExecuteQuery(ctx, "select", "tasks", func() error {
-		return r.client.From("tasks").
+		return r.client.scoped(ctx, "tasks").
 			Select("*, assigned_user:users!assigned_to(*), assigned_by_user:users!assigned_by(*)").
 			Eq("related_to_type", entityType).
 			Eq("related_to_id", entityID).
+			IsNull("deleted_at").
 			Execute(ctx, &tasks)
 	})
 
@@ -0,0 +1,93 @@
+package supabase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"goreal-backend/internal/domain"
+)
+
+// journalKey carries the journal of a running unit of work in a context
+type journalKey struct{}
+
+// journal records how to undo the writes made inside a unit of work
+type journal struct {
+	mu   sync.Mutex
+	undo []func(ctx context.Context) error
+}
+
+// journalFrom returns the journal of the unit of work running in ctx, if any
+func journalFrom(ctx context.Context) *journal {
+	j, _ := ctx.Value(journalKey{}).(*journal)
+	return j
+}
+
+// record appends an undo step
+func (j *journal) record(undo func(ctx context.Context) error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.undo = append(j.undo, undo)
+}
+
+// rollback runs the undo steps newest first. Every step is attempted even
+// if an earlier one fails.
+func (j *journal) rollback(ctx context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	// The undo writes themselves must not be journaled
+	ctx = context.WithValue(ctx, journalKey{}, (*journal)(nil))
+
+	var errs []error
+	for i := len(j.undo) - 1; i >= 0; i-- {
+		if err := j.undo[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	j.undo = nil
+	return errors.Join(errs...)
+}
+
+type unitOfWork struct {
+	client *Client
+}
+
+// NewUnitOfWork creates a unit of work for the Supabase backend.
+//
+// PostgREST runs every request in its own transaction, so a unit cannot
+// hold one open across calls. Instead each write is journaled and, if the
+// unit fails, compensated by the inverse write. Concurrent writers can
+// observe the intermediate state; operations that need strict isolation
+// belong in a Postgres function called over RPC.
+func NewUnitOfWork(client *Client) domain.UnitOfWork {
+	return &unitOfWork{
+		client: client,
+	}
+}
+
+// Do runs fn and undoes its writes if it returns an error
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Nested units join the enclosing one
+	if journalFrom(ctx) != nil {
+		return fn(ctx)
+	}
+
+	ctx, span := supabaseTracer.Start(ctx, "supabase.unit_of_work")
+	defer span.End()
+
+	j := &journal{}
+	unitCtx, committed := domain.WithCommitHooks(context.WithValue(ctx, journalKey{}, j))
+	if err := fn(unitCtx); err != nil {
+		span.RecordError(err)
+		// Compensate even if the caller's context was cancelled
+		if undoErr := j.rollback(context.WithoutCancel(ctx)); undoErr != nil {
+			return fmt.Errorf("%w (rollback incomplete: %v)", err, undoErr)
+		}
+		return err
+	}
+
+	committed()
+	return nil
+}
@@ -0,0 +1,72 @@
+package supabase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/infrastructure/supabase/supabasetest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitOfWork_CompensatesWritesOnError(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := domain.AcrossOrganizations(context.Background())
+	uow := NewUnitOfWork(client)
+
+	srv.Seed("leads",
+		supabasetest.Row{"id": "lead-1", "name": "Acme", "status": "qualified"},
+		supabasetest.Row{"id": "lead-2", "name": "Globex", "status": "new"},
+	)
+
+	boom := errors.New("boom")
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		require.NoError(t, client.From("clients").Insert(map[string]interface{}{"id": "client-1", "name": "Acme"}).Execute(ctx, nil))
+		require.NoError(t, client.From("leads").Update(map[string]interface{}{"status": "converted"}).Eq("id", "lead-1").ExecuteAffected(ctx))
+		require.NoError(t, client.From("leads").Delete().Eq("id", "lead-2").ExecuteAffected(ctx))
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+
+	assert.Empty(t, srv.Rows("clients"))
+	assert.ElementsMatch(t, []supabasetest.Row{
+		{"id": "lead-1", "name": "Acme", "status": "qualified"},
+		{"id": "lead-2", "name": "Globex", "status": "new"},
+	}, srv.Rows("leads"))
+}
+
+func TestUnitOfWork_KeepsWritesOnSuccess(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := domain.AcrossOrganizations(context.Background())
+	uow := NewUnitOfWork(client)
+
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		return client.From("clients").Insert(map[string]interface{}{"id": "client-1", "name": "Acme"}).Execute(ctx, nil)
+	})
+	require.NoError(t, err)
+	assert.Len(t, srv.Rows("clients"), 1)
+}
+
+func TestInventoryRepository_ReserveIsConditional(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := domain.AcrossOrganizations(context.Background())
+	repo := NewInventoryRepository(client)
+
+	unitID, clientID := uuid.New(), uuid.New()
+	srv.Seed("inventory", supabasetest.Row{"id": unitID.String(), "unit_number": "A-101", "status": "available"})
+
+	until := time.Now().Add(time.Hour)
+	require.NoError(t, repo.Reserve(ctx, unitID, clientID, until))
+	assert.Equal(t, "reserved", srv.Rows("inventory")[0]["status"])
+
+	err := repo.Reserve(ctx, unitID, clientID, until)
+	assert.ErrorIs(t, err, domain.ErrInventoryNotAvailable)
+
+	err = repo.Reserve(ctx, uuid.New(), clientID, until)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -2,11 +2,12 @@ package supabase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"goreal-backend/internal/domain"
 	"github.com/google/uuid"
+	"goreal-backend/internal/domain"
 )
 
 // userRepository implements domain.UserRepository using Supabase
@@ -22,20 +23,29 @@ func NewUserRepository(client *Client) domain.UserRepository {
 }
 
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	if err := domain.AssignOrganization(ctx, &user.OrganizationID); err != nil {
+		return err
+	}
 	// Convert domain user to database model
 	dbUser := &dbUser{
-		ID:            user.ID,
-		Email:         user.Email,
-		Username:      user.Username,
-		FullName:      user.FullName,
-		Role:          string(user.Role),
-		Bio:           user.Bio,
-		AvatarURL:     user.AvatarURL,
-		WalletAddress: user.WalletAddress,
-		PasswordHash:  user.PasswordHash,
-		IsActive:      user.IsActive,
-		CreatedAt:     user.CreatedAt,
-		UpdatedAt:     user.UpdatedAt,
+		ID:                  user.ID,
+		OrganizationID:      user.OrganizationID,
+		Email:               user.Email,
+		Username:            user.Username,
+		FullName:            user.FullName,
+		Role:                string(user.Role),
+		Bio:                 user.Bio,
+		AvatarURL:           user.AvatarURL,
+		WalletAddress:       user.WalletAddress,
+		PasswordHash:        user.PasswordHash,
+		IsActive:            user.IsActive,
+		EmailVerifiedAt:     user.EmailVerifiedAt,
+		MFAEnabled:          user.MFAEnabled,
+		FailedLoginAttempts: user.FailedLoginAttempts,
+		LastFailedLoginAt:   user.LastFailedLoginAt,
+		LockedUntil:         user.LockedUntil,
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
 	}
 
 	return r.client.ExecuteQuery(ctx, "insert", "profiles", func() error {
@@ -45,14 +55,14 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	var dbUser dbUser
-	
+
 	err := r.client.ExecuteQuery(ctx, "select_by_id", "profiles", func() error {
-		return r.client.From("profiles").
+		return r.client.scoped(ctx, "profiles").
 			Select("*").
 			Eq("id", id).
 			Single(ctx, &dbUser)
 	})
-	
+
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
@@ -62,14 +72,14 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var dbUser dbUser
-	
+
 	err := r.client.ExecuteQuery(ctx, "select_by_email", "profiles", func() error {
-		return r.client.From("profiles").
+		return r.client.scoped(ctx, "profiles").
 			Select("*").
 			Eq("email", email).
 			Single(ctx, &dbUser)
 	})
-	
+
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
@@ -79,14 +89,14 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
 	var dbUser dbUser
-	
+
 	err := r.client.ExecuteQuery(ctx, "select_by_username", "profiles", func() error {
-		return r.client.From("profiles").
+		return r.client.scoped(ctx, "profiles").
 			Select("*").
 			Eq("username", username).
 			Single(ctx, &dbUser)
 	})
-	
+
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
@@ -95,77 +105,60 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*d
 }
 
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	if err := domain.AssignOrganization(ctx, &user.OrganizationID); err != nil {
+		return err
+	}
 	// Convert domain user to database model
 	dbUser := &dbUser{
-		ID:            user.ID,
-		Email:         user.Email,
-		Username:      user.Username,
-		FullName:      user.FullName,
-		Role:          string(user.Role),
-		Bio:           user.Bio,
-		AvatarURL:     user.AvatarURL,
-		WalletAddress: user.WalletAddress,
-		PasswordHash:  user.PasswordHash,
-		IsActive:      user.IsActive,
-		LastLoginAt:   user.LastLoginAt,
-		UpdatedAt:     time.Now(),
+		ID:                  user.ID,
+		OrganizationID:      user.OrganizationID,
+		Email:               user.Email,
+		Username:            user.Username,
+		FullName:            user.FullName,
+		Role:                string(user.Role),
+		Bio:                 user.Bio,
+		AvatarURL:           user.AvatarURL,
+		WalletAddress:       user.WalletAddress,
+		PasswordHash:        user.PasswordHash,
+		IsActive:            user.IsActive,
+		LastLoginAt:         user.LastLoginAt,
+		EmailVerifiedAt:     user.EmailVerifiedAt,
+		MFAEnabled:          user.MFAEnabled,
+		FailedLoginAttempts: user.FailedLoginAttempts,
+		LastFailedLoginAt:   user.LastFailedLoginAt,
+		LockedUntil:         user.LockedUntil,
+		UpdatedAt:           time.Now(),
 	}
 
 	return r.client.ExecuteQuery(ctx, "update", "profiles", func() error {
-		return r.client.From("profiles").
+		return r.client.scoped(ctx, "profiles").
 			Update(dbUser).
 			Eq("id", user.ID).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 }
 
 func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.client.ExecuteQuery(ctx, "delete", "profiles", func() error {
-		return r.client.From("profiles").
+		return r.client.scoped(ctx, "profiles").
 			Delete().
 			Eq("id", id).
-			Execute(ctx, nil)
+			ExecuteAffected(ctx)
 	})
 }
 
 func (r *userRepository) List(ctx context.Context, filters domain.UserFilters) ([]*domain.User, error) {
-	query := r.client.From("profiles").Select("*")
+	query := r.client.scoped(ctx, "profiles").Select("*")
 
-	// Apply filters
-	if filters.Role != nil {
-		query = query.Eq("role", string(*filters.Role))
-	}
-	if filters.IsActive != nil {
-		query = query.Eq("is_active", *filters.IsActive)
-	}
-	if filters.Search != nil && *filters.Search != "" {
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		// Use OR condition for searching across multiple fields
-		query = query.Or(fmt.Sprintf("full_name.ilike.%s,email.ilike.%s,username.ilike.%s", 
-			searchPattern, searchPattern, searchPattern))
-	}
-
-	// Apply sorting
-	if filters.SortBy != "" {
-		ascending := filters.SortOrder != "desc"
-		query = query.Order(filters.SortBy, ascending)
-	} else {
-		query = query.Order("created_at", false) // Default sort by created_at desc
-	}
-
-	// Apply pagination
-	if filters.Limit > 0 {
-		query = query.Limit(filters.Limit)
-	}
-	if filters.Offset > 0 {
-		query = query.Offset(filters.Offset)
-	}
+	query = applyUserFilters(query, filters)
+	query = applySort(query, filters.BaseFilters, userSortable, "created_at", false)
+	query = applyPagination(query, filters.BaseFilters)
 
 	var dbUsers []dbUser
 	err := r.client.ExecuteQuery(ctx, "select_list", "profiles", func() error {
 		return query.Execute(ctx, &dbUsers)
 	})
-	
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
@@ -180,20 +173,9 @@ func (r *userRepository) List(ctx context.Context, filters domain.UserFilters) (
 }
 
 func (r *userRepository) Count(ctx context.Context, filters domain.UserFilters) (int, error) {
-	query := r.client.From("profiles").Select("id")
+	query := r.client.scoped(ctx, "profiles").Select("id")
 
-	// Apply filters (same as List method)
-	if filters.Role != nil {
-		query = query.Eq("role", string(*filters.Role))
-	}
-	if filters.IsActive != nil {
-		query = query.Eq("is_active", *filters.IsActive)
-	}
-	if filters.Search != nil && *filters.Search != "" {
-		searchPattern := fmt.Sprintf("%%%s%%", *filters.Search)
-		query = query.Or(fmt.Sprintf("full_name.ilike.%s,email.ilike.%s,username.ilike.%s", 
-			searchPattern, searchPattern, searchPattern))
-	}
+	query = applyUserFilters(query, filters)
 
 	var result []map[string]interface{}
 	count, err := query.ExecuteWithCount(ctx, &result)
@@ -204,39 +186,137 @@ func (r *userRepository) Count(ctx context.Context, filters domain.UserFilters)
 	return count, nil
 }
 
+// applyUserFilters adds the UserFilters conditions shared by List and Count
+// failedLoginRetries bounds how often RecordFailedLogin rereads a count
+// that a concurrent failure changed
+const failedLoginRetries = 5
+
+// RecordFailedLogin counts a failed login against a user. PostgREST cannot
+// raise a column in place, so the count is written only if it is still the
+// one read, and read again when a concurrent failure changed it first.
+func (r *userRepository) RecordFailedLogin(ctx context.Context, id uuid.UUID, at, since time.Time) (int, error) {
+	for i := 0; i < failedLoginRetries; i++ {
+		user, err := r.GetByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+
+		attempts := 1
+		if user.LastFailedLoginAt != nil && !user.LastFailedLoginAt.Before(since) {
+			attempts = user.FailedLoginAttempts + 1
+		}
+		query := r.client.scoped(ctx, "profiles").
+			Update(map[string]interface{}{
+				"failed_login_attempts": attempts,
+				"last_failed_login_at":  at,
+				"updated_at":            time.Now(),
+			}).
+			Eq("id", id).
+			Eq("failed_login_attempts", user.FailedLoginAttempts)
+		if user.LastFailedLoginAt == nil {
+			query = query.IsNull("last_failed_login_at")
+		} else {
+			query = query.Eq("last_failed_login_at", user.LastFailedLoginAt.UTC())
+		}
+
+		err = r.client.ExecuteQuery(ctx, "update", "profiles", func() error {
+			return query.ExecuteAffected(ctx)
+		})
+		if err == nil {
+			return attempts, nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return 0, fmt.Errorf("failed to record failed login: %w", err)
+		}
+	}
+	return 0, fmt.Errorf("failed to record failed login: %w", domain.ErrConflict)
+}
+
+// LockUntil locks a user until a time, unless already locked for longer
+func (r *userRepository) LockUntil(ctx context.Context, id uuid.UUID, until time.Time) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	err := r.client.ExecuteQuery(ctx, "update", "profiles", func() error {
+		return r.client.scoped(ctx, "profiles").
+			Update(map[string]interface{}{
+				"locked_until": until,
+				"updated_at":   time.Now(),
+			}).
+			Eq("id", id).
+			Or("locked_until.is.null,locked_until.lt." + quoteValue(formatValue(until.UTC()))).
+			ExecuteAffected(ctx)
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		// Already locked for longer
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+	return nil
+}
+
+func applyUserFilters(query *QueryBuilder, filters domain.UserFilters) *QueryBuilder {
+	if filters.Role != nil {
+		query = query.Eq("role", string(*filters.Role))
+	}
+	if filters.IsActive != nil {
+		query = query.Eq("is_active", *filters.IsActive)
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		// Use OR condition for searching across multiple fields
+		query = query.IlikeAny(*filters.Search, "full_name", "email", "username")
+	}
+	return query
+}
+
 // dbUser represents the database model for users
 type dbUser struct {
-	ID            uuid.UUID  `json:"id" db:"id"`
-	Email         string     `json:"email" db:"email"`
-	Username      string     `json:"username" db:"username"`
-	FullName      string     `json:"full_name" db:"full_name"`
-	Role          string     `json:"role" db:"role"`
-	Bio           *string    `json:"bio" db:"bio"`
-	AvatarURL     *string    `json:"avatar_url" db:"avatar_url"`
-	WalletAddress *string    `json:"wallet_address" db:"wallet_address"`
-	PasswordHash  string     `json:"password_hash" db:"password_hash"`
-	IsActive      bool       `json:"is_active" db:"is_active"`
-	LastLoginAt   *time.Time `json:"last_login_at" db:"last_login_at"`
-	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	ID                  uuid.UUID  `json:"id" db:"id"`
+	OrganizationID      *uuid.UUID `json:"organization_id" db:"organization_id"`
+	Email               string     `json:"email" db:"email"`
+	Username            string     `json:"username" db:"username"`
+	FullName            string     `json:"full_name" db:"full_name"`
+	Role                string     `json:"role" db:"role"`
+	Bio                 *string    `json:"bio" db:"bio"`
+	AvatarURL           *string    `json:"avatar_url" db:"avatar_url"`
+	WalletAddress       *string    `json:"wallet_address" db:"wallet_address"`
+	PasswordHash        string     `json:"password_hash" db:"password_hash"`
+	IsActive            bool       `json:"is_active" db:"is_active"`
+	LastLoginAt         *time.Time `json:"last_login_at" db:"last_login_at"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at" db:"email_verified_at"`
+	MFAEnabled          bool       `json:"mfa_enabled" db:"mfa_enabled"`
+	FailedLoginAttempts int        `json:"failed_login_attempts" db:"failed_login_attempts"`
+	LastFailedLoginAt   *time.Time `json:"last_failed_login_at" db:"last_failed_login_at"`
+	LockedUntil         *time.Time `json:"locked_until" db:"locked_until"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // dbUserToDomain converts database user model to domain model
 func (r *userRepository) dbUserToDomain(dbUser *dbUser) *domain.User {
 	return &domain.User{
-		ID:            dbUser.ID,
-		Email:         dbUser.Email,
-		Username:      dbUser.Username,
-		FullName:      dbUser.FullName,
-		Role:          domain.UserRole(dbUser.Role),
-		Bio:           dbUser.Bio,
-		AvatarURL:     dbUser.AvatarURL,
-		WalletAddress: dbUser.WalletAddress,
-		PasswordHash:  dbUser.PasswordHash,
-		IsActive:      dbUser.IsActive,
-		LastLoginAt:   dbUser.LastLoginAt,
-		CreatedAt:     dbUser.CreatedAt,
-		UpdatedAt:     dbUser.UpdatedAt,
+		ID:                  dbUser.ID,
+		OrganizationID:      dbUser.OrganizationID,
+		Email:               dbUser.Email,
+		Username:            dbUser.Username,
+		FullName:            dbUser.FullName,
+		Role:                domain.UserRole(dbUser.Role),
+		Bio:                 dbUser.Bio,
+		AvatarURL:           dbUser.AvatarURL,
+		WalletAddress:       dbUser.WalletAddress,
+		PasswordHash:        dbUser.PasswordHash,
+		IsActive:            dbUser.IsActive,
+		LastLoginAt:         dbUser.LastLoginAt,
+		EmailVerifiedAt:     dbUser.EmailVerifiedAt,
+		MFAEnabled:          dbUser.MFAEnabled,
+		FailedLoginAttempts: dbUser.FailedLoginAttempts,
+		LastFailedLoginAt:   dbUser.LastFailedLoginAt,
+		LockedUntil:         dbUser.LockedUntil,
+		CreatedAt:           dbUser.CreatedAt,
+		UpdatedAt:           dbUser.UpdatedAt,
 	}
 }
 
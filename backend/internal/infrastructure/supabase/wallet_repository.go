@@ -0,0 +1,150 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var walletTracer = otel.Tracer("goreal-backend/infrastructure/supabase/wallet")
+
+// walletRepository implements domain.WalletRepository using Supabase
+type walletRepository struct {
+	client *Client
+}
+
+// NewWalletRepository creates a new wallet repository
+func NewWalletRepository(client *Client) domain.WalletRepository {
+	return &walletRepository{
+		client: client,
+	}
+}
+
+// Create stores a new wallet; addresses must be unique
+func (r *walletRepository) Create(ctx context.Context, wallet *domain.Wallet) error {
+	ctx, span := walletTracer.Start(ctx, "walletRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", wallet.UserID.String()),
+		attribute.String("wallet.address", wallet.Address),
+	)
+
+	if wallet.CreatedAt.IsZero() {
+		wallet.CreatedAt = time.Now()
+	}
+	wallet.UpdatedAt = wallet.CreatedAt
+
+	err := r.client.ExecuteQuery(ctx, "insert", "wallets", func() error {
+		return r.client.From("wallets").Insert(wallet).Execute(ctx, nil)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create wallet: %w", err)
+	}
+
+	return nil
+}
+
+// GetByAddress retrieves the wallet with an address
+func (r *walletRepository) GetByAddress(ctx context.Context, address string) (*domain.Wallet, error) {
+	ctx, span := walletTracer.Start(ctx, "walletRepository.GetByAddress")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("wallet.address", address))
+
+	var wallet domain.Wallet
+	err := r.client.ExecuteQuery(ctx, "select", "wallets", func() error {
+		return r.client.From("wallets").
+			Select("*").
+			Eq("address", address).
+			Single(ctx, &wallet)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get wallet by address: %w", err)
+	}
+
+	return &wallet, nil
+}
+
+// ListByUser returns the wallets of a user, oldest first
+func (r *walletRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Wallet, error) {
+	ctx, span := walletTracer.Start(ctx, "walletRepository.ListByUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	wallets := []*domain.Wallet{}
+	err := r.client.ExecuteQuery(ctx, "select", "wallets", func() error {
+		return r.client.From("wallets").
+			Select("*").
+			Eq("user_id", userID).
+			Order("created_at", true).
+			Order("id", true).
+			Execute(ctx, &wallets)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list wallets: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(wallets)))
+
+	return wallets, nil
+}
+
+// Update updates an existing wallet
+func (r *walletRepository) Update(ctx context.Context, wallet *domain.Wallet) error {
+	ctx, span := walletTracer.Start(ctx, "walletRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("wallet.id", wallet.ID.String()))
+
+	wallet.UpdatedAt = time.Now()
+
+	err := r.client.ExecuteQuery(ctx, "update", "wallets", func() error {
+		return r.client.From("wallets").
+			Update(wallet).
+			Eq("id", wallet.ID).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to update wallet: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a wallet
+func (r *walletRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := walletTracer.Start(ctx, "walletRepository.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("wallet.id", id.String()))
+
+	err := r.client.ExecuteQuery(ctx, "delete", "wallets", func() error {
+		return r.client.From("wallets").
+			Delete().
+			Eq("id", id).
+			ExecuteAffected(ctx)
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete wallet: %w", err)
+	}
+
+	return nil
+}
@@ -12,10 +12,15 @@ import (
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var authTracer = otel.Tracer("goreal-backend/middleware/auth")
 
+// mfaPolicyKey carries the MFA policy of the auth service that
+// authenticated a request, for RequireMinRole
+type mfaPolicyKey struct{}
+
 // AuthRequired middleware that requires valid JWT token
 func AuthRequired(authService domain.AuthService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -55,9 +60,18 @@ func AuthRequired(authService domain.AuthService) func(http.Handler) http.Handle
 				return
 			}
 
+			// Users outside any organization have no records to work on
+			if user.OrganizationID == nil {
+				span.SetAttributes(attribute.String("error", "no_organization"))
+				http.Error(w, "Account does not belong to an organization", http.StatusForbidden)
+				return
+			}
+
 			// Add user to context
 			ctx = context.WithValue(ctx, "user", user)
 			ctx = context.WithValue(ctx, "user_id", user.ID.String())
+			ctx = withOrganization(ctx, user)
+			ctx = context.WithValue(ctx, mfaPolicyKey{}, authService.MFAPolicy())
 
 			span.SetAttributes(
 				attribute.String("user.id", user.ID.String()),
@@ -71,7 +85,8 @@ func AuthRequired(authService domain.AuthService) func(http.Handler) http.Handle
 	}
 }
 
-// RequireRole middleware that requires specific user role
+// RequireRole middleware that requires specific user role. When the MFA
+// policy covers the user's role, the user must also have MFA enabled.
 func RequireRole(roles ...domain.UserRole) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -105,6 +120,16 @@ func RequireRole(roles ...domain.UserRole) func(http.Handler) http.Handler {
 				return
 			}
 
+			if mfaMissing(ctx, user) {
+				span.SetAttributes(
+					attribute.String("user.role", string(user.Role)),
+					attribute.StringSlice("required.roles", roleSliceToStringSlice(roles)),
+					attribute.String("error", "mfa_required"),
+				)
+				http.Error(w, "Multi-factor authentication required", http.StatusForbidden)
+				return
+			}
+
 			span.SetAttributes(
 				attribute.String("user.id", user.ID.String()),
 				attribute.String("user.role", string(user.Role)),
@@ -117,7 +142,8 @@ func RequireRole(roles ...domain.UserRole) func(http.Handler) http.Handler {
 	}
 }
 
-// RequireMinRole middleware that requires minimum user role level
+// RequireMinRole middleware that requires minimum user role level. When the
+// MFA policy covers the user's role, the user must also have MFA enabled.
 func RequireMinRole(minRole domain.UserRole) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -143,6 +169,18 @@ func RequireMinRole(minRole domain.UserRole) func(http.Handler) http.Handler {
 				return
 			}
 
+			// Users the MFA policy covers are refused until they have set up
+			// an authenticator, even on endpoints open to lesser roles
+			if mfaMissing(ctx, user) {
+				span.SetAttributes(
+					attribute.String("user.role", string(user.Role)),
+					attribute.String("required.min_role", string(minRole)),
+					attribute.String("error", "mfa_required"),
+				)
+				http.Error(w, "Multi-factor authentication required", http.StatusForbidden)
+				return
+			}
+
 			span.SetAttributes(
 				attribute.String("user.id", user.ID.String()),
 				attribute.String("user.role", string(user.Role)),
@@ -185,26 +223,16 @@ func roleSliceToStringSlice(roles []domain.UserRole) []string {
 	return result
 }
 
+// mfaMissing reports whether the MFA policy covers the user's role and the
+// user has not set up an authenticator
+func mfaMissing(ctx context.Context, user *domain.User) bool {
+	policy, _ := ctx.Value(mfaPolicyKey{}).(domain.MFAPolicy)
+	return !user.MFAEnabled && policy.Covers(user.Role)
+}
+
 // hasMinimumRole checks if user role meets minimum requirement
 func hasMinimumRole(userRole, minRole domain.UserRole) bool {
-	roleHierarchy := map[domain.UserRole]int{
-		domain.RoleUser:       1,
-		domain.RoleCreator:    2,
-		domain.RoleClient:     2,
-		domain.RoleEmployee:   3,
-		domain.RoleManager:    4,
-		domain.RoleAdmin:      5,
-		domain.RoleSuperAdmin: 6,
-	}
-
-	userLevel, userExists := roleHierarchy[userRole]
-	minLevel, minExists := roleHierarchy[minRole]
-
-	if !userExists || !minExists {
-		return false
-	}
-
-	return userLevel >= minLevel
+	return userRole.AtLeast(minRole)
 }
 
 // OptionalAuth middleware that extracts user if token is present but doesn't require it
@@ -221,10 +249,12 @@ func OptionalAuth(authService domain.AuthService) func(http.Handler) http.Handle
 				if len(parts) == 2 && parts[0] == "Bearer" && parts[1] != "" {
 					// Try to validate token
 					user, err := authService.ValidateToken(ctx, parts[1])
-					if err == nil {
-						// Add user to context if token is valid
+					if err == nil && user.OrganizationID != nil {
+						// Add user to context if token is valid and the user belongs to an organization
 						ctx = context.WithValue(ctx, "user", user)
 						ctx = context.WithValue(ctx, "user_id", user.ID.String())
+						ctx = withOrganization(ctx, user)
+						ctx = context.WithValue(ctx, mfaPolicyKey{}, authService.MFAPolicy())
 
 						span.SetAttributes(
 							attribute.String("user.id", user.ID.String()),
@@ -246,11 +276,24 @@ func OptionalAuth(authService domain.AuthService) func(http.Handler) http.Handle
 	}
 }
 
+// withOrganization scopes the request to the user's organization so every
+// repository call made while serving it only sees that tenant's records.
+// Callers turn away users without an organization first.
+func withOrganization(ctx context.Context, user *domain.User) context.Context {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("organization.id", user.OrganizationID.String()))
+	return domain.WithOrganization(ctx, *user.OrganizationID)
+}
+
 // AdminOnly is a convenience middleware for admin-only endpoints
 func AdminOnly() func(http.Handler) http.Handler {
 	return RequireRole(domain.RoleAdmin, domain.RoleSuperAdmin)
 }
 
+// SuperAdminOnly is a convenience middleware for deployment-wide endpoints
+func SuperAdminOnly() func(http.Handler) http.Handler {
+	return RequireRole(domain.RoleSuperAdmin)
+}
+
 // ManagerOrAbove is a convenience middleware for manager+ endpoints
 func ManagerOrAbove() func(http.Handler) http.Handler {
 	return RequireMinRole(domain.RoleManager)
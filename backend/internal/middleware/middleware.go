@@ -1,8 +1,12 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"net/netip"
+	"strings"
 	"sync"
 	"time"
 
@@ -71,6 +75,12 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap exposes the wrapped writer to http.ResponseController so handlers
+// can flush streamed responses
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // RateLimiter creates a rate limiting middleware
 func RateLimiter(cfg config.RateLimitConfig) func(http.Handler) http.Handler {
 	// Create a map to store rate limiters for each IP
@@ -92,7 +102,7 @@ func RateLimiter(cfg config.RateLimitConfig) func(http.Handler) http.Handler {
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ip := getClientIP(r)
+			ip := ClientIP(r)
 			
 			mu.RLock()
 			limiter, exists := limiters[ip]
@@ -118,20 +128,78 @@ func RateLimiter(cfg config.RateLimitConfig) func(http.Handler) http.Handler {
 	}
 }
 
-// getClientIP extracts the client IP address from the request
-func getClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		return xff
+type clientIPKey struct{}
+
+// RealIP resolves the address of the client behind each request, for
+// ClientIP. Forwarding headers are only believed on requests from one of
+// the trusted proxies, and then only back to the right-most hop that is not
+// itself a trusted proxy: anything further left was written by the client.
+func RealIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), clientIPKey{}, resolveClientIP(r, trusted))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
 	}
-	
-	// Check X-Real-IP header
-	if xri := r.Header.Get("X-Real-IP"); xri != "" {
-		return xri
+}
+
+// ClientIP returns the address of the client that sent the request, as
+// resolved by RealIP, or else the connection's peer address
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
 	}
-	
-	// Fall back to RemoteAddr
-	return r.RemoteAddr
+	return remoteHost(r)
+}
+
+// resolveClientIP walks the forwarded hops from the right, where each
+// trusted proxy appended the address it was connected from
+func resolveClientIP(r *http.Request, trusted []netip.Prefix) string {
+	peer := remoteHost(r)
+	client, err := netip.ParseAddr(peer)
+	if err != nil || !isTrusted(client, trusted) {
+		return peer
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	if len(hops) == 0 {
+		hops = r.Header.Values("X-Real-IP")
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		client = hop.Unmap()
+		if !isTrusted(client, trusted) {
+			break
+		}
+	}
+	return client.String()
+}
+
+func isTrusted(addr netip.Addr, trusted []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteHost is the connection's peer address without its port, so that
+// every connection from one client counts as the same address
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
 }
 
 // CORS middleware handles Cross-Origin Resource Sharing
@@ -220,7 +288,7 @@ func RequestLogger(next http.Handler) http.Handler {
 			attribute.String("http.method", r.Method),
 			attribute.String("http.url", r.URL.String()),
 			attribute.String("http.user_agent", r.UserAgent()),
-			attribute.String("http.remote_addr", getClientIP(r)),
+			attribute.String("http.remote_addr", ClientIP(r)),
 			attribute.Int("http.status_code", wrapped.statusCode),
 			attribute.Int64("http.duration_ms", duration.Milliseconds()),
 		)
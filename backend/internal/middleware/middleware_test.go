@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRealIP(t *testing.T) {
+	proxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	cases := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{"direct client", "203.0.113.7:51234", nil, "203.0.113.7"},
+		{"headers from an untrusted peer", "203.0.113.7:51234",
+			map[string]string{"X-Forwarded-For": "198.51.100.9", "X-Real-IP": "198.51.100.9"}, "203.0.113.7"},
+		{"one proxy", "10.0.0.2:443", map[string]string{"X-Forwarded-For": "198.51.100.9"}, "198.51.100.9"},
+		{"spoofed hop left of the proxy's", "10.0.0.2:443",
+			map[string]string{"X-Forwarded-For": "192.0.2.1, 198.51.100.9"}, "198.51.100.9"},
+		{"chained proxies", "10.0.0.2:443",
+			map[string]string{"X-Forwarded-For": "198.51.100.9, 10.0.0.5"}, "198.51.100.9"},
+		{"real IP header", "10.0.0.2:443", map[string]string{"X-Real-IP": "198.51.100.9"}, "198.51.100.9"},
+		{"unreadable hop", "10.0.0.2:443", map[string]string{"X-Forwarded-For": "unknown"}, "10.0.0.2"},
+		{"IPv6 peer", "[2001:db8::1]:51234", nil, "2001:db8::1"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for name, value := range tc.headers {
+				req.Header.Set(name, value)
+			}
+
+			var got string
+			RealIP(proxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = ClientIP(r)
+			})).ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestClientIP_WithoutRealIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	req.Header.Set("X-Forwarded-For", "198.51.100.9")
+
+	assert.Equal(t, "203.0.113.7", ClientIP(req), "the port and forwarding headers are ignored")
+}
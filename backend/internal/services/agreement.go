@@ -0,0 +1,194 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/pkg/document"
+)
+
+// agreementData is what agreement templates are executed against
+type agreementData struct {
+	Sale     *domain.Sale
+	Client   *domain.Client
+	Unit     *domain.Inventory
+	Project  *domain.Project
+	Payments []*domain.PaymentSchedule
+	// Date is the agreement date: the sale's, or today's if it has none
+	Date time.Time
+}
+
+// defaultAgreementTemplate is used when neither the project nor the
+// organization has saved a template
+var defaultAgreementTemplate = &domain.AgreementTemplate{
+	Name: "Standard sale agreement",
+	Body: `# Agreement for Sale
+
+This agreement for sale is made on {{date .Date}} between the developer of {{.Project.Name}} (the "Developer") and {{.Client.Name}}{{with .Client.Address}}, residing at {{address .}}{{end}} (the "Buyer"), for the unit described below under sale {{.Sale.SaleNumber}}.
+
+# Property
+
+| Item | Details |
+| Project | {{.Project.Name}} |
+| RERA registration | {{text .Project.RERANumber}} |
+| Unit | {{.Unit.UnitNumber}} |
+| Tower / block | {{text .Unit.TowerBlock}} |
+| Floor | {{number .Unit.FloorNumber}} |
+| Unit type | {{text .Unit.UnitType}} |
+| Carpet area (sq ft) | {{number .Unit.CarpetArea}} |
+| Parking slots | {{.Unit.ParkingSlots}} |
+
+# Consideration
+
+| Item | Amount (INR) |
+| Total price | {{money .Sale.TotalAmount}} |
+| Discount | {{money .Sale.DiscountAmount}} |
+| Final price | {{money .Sale.FinalAmount}} |
+| Booking amount | {{money .Sale.BookingAmount}} |
+{{if .Payments}}
+# Payment Schedule
+
+| No. | Due date | Amount (INR) | Description |
+{{range .Payments}}| {{.InstallmentNumber}} | {{date .DueDate}} | {{money .Amount}} | {{text .Description}} |
+{{end}}{{end}}
+# Terms
+
+The Buyer agrees to pay the final price of INR {{money .Sale.FinalAmount}}{{if .Payments}} according to the payment schedule above{{end}}. Possession of the unit will be handed over {{with .Sale.PossessionDate}}on or before {{date .}}{{else}}on completion of the project{{end}}, subject to payment in full.
+
+The unit is sold free of encumbrances. The Buyer shall bear the stamp duty and registration charges for this agreement and the conveyance deed.
+
+# Signatures
+
+| For the Developer | Buyer |
+| | {{.Client.Name}} |
+`,
+}
+
+var agreementFuncs = template.FuncMap{
+	"money":   formatMoney,
+	"date":    formatDate,
+	"text":    formatText,
+	"number":  formatNumber,
+	"address": formatAddress,
+}
+
+// renderAgreement executes a template and lays the result out as a document
+func renderAgreement(tmpl *domain.AgreementTemplate, data *agreementData) (*document.Document, error) {
+	parsed, err := template.New(tmpl.Name).Funcs(agreementFuncs).Option("missingkey=error").Parse(tmpl.Body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid agreement template: %s: %w", err, domain.ErrInvalidInput)
+	}
+
+	var markup bytes.Buffer
+	if err := parsed.Execute(&markup, data); err != nil {
+		return nil, fmt.Errorf("failed to render agreement template: %s: %w", err, domain.ErrInvalidInput)
+	}
+
+	return document.Parse("Sale Agreement "+data.Sale.SaleNumber, markup.String()), nil
+}
+
+// sampleAgreementData exercises a template before it is saved
+func sampleAgreementData() *agreementData {
+	description := "Booking amount"
+	return &agreementData{
+		Sale:    &domain.Sale{SaleNumber: "SALE-SAMPLE", TotalAmount: 5000000, FinalAmount: 5000000},
+		Client:  &domain.Client{Name: "Sample Buyer", Address: &domain.Address{City: "Mumbai"}},
+		Unit:    &domain.Inventory{UnitNumber: "A-101"},
+		Project: &domain.Project{Name: "Sample Project"},
+		Payments: []*domain.PaymentSchedule{
+			{InstallmentNumber: 1, DueDate: time.Now(), Amount: 500000, Description: &description},
+		},
+		Date: time.Now(),
+	}
+}
+
+// formatMoney renders an amount with thousands separators and two decimals
+func formatMoney(v interface{}) string {
+	var amount float64
+	switch v := v.(type) {
+	case float64:
+		amount = v
+	case *float64:
+		if v == nil {
+			return "-"
+		}
+		amount = *v
+	default:
+		return fmt.Sprint(v)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	cents := int64(math.Round(amount * 100))
+	whole := strconv.FormatInt(cents/100, 10)
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	return fmt.Sprintf("%s%s.%02d", sign, whole, cents%100)
+}
+
+func formatDate(v interface{}) string {
+	switch v := v.(type) {
+	case time.Time:
+		return v.Format("02 January 2006")
+	case *time.Time:
+		if v == nil {
+			return "-"
+		}
+		return v.Format("02 January 2006")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatText(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return "-"
+		}
+		return v
+	case *string:
+		if v == nil || *v == "" {
+			return "-"
+		}
+		return *v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatNumber(v interface{}) string {
+	switch v := v.(type) {
+	case *int:
+		if v == nil {
+			return "-"
+		}
+		return strconv.Itoa(*v)
+	case *float64:
+		if v == nil {
+			return "-"
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatAddress(address *domain.Address) string {
+	var parts []string
+	for _, part := range []string{address.Street, address.City, address.State, address.PostalCode, address.Country} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
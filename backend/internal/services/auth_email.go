@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ResetPassword mails a password reset link to the user with an email
+// address. Unknown and inactive addresses are ignored without an error so
+// that the response does not reveal which addresses have accounts.
+func (s *authService) ResetPassword(ctx context.Context, email string) error {
+	ctx, span := authTracer.Start(signInContext(ctx), "authService.ResetPassword")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.email", email))
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil || !user.IsActive {
+		return nil
+	}
+
+	span.SetAttributes(attribute.String("user.id", user.ID.String()))
+
+	// Only the latest link works
+	token, err := s.issueAuthToken(ctx, user.ID, domain.TokenPurposePasswordReset, s.config.Auth.PasswordResetExpiry)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	err = s.send(ctx, &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Someone asked to reset the password of your account. To choose a new password, open this link:\n\n"+
+			"%s\n\n"+
+			"The link can be used once and expires in %s. If you did not ask for it, you can ignore this email.\n",
+			greetingName(user), s.appLink("/reset-password", token), describeDuration(s.config.Auth.PasswordResetExpiry)),
+	})
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmPasswordReset sets a new password with a mailed reset token. The
+// user is signed out everywhere and their account unlocked, and since the
+// token proves they can read their mail, their email address counts as
+// verified.
+func (s *authService) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	ctx, span := authTracer.Start(signInContext(ctx), "authService.ConfirmPasswordReset")
+	defer span.End()
+
+	if err := validatePassword(newPassword); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	now := time.Now()
+	redeemed, err := s.redeemAuthToken(ctx, domain.TokenPurposePasswordReset, token, now)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.String("user.id", redeemed.UserID.String()))
+
+	user, err := s.userRepo.GetByID(ctx, redeemed.UserID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("user not found: %w", err)
+	}
+	if !user.IsActive {
+		err := domain.ErrAccountInactive
+		span.RecordError(err)
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to hash new password: %w", err)
+	}
+
+	user.PasswordHash = string(hashedPassword)
+	clearFailedLogins(user)
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &now
+	}
+	user.UpdatedAt = now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	// Any other reset link still in the mailbox stops working, and so does
+	// every session opened with the old password
+	if _, err := s.tokenRepo.InvalidateByUser(ctx, user.ID, domain.TokenPurposePasswordReset, now); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to invalidate reset tokens: %w", err)
+	}
+	if _, err := s.sessionRepo.RevokeByUser(ctx, user.ID, domain.RevokeReasonPasswordChanged, now); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to end sessions: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail marks the email address of the user a mailed verification
+// token was issued to as verified
+func (s *authService) VerifyEmail(ctx context.Context, token string) error {
+	ctx, span := authTracer.Start(signInContext(ctx), "authService.VerifyEmail")
+	defer span.End()
+
+	now := time.Now()
+	redeemed, err := s.redeemAuthToken(ctx, domain.TokenPurposeEmailVerification, token, now)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.String("user.id", redeemed.UserID.String()))
+
+	user, err := s.userRepo.GetByID(ctx, redeemed.UserID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("user not found: %w", err)
+	}
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	return nil
+}
+
+// ResendVerification mails a new verification link to a user who has not
+// verified their address yet. Like ResetPassword, it does not reveal
+// whether the address has an account.
+func (s *authService) ResendVerification(ctx context.Context, email string) error {
+	ctx, span := authTracer.Start(signInContext(ctx), "authService.ResendVerification")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.email", email))
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil || !user.IsActive || user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	span.SetAttributes(attribute.String("user.id", user.ID.String()))
+
+	if err := s.sendVerification(ctx, user); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// sendVerification mails a user a link to verify their email address
+func (s *authService) sendVerification(ctx context.Context, user *domain.User) error {
+	token, err := s.issueAuthToken(ctx, user.ID, domain.TokenPurposeEmailVerification, s.config.Auth.EmailVerificationExpiry)
+	if err != nil {
+		return err
+	}
+
+	err = s.send(ctx, &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Please confirm that %s is your email address by opening this link:\n\n"+
+			"%s\n\n"+
+			"The link expires in %s. If you did not create an account, you can ignore this email.\n",
+			greetingName(user), user.Email, s.appLink("/verify-email", token),
+			describeDuration(s.config.Auth.EmailVerificationExpiry)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	return nil
+}
+
+// issueAuthToken creates a single-use token for a user, replacing the
+// user's outstanding tokens of the same purpose. It returns the secret to
+// mail; only its hash is stored.
+func (s *authService) issueAuthToken(ctx context.Context, userID uuid.UUID, purpose domain.TokenPurpose, ttl time.Duration) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	now := time.Now()
+	if _, err := s.tokenRepo.InvalidateByUser(ctx, userID, purpose, now); err != nil {
+		return "", fmt.Errorf("failed to invalidate previous tokens: %w", err)
+	}
+	err := s.tokenRepo.Create(ctx, &domain.AuthToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store token: %w", err)
+	}
+	return token, nil
+}
+
+// redeemAuthToken uses up a mailed token. Unknown, used and expired tokens
+// are all reported as domain.ErrInvalidToken.
+func (s *authService) redeemAuthToken(ctx context.Context, purpose domain.TokenPurpose, token string, at time.Time) (*domain.AuthToken, error) {
+	if token == "" {
+		return nil, fmt.Errorf("token is required: %w", domain.ErrInvalidToken)
+	}
+	redeemed, err := s.tokenRepo.Consume(ctx, purpose, hashToken(token), at)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("token is unknown, used or expired: %w", domain.ErrInvalidToken)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem token: %w", err)
+	}
+	return redeemed, nil
+}
+
+// send mails a message with the configured mailer, if there is one
+func (s *authService) send(ctx context.Context, msg *domain.EmailMessage) error {
+	if s.mailer == nil {
+		log.Printf("No mailer configured, not sending %q to %s", msg.Subject, msg.To)
+		return nil
+	}
+	return s.mailer.Send(ctx, msg)
+}
+
+// appLink returns the web app address of a page that takes a token
+func (s *authService) appLink(path, token string) string {
+	return strings.TrimRight(s.config.AppURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+// greetingName is how a user is addressed in mail
+func greetingName(user *domain.User) string {
+	if name := strings.TrimSpace(user.FullName); name != "" {
+		return name
+	}
+	return user.Username
+}
+
+// describeDuration writes out a token lifetime for mail, in hours when it
+// is a whole number of them
+func describeDuration(d time.Duration) string {
+	plural := func(n int64, unit string) string {
+		if n == 1 {
+			return "1 " + unit
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+	if d >= time.Hour && d%time.Hour == 0 {
+		return plural(int64(d/time.Hour), "hour")
+	}
+	return plural(int64(d/time.Minute), "minute")
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Login methods, as recorded in metrics
+const (
+	loginMethodPassword = "password"
+	loginMethodMFA      = "mfa"
+	loginMethodSIWE     = "siwe"
+)
+
+const (
+	defaultAuthEventLimit = 50
+	maxAuthEventLimit     = 200
+)
+
+// UnlockAccount lifts the lock on an account and forgets its failed logins
+func (s *authService) UnlockAccount(ctx context.Context, userID, unlockedBy uuid.UUID) error {
+	ctx, span := authTracer.Start(ctx, "authService.UnlockAccount")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("unlocked_by", unlockedBy.String()),
+	)
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	now := time.Now()
+	clearFailedLogins(user)
+	user.UpdatedAt = now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+
+	event := newAuthEvent(ctx, domain.AuthEventAccountUnlocked, user.Email, now)
+	event.UserID = &user.ID
+	event.ActorID = &unlockedBy
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to record unlock: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuthEvents returns the latest entries of a user's login audit trail,
+// newest first
+func (s *authService) ListAuthEvents(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.AuthEvent, error) {
+	ctx, span := authTracer.Start(ctx, "authService.ListAuthEvents")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	if limit <= 0 {
+		limit = defaultAuthEventLimit
+	}
+	if limit > maxAuthEventLimit {
+		limit = maxAuthEventLimit
+	}
+
+	events, err := s.eventRepo.ListByUser(ctx, userID, limit)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list auth events: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(events)))
+
+	return events, nil
+}
+
+// checkAddressLockout refuses logins from an IP address with too many
+// recent failures. The lock lasts from the latest failure for as long as
+// the excess failures make it.
+func (s *authService) checkAddressLockout(ctx context.Context, method string, now time.Time) error {
+	threshold := s.config.Auth.IPLockoutThreshold
+	ip := domain.ClientInfoFromContext(ctx).IPAddress
+	if threshold <= 0 || ip == "" {
+		return nil
+	}
+
+	failures, latest, err := s.eventRepo.FailuresFromAddress(ctx, ip, now.Add(-s.config.Auth.FailureWindow))
+	if err != nil {
+		return fmt.Errorf("failed to count failed logins: %w", err)
+	}
+	if failures < threshold || latest == nil {
+		return nil
+	}
+
+	until := latest.Add(s.lockoutDuration(failures - threshold))
+	if !now.Before(until) {
+		return nil
+	}
+
+	s.recordLoginAttempt(ctx, false, method)
+	s.recordAuthFailure(ctx, "address_locked")
+	return &domain.LockoutError{Until: until}
+}
+
+// checkAccountLockout refuses logins to a locked account
+func (s *authService) checkAccountLockout(ctx context.Context, user *domain.User, method string, now time.Time) error {
+	if user.LockedUntil == nil || !now.Before(*user.LockedUntil) {
+		return nil
+	}
+
+	s.recordLoginAttempt(ctx, false, method)
+	s.recordAuthFailure(ctx, "account_locked")
+	return &domain.LockoutError{Until: *user.LockedUntil}
+}
+
+// loginFailed records a failed login in the audit trail and counts it
+// against the account, if there is one. An account is locked once it has
+// LockoutThreshold failures in a row, each no further apart than
+// FailureWindow; every further failure locks it again for twice as long.
+// The repository raises the count in one step, so failures racing each
+// other are all counted. Errors are recorded on the span rather than returned, so that the caller
+// answers with the failure it was handling.
+func (s *authService) loginFailed(ctx context.Context, user *domain.User, email, method string, now time.Time) {
+	span := trace.SpanFromContext(ctx)
+	s.recordLoginAttempt(ctx, false, method)
+
+	event := newAuthEvent(ctx, domain.AuthEventLoginFailed, email, now)
+	if user != nil {
+		event.UserID = &user.ID
+	}
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		span.RecordError(fmt.Errorf("failed to record failed login: %w", err))
+	}
+
+	threshold := s.config.Auth.LockoutThreshold
+	if user == nil || threshold <= 0 {
+		return
+	}
+
+	attempts, err := s.userRepo.RecordFailedLogin(ctx, user.ID, now, now.Add(-s.config.Auth.FailureWindow))
+	if err != nil {
+		span.RecordError(fmt.Errorf("failed to count failed login: %w", err))
+		return
+	}
+	user.FailedLoginAttempts = attempts
+	user.LastFailedLoginAt = &now
+
+	excess := attempts - threshold
+	if excess >= 0 {
+		until := now.Add(s.lockoutDuration(excess))
+		if err := s.userRepo.LockUntil(ctx, user.ID, until); err != nil {
+			span.RecordError(fmt.Errorf("failed to lock account: %w", err))
+			return
+		}
+		user.LockedUntil = &until
+	}
+
+	span.SetAttributes(attribute.Int("user.failed_login_attempts", user.FailedLoginAttempts))
+
+	if excess >= 0 {
+		s.accountLocked(ctx, user, now)
+	}
+}
+
+// accountLocked records a new lock on an account and tells its owner
+func (s *authService) accountLocked(ctx context.Context, user *domain.User, now time.Time) {
+	span := trace.SpanFromContext(ctx)
+	s.recordAuthFailure(ctx, "account_locked")
+
+	event := newAuthEvent(ctx, domain.AuthEventAccountLocked, user.Email, now)
+	event.UserID = &user.ID
+	event.LockedUntil = user.LockedUntil
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		span.RecordError(fmt.Errorf("failed to record lockout: %w", err))
+	}
+
+	err := s.send(ctx, &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Your account has been locked",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"There were %d failed attempts in a row to log in to your account, so it is locked until %s.\n\n"+
+			"If this was you, you can try again then. If it was not, someone may be trying to guess your "+
+			"password; resetting your password from the login page also unlocks your account.\n",
+			greetingName(user), user.FailedLoginAttempts, user.LockedUntil.UTC().Format("2 Jan 2006 15:04 MST")),
+	})
+	if err != nil {
+		span.RecordError(fmt.Errorf("failed to send lockout email: %w", err))
+	}
+}
+
+// lockoutDuration is how long a lock lasts after a number of failures
+// beyond the threshold: LockoutDuration, doubled for each, up to
+// MaxLockoutDuration
+func (s *authService) lockoutDuration(excess int) time.Duration {
+	duration, max := s.config.Auth.LockoutDuration, s.config.Auth.MaxLockoutDuration
+	for i := 0; i < excess && duration < max; i++ {
+		duration *= 2
+	}
+	if max > 0 && duration > max {
+		duration = max
+	}
+	return duration
+}
+
+// clearFailedLogins forgets the failed logins of a user and lifts any lock.
+// The caller saves the user.
+func clearFailedLogins(user *domain.User) {
+	user.FailedLoginAttempts = 0
+	user.LastFailedLoginAt = nil
+	user.LockedUntil = nil
+}
+
+// recordLoginAttempt counts a login in the metrics, if they are collected
+func (s *authService) recordLoginAttempt(ctx context.Context, success bool, method string) {
+	if s.metrics != nil {
+		s.metrics.RecordLoginAttempt(ctx, success, method)
+	}
+}
+
+// recordAuthFailure counts a refused login in the metrics, if they are
+// collected
+func (s *authService) recordAuthFailure(ctx context.Context, reason string) {
+	if s.metrics != nil {
+		s.metrics.RecordAuthFailure(ctx, reason)
+	}
+}
+
+// newAuthEvent starts an audit trail entry for the device of a request
+func newAuthEvent(ctx context.Context, eventType domain.AuthEventType, email string, at time.Time) *domain.AuthEvent {
+	client := domain.ClientInfoFromContext(ctx)
+	return &domain.AuthEvent{
+		ID:        uuid.New(),
+		Email:     email,
+		Type:      eventType,
+		IPAddress: optionalString(client.IPAddress),
+		UserAgent: optionalString(client.UserAgent),
+		CreatedAt: at,
+	}
+}
@@ -0,0 +1,371 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/pkg/totp"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// mfaChallengeAudience marks the tokens Login issues to users who still
+	// have to give a code, so they can never pass for access tokens
+	mfaChallengeAudience = "mfa"
+	// mfaSkew is how many time steps either side of now a code is accepted
+	// for, to allow for clock drift on the user's device
+	mfaSkew = 1
+	// recoveryCodeCount is how many recovery codes a user is given
+	recoveryCodeCount = 10
+)
+
+// recoveryCodeEncoding writes recovery codes in lower case base32, which
+// has no easily confused characters
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// VerifyMFA completes a login with the challenge token Login returned and a
+// code from the user's authenticator or one of their recovery codes
+func (s *authService) VerifyMFA(ctx context.Context, mfaToken, code string) (*domain.AuthResponse, error) {
+	ctx, span := authTracer.Start(signInContext(ctx), "authService.VerifyMFA")
+	defer span.End()
+
+	now := time.Now()
+	if err := s.checkAddressLockout(ctx, loginMethodMFA, now); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	userID, err := s.validateMFAChallenge(mfaToken)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if !user.IsActive {
+		err := domain.ErrAccountInactive
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := s.checkAccountLockout(ctx, user, loginMethodMFA, now); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Wrong codes count towards a lockout like wrong passwords, so that a
+	// challenge cannot be used to guess codes
+	if err := s.checkMFACode(ctx, user, code); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrInvalidMFACode) {
+			s.loginFailed(ctx, user, user.Email, loginMethodMFA, now)
+		}
+		return nil, err
+	}
+
+	response, err := s.completeLogin(ctx, user, loginMethodMFA)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// EnrollMFA creates a new authenticator for a user. It stays pending, and
+// logins keep working without it, until ConfirmMFA.
+func (s *authService) EnrollMFA(ctx context.Context, userID uuid.UUID) (*domain.MFASetup, error) {
+	ctx, span := authTracer.Start(ctx, "authService.EnrollMFA")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if user.MFAEnabled {
+		err := fmt.Errorf("MFA is already enabled: %w", domain.ErrConflict)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// A pending authenticator from an earlier attempt is replaced
+	if err := s.mfaRepo.Save(ctx, &domain.MFAEnrollment{UserID: user.ID, Secret: secret}); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to save authenticator: %w", err)
+	}
+
+	return &domain.MFASetup{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, s.config.Auth.MFAIssuer, user.Email),
+	}, nil
+}
+
+// ConfirmMFA turns MFA on with a first code from the pending authenticator
+// and returns the user's recovery codes, which are not shown again. Every
+// session of the user ends, so that each one left has passed MFA.
+func (s *authService) ConfirmMFA(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	ctx, span := authTracer.Start(ctx, "authService.ConfirmMFA")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	enrollment, err := s.mfaRepo.GetByUser(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("no authenticator to confirm: %w", err)
+	}
+	if user.MFAEnabled || enrollment.ConfirmedAt != nil {
+		err := fmt.Errorf("MFA is already enabled: %w", domain.ErrConflict)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	step, ok := totp.Validate(enrollment.Secret, strings.TrimSpace(code), now, mfaSkew)
+	if !ok {
+		err := domain.ErrInvalidMFACode
+		span.RecordError(err)
+		return nil, err
+	}
+
+	enrollment.ConfirmedAt = &now
+	enrollment.LastStep = step
+	if err := s.mfaRepo.Save(ctx, enrollment); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to confirm authenticator: %w", err)
+	}
+
+	codes, err := s.newRecoveryCodes(ctx, userID, now)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	user.MFAEnabled = true
+	user.UpdatedAt = now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to enable MFA: %w", err)
+	}
+
+	revoked, err := s.sessionRepo.RevokeByUser(ctx, userID, domain.RevokeReasonMFAEnabled, now)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to end sessions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("sessions.revoked", revoked))
+
+	return codes, nil
+}
+
+// DisableMFA turns MFA off, given a current code
+func (s *authService) DisableMFA(ctx context.Context, userID uuid.UUID, code string) error {
+	ctx, span := authTracer.Start(ctx, "authService.DisableMFA")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	user, err := s.mfaUser(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if err := s.checkMFACode(ctx, user, code); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if err := s.mfaRepo.Delete(ctx, userID); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to remove authenticator: %w", err)
+	}
+
+	user.MFAEnabled = false
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to disable MFA: %w", err)
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces a user's recovery codes, given a current
+// code
+func (s *authService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	ctx, span := authTracer.Start(ctx, "authService.RegenerateRecoveryCodes")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	user, err := s.mfaUser(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := s.checkMFACode(ctx, user, code); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	codes, err := s.newRecoveryCodes(ctx, userID, time.Now())
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// MFAPolicy returns which users need MFA
+func (s *authService) MFAPolicy() domain.MFAPolicy {
+	return domain.MFAPolicy{
+		Required: s.config.Auth.RequireMFA,
+		MinRole:  domain.UserRole(s.config.Auth.MFAMinRole),
+	}
+}
+
+// mfaUser loads a user who has MFA turned on
+func (s *authService) mfaUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if !user.MFAEnabled {
+		return nil, fmt.Errorf("MFA is not enabled: %w", domain.ErrConflict)
+	}
+	return user, nil
+}
+
+// checkMFACode accepts a code from the user's authenticator, at most once,
+// or one of their unused recovery codes
+func (s *authService) checkMFACode(ctx context.Context, user *domain.User, code string) error {
+	enrollment, err := s.mfaRepo.GetByUser(ctx, user.ID)
+	if err != nil || enrollment.ConfirmedAt == nil {
+		return fmt.Errorf("user has no confirmed authenticator: %w", domain.ErrInvalidMFACode)
+	}
+
+	now := time.Now()
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Validate(enrollment.Secret, code, now, mfaSkew); ok {
+		err := s.mfaRepo.UseStep(ctx, user.ID, step, now)
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("code was already used: %w", domain.ErrInvalidMFACode)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record code: %w", err)
+		}
+		return nil
+	}
+
+	err = s.mfaRepo.UseRecoveryCode(ctx, user.ID, hashToken(normalizeRecoveryCode(code)), now)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidMFACode
+	}
+	if err != nil {
+		return fmt.Errorf("failed to redeem recovery code: %w", err)
+	}
+	return nil
+}
+
+// newRecoveryCodes replaces a user's recovery codes and returns the new
+// ones; only their hashes are stored
+func (s *authService) newRecoveryCodes(ctx context.Context, userID uuid.UUID, at time.Time) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		secret := make([]byte, 5)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		encoded := recoveryCodeEncoding.EncodeToString(secret)
+		codes[i] = encoded[:4] + "-" + encoded[4:]
+		hashes[i] = hashToken(encoded)
+	}
+
+	if err := s.mfaRepo.ReplaceRecoveryCodes(ctx, userID, hashes, at); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// normalizeRecoveryCode strips what users add or change when typing a
+// recovery code: case, dashes and spaces
+func normalizeRecoveryCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+}
+
+// mfaChallenge answers a correct password from a user with an authenticator
+// with a short-lived token to exchange, along with a code, in VerifyMFA
+func (s *authService) mfaChallenge(user *domain.User) (*domain.AuthResponse, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Subject:   user.ID.String(),
+		Audience:  jwt.ClaimStrings{mfaChallengeAudience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(s.config.Auth.MFAChallengeExpiry)),
+		IssuedAt:  jwt.NewNumericDate(now),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.config.JWT.AccessSecret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate MFA challenge: %w", err)
+	}
+
+	return &domain.AuthResponse{
+		User:        user,
+		ExpiresIn:   int(s.config.Auth.MFAChallengeExpiry.Seconds()),
+		MFARequired: true,
+		MFAToken:    token,
+	}, nil
+}
+
+// validateMFAChallenge returns the user a challenge token was issued to
+func (s *authService) validateMFAChallenge(tokenString string) (uuid.UUID, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.config.JWT.AccessSecret), nil
+	}, jwt.WithAudience(mfaChallengeAudience), jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid MFA challenge: %v: %w", err, domain.ErrInvalidToken)
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID in MFA challenge: %w", domain.ErrInvalidToken)
+	}
+	return userID, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
+	"unicode/utf8"
 
 	"goreal-backend/internal/config"
 	"goreal-backend/internal/domain"
@@ -13,34 +15,73 @@ import (
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// TokenClaims represents JWT token claims
-type TokenClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+// accessClaims are the claims of the tokens this service issues. OrganizationID
+// names the tenant the requests made with the token are scoped to, and
+// SessionID the session the token belongs to.
+type accessClaims struct {
+	OrganizationID string `json:"org_id,omitempty"`
+	SessionID      string `json:"sid,omitempty"`
+	jwt.RegisteredClaims
 }
 
 var authTracer = otel.Tracer("goreal-backend/services/auth")
 
+const (
+	// minPasswordLength is the shortest password accepted, as the min=8 rule
+	// on RegisterRequest and ChangePasswordRequest says
+	minPasswordLength = 8
+	// maxPasswordBytes is the most bcrypt hashes
+	maxPasswordBytes = 72
+)
+
 type authService struct {
-	config   *config.Config
-	userRepo domain.UserRepository
+	config      *config.Config
+	userRepo    domain.UserRepository
+	sessionRepo domain.SessionRepository
+	tokenRepo   domain.AuthTokenRepository
+	mfaRepo     domain.MFARepository
+	eventRepo   domain.AuthEventRepository
+	walletRepo  domain.WalletRepository
+	nonceRepo   domain.SIWENonceRepository
+	mailer      domain.Mailer
+	metrics     domain.AuthMetrics
 }
 
-// NewAuthService creates a new auth service
-func NewAuthService(cfg *config.Config, userRepo domain.UserRepository) domain.AuthService {
+// NewAuthService creates a new auth service. The mailer sends verification
+// and password reset links and lockout notices; with a nil mailer no mail
+// is sent. Login metrics are not recorded when metrics is nil.
+func NewAuthService(cfg *config.Config, userRepo domain.UserRepository, sessionRepo domain.SessionRepository,
+	tokenRepo domain.AuthTokenRepository, mfaRepo domain.MFARepository, eventRepo domain.AuthEventRepository,
+	walletRepo domain.WalletRepository, nonceRepo domain.SIWENonceRepository, mailer domain.Mailer,
+	metrics domain.AuthMetrics) domain.AuthService {
 	return &authService{
-		config:   cfg,
-		userRepo: userRepo,
+		config:      cfg,
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		tokenRepo:   tokenRepo,
+		mfaRepo:     mfaRepo,
+		eventRepo:   eventRepo,
+		walletRepo:  walletRepo,
+		nonceRepo:   nonceRepo,
+		mailer:      mailer,
+		metrics:     metrics,
 	}
 }
 
+// signInContext lifts tenancy for the flows that find a user by email,
+// token or wallet before anyone is signed in. The tokens they issue carry
+// the user's organization, which scopes every later request.
+func signInContext(ctx context.Context) context.Context {
+	return domain.AcrossOrganizations(ctx)
+}
+
 // Login authenticates a user and returns a JWT token
 func (s *authService) Login(ctx context.Context, email, password string) (*domain.AuthResponse, error) {
-	ctx, span := authTracer.Start(ctx, "authService.Login")
+	ctx, span := authTracer.Start(signInContext(ctx), "authService.Login")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("user.email", email))
@@ -52,23 +93,39 @@ func (s *authService) Login(ctx context.Context, email, password string) (*domai
 		return nil, err
 	}
 
+	// Addresses with too many failed logins are turned away before any
+	// password is checked
+	now := time.Now()
+	if err := s.checkAddressLockout(ctx, loginMethodPassword, now); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	// Get user from database
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		span.RecordError(err)
+		s.loginFailed(ctx, nil, email, loginMethodPassword, now)
 		return nil, fmt.Errorf("invalid credentials")
 	}
 
+	if err := s.checkAccountLockout(ctx, user, loginMethodPassword, now); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	// Verify password
 	if user.PasswordHash == "" {
 		err := errors.New("user has no password set")
 		span.RecordError(err)
+		s.loginFailed(ctx, user, email, loginMethodPassword, now)
 		return nil, fmt.Errorf("invalid credentials")
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
 	if err != nil {
 		span.RecordError(err)
+		s.loginFailed(ctx, user, email, loginMethodPassword, now)
 		return nil, fmt.Errorf("invalid credentials")
 	}
 
@@ -79,44 +136,68 @@ func (s *authService) Login(ctx context.Context, email, password string) (*domai
 		return nil, err
 	}
 
-	// Generate JWT token
-	accessToken, expiresIn, err := s.generateAccessToken(user)
-	if err != nil {
+	if s.config.Auth.RequireEmailVerification && user.EmailVerifiedAt == nil {
+		err := domain.ErrEmailNotVerified
 		span.RecordError(err)
 		return nil, err
 	}
 
-	// Generate refresh token
-	refreshToken, _, err := s.generateRefreshToken(user)
+	span.SetAttributes(
+		attribute.String("user.id", user.ID.String()),
+		attribute.String("user.role", string(user.Role)),
+		attribute.Bool("user.mfa_enabled", user.MFAEnabled),
+	)
+
+	// Users with an authenticator are only signed in once they answer a
+	// challenge with a code from it
+	if user.MFAEnabled {
+		response, err := s.mfaChallenge(user)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		return response, nil
+	}
+
+	response, err := s.completeLogin(ctx, user, loginMethodPassword)
 	if err != nil {
 		span.RecordError(err)
 		return nil, err
 	}
 
-	// Update last login time
+	return response, nil
+}
+
+// completeLogin starts a session for a user who has proved who they are,
+// by the given method
+func (s *authService) completeLogin(ctx context.Context, user *domain.User, method string) (*domain.AuthResponse, error) {
+	// Start a session for the device and issue its tokens
+	response, err := s.startSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	s.recordLoginAttempt(ctx, true, method)
+
+	// Update last login time; earlier failed logins no longer count
 	now := time.Now()
 	user.LastLoginAt = &now
+	clearFailedLogins(user)
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		// Log error but don't fail login
-		span.RecordError(fmt.Errorf("failed to update last login: %w", err))
+		trace.SpanFromContext(ctx).RecordError(fmt.Errorf("failed to update last login: %w", err))
 	}
 
-	span.SetAttributes(
-		attribute.String("user.id", user.ID.String()),
-		attribute.String("user.role", string(user.Role)),
-	)
-
-	return &domain.AuthResponse{
-		User:         user,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    expiresIn,
-	}, nil
+	return response, nil
 }
 
-// Register creates a new user account
+// registrationRole is the role of every account that registers itself.
+// Admins grant other roles; super admins are only created out of band.
+const registrationRole = domain.RoleUser
+
+// Register creates a new user account with the registration role. Asking
+// for any other role is refused rather than silently downgraded.
 func (s *authService) Register(ctx context.Context, req *domain.RegisterRequest) (*domain.AuthResponse, error) {
-	ctx, span := authTracer.Start(ctx, "authService.Register")
+	ctx, span := authTracer.Start(signInContext(ctx), "authService.Register")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("user.email", req.Email))
@@ -127,6 +208,15 @@ func (s *authService) Register(ctx context.Context, req *domain.RegisterRequest)
 		span.RecordError(err)
 		return nil, err
 	}
+	if err := validatePassword(req.Password); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	if req.Role != "" && req.Role != registrationRole {
+		err := fmt.Errorf("role %s cannot be chosen at registration: %w", req.Role, domain.ErrInvalidInput)
+		span.RecordError(err)
+		return nil, err
+	}
 
 	// Check if user already exists
 	existingUser, err := s.userRepo.GetByEmail(ctx, req.Email)
@@ -151,18 +241,20 @@ func (s *authService) Register(ctx context.Context, req *domain.RegisterRequest)
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	// Create user
+	// Create user in the organization open to registration
 	now := time.Now()
+	organizationID := s.config.Auth.RegistrationOrganizationID
 	user := &domain.User{
-		ID:           uuid.New(),
-		Email:        req.Email,
-		Username:     req.Username,
-		FullName:     req.FullName,
-		Role:         req.Role,
-		PasswordHash: string(hashedPassword),
-		IsActive:     true,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:             uuid.New(),
+		Email:          req.Email,
+		Username:       req.Username,
+		FullName:       req.FullName,
+		Role:           registrationRole,
+		PasswordHash:   string(hashedPassword),
+		IsActive:       true,
+		OrganizationID: &organizationID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}
 
 	// Save user to database
@@ -171,35 +263,40 @@ func (s *authService) Register(ctx context.Context, req *domain.RegisterRequest)
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// Generate tokens
-	accessToken, expiresIn, err := s.generateAccessToken(user)
-	if err != nil {
+	span.SetAttributes(
+		attribute.String("user.id", user.ID.String()),
+		attribute.String("user.role", string(user.Role)),
+	)
+
+	// A failed mail does not undo the registration; the user can ask for
+	// the link again
+	if err := s.sendVerification(ctx, user); err != nil {
 		span.RecordError(err)
-		return nil, err
+		log.Printf("Failed to send verification email to user %s: %v", user.ID, err)
+	}
+
+	// Users who must verify their address first are signed in by Login
+	// once they have
+	if s.config.Auth.RequireEmailVerification {
+		return &domain.AuthResponse{User: user}, nil
 	}
 
-	refreshToken, _, err := s.generateRefreshToken(user)
+	// Sign the new user in on the device they registered from
+	response, err := s.startSession(ctx, user)
 	if err != nil {
 		span.RecordError(err)
 		return nil, err
 	}
 
-	span.SetAttributes(
-		attribute.String("user.id", user.ID.String()),
-		attribute.String("user.role", string(user.Role)),
-	)
-
-	return &domain.AuthResponse{
-		User:         user,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    expiresIn,
-	}, nil
+	return response, nil
 }
 
-// RefreshToken generates a new access token from a refresh token
+// RefreshToken exchanges a refresh token for a new pair of tokens. Every
+// refresh token is good for one refresh only: presenting one that has
+// already been exchanged means it was stolen or replayed, so the whole
+// session is revoked.
 func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*domain.AuthResponse, error) {
-	ctx, span := authTracer.Start(ctx, "authService.RefreshToken")
+	ctx, span := authTracer.Start(signInContext(ctx), "authService.RefreshToken")
 	defer span.End()
 
 	// Validate refresh token
@@ -209,14 +306,29 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 		return nil, fmt.Errorf("invalid refresh token: %w", err)
 	}
 
-	// Get user from database
-	userID, err := uuid.Parse(claims.UserID)
+	session, err := s.tokenSession(ctx, claims)
 	if err != nil {
 		span.RecordError(err)
-		return nil, fmt.Errorf("invalid user ID in token: %w", err)
+		return nil, fmt.Errorf("invalid refresh token: %w", err)
 	}
 
-	user, err := s.userRepo.GetByID(ctx, userID)
+	span.SetAttributes(attribute.String("session.id", session.ID.String()))
+
+	now := time.Now()
+	if !session.Active(now) {
+		err := fmt.Errorf("session has ended: %w", domain.ErrInvalidToken)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	oldHash := hashToken(refreshToken)
+	if oldHash != session.TokenHash {
+		err := s.revokeReused(ctx, session.ID, now)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, session.UserID)
 	if err != nil {
 		span.RecordError(err)
 		return nil, fmt.Errorf("user not found: %w", err)
@@ -229,8 +341,19 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 		return nil, err
 	}
 
-	// Generate new access token
-	accessToken, expiresIn, err := s.generateAccessToken(user)
+	response, newHash, expiresAt, err := s.issueTokens(user, session.ID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// The rotation only succeeds if the session still holds the presented
+	// token, so of two refreshes racing with one token the second is
+	// treated as a replay
+	err = s.sessionRepo.Rotate(ctx, session.ID, oldHash, newHash, expiresAt, now)
+	if errors.Is(err, domain.ErrNotFound) {
+		err = s.revokeReused(ctx, session.ID, now)
+	}
 	if err != nil {
 		span.RecordError(err)
 		return nil, err
@@ -241,38 +364,55 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 		attribute.String("user.email", user.Email),
 	)
 
-	return &domain.AuthResponse{
-		User:         user,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken, // Keep the same refresh token
-		ExpiresIn:    expiresIn,
-	}, nil
+	return response, nil
 }
 
-// Logout handles user logout
-func (s *authService) Logout(ctx context.Context, userID uuid.UUID) error {
+// Logout ends the session of a refresh token, or every session of the user
+// when no token is given
+func (s *authService) Logout(ctx context.Context, userID uuid.UUID, refreshToken string) error {
 	ctx, span := authTracer.Start(ctx, "authService.Logout")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("user.id", userID.String()))
 
-	// In a real implementation, you might want to:
-	// 1. Invalidate the refresh token in the database
-	// 2. Add the access token to a blacklist
-	// For now, we'll just log the logout event
+	if refreshToken == "" {
+		revoked, err := s.sessionRepo.RevokeByUser(ctx, userID, domain.RevokeReasonLogout, time.Now())
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to end sessions: %w", err)
+		}
+		span.SetAttributes(attribute.Int("sessions.revoked", revoked))
+		return nil
+	}
+
+	claims, err := s.validateRefreshToken(refreshToken)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("invalid refresh token: %w", err)
+	}
+	session, err := s.tokenSession(ctx, claims)
+	if err != nil || session.UserID != userID {
+		err := fmt.Errorf("refresh token does not belong to the user: %w", domain.ErrInvalidToken)
+		span.RecordError(err)
+		return err
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, session.ID, domain.RevokeReasonLogout, time.Now()); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to end session: %w", err)
+	}
 
-	// TODO: Implement token blacklisting or refresh token invalidation
-	// This could involve storing active tokens in Redis or database
+	span.SetAttributes(attribute.String("session.id", session.ID.String()))
 
 	return nil
 }
 
 // ValidateToken validates a JWT token and returns the user
 func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*domain.User, error) {
-	ctx, span := authTracer.Start(ctx, "authService.ValidateToken")
+	ctx, span := authTracer.Start(signInContext(ctx), "authService.ValidateToken")
 	defer span.End()
 
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &accessClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(s.config.JWT.AccessSecret), nil
 	})
 
@@ -287,7 +427,7 @@ func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*d
 		return nil, err
 	}
 
-	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	claims, ok := token.Claims.(*accessClaims)
 	if !ok {
 		err := errors.New("invalid token claims")
 		span.RecordError(err)
@@ -314,6 +454,25 @@ func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*d
 		return nil, err
 	}
 
+	// A token minted before the user moved organization no longer applies
+	if claims.OrganizationID != organizationClaim(user) {
+		err := fmt.Errorf("token organization does not match user: %w", domain.ErrInvalidToken)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Nor does one whose session has been logged out or revoked
+	session, err := s.tokenSession(ctx, claims)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	if !session.Active(time.Now()) {
+		err := fmt.Errorf("session has ended: %w", domain.ErrInvalidToken)
+		span.RecordError(err)
+		return nil, err
+	}
+
 	span.SetAttributes(
 		attribute.String("user.id", user.ID.String()),
 		attribute.String("user.email", user.Email),
@@ -323,15 +482,19 @@ func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*d
 	return user, nil
 }
 
-// generateAccessToken creates a JWT access token
-func (s *authService) generateAccessToken(user *domain.User) (string, int, error) {
+// generateAccessToken creates a JWT access token for a session
+func (s *authService) generateAccessToken(user *domain.User, sessionID uuid.UUID) (string, int, error) {
 	expiresAt := time.Now().Add(s.config.JWT.AccessTokenExpiry)
 
-	claims := jwt.RegisteredClaims{
-		Subject:   user.ID.String(),
-		Issuer:    user.Email,
-		ExpiresAt: jwt.NewNumericDate(expiresAt),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	claims := accessClaims{
+		OrganizationID: organizationClaim(user),
+		SessionID:      sessionID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID.String(),
+			Issuer:    user.Email,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -345,29 +508,35 @@ func (s *authService) generateAccessToken(user *domain.User) (string, int, error
 	return tokenString, expiresIn, nil
 }
 
-// generateRefreshToken creates a JWT refresh token
-func (s *authService) generateRefreshToken(user *domain.User) (string, int64, error) {
+// generateRefreshToken creates a JWT refresh token for a session. Each
+// token gets an ID of its own so that no two tokens of a session are equal.
+func (s *authService) generateRefreshToken(user *domain.User, sessionID uuid.UUID) (string, time.Time, error) {
 	expiresAt := time.Now().Add(s.config.JWT.RefreshTokenExpiry)
 
-	claims := jwt.RegisteredClaims{
-		Subject:   user.ID.String(),
-		Issuer:    user.Email,
-		ExpiresAt: jwt.NewNumericDate(expiresAt),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	claims := accessClaims{
+		OrganizationID: organizationClaim(user),
+		SessionID:      sessionID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   user.ID.String(),
+			Issuer:    user.Email,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(s.config.JWT.RefreshSecret))
 	if err != nil {
-		return "", 0, err
+		return "", time.Time{}, err
 	}
 
-	return tokenString, expiresAt.Unix(), nil
+	return tokenString, expiresAt, nil
 }
 
 // validateRefreshToken validates a refresh token
-func (s *authService) validateRefreshToken(tokenString string) (*TokenClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
+func (s *authService) validateRefreshToken(tokenString string) (*accessClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &accessClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(s.config.JWT.RefreshSecret), nil
 	})
 
@@ -379,24 +548,33 @@ func (s *authService) validateRefreshToken(tokenString string) (*TokenClaims, er
 		return nil, errors.New("invalid refresh token")
 	}
 
-	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	claims, ok := token.Claims.(*accessClaims)
 	if !ok {
 		return nil, errors.New("invalid refresh token claims")
 	}
 
-	return &TokenClaims{
-		UserID: claims.Subject,
-		Email:  claims.Issuer,
-		Role:   "user",
-	}, nil
+	return claims, nil
+}
+
+// organizationClaim renders the org_id claim for a user's organization
+func organizationClaim(user *domain.User) string {
+	if user.OrganizationID == nil {
+		return ""
+	}
+	return user.OrganizationID.String()
 }
 
 func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, oldPassword, newPassword string) error {
-	_, span := authTracer.Start(ctx, "authService.ChangePassword")
+	ctx, span := authTracer.Start(ctx, "authService.ChangePassword")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("user.id", userID.String()))
 
+	if err := validatePassword(newPassword); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
 	// Get user from database
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -427,49 +605,23 @@ func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, oldP
 		return fmt.Errorf("failed to update password: %w", err)
 	}
 
+	// Sign out every device that knew the old password
+	if _, err := s.sessionRepo.RevokeByUser(ctx, userID, domain.RevokeReasonPasswordChanged, time.Now()); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to end sessions: %w", err)
+	}
+
 	return nil
 }
 
-func (s *authService) ResetPassword(ctx context.Context, email string) error {
-	_, span := authTracer.Start(ctx, "authService.ResetPassword")
-	defer span.End()
-
-	span.SetAttributes(attribute.String("user.email", email))
-
-	// Check if user exists
-	user, err := s.userRepo.GetByEmail(ctx, email)
-	if err != nil {
-		// Don't reveal if user exists or not for security
-		return nil
+// validatePassword applies the password policy to a new password: at least
+// minPasswordLength characters, and no more than bcrypt can hash
+func validatePassword(password string) error {
+	if utf8.RuneCountInString(password) < minPasswordLength {
+		return fmt.Errorf("password must be at least %d characters: %w", minPasswordLength, domain.ErrInvalidInput)
 	}
-
-	if !user.IsActive {
-		// Don't reveal if user exists or not for security
-		return nil
+	if len(password) > maxPasswordBytes {
+		return fmt.Errorf("password must be at most %d bytes: %w", maxPasswordBytes, domain.ErrInvalidInput)
 	}
-
-	// TODO: Implement password reset with email
-	// 1. Generate reset token
-	// 2. Store token with expiration in database
-	// 3. Send reset email to user
-	// For now, just log the request
-
-	return fmt.Errorf("password reset email functionality not yet implemented")
-}
-
-func (s *authService) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
-	ctx, span := authTracer.Start(ctx, "authService.ConfirmPasswordReset")
-	defer span.End()
-
-	span.SetAttributes(attribute.String("reset.token", token))
-
-	// TODO: Implement password reset confirmation
-	// 1. Validate reset token from database
-	// 2. Check if token is not expired
-	// 3. Get user associated with token
-	// 4. Hash new password
-	// 5. Update user password
-	// 6. Invalidate reset token
-
-	return fmt.Errorf("password reset confirmation not yet implemented")
+	return nil
 }
@@ -0,0 +1,676 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/config"
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/infrastructure/memory"
+	"goreal-backend/pkg/secp256k1"
+	"goreal-backend/pkg/siwe"
+	"goreal-backend/pkg/totp"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const authTestPassword = "correct horse"
+
+type authFixture struct {
+	ctx      context.Context
+	cfg      *config.Config
+	users    domain.UserRepository
+	sessions domain.SessionRepository
+	tokens   domain.AuthTokenRepository
+	mfa      domain.MFARepository
+	events   domain.AuthEventRepository
+	wallets  domain.WalletRepository
+	metrics  *loginMetrics
+	outbox   *outbox
+	service  domain.AuthService
+	user     *domain.User
+}
+
+// outbox is a mailer that keeps what it sends
+type outbox struct {
+	sent []*domain.EmailMessage
+}
+
+func (o *outbox) Send(_ context.Context, msg *domain.EmailMessage) error {
+	o.sent = append(o.sent, msg)
+	return nil
+}
+
+// token returns the token in the link of the last message sent to an
+// address
+func (o *outbox) token(t *testing.T, to string) string {
+	t.Helper()
+	for i := len(o.sent) - 1; i >= 0; i-- {
+		if o.sent[i].To != to {
+			continue
+		}
+		match := regexp.MustCompile(`\?token=([A-Za-z0-9_-]+)`).FindStringSubmatch(o.sent[i].Body)
+		require.NotNil(t, match, "no link in %q", o.sent[i].Body)
+		return match[1]
+	}
+	t.Fatalf("no mail sent to %s", to)
+	return ""
+}
+
+// loginMetrics keeps the login outcomes it is given
+type loginMetrics struct {
+	attempts []string
+	failures []string
+}
+
+func (m *loginMetrics) RecordLoginAttempt(_ context.Context, success bool, method string) {
+	m.attempts = append(m.attempts, fmt.Sprintf("%s:%t", method, success))
+}
+
+func (m *loginMetrics) RecordAuthFailure(_ context.Context, reason string) {
+	m.failures = append(m.failures, reason)
+}
+
+func newAuthFixture(t *testing.T) *authFixture {
+	t.Helper()
+	store := memory.NewStore()
+	organizationID := uuid.New()
+	f := &authFixture{
+		ctx: domain.WithOrganization(context.Background(), organizationID),
+		cfg: &config.Config{
+			AppURL: "https://app.example.com",
+			JWT: config.JWTConfig{
+				AccessSecret:       "access-secret",
+				RefreshSecret:      "refresh-secret",
+				AccessTokenExpiry:  15 * time.Minute,
+				RefreshTokenExpiry: 24 * time.Hour,
+			},
+			Auth: config.AuthConfig{
+				EmailVerificationExpiry: 48 * time.Hour,
+				PasswordResetExpiry:     time.Hour,
+				MFAIssuer:               "GoReal",
+				MFAChallengeExpiry:      5 * time.Minute,
+				LockoutThreshold:        3,
+				IPLockoutThreshold:      10,
+				FailureWindow:           time.Hour,
+				LockoutDuration:         time.Minute,
+				MaxLockoutDuration:      5 * time.Minute,
+				SIWEDomain:              "app.example.com",
+				SIWEChainIDs:            []int64{1},
+				SIWENonceExpiry:         10 * time.Minute,
+
+				RegistrationOrganizationID: organizationID,
+			},
+		},
+		users:    memory.NewUserRepository(store),
+		sessions: memory.NewSessionRepository(store),
+		tokens:   memory.NewAuthTokenRepository(store),
+		mfa:      memory.NewMFARepository(store),
+		events:   memory.NewAuthEventRepository(store),
+		wallets:  memory.NewWalletRepository(store),
+		metrics:  &loginMetrics{},
+		outbox:   &outbox{},
+	}
+	f.service = NewAuthService(f.cfg, f.users, f.sessions, f.tokens, f.mfa, f.events, f.wallets,
+		memory.NewSIWENonceRepository(store), f.outbox, f.metrics)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(authTestPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+	f.user = &domain.User{ID: uuid.New(), Email: "ana@example.com", Username: "ana", FullName: "Ana",
+		Role: domain.RoleEmployee, PasswordHash: string(hash), IsActive: true}
+	require.NoError(t, f.users.Create(f.ctx, f.user))
+	return f
+}
+
+// login signs the fixture's user in from a named device
+func (f *authFixture) login(t *testing.T, device string) *domain.AuthResponse {
+	t.Helper()
+	ctx := domain.WithClientInfo(f.ctx, domain.ClientInfo{DeviceName: device, UserAgent: "test", IPAddress: "10.0.0.1"})
+	response, err := f.service.Login(ctx, f.user.Email, authTestPassword)
+	require.NoError(t, err)
+	return response
+}
+
+func TestAuthService_RefreshRotatesTokens(t *testing.T) {
+	f := newAuthFixture(t)
+	first := f.login(t, "Laptop")
+
+	second, err := f.service.RefreshToken(f.ctx, first.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	assert.Equal(t, f.user.ID, second.User.ID)
+
+	third, err := f.service.RefreshToken(f.ctx, second.RefreshToken)
+	require.NoError(t, err)
+
+	_, err = f.service.ValidateToken(f.ctx, third.AccessToken)
+	require.NoError(t, err)
+
+	sessions, err := f.service.ListSessions(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1, "refreshing keeps the session")
+	assert.Equal(t, 2, sessions[0].Rotations)
+	require.NotNil(t, sessions[0].DeviceName)
+	assert.Equal(t, "Laptop", *sessions[0].DeviceName)
+	require.NotNil(t, sessions[0].IPAddress)
+	assert.Equal(t, "10.0.0.1", *sessions[0].IPAddress)
+}
+
+func TestAuthService_RefreshTokenReuseRevokesSession(t *testing.T) {
+	f := newAuthFixture(t)
+	stolen := f.login(t, "Laptop")
+	other := f.login(t, "Phone")
+
+	rotated, err := f.service.RefreshToken(f.ctx, stolen.RefreshToken)
+	require.NoError(t, err)
+
+	// The replayed token ends the session for the thief and the owner alike
+	_, err = f.service.RefreshToken(f.ctx, stolen.RefreshToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "replayed token: %v", err)
+	_, err = f.service.RefreshToken(f.ctx, rotated.RefreshToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "latest token of a revoked session: %v", err)
+	_, err = f.service.ValidateToken(f.ctx, rotated.AccessToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "access token of a revoked session: %v", err)
+
+	sessions, err := f.service.ListSessions(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1, "other devices stay signed in")
+	_, err = f.service.ValidateToken(f.ctx, other.AccessToken)
+	assert.NoError(t, err)
+
+	claims, err := f.service.(*authService).validateRefreshToken(stolen.RefreshToken)
+	require.NoError(t, err)
+	session, err := f.sessions.GetByID(f.ctx, uuid.MustParse(claims.SessionID))
+	require.NoError(t, err)
+	require.NotNil(t, session.RevokedReason)
+	assert.Equal(t, domain.RevokeReasonTokenReuse, *session.RevokedReason)
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	f := newAuthFixture(t)
+	laptop := f.login(t, "Laptop")
+	phone := f.login(t, "Phone")
+	tablet := f.login(t, "Tablet")
+
+	require.NoError(t, f.service.Logout(f.ctx, f.user.ID, laptop.RefreshToken))
+	_, err := f.service.ValidateToken(f.ctx, laptop.AccessToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "logged out session: %v", err)
+	_, err = f.service.RefreshToken(f.ctx, laptop.RefreshToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "logged out session: %v", err)
+
+	err = f.service.Logout(f.ctx, uuid.New(), phone.RefreshToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "another user's token: %v", err)
+
+	sessions, err := f.service.ListSessions(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	var phoneSession uuid.UUID
+	for _, session := range sessions {
+		if *session.DeviceName == "Phone" {
+			phoneSession = session.ID
+		}
+	}
+
+	err = f.service.RevokeSession(f.ctx, uuid.New(), phoneSession)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "another user's session: %v", err)
+	require.NoError(t, f.service.RevokeSession(f.ctx, f.user.ID, phoneSession))
+	_, err = f.service.ValidateToken(f.ctx, phone.AccessToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "revoked session: %v", err)
+
+	// Without a token the user is logged out everywhere
+	require.NoError(t, f.service.Logout(f.ctx, f.user.ID, ""))
+	_, err = f.service.ValidateToken(f.ctx, tablet.AccessToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "logged out everywhere: %v", err)
+}
+
+func TestAuthService_ForceLogoutAndPasswordChange(t *testing.T) {
+	f := newAuthFixture(t)
+	f.login(t, "Laptop")
+	f.login(t, "Phone")
+
+	revoked, err := f.service.RevokeAllSessions(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, revoked)
+	sessions, err := f.service.ListSessions(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	_, err = f.service.RevokeAllSessions(f.ctx, uuid.New())
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "unknown user: %v", err)
+
+	laptop := f.login(t, "Laptop")
+	err = f.service.ChangePassword(f.ctx, f.user.ID, authTestPassword, "short")
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "short password: %v", err)
+	require.NoError(t, f.service.ChangePassword(f.ctx, f.user.ID, authTestPassword, "battery staple"))
+	_, err = f.service.RefreshToken(f.ctx, laptop.RefreshToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "session from before the password change: %v", err)
+}
+
+func TestAuthService_PasswordReset(t *testing.T) {
+	f := newAuthFixture(t)
+	laptop := f.login(t, "Laptop")
+
+	// Unknown addresses look the same as known ones to the caller
+	require.NoError(t, f.service.ResetPassword(f.ctx, "nobody@example.com"))
+	assert.Empty(t, f.outbox.sent)
+
+	require.NoError(t, f.service.ResetPassword(f.ctx, f.user.Email))
+	stale := f.outbox.token(t, f.user.Email)
+	require.NoError(t, f.service.ResetPassword(f.ctx, f.user.Email))
+	token := f.outbox.token(t, f.user.Email)
+	assert.Contains(t, f.outbox.sent[1].Body, "https://app.example.com/reset-password?token="+token)
+
+	err := f.service.ConfirmPasswordReset(f.ctx, stale, "battery staple")
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "superseded link: %v", err)
+	err = f.service.ConfirmPasswordReset(f.ctx, "not-a-token", "battery staple")
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "unknown token: %v", err)
+
+	// The policy registration applies holds for resets too, and a refused
+	// password leaves the link usable
+	for _, password := range []string{"", "short", strings.Repeat("long", 20)} {
+		err = f.service.ConfirmPasswordReset(f.ctx, token, password)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput), "password %q: %v", password, err)
+	}
+
+	require.NoError(t, f.service.ConfirmPasswordReset(f.ctx, token, "battery staple"))
+	err = f.service.ConfirmPasswordReset(f.ctx, token, "another password")
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "used link: %v", err)
+
+	_, err = f.service.Login(f.ctx, f.user.Email, "battery staple")
+	assert.NoError(t, err)
+	_, err = f.service.RefreshToken(f.ctx, laptop.RefreshToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "session from before the reset: %v", err)
+
+	user, err := f.users.GetByID(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, user.EmailVerifiedAt, "a reset proves the address")
+}
+
+func TestAuthService_PasswordResetExpires(t *testing.T) {
+	f := newAuthFixture(t)
+	f.cfg.Auth.PasswordResetExpiry = -time.Minute
+
+	require.NoError(t, f.service.ResetPassword(f.ctx, f.user.Email))
+	err := f.service.ConfirmPasswordReset(f.ctx, f.outbox.token(t, f.user.Email), "battery staple")
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "expired link: %v", err)
+}
+
+func TestAuthService_EmailVerification(t *testing.T) {
+	f := newAuthFixture(t)
+	f.cfg.Auth.RequireEmailVerification = true
+
+	_, err := f.service.Register(f.ctx, &domain.RegisterRequest{
+		Email: "bo@example.com", Username: "bo", FullName: "Bo", Password: "short", Role: domain.RoleUser,
+	})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "short password: %v", err)
+
+	response, err := f.service.Register(f.ctx, &domain.RegisterRequest{
+		Email: "bo@example.com", Username: "bo", FullName: "Bo", Password: authTestPassword, Role: domain.RoleUser,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, response.AccessToken, "no session before the address is verified")
+	assert.Nil(t, response.User.EmailVerifiedAt)
+	require.NotNil(t, response.User.OrganizationID, "registration joins an organization")
+	assert.Equal(t, f.cfg.Auth.RegistrationOrganizationID, *response.User.OrganizationID)
+
+	_, err = f.service.Login(f.ctx, "bo@example.com", authTestPassword)
+	assert.True(t, errors.Is(err, domain.ErrEmailNotVerified), "unverified login: %v", err)
+
+	first := f.outbox.token(t, "bo@example.com")
+	require.NoError(t, f.service.ResendVerification(f.ctx, "bo@example.com"))
+	token := f.outbox.token(t, "bo@example.com")
+	err = f.service.VerifyEmail(f.ctx, first)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "superseded link: %v", err)
+
+	require.NoError(t, f.service.VerifyEmail(f.ctx, token))
+	err = f.service.VerifyEmail(f.ctx, token)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "used link: %v", err)
+
+	_, err = f.service.Login(f.ctx, "bo@example.com", authTestPassword)
+	require.NoError(t, err)
+
+	// Verified users are not sent another link
+	sent := len(f.outbox.sent)
+	require.NoError(t, f.service.ResendVerification(f.ctx, "bo@example.com"))
+	assert.Len(t, f.outbox.sent, sent)
+}
+
+// enableMFA sets up an authenticator for the fixture's user and returns its
+// secret and the recovery codes
+func (f *authFixture) enableMFA(t *testing.T) (string, []string) {
+	t.Helper()
+	setup, err := f.service.EnrollMFA(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.Contains(t, setup.ProvisioningURI, "otpauth://totp/GoReal:ana@example.com?")
+
+	codes, err := f.service.ConfirmMFA(f.ctx, f.user.ID, mfaCode(t, setup.Secret, time.Now()))
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+	return setup.Secret, codes
+}
+
+// mfaCode returns the authenticator code for a time
+func mfaCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	code, err := totp.Code(secret, at)
+	require.NoError(t, err)
+	return code
+}
+
+func TestAuthService_RegistrationRole(t *testing.T) {
+	f := newAuthFixture(t)
+
+	for _, role := range []domain.UserRole{domain.RoleSuperAdmin, domain.RoleAdmin, domain.RoleEmployee, domain.RoleClient} {
+		_, err := f.service.Register(f.ctx, &domain.RegisterRequest{
+			Email: "eve@example.com", Username: "eve", FullName: "Eve", Password: authTestPassword, Role: role,
+		})
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput), "register as %s: %v", role, err)
+	}
+	_, err := f.users.GetByEmail(domain.AcrossOrganizations(f.ctx), "eve@example.com")
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "refused registrations create no account: %v", err)
+
+	response, err := f.service.Register(f.ctx, &domain.RegisterRequest{
+		Email: "eve@example.com", Username: "eve", FullName: "Eve", Password: authTestPassword,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleUser, response.User.Role)
+}
+
+func TestAuthService_MFALogin(t *testing.T) {
+	f := newAuthFixture(t)
+	before := f.login(t, "Laptop")
+	secret, _ := f.enableMFA(t)
+
+	_, err := f.service.ValidateToken(f.ctx, before.AccessToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "session from before MFA: %v", err)
+
+	challenge := f.login(t, "Laptop")
+	assert.True(t, challenge.MFARequired)
+	assert.Empty(t, challenge.AccessToken)
+	assert.Empty(t, challenge.RefreshToken)
+	_, err = f.service.ValidateToken(f.ctx, challenge.MFAToken)
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "challenge as access token: %v", err)
+
+	// The code that confirmed the authenticator cannot be replayed
+	_, err = f.service.VerifyMFA(f.ctx, challenge.MFAToken, mfaCode(t, secret, time.Now()))
+	assert.True(t, errors.Is(err, domain.ErrInvalidMFACode), "replayed code: %v", err)
+	_, err = f.service.VerifyMFA(f.ctx, challenge.MFAToken, "000000x")
+	assert.True(t, errors.Is(err, domain.ErrInvalidMFACode), "wrong code: %v", err)
+	_, err = f.service.VerifyMFA(f.ctx, "not-a-token", mfaCode(t, secret, time.Now().Add(totp.Period)))
+	assert.True(t, errors.Is(err, domain.ErrInvalidToken), "bad challenge: %v", err)
+
+	response, err := f.service.VerifyMFA(f.ctx, challenge.MFAToken, mfaCode(t, secret, time.Now().Add(totp.Period)))
+	require.NoError(t, err)
+	assert.False(t, response.MFARequired)
+	_, err = f.service.ValidateToken(f.ctx, response.AccessToken)
+	require.NoError(t, err)
+}
+
+func TestAuthService_MFARecoveryCodes(t *testing.T) {
+	f := newAuthFixture(t)
+	_, codes := f.enableMFA(t)
+
+	challenge := f.login(t, "Phone")
+	_, err := f.service.VerifyMFA(f.ctx, challenge.MFAToken, strings.ToUpper(codes[0]))
+	require.NoError(t, err)
+	_, err = f.service.VerifyMFA(f.ctx, challenge.MFAToken, codes[0])
+	assert.True(t, errors.Is(err, domain.ErrInvalidMFACode), "used recovery code: %v", err)
+
+	fresh, err := f.service.RegenerateRecoveryCodes(f.ctx, f.user.ID, codes[1])
+	require.NoError(t, err)
+	_, err = f.service.VerifyMFA(f.ctx, challenge.MFAToken, codes[2])
+	assert.True(t, errors.Is(err, domain.ErrInvalidMFACode), "replaced recovery code: %v", err)
+
+	require.NoError(t, f.service.DisableMFA(f.ctx, f.user.ID, fresh[0]))
+	response := f.login(t, "Phone")
+	assert.False(t, response.MFARequired)
+	assert.NotEmpty(t, response.AccessToken)
+
+	err = f.service.DisableMFA(f.ctx, f.user.ID, fresh[1])
+	assert.True(t, errors.Is(err, domain.ErrConflict), "disabled twice: %v", err)
+}
+
+// loginFrom tries to log in from an IP address
+func (f *authFixture) loginFrom(ip, email, password string) (*domain.AuthResponse, error) {
+	ctx := domain.WithClientInfo(f.ctx, domain.ClientInfo{UserAgent: "test", IPAddress: ip})
+	return f.service.Login(ctx, email, password)
+}
+
+func TestAuthService_AccountLockout(t *testing.T) {
+	f := newAuthFixture(t)
+
+	for i := 0; i < 2; i++ {
+		_, err := f.loginFrom("10.0.0.1", f.user.Email, "wrong")
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, domain.ErrAccountLocked), "attempt %d: %v", i+1, err)
+	}
+	_, err := f.loginFrom("10.0.0.1", f.user.Email, "wrong")
+	require.Error(t, err)
+
+	// Even the right password is refused while the account is locked
+	_, err = f.loginFrom("10.0.0.2", f.user.Email, authTestPassword)
+	var lockout *domain.LockoutError
+	require.True(t, errors.As(err, &lockout), "locked account: %v", err)
+	assert.True(t, errors.Is(err, domain.ErrAccountLocked))
+	assert.WithinDuration(t, time.Now().Add(time.Minute), lockout.Until, 5*time.Second)
+
+	require.NotEmpty(t, f.outbox.sent)
+	notice := f.outbox.sent[len(f.outbox.sent)-1]
+	assert.Equal(t, f.user.Email, notice.To)
+	assert.Equal(t, "Your account has been locked", notice.Subject)
+	assert.Contains(t, f.metrics.failures, "account_locked")
+
+	// Once the lock ends, the next failure locks the account for twice as long
+	user, err := f.users.GetByID(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	user.LockedUntil = ptrTo(time.Now().Add(-time.Second))
+	require.NoError(t, f.users.Update(f.ctx, user))
+	_, err = f.loginFrom("10.0.0.1", f.user.Email, "wrong")
+	require.Error(t, err)
+	_, err = f.loginFrom("10.0.0.1", f.user.Email, authTestPassword)
+	require.True(t, errors.As(err, &lockout), "locked again: %v", err)
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), lockout.Until, 5*time.Second)
+
+	admin := uuid.New()
+	require.NoError(t, f.service.UnlockAccount(f.ctx, f.user.ID, admin))
+	_, err = f.loginFrom("10.0.0.1", f.user.Email, authTestPassword)
+	require.NoError(t, err)
+	assert.Equal(t, "password:true", f.metrics.attempts[len(f.metrics.attempts)-1])
+
+	events, err := f.service.ListAuthEvents(f.ctx, f.user.ID, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 7)
+	assert.Equal(t, domain.AuthEventAccountUnlocked, events[0].Type)
+	require.NotNil(t, events[0].ActorID)
+	assert.Equal(t, admin, *events[0].ActorID)
+
+	// A lock is recorded at the moment of the failure that caused it
+	counts := map[domain.AuthEventType]int{}
+	for _, event := range events[1:] {
+		counts[event.Type]++
+		if event.Type == domain.AuthEventAccountLocked {
+			assert.NotNil(t, event.LockedUntil)
+		}
+	}
+	assert.Equal(t, map[domain.AuthEventType]int{domain.AuthEventLoginFailed: 4, domain.AuthEventAccountLocked: 2}, counts)
+
+	// The failures before the login no longer count towards a lock
+	user, err = f.users.GetByID(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.Zero(t, user.FailedLoginAttempts)
+	assert.Nil(t, user.LockedUntil)
+}
+
+func TestAuthService_AddressLockout(t *testing.T) {
+	f := newAuthFixture(t)
+
+	for i := 0; i < 10; i++ {
+		_, err := f.loginFrom("10.0.0.9", fmt.Sprintf("guess%d@example.com", i), "wrong")
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, domain.ErrAccountLocked), "attempt %d: %v", i+1, err)
+	}
+
+	_, err := f.loginFrom("10.0.0.9", f.user.Email, authTestPassword)
+	assert.True(t, errors.Is(err, domain.ErrAccountLocked), "locked out address: %v", err)
+	assert.Contains(t, f.metrics.failures, "address_locked")
+
+	_, err = f.loginFrom("10.0.0.1", f.user.Email, authTestPassword)
+	require.NoError(t, err, "other addresses can still log in")
+}
+
+// signIn writes a SIWE message for a wallet with a fresh nonce and signs
+// it; edit can change the message before it is signed
+func (f *authFixture) signIn(t *testing.T, key *secp256k1.PrivateKey, edit func(*siwe.Message)) (string, string) {
+	t.Helper()
+	challenge, err := f.service.IssueSIWENonce(f.ctx)
+	require.NoError(t, err)
+
+	msg := &siwe.Message{
+		Domain:    challenge.Domain,
+		Address:   siwe.PublicKeyAddress(&key.PublicKey),
+		Statement: "Sign in to GoReal",
+		URI:       challenge.URI,
+		ChainID:   1,
+		Nonce:     challenge.Nonce,
+		IssuedAt:  time.Now().UTC(),
+	}
+	if edit != nil {
+		edit(msg)
+	}
+	text := msg.String()
+	sig, err := siwe.Sign(key, text)
+	require.NoError(t, err)
+	return text, "0x" + hex.EncodeToString(sig)
+}
+
+func TestAuthService_WalletLogin(t *testing.T) {
+	f := newAuthFixture(t)
+	key, err := secp256k1.GenerateKey()
+	require.NoError(t, err)
+	address := siwe.PublicKeyAddress(&key.PublicKey)
+
+	message, signature := f.signIn(t, key, nil)
+	_, err = f.service.LoginWithWallet(f.ctx, message, signature)
+	assert.True(t, errors.Is(err, domain.ErrInvalidCredentials), "wallet not linked yet: %v", err)
+
+	message, signature = f.signIn(t, key, nil)
+	wallet, err := f.service.LinkWallet(f.ctx, f.user.ID, message, signature, "metamask")
+	require.NoError(t, err)
+	assert.Equal(t, address, wallet.Address)
+	assert.True(t, wallet.IsVerified)
+	user, err := f.users.GetByID(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	require.NotNil(t, user.WalletAddress)
+	assert.Equal(t, address, *user.WalletAddress, "first wallet becomes the wallet address")
+
+	_, err = f.service.LoginWithWallet(f.ctx, message, signature)
+	assert.True(t, errors.Is(err, domain.ErrInvalidCredentials), "replayed message: %v", err)
+
+	message, signature = f.signIn(t, key, nil)
+	response, err := f.service.LoginWithWallet(f.ctx, message, signature)
+	require.NoError(t, err)
+	assert.Equal(t, f.user.ID, response.User.ID)
+	assert.NotEmpty(t, response.AccessToken)
+	assert.NotEmpty(t, response.RefreshToken)
+	_, err = f.service.ValidateToken(f.ctx, response.AccessToken)
+	require.NoError(t, err)
+	assert.Contains(t, f.metrics.attempts, "siwe:true")
+
+	wallet, err = f.wallets.GetByAddress(f.ctx, address)
+	require.NoError(t, err)
+	assert.True(t, wallet.IsVerified)
+	assert.NotNil(t, wallet.LastUsed)
+
+	// Another user cannot take the wallet over
+	other := &domain.User{ID: uuid.New(), Email: "ben@example.com", Username: "ben", IsActive: true}
+	require.NoError(t, f.users.Create(f.ctx, other))
+	message, signature = f.signIn(t, key, nil)
+	_, err = f.service.LinkWallet(f.ctx, other.ID, message, signature, "")
+	assert.True(t, errors.Is(err, domain.ErrConflict), "wallet of another user: %v", err)
+
+	require.NoError(t, f.service.UnlinkWallet(f.ctx, f.user.ID, wallet.ID))
+	user, err = f.users.GetByID(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.Nil(t, user.WalletAddress)
+	err = f.service.UnlinkWallet(f.ctx, f.user.ID, wallet.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "unlinked twice: %v", err)
+}
+
+func TestAuthService_WalletLoginRefusals(t *testing.T) {
+	f := newAuthFixture(t)
+	key, err := secp256k1.GenerateKey()
+	require.NoError(t, err)
+	message, signature := f.signIn(t, key, nil)
+	_, err = f.service.LinkWallet(f.ctx, f.user.ID, message, signature, "")
+	require.NoError(t, err)
+
+	impostor, err := secp256k1.GenerateKey()
+	require.NoError(t, err)
+	expired := time.Now().Add(-time.Second)
+
+	cases := map[string]func() (string, string){
+		"other domain": func() (string, string) {
+			return f.signIn(t, key, func(m *siwe.Message) { m.Domain = "evil.example.com" })
+		},
+		"other scheme": func() (string, string) {
+			return f.signIn(t, key, func(m *siwe.Message) { m.Scheme = "http" })
+		},
+		"URI on another origin": func() (string, string) {
+			return f.signIn(t, key, func(m *siwe.Message) { m.URI = "https://evil.example.com/login" })
+		},
+		"chain not allowed": func() (string, string) {
+			return f.signIn(t, key, func(m *siwe.Message) { m.ChainID = 137 })
+		},
+		"expired": func() (string, string) {
+			return f.signIn(t, key, func(m *siwe.Message) { m.ExpirationTime = &expired })
+		},
+		"nonce never issued": func() (string, string) {
+			return f.signIn(t, key, func(m *siwe.Message) { m.Nonce = "0123456789abcdef" })
+		},
+		"signed by another key": func() (string, string) {
+			message, _ := f.signIn(t, key, nil)
+			sig, err := siwe.Sign(impostor, message)
+			require.NoError(t, err)
+			return message, "0x" + hex.EncodeToString(sig)
+		},
+		"malformed signature": func() (string, string) {
+			message, _ := f.signIn(t, key, nil)
+			return message, "0x1234"
+		},
+	}
+	f.cfg.Auth.LockoutThreshold = len(cases)
+	for name, build := range cases {
+		message, signature := build()
+		_, err := f.service.LoginWithWallet(f.ctx, message, signature)
+		assert.True(t, errors.Is(err, domain.ErrInvalidCredentials), "%s: %v", name, err)
+	}
+
+	// The failures count against the account like wrong passwords
+	user, err := f.users.GetByID(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	require.NotNil(t, user.LockedUntil)
+	message, signature = f.signIn(t, key, nil)
+	_, err = f.service.LoginWithWallet(f.ctx, message, signature)
+	assert.True(t, errors.Is(err, domain.ErrAccountLocked), "locked account: %v", err)
+
+	// Users with MFA still have to give a code
+	require.NoError(t, f.service.UnlockAccount(f.ctx, f.user.ID, uuid.New()))
+	user, err = f.users.GetByID(f.ctx, f.user.ID)
+	require.NoError(t, err)
+	user.MFAEnabled = true
+	require.NoError(t, f.users.Update(f.ctx, user))
+	message, signature = f.signIn(t, key, nil)
+	response, err := f.service.LoginWithWallet(f.ctx, message, signature)
+	require.NoError(t, err)
+	assert.True(t, response.MFARequired)
+	assert.Empty(t, response.AccessToken)
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ListSessions returns the devices a user is signed in on, most recently
+// used first
+func (s *authService) ListSessions(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	ctx, span := authTracer.Start(ctx, "authService.ListSessions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	sessions, err := s.sessionRepo.ListActive(ctx, userID, time.Now())
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(sessions)))
+
+	return sessions, nil
+}
+
+// RevokeSession signs a user out of one of their devices
+func (s *authService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	ctx, span := authTracer.Start(ctx, "authService.RevokeSession")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("session.id", sessionID.String()),
+	)
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	// Another user's session is not revealed to exist
+	if session.UserID != userID {
+		err := fmt.Errorf("session %s: %w", sessionID, domain.ErrNotFound)
+		span.RecordError(err)
+		return err
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, sessionID, domain.RevokeReasonUser, time.Now()); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions signs a user out everywhere, for an administrator
+// forcing them to log in again. It returns how many sessions were ended.
+func (s *authService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, span := authTracer.Start(ctx, "authService.RevokeAllSessions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	revoked, err := s.sessionRepo.RevokeByUser(ctx, userID, domain.RevokeReasonAdmin, time.Now())
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("sessions.revoked", revoked))
+
+	return revoked, nil
+}
+
+// startSession signs a user in on the device recorded in ctx and issues the
+// first tokens of the session
+func (s *authService) startSession(ctx context.Context, user *domain.User) (*domain.AuthResponse, error) {
+	sessionID := uuid.New()
+	response, hash, expiresAt, err := s.issueTokens(user, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := domain.ClientInfoFromContext(ctx)
+	now := time.Now()
+	session := &domain.Session{
+		ID:         sessionID,
+		UserID:     user.ID,
+		DeviceName: optionalString(client.DeviceName),
+		UserAgent:  optionalString(client.UserAgent),
+		IPAddress:  optionalString(client.IPAddress),
+		TokenHash:  hash,
+		ExpiresAt:  expiresAt,
+		LastUsedAt: now,
+		CreatedAt:  now,
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return response, nil
+}
+
+// issueTokens creates a new pair of tokens for a session. It returns the hash
+// of the refresh token, to be stored with the session, and when it expires.
+func (s *authService) issueTokens(user *domain.User, sessionID uuid.UUID) (*domain.AuthResponse, string, time.Time, error) {
+	accessToken, expiresIn, err := s.generateAccessToken(user, sessionID)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	refreshToken, expiresAt, err := s.generateRefreshToken(user, sessionID)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	return &domain.AuthResponse{
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    expiresIn,
+	}, hashToken(refreshToken), expiresAt, nil
+}
+
+// tokenSession loads the session a token was issued for
+func (s *authService) tokenSession(ctx context.Context, claims *accessClaims) (*domain.Session, error) {
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("token has no session: %w", domain.ErrInvalidToken)
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("token session does not exist: %w", domain.ErrInvalidToken)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session.UserID.String() != claims.Subject {
+		return nil, fmt.Errorf("token session belongs to another user: %w", domain.ErrInvalidToken)
+	}
+
+	return session, nil
+}
+
+// revokeReused ends a session one of whose refresh tokens was presented
+// after it had been exchanged, and returns the error to report
+func (s *authService) revokeReused(ctx context.Context, sessionID uuid.UUID, at time.Time) error {
+	if err := s.sessionRepo.Revoke(ctx, sessionID, domain.RevokeReasonTokenReuse, at); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return fmt.Errorf("refresh token was already used, session revoked: %w", domain.ErrInvalidToken)
+}
+
+// hashToken returns the SHA-256 of a token, hex encoded, as it is stored
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// optionalString returns nil for a blank string
+func optionalString(s string) *string {
+	if s = strings.TrimSpace(s); s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/pkg/siwe"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// defaultWalletType labels wallets linked without saying which app they are
+const defaultWalletType = "unknown"
+
+// IssueSIWENonce starts a Sign-In with Ethereum: it returns a nonce for the
+// message the wallet signs, along with the domain and URI the message must
+// name and the chains it may be for
+func (s *authService) IssueSIWENonce(ctx context.Context) (*domain.SIWEChallenge, error) {
+	ctx, span := authTracer.Start(ctx, "authService.IssueSIWENonce")
+	defer span.End()
+
+	nonce, err := siwe.GenerateNonce()
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	record := &domain.SIWENonce{
+		ID:        uuid.New(),
+		Nonce:     nonce,
+		ExpiresAt: now.Add(s.config.Auth.SIWENonceExpiry),
+		CreatedAt: now,
+	}
+	if err := s.nonceRepo.Create(ctx, record); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to store nonce: %w", err)
+	}
+
+	return &domain.SIWEChallenge{
+		Domain:    s.config.Auth.SIWEDomain,
+		URI:       s.config.AppURL,
+		ChainIDs:  s.config.Auth.SIWEChainIDs,
+		Nonce:     nonce,
+		ExpiresAt: record.ExpiresAt,
+	}, nil
+}
+
+// LoginWithWallet signs in the user a wallet is linked to, given an
+// EIP-4361 message and the wallet's signature of it. Failures count towards
+// a lockout like wrong passwords, and users with MFA still have to give a
+// code.
+func (s *authService) LoginWithWallet(ctx context.Context, message, signature string) (*domain.AuthResponse, error) {
+	ctx, span := authTracer.Start(signInContext(ctx), "authService.LoginWithWallet")
+	defer span.End()
+
+	now := time.Now()
+	if err := s.checkAddressLockout(ctx, loginMethodSIWE, now); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	msg, err := siwe.Parse(message)
+	if err != nil {
+		span.RecordError(err)
+		s.loginFailed(ctx, nil, "", loginMethodSIWE, now)
+		return nil, fmt.Errorf("%v: %w", err, domain.ErrInvalidCredentials)
+	}
+
+	span.SetAttributes(attribute.String("wallet.address", msg.Address))
+
+	wallet, err := s.walletRepo.GetByAddress(ctx, msg.Address)
+	if err != nil || !wallet.IsActive {
+		err := fmt.Errorf("wallet is not linked to an account: %w", domain.ErrInvalidCredentials)
+		span.RecordError(err)
+		s.loginFailed(ctx, nil, "", loginMethodSIWE, now)
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, wallet.UserID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	if err := s.checkAccountLockout(ctx, user, loginMethodSIWE, now); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := s.verifySIWE(ctx, msg, message, signature, now); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrInvalidCredentials) {
+			s.loginFailed(ctx, user, user.Email, loginMethodSIWE, now)
+		}
+		return nil, err
+	}
+
+	if !user.IsActive {
+		err := domain.ErrAccountInactive
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if s.config.Auth.RequireEmailVerification && user.EmailVerifiedAt == nil {
+		err := domain.ErrEmailNotVerified
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", user.ID.String()),
+		attribute.String("user.role", string(user.Role)),
+		attribute.Bool("user.mfa_enabled", user.MFAEnabled),
+	)
+
+	// The signature proves the user controls the wallet
+	wallet.IsVerified = true
+	wallet.LastUsed = &now
+	if err := s.walletRepo.Update(ctx, wallet); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to update wallet: %w", err)
+	}
+
+	if user.MFAEnabled {
+		response, err := s.mfaChallenge(user)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		return response, nil
+	}
+
+	response, err := s.completeLogin(ctx, user, loginMethodSIWE)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// LinkWallet adds a wallet to a user's account, given an EIP-4361 message
+// signed by it. The wallet becomes the user's wallet address if they have
+// none yet.
+func (s *authService) LinkWallet(ctx context.Context, userID uuid.UUID, message, signature, walletType string) (*domain.Wallet, error) {
+	ctx, span := authTracer.Start(ctx, "authService.LinkWallet")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	now := time.Now()
+	msg, err := siwe.Parse(message)
+	if err == nil {
+		err = s.verifySIWE(ctx, msg, message, signature, now)
+	}
+	if errors.Is(err, domain.ErrInvalidCredentials) || errors.Is(err, siwe.ErrInvalidMessage) {
+		err = fmt.Errorf("wallet signature not accepted: %v: %w", err, domain.ErrInvalidInput)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("wallet.address", msg.Address))
+
+	wallet, err := s.walletRepo.GetByAddress(ctx, msg.Address)
+	switch {
+	case err == nil && wallet.UserID != userID:
+		err := fmt.Errorf("wallet is linked to another account: %w", domain.ErrConflict)
+		span.RecordError(err)
+		return nil, err
+	case err == nil:
+		// Linking a wallet again verifies it again
+		wallet.IsActive = true
+		wallet.IsVerified = true
+		wallet.LastUsed = &now
+		if err := s.walletRepo.Update(ctx, wallet); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to update wallet: %w", err)
+		}
+		return wallet, nil
+	case !errors.Is(err, domain.ErrNotFound):
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to look up wallet: %w", err)
+	}
+
+	if walletType == "" {
+		walletType = defaultWalletType
+	}
+	wallet = &domain.Wallet{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Address:    msg.Address,
+		WalletType: walletType,
+		IsActive:   true,
+		IsVerified: true,
+		LastUsed:   &now,
+		CreatedAt:  now,
+	}
+	if err := s.walletRepo.Create(ctx, wallet); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, fmt.Errorf("wallet is already linked: %w", domain.ErrConflict)
+		}
+		return nil, fmt.Errorf("failed to link wallet: %w", err)
+	}
+
+	if user.WalletAddress == nil {
+		user.WalletAddress = &wallet.Address
+		user.UpdatedAt = now
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to set wallet address: %w", err)
+		}
+	}
+
+	return wallet, nil
+}
+
+// ListWallets returns the wallets linked to a user's account, oldest first
+func (s *authService) ListWallets(ctx context.Context, userID uuid.UUID) ([]*domain.Wallet, error) {
+	ctx, span := authTracer.Start(ctx, "authService.ListWallets")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+
+	wallets, err := s.walletRepo.ListByUser(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list wallets: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(wallets)))
+
+	return wallets, nil
+}
+
+// UnlinkWallet removes a wallet from a user's account. If it was the user's
+// wallet address, their next oldest wallet takes its place.
+func (s *authService) UnlinkWallet(ctx context.Context, userID, walletID uuid.UUID) error {
+	ctx, span := authTracer.Start(ctx, "authService.UnlinkWallet")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("wallet.id", walletID.String()),
+	)
+
+	wallets, err := s.walletRepo.ListByUser(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to list wallets: %w", err)
+	}
+
+	// Only the user's own wallets can be unlinked; anyone else's is
+	// reported as not found
+	var unlinked *domain.Wallet
+	var remaining []*domain.Wallet
+	for _, wallet := range wallets {
+		if wallet.ID == walletID {
+			unlinked = wallet
+		} else {
+			remaining = append(remaining, wallet)
+		}
+	}
+	if unlinked == nil {
+		err := fmt.Errorf("wallet not found: %w", domain.ErrNotFound)
+		span.RecordError(err)
+		return err
+	}
+
+	if err := s.walletRepo.Delete(ctx, walletID); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to unlink wallet: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("user not found: %w", err)
+	}
+	if user.WalletAddress != nil && *user.WalletAddress == unlinked.Address {
+		user.WalletAddress = nil
+		if len(remaining) > 0 {
+			user.WalletAddress = &remaining[0].Address
+		}
+		user.UpdatedAt = time.Now()
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to update wallet address: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// verifySIWE checks a parsed message for this site and an allowed chain,
+// its time bounds and its signature, then redeems its nonce so it cannot be
+// replayed. A message or
+// signature that is not accepted is ErrInvalidCredentials.
+func (s *authService) verifySIWE(ctx context.Context, msg *siwe.Message, message, signature string, now time.Time) error {
+	expected := siwe.Expected{
+		Domain:   s.config.Auth.SIWEDomain,
+		Origin:   s.config.AppURL,
+		ChainIDs: s.config.Auth.SIWEChainIDs,
+	}
+	if err := msg.Validate(expected, now); err != nil {
+		return fmt.Errorf("%v: %w", err, domain.ErrInvalidCredentials)
+	}
+
+	sig, err := siwe.DecodeSignature(signature)
+	if err != nil {
+		return fmt.Errorf("%v: %w", err, domain.ErrInvalidCredentials)
+	}
+	signer, err := siwe.RecoverAddress(message, sig)
+	if err != nil {
+		return fmt.Errorf("%v: %w", err, domain.ErrInvalidCredentials)
+	}
+	if signer != msg.Address {
+		return fmt.Errorf("message was not signed by %s: %w", msg.Address, domain.ErrInvalidCredentials)
+	}
+
+	// The nonce is only spent once the signature is known to be good
+	err = s.nonceRepo.Consume(ctx, msg.Nonce, now)
+	if errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("nonce was not issued, has expired or was already used: %w", domain.ErrInvalidCredentials)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to redeem nonce: %w", err)
+	}
+	return nil
+}
@@ -169,6 +169,12 @@ func (s *clientService) Update(ctx context.Context, id uuid.UUID, req *domain.Up
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
+	// Reject stale edits before touching anything
+	if err := domain.CheckVersion(req.Version, client.UpdatedAt); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	// Update fields
 	if req.Name != nil {
 		client.Name = *req.Name
@@ -220,9 +226,6 @@ func (s *clientService) Update(ctx context.Context, id uuid.UUID, req *domain.Up
 	// Note: Client struct doesn't have Notes field
 	// Notes could be stored in CustomFields or a separate notes system
 
-	// Update timestamp
-	client.UpdatedAt = time.Now()
-
 	if err := s.clientRepo.Update(ctx, client); err != nil {
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to update client: %w", err)
@@ -231,14 +234,14 @@ func (s *clientService) Update(ctx context.Context, id uuid.UUID, req *domain.Up
 	return client, nil
 }
 
-// Delete deletes a client
-func (s *clientService) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete moves a client to the trash
+func (s *clientService) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
 	ctx, span := clientTracer.Start(ctx, "clientService.Delete")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("client.id", id.String()))
 
-	if err := s.clientRepo.Delete(ctx, id); err != nil {
+	if err := s.clientRepo.Delete(ctx, id, deletedBy); err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to delete client: %w", err)
 	}
@@ -246,6 +249,27 @@ func (s *clientService) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// Restore takes a client out of the trash
+func (s *clientService) Restore(ctx context.Context, id uuid.UUID) (*domain.Client, error) {
+	ctx, span := clientTracer.Start(ctx, "clientService.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("client.id", id.String()))
+
+	if err := s.clientRepo.Restore(ctx, id); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to restore client: %w", err)
+	}
+
+	client, err := s.clientRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get restored client: %w", err)
+	}
+
+	return client, nil
+}
+
 // List retrieves clients with pagination and filtering
 func (s *clientService) List(ctx context.Context, filters domain.ClientFilters) ([]*domain.Client, error) {
 	ctx, span := clientTracer.Start(ctx, "clientService.List")
@@ -344,7 +368,6 @@ func (s *clientService) AssignToUser(ctx context.Context, clientID, userID uuid.
 
 	// Update assignment
 	client.AssignedTo = &userID
-	client.UpdatedAt = time.Now()
 
 	if err := s.clientRepo.Update(ctx, client); err != nil {
 		span.RecordError(err)
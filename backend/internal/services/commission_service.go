@@ -0,0 +1,550 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var commissionTracer = otel.Tracer("goreal-backend/services/commission")
+
+// commissionPeriod is the layout of report periods: calendar months
+const commissionPeriod = "2006-01"
+
+type commissionService struct {
+	commissionRepo domain.CommissionRepository
+	planRepo       domain.CommissionPlanRepository
+	saleRepo       domain.SaleRepository
+	userRepo       domain.UserRepository
+	uow            domain.UnitOfWork
+}
+
+// NewCommissionService creates a new commission service
+func NewCommissionService(
+	commissionRepo domain.CommissionRepository,
+	planRepo domain.CommissionPlanRepository,
+	saleRepo domain.SaleRepository,
+	userRepo domain.UserRepository,
+	uow domain.UnitOfWork,
+) domain.CommissionService {
+	return &commissionService{
+		commissionRepo: commissionRepo,
+		planRepo:       planRepo,
+		saleRepo:       saleRepo,
+		userRepo:       userRepo,
+		uow:            uow,
+	}
+}
+
+// CalculateCommissions creates the commissions earned on an approved or
+// completed sale under the plan in effect. The sale's own commission rate,
+// if set, replaces the plan's rate; the plan still decides the manager's
+// share. A sale is only paid commission once, so calling it again returns
+// the commissions already created.
+func (s *commissionService) CalculateCommissions(ctx context.Context, saleID uuid.UUID) ([]*domain.Commission, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionService.CalculateCommissions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	var created []*domain.Commission
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		sale, err := s.saleRepo.GetByID(ctx, saleID)
+		if err != nil {
+			return fmt.Errorf("failed to get sale: %w", err)
+		}
+		if sale.Status != domain.SaleStatusApproved && sale.Status != domain.SaleStatusCompleted {
+			return fmt.Errorf("commission is only earned on approved or completed sales: %w", domain.ErrValidationFailed)
+		}
+
+		existing, err := s.commissionRepo.GetBySale(ctx, sale.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get commissions: %w", err)
+		}
+		for _, commission := range existing {
+			if commission.CommissionType != domain.CommissionTypeClawback {
+				created = append(created, commission)
+			}
+		}
+		if len(created) > 0 {
+			return nil
+		}
+
+		plan, err := s.planRepo.GetLatest(ctx)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("failed to get commission plan: %w", err)
+		}
+		if plan == nil && sale.CommissionRate == nil {
+			return nil
+		}
+		if sale.SalespersonID == nil && sale.ManagerID == nil {
+			return nil
+		}
+
+		rate, managerShare := 0.0, 0.0
+		var planID *uuid.UUID
+		if plan != nil {
+			planID, managerShare = &plan.ID, plan.ManagerShare
+			volume, err := s.monthlyVolume(ctx, sale)
+			if err != nil {
+				return err
+			}
+			rate = plan.RateFor(volume)
+		}
+		if sale.CommissionRate != nil {
+			rate = *sale.CommissionRate
+		}
+		if sale.ManagerID == nil {
+			managerShare = 0
+		}
+		if sale.SalespersonID == nil {
+			managerShare = 100
+		}
+
+		span.SetAttributes(
+			attribute.Float64("commission.rate", rate),
+			attribute.Float64("commission.manager_share", managerShare),
+		)
+
+		primary, manager := domain.SplitCommission(sale.FinalAmount, rate, managerShare)
+		shares := []struct {
+			employee *uuid.UUID
+			kind     string
+			amount   float64
+			rate     float64
+		}{
+			{sale.SalespersonID, domain.CommissionTypePrimary, primary, rate * (100 - managerShare) / 100},
+			{sale.ManagerID, domain.CommissionTypeManager, manager, rate * managerShare / 100},
+		}
+		for _, share := range shares {
+			if share.employee == nil || share.amount <= 0 {
+				continue
+			}
+			shareRate := math.Round(share.rate*100) / 100
+			commission := &domain.Commission{
+				ID:               uuid.New(),
+				SaleID:           sale.ID,
+				PlanID:           planID,
+				EmployeeID:       share.employee,
+				CommissionType:   share.kind,
+				CommissionRate:   &shareRate,
+				CommissionAmount: share.amount,
+				Status:           domain.PaymentStatusPending,
+			}
+			if err := s.commissionRepo.Create(ctx, commission); err != nil {
+				return fmt.Errorf("failed to create %s commission: %w", share.kind, err)
+			}
+			created = append(created, commission)
+		}
+
+		total := primary + manager
+		sale.CommissionRate = &rate
+		sale.CommissionAmount = &total
+		if err := s.saleRepo.Update(ctx, sale); err != nil {
+			return fmt.Errorf("failed to record commission on sale: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(created)))
+
+	return created, nil
+}
+
+// monthlyVolume is the final amount of the approved and completed sales of
+// a sale's salesperson in the calendar month of the sale, the sale included
+func (s *commissionService) monthlyVolume(ctx context.Context, sale *domain.Sale) (float64, error) {
+	if sale.SalespersonID == nil {
+		return sale.FinalAmount, nil
+	}
+
+	from := time.Date(sale.SaleDate.Year(), sale.SaleDate.Month(), 1, 0, 0, 0, 0, sale.SaleDate.Location())
+	to := from.AddDate(0, 1, 0).Add(-time.Nanosecond)
+
+	volume := int64(0)
+	for _, status := range []domain.SaleStatus{domain.SaleStatusApproved, domain.SaleStatusCompleted} {
+		status := status
+		sales, err := s.saleRepo.List(ctx, domain.SaleFilters{
+			SalespersonID: sale.SalespersonID,
+			Status:        &status,
+			SaleDateFrom:  &from,
+			SaleDateTo:    &to,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get monthly sales: %w", err)
+		}
+		for _, other := range sales {
+			volume += int64(math.Round(other.FinalAmount * 100))
+		}
+	}
+	return float64(volume) / 100, nil
+}
+
+// ClawBackCommissions takes back commission on a cancelled or refunded
+// sale. A full clawback cancels the commissions not yet paid. When the
+// plan they were earned under claws back, the paid part, or for partial
+// refunds the refunded share of everything earned, is recovered as
+// negative clawback commissions that are set off against later payouts.
+func (s *commissionService) ClawBackCommissions(ctx context.Context, saleID uuid.UUID, req *domain.ClawbackRequest) ([]*domain.Commission, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionService.ClawBackCommissions")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("sale.id", saleID.String()),
+		attribute.Float64("clawback.amount", req.Amount),
+	)
+
+	if req.Amount < 0 {
+		return nil, fmt.Errorf("refunded amount cannot be negative: %w", domain.ErrInvalidInput)
+	}
+
+	var commissions []*domain.Commission
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		sale, err := s.saleRepo.GetByID(ctx, saleID)
+		if err != nil {
+			return fmt.Errorf("failed to get sale: %w", err)
+		}
+		fraction := 1.0
+		if req.Amount > 0 && req.Amount < sale.FinalAmount {
+			fraction = req.Amount / sale.FinalAmount
+		}
+
+		commissions, err = s.commissionRepo.GetBySale(ctx, sale.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get commissions: %w", err)
+		}
+
+		var reason *string
+		if trimmed := strings.TrimSpace(req.Reason); trimmed != "" {
+			reason = &trimmed
+		}
+
+		var planID *uuid.UUID
+		earned := map[uuid.UUID]int64{}
+		recovered := map[uuid.UUID]int64{}
+		var employees []uuid.UUID
+		for _, commission := range commissions {
+			if commission.EmployeeID == nil || commission.Status == domain.PaymentStatusCancelled {
+				continue
+			}
+			if planID == nil {
+				planID = commission.PlanID
+			}
+			// Nothing unpaid survives a full clawback, earlier clawbacks
+			// included; the paid part is recovered below
+			if fraction == 1 && commission.Status == domain.PaymentStatusPending {
+				commission.Status = domain.PaymentStatusCancelled
+				if reason != nil {
+					commission.Notes = reason
+				}
+				if err := s.commissionRepo.Update(ctx, commission); err != nil {
+					return fmt.Errorf("failed to cancel commission: %w", err)
+				}
+				continue
+			}
+
+			employee := *commission.EmployeeID
+			cents := int64(math.Round(commission.CommissionAmount * 100))
+			if commission.CommissionType == domain.CommissionTypeClawback {
+				recovered[employee] -= cents
+				continue
+			}
+			if _, ok := earned[employee]; !ok {
+				employees = append(employees, employee)
+			}
+			earned[employee] += cents
+		}
+
+		if planID == nil {
+			return nil
+		}
+		plan, err := s.planRepo.GetByID(ctx, *planID)
+		if err != nil {
+			return fmt.Errorf("failed to get commission plan: %w", err)
+		}
+		if !plan.Clawback {
+			return nil
+		}
+
+		for _, employee := range employees {
+			owed := int64(math.Round(float64(earned[employee]) * fraction))
+			if remaining := earned[employee] - recovered[employee]; owed > remaining {
+				owed = remaining
+			}
+			if owed <= 0 {
+				continue
+			}
+			employee := employee
+			clawback := &domain.Commission{
+				ID:               uuid.New(),
+				SaleID:           sale.ID,
+				PlanID:           planID,
+				EmployeeID:       &employee,
+				CommissionType:   domain.CommissionTypeClawback,
+				CommissionAmount: -float64(owed) / 100,
+				Status:           domain.PaymentStatusPending,
+				Notes:            reason,
+			}
+			if err := s.commissionRepo.Create(ctx, clawback); err != nil {
+				return fmt.Errorf("failed to create clawback: %w", err)
+			}
+			commissions = append(commissions, clawback)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return commissions, nil
+}
+
+// PayCommission settles a pending commission in full. Clawbacks are settled
+// the same way, with their negative amount.
+func (s *commissionService) PayCommission(ctx context.Context, commissionID uuid.UUID, req *domain.PayCommissionRequest) error {
+	ctx, span := commissionTracer.Start(ctx, "commissionService.PayCommission")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("commission.id", commissionID.String()))
+
+	if strings.TrimSpace(req.PaymentMethod) == "" {
+		return fmt.Errorf("payment method is required: %w", domain.ErrInvalidInput)
+	}
+
+	commission, err := s.commissionRepo.GetByID(ctx, commissionID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to get commission: %w", err)
+	}
+	if commission.Status != domain.PaymentStatusPending {
+		return fmt.Errorf("commission is %s, not pending: %w", commission.Status, domain.ErrConflict)
+	}
+	if math.Round(req.Amount*100) != math.Round(commission.CommissionAmount*100) {
+		return fmt.Errorf("payment of %.2f does not match the commission of %.2f: %w",
+			req.Amount, commission.CommissionAmount, domain.ErrInvalidInput)
+	}
+
+	paidDate := time.Now()
+	if req.PaymentDate != nil {
+		paidDate = *req.PaymentDate
+	}
+	commission.Status = domain.PaymentStatusPaid
+	commission.PaidDate = &paidDate
+	commission.PaymentReference = req.Reference
+	if req.Notes != nil {
+		commission.Notes = req.Notes
+	}
+
+	if err := s.commissionRepo.Update(ctx, commission); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to pay commission: %w", err)
+	}
+
+	return nil
+}
+
+// ListCommissions retrieves commissions with pagination and filtering
+func (s *commissionService) ListCommissions(ctx context.Context, filters domain.CommissionFilters) ([]*domain.Commission, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionService.ListCommissions")
+	defer span.End()
+
+	commissions, err := s.commissionRepo.List(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list commissions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(commissions)))
+
+	return commissions, nil
+}
+
+// GetCommissionReport totals the commissions matching filters per employee
+// and calendar month, by period and then employee name. Clawbacks count
+// against the total; cancelled commissions are left out.
+func (s *commissionService) GetCommissionReport(ctx context.Context, filters domain.CommissionFilters) ([]*domain.CommissionReport, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionService.GetCommissionReport")
+	defer span.End()
+
+	filters.BaseFilters = domain.BaseFilters{}
+	commissions, err := s.commissionRepo.List(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list commissions: %w", err)
+	}
+
+	type key struct {
+		employee uuid.UUID
+		period   string
+	}
+	reports := map[key]*domain.CommissionReport{}
+	counted := map[key]map[uuid.UUID]bool{}
+	names := map[uuid.UUID]string{}
+	amounts := map[uuid.UUID]float64{}
+
+	for _, commission := range commissions {
+		if commission.EmployeeID == nil || commission.Status == domain.PaymentStatusCancelled {
+			continue
+		}
+		k := key{*commission.EmployeeID, commission.CreatedAt.Format(commissionPeriod)}
+		report, ok := reports[k]
+		if !ok {
+			name, err := s.employeeName(ctx, k.employee, names)
+			if err != nil {
+				span.RecordError(err)
+				return nil, err
+			}
+			report = &domain.CommissionReport{UserID: k.employee, UserName: name, Period: k.period}
+			reports[k] = report
+			counted[k] = map[uuid.UUID]bool{}
+		}
+
+		amount := commission.CommissionAmount
+		report.TotalCommission += amount
+		switch commission.Status {
+		case domain.PaymentStatusPaid:
+			report.PaidCommission += amount
+		default:
+			report.PendingCommission += amount
+		}
+		if commission.CommissionType == domain.CommissionTypeClawback {
+			report.ClawbackCommission += amount
+			continue
+		}
+
+		if counted[k][commission.SaleID] {
+			continue
+		}
+		counted[k][commission.SaleID] = true
+		finalAmount, err := s.saleAmount(ctx, commission.SaleID, amounts)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		report.TotalSales += finalAmount
+		report.SalesCount++
+	}
+
+	result := make([]*domain.CommissionReport, 0, len(reports))
+	for _, report := range reports {
+		report.TotalCommission = roundAmount(report.TotalCommission)
+		report.PaidCommission = roundAmount(report.PaidCommission)
+		report.PendingCommission = roundAmount(report.PendingCommission)
+		report.ClawbackCommission = roundAmount(report.ClawbackCommission)
+		result = append(result, report)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Period != result[j].Period {
+			return result[i].Period < result[j].Period
+		}
+		if result[i].UserName != result[j].UserName {
+			return result[i].UserName < result[j].UserName
+		}
+		return result[i].UserID.String() < result[j].UserID.String()
+	})
+
+	span.SetAttributes(attribute.Int("result.count", len(result)))
+
+	return result, nil
+}
+
+// employeeName looks up the name of an employee once per report
+func (s *commissionService) employeeName(ctx context.Context, id uuid.UUID, names map[uuid.UUID]string) (string, error) {
+	if name, ok := names[id]; ok {
+		return name, nil
+	}
+	user, err := s.userRepo.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		names[id] = ""
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get employee: %w", err)
+	}
+	names[id] = user.FullName
+	return user.FullName, nil
+}
+
+// saleAmount looks up the final amount of a sale once per report
+func (s *commissionService) saleAmount(ctx context.Context, id uuid.UUID, amounts map[uuid.UUID]float64) (float64, error) {
+	if amount, ok := amounts[id]; ok {
+		return amount, nil
+	}
+	sale, err := s.saleRepo.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		amounts[id] = 0
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sale: %w", err)
+	}
+	amounts[id] = sale.FinalAmount
+	return sale.FinalAmount, nil
+}
+
+// SaveCommissionPlan validates a plan and puts it into effect
+func (s *commissionService) SaveCommissionPlan(ctx context.Context, req *domain.SaveCommissionPlanRequest) (*domain.CommissionPlan, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionService.SaveCommissionPlan")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("commission_plan.type", string(req.Type)))
+
+	plan := &domain.CommissionPlan{
+		ID:           uuid.New(),
+		Name:         strings.TrimSpace(req.Name),
+		Type:         req.Type,
+		Rate:         req.Rate,
+		Slabs:        req.Slabs,
+		ManagerShare: req.ManagerShare,
+		Clawback:     req.Clawback,
+		CreatedBy:    req.CreatedBy,
+	}
+	if plan.Type == domain.CommissionPlanTiered {
+		plan.Rate = 0
+	}
+	if err := plan.Validate(); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := s.planRepo.Create(ctx, plan); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create commission plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// ListCommissionPlans returns the saved plans, the one in effect first
+func (s *commissionService) ListCommissionPlans(ctx context.Context) ([]*domain.CommissionPlan, error) {
+	ctx, span := commissionTracer.Start(ctx, "commissionService.ListCommissionPlans")
+	defer span.End()
+
+	plans, err := s.planRepo.List(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list commission plans: %w", err)
+	}
+
+	return plans, nil
+}
+
+// roundAmount rounds a sum of amounts to the cent
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommissionService_TieredSplitAndClawback(t *testing.T) {
+	f := newSalesFixture(t)
+
+	_, err := f.commissions.SaveCommissionPlan(f.ctx, &domain.SaveCommissionPlanRequest{
+		Name:  "Unordered",
+		Type:  domain.CommissionPlanTiered,
+		Slabs: []domain.CommissionSlab{{MinVolume: 0, Rate: 2}, {MinVolume: 0, Rate: 3}},
+	})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "slabs out of order: %v", err)
+
+	plan, err := f.commissions.SaveCommissionPlan(f.ctx, &domain.SaveCommissionPlanRequest{
+		Name:         "Volume bonus",
+		Type:         domain.CommissionPlanTiered,
+		Slabs:        []domain.CommissionSlab{{MinVolume: 0, Rate: 1}, {MinVolume: 400000, Rate: 2}},
+		ManagerShare: 25,
+		Clawback:     true,
+		CreatedBy:    f.manager.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = f.commissions.CalculateCommissions(f.ctx, f.sale.ID)
+	assert.True(t, errors.Is(err, domain.ErrValidationFailed), "pending sales earn nothing: %v", err)
+
+	sale, err := f.sales.GetByID(f.ctx, f.sale.ID)
+	require.NoError(t, err)
+	sale.ManagerID = &f.manager.ID
+	require.NoError(t, f.sales.Update(f.ctx, sale))
+
+	// The sale alone reaches the second slab, so 2% of 500000 is split 75/25
+	require.NoError(t, f.service.ApproveSale(f.ctx, f.sale.ID, f.manager.ID))
+	commissions, err := f.service.CalculateCommission(f.ctx, f.sale.ID)
+	require.NoError(t, err)
+	require.Len(t, commissions, 2, "approving calculated them once")
+
+	byType := map[string]*domain.Commission{}
+	for _, c := range commissions {
+		byType[c.CommissionType] = c
+		assert.Equal(t, &plan.ID, c.PlanID)
+	}
+	assert.Equal(t, 7500.0, byType[domain.CommissionTypePrimary].CommissionAmount)
+	assert.Equal(t, f.employee.ID, *byType[domain.CommissionTypePrimary].EmployeeID)
+	assert.Equal(t, 2500.0, byType[domain.CommissionTypeManager].CommissionAmount)
+	assert.Equal(t, f.manager.ID, *byType[domain.CommissionTypeManager].EmployeeID)
+
+	sale, err = f.service.GetByID(f.ctx, f.sale.ID)
+	require.NoError(t, err)
+	require.NotNil(t, sale.CommissionAmount)
+	assert.Equal(t, 10000.0, *sale.CommissionAmount)
+
+	primary := byType[domain.CommissionTypePrimary]
+	err = f.commissions.PayCommission(f.ctx, primary.ID, &domain.PayCommissionRequest{Amount: 7000, PaymentMethod: "bank_transfer"})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "partial payment: %v", err)
+	require.NoError(t, f.commissions.PayCommission(f.ctx, primary.ID,
+		&domain.PayCommissionRequest{Amount: 7500, PaymentMethod: "bank_transfer"}))
+
+	// A 10% refund claws back 10% of what each of them earned
+	commissions, err = f.commissions.ClawBackCommissions(f.ctx, f.sale.ID, &domain.ClawbackRequest{Amount: 50000, Reason: "Refund"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"primary": 7500, "manager": 2500, "clawback": -1000}, totalsByType(commissions))
+
+	// Cancelling cancels everything unpaid and recovers the rest
+	require.NoError(t, f.service.CancelSale(f.ctx, f.sale.ID, f.manager.ID, "Buyer withdrew"))
+	commissions, err = f.commissions.ListCommissions(f.ctx, domain.CommissionFilters{SaleID: &f.sale.ID})
+	require.NoError(t, err)
+	live := []*domain.Commission{}
+	for _, c := range commissions {
+		if c.Status != domain.PaymentStatusCancelled {
+			live = append(live, c)
+		}
+	}
+	assert.Equal(t, map[string]float64{"primary": 7500, "clawback": -7500}, totalsByType(live))
+
+	reports, err := f.commissions.GetCommissionReport(f.ctx, domain.CommissionFilters{})
+	require.NoError(t, err)
+	require.Len(t, reports, 1, "the manager's commission was cancelled")
+	report := reports[0]
+	assert.Equal(t, f.employee.ID, report.UserID)
+	assert.Equal(t, "employee", report.UserName)
+	assert.Equal(t, 0.0, report.TotalCommission)
+	assert.Equal(t, 7500.0, report.PaidCommission)
+	assert.Equal(t, -7500.0, report.PendingCommission)
+	assert.Equal(t, -7500.0, report.ClawbackCommission)
+	assert.Equal(t, 500000.0, report.TotalSales)
+	assert.Equal(t, 1, report.SalesCount)
+}
+
+func TestCommissionService_SaleRateWithoutClawback(t *testing.T) {
+	f := newSalesFixture(t)
+
+	_, err := f.commissions.SaveCommissionPlan(f.ctx, &domain.SaveCommissionPlanRequest{
+		Name: "Flat", Type: domain.CommissionPlanFlat, Rate: 2, ManagerShare: 50, CreatedBy: f.manager.ID,
+	})
+	require.NoError(t, err)
+
+	rate := 3.0
+	_, err = f.service.Update(f.ctx, f.sale.ID, &domain.UpdateSaleRequest{CommissionRate: &rate})
+	require.NoError(t, err)
+	require.NoError(t, f.service.ApproveSale(f.ctx, f.sale.ID, f.manager.ID))
+
+	// Without a manager the salesperson keeps the whole commission
+	commissions, err := f.commissions.CalculateCommissions(f.ctx, f.sale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"primary": 15000}, totalsByType(commissions))
+
+	commissions, err = f.commissions.ClawBackCommissions(f.ctx, f.sale.ID, &domain.ClawbackRequest{Amount: 100000})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"primary": 15000}, totalsByType(commissions), "the plan does not claw back")
+}
+
+func totalsByType(commissions []*domain.Commission) map[string]float64 {
+	totals := map[string]float64{}
+	for _, c := range commissions {
+		totals[c.CommissionType] += c.CommissionAmount
+	}
+	return totals
+}
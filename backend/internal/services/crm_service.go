@@ -23,6 +23,7 @@ type leadService struct {
 	userRepo   domain.UserRepository
 	taskRepo   domain.TaskRepository
 	followUpRepo domain.FollowUpRepository
+	uow        domain.UnitOfWork
 	notificationService domain.NotificationService
 }
 
@@ -34,6 +35,7 @@ func NewLeadService(
 	userRepo domain.UserRepository,
 	taskRepo domain.TaskRepository,
 	followUpRepo domain.FollowUpRepository,
+	uow domain.UnitOfWork,
 	notificationService domain.NotificationService,
 ) domain.LeadService {
 	return &leadService{
@@ -43,6 +45,7 @@ func NewLeadService(
 		userRepo:            userRepo,
 		taskRepo:            taskRepo,
 		followUpRepo:        followUpRepo,
+		uow:                 uow,
 		notificationService: notificationService,
 	}
 }
@@ -147,6 +150,12 @@ func (s *leadService) Update(ctx context.Context, id uuid.UUID, req *domain.Upda
 		return nil, fmt.Errorf("failed to get lead: %w", err)
 	}
 
+	// Reject stale edits before touching anything
+	if err := domain.CheckVersion(req.Version, lead.UpdatedAt); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	// Update fields
 	if req.Name != nil {
 		lead.Name = *req.Name
@@ -225,8 +234,6 @@ func (s *leadService) Update(ctx context.Context, id uuid.UUID, req *domain.Upda
 		return nil, fmt.Errorf("budget minimum cannot be greater than maximum")
 	}
 
-	lead.UpdatedAt = time.Now()
-
 	// Save to repository
 	if err := s.leadRepo.Update(ctx, lead); err != nil {
 		span.RecordError(err)
@@ -237,11 +244,11 @@ func (s *leadService) Update(ctx context.Context, id uuid.UUID, req *domain.Upda
 	return lead, nil
 }
 
-func (s *leadService) Delete(ctx context.Context, id uuid.UUID) error {
+func (s *leadService) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
 	ctx, span := tracer.Start(ctx, "leadService.Delete")
 	defer span.End()
 
-	if err := s.leadRepo.Delete(ctx, id); err != nil {
+	if err := s.leadRepo.Delete(ctx, id, deletedBy); err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to delete lead: %w", err)
 	}
@@ -250,6 +257,26 @@ func (s *leadService) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (s *leadService) Restore(ctx context.Context, id uuid.UUID) (*domain.Lead, error) {
+	ctx, span := tracer.Start(ctx, "leadService.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("lead.id", id.String()))
+
+	if err := s.leadRepo.Restore(ctx, id); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to restore lead: %w", err)
+	}
+
+	lead, err := s.leadRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get restored lead: %w", err)
+	}
+
+	return lead, nil
+}
+
 func (s *leadService) List(ctx context.Context, filters domain.LeadFilters) ([]*domain.Lead, error) {
 	ctx, span := tracer.Start(ctx, "leadService.List")
 	defer span.End()
@@ -297,7 +324,6 @@ func (s *leadService) AssignToUser(ctx context.Context, leadID, userID uuid.UUID
 
 	// Update assignment
 	lead.AssignedTo = &userID
-	lead.UpdatedAt = time.Now()
 
 	if err := s.leadRepo.Update(ctx, lead); err != nil {
 		span.RecordError(err)
@@ -352,18 +378,21 @@ func (s *leadService) ConvertToClient(ctx context.Context, leadID uuid.UUID, req
 		UpdatedAt:        time.Now(),
 	}
 
-	// Save client
-	if err := s.clientRepo.Create(ctx, client); err != nil {
-		span.RecordError(err)
-		return nil, fmt.Errorf("failed to create client: %w", err)
-	}
+	// Save client and mark the lead converted together
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.clientRepo.Create(ctx, client); err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
 
-	// Update lead status to converted
-	lead.Status = domain.LeadStatusConverted
-	lead.UpdatedAt = time.Now()
-	if err := s.leadRepo.Update(ctx, lead); err != nil {
+		lead.Status = domain.LeadStatusConverted
+		if err := s.leadRepo.Update(ctx, lead); err != nil {
+			return fmt.Errorf("failed to update lead status: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to update lead status: %w", err)
+		return nil, err
 	}
 
 	span.SetAttributes(
@@ -393,7 +422,6 @@ func (s *leadService) BulkAssign(ctx context.Context, leadIDs []uuid.UUID, userI
 		}
 
 		lead.AssignedTo = &userID
-		lead.UpdatedAt = time.Now()
 
 		if err := s.leadRepo.Update(ctx, lead); err != nil {
 			span.RecordError(err)
@@ -455,7 +483,6 @@ func (s *leadService) UpdateScore(ctx context.Context, leadID uuid.UUID, score i
 
 	// Update score
 	lead.Score = score
-	lead.UpdatedAt = time.Now()
 
 	if err := s.leadRepo.Update(ctx, lead); err != nil {
 		span.RecordError(err)
@@ -501,7 +528,6 @@ func (s *leadService) ScheduleFollowUp(ctx context.Context, leadID uuid.UUID, re
 
 	// Update lead's next follow-up date
 	lead.NextFollowUp = &req.FollowUpDate
-	lead.UpdatedAt = time.Now()
 
 	if err := s.leadRepo.Update(ctx, lead); err != nil {
 		span.RecordError(err)
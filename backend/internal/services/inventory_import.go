@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// importColumns are the columns an inventory import may have. Only
+// unit_number is required.
+var importColumns = map[string]bool{
+	"unit_number": true, "floor_number": true, "tower_block": true, "unit_type": true,
+	"carpet_area": true, "built_up_area": true, "super_built_up_area": true, "facing": true,
+	"base_price": true, "price_per_sqft": true, "parking_slots": true, "balconies": true,
+	"bathrooms": true, "features": true, "floor_plan_url": true,
+}
+
+// ImportInventory adds the units of a spreadsheet to a project. Units
+// with a base price or a price per square foot keep it; the rest are
+// priced from the project's price list in effect. Every row is checked
+// before any unit is created, and the problems are returned in the result
+// rather than as an error: when there are any, or on a dry run, nothing is
+// saved.
+func (s *propertyService) ImportInventory(ctx context.Context, req *domain.InventoryImportRequest) (*domain.InventoryImportResult, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.ImportInventory")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project.id", req.ProjectID.String()),
+		attribute.Bool("dry_run", req.DryRun),
+	)
+
+	if len(req.Rows) == 0 {
+		return nil, fmt.Errorf("the file has no header row: %w", domain.ErrInvalidInput)
+	}
+	if len(req.Rows)-1 > domain.MaxImportRows {
+		return nil, fmt.Errorf("imports are limited to %d units: %w", domain.MaxImportRows, domain.ErrInvalidInput)
+	}
+
+	result := &domain.InventoryImportResult{
+		DryRun: req.DryRun,
+		Units:  []*domain.Inventory{},
+		Errors: []domain.ImportRowError{},
+	}
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.projectRepo.GetByID(ctx, req.ProjectID); err != nil {
+			return fmt.Errorf("project not found: %w", err)
+		}
+		existing, err := s.inventoryRepo.GetByProject(ctx, req.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to get project inventory: %w", err)
+		}
+		priceList, err := s.priceListRepo.GetEffective(ctx, req.ProjectID, time.Now())
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("failed to get price list: %w", err)
+		}
+
+		importer := &inventoryImporter{
+			req:       req,
+			result:    result,
+			priceList: priceList,
+			taken:     make(map[string]int),
+		}
+		for _, unit := range existing {
+			importer.taken[strings.ToLower(unit.UnitNumber)] = 0
+		}
+		if !importer.readHeader(req.Rows[0]) {
+			return nil
+		}
+		for i, row := range req.Rows[1:] {
+			importer.readRow(i+2, row)
+		}
+		if req.DryRun || len(result.Errors) > 0 {
+			return nil
+		}
+
+		for _, unit := range result.Units {
+			if err := s.inventoryRepo.Create(ctx, unit); err != nil {
+				return fmt.Errorf("failed to create inventory %s: %w", unit.UnitNumber, err)
+			}
+		}
+		if err := s.projectRepo.RefreshUnitCounts(ctx, req.ProjectID); err != nil {
+			return fmt.Errorf("failed to update project unit counts: %w", err)
+		}
+		result.Created = len(result.Units)
+		s.publishUnits(ctx, result.Units...)
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("import.rows", result.Rows),
+		attribute.Int("import.created", result.Created),
+		attribute.Int("import.errors", len(result.Errors)),
+	)
+
+	return result, nil
+}
+
+// inventoryImporter turns the rows of an import into units
+type inventoryImporter struct {
+	req       *domain.InventoryImportRequest
+	result    *domain.InventoryImportResult
+	priceList *domain.PriceList
+	columns   []string
+	// taken maps the lower-cased unit numbers already used to the row that
+	// used them, or 0 for units already in the project
+	taken map[string]int
+}
+
+// readHeader records the columns of the header row, reporting unknown,
+// repeated and missing columns
+func (im *inventoryImporter) readHeader(header []string) bool {
+	seen := make(map[string]bool, len(header))
+	for _, name := range header {
+		column := strings.ToLower(strings.TrimSpace(name))
+		column = strings.NewReplacer(" ", "_", "-", "_").Replace(column)
+		switch {
+		case column == "":
+		case !importColumns[column]:
+			im.fail(1, name, "unknown column")
+		case seen[column]:
+			im.fail(1, name, "column appears more than once")
+		}
+		seen[column] = true
+		im.columns = append(im.columns, column)
+	}
+	if !seen["unit_number"] {
+		im.fail(1, "unit_number", "column is required")
+	}
+	return len(im.result.Errors) == 0
+}
+
+// readRow adds the unit of a row, or the problems with it. Blank rows are
+// ignored.
+func (im *inventoryImporter) readRow(rowNumber int, row []string) {
+	values := make(map[string]string, len(im.columns))
+	for i, value := range row {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if i >= len(im.columns) || im.columns[i] == "" {
+			im.fail(rowNumber, "", fmt.Sprintf("value %q is not under a column", value))
+			continue
+		}
+		values[im.columns[i]] = value
+	}
+	if len(values) == 0 && !im.failed(rowNumber) {
+		return
+	}
+	im.result.Rows++
+
+	now := time.Now()
+	unit := &domain.Inventory{
+		ID:         uuid.New(),
+		ProjectID:  im.req.ProjectID,
+		UnitNumber: values["unit_number"],
+		Status:     domain.UnitStatusAvailable,
+		Features:   []string{},
+		CreatedBy:  im.req.CreatedBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if unit.UnitNumber == "" {
+		im.fail(rowNumber, "unit_number", "is required")
+	} else if row, ok := im.taken[strings.ToLower(unit.UnitNumber)]; ok {
+		if row == 0 {
+			im.fail(rowNumber, "unit_number", fmt.Sprintf("unit %s already exists in the project", unit.UnitNumber))
+		} else {
+			im.fail(rowNumber, "unit_number", fmt.Sprintf("unit %s is also on row %d", unit.UnitNumber, row))
+		}
+	} else {
+		im.taken[strings.ToLower(unit.UnitNumber)] = rowNumber
+	}
+
+	unit.TowerBlock = optionalText(values["tower_block"])
+	unit.UnitType = optionalText(values["unit_type"])
+	unit.Facing = optionalText(values["facing"])
+	unit.FloorPlanURL = optionalText(values["floor_plan_url"])
+	if features := values["features"]; features != "" {
+		for _, feature := range strings.FieldsFunc(features, func(r rune) bool { return r == ';' || r == '|' }) {
+			if feature = strings.TrimSpace(feature); feature != "" {
+				unit.Features = append(unit.Features, feature)
+			}
+		}
+	}
+
+	unit.FloorNumber = im.integer(rowNumber, values, "floor_number", true)
+	for _, count := range []struct {
+		column string
+		value  *int
+	}{
+		{"parking_slots", &unit.ParkingSlots},
+		{"balconies", &unit.Balconies},
+		{"bathrooms", &unit.Bathrooms},
+	} {
+		if n := im.integer(rowNumber, values, count.column, false); n != nil {
+			*count.value = *n
+		}
+	}
+	unit.CarpetArea = im.amount(rowNumber, values, "carpet_area")
+	unit.BuiltUpArea = im.amount(rowNumber, values, "built_up_area")
+	unit.SuperBuiltUpArea = im.amount(rowNumber, values, "super_built_up_area")
+	basePrice := im.amount(rowNumber, values, "base_price")
+	pricePerSqft := im.amount(rowNumber, values, "price_per_sqft")
+
+	if im.failed(rowNumber) {
+		return
+	}
+	if err := im.price(unit, basePrice, pricePerSqft); err != nil {
+		im.fail(rowNumber, "", err.Error())
+		return
+	}
+	im.result.Units = append(im.result.Units, unit)
+}
+
+// price sets the prices of a unit from the file or the price list, and
+// checks them against the business rules
+func (im *inventoryImporter) price(unit *domain.Inventory, basePrice, pricePerSqft *float64) error {
+	area := domain.SaleableArea(unit)
+	if basePrice == nil && pricePerSqft == nil {
+		if im.priceList == nil {
+			return errors.New("the unit has no price and the project has no price list in effect")
+		}
+		quote, err := im.priceList.Quote(unit)
+		var pricingErr *domain.PricingError
+		if errors.As(err, &pricingErr) {
+			return errors.New(pricingErr.Reason)
+		}
+		if err != nil {
+			return err
+		}
+		quote.Apply(unit)
+		return nil
+	}
+
+	if area == nil {
+		return errors.New("an area is needed to check the price")
+	}
+	if basePrice == nil {
+		price := math.Round(*pricePerSqft * *area)
+		basePrice = &price
+	}
+	if pricePerSqft == nil {
+		rate := math.Round(*basePrice / *area * 100) / 100
+		pricePerSqft = &rate
+	}
+	if err := (domain.BusinessRules{}).ValidatePropertyPrice(*basePrice, *pricePerSqft, *area); err != nil {
+		return err
+	}
+	finalPrice := *basePrice
+	unit.BasePrice, unit.FinalPrice, unit.PricePerSqft = basePrice, &finalPrice, pricePerSqft
+	return nil
+}
+
+// integer parses a whole number column; only floors may be negative
+func (im *inventoryImporter) integer(rowNumber int, values map[string]string, column string, signed bool) *int {
+	value, ok := values[column]
+	if !ok {
+		return nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || (!signed && n < 0) {
+		im.fail(rowNumber, column, fmt.Sprintf("%q is not a valid count", value))
+		return nil
+	}
+	return &n
+}
+
+// amount parses a non-negative number column, allowing thousands separators
+func (im *inventoryImporter) amount(rowNumber int, values map[string]string, column string) *float64 {
+	value, ok := values[column]
+	if !ok {
+		return nil
+	}
+	n, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+	if err != nil || n < 0 || math.IsInf(n, 0) || math.IsNaN(n) {
+		im.fail(rowNumber, column, fmt.Sprintf("%q is not a valid amount", value))
+		return nil
+	}
+	return &n
+}
+
+func (im *inventoryImporter) fail(rowNumber int, column, message string) {
+	im.result.Errors = append(im.result.Errors, domain.ImportRowError{Row: rowNumber, Column: column, Message: message})
+}
+
+// failed reports whether a row has any problems
+func (im *inventoryImporter) failed(rowNumber int) bool {
+	for i := len(im.result.Errors) - 1; i >= 0 && im.result.Errors[i].Row >= rowNumber; i-- {
+		if im.result.Errors[i].Row == rowNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// optionalText returns nil for an empty value
+func optionalText(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
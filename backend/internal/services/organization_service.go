@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var organizationTracer = otel.Tracer("goreal-backend/services/organization")
+
+// Organization limits
+const (
+	maxOrganizationNameLength = 200
+	maxOrganizationSlugLength = 63
+)
+
+// organizationSlug is a lowercase, hyphen separated slug
+var organizationSlug = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type organizationService struct {
+	organizationRepo domain.OrganizationRepository
+	userRepo         domain.UserRepository
+}
+
+// NewOrganizationService creates a new organization service
+func NewOrganizationService(organizationRepo domain.OrganizationRepository, userRepo domain.UserRepository) domain.OrganizationService {
+	return &organizationService{
+		organizationRepo: organizationRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// Create creates a new, active organization. A new tenant belongs to no
+// organization yet, so this runs across organizations.
+func (s *organizationService) Create(ctx context.Context, req *domain.CreateOrganizationRequest) (*domain.Organization, error) {
+	ctx, span := organizationTracer.Start(ctx, "organizationService.Create")
+	defer span.End()
+
+	name := strings.TrimSpace(req.Name)
+	slug := strings.TrimSpace(req.Slug)
+	if slug == "" {
+		slug = slugify(name)
+	}
+	if err := validateOrganization(name, slug); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	organization := &domain.Organization{
+		ID:        uuid.New(),
+		Name:      name,
+		Slug:      slug,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	span.SetAttributes(
+		attribute.String("organization.id", organization.ID.String()),
+		attribute.String("organization.slug", organization.Slug),
+	)
+
+	if err := s.organizationRepo.Create(domain.AcrossOrganizations(ctx), organization); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return organization, nil
+}
+
+// GetByID retrieves an organization by ID; callers only see their own
+func (s *organizationService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	ctx, span := organizationTracer.Start(ctx, "organizationService.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("organization.id", id.String()))
+
+	organization, err := s.organizationRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get organization by ID: %w", err)
+	}
+
+	return organization, nil
+}
+
+// Update renames an organization or changes its slug
+func (s *organizationService) Update(ctx context.Context, id uuid.UUID, req *domain.UpdateOrganizationRequest) (*domain.Organization, error) {
+	ctx, span := organizationTracer.Start(ctx, "organizationService.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("organization.id", id.String()))
+
+	organization, err := s.organizationRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	if req.Name != nil {
+		organization.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Slug != nil {
+		organization.Slug = strings.TrimSpace(*req.Slug)
+	}
+	if err := validateOrganization(organization.Name, organization.Slug); err != nil {
+		return nil, err
+	}
+
+	if err := s.organizationRepo.Update(ctx, organization); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return organization, nil
+}
+
+// List retrieves organizations; callers only see their own
+func (s *organizationService) List(ctx context.Context, filters domain.OrganizationFilters) ([]*domain.Organization, error) {
+	ctx, span := organizationTracer.Start(ctx, "organizationService.List")
+	defer span.End()
+
+	organizations, err := s.organizationRepo.List(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("organizations.count", len(organizations)))
+
+	return organizations, nil
+}
+
+// AddMember moves a user into an active organization. The user may belong to
+// any organization, so this runs across organizations. Tokens issued for the
+// previous organization stop being accepted once the user has moved.
+func (s *organizationService) AddMember(ctx context.Context, organizationID, userID uuid.UUID) (*domain.User, error) {
+	ctx, span := organizationTracer.Start(ctx, "organizationService.AddMember")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("organization.id", organizationID.String()),
+		attribute.String("user.id", userID.String()),
+	)
+
+	ctx = domain.AcrossOrganizations(ctx)
+
+	organization, err := s.organizationRepo.GetByID(ctx, organizationID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if !organization.IsActive {
+		return nil, fmt.Errorf("organization %s is not active: %w", organization.Slug, domain.ErrValidationFailed)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.OrganizationID != nil && *user.OrganizationID == organizationID {
+		return user, nil
+	}
+
+	user.OrganizationID = &organizationID
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to move user: %w", err)
+	}
+
+	return user, nil
+}
+
+// validateOrganization checks the name and slug of an organization
+func validateOrganization(name, slug string) error {
+	if name == "" {
+		return fmt.Errorf("organization name is required: %w", domain.ErrInvalidInput)
+	}
+	if len(name) > maxOrganizationNameLength {
+		return fmt.Errorf("organization name must be at most %d characters: %w", maxOrganizationNameLength, domain.ErrInvalidInput)
+	}
+	if len(slug) > maxOrganizationSlugLength || !organizationSlug.MatchString(slug) {
+		return fmt.Errorf("organization slug %q must be lowercase letters, digits and hyphens: %w", slug, domain.ErrInvalidInput)
+	}
+	return nil
+}
+
+// slugify derives a slug from an organization name
+func slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		default:
+			hyphen = true
+		}
+	}
+	return b.String()
+}
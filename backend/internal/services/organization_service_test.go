@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/infrastructure/memory"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationService_CreateAndScope(t *testing.T) {
+	store := memory.NewStore()
+	s := NewOrganizationService(memory.NewOrganizationRepository(store), memory.NewUserRepository(store))
+	ctx := context.Background()
+
+	acme, err := s.Create(ctx, &domain.CreateOrganizationRequest{Name: "  Acme Realty & Sons "})
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Realty & Sons", acme.Name)
+	assert.Equal(t, "acme-realty-sons", acme.Slug)
+	assert.True(t, acme.IsActive)
+
+	other, err := s.Create(ctx, &domain.CreateOrganizationRequest{Name: "Other", Slug: "other"})
+	require.NoError(t, err)
+
+	_, err = s.Create(ctx, &domain.CreateOrganizationRequest{Name: "Acme again", Slug: "acme-realty-sons"})
+	assert.True(t, errors.Is(err, domain.ErrAlreadyExists), "duplicate slug: %v", err)
+	_, err = s.Create(ctx, &domain.CreateOrganizationRequest{Name: "Bad", Slug: "Not A Slug"})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "invalid slug: %v", err)
+	_, err = s.Create(ctx, &domain.CreateOrganizationRequest{Name: " "})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "missing name: %v", err)
+
+	// A tenant only sees and changes itself
+	ctxAcme := domain.WithOrganization(ctx, acme.ID)
+	organizations, err := s.List(ctxAcme, domain.OrganizationFilters{})
+	require.NoError(t, err)
+	require.Len(t, organizations, 1)
+	assert.Equal(t, acme.ID, organizations[0].ID)
+
+	_, err = s.GetByID(ctxAcme, other.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "other tenant: %v", err)
+	_, err = s.Update(ctxAcme, other.ID, &domain.UpdateOrganizationRequest{Name: ptrTo("Taken over")})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "update other tenant: %v", err)
+
+	renamed, err := s.Update(ctxAcme, acme.ID, &domain.UpdateOrganizationRequest{Name: ptrTo("Acme Homes")})
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Homes", renamed.Name)
+	assert.Equal(t, "acme-realty-sons", renamed.Slug)
+
+	_, err = s.Update(ctxAcme, acme.ID, &domain.UpdateOrganizationRequest{Slug: ptrTo("other")})
+	assert.True(t, errors.Is(err, domain.ErrAlreadyExists), "slug taken: %v", err)
+}
+
+func TestOrganizationService_AddMember(t *testing.T) {
+	store := memory.NewStore()
+	organizations := memory.NewOrganizationRepository(store)
+	users := memory.NewUserRepository(store)
+	s := NewOrganizationService(organizations, users)
+	ctx := context.Background()
+
+	from, err := s.Create(ctx, &domain.CreateOrganizationRequest{Name: "From"})
+	require.NoError(t, err)
+	to, err := s.Create(ctx, &domain.CreateOrganizationRequest{Name: "To"})
+	require.NoError(t, err)
+	ctxFrom := domain.WithOrganization(ctx, from.ID)
+	ctxTo := domain.WithOrganization(ctx, to.ID)
+
+	user := &domain.User{ID: uuid.New(), Email: "mover@example.com", Username: "mover", Role: domain.RoleEmployee, IsActive: true}
+	require.NoError(t, users.Create(ctxFrom, user))
+
+	// The caller's own tenancy does not limit a move
+	moved, err := s.AddMember(ctxFrom, to.ID, user.ID)
+	require.NoError(t, err)
+	require.NotNil(t, moved.OrganizationID)
+	assert.Equal(t, to.ID, *moved.OrganizationID)
+
+	_, err = users.GetByID(ctxFrom, user.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "moved out: %v", err)
+	_, err = users.GetByID(ctxTo, user.ID)
+	assert.NoError(t, err)
+
+	_, err = s.AddMember(ctx, to.ID, uuid.New())
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "missing user: %v", err)
+	_, err = s.AddMember(ctx, uuid.New(), user.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "missing organization: %v", err)
+
+	from.IsActive = false
+	require.NoError(t, organizations.Update(ctxFrom, from))
+	_, err = s.AddMember(ctx, from.ID, user.ID)
+	assert.True(t, errors.Is(err, domain.ErrValidationFailed), "inactive organization: %v", err)
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GetAvailabilityMatrix lays a project's units out as a stacking plan:
+// towers in name order, each with its floors from the top down
+func (s *propertyService) GetAvailabilityMatrix(ctx context.Context, projectID uuid.UUID, filters domain.AvailabilityFilters) (*domain.AvailabilityMatrix, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.GetAvailabilityMatrix")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	units, err := s.inventoryRepo.GetByProject(ctx, projectID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get project inventory: %w", err)
+	}
+
+	matrix := &domain.AvailabilityMatrix{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		Towers:      []domain.AvailabilityTower{},
+		Summary:     map[domain.UnitStatus]int{},
+		GeneratedAt: time.Now(),
+	}
+
+	towers := map[string]map[string]*domain.AvailabilityFloor{}
+	for _, unit := range units {
+		if !availabilityMatch(filters, unit) {
+			continue
+		}
+		tower := ""
+		if unit.TowerBlock != nil {
+			tower = *unit.TowerBlock
+		}
+		if towers[tower] == nil {
+			towers[tower] = map[string]*domain.AvailabilityFloor{}
+		}
+		key := ""
+		if unit.FloorNumber != nil {
+			key = strconv.Itoa(*unit.FloorNumber)
+		}
+		floor, ok := towers[tower][key]
+		if !ok {
+			floor = &domain.AvailabilityFloor{Floor: unit.FloorNumber}
+			towers[tower][key] = floor
+		}
+		floor.Units = append(floor.Units, domain.NewAvailabilityCell(unit))
+		matrix.Summary[unit.Status]++
+		matrix.TotalUnits++
+	}
+
+	for name, floors := range towers {
+		tower := domain.AvailabilityTower{Name: name, Floors: make([]domain.AvailabilityFloor, 0, len(floors))}
+		for _, floor := range floors {
+			sort.Slice(floor.Units, func(i, j int) bool {
+				return naturalLess(floor.Units[i].UnitNumber, floor.Units[j].UnitNumber)
+			})
+			if len(floor.Units) > tower.Width {
+				tower.Width = len(floor.Units)
+			}
+			tower.Floors = append(tower.Floors, *floor)
+		}
+		sort.Slice(tower.Floors, func(i, j int) bool {
+			a, b := tower.Floors[i].Floor, tower.Floors[j].Floor
+			if a == nil || b == nil {
+				return a != nil
+			}
+			return *a > *b
+		})
+		matrix.Towers = append(matrix.Towers, tower)
+	}
+	sort.Slice(matrix.Towers, func(i, j int) bool {
+		return naturalLess(matrix.Towers[i].Name, matrix.Towers[j].Name)
+	})
+
+	span.SetAttributes(
+		attribute.Int("matrix.towers", len(matrix.Towers)),
+		attribute.Int("matrix.units", matrix.TotalUnits),
+	)
+
+	return matrix, nil
+}
+
+// WatchAvailability streams the changes to the units of a project that
+// match filters until ctx is done, when the channel is closed. The channel
+// is also closed if the watcher falls too far behind; it should then
+// reload the matrix and watch again.
+func (s *propertyService) WatchAvailability(ctx context.Context, projectID uuid.UUID, filters domain.AvailabilityFilters) (<-chan domain.UnitChange, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	matched := make(chan domain.UnitChange)
+	if s.feed == nil {
+		go func() {
+			<-ctx.Done()
+			close(matched)
+		}()
+		return matched, nil
+	}
+
+	changes, unsubscribe := s.feed.Subscribe(projectID)
+	go func() {
+		defer close(matched)
+		defer unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case change, ok := <-changes:
+				if !ok {
+					return
+				}
+				if !availabilityMatch(filters, &domain.Inventory{UnitType: change.UnitType, Facing: change.Facing}) {
+					continue
+				}
+				select {
+				case matched <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return matched, nil
+}
+
+// availabilityMatch reports whether a unit passes the filters of a
+// stacking plan
+func availabilityMatch(filters domain.AvailabilityFilters, unit *domain.Inventory) bool {
+	if filters.UnitType != nil && (unit.UnitType == nil || !strings.EqualFold(*unit.UnitType, *filters.UnitType)) {
+		return false
+	}
+	if filters.Facing != nil && (unit.Facing == nil || !strings.EqualFold(*unit.Facing, *filters.Facing)) {
+		return false
+	}
+	return true
+}
+
+// naturalLess orders strings with their runs of digits compared as
+// numbers, so that unit A-99 comes before A-100 and Tower 2 before Tower 10
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		da, db := leadingDigits(a), leadingDigits(b)
+		if da != "" && db != "" {
+			na, nb := strings.TrimLeft(da, "0"), strings.TrimLeft(db, "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			a, b = a[len(da):], b[len(db):]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+// leadingDigits returns the run of digits s starts with
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/pkg/geo"
+)
+
+// searchArea is the part of the map a property search is limited to. A
+// search with a centre but no radius is not limited; its results are only
+// ranked by distance.
+type searchArea struct {
+	centre   *geo.Point
+	radiusKm float64
+	box      *geo.BoundingBox
+	polygon  geo.Polygon
+	// byLocation is set when the centre is the geocoded search location,
+	// which then replaces matching the society location by name
+	byLocation bool
+}
+
+// newSearchArea works out and checks the area of a property search
+func (s *propertyService) newSearchArea(ctx context.Context, req *domain.PropertySearchRequest) (*searchArea, error) {
+	area := &searchArea{}
+
+	if req.Near != nil {
+		centre := toGeoPoint(*req.Near)
+		if !centre.Valid() {
+			return nil, fmt.Errorf("search centre is not a valid position: %w", domain.ErrInvalidInput)
+		}
+		area.centre = &centre
+	}
+
+	if req.RadiusKm != nil {
+		if *req.RadiusKm <= 0 || math.IsNaN(*req.RadiusKm) {
+			return nil, fmt.Errorf("search radius must be positive: %w", domain.ErrInvalidInput)
+		}
+		area.radiusKm = *req.RadiusKm
+		if area.centre == nil {
+			if req.Location == nil || strings.TrimSpace(*req.Location) == "" {
+				return nil, fmt.Errorf("a radius search needs a centre or a location: %w", domain.ErrInvalidInput)
+			}
+			point, err := s.geocode(ctx, *req.Location)
+			if err != nil {
+				return nil, err
+			}
+			if point == nil {
+				return nil, fmt.Errorf("location %q could not be found on the map: %w", *req.Location, domain.ErrInvalidInput)
+			}
+			area.centre = point
+			area.byLocation = true
+		}
+	}
+
+	if req.BoundingBox != nil {
+		box := geo.BoundingBox{
+			MinLat: req.BoundingBox.MinLatitude,
+			MinLng: req.BoundingBox.MinLongitude,
+			MaxLat: req.BoundingBox.MaxLatitude,
+			MaxLng: req.BoundingBox.MaxLongitude,
+		}
+		if !box.Valid() {
+			return nil, fmt.Errorf("search bounding box is not valid: %w", domain.ErrInvalidInput)
+		}
+		area.box = &box
+	}
+
+	if req.Polygon != nil {
+		polygon := make(geo.Polygon, len(req.Polygon))
+		for i, p := range req.Polygon {
+			polygon[i] = toGeoPoint(p)
+		}
+		if !polygon.Valid() {
+			return nil, fmt.Errorf("search polygon needs at least 3 valid points: %w", domain.ErrInvalidInput)
+		}
+		area.polygon = polygon
+	}
+
+	return area, nil
+}
+
+// active reports whether the area limits or ranks the search at all
+func (a *searchArea) active() bool {
+	return a.centre != nil || a.bounded()
+}
+
+// bounded reports whether the area leaves out some of the map
+func (a *searchArea) bounded() bool {
+	return a.radiusKm > 0 || a.box != nil || a.polygon != nil
+}
+
+// locate reports whether a society falls in the area, and its distance
+// from the centre if the area has one. A society with no coordinates is
+// only in an area that leaves nothing out.
+func (a *searchArea) locate(society *domain.Society) (*float64, bool) {
+	position, ok := society.Coordinates()
+	if !ok {
+		return nil, !a.bounded()
+	}
+	point := toGeoPoint(position)
+	if a.box != nil && !a.box.Contains(point) {
+		return nil, false
+	}
+	if a.polygon != nil && !a.polygon.Contains(point) {
+		return nil, false
+	}
+	if a.centre == nil {
+		return nil, true
+	}
+	distance := geo.Distance(*a.centre, point)
+	if a.radiusKm > 0 && distance > a.radiusKm {
+		return nil, false
+	}
+	rounded := roundKm(distance)
+	return &rounded, true
+}
+
+// locateSociety sets the coordinates of a society: those given with the
+// request, else those of its address, else the geocoded position of its
+// address and location. A society the geocoder cannot place is left
+// without coordinates.
+func (s *propertyService) locateSociety(ctx context.Context, society *domain.Society, latitude, longitude *float64) error {
+	if latitude != nil || longitude != nil {
+		if latitude == nil || longitude == nil {
+			return fmt.Errorf("latitude and longitude must be given together: %w", domain.ErrInvalidInput)
+		}
+		if !(geo.Point{Lat: *latitude, Lng: *longitude}).Valid() {
+			return fmt.Errorf("society coordinates are out of range: %w", domain.ErrInvalidInput)
+		}
+		society.Latitude, society.Longitude = latitude, longitude
+		return nil
+	}
+
+	if society.Address != nil && (society.Address.Latitude != nil || society.Address.Longitude != nil) {
+		position, ok := society.Address.Coordinates()
+		if !ok || !toGeoPoint(position).Valid() {
+			return fmt.Errorf("address coordinates are not valid: %w", domain.ErrInvalidInput)
+		}
+		society.Latitude, society.Longitude = &position.Latitude, &position.Longitude
+		return nil
+	}
+
+	society.Latitude, society.Longitude = nil, nil
+	point, err := s.geocode(ctx, societyAddress(society))
+	if err != nil {
+		log.Printf("Failed to geocode society %s: %v", society.ID, err)
+		return nil
+	}
+	if point != nil {
+		society.Latitude, society.Longitude = &point.Lat, &point.Lng
+	}
+	return nil
+}
+
+// locateFacilities checks a project's nearby facilities, geocodes those
+// given without coordinates by name, and measures how far each one is from
+// the society. The facilities are returned nearest first, with those that
+// could not be placed last.
+func (s *propertyService) locateFacilities(ctx context.Context, society *domain.Society, facilities []domain.NearbyFacility) ([]domain.NearbyFacility, error) {
+	located := make([]domain.NearbyFacility, len(facilities))
+	copy(located, facilities)
+
+	origin, hasOrigin := society.Coordinates()
+	for i := range located {
+		facility := &located[i]
+		facility.Name = strings.TrimSpace(facility.Name)
+		if facility.Name == "" {
+			return nil, fmt.Errorf("nearby facility name is required: %w", domain.ErrInvalidInput)
+		}
+		if facility.Latitude != nil || facility.Longitude != nil {
+			position, ok := facility.Coordinates()
+			if !ok || !toGeoPoint(position).Valid() {
+				return nil, fmt.Errorf("coordinates of %s are not valid: %w", facility.Name, domain.ErrInvalidInput)
+			}
+		} else {
+			point, err := s.geocode(ctx, facility.Name)
+			if err != nil {
+				log.Printf("Failed to geocode facility %q: %v", facility.Name, err)
+			} else if point != nil {
+				facility.Latitude, facility.Longitude = &point.Lat, &point.Lng
+			}
+		}
+
+		facility.DistanceKm = nil
+		if position, ok := facility.Coordinates(); ok && hasOrigin {
+			distance := roundKm(geo.Distance(toGeoPoint(origin), toGeoPoint(position)))
+			facility.DistanceKm = &distance
+		}
+	}
+
+	sort.SliceStable(located, func(i, j int) bool {
+		return distanceLess(located[i].DistanceKm, located[j].DistanceKm)
+	})
+	return located, nil
+}
+
+// relocateProjects measures the facilities of a society's projects again
+// after the society has moved
+func (s *propertyService) relocateProjects(ctx context.Context, society *domain.Society) error {
+	projects, err := s.projectRepo.GetBySociety(ctx, society.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get projects by society: %w", err)
+	}
+	for _, project := range projects {
+		if len(project.NearbyFacilities) == 0 {
+			continue
+		}
+		facilities, err := s.locateFacilities(ctx, society, project.NearbyFacilities)
+		if err != nil {
+			return err
+		}
+		project.NearbyFacilities = facilities
+		if err := s.projectRepo.Update(ctx, project); err != nil {
+			return fmt.Errorf("failed to update project %s: %w", project.ID, err)
+		}
+	}
+	return nil
+}
+
+// geocode finds an address with the configured geocoder. It returns nil
+// when there is no geocoder or the address is unknown.
+func (s *propertyService) geocode(ctx context.Context, address string) (*geo.Point, error) {
+	if s.geocoder == nil || strings.TrimSpace(address) == "" {
+		return nil, nil
+	}
+	position, err := s.geocoder.Geocode(ctx, address)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to geocode %q: %w", address, err)
+	}
+	point := toGeoPoint(*position)
+	return &point, nil
+}
+
+// societyAddress writes out a society's address for geocoding, from the
+// most specific part to the least
+func societyAddress(society *domain.Society) string {
+	var parts []string
+	add := func(part string) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if society.Address != nil {
+		add(society.Address.Street)
+	}
+	add(society.Location)
+	if society.Address != nil {
+		add(society.Address.City)
+		add(society.Address.State)
+		add(society.Address.Country)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sameCoordinates reports whether two optional coordinates are equal
+func sameCoordinates(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// distanceLess orders optional distances nearest first, unknown last
+func distanceLess(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a != nil && b == nil
+	}
+	return *a < *b
+}
+
+func toGeoPoint(p domain.GeoPoint) geo.Point {
+	return geo.Point{Lat: p.Latitude, Lng: p.Longitude}
+}
+
+// roundKm rounds a distance to the nearest 10 metres
+func roundKm(km float64) float64 {
+	return math.Round(km*100) / 100
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CreatePriceList adds the next version of a project's price list
+func (s *propertyService) CreatePriceList(ctx context.Context, projectID uuid.UUID, req *domain.CreatePriceListRequest) (*domain.PriceList, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.CreatePriceList")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	now := time.Now()
+	priceList := &domain.PriceList{
+		ID:             uuid.New(),
+		ProjectID:      projectID,
+		EffectiveFrom:  now,
+		BaseRate:       req.BaseRate,
+		FloorRise:      req.FloorRise,
+		FloorRiseFrom:  req.FloorRiseFrom,
+		FacingPremiums: orEmptyRates(req.FacingPremiums),
+		PLCs:           orEmptyRates(req.PLCs),
+		Notes:          req.Notes,
+		CreatedBy:      req.CreatedBy,
+		CreatedAt:      now,
+	}
+	if req.EffectiveFrom != nil {
+		priceList.EffectiveFrom = *req.EffectiveFrom
+	}
+	if err := priceList.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+			return fmt.Errorf("project not found: %w", err)
+		}
+		versions, err := s.priceListRepo.ListVersions(ctx, projectID)
+		if err != nil {
+			return fmt.Errorf("failed to list price lists: %w", err)
+		}
+		priceList.Version = 1
+		if len(versions) > 0 {
+			priceList.Version = versions[0].Version + 1
+		}
+		if err := s.priceListRepo.Create(ctx, priceList); err != nil {
+			return fmt.Errorf("failed to create price list: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("price_list.version", priceList.Version))
+
+	return priceList, nil
+}
+
+// ListPriceLists lists every version of a project's price list, newest first
+func (s *propertyService) ListPriceLists(ctx context.Context, projectID uuid.UUID) ([]*domain.PriceList, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.ListPriceLists")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+	priceLists, err := s.priceListRepo.ListVersions(ctx, projectID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list price lists: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(priceLists)))
+
+	return priceLists, nil
+}
+
+// GetEffectivePriceList returns the version of a project's price list in
+// effect at a time
+func (s *propertyService) GetEffectivePriceList(ctx context.Context, projectID uuid.UUID, at time.Time) (*domain.PriceList, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.GetEffectivePriceList")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	priceList, err := s.priceListRepo.GetEffective(ctx, projectID, at)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get price list: %w", err)
+	}
+
+	return priceList, nil
+}
+
+// ApplyPriceList reprices the available and blocked units of a project from
+// a version of its price list. A dry run only reports the changes, and may
+// preview any version; otherwise the version must be the one in effect, and
+// every change is saved together.
+func (s *propertyService) ApplyPriceList(ctx context.Context, projectID uuid.UUID, version int, dryRun bool) (*domain.PriceRevision, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.ApplyPriceList")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project.id", projectID.String()),
+		attribute.Int("price_list.version", version),
+		attribute.Bool("dry_run", dryRun),
+	)
+
+	var revision *domain.PriceRevision
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		priceList, err := s.priceListRepo.GetVersion(ctx, projectID, version)
+		if err != nil {
+			return fmt.Errorf("failed to get price list: %w", err)
+		}
+		if !dryRun {
+			effective, err := s.priceListRepo.GetEffective(ctx, projectID, time.Now())
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("failed to get price list: %w", err)
+			}
+			if effective == nil || effective.Version != version {
+				return fmt.Errorf("price list version %d is not in effect: %w", version, domain.ErrConflict)
+			}
+		}
+
+		units, err := s.inventoryRepo.GetByProject(ctx, projectID)
+		if err != nil {
+			return fmt.Errorf("failed to get project inventory: %w", err)
+		}
+
+		revision = &domain.PriceRevision{
+			PriceList: priceList,
+			DryRun:    dryRun,
+			Changes:   []domain.PriceChange{},
+			Unpriced:  []*domain.PricingError{},
+		}
+		for _, unit := range units {
+			if !manualUnitStatus(unit.Status) {
+				revision.Skipped++
+				continue
+			}
+			quote, err := priceList.Quote(unit)
+			var pricingErr *domain.PricingError
+			if errors.As(err, &pricingErr) {
+				revision.Unpriced = append(revision.Unpriced, pricingErr)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			old := unit.FinalPrice
+			if old != nil && *old == quote.FinalPrice && unit.BasePrice != nil && *unit.BasePrice == quote.BasePrice {
+				revision.Unchanged++
+				continue
+			}
+			change := domain.PriceChange{
+				InventoryID: unit.ID,
+				UnitNumber:  unit.UnitNumber,
+				OldPrice:    old,
+				NewPrice:    quote.FinalPrice,
+				Difference:  quote.FinalPrice,
+				Quote:       quote,
+			}
+			if old != nil {
+				change.Difference = quote.FinalPrice - *old
+			}
+			revision.Changes = append(revision.Changes, change)
+
+			if dryRun {
+				continue
+			}
+			quote.Apply(unit)
+			if err := s.inventoryRepo.Update(ctx, unit); err != nil {
+				return fmt.Errorf("failed to update inventory: %w", err)
+			}
+			s.publishUnits(ctx, unit)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("price_revision.changes", len(revision.Changes)),
+		attribute.Int("price_revision.unpriced", len(revision.Unpriced)),
+	)
+
+	return revision, nil
+}
+
+// priceNewUnit prices a unit given no price and an area from the project's
+// price list in effect, if it has one
+func (s *propertyService) priceNewUnit(ctx context.Context, unit *domain.Inventory) error {
+	if unit.BasePrice != nil || unit.FinalPrice != nil || domain.SaleableArea(unit) == nil {
+		return nil
+	}
+	priceList, err := s.priceListRepo.GetEffective(ctx, unit.ProjectID, time.Now())
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get price list: %w", err)
+	}
+	quote, err := priceList.Quote(unit)
+	if err != nil {
+		return err
+	}
+	quote.Apply(unit)
+	return nil
+}
+
+// orEmptyRates returns an empty map in place of nil so rate columns are
+// stored as empty objects
+func orEmptyRates(rates map[string]float64) map[string]float64 {
+	if rates == nil {
+		return map[string]float64{}
+	}
+	return rates
+}
@@ -0,0 +1,562 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Points the recommender awards for each reason a unit is suggested
+const (
+	pointsWithinBudget       = 40
+	pointsUnderBudget        = 20
+	pointsNearBudget         = 10
+	pointsRequirement        = 10
+	pointsTag                = 5
+	pointsShortlisted        = 25
+	pointsViewed             = 10
+	pointsShortlistedProject = 15
+	pointsViewedProject      = 8
+	pointsUnitType           = 10
+	pointsSimilarPurchase    = 15
+	pointsSimilarProject     = 8
+)
+
+const (
+	// budgetTolerance is how far over the lead's maximum budget a unit may
+	// be priced and still be suggested
+	budgetTolerance = 0.10
+	// maxKeywordMatches caps the requirement and tag matches that score
+	maxKeywordMatches = 3
+	// recommendationCandidates caps the available units scored per request
+	recommendationCandidates = 500
+	// similarClientLimit caps the clients whose purchases are consulted
+	similarClientLimit = 50
+	// defaultRecommendationLimit is the number of suggestions returned
+	// when the caller does not ask for a number
+	defaultRecommendationLimit = 10
+)
+
+// requirementStopWords are words of a lead's requirements that say
+// nothing about the unit wanted
+var requirementStopWords = map[string]bool{
+	"and": true, "the": true, "with": true, "for": true, "near": true, "from": true, "not": true,
+	"looking": true, "want": true, "wants": true, "need": true, "needs": true, "prefer": true,
+	"preferred": true, "preferably": true, "good": true, "flat": true, "apartment": true, "unit": true,
+	"property": true, "budget": true, "lakh": true, "lakhs": true, "crore": true, "crores": true,
+}
+
+// bhkPattern joins "2 BHK" into "2bhk" so it matches the unit type "2BHK"
+var bhkPattern = regexp.MustCompile(`(\d)\s+(bhk|rk)\b`)
+
+// GetPropertyRecommendations suggests available units to a client, best
+// first. Units score points for fitting the budget and requirements of
+// the client's lead, for matching the client's tags, for resembling units
+// the client viewed or shortlisted and for resembling what similar
+// clients bought. Every point comes with a reason.
+func (s *propertyService) GetPropertyRecommendations(ctx context.Context, clientID uuid.UUID, limit int) ([]*domain.PropertyRecommendation, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.GetPropertyRecommendations")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("client.id", clientID.String()))
+
+	client, err := s.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	profile, err := s.clientProfile(ctx, client)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	available := domain.UnitStatusAvailable
+	units, err := s.inventoryRepo.List(ctx, domain.InventoryFilters{
+		BaseFilters: domain.BaseFilters{Limit: recommendationCandidates},
+		Status:      &available,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list available units: %w", err)
+	}
+
+	places := newPlaceCache(s)
+	recommendations := []*domain.PropertyRecommendation{}
+	for _, unit := range units {
+		project, society, err := places.get(ctx, unit.ProjectID)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		if rec := profile.score(unit, project, society); rec != nil {
+			recommendations = append(recommendations, rec)
+		}
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		pa, pb := unitPrice(a.Inventory), unitPrice(b.Inventory)
+		if pa != nil && pb != nil && *pa != *pb {
+			return *pa < *pb
+		}
+		if (pa == nil) != (pb == nil) {
+			return pa != nil
+		}
+		return a.Inventory.UnitNumber < b.Inventory.UnitNumber
+	})
+
+	if limit <= 0 {
+		limit = defaultRecommendationLimit
+	}
+	if limit < len(recommendations) {
+		recommendations = recommendations[:limit]
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(recommendations)))
+
+	return recommendations, nil
+}
+
+// RecordInterest records that a client viewed or shortlisted a unit.
+// Recording the same interest again returns the one already recorded.
+func (s *propertyService) RecordInterest(ctx context.Context, clientID uuid.UUID, req *domain.RecordInterestRequest) (*domain.PropertyInterest, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.RecordInterest")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("client.id", clientID.String()),
+		attribute.String("inventory.id", req.InventoryID.String()),
+		attribute.String("interest.kind", string(req.Kind)),
+	)
+
+	if !req.Kind.Valid() {
+		return nil, fmt.Errorf("interest kind must be viewed or shortlisted: %w", domain.ErrInvalidInput)
+	}
+	if _, err := s.clientRepo.GetByID(ctx, clientID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	if _, err := s.inventoryRepo.GetByID(ctx, req.InventoryID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get unit: %w", err)
+	}
+
+	existing, err := s.interestRepo.ListByClient(ctx, clientID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list client interests: %w", err)
+	}
+	for _, interest := range existing {
+		if interest.InventoryID == req.InventoryID && interest.Kind == req.Kind {
+			return interest, nil
+		}
+	}
+
+	interest := &domain.PropertyInterest{
+		ID:          uuid.New(),
+		ClientID:    clientID,
+		InventoryID: req.InventoryID,
+		Kind:        req.Kind,
+		RecordedBy:  req.RecordedBy,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.interestRepo.Create(ctx, interest); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to record interest: %w", err)
+	}
+
+	return interest, nil
+}
+
+// GetClientInterests lists the units a client viewed or shortlisted,
+// newest first
+func (s *propertyService) GetClientInterests(ctx context.Context, clientID uuid.UUID) ([]*domain.PropertyInterest, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.GetClientInterests")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("client.id", clientID.String()))
+
+	if _, err := s.clientRepo.GetByID(ctx, clientID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	interests, err := s.interestRepo.ListByClient(ctx, clientID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list client interests: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(interests)))
+
+	return interests, nil
+}
+
+// clientProfile is what the recommender knows about the units a client
+// wants
+type clientProfile struct {
+	budgetMin, budgetMax *float64
+	// keywords are the words of the lead's requirements
+	keywords []string
+	// tags are the client's and the lead's tags
+	tags        []string
+	shortlisted map[uuid.UUID]bool
+	viewed      map[uuid.UUID]bool
+	// projects holds the strongest interest the client showed in a unit
+	// of each project
+	projects map[uuid.UUID]domain.PropertyInterestKind
+	// unitTypes are the normalized types of the units the client looked at
+	unitTypes map[string]bool
+	// bought holds the projects similar clients bought in, and the unit
+	// types they bought there
+	bought map[uuid.UUID]map[string]bool
+}
+
+// clientProfile gathers the lead, tags and interests of a client and the
+// purchases of similar clients: those who share a tag with the client or
+// looked at the same units
+func (s *propertyService) clientProfile(ctx context.Context, client *domain.Client) (*clientProfile, error) {
+	p := &clientProfile{
+		shortlisted: map[uuid.UUID]bool{},
+		viewed:      map[uuid.UUID]bool{},
+		projects:    map[uuid.UUID]domain.PropertyInterestKind{},
+		unitTypes:   map[string]bool{},
+		bought:      map[uuid.UUID]map[string]bool{},
+	}
+
+	tags := append([]string{}, client.Tags...)
+	if client.LeadID != nil {
+		lead, err := s.leadRepo.GetByID(ctx, *client.LeadID)
+		switch {
+		case err == nil:
+			p.budgetMin, p.budgetMax = lead.BudgetMin, lead.BudgetMax
+			if lead.Requirements != nil {
+				p.keywords = requirementKeywords(*lead.Requirements)
+			}
+			tags = append(tags, lead.Tags...)
+		case !errors.Is(err, domain.ErrNotFound):
+			return nil, fmt.Errorf("failed to get lead of client: %w", err)
+		}
+	}
+	p.tags = uniqueFold(tags)
+
+	var peers []uuid.UUID
+	seen := map[uuid.UUID]bool{client.ID: true}
+	addPeer := func(id uuid.UUID) {
+		if !seen[id] {
+			seen[id] = true
+			peers = append(peers, id)
+		}
+	}
+
+	interests, err := s.interestRepo.ListByClient(ctx, client.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list client interests: %w", err)
+	}
+	for _, interest := range interests {
+		unit, err := s.inventoryRepo.GetByID(ctx, interest.InventoryID)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get unit of interest: %w", err)
+		}
+
+		if interest.Kind == domain.PropertyInterestShortlisted {
+			p.shortlisted[unit.ID] = true
+			p.projects[unit.ProjectID] = domain.PropertyInterestShortlisted
+		} else {
+			p.viewed[unit.ID] = true
+			if p.projects[unit.ProjectID] == "" {
+				p.projects[unit.ProjectID] = domain.PropertyInterestViewed
+			}
+		}
+		if unit.UnitType != nil {
+			p.unitTypes[normalizePhrase(*unit.UnitType)] = true
+		}
+
+		others, err := s.interestRepo.ListByInventory(ctx, unit.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list unit interests: %w", err)
+		}
+		for _, other := range others {
+			addPeer(other.ClientID)
+		}
+	}
+
+	for _, tag := range client.Tags {
+		clients, err := s.clientRepo.List(ctx, domain.ClientFilters{
+			BaseFilters: domain.BaseFilters{Limit: similarClientLimit},
+			Tags:        []string{tag},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clients tagged %q: %w", tag, err)
+		}
+		for _, other := range clients {
+			addPeer(other.ID)
+		}
+	}
+
+	if len(peers) > similarClientLimit {
+		peers = peers[:similarClientLimit]
+	}
+	for _, peer := range peers {
+		if err := s.addPurchases(ctx, p, peer); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// addPurchases records the units a client bought in the profile
+func (s *propertyService) addPurchases(ctx context.Context, p *clientProfile, clientID uuid.UUID) error {
+	sales, err := s.saleRepo.GetByClient(ctx, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to get sales of client: %w", err)
+	}
+
+	for _, sale := range sales {
+		if sale.Status != domain.SaleStatusApproved && sale.Status != domain.SaleStatusCompleted {
+			continue
+		}
+		unit, err := s.inventoryRepo.GetByID(ctx, sale.InventoryID)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get unit of sale: %w", err)
+		}
+
+		if p.bought[unit.ProjectID] == nil {
+			p.bought[unit.ProjectID] = map[string]bool{}
+		}
+		if unit.UnitType != nil {
+			p.bought[unit.ProjectID][normalizePhrase(*unit.UnitType)] = true
+		}
+	}
+	return nil
+}
+
+// score rates a unit for the client. It returns nil when nothing speaks
+// for the unit or it is priced well over the client's budget.
+func (p *clientProfile) score(unit *domain.Inventory, project *domain.Project, society *domain.Society) *domain.PropertyRecommendation {
+	rec := &domain.PropertyRecommendation{Inventory: unit, Reasons: []string{}}
+	add := func(points int, format string, args ...interface{}) {
+		rec.Score += points
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf(format, args...))
+	}
+
+	if price := unitPrice(unit); price != nil {
+		switch {
+		case p.budgetMax != nil && *price > *p.budgetMax*(1+budgetTolerance):
+			return nil
+		case p.budgetMax != nil && *price > *p.budgetMax:
+			add(pointsNearBudget, "priced up to %.0f%% over the client's budget", budgetTolerance*100)
+		case p.budgetMin != nil && *price < *p.budgetMin:
+			add(pointsUnderBudget, "priced under the client's budget")
+		case p.budgetMin != nil || p.budgetMax != nil:
+			add(pointsWithinBudget, "priced within the client's budget")
+		}
+	}
+
+	terms := unitTerms(unit, project, society)
+	matched := 0
+	for _, keyword := range p.keywords {
+		if matched < maxKeywordMatches && matchesTerms(keyword, terms) {
+			add(pointsRequirement, "matches the requirement %q", keyword)
+			matched++
+		}
+	}
+	matched = 0
+	for _, tag := range p.tags {
+		if matched < maxKeywordMatches && matchesTerms(normalizePhrase(tag), terms) {
+			add(pointsTag, "matches the tag %q", tag)
+			matched++
+		}
+	}
+
+	switch {
+	case p.shortlisted[unit.ID]:
+		add(pointsShortlisted, "shortlisted by the client")
+	case p.viewed[unit.ID]:
+		add(pointsViewed, "viewed by the client")
+	case p.projects[unit.ProjectID] == domain.PropertyInterestShortlisted:
+		add(pointsShortlistedProject, "in %s, where the client shortlisted a unit", project.Name)
+	case p.projects[unit.ProjectID] == domain.PropertyInterestViewed:
+		add(pointsViewedProject, "in %s, where the client viewed a unit", project.Name)
+	}
+
+	unitType := ""
+	if unit.UnitType != nil {
+		unitType = normalizePhrase(*unit.UnitType)
+	}
+	if unitType != "" && p.unitTypes[unitType] && !p.shortlisted[unit.ID] && !p.viewed[unit.ID] {
+		add(pointsUnitType, "a %s like the units the client looked at", *unit.UnitType)
+	}
+
+	if types, ok := p.bought[unit.ProjectID]; ok {
+		if unitType != "" && types[unitType] {
+			add(pointsSimilarPurchase, "similar clients bought a %s in %s", *unit.UnitType, project.Name)
+		} else {
+			add(pointsSimilarProject, "similar clients bought in %s", project.Name)
+		}
+	}
+
+	if rec.Score == 0 {
+		return nil
+	}
+	return rec
+}
+
+// placeCache caches the projects and societies of the units being scored
+type placeCache struct {
+	s         *propertyService
+	projects  map[uuid.UUID]*domain.Project
+	societies map[uuid.UUID]*domain.Society
+}
+
+func newPlaceCache(s *propertyService) *placeCache {
+	return &placeCache{
+		s:         s,
+		projects:  map[uuid.UUID]*domain.Project{},
+		societies: map[uuid.UUID]*domain.Society{},
+	}
+}
+
+// get returns a project and its society
+func (c *placeCache) get(ctx context.Context, projectID uuid.UUID) (*domain.Project, *domain.Society, error) {
+	project, ok := c.projects[projectID]
+	if !ok {
+		var err error
+		project, err = c.s.projectRepo.GetByID(ctx, projectID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get project %s: %w", projectID, err)
+		}
+		c.projects[projectID] = project
+	}
+
+	society, ok := c.societies[project.SocietyID]
+	if !ok {
+		var err error
+		society, err = c.s.societyRepo.GetByID(ctx, project.SocietyID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get society of project %s: %w", projectID, err)
+		}
+		c.societies[project.SocietyID] = society
+	}
+	return project, society, nil
+}
+
+// unitTerms lists the normalized descriptions of a unit, its project and
+// its society that requirements and tags are matched against
+func unitTerms(unit *domain.Inventory, project *domain.Project, society *domain.Society) []string {
+	var terms []string
+	add := func(values ...string) {
+		for _, value := range values {
+			if term := normalizePhrase(value); term != "" {
+				terms = append(terms, term)
+			}
+		}
+	}
+
+	for _, value := range []*string{unit.UnitType, unit.Facing, unit.TowerBlock, project.ProjectType} {
+		if value != nil {
+			add(*value)
+		}
+	}
+	add(unit.Features...)
+	add(project.Name)
+	add(project.Amenities...)
+	add(society.Name, society.Location)
+	add(society.Amenities...)
+	if society.Address != nil {
+		add(society.Address.City, society.Address.State)
+	}
+	return terms
+}
+
+// matchesTerms reports whether a normalized phrase appears as whole words
+// in one of the terms
+func matchesTerms(phrase string, terms []string) bool {
+	if phrase == "" {
+		return false
+	}
+	for _, term := range terms {
+		if strings.Contains(" "+term+" ", " "+phrase+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// requirementKeywords picks the words of a lead's requirements worth
+// matching, in order and without repeats
+func requirementKeywords(requirements string) []string {
+	var keywords []string
+	seen := map[string]bool{}
+	for _, word := range strings.Fields(normalizePhrase(requirements)) {
+		if len(word) < 3 || requirementStopWords[word] || seen[word] || isNumber(word) {
+			continue
+		}
+		seen[word] = true
+		keywords = append(keywords, word)
+	}
+	return keywords
+}
+
+// normalizePhrase lowercases s and reduces it to words separated by
+// single spaces
+func normalizePhrase(s string) string {
+	s = bhkPattern.ReplaceAllString(strings.ToLower(s), "$1$2")
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// uniqueFold drops repeated values, ignoring case
+func uniqueFold(values []string) []string {
+	var unique []string
+	seen := map[string]bool{}
+	for _, value := range values {
+		key := strings.ToLower(strings.TrimSpace(value))
+		if key != "" && !seen[key] {
+			seen[key] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// isNumber reports whether word is made of digits only
+func isNumber(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// unitPrice returns the price a unit sells for
+func unitPrice(unit *domain.Inventory) *float64 {
+	if unit.FinalPrice != nil {
+		return unit.FinalPrice
+	}
+	return unit.BasePrice
+}
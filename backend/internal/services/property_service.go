@@ -0,0 +1,862 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var propertyTracer = otel.Tracer("goreal-backend/services/property")
+
+// propertyService manages societies, projects and their units. Unit
+// reservations are delegated to the embedded reservation service.
+type propertyService struct {
+	domain.ReservationService
+	societyRepo   domain.SocietyRepository
+	projectRepo   domain.ProjectRepository
+	inventoryRepo domain.InventoryRepository
+	interestRepo  domain.PropertyInterestRepository
+	priceListRepo domain.PriceListRepository
+	clientRepo    domain.ClientRepository
+	leadRepo      domain.LeadRepository
+	saleRepo      domain.SaleRepository
+	uow           domain.UnitOfWork
+	feed          domain.UnitChangeFeed
+	geocoder      domain.Geocoder
+}
+
+// NewPropertyService creates a new property service
+func NewPropertyService(
+	societyRepo domain.SocietyRepository,
+	projectRepo domain.ProjectRepository,
+	inventoryRepo domain.InventoryRepository,
+	interestRepo domain.PropertyInterestRepository,
+	priceListRepo domain.PriceListRepository,
+	clientRepo domain.ClientRepository,
+	leadRepo domain.LeadRepository,
+	saleRepo domain.SaleRepository,
+	uow domain.UnitOfWork,
+	reservationService domain.ReservationService,
+	feed domain.UnitChangeFeed,
+	geocoder domain.Geocoder,
+) domain.PropertyService {
+	return &propertyService{
+		ReservationService: reservationService,
+		societyRepo:        societyRepo,
+		projectRepo:        projectRepo,
+		inventoryRepo:      inventoryRepo,
+		interestRepo:       interestRepo,
+		priceListRepo:      priceListRepo,
+		clientRepo:         clientRepo,
+		leadRepo:           leadRepo,
+		saleRepo:           saleRepo,
+		uow:                uow,
+		feed:               feed,
+		geocoder:           geocoder,
+	}
+}
+
+// CreateSociety creates a new society, placing it on the map from the
+// coordinates given or else by geocoding its address
+func (s *propertyService) CreateSociety(ctx context.Context, req *domain.CreateSocietyRequest) (*domain.Society, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.CreateSociety")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("society.name", req.Name))
+
+	if strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.Location) == "" {
+		return nil, fmt.Errorf("society name and location are required: %w", domain.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	society := &domain.Society{
+		ID:            uuid.New(),
+		Name:          req.Name,
+		DeveloperName: req.DeveloperName,
+		Location:      req.Location,
+		Address:       req.Address,
+		TotalArea:     req.TotalArea,
+		TotalUnits:    req.TotalUnits,
+		Amenities:     orEmpty(req.Amenities),
+		Description:   req.Description,
+		Images:        orEmpty(req.Images),
+		BrochureURL:   req.BrochureURL,
+		IsActive:      true,
+		CreatedBy:     req.CreatedBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.locateSociety(ctx, society, req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
+
+	if err := s.societyRepo.Create(ctx, society); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create society: %w", err)
+	}
+
+	return society, nil
+}
+
+// GetSociety retrieves a society by ID
+func (s *propertyService) GetSociety(ctx context.Context, id uuid.UUID) (*domain.Society, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.GetSociety")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("society.id", id.String()))
+
+	society, err := s.societyRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get society: %w", err)
+	}
+
+	return society, nil
+}
+
+// UpdateSociety applies the fields set in req to a society. A society
+// whose address or location changes without new coordinates is geocoded
+// again, and the facilities of its projects are measured from where it
+// now is.
+func (s *propertyService) UpdateSociety(ctx context.Context, id uuid.UUID, req *domain.UpdateSocietyRequest) (*domain.Society, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.UpdateSociety")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("society.id", id.String()))
+
+	society, err := s.societyRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get society: %w", err)
+	}
+
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			return nil, fmt.Errorf("society name cannot be empty: %w", domain.ErrInvalidInput)
+		}
+		society.Name = *req.Name
+	}
+	if req.DeveloperName != nil {
+		society.DeveloperName = req.DeveloperName
+	}
+	if req.Location != nil {
+		if strings.TrimSpace(*req.Location) == "" {
+			return nil, fmt.Errorf("society location cannot be empty: %w", domain.ErrInvalidInput)
+		}
+		society.Location = *req.Location
+	}
+	if req.Address != nil {
+		society.Address = req.Address
+	}
+	if req.TotalArea != nil {
+		society.TotalArea = req.TotalArea
+	}
+	if req.TotalUnits != nil {
+		society.TotalUnits = req.TotalUnits
+	}
+	if req.Amenities != nil {
+		society.Amenities = req.Amenities
+	}
+	if req.Description != nil {
+		society.Description = req.Description
+	}
+	if req.Images != nil {
+		society.Images = req.Images
+	}
+	if req.BrochureURL != nil {
+		society.BrochureURL = req.BrochureURL
+	}
+	if req.IsActive != nil {
+		society.IsActive = *req.IsActive
+	}
+
+	latitude, longitude := society.Latitude, society.Longitude
+	if req.Latitude != nil || req.Longitude != nil || req.Address != nil || req.Location != nil {
+		if err := s.locateSociety(ctx, society, req.Latitude, req.Longitude); err != nil {
+			return nil, err
+		}
+	}
+	moved := !sameCoordinates(latitude, society.Latitude) || !sameCoordinates(longitude, society.Longitude)
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.societyRepo.Update(ctx, society); err != nil {
+			return fmt.Errorf("failed to update society: %w", err)
+		}
+		if moved {
+			return s.relocateProjects(ctx, society)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return society, nil
+}
+
+// ListSocieties lists societies with filtering and pagination
+func (s *propertyService) ListSocieties(ctx context.Context, filters domain.SocietyFilters) ([]*domain.Society, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.ListSocieties")
+	defer span.End()
+
+	societies, err := s.societyRepo.List(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list societies: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(societies)))
+
+	return societies, nil
+}
+
+// CreateProject creates a new project in an existing society
+func (s *propertyService) CreateProject(ctx context.Context, req *domain.CreateProjectRequest) (*domain.Project, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.CreateProject")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project.name", req.Name),
+		attribute.String("society.id", req.SocietyID.String()),
+	)
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("project name is required: %w", domain.ErrInvalidInput)
+	}
+	if req.ExpectedCompletion != nil && req.StartDate != nil && req.ExpectedCompletion.Before(*req.StartDate) {
+		return nil, fmt.Errorf("expected completion is before the start date: %w", domain.ErrInvalidInput)
+	}
+	society, err := s.societyRepo.GetByID(ctx, req.SocietyID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("society not found: %w", err)
+	}
+	facilities, err := s.locateFacilities(ctx, society, req.NearbyFacilities)
+	if err != nil {
+		return nil, err
+	}
+
+	status := req.Status
+	if status == "" {
+		status = domain.ProjectStatusPlanning
+	}
+
+	now := time.Now()
+	available := 0
+	project := &domain.Project{
+		ID:                 uuid.New(),
+		SocietyID:          req.SocietyID,
+		Name:               req.Name,
+		ProjectType:        req.ProjectType,
+		Status:             status,
+		StartDate:          req.StartDate,
+		ExpectedCompletion: req.ExpectedCompletion,
+		TotalUnits:         req.TotalUnits,
+		AvailableUnits:     &available,
+		BasePrice:          req.BasePrice,
+		PricePerSqft:       req.PricePerSqft,
+		Description:        req.Description,
+		Specifications:     req.Specifications,
+		FloorPlans:         orEmpty(req.FloorPlans),
+		Images:             orEmpty(req.Images),
+		Videos:             orEmpty(req.Videos),
+		BrochureURL:        req.BrochureURL,
+		RERANumber:         req.RERANumber,
+		Amenities:          orEmpty(req.Amenities),
+		NearbyFacilities:   facilities,
+		CreatedBy:          req.CreatedBy,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	if err := s.projectRepo.Create(ctx, project); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return project, nil
+}
+
+// GetProject retrieves a project by ID
+func (s *propertyService) GetProject(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.GetProject")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", id.String()))
+
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project, nil
+}
+
+// UpdateProject applies the fields set in req to a project. Unit counts
+// follow the project's inventory and cannot be set directly, and the
+// distances to nearby facilities are measured from the project's society.
+func (s *propertyService) UpdateProject(ctx context.Context, id uuid.UUID, req *domain.UpdateProjectRequest) (*domain.Project, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.UpdateProject")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", id.String()))
+
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			return nil, fmt.Errorf("project name cannot be empty: %w", domain.ErrInvalidInput)
+		}
+		project.Name = *req.Name
+	}
+	if req.ProjectType != nil {
+		project.ProjectType = req.ProjectType
+	}
+	if req.Status != nil {
+		project.Status = *req.Status
+	}
+	if req.StartDate != nil {
+		project.StartDate = req.StartDate
+	}
+	if req.ExpectedCompletion != nil {
+		project.ExpectedCompletion = req.ExpectedCompletion
+	}
+	if req.ActualCompletion != nil {
+		project.ActualCompletion = req.ActualCompletion
+	}
+	if req.TotalUnits != nil {
+		project.TotalUnits = req.TotalUnits
+	}
+	if req.BasePrice != nil {
+		project.BasePrice = req.BasePrice
+	}
+	if req.PricePerSqft != nil {
+		project.PricePerSqft = req.PricePerSqft
+	}
+	if req.Description != nil {
+		project.Description = req.Description
+	}
+	if req.Specifications != nil {
+		project.Specifications = req.Specifications
+	}
+	if req.FloorPlans != nil {
+		project.FloorPlans = req.FloorPlans
+	}
+	if req.Images != nil {
+		project.Images = req.Images
+	}
+	if req.Videos != nil {
+		project.Videos = req.Videos
+	}
+	if req.BrochureURL != nil {
+		project.BrochureURL = req.BrochureURL
+	}
+	if req.RERANumber != nil {
+		project.RERANumber = req.RERANumber
+	}
+	if req.Amenities != nil {
+		project.Amenities = req.Amenities
+	}
+	if req.NearbyFacilities != nil {
+		society, err := s.societyRepo.GetByID(ctx, project.SocietyID)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to get society: %w", err)
+		}
+		project.NearbyFacilities, err = s.locateFacilities(ctx, society, req.NearbyFacilities)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if project.ExpectedCompletion != nil && project.StartDate != nil && project.ExpectedCompletion.Before(*project.StartDate) {
+		return nil, fmt.Errorf("expected completion is before the start date: %w", domain.ErrInvalidInput)
+	}
+	if project.Status == domain.ProjectStatusCompleted && project.ActualCompletion == nil {
+		completed := time.Now()
+		project.ActualCompletion = &completed
+	}
+
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return project, nil
+}
+
+// ListProjects lists projects with filtering and pagination
+func (s *propertyService) ListProjects(ctx context.Context, filters domain.ProjectFilters) ([]*domain.Project, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.ListProjects")
+	defer span.End()
+
+	projects, err := s.projectRepo.List(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(projects)))
+
+	return projects, nil
+}
+
+// GetProjectsBySociety lists the projects of a society
+func (s *propertyService) GetProjectsBySociety(ctx context.Context, societyID uuid.UUID) ([]*domain.Project, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.GetProjectsBySociety")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("society.id", societyID.String()))
+
+	if _, err := s.societyRepo.GetByID(ctx, societyID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get society: %w", err)
+	}
+
+	projects, err := s.projectRepo.GetBySociety(ctx, societyID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get projects by society: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(projects)))
+
+	return projects, nil
+}
+
+// CreateInventory adds an available unit to a project and recounts the
+// project's units. Units given no price are priced from the project's
+// price list in effect.
+func (s *propertyService) CreateInventory(ctx context.Context, req *domain.CreateInventoryRequest) (*domain.Inventory, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.CreateInventory")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project.id", req.ProjectID.String()),
+		attribute.String("inventory.unit_number", req.UnitNumber),
+	)
+
+	if strings.TrimSpace(req.UnitNumber) == "" {
+		return nil, fmt.Errorf("unit number is required: %w", domain.ErrInvalidInput)
+	}
+	if req.ParkingSlots < 0 || req.Balconies < 0 || req.Bathrooms < 0 {
+		return nil, fmt.Errorf("unit counts cannot be negative: %w", domain.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	unit := &domain.Inventory{
+		ID:               uuid.New(),
+		ProjectID:        req.ProjectID,
+		UnitNumber:       req.UnitNumber,
+		FloorNumber:      req.FloorNumber,
+		TowerBlock:       req.TowerBlock,
+		UnitType:         req.UnitType,
+		CarpetArea:       req.CarpetArea,
+		BuiltUpArea:      req.BuiltUpArea,
+		SuperBuiltUpArea: req.SuperBuiltUpArea,
+		Facing:           req.Facing,
+		Status:           domain.UnitStatusAvailable,
+		BasePrice:        req.BasePrice,
+		FinalPrice:       req.FinalPrice,
+		PricePerSqft:     req.PricePerSqft,
+		ParkingSlots:     req.ParkingSlots,
+		Balconies:        req.Balconies,
+		Bathrooms:        req.Bathrooms,
+		FloorPlanURL:     req.FloorPlanURL,
+		Features:         orEmpty(req.Features),
+		CreatedBy:        req.CreatedBy,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if unit.FinalPrice == nil {
+		unit.FinalPrice = unit.BasePrice
+	}
+
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.projectRepo.GetByID(ctx, req.ProjectID); err != nil {
+			return fmt.Errorf("project not found: %w", err)
+		}
+		if err := s.checkUnitNumber(ctx, unit); err != nil {
+			return err
+		}
+		if err := s.priceNewUnit(ctx, unit); err != nil {
+			return err
+		}
+		if err := s.inventoryRepo.Create(ctx, unit); err != nil {
+			return fmt.Errorf("failed to create inventory: %w", err)
+		}
+		if err := s.projectRepo.RefreshUnitCounts(ctx, unit.ProjectID); err != nil {
+			return fmt.Errorf("failed to update project unit counts: %w", err)
+		}
+		s.publishUnits(ctx, unit)
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return unit, nil
+}
+
+// GetInventory retrieves a unit by ID
+func (s *propertyService) GetInventory(ctx context.Context, id uuid.UUID) (*domain.Inventory, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.GetInventory")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	unit, err := s.inventoryRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get inventory: %w", err)
+	}
+
+	return unit, nil
+}
+
+// UpdateInventory applies the fields set in req to a unit. Only available
+// and blocked units can be moved between those two statuses here;
+// reservations and sales go through the reservation service.
+func (s *propertyService) UpdateInventory(ctx context.Context, id uuid.UUID, req *domain.UpdateInventoryRequest) (*domain.Inventory, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.UpdateInventory")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", id.String()))
+
+	var unit *domain.Inventory
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		unit, err = s.inventoryRepo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get inventory: %w", err)
+		}
+
+		statusChanged := req.Status != nil && *req.Status != unit.Status
+		if statusChanged {
+			if !manualUnitStatus(unit.Status) || !manualUnitStatus(*req.Status) {
+				return fmt.Errorf("unit status cannot change from %s to %s directly: %w",
+					unit.Status, *req.Status, domain.ErrInvalidTransition)
+			}
+			unit.Status = *req.Status
+		}
+
+		if req.UnitNumber != nil {
+			if strings.TrimSpace(*req.UnitNumber) == "" {
+				return fmt.Errorf("unit number cannot be empty: %w", domain.ErrInvalidInput)
+			}
+			if *req.UnitNumber != unit.UnitNumber {
+				unit.UnitNumber = *req.UnitNumber
+				if err := s.checkUnitNumber(ctx, unit); err != nil {
+					return err
+				}
+			}
+		}
+		if req.FloorNumber != nil {
+			unit.FloorNumber = req.FloorNumber
+		}
+		if req.TowerBlock != nil {
+			unit.TowerBlock = req.TowerBlock
+		}
+		if req.UnitType != nil {
+			unit.UnitType = req.UnitType
+		}
+		if req.CarpetArea != nil {
+			unit.CarpetArea = req.CarpetArea
+		}
+		if req.BuiltUpArea != nil {
+			unit.BuiltUpArea = req.BuiltUpArea
+		}
+		if req.SuperBuiltUpArea != nil {
+			unit.SuperBuiltUpArea = req.SuperBuiltUpArea
+		}
+		if req.Facing != nil {
+			unit.Facing = req.Facing
+		}
+		if req.BasePrice != nil {
+			unit.BasePrice = req.BasePrice
+		}
+		if req.FinalPrice != nil {
+			unit.FinalPrice = req.FinalPrice
+		}
+		if req.PricePerSqft != nil {
+			unit.PricePerSqft = req.PricePerSqft
+		}
+		if req.ParkingSlots != nil {
+			unit.ParkingSlots = *req.ParkingSlots
+		}
+		if req.Balconies != nil {
+			unit.Balconies = *req.Balconies
+		}
+		if req.Bathrooms != nil {
+			unit.Bathrooms = *req.Bathrooms
+		}
+		if req.FloorPlanURL != nil {
+			unit.FloorPlanURL = req.FloorPlanURL
+		}
+		if req.Features != nil {
+			unit.Features = req.Features
+		}
+		if unit.ParkingSlots < 0 || unit.Balconies < 0 || unit.Bathrooms < 0 {
+			return fmt.Errorf("unit counts cannot be negative: %w", domain.ErrInvalidInput)
+		}
+
+		if err := s.inventoryRepo.Update(ctx, unit); err != nil {
+			return fmt.Errorf("failed to update inventory: %w", err)
+		}
+		s.publishUnits(ctx, unit)
+		if !statusChanged {
+			return nil
+		}
+		if err := s.projectRepo.RefreshUnitCounts(ctx, unit.ProjectID); err != nil {
+			return fmt.Errorf("failed to update project unit counts: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return unit, nil
+}
+
+// ListInventory lists units with filtering and pagination
+func (s *propertyService) ListInventory(ctx context.Context, filters domain.InventoryFilters) ([]*domain.Inventory, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.ListInventory")
+	defer span.End()
+
+	units, err := s.inventoryRepo.List(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list inventory: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(units)))
+
+	return units, nil
+}
+
+// GetAvailableUnits lists the available units of a project
+func (s *propertyService) GetAvailableUnits(ctx context.Context, projectID uuid.UUID) ([]*domain.Inventory, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.GetAvailableUnits")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+
+	units, err := s.inventoryRepo.GetAvailable(ctx, projectID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get available units: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(units)))
+
+	return units, nil
+}
+
+// SearchProperties finds units matching a buyer's criteria, cheapest first.
+// Location, project type, amenities and the search area narrow the search
+// to the projects that match them; the rest are matched against the units
+// themselves. A search with a centre ranks units by their society's
+// distance from it before price. Only available units are returned unless
+// another status is asked for. The units of every matching project are
+// fetched in one query.
+func (s *propertyService) SearchProperties(ctx context.Context, req *domain.PropertySearchRequest) ([]*domain.Inventory, error) {
+	ctx, span := propertyTracer.Start(ctx, "propertyService.SearchProperties")
+	defer span.End()
+
+	area, err := s.newSearchArea(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	status := domain.UnitStatusAvailable
+	if req.Status != nil {
+		status = *req.Status
+	}
+	filters := domain.InventoryFilters{
+		UnitType: req.UnitType,
+		Status:   &status,
+		Facing:   req.Facing,
+		PriceMin: req.PriceMin,
+		PriceMax: req.PriceMax,
+		AreaMin:  req.AreaMin,
+		AreaMax:  req.AreaMax,
+	}
+
+	if req.ParkingRequired != nil && *req.ParkingRequired {
+		slots := 1
+		filters.MinParkingSlots = &slots
+	}
+
+	var distances map[uuid.UUID]*float64
+	if req.Location != nil || req.ProjectType != nil || len(req.Amenities) > 0 || area.active() {
+		var projects []*domain.Project
+		projects, distances, err = s.matchingProjects(ctx, req, area)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		if len(projects) == 0 {
+			span.SetAttributes(attribute.Int("result.count", 0))
+			return []*domain.Inventory{}, nil
+		}
+		for _, project := range projects {
+			filters.ProjectIDs = append(filters.ProjectIDs, project.ID)
+		}
+	}
+
+	// The store pages a search by price. Ranking by distance from a centre
+	// it does not know about needs every unit in the area, so that search
+	// is paged here instead.
+	filters.SortBy, filters.SortOrder = "final_price", "asc"
+	if area.centre == nil {
+		filters.Limit, filters.Offset = req.Limit, req.Offset
+	}
+	results, err := s.inventoryRepo.List(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to search inventory: %w", err)
+	}
+	if results == nil {
+		results = []*domain.Inventory{}
+	}
+	for _, unit := range results {
+		unit.DistanceKm = distances[unit.ProjectID]
+	}
+
+	if area.centre != nil {
+		sort.SliceStable(results, func(i, j int) bool {
+			return distanceLess(results[i].DistanceKm, results[j].DistanceKm)
+		})
+		if req.Offset > 0 {
+			if req.Offset >= len(results) {
+				results = []*domain.Inventory{}
+			} else {
+				results = results[req.Offset:]
+			}
+		}
+		if req.Limit > 0 && req.Limit < len(results) {
+			results = results[:req.Limit]
+		}
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(results)))
+
+	return results, nil
+}
+
+// matchingProjects returns the projects whose type, society location and
+// amenities (the project's together with its society's) satisfy req and
+// whose society lies in the search area, with the distance of each one's
+// society from the centre of the area
+func (s *propertyService) matchingProjects(ctx context.Context, req *domain.PropertySearchRequest, area *searchArea) ([]*domain.Project, map[uuid.UUID]*float64, error) {
+	projects, err := s.projectRepo.List(ctx, domain.ProjectFilters{ProjectType: req.ProjectType})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	societies := map[uuid.UUID]*domain.Society{}
+	matched := []*domain.Project{}
+	distances := map[uuid.UUID]*float64{}
+	for _, project := range projects {
+		society, ok := societies[project.SocietyID]
+		if !ok {
+			society, err = s.societyRepo.GetByID(ctx, project.SocietyID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get society of project %s: %w", project.ID, err)
+			}
+			societies[project.SocietyID] = society
+		}
+
+		if req.Location != nil && !area.byLocation && !containsFold(society.Location, *req.Location) {
+			continue
+		}
+		if !hasAmenities(req.Amenities, project.Amenities, society.Amenities) {
+			continue
+		}
+		distance, ok := area.locate(society)
+		if !ok {
+			continue
+		}
+		matched = append(matched, project)
+		distances[project.ID] = distance
+	}
+	return matched, distances, nil
+}
+
+// checkUnitNumber rejects a unit number already used in the unit's project
+func (s *propertyService) checkUnitNumber(ctx context.Context, unit *domain.Inventory) error {
+	units, err := s.inventoryRepo.GetByProject(ctx, unit.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project inventory: %w", err)
+	}
+	for _, other := range units {
+		if other.ID != unit.ID && strings.EqualFold(other.UnitNumber, unit.UnitNumber) {
+			return fmt.Errorf("unit %s already exists in the project: %w", unit.UnitNumber, domain.ErrAlreadyExists)
+		}
+	}
+	return nil
+}
+
+// publishUnits publishes units to the unit feed once the unit of work in
+// ctx commits
+func (s *propertyService) publishUnits(ctx context.Context, units ...*domain.Inventory) {
+	if s.feed == nil || len(units) == 0 {
+		return
+	}
+	domain.AfterCommit(ctx, func() { s.feed.Publish(units...) })
+}
+
+// manualUnitStatus reports whether a unit status may be set by hand
+func manualUnitStatus(status domain.UnitStatus) bool {
+	return status == domain.UnitStatusAvailable || status == domain.UnitStatusBlocked
+}
+
+// hasAmenities reports whether every wanted amenity is in one of the lists
+func hasAmenities(wanted []string, lists ...[]string) bool {
+	for _, amenity := range wanted {
+		found := false
+		for _, list := range lists {
+			for _, have := range list {
+				if strings.EqualFold(have, amenity) {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold reports whether substr is within s, ignoring case
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(strings.TrimSpace(substr)))
+}
+
+// orEmpty returns an empty slice in place of nil so array columns are
+// stored as empty arrays
+func orEmpty(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,605 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/infrastructure/geocoding"
+	"goreal-backend/internal/infrastructure/memory"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPropertyService(t *testing.T) (domain.PropertyService, *memory.Store, context.Context) {
+	t.Helper()
+	store := memory.NewStore()
+	inventory := memory.NewInventoryRepository(store)
+	projects := memory.NewProjectRepository(store)
+	sales := memory.NewSaleRepository(store)
+	clients := memory.NewClientRepository(store)
+	uow := memory.NewUnitOfWork(store)
+	feed := NewUnitFeed()
+	reservations := NewReservationService(inventory, projects, sales, clients, uow, nil, feed)
+	geocoder, err := geocoding.NewOfflineGeocoder("")
+	require.NoError(t, err)
+	return NewPropertyService(memory.NewSocietyRepository(store), projects, inventory,
+		memory.NewPropertyInterestRepository(store), memory.NewPriceListRepository(store), clients, memory.NewLeadRepository(store), sales,
+		uow, reservations, feed, geocoder), store, domain.WithOrganization(context.Background(), uuid.New())
+}
+
+func TestPropertyService_UnitsAndCounts(t *testing.T) {
+	s, _, ctx := newTestPropertyService(t)
+	manager := uuid.New()
+
+	_, err := s.CreateProject(ctx, &domain.CreateProjectRequest{SocietyID: uuid.New(), Name: "Orphan"})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "project in a missing society: %v", err)
+
+	society, err := s.CreateSociety(ctx, &domain.CreateSocietyRequest{Name: "Green Acres", Location: "Pune West",
+		Amenities: []string{"Pool"}, CreatedBy: manager})
+	require.NoError(t, err)
+	project, err := s.CreateProject(ctx, &domain.CreateProjectRequest{SocietyID: society.ID, Name: "Tower A",
+		ProjectType: ptrTo("residential"), CreatedBy: manager})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProjectStatusPlanning, project.Status)
+
+	var units []*domain.Inventory
+	for i, price := range []float64{9000000, 5000000, 7000000} {
+		unit, err := s.CreateInventory(ctx, &domain.CreateInventoryRequest{ProjectID: project.ID,
+			UnitNumber: []string{"A-101", "A-102", "A-103"}[i], BasePrice: &price, ParkingSlots: i, CreatedBy: manager})
+		require.NoError(t, err)
+		assert.Equal(t, &price, unit.FinalPrice, "final price defaults to the base price")
+		units = append(units, unit)
+	}
+	_, err = s.CreateInventory(ctx, &domain.CreateInventoryRequest{ProjectID: project.ID, UnitNumber: "a-101"})
+	assert.True(t, errors.Is(err, domain.ErrAlreadyExists), "duplicate unit number: %v", err)
+
+	blocked := domain.UnitStatusBlocked
+	_, err = s.UpdateInventory(ctx, units[0].ID, &domain.UpdateInventoryRequest{Status: &blocked})
+	require.NoError(t, err)
+	require.NoError(t, s.ReserveUnit(ctx, units[1].ID, uuid.New(), time.Hour))
+
+	sold := domain.UnitStatusSold
+	_, err = s.UpdateInventory(ctx, units[2].ID, &domain.UpdateInventoryRequest{Status: &sold})
+	assert.True(t, errors.Is(err, domain.ErrInvalidTransition), "selling by hand: %v", err)
+	available := domain.UnitStatusAvailable
+	_, err = s.UpdateInventory(ctx, units[1].ID, &domain.UpdateInventoryRequest{Status: &available})
+	assert.True(t, errors.Is(err, domain.ErrInvalidTransition), "releasing by hand: %v", err)
+
+	project, err = s.GetProject(ctx, project.ID)
+	require.NoError(t, err)
+	require.NotNil(t, project.AvailableUnits)
+	assert.Equal(t, 1, *project.AvailableUnits)
+	assert.Equal(t, 2, project.BlockedUnits)
+
+	got, err := s.SearchProperties(ctx, &domain.PropertySearchRequest{Location: ptrTo("pune"), Amenities: []string{"pool"}})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, units[2].ID, got[0].ID)
+
+	require.NoError(t, s.ReleaseUnit(ctx, units[1].ID))
+	_, err = s.UpdateInventory(ctx, units[0].ID, &domain.UpdateInventoryRequest{Status: &available})
+	require.NoError(t, err)
+
+	got, err = s.SearchProperties(ctx, &domain.PropertySearchRequest{ProjectType: ptrTo("residential")})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{units[1].ID, units[2].ID, units[0].ID}, unitIDs(got), "cheapest first")
+
+	got, err = s.SearchProperties(ctx, &domain.PropertySearchRequest{ParkingRequired: ptrTo(true), Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{units[1].ID}, unitIDs(got), "A-101 has no parking")
+
+	got, err = s.SearchProperties(ctx, &domain.PropertySearchRequest{Location: ptrTo("Mumbai")})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+// listCounter records the filters of every inventory List call
+type listCounter struct {
+	domain.InventoryRepository
+	calls []domain.InventoryFilters
+}
+
+func (r *listCounter) List(ctx context.Context, filters domain.InventoryFilters) ([]*domain.Inventory, error) {
+	r.calls = append(r.calls, filters)
+	return r.InventoryRepository.List(ctx, filters)
+}
+
+func TestPropertyService_SearchIsOneQuery(t *testing.T) {
+	store := memory.NewStore()
+	inventory := &listCounter{InventoryRepository: memory.NewInventoryRepository(store)}
+	projects := memory.NewProjectRepository(store)
+	geocoder, err := geocoding.NewOfflineGeocoder("")
+	require.NoError(t, err)
+	s := NewPropertyService(memory.NewSocietyRepository(store), projects, inventory,
+		memory.NewPropertyInterestRepository(store), memory.NewPriceListRepository(store), memory.NewClientRepository(store),
+		memory.NewLeadRepository(store), memory.NewSaleRepository(store), memory.NewUnitOfWork(store), nil, NewUnitFeed(), geocoder)
+	ctx := domain.WithOrganization(context.Background(), uuid.New())
+	manager := uuid.New()
+
+	var units []*domain.Inventory
+	for i, location := range []string{"Pune West", "Pune East", "Mumbai"} {
+		society, err := s.CreateSociety(ctx, &domain.CreateSocietyRequest{Name: location, Location: location, CreatedBy: manager})
+		require.NoError(t, err)
+		project, err := s.CreateProject(ctx, &domain.CreateProjectRequest{SocietyID: society.ID, Name: location, CreatedBy: manager})
+		require.NoError(t, err)
+		for _, price := range []float64{float64(3 - i), float64(6 - i)} {
+			unit, err := s.CreateInventory(ctx, &domain.CreateInventoryRequest{ProjectID: project.ID,
+				UnitNumber: uuid.NewString()[:8], BasePrice: &price, ParkingSlots: 1, CreatedBy: manager})
+			require.NoError(t, err)
+			units = append(units, unit)
+		}
+	}
+
+	inventory.calls = nil
+	got, err := s.SearchProperties(ctx, &domain.PropertySearchRequest{Location: ptrTo("pune"), ParkingRequired: ptrTo(true),
+		Limit: 2, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{units[0].ID, units[3].ID}, unitIDs(got), "the second and third cheapest in Pune")
+
+	require.Len(t, inventory.calls, 1, "one query for every matching project")
+	filters := inventory.calls[0]
+	assert.ElementsMatch(t, []uuid.UUID{units[0].ProjectID, units[2].ProjectID}, filters.ProjectIDs)
+	assert.Equal(t, 2, filters.Limit)
+	assert.Equal(t, 1, filters.Offset)
+	require.NotNil(t, filters.MinParkingSlots)
+	assert.Equal(t, 1, *filters.MinParkingSlots)
+}
+
+func TestPropertyService_Recommendations(t *testing.T) {
+	s, store, ctx := newTestPropertyService(t)
+	manager := uuid.New()
+
+	pune, err := s.CreateSociety(ctx, &domain.CreateSocietyRequest{Name: "Green Acres", Location: "Pune West",
+		Amenities: []string{"Gym"}, CreatedBy: manager})
+	require.NoError(t, err)
+	mumbai, err := s.CreateSociety(ctx, &domain.CreateSocietyRequest{Name: "Blue Bay", Location: "Mumbai",
+		CreatedBy: manager})
+	require.NoError(t, err)
+	skyline, err := s.CreateProject(ctx, &domain.CreateProjectRequest{SocietyID: pune.ID, Name: "Skyline", CreatedBy: manager})
+	require.NoError(t, err)
+	harbour, err := s.CreateProject(ctx, &domain.CreateProjectRequest{SocietyID: mumbai.ID, Name: "Harbour", CreatedBy: manager})
+	require.NoError(t, err)
+
+	unit := func(project *domain.Project, number, unitType string, price float64) *domain.Inventory {
+		created, err := s.CreateInventory(ctx, &domain.CreateInventoryRequest{ProjectID: project.ID, UnitNumber: number,
+			UnitType: &unitType, BasePrice: &price, CreatedBy: manager})
+		require.NoError(t, err)
+		return created
+	}
+	a101 := unit(skyline, "A-101", "2BHK", 5000000)
+	unit(skyline, "A-102", "3BHK", 8000000)
+	a103 := unit(skyline, "A-103", "2BHK", 5400000)
+	unit(skyline, "A-104", "2BHK", 6000000)
+	h1 := unit(harbour, "H-1", "2BHK", 4500000)
+
+	leads, clients := memory.NewLeadRepository(store), memory.NewClientRepository(store)
+	lead := &domain.Lead{ID: uuid.New(), Name: "Asha", Source: domain.LeadSourceWebsite, Status: domain.LeadStatusConverted,
+		BudgetMin: ptrTo(4000000.0), BudgetMax: ptrTo(5000000.0), Requirements: ptrTo("2 BHK with a gym"),
+		CreatedBy: manager}
+	require.NoError(t, leads.Create(ctx, lead))
+	client := &domain.Client{ID: uuid.New(), LeadID: &lead.ID, ClientType: domain.ClientTypeIndividual, Name: "Asha",
+		Tags: []string{"investor"}, CreatedBy: manager}
+	require.NoError(t, clients.Create(ctx, client))
+
+	// An investor like the client bought a 2BHK in Skyline
+	peer := &domain.Client{ID: uuid.New(), ClientType: domain.ClientTypeInvestor, Name: "Ravi",
+		Tags: []string{"investor"}, CreatedBy: manager}
+	require.NoError(t, clients.Create(ctx, peer))
+	sold := unit(skyline, "A-100", "2BHK", 5000000)
+	require.NoError(t, memory.NewSaleRepository(store).Create(ctx, &domain.Sale{ID: uuid.New(), SaleNumber: "SAL-1",
+		ClientID: peer.ID, InventoryID: sold.ID, Status: domain.SaleStatusApproved, CreatedBy: manager}))
+	require.NoError(t, s.ReserveUnit(ctx, sold.ID, peer.ID, time.Hour))
+
+	_, err = s.RecordInterest(ctx, client.ID, &domain.RecordInterestRequest{InventoryID: h1.ID, Kind: "liked"})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "unknown kind: %v", err)
+	_, err = s.RecordInterest(ctx, uuid.New(), &domain.RecordInterestRequest{InventoryID: h1.ID, Kind: domain.PropertyInterestViewed})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "missing client: %v", err)
+	viewed, err := s.RecordInterest(ctx, client.ID, &domain.RecordInterestRequest{InventoryID: h1.ID,
+		Kind: domain.PropertyInterestViewed, RecordedBy: manager})
+	require.NoError(t, err)
+	again, err := s.RecordInterest(ctx, client.ID, &domain.RecordInterestRequest{InventoryID: h1.ID,
+		Kind: domain.PropertyInterestViewed, RecordedBy: manager})
+	require.NoError(t, err)
+	assert.Equal(t, viewed.ID, again.ID, "recording an interest twice keeps the first")
+
+	got, err := s.GetPropertyRecommendations(ctx, client.ID, 0)
+	require.NoError(t, err)
+	ids := make([]uuid.UUID, len(got))
+	for i, rec := range got {
+		ids[i] = rec.Inventory.ID
+	}
+	assert.Equal(t, []uuid.UUID{a101.ID, h1.ID, a103.ID}, ids, "A-102 and A-104 are over budget")
+
+	assert.Equal(t, 85, got[0].Score)
+	assert.Equal(t, []string{
+		"priced within the client's budget",
+		`matches the requirement "2bhk"`,
+		`matches the requirement "gym"`,
+		"a 2BHK like the units the client looked at",
+		"similar clients bought a 2BHK in Skyline",
+	}, got[0].Reasons)
+	assert.Contains(t, got[1].Reasons, "viewed by the client")
+	assert.Contains(t, got[2].Reasons, "priced up to 10% over the client's budget")
+
+	got, err = s.GetPropertyRecommendations(ctx, client.ID, 1)
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	_, err = s.GetPropertyRecommendations(ctx, uuid.New(), 0)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "missing client: %v", err)
+}
+
+func TestPropertyService_PriceLists(t *testing.T) {
+	s, _, ctx := newTestPropertyService(t)
+	manager := uuid.New()
+
+	society, err := s.CreateSociety(ctx, &domain.CreateSocietyRequest{Name: "Green Acres", Location: "Pune", CreatedBy: manager})
+	require.NoError(t, err)
+	project, err := s.CreateProject(ctx, &domain.CreateProjectRequest{SocietyID: society.ID, Name: "Skyline", CreatedBy: manager})
+	require.NoError(t, err)
+
+	_, err = s.CreatePriceList(ctx, project.ID, &domain.CreatePriceListRequest{BaseRate: 0})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "no base rate: %v", err)
+	_, err = s.CreatePriceList(ctx, uuid.New(), &domain.CreatePriceListRequest{BaseRate: 5000})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "missing project: %v", err)
+
+	launch, err := s.CreatePriceList(ctx, project.ID, &domain.CreatePriceListRequest{
+		EffectiveFrom: ptrTo(time.Now().Add(-time.Hour)), BaseRate: 5000, FloorRise: 50, FloorRiseFrom: 2,
+		FacingPremiums: map[string]float64{"East": 100}, PLCs: map[string]float64{"Corner": 200}, CreatedBy: manager})
+	require.NoError(t, err)
+	assert.Equal(t, 1, launch.Version)
+
+	// Floor 3 pays two floors of rise: 5000 + 100 + 100 + 200 a square foot
+	corner, err := s.CreateInventory(ctx, &domain.CreateInventoryRequest{ProjectID: project.ID, UnitNumber: "A-301",
+		FloorNumber: ptrTo(3), SuperBuiltUpArea: ptrTo(1000.0), Facing: ptrTo("east"),
+		Features: []string{"corner"}, CreatedBy: manager})
+	require.NoError(t, err)
+	assert.Equal(t, ptrTo(5400000.0), corner.FinalPrice)
+	assert.Equal(t, ptrTo(5000000.0), corner.BasePrice)
+	assert.Equal(t, ptrTo(5400.0), corner.PricePerSqft)
+	plain, err := s.CreateInventory(ctx, &domain.CreateInventoryRequest{ProjectID: project.ID, UnitNumber: "A-101",
+		FloorNumber: ptrTo(1), CarpetArea: ptrTo(800.0), CreatedBy: manager})
+	require.NoError(t, err)
+	assert.Equal(t, ptrTo(4000000.0), plain.FinalPrice)
+	unpriced, err := s.CreateInventory(ctx, &domain.CreateInventoryRequest{ProjectID: project.ID, UnitNumber: "A-102",
+		CreatedBy: manager})
+	require.NoError(t, err)
+	assert.Nil(t, unpriced.FinalPrice, "no area to price by")
+
+	revised, err := s.CreatePriceList(ctx, project.ID, &domain.CreatePriceListRequest{BaseRate: 5500, CreatedBy: manager})
+	require.NoError(t, err)
+	announced, err := s.CreatePriceList(ctx, project.ID, &domain.CreatePriceListRequest{
+		EffectiveFrom: ptrTo(time.Now().Add(24 * time.Hour)), BaseRate: 6000, CreatedBy: manager})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 2}, []int{announced.Version, revised.Version})
+
+	versions, err := s.ListPriceLists(ctx, project.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, 3, versions[0].Version)
+	current, err := s.GetEffectivePriceList(ctx, project.ID, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, revised.ID, current.ID)
+
+	_, err = s.ApplyPriceList(ctx, project.ID, 3, false)
+	assert.True(t, errors.Is(err, domain.ErrConflict), "version not in effect yet: %v", err)
+
+	require.NoError(t, s.ReserveUnit(ctx, plain.ID, uuid.New(), time.Hour))
+	preview, err := s.ApplyPriceList(ctx, project.ID, 2, true)
+	require.NoError(t, err)
+	assert.True(t, preview.DryRun)
+	require.Len(t, preview.Changes, 1)
+	assert.Equal(t, corner.ID, preview.Changes[0].InventoryID)
+	assert.Equal(t, 5500000.0, preview.Changes[0].NewPrice)
+	assert.Equal(t, 100000.0, preview.Changes[0].Difference)
+	assert.Equal(t, 1, preview.Skipped, "A-101 is reserved")
+	require.Len(t, preview.Unpriced, 1)
+	assert.Equal(t, "A-102", preview.Unpriced[0].UnitNumber)
+
+	unit, err := s.GetInventory(ctx, corner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ptrTo(5400000.0), unit.FinalPrice, "a dry run changes nothing")
+
+	applied, err := s.ApplyPriceList(ctx, project.ID, 2, false)
+	require.NoError(t, err)
+	assert.Len(t, applied.Changes, 1)
+	unit, err = s.GetInventory(ctx, corner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ptrTo(5500000.0), unit.FinalPrice)
+
+	again, err := s.ApplyPriceList(ctx, project.ID, 2, false)
+	require.NoError(t, err)
+	assert.Empty(t, again.Changes)
+	assert.Equal(t, 1, again.Unchanged)
+}
+
+func TestPropertyService_ImportInventory(t *testing.T) {
+	s, _, ctx := newTestPropertyService(t)
+	manager := uuid.New()
+
+	society, err := s.CreateSociety(ctx, &domain.CreateSocietyRequest{Name: "Green Acres", Location: "Pune", CreatedBy: manager})
+	require.NoError(t, err)
+	project, err := s.CreateProject(ctx, &domain.CreateProjectRequest{SocietyID: society.ID, Name: "Skyline", CreatedBy: manager})
+	require.NoError(t, err)
+	_, err = s.CreateInventory(ctx, &domain.CreateInventoryRequest{ProjectID: project.ID, UnitNumber: "B-1",
+		BasePrice: ptrTo(1000000.0), CreatedBy: manager})
+	require.NoError(t, err)
+
+	result, err := s.ImportInventory(ctx, &domain.InventoryImportRequest{ProjectID: project.ID,
+		Rows: [][]string{{"Unit Number", "Colour"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ImportRowError{{Row: 1, Column: "Colour", Message: "unknown column"}}, result.Errors)
+
+	rows := [][]string{
+		{"Unit Number", "Floor Number", "Carpet Area", "Base Price", "Features"},
+		{"A-1", "2", "900", "", "Corner; Park View"},
+		{"a-1", "2", "900", "", ""},
+		{"A-2", "two", "900", "", ""},
+		{"A-3", "", "", "", ""},
+		{"b-1", "1", "900", "4,500,000", ""},
+		{"", "", "", "", ""},
+		{"A-4", "1", "1,000", "4,500,000", ""},
+	}
+	result, err = s.ImportInventory(ctx, &domain.InventoryImportRequest{ProjectID: project.ID, Rows: rows, CreatedBy: manager})
+	require.NoError(t, err)
+	var failed []int
+	for _, rowErr := range result.Errors {
+		failed = append(failed, rowErr.Row)
+	}
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, failed, "%v", result.Errors)
+	assert.Contains(t, result.Errors[0].Message, "no price list in effect")
+	assert.Equal(t, 6, result.Rows, "the blank row is not counted")
+	assert.Zero(t, result.Created)
+
+	_, err = s.CreatePriceList(ctx, project.ID, &domain.CreatePriceListRequest{
+		EffectiveFrom: ptrTo(time.Now().Add(-time.Minute)), BaseRate: 5000, PLCs: map[string]float64{"park view": 500},
+		CreatedBy: manager})
+	require.NoError(t, err)
+	rows = [][]string{rows[0], rows[1], rows[7]}
+
+	result, err = s.ImportInventory(ctx, &domain.InventoryImportRequest{ProjectID: project.ID, Rows: rows,
+		DryRun: true, CreatedBy: manager})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	require.Len(t, result.Units, 2)
+	assert.Zero(t, result.Created)
+	assert.Equal(t, ptrTo(4950000.0), result.Units[0].FinalPrice, "900 sq ft at 5000 plus 500 for the park view")
+	assert.Equal(t, []string{"Corner", "Park View"}, result.Units[0].Features)
+	assert.Equal(t, ptrTo(4500.0), result.Units[1].PricePerSqft)
+
+	units, err := s.GetAvailableUnits(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Len(t, units, 1, "a dry run creates nothing")
+
+	result, err = s.ImportInventory(ctx, &domain.InventoryImportRequest{ProjectID: project.ID, Rows: rows, CreatedBy: manager})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	project, err = s.GetProject(ctx, project.ID)
+	require.NoError(t, err)
+	require.NotNil(t, project.AvailableUnits)
+	assert.Equal(t, 3, *project.AvailableUnits)
+
+	_, err = s.ImportInventory(ctx, &domain.InventoryImportRequest{ProjectID: uuid.New(), Rows: rows})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "missing project: %v", err)
+	_, err = s.ImportInventory(ctx, &domain.InventoryImportRequest{ProjectID: project.ID})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "empty file: %v", err)
+}
+
+func TestPropertyService_AvailabilityMatrix(t *testing.T) {
+	s, _, ctx := newTestPropertyService(t)
+	manager := uuid.New()
+
+	society, err := s.CreateSociety(ctx, &domain.CreateSocietyRequest{Name: "Green Acres", Location: "Pune", CreatedBy: manager})
+	require.NoError(t, err)
+	project, err := s.CreateProject(ctx, &domain.CreateProjectRequest{SocietyID: society.ID, Name: "Skyline", CreatedBy: manager})
+	require.NoError(t, err)
+
+	units := map[string]*domain.Inventory{}
+	for _, u := range []struct {
+		tower, number, unitType, facing string
+		floor                           int
+	}{
+		{"Tower 10", "1001", "2BHK", "North", 1},
+		{"Tower 2", "A-1001", "3BHK", "North", 10},
+		{"Tower 2", "A-901", "2BHK", "East", 9},
+		{"Tower 2", "A-902", "2BHK", "north", 9},
+		{"Tower 2", "A-1002", "2BHK", "South", 10},
+	} {
+		unit, err := s.CreateInventory(ctx, &domain.CreateInventoryRequest{ProjectID: project.ID, TowerBlock: ptrTo(u.tower),
+			FloorNumber: ptrTo(u.floor), UnitNumber: u.number, UnitType: ptrTo(u.unitType), Facing: ptrTo(u.facing),
+			BasePrice: ptrTo(5000000.0), CreatedBy: manager})
+		require.NoError(t, err)
+		units[u.number] = unit
+	}
+	require.NoError(t, s.ReserveUnit(ctx, units["A-901"].ID, uuid.New(), time.Hour))
+
+	matrix, err := s.GetAvailabilityMatrix(ctx, project.ID, domain.AvailabilityFilters{})
+	require.NoError(t, err)
+	assert.Equal(t, 5, matrix.TotalUnits)
+	assert.Equal(t, map[domain.UnitStatus]int{domain.UnitStatusAvailable: 4, domain.UnitStatusReserved: 1}, matrix.Summary)
+	require.Len(t, matrix.Towers, 2)
+	tower := matrix.Towers[0]
+	assert.Equal(t, "Tower 2", tower.Name, "towers in natural order")
+	assert.Equal(t, 2, tower.Width)
+	require.Len(t, tower.Floors, 2)
+	assert.Equal(t, ptrTo(10), tower.Floors[0].Floor, "top floor first")
+	assert.Equal(t, "A-901", tower.Floors[1].Units[0].UnitNumber)
+	assert.Equal(t, domain.UnitStatusReserved, tower.Floors[1].Units[0].Status)
+	assert.NotNil(t, tower.Floors[1].Units[0].ReservedUntil)
+
+	matrix, err = s.GetAvailabilityMatrix(ctx, project.ID, domain.AvailabilityFilters{UnitType: ptrTo("2bhk"), Facing: ptrTo("North")})
+	require.NoError(t, err)
+	assert.Equal(t, 2, matrix.TotalUnits)
+
+	_, err = s.GetAvailabilityMatrix(ctx, uuid.New(), domain.AvailabilityFilters{})
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "missing project: %v", err)
+
+	watchCtx, stop := context.WithCancel(ctx)
+	changes, err := s.WatchAvailability(watchCtx, project.ID, domain.AvailabilityFilters{UnitType: ptrTo("2BHK")})
+	require.NoError(t, err)
+
+	go func() {
+		s.ReserveUnit(ctx, units["A-1001"].ID, uuid.New(), time.Hour)
+		s.ReleaseUnit(ctx, units["A-901"].ID)
+	}()
+	select {
+	case change := <-changes:
+		assert.Equal(t, units["A-901"].ID, change.InventoryID, "the 3BHK is filtered out")
+		assert.Equal(t, domain.UnitStatusAvailable, change.Status)
+		assert.Equal(t, "Tower 2", change.TowerBlock)
+		assert.Nil(t, change.ReservedUntil)
+	case <-time.After(time.Second):
+		t.Fatal("no change streamed")
+	}
+
+	stop()
+	for range changes {
+	}
+}
+
+func TestPropertyService_GeoSearch(t *testing.T) {
+	s, _, ctx := newTestPropertyService(t)
+	manager := uuid.New()
+
+	_, err := s.CreateSociety(ctx, &domain.CreateSocietyRequest{Name: "Half", Location: "Pune", Latitude: ptrTo(18.5),
+		CreatedBy: manager})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "latitude without longitude: %v", err)
+
+	units := map[string]*domain.Inventory{}
+	societies := map[string]*domain.Society{}
+	for _, c := range []struct {
+		name, location string
+		latitude       *float64
+		longitude      *float64
+		price          float64
+	}{
+		{"Koramangala Court", "Koramangala", ptrTo(12.9352), ptrTo(77.6245), 9000000},
+		{"Whitefield Greens", "Whitefield, Bangalore", nil, nil, 6000000},
+		{"Bay View", "Mumbai", nil, nil, 7000000},
+		{"Nowhere Homes", "Atlantis", nil, nil, 5000000},
+	} {
+		society, err := s.CreateSociety(ctx, &domain.CreateSocietyRequest{Name: c.name, Location: c.location,
+			Latitude: c.latitude, Longitude: c.longitude, CreatedBy: manager})
+		require.NoError(t, err)
+		project, err := s.CreateProject(ctx, &domain.CreateProjectRequest{SocietyID: society.ID, Name: c.name, CreatedBy: manager})
+		require.NoError(t, err)
+		unit, err := s.CreateInventory(ctx, &domain.CreateInventoryRequest{ProjectID: project.ID, UnitNumber: "101",
+			BasePrice: ptrTo(c.price), CreatedBy: manager})
+		require.NoError(t, err)
+		societies[c.name], units[c.name] = society, unit
+	}
+	require.NotNil(t, societies["Whitefield Greens"].Latitude, "geocoded from the location")
+	assert.InDelta(t, 12.9698, *societies["Whitefield Greens"].Latitude, 0.0001)
+	assert.Nil(t, societies["Nowhere Homes"].Latitude, "unknown places stay off the map")
+
+	unitIDs := func(found []*domain.Inventory) []uuid.UUID {
+		ids := []uuid.UUID{}
+		for _, unit := range found {
+			ids = append(ids, unit.ID)
+		}
+		return ids
+	}
+
+	bangalore := &domain.GeoPoint{Latitude: 12.9716, Longitude: 77.5946}
+	found, err := s.SearchProperties(ctx, &domain.PropertySearchRequest{Near: bangalore, RadiusKm: ptrTo(20.0)})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{units["Koramangala Court"].ID, units["Whitefield Greens"].ID}, unitIDs(found),
+		"nearest first, whatever the price")
+	require.NotNil(t, found[0].DistanceKm)
+	assert.InDelta(t, 5.2, *found[0].DistanceKm, 0.2)
+
+	found, err = s.SearchProperties(ctx, &domain.PropertySearchRequest{Location: ptrTo("Bengaluru"), RadiusKm: ptrTo(20.0)})
+	require.NoError(t, err)
+	assert.Len(t, found, 2, "a radius around a geocoded location replaces matching it by name")
+
+	found, err = s.SearchProperties(ctx, &domain.PropertySearchRequest{Near: bangalore})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{units["Koramangala Court"].ID, units["Whitefield Greens"].ID, units["Bay View"].ID,
+		units["Nowhere Homes"].ID}, unitIDs(found), "a centre alone only ranks")
+
+	found, err = s.SearchProperties(ctx, &domain.PropertySearchRequest{BoundingBox: &domain.GeoBoundingBox{
+		MinLatitude: 18.8, MinLongitude: 72.7, MaxLatitude: 19.3, MaxLongitude: 73.1}})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{units["Bay View"].ID}, unitIDs(found))
+	assert.Nil(t, found[0].DistanceKm, "no centre, no distance")
+
+	found, err = s.SearchProperties(ctx, &domain.PropertySearchRequest{Polygon: []domain.GeoPoint{
+		{Latitude: 12.90, Longitude: 77.70}, {Latitude: 13.05, Longitude: 77.72}, {Latitude: 12.98, Longitude: 77.80}}})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{units["Whitefield Greens"].ID}, unitIDs(found))
+
+	for name, req := range map[string]*domain.PropertySearchRequest{
+		"zero radius":        {Near: bangalore, RadiusKm: ptrTo(0.0)},
+		"radius, no centre":  {RadiusKm: ptrTo(5.0)},
+		"unknown location":   {Location: ptrTo("Atlantis"), RadiusKm: ptrTo(5.0)},
+		"inverted box":       {BoundingBox: &domain.GeoBoundingBox{MinLatitude: 20, MaxLatitude: 10}},
+		"two-point polygon":  {Polygon: []domain.GeoPoint{{Latitude: 1, Longitude: 1}, {Latitude: 2, Longitude: 2}}},
+		"centre off the map": {Near: &domain.GeoPoint{Latitude: 91}},
+	} {
+		_, err := s.SearchProperties(ctx, req)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput), "%s: %v", name, err)
+	}
+
+	project, err := s.CreateProject(ctx, &domain.CreateProjectRequest{SocietyID: societies["Koramangala Court"].ID,
+		Name: "Court Phase 2", CreatedBy: manager, NearbyFacilities: []domain.NearbyFacility{
+			{Name: "Tech Park", Category: "office"},
+			{Name: "Whitefield", Category: "neighbourhood"},
+			{Name: "Forum Mall", Category: "shopping", Latitude: ptrTo(12.9345), Longitude: ptrTo(77.6112)},
+		}})
+	require.NoError(t, err)
+	require.Len(t, project.NearbyFacilities, 3)
+	assert.Equal(t, "Forum Mall", project.NearbyFacilities[0].Name, "nearest facility first")
+	assert.InDelta(t, 1.45, *project.NearbyFacilities[0].DistanceKm, 0.1)
+	assert.Equal(t, "Whitefield", project.NearbyFacilities[1].Name, "placed by the geocoder")
+	assert.NotNil(t, project.NearbyFacilities[1].DistanceKm)
+	assert.Nil(t, project.NearbyFacilities[2].DistanceKm, "facilities that cannot be placed come last")
+
+	_, err = s.UpdateProject(ctx, project.ID, &domain.UpdateProjectRequest{NearbyFacilities: []domain.NearbyFacility{{Name: " "}}})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "facility without a name: %v", err)
+
+	_, err = s.UpdateSociety(ctx, societies["Koramangala Court"].ID, &domain.UpdateSocietyRequest{
+		Latitude: ptrTo(12.9345), Longitude: ptrTo(77.6112)})
+	require.NoError(t, err)
+	project, err = s.GetProject(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, *project.NearbyFacilities[0].DistanceKm, "distances follow the society when it moves")
+}
+
+func TestUnitFeed_DropsSlowWatchers(t *testing.T) {
+	feed := NewUnitFeed()
+	unit := &domain.Inventory{ID: uuid.New(), ProjectID: uuid.New(), Status: domain.UnitStatusSold}
+
+	slow, _ := feed.Subscribe(unit.ProjectID)
+	other, unsubscribe := feed.Subscribe(uuid.New())
+	for i := 0; i <= unitWatcherBuffer; i++ {
+		feed.Publish(unit)
+	}
+
+	received := 0
+	for range slow {
+		received++
+	}
+	assert.Equal(t, unitWatcherBuffer, received, "the channel is closed once the watcher falls behind")
+
+	unsubscribe()
+	unsubscribe()
+	_, open := <-other
+	assert.False(t, open)
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+func unitIDs(units []*domain.Inventory) []uuid.UUID {
+	ids := make([]uuid.UUID, len(units))
+	for i, unit := range units {
+		ids[i] = unit.ID
+	}
+	return ids
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"goreal-backend/internal/config"
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var reservationTracer = otel.Tracer("goreal-backend/services/reservation")
+
+type reservationService struct {
+	inventoryRepo       domain.InventoryRepository
+	projectRepo         domain.ProjectRepository
+	saleRepo            domain.SaleRepository
+	clientRepo          domain.ClientRepository
+	uow                 domain.UnitOfWork
+	notificationService domain.NotificationService
+	feed                domain.UnitChangeFeed
+}
+
+// NewReservationService creates a new reservation service
+func NewReservationService(
+	inventoryRepo domain.InventoryRepository,
+	projectRepo domain.ProjectRepository,
+	saleRepo domain.SaleRepository,
+	clientRepo domain.ClientRepository,
+	uow domain.UnitOfWork,
+	notificationService domain.NotificationService,
+	feed domain.UnitChangeFeed,
+) domain.ReservationService {
+	return &reservationService{
+		inventoryRepo:       inventoryRepo,
+		projectRepo:         projectRepo,
+		saleRepo:            saleRepo,
+		clientRepo:          clientRepo,
+		uow:                 uow,
+		notificationService: notificationService,
+		feed:                feed,
+	}
+}
+
+// ReserveUnit reserves an available unit for a client for the given
+// duration. The repository checks availability as part of the write, so
+// two clients can never reserve the same unit.
+func (s *reservationService) ReserveUnit(ctx context.Context, unitID, clientID uuid.UUID, duration time.Duration) error {
+	ctx, span := reservationTracer.Start(ctx, "reservationService.ReserveUnit")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("inventory.id", unitID.String()),
+		attribute.String("client.id", clientID.String()),
+	)
+
+	if duration <= 0 {
+		return fmt.Errorf("reservation duration must be positive: %w", domain.ErrInvalidInput)
+	}
+
+	err := s.changeUnit(ctx, unitID, func(ctx context.Context, unit *domain.Inventory) error {
+		return s.inventoryRepo.Reserve(ctx, unit.ID, clientID, time.Now().Add(duration))
+	})
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// ReleaseUnit returns a unit to the available pool
+func (s *reservationService) ReleaseUnit(ctx context.Context, unitID uuid.UUID) error {
+	ctx, span := reservationTracer.Start(ctx, "reservationService.ReleaseUnit")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("inventory.id", unitID.String()))
+
+	err := s.changeUnit(ctx, unitID, func(ctx context.Context, unit *domain.Inventory) error {
+		return s.inventoryRepo.Release(ctx, unit.ID)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// ConfirmReservation holds a unit for the client with no expiry. A unit
+// whose reservation lapsed is reserved again if nobody else took it.
+func (s *reservationService) ConfirmReservation(ctx context.Context, unitID, clientID uuid.UUID) error {
+	ctx, span := reservationTracer.Start(ctx, "reservationService.ConfirmReservation")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("inventory.id", unitID.String()),
+		attribute.String("client.id", clientID.String()),
+	)
+
+	err := s.changeUnit(ctx, unitID, func(ctx context.Context, unit *domain.Inventory) error {
+		if unit.Status == domain.UnitStatusAvailable {
+			if err := s.inventoryRepo.Reserve(ctx, unit.ID, clientID, time.Now()); err != nil {
+				return err
+			}
+			reserved, err := s.inventoryRepo.GetByID(ctx, unit.ID)
+			if err != nil {
+				return err
+			}
+			unit = reserved
+		}
+		if !heldBy(unit, clientID) {
+			return fmt.Errorf("unit %s is held by someone else: %w", unit.UnitNumber, domain.ErrInventoryNotAvailable)
+		}
+		if unit.ReservedUntil == nil {
+			return nil
+		}
+		unit.ReservedUntil = nil
+		return s.inventoryRepo.Update(ctx, unit)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// SellUnit marks a unit sold to the client
+func (s *reservationService) SellUnit(ctx context.Context, unitID, clientID uuid.UUID) error {
+	ctx, span := reservationTracer.Start(ctx, "reservationService.SellUnit")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("inventory.id", unitID.String()),
+		attribute.String("client.id", clientID.String()),
+	)
+
+	err := s.changeUnit(ctx, unitID, func(ctx context.Context, unit *domain.Inventory) error {
+		if unit.Status != domain.UnitStatusAvailable && !heldBy(unit, clientID) {
+			return fmt.Errorf("unit %s is not available for sale: %w", unit.UnitNumber, domain.ErrInventoryNotAvailable)
+		}
+		unit.Status = domain.UnitStatusSold
+		unit.ReservedBy = &clientID
+		unit.ReservedUntil = nil
+		return s.inventoryRepo.Update(ctx, unit)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// ReleaseExpired releases every unit whose reservation has run out and
+// tells the salesperson handling each one. A unit reserved again or sold
+// while the sweep runs is left alone.
+func (s *reservationService) ReleaseExpired(ctx context.Context) (int, error) {
+	ctx, span := reservationTracer.Start(ctx, "reservationService.ReleaseExpired")
+	defer span.End()
+
+	now := time.Now()
+	status := domain.UnitStatusReserved
+	units, err := s.inventoryRepo.List(ctx, domain.InventoryFilters{Status: &status, ReservedUntilTo: &now})
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to list expired reservations: %w", err)
+	}
+
+	released := 0
+	var errs []error
+	for _, unit := range units {
+		skipped := false
+		err := s.uow.Do(ctx, func(ctx context.Context) error {
+			err := s.inventoryRepo.ReleaseIfExpired(ctx, unit.ID, now)
+			if errors.Is(err, domain.ErrNotFound) {
+				skipped = true
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := s.projectRepo.RefreshUnitCounts(ctx, unit.ProjectID); err != nil {
+				return err
+			}
+			s.publishRelease(ctx, unit)
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to release unit %s: %w", unit.ID, err))
+			continue
+		}
+		if skipped {
+			continue
+		}
+		released++
+		s.sendReservationExpiredNotification(ctx, unit)
+	}
+
+	span.SetAttributes(attribute.Int("inventory.released", released))
+
+	if err := errors.Join(errs...); err != nil {
+		span.RecordError(err)
+		return released, err
+	}
+	return released, nil
+}
+
+// changeUnit applies change to a unit and recounts its project's units in
+// the same unit of work. The changed unit is published once the unit of
+// work commits.
+func (s *reservationService) changeUnit(ctx context.Context, unitID uuid.UUID, change func(context.Context, *domain.Inventory) error) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		unit, err := s.inventoryRepo.GetByID(ctx, unitID)
+		if err != nil {
+			return fmt.Errorf("failed to get inventory: %w", err)
+		}
+		if err := change(ctx, unit); err != nil {
+			return err
+		}
+		if err := s.projectRepo.RefreshUnitCounts(ctx, unit.ProjectID); err != nil {
+			return fmt.Errorf("failed to update project unit counts: %w", err)
+		}
+		if s.feed == nil {
+			return nil
+		}
+		changed, err := s.inventoryRepo.GetByID(ctx, unitID)
+		if err != nil {
+			return fmt.Errorf("failed to get inventory: %w", err)
+		}
+		domain.AfterCommit(ctx, func() { s.feed.Publish(changed) })
+		return nil
+	})
+}
+
+// publishRelease publishes a unit whose expired reservation was released
+// once the unit of work commits
+func (s *reservationService) publishRelease(ctx context.Context, unit *domain.Inventory) {
+	if s.feed == nil {
+		return
+	}
+	released := *unit
+	released.Status = domain.UnitStatusAvailable
+	released.ReservedBy = nil
+	released.ReservedUntil = nil
+	domain.AfterCommit(ctx, func() { s.feed.Publish(&released) })
+}
+
+// heldBy reports whether a unit is reserved for the client
+func heldBy(unit *domain.Inventory, clientID uuid.UUID) bool {
+	return unit.Status == domain.UnitStatusReserved && unit.ReservedBy != nil && *unit.ReservedBy == clientID
+}
+
+// sendReservationExpiredNotification tells the salesperson of the client's
+// open sale of a unit, or else the client's account owner, that the unit
+// was released
+func (s *reservationService) sendReservationExpiredNotification(ctx context.Context, unit *domain.Inventory) {
+	if s.notificationService == nil || unit.ReservedBy == nil {
+		return
+	}
+
+	var recipient *uuid.UUID
+	data := map[string]interface{}{
+		"inventory_id": unit.ID.String(),
+		"unit_number":  unit.UnitNumber,
+		"client_id":    unit.ReservedBy.String(),
+	}
+
+	sales, err := s.saleRepo.GetByClient(ctx, *unit.ReservedBy)
+	if err != nil {
+		log.Printf("Failed to look up sales for expired reservation of unit %s: %v", unit.ID, err)
+	}
+	for _, sale := range sales {
+		open := sale.Status == domain.SaleStatusDraft || sale.Status == domain.SaleStatusPending
+		if sale.InventoryID == unit.ID && open && sale.SalespersonID != nil {
+			recipient = sale.SalespersonID
+			data["sale_id"] = sale.ID.String()
+			data["sale_number"] = sale.SaleNumber
+			break
+		}
+	}
+	if recipient == nil {
+		if client, err := s.clientRepo.GetByID(ctx, *unit.ReservedBy); err == nil {
+			recipient = client.AssignedTo
+		}
+	}
+	if recipient == nil {
+		return
+	}
+
+	s.notificationService.Create(ctx, &domain.CreateNotificationRequest{
+		UserID:  *recipient,
+		Type:    string(domain.NotificationTypeReservationExpired),
+		Title:   "Reservation Expired",
+		Message: fmt.Sprintf("The reservation of unit %s has expired and the unit is available again", unit.UnitNumber),
+		Data:    data,
+	})
+}
+
+// ReservationSweeper releases expired inventory reservations in the
+// background
+type ReservationSweeper struct {
+	interval     time.Duration
+	reservations domain.ReservationService
+}
+
+// NewReservationSweeper creates a new reservation sweeper
+func NewReservationSweeper(cfg *config.Config, reservations domain.ReservationService) *ReservationSweeper {
+	return &ReservationSweeper{
+		interval:     cfg.ReservationSweepInterval,
+		reservations: reservations,
+	}
+}
+
+// Run releases expired reservations of every organization on every
+// interval until the context is cancelled
+func (w *ReservationSweeper) Run(ctx context.Context) {
+	if w.interval <= 0 {
+		return
+	}
+	ctx = domain.AcrossOrganizations(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := w.reservations.ReleaseExpired(ctx)
+			if err != nil {
+				log.Printf("Reservation sweep failed: %v", err)
+			}
+			if n > 0 {
+				log.Printf("Released %d units with expired reservations", n)
+			}
+		}
+	}
+}
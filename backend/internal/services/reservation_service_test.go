@@ -0,0 +1,90 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (f *salesFixture) unitCounts(t *testing.T) (available, sold, blocked int) {
+	t.Helper()
+	project, err := f.projects.GetByID(f.ctx, f.project.ID)
+	require.NoError(t, err)
+	require.NotNil(t, project.AvailableUnits)
+	return *project.AvailableUnits, project.SoldUnits, project.BlockedUnits
+}
+
+func TestReservationService_OneClientWins(t *testing.T) {
+	f := newSalesFixture(t)
+	unit := &domain.Inventory{ID: uuid.New(), ProjectID: f.project.ID, UnitNumber: "A-102",
+		Status: domain.UnitStatusAvailable, Features: []string{}, CreatedBy: f.manager.ID}
+	require.NoError(t, f.inventory.Create(f.ctx, unit))
+
+	var wg sync.WaitGroup
+	results := make(chan error, 10)
+	for i := 0; i < cap(results); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- f.reservations.ReserveUnit(f.ctx, unit.ID, uuid.New(), time.Hour)
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	won := 0
+	for err := range results {
+		if err == nil {
+			won++
+			continue
+		}
+		assert.True(t, errors.Is(err, domain.ErrInventoryNotAvailable), "losing reservation: %v", err)
+	}
+	assert.Equal(t, 1, won)
+
+	available, sold, blocked := f.unitCounts(t)
+	assert.Equal(t, [3]int{0, 0, 2}, [3]int{available, sold, blocked})
+}
+
+func TestReservationService_ExpiryAndSale(t *testing.T) {
+	f := newSalesFixture(t)
+
+	unit, err := f.inventory.GetByID(f.ctx, f.sale.InventoryID)
+	require.NoError(t, err)
+	lapsed := time.Now().Add(-time.Minute)
+	unit.ReservedUntil = &lapsed
+	require.NoError(t, f.inventory.Update(f.ctx, unit))
+
+	released, err := f.reservations.ReleaseExpired(f.ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+	assert.Equal(t, domain.UnitStatusAvailable, f.unitStatus(t))
+	available, _, blocked := f.unitCounts(t)
+	assert.Equal(t, [2]int{1, 0}, [2]int{available, blocked})
+
+	// Approving takes the lapsed unit back and holds it until the sale closes
+	require.NoError(t, f.service.ApproveSale(f.ctx, f.sale.ID, f.manager.ID))
+	unit, err = f.inventory.GetByID(f.ctx, f.sale.InventoryID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UnitStatusReserved, unit.Status)
+	assert.Nil(t, unit.ReservedUntil)
+
+	released, err = f.reservations.ReleaseExpired(f.ctx)
+	require.NoError(t, err)
+	assert.Zero(t, released)
+
+	err = f.reservations.SellUnit(f.ctx, unit.ID, uuid.New())
+	assert.True(t, errors.Is(err, domain.ErrInventoryNotAvailable), "another client's unit: %v", err)
+
+	require.NoError(t, f.service.CompleteSale(f.ctx, f.sale.ID, f.manager.ID))
+	assert.Equal(t, domain.UnitStatusSold, f.unitStatus(t))
+	available, sold, blocked := f.unitCounts(t)
+	assert.Equal(t, [3]int{0, 1, 0}, [3]int{available, sold, blocked})
+}
@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"goreal-backend/internal/config"
@@ -15,31 +19,61 @@ import (
 
 var salesTracer = otel.Tracer("goreal-backend/services/sales")
 
+// saleReservationPeriod is how long a unit stays reserved for a new sale
+const saleReservationPeriod = 7 * 24 * time.Hour
+
 type salesService struct {
 	config              *config.Config
 	saleRepo            domain.SaleRepository
+	historyRepo         domain.SaleStatusHistoryRepository
 	clientRepo          domain.ClientRepository
 	inventoryRepo       domain.InventoryRepository
+	projectRepo         domain.ProjectRepository
+	paymentRepo         domain.PaymentScheduleRepository
+	revisionRepo        domain.PaymentScheduleRevisionRepository
+	templateRepo        domain.AgreementTemplateRepository
+	agreementRepo       domain.AgreementRepository
 	userRepo            domain.UserRepository
+	uow                 domain.UnitOfWork
 	notificationService domain.NotificationService
+	commissionService   domain.CommissionService
+	reservationService  domain.ReservationService
 }
 
 // NewSalesService creates a new sales service
 func NewSalesService(
 	cfg *config.Config,
 	saleRepo domain.SaleRepository,
+	historyRepo domain.SaleStatusHistoryRepository,
 	clientRepo domain.ClientRepository,
 	inventoryRepo domain.InventoryRepository,
+	projectRepo domain.ProjectRepository,
+	paymentRepo domain.PaymentScheduleRepository,
+	revisionRepo domain.PaymentScheduleRevisionRepository,
+	templateRepo domain.AgreementTemplateRepository,
+	agreementRepo domain.AgreementRepository,
 	userRepo domain.UserRepository,
+	uow domain.UnitOfWork,
 	notificationService domain.NotificationService,
+	commissionService domain.CommissionService,
+	reservationService domain.ReservationService,
 ) domain.SalesService {
 	return &salesService{
 		config:              cfg,
 		saleRepo:            saleRepo,
+		historyRepo:         historyRepo,
 		clientRepo:          clientRepo,
 		inventoryRepo:       inventoryRepo,
+		projectRepo:         projectRepo,
+		paymentRepo:         paymentRepo,
+		revisionRepo:        revisionRepo,
+		templateRepo:        templateRepo,
+		agreementRepo:       agreementRepo,
 		userRepo:            userRepo,
+		uow:                 uow,
 		notificationService: notificationService,
+		commissionService:   commissionService,
+		reservationService:  reservationService,
 	}
 }
 
@@ -75,11 +109,10 @@ func (s *salesService) Create(ctx context.Context, req *domain.CreateSaleRequest
 		return nil, fmt.Errorf("client not found: %w", err)
 	}
 
-	// Skip inventory validation for now since InventoryRepository is not implemented
-	// inventory, err := s.inventoryRepo.GetByID(ctx, req.InventoryID)
-	// if err != nil {
-	// 	return nil, fmt.Errorf("inventory not found: %w", err)
-	// }
+	inventory, err := s.inventoryRepo.GetByID(ctx, req.InventoryID)
+	if err != nil {
+		return nil, fmt.Errorf("inventory not found: %w", err)
+	}
 
 	if req.SalespersonID != nil {
 		_, err = s.userRepo.GetByID(ctx, *req.SalespersonID)
@@ -95,10 +128,9 @@ func (s *salesService) Create(ctx context.Context, req *domain.CreateSaleRequest
 		}
 	}
 
-	// Skip inventory availability check for now
-	// if inventory.Status != domain.InventoryStatusAvailable {
-	// 	return nil, fmt.Errorf("inventory unit is not available for sale")
-	// }
+	if inventory.Status != domain.UnitStatusAvailable {
+		return nil, fmt.Errorf("inventory unit is not available for sale: %w", domain.ErrInventoryNotAvailable)
+	}
 
 	// Generate sale number
 	saleNumber := s.generateSaleNumber()
@@ -125,19 +157,22 @@ func (s *salesService) Create(ctx context.Context, req *domain.CreateSaleRequest
 		UpdatedAt:      now,
 	}
 
-	if err := s.saleRepo.Create(ctx, sale); err != nil {
+	// Save the sale and reserve its unit together, so a unit that was
+	// taken in the meantime leaves no orphaned sale behind
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.saleRepo.Create(ctx, sale); err != nil {
+			return fmt.Errorf("failed to create sale: %w", err)
+		}
+		if err := s.reservationService.ReserveUnit(ctx, req.InventoryID, req.ClientID, saleReservationPeriod); err != nil {
+			return fmt.Errorf("failed to reserve inventory: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to create sale: %w", err)
+		return nil, err
 	}
 
-	// Skip inventory reservation for now since InventoryRepository is not fully implemented
-	// if err := s.inventoryRepo.UpdateStatus(ctx, req.InventoryID, domain.InventoryStatusReserved); err != nil {
-	// 	span.RecordError(err)
-	// 	// Try to rollback the sale creation if possible
-	// 	s.saleRepo.Delete(ctx, sale.ID)
-	// 	return nil, fmt.Errorf("failed to reserve inventory: %w", err)
-	// }
-
 	// Send notifications
 	go s.sendSaleCreatedNotification(context.Background(), sale)
 
@@ -186,6 +221,12 @@ func (s *salesService) Update(ctx context.Context, id uuid.UUID, req *domain.Upd
 		return nil, fmt.Errorf("failed to get sale: %w", err)
 	}
 
+	// Reject stale edits before touching anything
+	if err := domain.CheckVersion(req.Version, sale.UpdatedAt); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	// Check if sale can be updated
 	if sale.Status == domain.SaleStatusCompleted || sale.Status == domain.SaleStatusCancelled {
 		return nil, fmt.Errorf("cannot update completed or cancelled sale")
@@ -216,9 +257,6 @@ func (s *salesService) Update(ctx context.Context, id uuid.UUID, req *domain.Upd
 		sale.Notes = req.Notes
 	}
 
-	// Update timestamp
-	sale.UpdatedAt = time.Now()
-
 	if err := s.saleRepo.Update(ctx, sale); err != nil {
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to update sale: %w", err)
@@ -227,8 +265,8 @@ func (s *salesService) Update(ctx context.Context, id uuid.UUID, req *domain.Upd
 	return sale, nil
 }
 
-// Delete deletes a sale
-func (s *salesService) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete moves a sale to the trash
+func (s *salesService) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
 	ctx, span := salesTracer.Start(ctx, "salesService.Delete")
 	defer span.End()
 
@@ -246,22 +284,46 @@ func (s *salesService) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("cannot delete completed sale")
 	}
 
-	// Skip inventory release for now since InventoryRepository is not fully implemented
-	// if sale.Status == domain.SaleStatusPending || sale.Status == domain.SaleStatusApproved {
-	// 	if err := s.inventoryRepo.UpdateStatus(ctx, sale.InventoryID, domain.InventoryStatusAvailable); err != nil {
-	// 		span.RecordError(err)
-	// 		// Log error but continue with deletion
-	// 	}
-	// }
-
-	if err := s.saleRepo.Delete(ctx, id); err != nil {
+	// Trash the sale and free its unit together. An approved sale holds its
+	// unit with no expiry, so nothing else would ever release it.
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.saleRepo.Delete(ctx, id, deletedBy); err != nil {
+			return fmt.Errorf("failed to delete sale: %w", err)
+		}
+		if sale.Status == domain.SaleStatusPending || sale.Status == domain.SaleStatusApproved {
+			return s.releaseUnit(ctx, sale)
+		}
+		return nil
+	})
+	if err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("failed to delete sale: %w", err)
+		return err
 	}
 
 	return nil
 }
 
+// Restore takes a sale out of the trash
+func (s *salesService) Restore(ctx context.Context, id uuid.UUID) (*domain.Sale, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", id.String()))
+
+	if err := s.saleRepo.Restore(ctx, id); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to restore sale: %w", err)
+	}
+
+	sale, err := s.saleRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get restored sale: %w", err)
+	}
+
+	return sale, nil
+}
+
 // List retrieves sales with pagination and filtering
 func (s *salesService) List(ctx context.Context, filters domain.SaleFilters) ([]*domain.Sale, error) {
 	ctx, span := salesTracer.Start(ctx, "salesService.List")
@@ -299,189 +361,699 @@ func (s *salesService) Count(ctx context.Context, filters domain.SaleFilters) (i
 	return count, nil
 }
 
-// UpdateStatus updates sale status
-func (s *salesService) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.SaleStatus) error {
-	ctx, span := salesTracer.Start(ctx, "salesService.UpdateStatus")
+// GetSalesStats returns sales statistics
+func (s *salesService) GetSalesStats(ctx context.Context, filters domain.SaleFilters) (*domain.SalesStats, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.GetSalesStats")
+	defer span.End()
+
+	stats, err := s.saleRepo.GetSalesStats(ctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get sales stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// TransitionSale moves a sale to another status. The change must be one
+// domain.SaleTransitions allows for the role of the user making it. The new
+// status, its history record and the effect on the sale's unit are saved
+// together.
+func (s *salesService) TransitionSale(ctx context.Context, saleID uuid.UUID, req *domain.SaleTransitionRequest) (*domain.Sale, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.TransitionSale")
 	defer span.End()
 
 	span.SetAttributes(
-		attribute.String("sale.id", id.String()),
-		attribute.String("sale.status", string(status)),
+		attribute.String("sale.id", saleID.String()),
+		attribute.String("sale.status", string(req.Status)),
+		attribute.String("user.id", req.ChangedBy.String()),
 	)
 
-	// Get sale to validate and handle inventory
-	sale, err := s.saleRepo.GetByID(ctx, id)
+	actor, err := s.userRepo.GetByID(ctx, req.ChangedBy)
 	if err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("failed to get sale: %w", err)
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	sale, err := s.saleRepo.GetByID(ctx, saleID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get sale: %w", err)
+	}
+
+	transition, err := domain.FindSaleTransition(sale.Status, req.Status)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	if err := transition.Authorize(actor.Role, req.Reason); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	if transition.To == domain.SaleStatusApproved {
+		if err := (domain.BusinessRules{}).CanSaleBeApproved(sale); err != nil {
+			return nil, fmt.Errorf("%s: %w", err, domain.ErrValidationFailed)
+		}
+	}
+
+	var reason *string
+	if req.Reason != nil && strings.TrimSpace(*req.Reason) != "" {
+		trimmed := strings.TrimSpace(*req.Reason)
+		reason = &trimmed
+	}
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.saleRepo.UpdateStatus(ctx, sale.ID, transition.From, transition.To); err != nil {
+			return fmt.Errorf("failed to update sale status: %w", err)
+		}
+		entry := &domain.SaleStatusHistory{
+			ID:         uuid.New(),
+			SaleID:     sale.ID,
+			FromStatus: transition.From,
+			ToStatus:   transition.To,
+			ChangedBy:  actor.ID,
+			Reason:     reason,
+		}
+		if err := s.historyRepo.Create(ctx, entry); err != nil {
+			return fmt.Errorf("failed to record sale status history: %w", err)
+		}
+		if err := s.settleUnit(ctx, sale, transition.To); err != nil {
+			return err
+		}
+		return s.settleCommissions(ctx, sale.ID, transition.To, actor.ID, reason)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	updated, err := s.saleRepo.GetByID(ctx, sale.ID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get updated sale: %w", err)
 	}
 
-	// Skip business rules validation for now since BusinessRules is not fully implemented
-	// br := domain.BusinessRules{}
-	// if err := br.CanSaleChangeStatus(sale, status); err != nil {
-	// 	return fmt.Errorf("invalid status transition: %w", err)
-	// }
-
-	// Skip inventory status changes for now since InventoryRepository is not fully implemented
-	// switch status {
-	// case domain.SaleStatusCompleted:
-	// 	// Mark inventory as sold
-	// 	if err := s.inventoryRepo.UpdateStatus(ctx, sale.InventoryID, domain.InventoryStatusSold); err != nil {
-	// 		span.RecordError(err)
-	// 		return fmt.Errorf("failed to mark inventory as sold: %w", err)
-	// 	}
-	//
-	// 	// Update project sold units if inventory has project reference
-	// 	inventory, err := s.inventoryRepo.GetByID(ctx, sale.InventoryID)
-	// 	if err == nil && inventory.ProjectID != nil {
-	// 		if err := s.projectService.SellUnits(ctx, *inventory.ProjectID, 1); err != nil {
-	// 			span.RecordError(err)
-	// 			// Log error but don't fail the sale completion
-	// 		}
-	// 	}
-
-	// case domain.SaleStatusCancelled:
-	// 	// Release inventory back to available
-	// 	if err := s.inventoryRepo.UpdateStatus(ctx, sale.InventoryID, domain.InventoryStatusAvailable); err != nil {
-	// 		span.RecordError(err)
-	// 		return fmt.Errorf("failed to release inventory: %w", err)
-	// 	}
-	// }
-
-	if err := s.saleRepo.UpdateStatus(ctx, id, status); err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to update sale status: %w", err)
-	}
-
-	// Send notifications for important status changes
-	go s.sendSaleStatusNotification(context.Background(), sale, status)
+	// Send notifications
+	go s.sendSaleStatusNotification(context.Background(), updated, transition.To)
+
+	return updated, nil
+}
 
+// settleUnit applies a sale's new status to its unit: approving the sale
+// holds the unit until the sale closes, completing it sells the unit and
+// cancelling it releases the unit
+func (s *salesService) settleUnit(ctx context.Context, sale *domain.Sale, status domain.SaleStatus) error {
+	switch status {
+	case domain.SaleStatusApproved:
+		if err := s.reservationService.ConfirmReservation(ctx, sale.InventoryID, sale.ClientID); err != nil {
+			return fmt.Errorf("failed to hold inventory: %w", err)
+		}
+	case domain.SaleStatusCompleted:
+		if err := s.reservationService.SellUnit(ctx, sale.InventoryID, sale.ClientID); err != nil {
+			return fmt.Errorf("failed to mark inventory as sold: %w", err)
+		}
+	case domain.SaleStatusCancelled:
+		return s.releaseUnit(ctx, sale)
+	}
 	return nil
 }
 
-// GetSalesStats returns sales statistics
-func (s *salesService) GetSalesStats(ctx context.Context, filters domain.SaleFilters) (*domain.SalesStats, error) {
-	ctx, span := salesTracer.Start(ctx, "salesService.GetSalesStats")
+// releaseUnit releases a sale's unit if the sale's client still holds it;
+// a unit whose reservation lapsed may have been taken by someone else since
+func (s *salesService) releaseUnit(ctx context.Context, sale *domain.Sale) error {
+	unit, err := s.inventoryRepo.GetByID(ctx, sale.InventoryID)
+	if err != nil {
+		return fmt.Errorf("failed to get inventory: %w", err)
+	}
+	if unit.Status != domain.UnitStatusReserved || unit.ReservedBy == nil || *unit.ReservedBy != sale.ClientID {
+		return nil
+	}
+	if err := s.reservationService.ReleaseUnit(ctx, unit.ID); err != nil {
+		return fmt.Errorf("failed to release inventory: %w", err)
+	}
+	return nil
+}
+
+// settleCommissions applies a sale's new status to its commissions: they
+// are earned when the sale is approved or completed and clawed back when
+// it is cancelled
+func (s *salesService) settleCommissions(ctx context.Context, saleID uuid.UUID, status domain.SaleStatus, changedBy uuid.UUID, reason *string) error {
+	switch status {
+	case domain.SaleStatusApproved, domain.SaleStatusCompleted:
+		if _, err := s.commissionService.CalculateCommissions(ctx, saleID); err != nil {
+			return fmt.Errorf("failed to calculate commissions: %w", err)
+		}
+	case domain.SaleStatusCancelled:
+		req := &domain.ClawbackRequest{RequestedBy: changedBy}
+		if reason != nil {
+			req.Reason = *reason
+		}
+		if _, err := s.commissionService.ClawBackCommissions(ctx, saleID, req); err != nil {
+			return fmt.Errorf("failed to claw back commissions: %w", err)
+		}
+	}
+	return nil
+}
+
+// ApproveSale approves a pending sale
+func (s *salesService) ApproveSale(ctx context.Context, saleID uuid.UUID, approverID uuid.UUID) error {
+	_, err := s.TransitionSale(ctx, saleID, &domain.SaleTransitionRequest{
+		Status:    domain.SaleStatusApproved,
+		ChangedBy: approverID,
+	})
+	return err
+}
+
+// CompleteSale completes an approved sale
+func (s *salesService) CompleteSale(ctx context.Context, saleID uuid.UUID, completedBy uuid.UUID) error {
+	_, err := s.TransitionSale(ctx, saleID, &domain.SaleTransitionRequest{
+		Status:    domain.SaleStatusCompleted,
+		ChangedBy: completedBy,
+	})
+	return err
+}
+
+// CancelSale cancels a sale that has not been completed
+func (s *salesService) CancelSale(ctx context.Context, saleID uuid.UUID, cancelledBy uuid.UUID, reason string) error {
+	_, err := s.TransitionSale(ctx, saleID, &domain.SaleTransitionRequest{
+		Status:    domain.SaleStatusCancelled,
+		Reason:    &reason,
+		ChangedBy: cancelledBy,
+	})
+	return err
+}
+
+// GetStatusHistory returns the status changes of a sale, oldest first
+func (s *salesService) GetStatusHistory(ctx context.Context, saleID uuid.UUID) ([]*domain.SaleStatusHistory, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.GetStatusHistory")
 	defer span.End()
 
-	stats, err := s.saleRepo.GetSalesStats(ctx, filters)
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	// The sale must be visible to the caller for its history to be
+	if _, err := s.saleRepo.GetByID(ctx, saleID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get sale: %w", err)
+	}
+
+	history, err := s.historyRepo.ListBySale(ctx, saleID)
 	if err != nil {
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to get sales stats: %w", err)
+		return nil, fmt.Errorf("failed to get sale status history: %w", err)
 	}
 
-	return stats, nil
+	span.SetAttributes(attribute.Int("result.count", len(history)))
+
+	return history, nil
 }
 
-// ApproveSale approves a sale
-func (s *salesService) ApproveSale(ctx context.Context, saleID uuid.UUID, approverID uuid.UUID) error {
-	ctx, span := salesTracer.Start(ctx, "salesService.ApproveSale")
+// GenerateAgreement renders a sale's agreement from the newest template of
+// its project, falling back to the organization default and then the
+// built-in template. The document is stored and added to the sale's
+// documents unless it is identical to the last one generated.
+func (s *salesService) GenerateAgreement(ctx context.Context, saleID uuid.UUID, req *domain.GenerateAgreementRequest) (*domain.Agreement, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.GenerateAgreement")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("sale.id", saleID.String()),
-		attribute.String("approver.id", approverID.String()),
+		attribute.String("agreement.format", string(req.Format)),
 	)
 
-	// Validate approver exists
-	_, err := s.userRepo.GetByID(ctx, approverID)
+	format, err := domain.ParseAgreementFormat(string(req.Format))
 	if err != nil {
-		return fmt.Errorf("approver not found: %w", err)
+		return nil, err
 	}
 
-	// Update sale status to approved
-	if err := s.saleRepo.UpdateStatus(ctx, saleID, domain.SaleStatusApproved); err != nil {
+	data, err := s.agreementData(ctx, saleID)
+	if err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("failed to approve sale: %w", err)
+		return nil, err
 	}
 
-	// Send notification
+	tmpl, err := s.agreementTemplate(ctx, data.Project.ID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	doc, err := renderAgreement(tmpl, data)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	var content []byte
+	if format == domain.AgreementFormatDOCX {
+		content, err = doc.DOCX()
+	} else {
+		content, err = doc.PDF()
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to write agreement: %w", err)
+	}
+
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	// Regenerating an unchanged agreement returns the stored copy
+	latest, err := s.agreementRepo.GetLatest(ctx, saleID, format)
+	if err == nil && latest.Checksum == checksum {
+		span.SetAttributes(attribute.Bool("agreement.reused", true))
+		return latest, nil
+	}
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get latest agreement: %w", err)
+	}
+
+	agreement := &domain.Agreement{
+		ID:              uuid.New(),
+		SaleID:          saleID,
+		TemplateVersion: tmpl.Version,
+		Format:          format,
+		FileName:        fmt.Sprintf("%s-agreement.%s", data.Sale.SaleNumber, format),
+		Checksum:        checksum,
+		Content:         content,
+		CreatedBy:       req.GeneratedBy,
+	}
+	if tmpl.ID != uuid.Nil {
+		agreement.TemplateID = &tmpl.ID
+	}
+
+	sale := data.Sale
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.agreementRepo.Create(ctx, agreement); err != nil {
+			return fmt.Errorf("failed to store agreement: %w", err)
+		}
+		sale.Documents = append(sale.Documents, agreement.DocumentPath())
+		if err := s.saleRepo.Update(ctx, sale); err != nil {
+			return fmt.Errorf("failed to attach agreement to sale: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("agreement.id", agreement.ID.String()),
+		attribute.Int("agreement.template_version", agreement.TemplateVersion),
+		attribute.Int("agreement.size", len(content)),
+	)
+
+	return agreement, nil
+}
+
+// agreementData loads everything an agreement template can refer to
+func (s *salesService) agreementData(ctx context.Context, saleID uuid.UUID) (*agreementData, error) {
 	sale, err := s.saleRepo.GetByID(ctx, saleID)
-	if err == nil {
-		go s.sendSaleStatusNotification(context.Background(), sale, domain.SaleStatusApproved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sale: %w", err)
+	}
+	client, err := s.clientRepo.GetByID(ctx, sale.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	unit, err := s.inventoryRepo.GetByID(ctx, sale.InventoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory: %w", err)
+	}
+	project, err := s.projectRepo.GetByID(ctx, unit.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
-	return nil
+	data := &agreementData{
+		Sale:     sale,
+		Client:   client,
+		Unit:     unit,
+		Project:  project,
+		Payments: []*domain.PaymentSchedule{},
+		Date:     time.Now(),
+	}
+	if sale.AgreementDate != nil {
+		data.Date = *sale.AgreementDate
+	}
+	if data.Payments, err = s.paymentRepo.GetBySale(ctx, sale.ID); err != nil {
+		return nil, fmt.Errorf("failed to get payment schedule: %w", err)
+	}
+	return data, nil
 }
 
-// CompleteSale completes a sale
-func (s *salesService) CompleteSale(ctx context.Context, saleID uuid.UUID) error {
-	ctx, span := salesTracer.Start(ctx, "salesService.CompleteSale")
+// agreementTemplate picks the template for a project's sales
+func (s *salesService) agreementTemplate(ctx context.Context, projectID uuid.UUID) (*domain.AgreementTemplate, error) {
+	for _, id := range []*uuid.UUID{&projectID, nil} {
+		tmpl, err := s.templateRepo.GetLatest(ctx, id)
+		if err == nil {
+			return tmpl, nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get agreement template: %w", err)
+		}
+	}
+	return defaultAgreementTemplate, nil
+}
+
+// GetAgreement returns a stored agreement of a sale
+func (s *salesService) GetAgreement(ctx context.Context, saleID, agreementID uuid.UUID) (*domain.Agreement, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.GetAgreement")
 	defer span.End()
 
-	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+	span.SetAttributes(
+		attribute.String("sale.id", saleID.String()),
+		attribute.String("agreement.id", agreementID.String()),
+	)
 
-	// Update sale status to completed
-	if err := s.saleRepo.UpdateStatus(ctx, saleID, domain.SaleStatusCompleted); err != nil {
+	if _, err := s.saleRepo.GetByID(ctx, saleID); err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("failed to complete sale: %w", err)
+		return nil, fmt.Errorf("failed to get sale: %w", err)
 	}
 
-	// Send notification
-	sale, err := s.saleRepo.GetByID(ctx, saleID)
-	if err == nil {
-		go s.sendSaleStatusNotification(context.Background(), sale, domain.SaleStatusCompleted)
+	agreement, err := s.agreementRepo.GetByID(ctx, agreementID)
+	if err == nil && agreement.SaleID != saleID {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get agreement: %w", err)
 	}
 
-	return nil
+	return agreement, nil
+}
+
+// SaveAgreementTemplate adds a new version of a project's agreement
+// template, or of the organization default when no project is given. The
+// template must render against sample data before it is accepted.
+func (s *salesService) SaveAgreementTemplate(ctx context.Context, req *domain.SaveAgreementTemplateRequest) (*domain.AgreementTemplate, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.SaveAgreementTemplate")
+	defer span.End()
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, fmt.Errorf("template name is required: %w", domain.ErrInvalidInput)
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		return nil, fmt.Errorf("template body is required: %w", domain.ErrInvalidInput)
+	}
+	if req.ProjectID != nil {
+		span.SetAttributes(attribute.String("project.id", req.ProjectID.String()))
+		if _, err := s.projectRepo.GetByID(ctx, *req.ProjectID); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to get project: %w", err)
+		}
+	}
+
+	tmpl := &domain.AgreementTemplate{
+		ID:        uuid.New(),
+		ProjectID: req.ProjectID,
+		Name:      req.Name,
+		Version:   1,
+		Body:      req.Body,
+		CreatedBy: req.CreatedBy,
+	}
+	if _, err := renderAgreement(tmpl, sampleAgreementData()); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	latest, err := s.templateRepo.GetLatest(ctx, req.ProjectID)
+	switch {
+	case err == nil:
+		tmpl.Version = latest.Version + 1
+	case !errors.Is(err, domain.ErrNotFound):
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get agreement template: %w", err)
+	}
+
+	if err := s.templateRepo.Create(ctx, tmpl); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to save agreement template: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("template.version", tmpl.Version))
+
+	return tmpl, nil
 }
 
-// CancelSale cancels a sale
-func (s *salesService) CancelSale(ctx context.Context, saleID uuid.UUID, reason string) error {
-	ctx, span := salesTracer.Start(ctx, "salesService.CancelSale")
+// ListAgreementTemplates returns the versions of a project's agreement
+// template, or of the organization default, newest first
+func (s *salesService) ListAgreementTemplates(ctx context.Context, projectID *uuid.UUID) ([]*domain.AgreementTemplate, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.ListAgreementTemplates")
 	defer span.End()
 
+	templates, err := s.templateRepo.ListVersions(ctx, projectID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list agreement templates: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(templates)))
+
+	return templates, nil
+}
+
+// CreatePaymentSchedule sets up the payment schedule of a sale from the
+// installments given or a payment plan. The installments must add up to the
+// sale's amount due; a sale that already has a schedule is rescheduled
+// with ReschedulePayments instead.
+func (s *salesService) CreatePaymentSchedule(ctx context.Context, saleID uuid.UUID, req *domain.CreatePaymentScheduleRequest) ([]*domain.PaymentSchedule, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.CreatePaymentSchedule")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	sale, err := s.schedulableSale(ctx, saleID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	installments, plan, err := req.Schedule(sale.AmountDue(), 1)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	span.SetAttributes(
-		attribute.String("sale.id", saleID.String()),
-		attribute.String("reason", reason),
+		attribute.String("payment.plan", string(plan)),
+		attribute.Int("payment.installments", len(installments)),
 	)
 
-	// Update sale status to cancelled
-	if err := s.saleRepo.UpdateStatus(ctx, saleID, domain.SaleStatusCancelled); err != nil {
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		existing, err := s.paymentRepo.GetBySale(ctx, sale.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get payment schedule: %w", err)
+		}
+		if len(existing) > 0 {
+			return fmt.Errorf("sale already has a payment schedule, reschedule it instead: %w", domain.ErrConflict)
+		}
+		return s.replaceInstallments(ctx, sale.ID, nil, installments, plan, nil, req.CreatedBy)
+	})
+	if err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("failed to cancel sale: %w", err)
+		return nil, err
+	}
+
+	return s.GetPaymentSchedule(ctx, sale.ID)
+}
+
+// ReschedulePayments replaces the unsettled installments of a sale. Paid
+// and part paid installments are kept, so the new installments cover the
+// amount due less what the kept ones do. The change is recorded as a new
+// revision with its reason.
+func (s *salesService) ReschedulePayments(ctx context.Context, saleID uuid.UUID, req *domain.ReschedulePaymentsRequest) ([]*domain.PaymentSchedule, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.ReschedulePayments")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("sale.id", saleID.String()))
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("a reason is required to reschedule payments: %w", domain.ErrInvalidInput)
 	}
 
-	// Send notification
+	sale, err := s.schedulableSale(ctx, saleID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		existing, err := s.paymentRepo.GetBySale(ctx, sale.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get payment schedule: %w", err)
+		}
+		if len(existing) == 0 {
+			return fmt.Errorf("sale has no payment schedule to reschedule: %w", domain.ErrNotFound)
+		}
+
+		var replaced []*domain.PaymentSchedule
+		covered, last := 0.0, 0
+		for _, installment := range existing {
+			if !installment.IsSettled() {
+				replaced = append(replaced, installment)
+				continue
+			}
+			covered += installment.Amount
+			if installment.InstallmentNumber > last {
+				last = installment.InstallmentNumber
+			}
+		}
+		if len(replaced) == 0 {
+			return fmt.Errorf("every installment has been paid: %w", domain.ErrConflict)
+		}
+
+		installments, plan, err := req.Schedule(sale.AmountDue()-covered, last+1)
+		if err != nil {
+			return err
+		}
+
+		span.SetAttributes(
+			attribute.String("payment.plan", string(plan)),
+			attribute.Int("payment.replaced", len(replaced)),
+			attribute.Int("payment.installments", len(installments)),
+		)
+
+		return s.replaceInstallments(ctx, sale.ID, replaced, installments, plan, &reason, req.CreatedBy)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return s.GetPaymentSchedule(ctx, sale.ID)
+}
+
+// schedulableSale returns a sale whose payments can still be scheduled
+func (s *salesService) schedulableSale(ctx context.Context, saleID uuid.UUID) (*domain.Sale, error) {
 	sale, err := s.saleRepo.GetByID(ctx, saleID)
-	if err == nil {
-		go s.sendSaleStatusNotification(context.Background(), sale, domain.SaleStatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sale: %w", err)
 	}
+	if sale.Status == domain.SaleStatusCancelled {
+		return nil, fmt.Errorf("payments cannot be scheduled for a cancelled sale: %w", domain.ErrValidationFailed)
+	}
+	return sale, nil
+}
 
+// replaceInstallments deletes the replaced installments, stores the new
+// ones and records the change as the sale's next schedule revision. It
+// runs inside the caller's unit of work.
+func (s *salesService) replaceInstallments(ctx context.Context, saleID uuid.UUID, replaced []*domain.PaymentSchedule, installments []domain.PaymentInstallment, plan domain.PaymentPlanType, reason *string, changedBy uuid.UUID) error {
+	previous := make([]domain.PaymentInstallment, len(replaced))
+	for i, installment := range replaced {
+		if err := s.paymentRepo.Delete(ctx, installment.ID); err != nil {
+			return fmt.Errorf("failed to remove installment %d: %w", installment.InstallmentNumber, err)
+		}
+		previous[i] = domain.PaymentInstallment{
+			InstallmentNumber: installment.InstallmentNumber,
+			DueDate:           installment.DueDate,
+			Amount:            installment.Amount,
+			Description:       installment.Description,
+		}
+	}
+
+	for _, installment := range installments {
+		schedule := &domain.PaymentSchedule{
+			ID:                uuid.New(),
+			SaleID:            saleID,
+			InstallmentNumber: installment.InstallmentNumber,
+			DueDate:           installment.DueDate,
+			Amount:            installment.Amount,
+			Description:       installment.Description,
+			Status:            domain.PaymentStatusPending,
+		}
+		if err := s.paymentRepo.Create(ctx, schedule); err != nil {
+			return fmt.Errorf("failed to create installment %d: %w", installment.InstallmentNumber, err)
+		}
+	}
+
+	revisions, err := s.revisionRepo.ListBySale(ctx, saleID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment schedule revisions: %w", err)
+	}
+	revision := &domain.PaymentScheduleRevision{
+		ID:           uuid.New(),
+		SaleID:       saleID,
+		Revision:     len(revisions) + 1,
+		Plan:         plan,
+		Reason:       reason,
+		Previous:     previous,
+		Installments: installments,
+		ChangedBy:    changedBy,
+	}
+	if err := s.revisionRepo.Create(ctx, revision); err != nil {
+		return fmt.Errorf("failed to record payment schedule revision: %w", err)
+	}
 	return nil
 }
 
-// GenerateAgreement generates a sale agreement document
-func (s *salesService) GenerateAgreement(ctx context.Context, saleID uuid.UUID) ([]byte, error) {
-	ctx, span := salesTracer.Start(ctx, "salesService.GenerateAgreement")
+// GetPaymentSchedule returns the installments of a sale in order
+func (s *salesService) GetPaymentSchedule(ctx context.Context, saleID uuid.UUID) ([]*domain.PaymentSchedule, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.GetPaymentSchedule")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("sale.id", saleID.String()))
 
-	// TODO: Implement agreement generation
-	return nil, fmt.Errorf("agreement generation not implemented")
+	// The sale must be visible to the caller for its schedule to be
+	if _, err := s.saleRepo.GetByID(ctx, saleID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get sale: %w", err)
+	}
+
+	schedule, err := s.paymentRepo.GetBySale(ctx, saleID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get payment schedule: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(schedule)))
+
+	return schedule, nil
 }
 
-// CreatePaymentSchedule creates a payment schedule for a sale
-func (s *salesService) CreatePaymentSchedule(ctx context.Context, saleID uuid.UUID, req *domain.CreatePaymentScheduleRequest) ([]*domain.PaymentSchedule, error) {
-	ctx, span := salesTracer.Start(ctx, "salesService.CreatePaymentSchedule")
+// GetPaymentScheduleRevisions returns the changes to a sale's payment
+// schedule, oldest first
+func (s *salesService) GetPaymentScheduleRevisions(ctx context.Context, saleID uuid.UUID) ([]*domain.PaymentScheduleRevision, error) {
+	ctx, span := salesTracer.Start(ctx, "salesService.GetPaymentScheduleRevisions")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("sale.id", saleID.String()))
 
-	// TODO: Implement payment schedule creation
-	return nil, fmt.Errorf("payment schedule creation not implemented")
+	if _, err := s.saleRepo.GetByID(ctx, saleID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get sale: %w", err)
+	}
+
+	revisions, err := s.revisionRepo.ListBySale(ctx, saleID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get payment schedule revisions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(revisions)))
+
+	return revisions, nil
 }
 
-// CalculateCommission calculates commission for a sale
+// CalculateCommission creates the commissions earned on an approved or
+// completed sale, or returns the ones already created
 func (s *salesService) CalculateCommission(ctx context.Context, saleID uuid.UUID) ([]*domain.Commission, error) {
 	ctx, span := salesTracer.Start(ctx, "salesService.CalculateCommission")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("sale.id", saleID.String()))
 
-	// TODO: Implement commission calculation
-	return nil, fmt.Errorf("commission calculation not implemented")
+	commissions, err := s.commissionService.CalculateCommissions(ctx, saleID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return commissions, nil
 }
 
 // generateSaleNumber generates a unique sale number
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"goreal-backend/internal/config"
+	"goreal-backend/internal/domain"
+	"goreal-backend/internal/infrastructure/memory"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// salesFixture is a sales service over an in-memory store with a manager,
+// an employee and a sale reserving one unit
+type salesFixture struct {
+	ctx          context.Context
+	service      domain.SalesService
+	inventory    domain.InventoryRepository
+	projects     domain.ProjectRepository
+	payments     domain.PaymentScheduleRepository
+	sales        domain.SaleRepository
+	commissions  domain.CommissionService
+	reservations domain.ReservationService
+	project      *domain.Project
+	manager      *domain.User
+	employee     *domain.User
+	sale         *domain.Sale
+}
+
+func newSalesFixture(t *testing.T) *salesFixture {
+	t.Helper()
+	ctx := domain.WithOrganization(context.Background(), uuid.New())
+	store := memory.NewStore()
+	users := memory.NewUserRepository(store)
+	clients := memory.NewClientRepository(store)
+	inventory := memory.NewInventoryRepository(store)
+	projects := memory.NewProjectRepository(store)
+
+	user := func(name string, role domain.UserRole) *domain.User {
+		u := &domain.User{ID: uuid.New(), Email: name + "@example.com", Username: name, FullName: name, Role: role, IsActive: true}
+		require.NoError(t, users.Create(ctx, u))
+		return u
+	}
+	f := &salesFixture{
+		ctx:       ctx,
+		inventory: inventory,
+		projects:  projects,
+		payments:  memory.NewPaymentScheduleRepository(store),
+		manager:   user("manager", domain.RoleManager),
+		employee:  user("employee", domain.RoleEmployee),
+	}
+
+	client := &domain.Client{ID: uuid.New(), Name: "Buyer", ClientType: domain.ClientTypeIndividual, CreatedBy: f.manager.ID}
+	require.NoError(t, clients.Create(ctx, client))
+	f.project = &domain.Project{ID: uuid.New(), SocietyID: uuid.New(), Name: "Palm Heights", CreatedBy: f.manager.ID}
+	require.NoError(t, projects.Create(ctx, f.project))
+	unit := &domain.Inventory{ID: uuid.New(), ProjectID: f.project.ID, UnitNumber: "A-101",
+		Status: domain.UnitStatusAvailable, Features: []string{}, CreatedBy: f.manager.ID}
+	require.NoError(t, inventory.Create(ctx, unit))
+
+	f.sales = memory.NewSaleRepository(store)
+	uow := memory.NewUnitOfWork(store)
+	f.commissions = NewCommissionService(memory.NewCommissionRepository(store), memory.NewCommissionPlanRepository(store),
+		f.sales, users, uow)
+	f.reservations = NewReservationService(inventory, projects, f.sales, clients, uow, nil, nil)
+	f.service = NewSalesService(&config.Config{}, f.sales, memory.NewSaleStatusHistoryRepository(store),
+		clients, inventory, projects, f.payments, memory.NewPaymentScheduleRevisionRepository(store),
+		memory.NewAgreementTemplateRepository(store), memory.NewAgreementRepository(store), users, uow, nil, f.commissions,
+		f.reservations)
+
+	var err error
+	f.sale, err = f.service.Create(ctx, &domain.CreateSaleRequest{
+		ClientID:      client.ID,
+		InventoryID:   unit.ID,
+		SalespersonID: &f.employee.ID,
+		TotalAmount:   500000,
+	})
+	require.NoError(t, err)
+	return f
+}
+
+func (f *salesFixture) unitStatus(t *testing.T) domain.UnitStatus {
+	t.Helper()
+	unit, err := f.inventory.GetByID(f.ctx, f.sale.InventoryID)
+	require.NoError(t, err)
+	return unit.Status
+}
+
+func TestSalesService_Lifecycle(t *testing.T) {
+	f := newSalesFixture(t)
+	assert.Equal(t, domain.SaleStatusPending, f.sale.Status)
+	assert.Equal(t, domain.UnitStatusReserved, f.unitStatus(t))
+
+	err := f.service.ApproveSale(f.ctx, f.sale.ID, f.employee.ID)
+	assert.True(t, errors.Is(err, domain.ErrForbidden), "employee approving: %v", err)
+
+	err = f.service.CompleteSale(f.ctx, f.sale.ID, f.manager.ID)
+	assert.True(t, errors.Is(err, domain.ErrInvalidTransition), "completing a pending sale: %v", err)
+
+	require.NoError(t, f.service.ApproveSale(f.ctx, f.sale.ID, f.manager.ID))
+	require.NoError(t, f.service.CompleteSale(f.ctx, f.sale.ID, f.manager.ID))
+
+	sale, err := f.service.GetByID(f.ctx, f.sale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SaleStatusCompleted, sale.Status)
+	assert.Equal(t, domain.UnitStatusSold, f.unitStatus(t))
+
+	err = f.service.CancelSale(f.ctx, f.sale.ID, f.manager.ID, "Too late")
+	assert.True(t, errors.Is(err, domain.ErrInvalidTransition), "cancelling a completed sale: %v", err)
+
+	history, err := f.service.GetStatusHistory(f.ctx, f.sale.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, domain.SaleStatusPending, history[0].FromStatus)
+	assert.Equal(t, domain.SaleStatusApproved, history[0].ToStatus)
+	assert.Equal(t, domain.SaleStatusCompleted, history[1].ToStatus)
+	assert.Equal(t, f.manager.ID, history[1].ChangedBy)
+}
+
+func TestSalesService_DeleteReleasesUnit(t *testing.T) {
+	t.Run("Pending", func(t *testing.T) {
+		f := newSalesFixture(t)
+		require.Equal(t, domain.UnitStatusReserved, f.unitStatus(t))
+
+		require.NoError(t, f.service.Delete(f.ctx, f.sale.ID, f.manager.ID))
+		assert.Equal(t, domain.UnitStatusAvailable, f.unitStatus(t))
+	})
+
+	t.Run("Approved", func(t *testing.T) {
+		f := newSalesFixture(t)
+		require.NoError(t, f.service.ApproveSale(f.ctx, f.sale.ID, f.manager.ID))
+		unit, err := f.inventory.GetByID(f.ctx, f.sale.InventoryID)
+		require.NoError(t, err)
+		require.Nil(t, unit.ReservedUntil, "approval holds the unit with no expiry")
+
+		require.NoError(t, f.service.Delete(f.ctx, f.sale.ID, f.manager.ID))
+		assert.Equal(t, domain.UnitStatusAvailable, f.unitStatus(t))
+	})
+
+	t.Run("Completed", func(t *testing.T) {
+		f := newSalesFixture(t)
+		require.NoError(t, f.service.ApproveSale(f.ctx, f.sale.ID, f.manager.ID))
+		require.NoError(t, f.service.CompleteSale(f.ctx, f.sale.ID, f.manager.ID))
+
+		assert.Error(t, f.service.Delete(f.ctx, f.sale.ID, f.manager.ID))
+		assert.Equal(t, domain.UnitStatusSold, f.unitStatus(t))
+	})
+}
+
+func TestSalesService_CancelRequiresReason(t *testing.T) {
+	f := newSalesFixture(t)
+	blank, reason := "  ", " Buyer withdrew "
+
+	_, err := f.service.TransitionSale(f.ctx, f.sale.ID, &domain.SaleTransitionRequest{
+		Status:    domain.SaleStatusCancelled,
+		Reason:    &blank,
+		ChangedBy: f.employee.ID,
+	})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "cancelling without a reason: %v", err)
+
+	sale, err := f.service.TransitionSale(f.ctx, f.sale.ID, &domain.SaleTransitionRequest{
+		Status:    domain.SaleStatusCancelled,
+		Reason:    &reason,
+		ChangedBy: f.employee.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SaleStatusCancelled, sale.Status)
+	assert.Equal(t, domain.UnitStatusAvailable, f.unitStatus(t), "cancelling releases the unit")
+
+	history, err := f.service.GetStatusHistory(f.ctx, f.sale.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.NotNil(t, history[0].Reason)
+	assert.Equal(t, "Buyer withdrew", *history[0].Reason)
+}
+
+func TestSalesService_GenerateAgreement(t *testing.T) {
+	f := newSalesFixture(t)
+	pdf := &domain.GenerateAgreementRequest{Format: domain.AgreementFormatPDF, GeneratedBy: f.manager.ID}
+
+	agreement, err := f.service.GenerateAgreement(f.ctx, f.sale.ID, pdf)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(agreement.Content, []byte("%PDF-")))
+	assert.Nil(t, agreement.TemplateID, "the built-in template is used by default")
+	assert.Equal(t, f.sale.SaleNumber+"-agreement.pdf", agreement.FileName)
+
+	again, err := f.service.GenerateAgreement(f.ctx, f.sale.ID, pdf)
+	require.NoError(t, err)
+	assert.Equal(t, agreement.ID, again.ID, "an unchanged agreement is not stored twice")
+
+	sale, err := f.service.GetByID(f.ctx, f.sale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{agreement.DocumentPath()}, sale.Documents)
+
+	_, err = f.service.SaveAgreementTemplate(f.ctx, &domain.SaveAgreementTemplateRequest{
+		Name: "Broken", Body: "{{.Sale.Nope}}", CreatedBy: f.manager.ID,
+	})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "saving a template that does not render: %v", err)
+
+	save := func(projectID *uuid.UUID, body string) *domain.AgreementTemplate {
+		tmpl, err := f.service.SaveAgreementTemplate(f.ctx, &domain.SaveAgreementTemplateRequest{
+			ProjectID: projectID, Name: "Agreement", Body: body, CreatedBy: f.manager.ID,
+		})
+		require.NoError(t, err)
+		return tmpl
+	}
+	save(nil, "# Default\n\nFor {{.Client.Name}}")
+	save(&f.project.ID, "# Palm Heights v1")
+	override := save(&f.project.ID, "# Palm Heights v2\n\nUnit {{.Unit.UnitNumber}} for {{money .Sale.FinalAmount}}")
+	assert.Equal(t, 2, override.Version)
+
+	docx, err := f.service.GenerateAgreement(f.ctx, f.sale.ID, &domain.GenerateAgreementRequest{
+		Format: domain.AgreementFormatDOCX, GeneratedBy: f.manager.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, docx.TemplateID)
+	assert.Equal(t, override.ID, *docx.TemplateID, "the project's newest template overrides the default")
+	assert.Equal(t, 2, docx.TemplateVersion)
+
+	archive, err := zip.NewReader(bytes.NewReader(docx.Content), int64(len(docx.Content)))
+	require.NoError(t, err)
+	body, err := archive.Open("word/document.xml")
+	require.NoError(t, err)
+	xml, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Contains(t, string(xml), "Unit A-101 for 500,000.00")
+
+	stored, err := f.service.GetAgreement(f.ctx, f.sale.ID, docx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, docx.Content, stored.Content)
+}
+
+func TestSalesService_PaymentSchedule(t *testing.T) {
+	f := newSalesFixture(t)
+	start := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	_, err := f.service.CreatePaymentSchedule(f.ctx, f.sale.ID, &domain.CreatePaymentScheduleRequest{
+		Installments: []domain.PaymentInstallment{{DueDate: start, Amount: 400000}},
+		CreatedBy:    f.manager.ID,
+	})
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "installments short of the amount due: %v", err)
+
+	schedule, err := f.service.CreatePaymentSchedule(f.ctx, f.sale.ID, &domain.CreatePaymentScheduleRequest{
+		Plan:      &domain.PaymentPlan{Type: domain.PaymentPlanTimeBased, StartDate: start, Installments: 3},
+		CreatedBy: f.manager.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, schedule, 3)
+	assert.Equal(t, []float64{166666.67, 166666.67, 166666.66}, []float64{schedule[0].Amount, schedule[1].Amount, schedule[2].Amount})
+	assert.Equal(t, time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC), schedule[1].DueDate, "month ends are clamped")
+
+	_, err = f.service.CreatePaymentSchedule(f.ctx, f.sale.ID, &domain.CreatePaymentScheduleRequest{
+		Plan:      &domain.PaymentPlan{Type: domain.PaymentPlanTimeBased, StartDate: start, Installments: 2},
+		CreatedBy: f.manager.ID,
+	})
+	assert.True(t, errors.Is(err, domain.ErrConflict), "a second schedule: %v", err)
+
+	paid := schedule[0]
+	paid.Status = domain.PaymentStatusPaid
+	paid.PaidAmount = paid.Amount
+	require.NoError(t, f.payments.Update(f.ctx, paid))
+
+	reschedule := &domain.ReschedulePaymentsRequest{
+		CreatePaymentScheduleRequest: domain.CreatePaymentScheduleRequest{
+			Plan: &domain.PaymentPlan{Type: domain.PaymentPlanDownPaymentEMI, StartDate: start.AddDate(0, 2, 0),
+				DownPaymentPercent: 25, EMIMonths: 2},
+			CreatedBy: f.manager.ID,
+		},
+	}
+	_, err = f.service.ReschedulePayments(f.ctx, f.sale.ID, reschedule)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput), "rescheduling without a reason: %v", err)
+
+	reschedule.Reason = "Buyer asked for a down payment plan"
+	schedule, err = f.service.ReschedulePayments(f.ctx, f.sale.ID, reschedule)
+	require.NoError(t, err)
+	require.Len(t, schedule, 4)
+	assert.Equal(t, paid.ID, schedule[0].ID, "paid installments are kept")
+	assert.Equal(t, []int{1, 2, 3, 4}, []int{schedule[0].InstallmentNumber, schedule[1].InstallmentNumber,
+		schedule[2].InstallmentNumber, schedule[3].InstallmentNumber})
+	assert.Equal(t, 83333.33, schedule[1].Amount, "the down payment is a quarter of what was left")
+
+	revisions, err := f.service.GetPaymentScheduleRevisions(f.ctx, f.sale.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, domain.PaymentPlanTimeBased, revisions[0].Plan)
+	assert.Nil(t, revisions[0].Reason)
+	assert.Equal(t, 2, revisions[1].Revision)
+	assert.Equal(t, domain.PaymentPlanDownPaymentEMI, revisions[1].Plan)
+	require.NotNil(t, revisions[1].Reason)
+	assert.Equal(t, reschedule.Reason, *revisions[1].Reason)
+	assert.Len(t, revisions[1].Previous, 2, "the unpaid installments were replaced")
+	assert.Len(t, revisions[1].Installments, 3)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"goreal-backend/internal/domain"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var searchTracer = otel.Tracer("goreal-backend/services/search")
+
+// Search limits
+const (
+	minSearchLength    = 2
+	maxSearchLength    = 200
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+type searchService struct {
+	searchRepo domain.SearchRepository
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(searchRepo domain.SearchRepository) domain.SearchService {
+	return &searchService{
+		searchRepo: searchRepo,
+	}
+}
+
+// Search runs a search across leads, clients, companies and inventory
+func (s *searchService) Search(ctx context.Context, query domain.SearchQuery) (*domain.SearchResults, error) {
+	ctx, span := searchTracer.Start(ctx, "searchService.Search")
+	defer span.End()
+
+	query.Text = strings.TrimSpace(query.Text)
+	span.SetAttributes(
+		attribute.String("search.query", query.Text),
+		attribute.Int("search.limit", query.Limit),
+	)
+
+	if utf8.RuneCountInString(query.Text) < minSearchLength {
+		return nil, fmt.Errorf("search query must be at least %d characters: %w", minSearchLength, domain.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(query.Text) > maxSearchLength {
+		return nil, fmt.Errorf("search query must be at most %d characters: %w", maxSearchLength, domain.ErrInvalidInput)
+	}
+	if strings.IndexFunc(query.Text, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+		return nil, fmt.Errorf("search query must contain a letter or digit: %w", domain.ErrInvalidInput)
+	}
+	for _, t := range query.Types {
+		if !t.IsValid() {
+			return nil, fmt.Errorf("unknown search type %q: %w", t, domain.ErrInvalidInput)
+		}
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit > maxSearchLimit {
+		query.Limit = maxSearchLimit
+	}
+
+	hits, err := s.searchRepo.Search(ctx, query)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", len(hits)))
+
+	return &domain.SearchResults{
+		Query: query.Text,
+		Hits:  hits,
+	}, nil
+}
@@ -143,6 +143,12 @@ func (s *taskService) Update(ctx context.Context, id uuid.UUID, req *domain.Upda
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
 
+	// Reject stale edits before touching anything
+	if err := domain.CheckVersion(req.Version, task.UpdatedAt); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	// Update fields
 	if req.Title != nil {
 		task.Title = *req.Title
@@ -182,9 +188,6 @@ func (s *taskService) Update(ctx context.Context, id uuid.UUID, req *domain.Upda
 		}
 	}
 
-	// Update timestamp
-	task.UpdatedAt = time.Now()
-
 	if err := s.taskRepo.Update(ctx, task); err != nil {
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to update task: %w", err)
@@ -193,14 +196,14 @@ func (s *taskService) Update(ctx context.Context, id uuid.UUID, req *domain.Upda
 	return task, nil
 }
 
-// Delete deletes a task
-func (s *taskService) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete moves a task to the trash
+func (s *taskService) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
 	ctx, span := taskTracer.Start(ctx, "taskService.Delete")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("task.id", id.String()))
 
-	if err := s.taskRepo.Delete(ctx, id); err != nil {
+	if err := s.taskRepo.Delete(ctx, id, deletedBy); err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
@@ -208,6 +211,27 @@ func (s *taskService) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// Restore takes a task out of the trash
+func (s *taskService) Restore(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	ctx, span := taskTracer.Start(ctx, "taskService.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("task.id", id.String()))
+
+	if err := s.taskRepo.Restore(ctx, id); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get restored task: %w", err)
+	}
+
+	return task, nil
+}
+
 // List retrieves tasks with pagination and filtering
 func (s *taskService) List(ctx context.Context, filters domain.TaskFilters) ([]*domain.Task, error) {
 	ctx, span := taskTracer.Start(ctx, "taskService.List")
@@ -270,7 +294,6 @@ func (s *taskService) UpdateStatus(ctx context.Context, id uuid.UUID, status dom
 
 	// Update task status using the Update method
 	task.Status = status
-	task.UpdatedAt = time.Now()
 
 	if err := s.taskRepo.Update(ctx, task); err != nil {
 		span.RecordError(err)
@@ -386,7 +409,6 @@ func (s *taskService) AssignToUser(ctx context.Context, taskID, userID uuid.UUID
 
 	// Update assignment
 	task.AssignedTo = &userID
-	task.UpdatedAt = time.Now()
 
 	if err := s.taskRepo.Update(ctx, task); err != nil {
 		span.RecordError(err)
@@ -401,7 +423,7 @@ func (s *taskService) AssignToUser(ctx context.Context, taskID, userID uuid.UUID
 
 // sendTaskAssignmentNotification sends a notification when a task is assigned
 func (s *taskService) sendTaskAssignmentNotification(ctx context.Context, task *domain.Task) {
-	if s.notificationService == nil {
+	if s.notificationService == nil || task.AssignedTo == nil {
 		return
 	}
 
@@ -510,7 +532,6 @@ func (s *taskService) BulkAssign(ctx context.Context, taskIDs []uuid.UUID, userI
 		}
 
 		task.AssignedTo = &userID
-		task.UpdatedAt = time.Now()
 
 		if err := s.taskRepo.Update(ctx, task); err != nil {
 			span.RecordError(err)
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"goreal-backend/internal/config"
+	"goreal-backend/internal/domain"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var trashTracer = otel.Tracer("goreal-backend/services/trash")
+
+// TrashPurger permanently removes soft-deleted CRM records once they have
+// been in the trash longer than the configured retention period
+type TrashPurger struct {
+	retention time.Duration
+	interval  time.Duration
+
+	leadRepo   domain.LeadRepository
+	clientRepo domain.ClientRepository
+	saleRepo   domain.SaleRepository
+	taskRepo   domain.TaskRepository
+}
+
+// NewTrashPurger creates a new trash purger
+func NewTrashPurger(
+	cfg *config.Config,
+	leadRepo domain.LeadRepository,
+	clientRepo domain.ClientRepository,
+	saleRepo domain.SaleRepository,
+	taskRepo domain.TaskRepository,
+) *TrashPurger {
+	return &TrashPurger{
+		retention:  cfg.TrashRetention,
+		interval:   cfg.TrashPurgeInterval,
+		leadRepo:   leadRepo,
+		clientRepo: clientRepo,
+		saleRepo:   saleRepo,
+		taskRepo:   taskRepo,
+	}
+}
+
+// PurgeExpired removes every record deleted before the retention cutoff and
+// returns how many were removed. Dependent records go first so a client is
+// never purged while its sales still reference it. A failing table does not
+// stop the others from being purged.
+func (p *TrashPurger) PurgeExpired(ctx context.Context) (int, error) {
+	ctx, span := trashTracer.Start(ctx, "TrashPurger.PurgeExpired")
+	defer span.End()
+
+	cutoff := time.Now().Add(-p.retention)
+	purges := []struct {
+		table string
+		purge func(context.Context, time.Time) (int, error)
+	}{
+		{"sales", p.saleRepo.Purge},
+		{"tasks", p.taskRepo.Purge},
+		{"clients", p.clientRepo.Purge},
+		{"leads", p.leadRepo.Purge},
+	}
+
+	total := 0
+	var errs []error
+	for _, purge := range purges {
+		n, err := purge.purge(ctx, cutoff)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to purge %s: %w", purge.table, err))
+			continue
+		}
+		total += n
+	}
+
+	span.SetAttributes(attribute.Int("trash.purged", total))
+
+	if err := errors.Join(errs...); err != nil {
+		span.RecordError(err)
+		return total, err
+	}
+	return total, nil
+}
+
+// Run purges the trash of every organization on every interval until the
+// context is cancelled
+func (p *TrashPurger) Run(ctx context.Context) {
+	if p.interval <= 0 {
+		return
+	}
+	ctx = domain.AcrossOrganizations(ctx)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := p.PurgeExpired(ctx)
+			if err != nil {
+				log.Printf("Trash purge failed: %v", err)
+			}
+			if n > 0 {
+				log.Printf("Purged %d records from the trash", n)
+			}
+		}
+	}
+}
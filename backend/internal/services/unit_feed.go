@@ -0,0 +1,93 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"goreal-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// unitWatcherBuffer is how many changes a watcher may fall behind before
+// it is dropped
+const unitWatcherBuffer = 64
+
+// UnitFeed passes unit changes on to the watchers of each project. It
+// lives in the API process, so watchers only see the changes made through
+// the same instance.
+type UnitFeed struct {
+	mu       sync.Mutex
+	watchers map[uuid.UUID]map[*unitWatcher]struct{}
+}
+
+type unitWatcher struct {
+	changes chan domain.UnitChange
+	closed  bool
+}
+
+// NewUnitFeed creates a new unit feed
+func NewUnitFeed() *UnitFeed {
+	return &UnitFeed{watchers: make(map[uuid.UUID]map[*unitWatcher]struct{})}
+}
+
+// Publish sends the state of each unit to its project's watchers. A
+// watcher too far behind to take a change is dropped rather than allowed
+// to hold up the services publishing; it can reload the matrix and watch
+// again.
+func (f *UnitFeed) Publish(units ...*domain.Inventory) {
+	now := time.Now()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, unit := range units {
+		change := domain.UnitChange{
+			ProjectID:        unit.ProjectID,
+			Floor:            unit.FloorNumber,
+			AvailabilityCell: domain.NewAvailabilityCell(unit),
+			ChangedAt:        now,
+		}
+		if unit.TowerBlock != nil {
+			change.TowerBlock = *unit.TowerBlock
+		}
+		for w := range f.watchers[unit.ProjectID] {
+			select {
+			case w.changes <- change:
+			default:
+				f.remove(unit.ProjectID, w)
+			}
+		}
+	}
+}
+
+// Subscribe starts watching the units of a project
+func (f *UnitFeed) Subscribe(projectID uuid.UUID) (<-chan domain.UnitChange, func()) {
+	w := &unitWatcher{changes: make(chan domain.UnitChange, unitWatcherBuffer)}
+
+	f.mu.Lock()
+	if f.watchers[projectID] == nil {
+		f.watchers[projectID] = make(map[*unitWatcher]struct{})
+	}
+	f.watchers[projectID][w] = struct{}{}
+	f.mu.Unlock()
+
+	return w.changes, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.remove(projectID, w)
+	}
+}
+
+// remove ends a watcher's subscription; f.mu must be held
+func (f *UnitFeed) remove(projectID uuid.UUID, w *unitWatcher) {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	close(w.changes)
+	delete(f.watchers[projectID], w)
+	if len(f.watchers[projectID]) == 0 {
+		delete(f.watchers, projectID)
+	}
+}
@@ -97,6 +97,11 @@ func (s *userService) Create(ctx context.Context, req *domain.CreateUserRequest)
 	if req.FullName == "" {
 		return nil, fmt.Errorf("full name is required")
 	}
+	// Super admins act across organizations, so they are only created out
+	// of band
+	if req.Role == domain.RoleSuperAdmin {
+		return nil, fmt.Errorf("super admins cannot be created through the API: %w", domain.ErrForbidden)
+	}
 
 	// Check if user already exists
 	existingUser, err := s.userRepo.GetByEmail(ctx, req.Email)
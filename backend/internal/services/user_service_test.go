@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"goreal-backend/internal/config"
 	"goreal-backend/internal/domain"
@@ -63,6 +65,16 @@ func (m *MockUserRepository) Count(ctx context.Context, filters domain.UserFilte
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockUserRepository) RecordFailedLogin(ctx context.Context, id uuid.UUID, at, since time.Time) (int, error) {
+	args := m.Called(ctx, id, at, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserRepository) LockUntil(ctx context.Context, id uuid.UUID, until time.Time) error {
+	args := m.Called(ctx, id, until)
+	return args.Error(0)
+}
+
 func TestUserService_Create(t *testing.T) {
 	// Setup
 	mockRepo := new(MockUserRepository)
@@ -98,6 +110,21 @@ func TestUserService_Create(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserService_CreateRefusesSuperAdmin(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewUserService(&config.Config{}, mockRepo)
+
+	_, err := service.Create(context.Background(), &domain.CreateUserRequest{
+		Email:    "root@example.com",
+		Username: "root",
+		FullName: "Root",
+		Role:     domain.RoleSuperAdmin,
+	})
+
+	assert.True(t, errors.Is(err, domain.ErrForbidden), "super admin: %v", err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestUserService_GetByID(t *testing.T) {
 	// Setup
 	mockRepo := new(MockUserRepository)
@@ -0,0 +1,102 @@
+// Package document renders simple structured documents, such as sale
+// agreements, to PDF and DOCX using only the standard library.
+package document
+
+import (
+	"strings"
+)
+
+// BlockKind identifies the kind of a document block
+type BlockKind int
+
+const (
+	// Heading is a bold section title
+	Heading BlockKind = iota
+	// Paragraph is a run of wrapped body text
+	Paragraph
+	// Row is one row of a table; consecutive rows form a table and the
+	// first row of each table is its header
+	Row
+)
+
+// Block is a single heading, paragraph or table row
+type Block struct {
+	Kind  BlockKind
+	Text  string
+	Cells []string
+}
+
+// Document is a titled sequence of blocks
+type Document struct {
+	Title  string
+	Blocks []Block
+}
+
+// Parse builds a document from lightweight markup: lines starting with
+// "#" are headings, lines wrapped in "|" are table rows ("|---|" rows are
+// ignored) and blank lines separate paragraphs.
+func Parse(title, text string) *Document {
+	doc := &Document{Title: title}
+
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			doc.Blocks = append(doc.Blocks, Block{Kind: Paragraph, Text: strings.Join(paragraph, " ")})
+			paragraph = nil
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#"):
+			flush()
+			doc.Blocks = append(doc.Blocks, Block{Kind: Heading, Text: strings.TrimSpace(strings.TrimLeft(line, "#"))})
+		case strings.HasPrefix(line, "|"):
+			flush()
+			cells := strings.Split(strings.Trim(line, "|"), "|")
+			for i := range cells {
+				cells[i] = strings.TrimSpace(cells[i])
+			}
+			if !isRule(cells) {
+				doc.Blocks = append(doc.Blocks, Block{Kind: Row, Cells: cells})
+			}
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+
+	return doc
+}
+
+// isRule reports whether a table row is a markdown-style separator
+func isRule(cells []string) bool {
+	for _, cell := range cells {
+		if strings.Trim(cell, "-: ") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// table groups consecutive rows starting at blocks[i], padding every row
+// to the widest one. It returns the rows and the index after the table.
+func table(blocks []Block, i int) ([][]string, int) {
+	var rows [][]string
+	columns := 0
+	for ; i < len(blocks) && blocks[i].Kind == Row; i++ {
+		rows = append(rows, blocks[i].Cells)
+		if len(blocks[i].Cells) > columns {
+			columns = len(blocks[i].Cells)
+		}
+	}
+	for r, row := range rows {
+		padded := make([]string, columns)
+		copy(padded, row)
+		rows[r] = padded
+	}
+	return rows, i
+}
@@ -0,0 +1,86 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sample = `# Parties
+
+This agreement is made between
+GoReal Estates and Jane (the "Buyer").
+
+| No. | Due | Amount |
+|-----|-----|--------|
+| 1 | 2026-01-01 | 100,000.00 |
+| 2 | 2026-02-01 |
+
+## Signatures`
+
+func TestParse(t *testing.T) {
+	doc := Parse("Agreement", sample)
+
+	require.Len(t, doc.Blocks, 6)
+	assert.Equal(t, Block{Kind: Heading, Text: "Parties"}, doc.Blocks[0])
+	assert.Equal(t, `This agreement is made between GoReal Estates and Jane (the "Buyer").`, doc.Blocks[1].Text)
+	assert.Equal(t, []string{"No.", "Due", "Amount"}, doc.Blocks[2].Cells)
+	assert.Equal(t, []string{"2", "2026-02-01"}, doc.Blocks[4].Cells)
+	assert.Equal(t, Block{Kind: Heading, Text: "Signatures"}, doc.Blocks[5])
+
+	rows, next := table(doc.Blocks, 2)
+	assert.Equal(t, 5, next)
+	assert.Equal(t, []string{"2", "2026-02-01", ""}, rows[2], "short rows are padded")
+}
+
+func TestPDF(t *testing.T) {
+	doc := Parse("Agreement", strings.Repeat(sample+"\n\n", 40))
+
+	out, err := doc.PDF()
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Title (Agreement)")
+	assert.Greater(t, bytes.Count(out, []byte("/Type /Page ")), 1, "long documents break across pages")
+
+	again, err := doc.PDF()
+	require.NoError(t, err)
+	assert.Equal(t, out, again, "output is reproducible")
+}
+
+func TestDOCX(t *testing.T) {
+	out, err := Parse("Agreement", sample).DOCX()
+	require.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+
+	parts := map[string]string{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		parts[f.Name] = string(content)
+	}
+
+	require.Contains(t, parts, "[Content_Types].xml")
+	require.Contains(t, parts, "word/document.xml")
+	assert.Contains(t, parts["word/document.xml"], `Jane (the &#34;Buyer&#34;).`)
+	assert.Contains(t, parts["word/document.xml"], `<w:t xml:space="preserve">100,000.00</w:t>`)
+	assert.Equal(t, 1, strings.Count(parts["word/document.xml"], "<w:tbl>"))
+}
+
+func TestWrap(t *testing.T) {
+	lines := wrap("a b c "+strings.Repeat("x", 200), regular, bodySize, 100)
+	for _, line := range lines {
+		assert.LessOrEqual(t, textWidth(encode(line), regular, bodySize), 100.0)
+	}
+	assert.Equal(t, "a b c", lines[0])
+	assert.Equal(t, []byte("caf\xe9 \x80 ?"), encode("café € ✓"))
+}
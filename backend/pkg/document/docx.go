@@ -0,0 +1,138 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A4 page width and margins in twentieths of a point
+const (
+	docxPageWidth    = 11906
+	docxPageHeight   = 16838
+	docxMargin       = 1134
+	docxContentWidth = docxPageWidth - 2*docxMargin
+)
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+	`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+	`</Types>`
+
+const docxRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>` +
+	`</Relationships>`
+
+// DOCX renders the document as a WordprocessingML package
+func (d *Document) DOCX() ([]byte, error) {
+	var body strings.Builder
+	if d.Title != "" {
+		docxParagraph(&body, d.Title, true, 36)
+	}
+	for i := 0; i < len(d.Blocks); {
+		switch d.Blocks[i].Kind {
+		case Heading:
+			docxParagraph(&body, d.Blocks[i].Text, true, 28)
+			i++
+		case Paragraph:
+			docxParagraph(&body, d.Blocks[i].Text, false, 21)
+			i++
+		case Row:
+			var rows [][]string
+			rows, i = table(d.Blocks, i)
+			docxTable(&body, rows)
+		default:
+			i++
+		}
+	}
+
+	document := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		body.String() +
+		fmt.Sprintf(`<w:sectPr><w:pgSz w:w="%d" w:h="%d"/><w:pgMar w:top="%d" w:right="%d" w:bottom="%d" w:left="%d" w:header="708" w:footer="708" w:gutter="0"/></w:sectPr>`,
+			docxPageWidth, docxPageHeight, docxMargin, docxMargin, docxMargin, docxMargin) +
+		`</w:body></w:document>`
+
+	core := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">` +
+		`<dc:title>` + xmlText(d.Title) + `</dc:title></cp:coreProperties>`
+
+	var out bytes.Buffer
+	zw := zip.NewWriter(&out)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRelationships},
+		{"docProps/core.xml", core},
+		{"word/document.xml", document},
+	}
+	for _, part := range parts {
+		// A fixed timestamp keeps the output reproducible
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     part.name,
+			Method:   zip.Deflate,
+			Modified: time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", part.name, err)
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write docx: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// docxParagraph writes a paragraph; size is in half-points
+func docxParagraph(b *strings.Builder, text string, strong bool, size int) {
+	b.WriteString(`<w:p><w:pPr><w:spacing w:after="120"/></w:pPr><w:r><w:rPr>`)
+	if strong {
+		b.WriteString(`<w:b/>`)
+	}
+	fmt.Fprintf(b, `<w:sz w:val="%d"/></w:rPr><w:t xml:space="preserve">%s</w:t></w:r></w:p>`, size, xmlText(text))
+}
+
+func docxTable(b *strings.Builder, rows [][]string) {
+	width := docxContentWidth / len(rows[0])
+
+	b.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="0" w:type="auto"/><w:tblBorders>`)
+	for _, side := range []string{"top", "left", "bottom", "right", "insideH", "insideV"} {
+		fmt.Fprintf(b, `<w:%s w:val="single" w:sz="4" w:space="0" w:color="auto"/>`, side)
+	}
+	b.WriteString(`</w:tblBorders></w:tblPr><w:tblGrid>`)
+	for range rows[0] {
+		fmt.Fprintf(b, `<w:gridCol w:w="%d"/>`, width)
+	}
+	b.WriteString(`</w:tblGrid>`)
+
+	for r, row := range rows {
+		b.WriteString(`<w:tr>`)
+		for _, cell := range row {
+			fmt.Fprintf(b, `<w:tc><w:tcPr><w:tcW w:w="%d" w:type="dxa"/></w:tcPr>`, width)
+			docxParagraph(b, cell, r == 0, 21)
+			b.WriteString(`</w:tc>`)
+		}
+		b.WriteString(`</w:tr>`)
+	}
+	b.WriteString(`</w:tbl>`)
+
+	// Word merges adjacent tables unless a paragraph separates them
+	b.WriteString(`<w:p/>`)
+}
+
+func xmlText(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
@@ -0,0 +1,298 @@
+package document
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+)
+
+// A4 page geometry in points
+const (
+	pageWidth    = 595.28
+	pageHeight   = 841.89
+	pageMargin   = 56.0
+	contentWidth = pageWidth - 2*pageMargin
+	cellPadding  = 4.0
+	bodySize     = 10.5
+	headingSize  = 14.0
+	titleSize    = 18.0
+	footerSize   = 8.0
+	lineSpacing  = 1.35
+)
+
+// The two standard Type 1 fonts used, so nothing has to be embedded
+const (
+	regular = "F1"
+	bold    = "F2"
+)
+
+// PDF renders the document as an A4 PDF using the standard Helvetica fonts.
+// Text outside the WinAnsi character set is replaced by "?".
+func (d *Document) PDF() ([]byte, error) {
+	l := &layout{}
+	l.newPage()
+
+	if d.Title != "" {
+		l.paragraph(d.Title, bold, titleSize)
+		l.y -= titleSize * 0.5
+	}
+	for i := 0; i < len(d.Blocks); {
+		switch d.Blocks[i].Kind {
+		case Heading:
+			l.y -= headingSize * 0.5
+			l.paragraph(d.Blocks[i].Text, bold, headingSize)
+			i++
+		case Paragraph:
+			l.paragraph(d.Blocks[i].Text, regular, bodySize)
+			l.y -= bodySize * 0.6
+			i++
+		case Row:
+			var rows [][]string
+			rows, i = table(d.Blocks, i)
+			l.table(rows)
+			l.y -= bodySize * 0.6
+		default:
+			i++
+		}
+	}
+
+	for n, page := range l.pages {
+		footer := fmt.Sprintf("Page %d of %d", n+1, len(l.pages))
+		x := pageWidth - pageMargin - textWidth(encode(footer), regular, footerSize)
+		showText(page, x, pageMargin/2, regular, footerSize, footer)
+	}
+
+	return writePDF(d.Title, l.pages)
+}
+
+// layout flows blocks top to bottom, starting a new page when one is full
+type layout struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	y     float64
+}
+
+func (l *layout) newPage() {
+	l.page = &bytes.Buffer{}
+	l.pages = append(l.pages, l.page)
+	l.y = pageHeight - pageMargin
+}
+
+// reserve makes room for height points, breaking the page if needed
+func (l *layout) reserve(height float64) {
+	if l.y-height < pageMargin && l.y < pageHeight-pageMargin {
+		l.newPage()
+	}
+}
+
+func (l *layout) paragraph(text, font string, size float64) {
+	leading := size * lineSpacing
+	for _, line := range wrap(text, font, size, contentWidth) {
+		l.reserve(leading)
+		l.y -= leading
+		showText(l.page, pageMargin, l.y+size*0.25, font, size, line)
+	}
+}
+
+func (l *layout) table(rows [][]string) {
+	width := contentWidth / float64(len(rows[0]))
+	leading := bodySize * lineSpacing
+
+	for r, row := range rows {
+		font := regular
+		if r == 0 {
+			font = bold
+		}
+
+		cells := make([][]string, len(row))
+		lines := 1
+		for c, cell := range row {
+			cells[c] = wrap(cell, font, bodySize, width-2*cellPadding)
+			if len(cells[c]) > lines {
+				lines = len(cells[c])
+			}
+		}
+
+		height := float64(lines)*leading + 2*cellPadding
+		l.reserve(height)
+		top := l.y
+		for c, cell := range cells {
+			x := pageMargin + float64(c)*width
+			fmt.Fprintf(l.page, "0.5 w %.2f %.2f %.2f %.2f re S\n", x, top-height, width, height)
+			for n, line := range cell {
+				baseline := top - cellPadding - float64(n+1)*leading + bodySize*0.25
+				showText(l.page, x+cellPadding, baseline, font, bodySize, line)
+			}
+		}
+		l.y = top - height
+	}
+}
+
+func showText(page *bytes.Buffer, x, y float64, font string, size float64, text string) {
+	fmt.Fprintf(page, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(encode(text)))
+}
+
+// wrap breaks text into lines no wider than width, splitting words that
+// do not fit on a line of their own
+func wrap(text, font string, size, width float64) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if textWidth(encode(candidate), font, size) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = ""
+		for _, r := range word {
+			if line != "" && textWidth(encode(line+string(r)), font, size) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			line += string(r)
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func textWidth(text []byte, font string, size float64) float64 {
+	widths := &helveticaWidths
+	if font == bold {
+		widths = &helveticaBoldWidths
+	}
+
+	total := 0
+	for _, b := range text {
+		if b >= 32 && b < 127 {
+			total += widths[b-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// winAnsi maps the characters above Latin-1's control range that WinAnsi
+// places in 0x80-0x9F
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// encode converts text to WinAnsiEncoding
+func encode(text string) []byte {
+	out := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			out = append(out, ' ')
+		case r >= 32 && r < 127, r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		case winAnsi[r] != 0:
+			out = append(out, winAnsi[r])
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+// escape quotes encoded text for a PDF literal string
+func escape(text []byte) string {
+	var b strings.Builder
+	for _, c := range text {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 32 || c > 126:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// writePDF assembles the catalog, fonts and compressed page streams into a
+// PDF file with a cross-reference table
+func writePDF(title string, pages []*bytes.Buffer) ([]byte, error) {
+	var objects []string
+	add := func(body string) int {
+		objects = append(objects, body)
+		return len(objects)
+	}
+
+	catalog := add("")
+	root := add("")
+	add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	info := add(fmt.Sprintf("<< /Title (%s) /Producer (goreal-backend) >>", escape(encode(title))))
+
+	kids := make([]string, 0, len(pages))
+	for _, page := range pages {
+		var stream bytes.Buffer
+		zw := zlib.NewWriter(&stream)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to compress page: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress page: %w", err)
+		}
+
+		content := add(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.Bytes()))
+		kids = append(kids, fmt.Sprintf("%d 0 R", add(fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			root, pageWidth, pageHeight, regular, bold, content))))
+	}
+	objects[catalog-1] = fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", root)
+	objects[root-1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(objects))
+	for i, body := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, body)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objects)+1, catalog, info, xref)
+
+	return out.Bytes(), nil
+}
+
+// Glyph widths of the printable ASCII range (32-126) in 1/1000 em, from
+// the Adobe font metrics of the standard fonts
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
@@ -0,0 +1,161 @@
+package geo
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Place is a named position in a gazetteer
+type Place struct {
+	Name  string
+	Point Point
+}
+
+// Gazetteer geocodes place names from a fixed list, without any network
+// access. Names are matched ignoring case, punctuation and extra spaces.
+type Gazetteer struct {
+	places map[string]Point
+}
+
+// NewGazetteer creates a gazetteer of the given places. Later places
+// replace earlier ones of the same name.
+func NewGazetteer(places ...Place) *Gazetteer {
+	g := &Gazetteer{places: make(map[string]Point, len(places))}
+	g.Add(places...)
+	return g
+}
+
+// Add adds places to the gazetteer
+func (g *Gazetteer) Add(places ...Place) {
+	for _, place := range places {
+		if key := normalizeName(place.Name); key != "" {
+			g.places[key] = place.Point
+		}
+	}
+}
+
+// Load adds the places of a CSV file with name, latitude and longitude
+// columns. A first row that does not parse as coordinates is taken as a
+// header and skipped.
+func (g *Gazetteer) Load(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read gazetteer: %w", err)
+		}
+		lat, latErr := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		lng, lngErr := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		if latErr != nil || lngErr != nil {
+			if line == 1 {
+				continue
+			}
+			return fmt.Errorf("gazetteer line %d: invalid coordinates", line)
+		}
+		point := Point{Lat: lat, Lng: lng}
+		if !point.Valid() {
+			return fmt.Errorf("gazetteer line %d: coordinates out of range", line)
+		}
+		g.Add(Place{Name: record[0], Point: point})
+	}
+}
+
+// Lookup finds the position of an address. The whole address is tried
+// first, then each of its comma-separated parts from the most specific,
+// and within a part ever shorter trailing runs of words, so that
+// "Plot 4, North Bangalore" is found as "bangalore".
+func (g *Gazetteer) Lookup(address string) (Point, bool) {
+	if p, ok := g.places[normalizeName(address)]; ok {
+		return p, true
+	}
+	for _, part := range strings.Split(address, ",") {
+		words := strings.Fields(normalizeName(part))
+		for i := range words {
+			if p, ok := g.places[strings.Join(words[i:], " ")]; ok {
+				return p, true
+			}
+		}
+	}
+	return Point{}, false
+}
+
+// Len returns the number of places in the gazetteer
+func (g *Gazetteer) Len() int {
+	return len(g.places)
+}
+
+// normalizeName lower-cases a name and reduces its punctuation and runs of
+// spaces to single spaces
+func normalizeName(name string) string {
+	mapped := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		if r == ',' {
+			return r
+		}
+		return ' '
+	}, name)
+	var parts []string
+	for _, part := range strings.Split(mapped, ",") {
+		if part = strings.Join(strings.Fields(part), " "); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// IndianCities are the principal cities of India, for gazetteers of
+// deployments working there
+var IndianCities = []Place{
+	{"Mumbai", Point{19.0760, 72.8777}},
+	{"Bombay", Point{19.0760, 72.8777}},
+	{"Delhi", Point{28.7041, 77.1025}},
+	{"New Delhi", Point{28.6139, 77.2090}},
+	{"Bangalore", Point{12.9716, 77.5946}},
+	{"Bengaluru", Point{12.9716, 77.5946}},
+	{"Hyderabad", Point{17.3850, 78.4867}},
+	{"Ahmedabad", Point{23.0225, 72.5714}},
+	{"Chennai", Point{13.0827, 80.2707}},
+	{"Kolkata", Point{22.5726, 88.3639}},
+	{"Pune", Point{18.5204, 73.8567}},
+	{"Jaipur", Point{26.9124, 75.7873}},
+	{"Surat", Point{21.1702, 72.8311}},
+	{"Lucknow", Point{26.8467, 80.9462}},
+	{"Kanpur", Point{26.4499, 80.3319}},
+	{"Nagpur", Point{21.1458, 79.0882}},
+	{"Indore", Point{22.7196, 75.8577}},
+	{"Thane", Point{19.2183, 72.9781}},
+	{"Navi Mumbai", Point{19.0330, 73.0297}},
+	{"Bhopal", Point{23.2599, 77.4126}},
+	{"Visakhapatnam", Point{17.6868, 83.2185}},
+	{"Vadodara", Point{22.3072, 73.1812}},
+	{"Gurgaon", Point{28.4595, 77.0266}},
+	{"Gurugram", Point{28.4595, 77.0266}},
+	{"Noida", Point{28.5355, 77.3910}},
+	{"Ghaziabad", Point{28.6692, 77.4538}},
+	{"Faridabad", Point{28.4089, 77.3178}},
+	{"Chandigarh", Point{30.7333, 76.7794}},
+	{"Kochi", Point{9.9312, 76.2673}},
+	{"Coimbatore", Point{11.0168, 76.9558}},
+	{"Goa", Point{15.2993, 74.1240}},
+	{"Mysore", Point{12.2958, 76.6394}},
+	{"Whitefield", Point{12.9698, 77.7500}},
+	{"Electronic City", Point{12.8452, 77.6602}},
+	{"Hinjewadi", Point{18.5913, 73.7389}},
+	{"Powai", Point{19.1176, 72.9060}},
+	{"Andheri", Point{19.1136, 72.8697}},
+	{"Bandra", Point{19.0596, 72.8295}},
+	{"South Mumbai", Point{18.9220, 72.8347}},
+	{"North Bangalore", Point{13.0827, 77.5877}},
+}
@@ -0,0 +1,91 @@
+// Package geo measures distances between points on the Earth and tests
+// whether points fall inside bounding boxes and polygons. Coordinates are
+// WGS 84 degrees and distances are in kilometres.
+package geo
+
+import "math"
+
+// EarthRadiusKm is the mean radius of the Earth
+const EarthRadiusKm = 6371.0088
+
+// Point is a position given by latitude and longitude
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// Valid reports whether p is a position on the Earth
+func (p Point) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180 &&
+		!math.IsNaN(p.Lat) && !math.IsNaN(p.Lng)
+}
+
+// Distance returns the great-circle distance between two points using the
+// haversine formula
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLng := radians(b.Lng - a.Lng)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox is the area between two latitudes and two longitudes. A box
+// whose MinLng is greater than its MaxLng crosses the antimeridian.
+type BoundingBox struct {
+	MinLat, MinLng float64
+	MaxLat, MaxLng float64
+}
+
+// Valid reports whether b spans valid coordinates from south to north
+func (b BoundingBox) Valid() bool {
+	return Point{b.MinLat, b.MinLng}.Valid() && Point{b.MaxLat, b.MaxLng}.Valid() && b.MinLat <= b.MaxLat
+}
+
+// Contains reports whether p lies in the box, edges included
+func (b BoundingBox) Contains(p Point) bool {
+	if p.Lat < b.MinLat || p.Lat > b.MaxLat {
+		return false
+	}
+	if b.MinLng <= b.MaxLng {
+		return p.Lng >= b.MinLng && p.Lng <= b.MaxLng
+	}
+	return p.Lng >= b.MinLng || p.Lng <= b.MaxLng
+}
+
+// Polygon is a closed ring of points; the last point joins the first.
+// Polygons are treated as flat, which is accurate enough for the size of a
+// city district.
+type Polygon []Point
+
+// Valid reports whether the polygon has at least three valid points
+func (poly Polygon) Valid() bool {
+	if len(poly) < 3 {
+		return false
+	}
+	for _, p := range poly {
+		if !p.Valid() {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains reports whether p lies inside the polygon, using the even-odd
+// rule
+func (poly Polygon) Contains(p Point) bool {
+	inside := false
+	for i, j := 0, len(poly)-1; i < len(poly); j, i = i, i+1 {
+		a, b := poly[i], poly[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,71 @@
+package geo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistance(t *testing.T) {
+	mumbai := Point{19.0760, 72.8777}
+	pune := Point{18.5204, 73.8567}
+
+	assert.InDelta(t, 120, Distance(mumbai, pune), 2, "Mumbai to Pune as the crow flies")
+	assert.InDelta(t, Distance(mumbai, pune), Distance(pune, mumbai), 1e-9)
+	assert.Zero(t, Distance(pune, pune))
+	assert.InDelta(t, 20015, Distance(Point{0, 0}, Point{0, 180}), 1, "half way round the equator")
+}
+
+func TestBoundingBox(t *testing.T) {
+	box := BoundingBox{MinLat: 12.8, MinLng: 77.4, MaxLat: 13.2, MaxLng: 77.8}
+	require.True(t, box.Valid())
+	assert.True(t, box.Contains(Point{12.9716, 77.5946}))
+	assert.True(t, box.Contains(Point{12.8, 77.4}), "edges are inside")
+	assert.False(t, box.Contains(Point{18.5204, 73.8567}))
+	assert.False(t, BoundingBox{MinLat: 13, MaxLat: 12}.Valid())
+
+	pacific := BoundingBox{MinLat: -20, MinLng: 170, MaxLat: 20, MaxLng: -170}
+	assert.True(t, pacific.Contains(Point{0, 179}))
+	assert.True(t, pacific.Contains(Point{0, -175}))
+	assert.False(t, pacific.Contains(Point{0, 0}))
+}
+
+func TestPolygon(t *testing.T) {
+	// An L-shaped area
+	poly := Polygon{{0, 0}, {0, 2}, {1, 2}, {1, 1}, {2, 1}, {2, 0}}
+	require.True(t, poly.Valid())
+	assert.True(t, poly.Contains(Point{0.5, 1.5}))
+	assert.True(t, poly.Contains(Point{1.5, 0.5}))
+	assert.False(t, poly.Contains(Point{1.5, 1.5}), "the missing corner")
+	assert.False(t, poly.Contains(Point{3, 3}))
+	assert.False(t, Polygon{{0, 0}, {1, 1}}.Valid())
+}
+
+func TestGazetteer(t *testing.T) {
+	g := NewGazetteer(IndianCities...)
+
+	p, ok := g.Lookup("  BANGALORE ")
+	require.True(t, ok)
+	assert.Equal(t, Point{12.9716, 77.5946}, p)
+
+	p, ok = g.Lookup("Plot 4, Whitefield, Bangalore")
+	require.True(t, ok)
+	assert.Equal(t, Point{12.9698, 77.7500}, p, "the most specific part wins")
+
+	p, ok = g.Lookup("Near the station, West Pune")
+	require.True(t, ok)
+	assert.Equal(t, Point{18.5204, 73.8567}, p)
+
+	_, ok = g.Lookup("Atlantis")
+	assert.False(t, ok)
+
+	require.NoError(t, g.Load(strings.NewReader("name,latitude,longitude\nAtlantis, 10.5, -20.25\n")))
+	p, ok = g.Lookup("atlantis")
+	require.True(t, ok)
+	assert.Equal(t, Point{10.5, -20.25}, p)
+
+	assert.Error(t, g.Load(strings.NewReader("Atlantis,10.5,-20.25\nNowhere,north,west\n")))
+	assert.Error(t, g.Load(strings.NewReader("Nowhere,95,0\n")))
+}
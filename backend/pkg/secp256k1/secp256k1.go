@@ -0,0 +1,281 @@
+// Package secp256k1 implements the elliptic curve Ethereum signs with, as
+// far as needed to check wallet signatures: recovering the public key from a
+// recoverable ECDSA signature. Signing is included for tests and tools; it
+// is not constant time, so keys that matter should be kept out of it.
+package secp256k1
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// SignatureSize is the length of a recoverable signature: r and s, 32 bytes
+// each, and the recovery ID
+const SignatureSize = 65
+
+var (
+	// ErrInvalidSignature is returned for a signature that is malformed or
+	// that no public key produces for the hash
+	ErrInvalidSignature = errors.New("invalid secp256k1 signature")
+	// ErrInvalidKey is returned for a private key out of the curve's range
+	ErrInvalidKey = errors.New("invalid secp256k1 private key")
+)
+
+// Curve parameters (SEC 2, section 2.4.1): y² = x³ + 7 over the field of
+// order p, with base point G of order n
+var (
+	p     = fromHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F")
+	n     = fromHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141")
+	b     = big.NewInt(7)
+	g     = point{x: fromHex("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798"), y: fromHex("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8")}
+	halfN = new(big.Int).Rsh(n, 1)
+	// sqrtExp is (p+1)/4; since p ≡ 3 mod 4, a^sqrtExp is a square root of
+	// a whenever a has one
+	sqrtExp = new(big.Int).Rsh(new(big.Int).Add(p, big.NewInt(1)), 2)
+)
+
+// PublicKey is a point on the curve
+type PublicKey struct {
+	X, Y *big.Int
+}
+
+// Bytes returns the key uncompressed and without its 0x04 prefix: X and Y,
+// 32 bytes each, as Ethereum hashes it to derive an address
+func (k *PublicKey) Bytes() []byte {
+	out := make([]byte, 64)
+	k.X.FillBytes(out[:32])
+	k.Y.FillBytes(out[32:])
+	return out
+}
+
+// PrivateKey is a secret scalar and its public key
+type PrivateKey struct {
+	D *big.Int
+	PublicKey
+}
+
+// GenerateKey returns a new random private key
+func GenerateKey() (*PrivateKey, error) {
+	d, err := randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	return newPrivateKey(d), nil
+}
+
+// PrivateKeyFromBytes returns the private key with a 32 byte big-endian
+// secret
+func PrivateKeyFromBytes(secret []byte) (*PrivateKey, error) {
+	d := new(big.Int).SetBytes(secret)
+	if len(secret) != 32 || d.Sign() == 0 || d.Cmp(n) >= 0 {
+		return nil, ErrInvalidKey
+	}
+	return newPrivateKey(d), nil
+}
+
+func newPrivateKey(d *big.Int) *PrivateKey {
+	pub := g.mul(d)
+	return &PrivateKey{D: d, PublicKey: PublicKey{X: pub.x, Y: pub.y}}
+}
+
+// Sign signs a 32 byte hash, returning r, s and the recovery ID (0 or 1) in
+// Ethereum's layout. s is always in the lower half of the range.
+func (k *PrivateKey) Sign(hash []byte) ([]byte, error) {
+	e := hashToInt(hash)
+	for {
+		nonce, err := randomScalar()
+		if err != nil {
+			return nil, err
+		}
+
+		R := g.mul(nonce)
+		// An x beyond n would need a recovery ID of 2 or 3, which
+		// Ethereum cannot express; such a nonce is vanishingly unlikely
+		if R.x.Cmp(n) >= 0 {
+			continue
+		}
+		r := new(big.Int).Set(R.x)
+
+		s := new(big.Int).Mul(r, k.D)
+		s.Add(s, e)
+		s.Mul(s, new(big.Int).ModInverse(nonce, n))
+		s.Mod(s, n)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		recovery := byte(R.y.Bit(0))
+		if s.Cmp(halfN) > 0 {
+			s.Sub(n, s)
+			recovery ^= 1
+		}
+
+		sig := make([]byte, SignatureSize)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:64])
+		sig[64] = recovery
+		return sig, nil
+	}
+}
+
+// RecoverPublicKey returns the public key whose private key signed a 32
+// byte hash. The signature is r, s and a recovery ID of 0 or 1; wallets add
+// 27 to the recovery ID, and that form is accepted too.
+func RecoverPublicKey(hash, sig []byte) (*PublicKey, error) {
+	if len(hash) != 32 || len(sig) != SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+
+	recovery := sig[64]
+	if recovery >= 27 {
+		recovery -= 27
+	}
+	if recovery > 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if r.Sign() == 0 || r.Cmp(n) >= 0 || s.Sign() == 0 || s.Cmp(n) >= 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	// R is the point with x = r whose y has the parity of the recovery ID
+	R, ok := decompress(r, recovery == 1)
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+
+	// Q = r⁻¹(sR - eG)
+	e := hashToInt(hash)
+	rInv := new(big.Int).ModInverse(r, n)
+	u1 := new(big.Int).Mul(new(big.Int).Neg(e), rInv)
+	u1.Mod(u1, n)
+	u2 := new(big.Int).Mul(s, rInv)
+	u2.Mod(u2, n)
+
+	Q := g.mul(u1).add(R.mul(u2))
+	if Q.infinity() {
+		return nil, ErrInvalidSignature
+	}
+	return &PublicKey{X: Q.x, Y: Q.y}, nil
+}
+
+// point is a point on the curve in affine coordinates; nil coordinates are
+// the point at infinity
+type point struct {
+	x, y *big.Int
+}
+
+func (a point) infinity() bool {
+	return a.x == nil
+}
+
+// add returns a + b
+func (a point) add(b point) point {
+	switch {
+	case a.infinity():
+		return b
+	case b.infinity():
+		return a
+	case a.x.Cmp(b.x) == 0:
+		if a.y.Cmp(b.y) != 0 || a.y.Sign() == 0 {
+			return point{}
+		}
+		return a.double()
+	}
+
+	// λ = (y2 - y1) / (x2 - x1)
+	lambda := new(big.Int).Sub(b.y, a.y)
+	lambda.Mul(lambda, new(big.Int).ModInverse(new(big.Int).Sub(b.x, a.x), p))
+	lambda.Mod(lambda, p)
+	return a.withSlope(b, lambda)
+}
+
+// double returns a + a
+func (a point) double() point {
+	if a.infinity() || a.y.Sign() == 0 {
+		return point{}
+	}
+
+	// λ = 3x² / 2y
+	lambda := new(big.Int).Mul(a.x, a.x)
+	lambda.Mul(lambda, big.NewInt(3))
+	lambda.Mul(lambda, new(big.Int).ModInverse(new(big.Int).Lsh(a.y, 1), p))
+	lambda.Mod(lambda, p)
+	return a.withSlope(a, lambda)
+}
+
+// withSlope returns the third point on the line through a and b with slope
+// λ, mirrored in the x axis
+func (a point) withSlope(b point, lambda *big.Int) point {
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, a.x)
+	x.Sub(x, b.x)
+	x.Mod(x, p)
+
+	y := new(big.Int).Sub(a.x, x)
+	y.Mul(y, lambda)
+	y.Sub(y, a.y)
+	y.Mod(y, p)
+	return point{x: x, y: y}
+}
+
+// mul returns k·a by double-and-add
+func (a point) mul(k *big.Int) point {
+	result := point{}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		result = result.double()
+		if k.Bit(i) == 1 {
+			result = result.add(a)
+		}
+	}
+	return result
+}
+
+// decompress returns the point with the given x and the parity of y, if
+// x is on the curve
+func decompress(x *big.Int, odd bool) (point, bool) {
+	if x.Cmp(p) >= 0 {
+		return point{}, false
+	}
+
+	// y² = x³ + 7
+	y2 := new(big.Int).Exp(x, big.NewInt(3), p)
+	y2.Add(y2, b)
+	y2.Mod(y2, p)
+
+	y := new(big.Int).Exp(y2, sqrtExp, p)
+	if new(big.Int).Exp(y, big.NewInt(2), p).Cmp(y2) != 0 {
+		return point{}, false
+	}
+	if (y.Bit(0) == 1) != odd {
+		y.Sub(p, y)
+	}
+	return point{x: new(big.Int).Set(x), y: y}, true
+}
+
+// hashToInt reads a 32 byte hash as a number, as ECDSA does for curves of
+// 256 bit order
+func hashToInt(hash []byte) *big.Int {
+	return new(big.Int).SetBytes(hash)
+}
+
+// randomScalar returns a random number in [1, n)
+func randomScalar() (*big.Int, error) {
+	k, err := rand.Int(rand.Reader, new(big.Int).Sub(n, big.NewInt(1)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate scalar: %w", err)
+	}
+	return k.Add(k, big.NewInt(1)), nil
+}
+
+func fromHex(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("secp256k1: invalid constant " + s)
+	}
+	return v
+}
@@ -0,0 +1,102 @@
+package secp256k1
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKey(t *testing.T) {
+	secret := make([]byte, 32)
+	secret[31] = 1
+	one, err := PrivateKeyFromBytes(secret)
+	require.NoError(t, err)
+	assert.Equal(t, g.x, one.X, "1·G is G")
+	assert.Equal(t, g.y, one.Y)
+
+	secret[31] = 2
+	two, err := PrivateKeyFromBytes(secret)
+	require.NoError(t, err)
+	assert.Equal(t, fromHex("C6047F9441ED7D6D3045406E95C07CD85C778E4B8CEF3CA7ABAC09B95C709EE5"), two.X)
+	assert.Equal(t, fromHex("1AE168FEA63DC339A3C58419466CEAEEF7F632653266D0E1236431A950CFE52A"), two.Y)
+
+	assert.True(t, g.mul(n).infinity(), "n·G is the point at infinity")
+
+	_, err = PrivateKeyFromBytes(make([]byte, 32))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	_, err = PrivateKeyFromBytes(n.Bytes())
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	_, err = PrivateKeyFromBytes([]byte{1})
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestSignAndRecover(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte("sign in"))
+
+	for i := 0; i < 5; i++ {
+		sig, err := key.Sign(hash[:])
+		require.NoError(t, err)
+		require.Len(t, sig, SignatureSize)
+		assert.LessOrEqual(t, new(big.Int).SetBytes(sig[32:64]).Cmp(halfN), 0, "low s")
+
+		pub, err := RecoverPublicKey(hash[:], sig)
+		require.NoError(t, err)
+		assert.Equal(t, key.PublicKey.Bytes(), pub.Bytes())
+
+		// Wallets send the recovery ID plus 27
+		sig[64] += 27
+		pub, err = RecoverPublicKey(hash[:], sig)
+		require.NoError(t, err)
+		assert.Equal(t, key.PublicKey.Bytes(), pub.Bytes())
+	}
+
+	sig, err := key.Sign(hash[:])
+	require.NoError(t, err)
+
+	other := sha256.Sum256([]byte("sign out"))
+	pub, err := RecoverPublicKey(other[:], sig)
+	if err == nil {
+		assert.NotEqual(t, key.PublicKey.Bytes(), pub.Bytes(), "another hash recovers another key")
+	}
+
+	flipped := append([]byte(nil), sig...)
+	flipped[64] ^= 1
+	pub, err = RecoverPublicKey(hash[:], flipped)
+	if err == nil {
+		assert.NotEqual(t, key.PublicKey.Bytes(), pub.Bytes(), "the other recovery ID recovers another key")
+	}
+}
+
+func TestRecoverPublicKey_Invalid(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte("sign in"))
+	sig, err := key.Sign(hash[:])
+	require.NoError(t, err)
+
+	tamper := func(fn func(sig []byte)) []byte {
+		out := append([]byte(nil), sig...)
+		fn(out)
+		return out
+	}
+
+	cases := map[string][]byte{
+		"short":       sig[:64],
+		"recovery ID": tamper(func(s []byte) { s[64] = 2 }),
+		"zero r":      tamper(func(s []byte) { copy(s[:32], make([]byte, 32)) }),
+		"zero s":      tamper(func(s []byte) { copy(s[32:64], make([]byte, 32)) }),
+		"r beyond n":  tamper(func(s []byte) { n.FillBytes(s[:32]) }),
+	}
+	for name, bad := range cases {
+		_, err := RecoverPublicKey(hash[:], bad)
+		assert.ErrorIs(t, err, ErrInvalidSignature, name)
+	}
+
+	_, err = RecoverPublicKey(hash[:31], sig)
+	assert.ErrorIs(t, err, ErrInvalidSignature, "short hash")
+}
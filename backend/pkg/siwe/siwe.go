@@ -0,0 +1,388 @@
+// Package siwe implements Sign-In with Ethereum (EIP-4361): the message a
+// wallet signs to prove it controls an address, and recovering that address
+// from the wallet's personal_sign (EIP-191) signature.
+package siwe
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"goreal-backend/pkg/secp256k1"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Version is the only message version EIP-4361 defines
+const Version = "1"
+
+// MinNonceLength is the shortest nonce EIP-4361 allows
+const MinNonceLength = 8
+
+const headerSuffix = " wants you to sign in with your Ethereum account:"
+
+var (
+	// ErrInvalidMessage is returned for text that is not a SIWE message
+	ErrInvalidMessage = errors.New("invalid SIWE message")
+	// ErrInvalidAddress is returned for an address that is not 20 bytes of
+	// hex, or whose mixed case is not its EIP-55 checksum
+	ErrInvalidAddress = errors.New("invalid Ethereum address")
+	// ErrInvalidSignature is returned for a signature that is not 65 bytes
+	// of hex or that recovers no address
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrDomainMismatch is returned for a message meant for another site
+	ErrDomainMismatch = errors.New("SIWE message is for another domain")
+	// ErrSchemeMismatch is returned for a message whose scheme is not the
+	// expected origin's
+	ErrSchemeMismatch = errors.New("SIWE message is for another scheme")
+	// ErrURIMismatch is returned for a message whose URI is on another origin
+	ErrURIMismatch = errors.New("SIWE message URI is on another origin")
+	// ErrChainNotAllowed is returned for a message signed for a chain the
+	// verifier does not accept
+	ErrChainNotAllowed = errors.New("SIWE message is for a chain that is not allowed")
+	// ErrExpired is returned for a message past its expiration time
+	ErrExpired = errors.New("SIWE message has expired")
+	// ErrNotYetValid is returned for a message before its not-before time
+	ErrNotYetValid = errors.New("SIWE message is not valid yet")
+)
+
+// Message is a parsed EIP-4361 message
+type Message struct {
+	// Scheme is the optional scheme of the requesting site, as in https
+	Scheme string
+	// Domain is the host, and port if any, of the site asking to sign in
+	Domain string
+	// Address is the EIP-55 checksummed address signing in
+	Address   string
+	Statement string
+	URI       string
+	Version   string
+	ChainID   int64
+	Nonce     string
+	IssuedAt  time.Time
+
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// Parse reads a SIWE message. Messages are parsed strictly, as signed: the
+// signature covers the exact text, so nothing is normalized.
+func Parse(text string) (*Message, error) {
+	lines := strings.Split(text, "\n")
+	invalid := func(format string, args ...interface{}) (*Message, error) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMessage, fmt.Sprintf(format, args...))
+	}
+
+	if len(lines) < 8 || !strings.HasSuffix(lines[0], headerSuffix) {
+		return invalid("missing header")
+	}
+	msg := &Message{Domain: strings.TrimSuffix(lines[0], headerSuffix)}
+	if scheme, domain, ok := strings.Cut(msg.Domain, "://"); ok {
+		msg.Scheme, msg.Domain = scheme, domain
+	}
+	if msg.Domain == "" || strings.ContainsAny(msg.Domain, " /") {
+		return invalid("invalid domain %q", msg.Domain)
+	}
+
+	address, err := ChecksumAddress(lines[1])
+	if err != nil || address != lines[1] {
+		return invalid("address %q is not EIP-55 checksummed", lines[1])
+	}
+	msg.Address = address
+
+	// The statement is optional; either way it is set off by blank lines
+	if lines[2] != "" {
+		return invalid("missing blank line after address")
+	}
+	rest := lines[3:]
+	if rest[0] != "" {
+		if len(rest) < 2 || rest[1] != "" {
+			return invalid("missing blank line after statement")
+		}
+		msg.Statement = rest[0]
+		rest = rest[2:]
+	} else {
+		rest = rest[1:]
+	}
+
+	// The fields follow in a fixed order, the last four optional
+	field := func(name string, optional bool) (string, bool, error) {
+		prefix := name + ": "
+		if len(rest) == 0 || !strings.HasPrefix(rest[0], prefix) {
+			if optional {
+				return "", false, nil
+			}
+			return "", false, fmt.Errorf("%w: missing %s", ErrInvalidMessage, name)
+		}
+		value := strings.TrimPrefix(rest[0], prefix)
+		rest = rest[1:]
+		return value, true, nil
+	}
+	timeField := func(name string, optional bool) (*time.Time, error) {
+		value, ok, err := field(name, optional)
+		if err != nil || !ok {
+			return nil, err
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s is not an RFC 3339 time", ErrInvalidMessage, name)
+		}
+		return &t, nil
+	}
+
+	if msg.URI, _, err = field("URI", false); err != nil {
+		return nil, err
+	}
+	if uri, err := url.Parse(msg.URI); err != nil || uri.Scheme == "" {
+		return invalid("URI %q is not absolute", msg.URI)
+	}
+
+	if msg.Version, _, err = field("Version", false); err != nil {
+		return nil, err
+	}
+	if msg.Version != Version {
+		return invalid("unsupported version %q", msg.Version)
+	}
+
+	chainID, _, err := field("Chain ID", false)
+	if err != nil {
+		return nil, err
+	}
+	if msg.ChainID, err = strconv.ParseInt(chainID, 10, 64); err != nil || msg.ChainID <= 0 {
+		return invalid("invalid chain ID %q", chainID)
+	}
+
+	if msg.Nonce, _, err = field("Nonce", false); err != nil {
+		return nil, err
+	}
+	if !validNonce(msg.Nonce) {
+		return invalid("nonce must be at least %d letters and digits", MinNonceLength)
+	}
+
+	issuedAt, err := timeField("Issued At", false)
+	if err != nil {
+		return nil, err
+	}
+	msg.IssuedAt = *issuedAt
+	if msg.ExpirationTime, err = timeField("Expiration Time", true); err != nil {
+		return nil, err
+	}
+	if msg.NotBefore, err = timeField("Not Before", true); err != nil {
+		return nil, err
+	}
+	if msg.RequestID, _, err = field("Request ID", true); err != nil {
+		return nil, err
+	}
+
+	if len(rest) > 0 && rest[0] == "Resources:" {
+		for _, line := range rest[1:] {
+			resource, ok := strings.CutPrefix(line, "- ")
+			if !ok {
+				return invalid("invalid resource %q", line)
+			}
+			msg.Resources = append(msg.Resources, resource)
+		}
+		rest = nil
+	}
+	if len(rest) > 0 {
+		return invalid("unexpected line %q", rest[0])
+	}
+
+	return msg, nil
+}
+
+// String writes the message in the EIP-4361 format, ready to be signed
+func (m *Message) String() string {
+	var sb strings.Builder
+	if m.Scheme != "" {
+		sb.WriteString(m.Scheme + "://")
+	}
+	sb.WriteString(m.Domain + headerSuffix + "\n")
+	sb.WriteString(m.Address + "\n\n")
+	if m.Statement != "" {
+		sb.WriteString(m.Statement + "\n")
+	}
+	sb.WriteString("\n")
+
+	version := m.Version
+	if version == "" {
+		version = Version
+	}
+	fmt.Fprintf(&sb, "URI: %s\nVersion: %s\nChain ID: %d\nNonce: %s\nIssued At: %s",
+		m.URI, version, m.ChainID, m.Nonce, m.IssuedAt.Format(time.RFC3339Nano))
+	if m.ExpirationTime != nil {
+		sb.WriteString("\nExpiration Time: " + m.ExpirationTime.Format(time.RFC3339Nano))
+	}
+	if m.NotBefore != nil {
+		sb.WriteString("\nNot Before: " + m.NotBefore.Format(time.RFC3339Nano))
+	}
+	if m.RequestID != "" {
+		sb.WriteString("\nRequest ID: " + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		sb.WriteString("\nResources:")
+		for _, resource := range m.Resources {
+			sb.WriteString("\n- " + resource)
+		}
+	}
+	return sb.String()
+}
+
+// Expected is what a site verifying messages requires of them
+type Expected struct {
+	// Domain is the host, and port if any, messages must be for
+	Domain string
+	// Origin is the scheme, host and port, as in https://app.example.com,
+	// that message URIs must be on. A message that names a scheme must name
+	// this one.
+	Origin string
+	// ChainIDs are the chains messages may be signed for; a message for any
+	// other chain, or any chain when there are none, is refused
+	ChainIDs []int64
+}
+
+// Validate checks that the message is for the expected site and chain, and
+// valid at a time. It does not check the nonce or the signature.
+func (m *Message) Validate(expected Expected, at time.Time) error {
+	if !strings.EqualFold(m.Domain, expected.Domain) {
+		return fmt.Errorf("%w: %s", ErrDomainMismatch, m.Domain)
+	}
+
+	origin, err := url.Parse(expected.Origin)
+	if err != nil || origin.Scheme == "" || origin.Host == "" {
+		return fmt.Errorf("%w: expected origin %q is not absolute", ErrURIMismatch, expected.Origin)
+	}
+	if m.Scheme != "" && !strings.EqualFold(m.Scheme, origin.Scheme) {
+		return fmt.Errorf("%w: %s", ErrSchemeMismatch, m.Scheme)
+	}
+	uri, err := url.Parse(m.URI)
+	if err != nil || !strings.EqualFold(uri.Scheme, origin.Scheme) || !strings.EqualFold(uri.Host, origin.Host) {
+		return fmt.Errorf("%w: %s", ErrURIMismatch, m.URI)
+	}
+
+	if !slices.Contains(expected.ChainIDs, m.ChainID) {
+		return fmt.Errorf("%w: %d", ErrChainNotAllowed, m.ChainID)
+	}
+
+	if m.ExpirationTime != nil && !at.Before(*m.ExpirationTime) {
+		return ErrExpired
+	}
+	if m.NotBefore != nil && at.Before(*m.NotBefore) {
+		return ErrNotYetValid
+	}
+	return nil
+}
+
+// HashMessage returns the EIP-191 hash personal_sign signs: Keccak-256 of
+// the text behind a prefix that stops it passing for a transaction
+func HashMessage(text string) []byte {
+	return keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(text), text)))
+}
+
+// RecoverAddress returns the checksummed address whose key signed the text
+// with personal_sign
+func RecoverAddress(text string, signature []byte) (string, error) {
+	pub, err := secp256k1.RecoverPublicKey(HashMessage(text), signature)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return PublicKeyAddress(pub), nil
+}
+
+// Sign signs the text with personal_sign, as a wallet would. It is meant
+// for tests and tools.
+func Sign(key *secp256k1.PrivateKey, text string) ([]byte, error) {
+	sig, err := key.Sign(HashMessage(text))
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// DecodeSignature reads a 65 byte signature written in hex, with or
+// without a 0x prefix
+func DecodeSignature(s string) ([]byte, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(sig) != secp256k1.SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+	return sig, nil
+}
+
+// PublicKeyAddress returns the checksummed address of a public key: the
+// last 20 bytes of the Keccak-256 hash of its coordinates
+func PublicKeyAddress(pub *secp256k1.PublicKey) string {
+	return checksum(hex.EncodeToString(keccak256(pub.Bytes())[12:]))
+}
+
+// ChecksumAddress returns an address in its EIP-55 mixed-case form. An
+// address in all lower or all upper case is accepted as is; a mixed-case
+// one must already carry the right checksum.
+func ChecksumAddress(address string) (string, error) {
+	digits, ok := strings.CutPrefix(address, "0x")
+	if !ok || len(digits) != 40 {
+		return "", ErrInvalidAddress
+	}
+	if _, err := hex.DecodeString(digits); err != nil {
+		return "", ErrInvalidAddress
+	}
+
+	checksummed := checksum(strings.ToLower(digits))
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && address != checksummed {
+		return "", fmt.Errorf("%w: bad checksum", ErrInvalidAddress)
+	}
+	return checksummed, nil
+}
+
+// GenerateNonce returns a random nonce for a message: 128 bits in hex
+func GenerateNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// checksum applies EIP-55 to 40 lower-case hex digits: each letter is upper
+// cased where the matching nibble of the digits' Keccak-256 hash is 8 or more
+func checksum(digits string) string {
+	hash := keccak256([]byte(digits))
+	out := []byte(digits)
+	for i, c := range out {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+func validNonce(nonce string) bool {
+	if len(nonce) < MinNonceLength {
+		return false
+	}
+	for _, c := range nonce {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
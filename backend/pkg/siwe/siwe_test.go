@@ -0,0 +1,183 @@
+package siwe
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"goreal-backend/pkg/secp256k1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exampleMessage is the example from EIP-4361
+const exampleMessage = `service.invalid wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+I accept the ServiceOrg Terms of Service: https://service.invalid/tos
+
+URI: https://service.invalid/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+func TestParse(t *testing.T) {
+	msg, err := Parse(exampleMessage)
+	require.NoError(t, err)
+
+	assert.Equal(t, "service.invalid", msg.Domain)
+	assert.Equal(t, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", msg.Address)
+	assert.Equal(t, "I accept the ServiceOrg Terms of Service: https://service.invalid/tos", msg.Statement)
+	assert.Equal(t, "https://service.invalid/login", msg.URI)
+	assert.Equal(t, int64(1), msg.ChainID)
+	assert.Equal(t, "32891756", msg.Nonce)
+	assert.True(t, time.Date(2021, 9, 30, 16, 25, 24, 0, time.UTC).Equal(msg.IssuedAt))
+	assert.Nil(t, msg.ExpirationTime)
+	assert.Len(t, msg.Resources, 2)
+	assert.Equal(t, exampleMessage, msg.String(), "round trip")
+
+	expires := msg.IssuedAt.Add(time.Hour)
+	minimal := &Message{Scheme: "https", Domain: "localhost:3000", Address: msg.Address,
+		URI: "http://localhost:3000", ChainID: 11155111, Nonce: "abcdef0123456789",
+		IssuedAt: msg.IssuedAt, ExpirationTime: &expires, RequestID: "42"}
+	parsed, err := Parse(minimal.String())
+	require.NoError(t, err)
+	assert.Equal(t, "https", parsed.Scheme)
+	assert.Equal(t, "localhost:3000", parsed.Domain)
+	assert.Empty(t, parsed.Statement)
+	require.NotNil(t, parsed.ExpirationTime)
+	assert.True(t, expires.Equal(*parsed.ExpirationTime))
+	assert.Equal(t, "42", parsed.RequestID)
+	assert.Equal(t, minimal.String(), parsed.String())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"header":       strings.Replace(exampleMessage, "wants you to sign in", "asks you to sign in", 1),
+		"checksum":     strings.Replace(exampleMessage, "0xC02aaA39", "0xc02aaA39", 1),
+		"lower case":   strings.Replace(exampleMessage, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", 1),
+		"version":      strings.Replace(exampleMessage, "Version: 1", "Version: 2", 1),
+		"chain ID":     strings.Replace(exampleMessage, "Chain ID: 1", "Chain ID: one", 1),
+		"short nonce":  strings.Replace(exampleMessage, "Nonce: 32891756", "Nonce: 1234", 1),
+		"nonce":        strings.Replace(exampleMessage, "Nonce: 32891756", "Nonce: 3289-1756", 1),
+		"issued at":    strings.Replace(exampleMessage, "2021-09-30T16:25:24Z", "yesterday", 1),
+		"missing URI":  strings.Replace(exampleMessage, "URI: https://service.invalid/login\n", "", 1),
+		"relative URI": strings.Replace(exampleMessage, "https://service.invalid/login", "/login", 1),
+		"field order":  strings.Replace(exampleMessage, "Version: 1\nChain ID: 1", "Chain ID: 1\nVersion: 1", 1),
+		"trailing":     exampleMessage + "\nbonus line",
+		"empty":        "",
+	}
+	for name, text := range cases {
+		_, err := Parse(text)
+		assert.ErrorIs(t, err, ErrInvalidMessage, name)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	msg, err := Parse(exampleMessage)
+	require.NoError(t, err)
+	at := msg.IssuedAt.Add(time.Minute)
+	expected := Expected{Domain: "service.invalid", Origin: "https://service.invalid", ChainIDs: []int64{1, 11155111}}
+
+	assert.NoError(t, msg.Validate(expected, at))
+	upper := expected
+	upper.Domain, upper.Origin = "Service.Invalid", "HTTPS://Service.Invalid/app"
+	assert.NoError(t, msg.Validate(upper, at), "case and path do not matter")
+
+	refuse := func(edit func(m *Message, e *Expected), want error) {
+		t.Helper()
+		m, e := *msg, expected
+		edit(&m, &e)
+		assert.ErrorIs(t, m.Validate(e, at), want)
+	}
+	refuse(func(m *Message, e *Expected) { e.Domain = "evil.invalid" }, ErrDomainMismatch)
+	refuse(func(m *Message, e *Expected) { m.Scheme = "http" }, ErrSchemeMismatch)
+	refuse(func(m *Message, e *Expected) { m.URI = "https://evil.invalid/login" }, ErrURIMismatch)
+	refuse(func(m *Message, e *Expected) { m.URI = "http://service.invalid/login" }, ErrURIMismatch)
+	refuse(func(m *Message, e *Expected) { m.URI = "https://service.invalid:8443/login" }, ErrURIMismatch)
+	refuse(func(m *Message, e *Expected) { e.Origin = "/login" }, ErrURIMismatch)
+	refuse(func(m *Message, e *Expected) { m.ChainID = 137 }, ErrChainNotAllowed)
+	refuse(func(m *Message, e *Expected) { e.ChainIDs = nil }, ErrChainNotAllowed)
+
+	expires, notBefore := at.Add(time.Minute), at.Add(-time.Second)
+	msg.ExpirationTime, msg.NotBefore = &expires, &notBefore
+	assert.NoError(t, msg.Validate(expected, at))
+	assert.ErrorIs(t, msg.Validate(expected, expires), ErrExpired)
+	assert.ErrorIs(t, msg.Validate(expected, notBefore.Add(-time.Second)), ErrNotYetValid)
+}
+
+func TestChecksumAddress(t *testing.T) {
+	// EIP-55 test vectors
+	for _, address := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		got, err := ChecksumAddress(address)
+		require.NoError(t, err, address)
+		assert.Equal(t, address, got)
+
+		got, err = ChecksumAddress(strings.ToLower(address))
+		require.NoError(t, err, address)
+		assert.Equal(t, address, got, "lower case is checksummed")
+	}
+
+	for _, bad := range []string{
+		"0x5aaeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg",
+	} {
+		_, err := ChecksumAddress(bad)
+		assert.ErrorIs(t, err, ErrInvalidAddress, bad)
+	}
+}
+
+func TestHashMessage(t *testing.T) {
+	assert.Equal(t, "a1de988600a42c4b4ab089b619297c17d53cffae5d5120d82d8a92d0bb3b78f2",
+		hex.EncodeToString(HashMessage("Hello World")))
+}
+
+func TestRecoverAddress(t *testing.T) {
+	secret := make([]byte, 32)
+	secret[31] = 1
+	key, err := secp256k1.PrivateKeyFromBytes(secret)
+	require.NoError(t, err)
+	assert.Equal(t, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", PublicKeyAddress(&key.PublicKey))
+
+	sig, err := Sign(key, exampleMessage)
+	require.NoError(t, err)
+	assert.Contains(t, []byte{27, 28}, sig[64])
+
+	decoded, err := DecodeSignature("0x" + hex.EncodeToString(sig))
+	require.NoError(t, err)
+	address, err := RecoverAddress(exampleMessage, decoded)
+	require.NoError(t, err)
+	assert.Equal(t, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", address)
+
+	address, err = RecoverAddress(exampleMessage+" ", decoded)
+	if err == nil {
+		assert.NotEqual(t, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", address, "signature covers the exact text")
+	}
+
+	_, err = DecodeSignature("0x1234")
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+	_, err = DecodeSignature(strings.Repeat("zz", 65))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestGenerateNonce(t *testing.T) {
+	a, err := GenerateNonce()
+	require.NoError(t, err)
+	b, err := GenerateNonce()
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+	assert.True(t, validNonce(a))
+}
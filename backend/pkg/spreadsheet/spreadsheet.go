@@ -0,0 +1,64 @@
+// Package spreadsheet reads the rows of CSV files and of the first sheet of
+// XLSX workbooks using only the standard library.
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Read returns the rows of a CSV file or XLSX workbook. XLSX workbooks are
+// recognised by their zip signature; anything else is read as CSV.
+// Trailing empty rows are dropped.
+func Read(data []byte) ([][]string, error) {
+	if IsXLSX(data) {
+		return ReadXLSX(data)
+	}
+	return ReadCSV(bytes.NewReader(data))
+}
+
+// IsXLSX reports whether data looks like an XLSX workbook
+func IsXLSX(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// ReadCSV returns the rows of a CSV file. Rows may have different numbers
+// of fields, and a leading byte order mark is ignored.
+func ReadCSV(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse csv: %w", err)
+	}
+	return trimRows(rows), nil
+}
+
+// trimRows drops trailing empty rows
+func trimRows(rows [][]string) [][]string {
+	for len(rows) > 0 && isEmpty(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+// isEmpty reports whether every cell of a row is blank
+func isEmpty(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	rows, err := Read([]byte("\xef\xbb\xbfunit_number, base_price\nA-101,\"5,000,000\"\nA-102\n\n"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"unit_number", "base_price"}, {"A-101", "5,000,000"}, {"A-102"}}, rows)
+
+	_, err = Read([]byte("a,\"b\nc"))
+	assert.Error(t, err)
+}
+
+func TestReadXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Units" sheetId="1" r:id="rId3"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId3" Type="worksheet" Target="worksheets/units.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<si><t>unit_number</t></si><si><t>base_price</t></si>` +
+			`<si><r><t>A-</t></r><r><rPr><b/></rPr><t>101</t></r><rPh><t>x</t></rPh></si></sst>`,
+		"xl/worksheets/units.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="D1" t="inlineStr"><is><t>corner</t></is></c></row>` +
+			`<row r="2"><c r="A2" t="s"><v>2</v></c><c r="B2"><v>5.5E6</v></c><c r="D2" t="b"><v>1</v></c></row>` +
+			`<row r="4"><c r="B4" t="str"><v>TBD</v></c></row>` +
+			`<row r="5"><c r="A5" t="inlineStr"><is><t></t></is></c></row>` +
+			`</sheetData></worksheet>`,
+	} {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	rows, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"unit_number", "base_price", "", "corner"},
+		{"A-101", "5500000", "", "TRUE"},
+		{},
+		{"", "TBD"},
+	}, rows)
+
+	_, err = ReadXLSX([]byte(strings.Repeat("x", 10)))
+	assert.Error(t, err)
+}
+
+func TestColumnIndex(t *testing.T) {
+	for ref, want := range map[string]int{"A1": 0, "z9": 25, "AA10": 26, "XFD1": 16383} {
+		got, err := columnIndex(ref)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, ref)
+	}
+	_, err := columnIndex("XFE1")
+	assert.Error(t, err)
+	_, err = columnIndex("12")
+	assert.Error(t, err)
+}
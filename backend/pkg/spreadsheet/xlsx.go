@@ -0,0 +1,260 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxPartSize bounds how much of a single workbook part is decompressed
+const maxPartSize = 64 << 20
+
+// maxColumns bounds the column of a cell reference (XFD, the last column
+// Excel allows)
+const maxColumns = 16384
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Attrs []xml.Attr `xml:",any,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxText is a plain or rich text string. Phonetic runs are ignored.
+type xlsxText struct {
+	T    *string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if t.T != nil {
+		return *t.T
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string    `xml:"r,attr"`
+			T      string    `xml:"t,attr"`
+			V      string    `xml:"v"`
+			Inline *xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX returns the rows of the first sheet of an XLSX workbook. Cells
+// hold their displayed text for strings and booleans and their raw value
+// for numbers, so dates come back as serial day numbers. Missing cells and
+// rows are filled with empty strings, and trailing empty rows are dropped.
+func ReadXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open xlsx: %w", err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[strings.TrimPrefix(file.Name, "/")] = file
+	}
+
+	sheetPath, err := firstSheet(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared []string
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst xlsxSharedStrings
+		if err := decodePart(file, &sst); err != nil {
+			return nil, err
+		}
+		shared = make([]string, len(sst.Items))
+		for i, item := range sst.Items {
+			shared[i] = item.String()
+		}
+	}
+
+	file, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("failed to read xlsx: missing sheet %s", sheetPath)
+	}
+	var sheet xlsxSheet
+	if err := decodePart(file, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		index := len(rows)
+		if row.R > 0 {
+			index = row.R - 1
+		}
+		if index < len(rows) {
+			return nil, fmt.Errorf("failed to read xlsx: row %d is out of order", row.R)
+		}
+		for len(rows) < index {
+			rows = append(rows, []string{})
+		}
+
+		var cells []string
+		for _, cell := range row.Cells {
+			column := len(cells)
+			if cell.R != "" {
+				if column, err = columnIndex(cell.R); err != nil {
+					return nil, err
+				}
+			}
+			if column < len(cells) {
+				return nil, fmt.Errorf("failed to read xlsx: cell %s is out of order", cell.R)
+			}
+			for len(cells) < column {
+				cells = append(cells, "")
+			}
+
+			value, err := cellValue(cell.T, cell.V, cell.Inline, shared)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read xlsx cell %s: %w", cell.R, err)
+			}
+			cells = append(cells, value)
+		}
+		rows = append(rows, cells)
+	}
+	return trimRows(rows), nil
+}
+
+// firstSheet returns the path of the workbook's first sheet
+func firstSheet(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	file, ok := files["xl/workbook.xml"]
+	if !ok {
+		if _, ok := files[fallback]; ok {
+			return fallback, nil
+		}
+		return "", errors.New("failed to read xlsx: missing workbook")
+	}
+	var workbook xlsxWorkbook
+	if err := decodePart(file, &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", errors.New("failed to read xlsx: workbook has no sheets")
+	}
+
+	var id string
+	for _, attr := range workbook.Sheets[0].Attrs {
+		if attr.Name.Local == "id" && attr.Name.Space != "" {
+			id = attr.Value
+		}
+	}
+	file, ok = files["xl/_rels/workbook.xml.rels"]
+	if id == "" || !ok {
+		return fallback, nil
+	}
+	var rels xlsxRelationships
+	if err := decodePart(file, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != id {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+// decodePart unmarshals one XML part of the workbook
+func decodePart(file *zip.File, v any) error {
+	r, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open xlsx part %s: %w", file.Name, err)
+	}
+	defer r.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(r, maxPartSize)).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse xlsx part %s: %w", file.Name, err)
+	}
+	return nil
+}
+
+// cellValue returns the text of a cell of the given type
+func cellValue(kind, value string, inline *xlsxText, shared []string) (string, error) {
+	switch kind {
+	case "s":
+		i, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || i < 0 || i >= len(shared) {
+			return "", fmt.Errorf("invalid shared string %q", value)
+		}
+		return shared[i], nil
+	case "inlineStr":
+		if inline == nil {
+			return "", nil
+		}
+		return inline.String(), nil
+	case "b":
+		if strings.TrimSpace(value) == "1" {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case "str", "e":
+		return value, nil
+	default:
+		// Numbers are stored in full precision and may use exponents
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return value, nil
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	}
+}
+
+// columnIndex returns the zero-based column of a cell reference such as "B7"
+func columnIndex(ref string) (int, error) {
+	column := 0
+	letters := 0
+	for _, r := range ref {
+		if r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A'+1)
+		letters++
+		if column > maxColumns {
+			return 0, fmt.Errorf("failed to read xlsx: cell reference %s is out of range", ref)
+		}
+	}
+	if letters == 0 {
+		return 0, fmt.Errorf("failed to read xlsx: invalid cell reference %s", ref)
+	}
+	return column - 1, nil
+}
@@ -0,0 +1,79 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHOTP(t *testing.T) {
+	// RFC 6238 appendix B, SHA-1
+	key := []byte("12345678901234567890")
+	vectors := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	}
+	for unix, want := range vectors {
+		assert.Equal(t, want, hotp(key, Step(time.Unix(unix, 0)), 8), "T=%d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	at := time.Unix(1111111109, 0)
+
+	code, err := Code(secret, at)
+	require.NoError(t, err)
+	assert.Equal(t, "081804", code)
+
+	step, ok := Validate(secret, code, at, 1)
+	assert.True(t, ok)
+	assert.Equal(t, Step(at), step)
+
+	step, ok = Validate(secret, code, at.Add(Period), 1)
+	assert.True(t, ok, "one step of drift")
+	assert.Equal(t, Step(at), step)
+
+	_, ok = Validate(secret, code, at.Add(2*Period), 1)
+	assert.False(t, ok, "two steps of drift")
+	_, ok = Validate(secret, "123456", at, 1)
+	assert.False(t, ok)
+	_, ok = Validate(secret, "81804", at, 1)
+	assert.False(t, ok)
+	_, ok = Validate("not base32!", code, at, 1)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	require.NoError(t, err)
+	b, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+	assert.Len(t, a, 32, "160 bits in base32")
+
+	key, err := decode(a)
+	require.NoError(t, err)
+	assert.Len(t, key, secretSize)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(ProvisioningURI("JBSWY3DPEHPK3PXP", "GoReal", "ana@example.com"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/GoReal:ana@example.com", uri.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", uri.Query().Get("secret"))
+	assert.Equal(t, "GoReal", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+}
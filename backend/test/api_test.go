@@ -1,19 +1,32 @@
 package test
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
+	"time"
 
 	"goreal-backend/internal/container"
 	"goreal-backend/internal/domain"
 	"goreal-backend/internal/handlers"
+	"goreal-backend/internal/infrastructure/memory"
+	"goreal-backend/internal/middleware"
 	"goreal-backend/pkg/observability"
+	"goreal-backend/pkg/secp256k1"
+	"goreal-backend/pkg/siwe"
+	"goreal-backend/pkg/totp"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -23,7 +36,10 @@ func setupTestServer(t *testing.T) *httptest.Server {
 	// Initialize observability (disabled for tests)
 	observability.Init("goreal-test", "test")
 
-	// Initialize services
+	// Initialize services against the seeded in-memory backend
+	t.Setenv("DATA_BACKEND", "memory")
+	t.Setenv("MAIL_PROVIDER", "file")
+	t.Setenv("MAIL_OUTBOX_DIR", t.TempDir())
 	serviceContainer, err := container.NewContainer()
 	require.NoError(t, err)
 
@@ -34,16 +50,20 @@ func setupTestServer(t *testing.T) *httptest.Server {
 		serviceContainer.ClientService,
 		serviceContainer.LeadService,
 		serviceContainer.SalesService,
+		serviceContainer.CommissionService,
+		serviceContainer.PropertyService,
 		serviceContainer.TaskService,
 		nil, // notificationService - not available in container yet
 		serviceContainer.AnalyticsService,
+		serviceContainer.SearchService,
+		serviceContainer.OrganizationService,
 	)
 
 	// Setup router
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.RequestID)
+	r.Use(chimiddleware.Logger)
+	r.Use(chimiddleware.Recoverer)
+	r.Use(chimiddleware.RequestID)
 
 	// API routes
 	r.Route("/api", func(r chi.Router) {
@@ -57,22 +77,80 @@ func setupTestServer(t *testing.T) *httptest.Server {
 		// Authentication routes (public)
 		r.Route("/auth", handlerContainer.AuthHandler.Routes)
 
-		// Protected routes (no auth middleware for testing)
+		// Protected routes (authentication is optional for testing, but role
+		// checks apply)
 		r.Group(func(r chi.Router) {
+			r.Use(middleware.OptionalAuth(serviceContainer.AuthService))
+
 			// User management routes
 			r.Route("/users", handlerContainer.UserHandler.Routes)
 
 			// Client management routes
-			r.Route("/clients", handlerContainer.ClientHandler.Routes)
+			r.Route("/clients", func(r chi.Router) {
+				handlerContainer.ClientHandler.Routes(r)
+				handlerContainer.PropertyHandler.ClientRoutes(r)
+			})
+
+			// Lead management routes
+			r.Route("/leads", handlerContainer.LeadHandler.Routes)
 
 			// Task management routes
 			r.Route("/tasks", handlerContainer.TaskHandler.Routes)
+
+			// Sales routes
+			r.Route("/sales", handlerContainer.SalesHandler.Routes)
+
+			// Commission routes
+			r.Route("/commissions", handlerContainer.CommissionHandler.Routes)
+
+			// Property routes
+			r.Route("/societies", handlerContainer.PropertyHandler.SocietyRoutes)
+			r.Route("/projects", handlerContainer.PropertyHandler.ProjectRoutes)
+			r.Route("/inventory", handlerContainer.PropertyHandler.InventoryRoutes)
+
+			// Search routes
+			r.Route("/search", handlerContainer.SearchHandler.Routes)
+
+			// Organization routes
+			r.With(middleware.AdminOnly()).Route("/organizations", handlerContainer.OrganizationHandler.Routes)
 		})
 	})
 
 	return httptest.NewServer(r)
 }
 
+// signIn logs a seeded user in and returns their access token
+func signIn(t *testing.T, server *httptest.Server, email string) string {
+	t.Helper()
+	resp := do(t, http.MethodPost, server.URL+"/api/auth/login", "",
+		map[string]string{"email": email, "password": memory.DemoPassword})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "login as %s", email)
+
+	var body struct {
+		Data domain.AuthResponse `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body.Data.AccessToken
+}
+
+// do sends a request, with a bearer token and a JSON payload when given
+func do(t *testing.T, method, url, token string, payload interface{}) *http.Response {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		require.NoError(t, json.NewEncoder(&body).Encode(payload))
+	}
+	req, err := http.NewRequest(method, url, &body)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
 func TestHealthCheck(t *testing.T) {
 	server := setupTestServer(t)
 	defer server.Close()
@@ -89,6 +167,7 @@ func TestHealthCheck(t *testing.T) {
 func TestUserAPI(t *testing.T) {
 	server := setupTestServer(t)
 	defer server.Close()
+	admin := signIn(t, server, "admin@goreal.com")
 
 	t.Run("CreateUser", func(t *testing.T) {
 		user := domain.CreateUserRequest{
@@ -98,11 +177,7 @@ func TestUserAPI(t *testing.T) {
 			Role:     domain.RoleUser,
 		}
 
-		jsonData, err := json.Marshal(user)
-		require.NoError(t, err)
-
-		resp, err := http.Post(server.URL+"/api/users/", "application/json", bytes.NewBuffer(jsonData))
-		require.NoError(t, err)
+		resp := do(t, http.MethodPost, server.URL+"/api/users/", admin, user)
 		defer resp.Body.Close()
 
 		// This might fail due to database not being available in test
@@ -124,6 +199,7 @@ func TestUserAPI(t *testing.T) {
 func TestClientAPI(t *testing.T) {
 	server := setupTestServer(t)
 	defer server.Close()
+	employee := signIn(t, server, "employee1@goreal.com")
 
 	t.Run("CreateClient", func(t *testing.T) {
 		client := domain.CreateClientRequest{
@@ -133,11 +209,7 @@ func TestClientAPI(t *testing.T) {
 			ClientType: domain.ClientTypeIndividual,
 		}
 
-		jsonData, err := json.Marshal(client)
-		require.NoError(t, err)
-
-		resp, err := http.Post(server.URL+"/api/clients/", "application/json", bytes.NewBuffer(jsonData))
-		require.NoError(t, err)
+		resp := do(t, http.MethodPost, server.URL+"/api/clients/", employee, client)
 		defer resp.Body.Close()
 
 		// This might fail due to database not being available in test
@@ -154,11 +226,178 @@ func TestClientAPI(t *testing.T) {
 		// But it shows the endpoint is reachable
 		assert.True(t, resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusInternalServerError)
 	})
+
+	t.Run("ListClientsWithCursor", func(t *testing.T) {
+		type page struct {
+			Data       []domain.Client `json:"data"`
+			Pagination struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"pagination"`
+		}
+		get := func(query string) page {
+			resp := do(t, http.MethodGet, server.URL+"/api/clients/?"+query, employee, nil)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var p page
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+			return p
+		}
+
+		first := get("limit=1")
+		require.Len(t, first.Data, 1)
+		require.NotEmpty(t, first.Pagination.NextCursor)
+
+		second := get("limit=1&cursor=" + first.Pagination.NextCursor)
+		require.Len(t, second.Data, 1)
+		assert.NotEqual(t, first.Data[0].ID, second.Data[0].ID)
+		assert.False(t, second.Data[0].CreatedAt.After(first.Data[0].CreatedAt), "pages are newest first")
+
+		resp := do(t, http.MethodGet, server.URL+"/api/clients/?cursor=not-a-cursor", employee, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("UpdateWithIfMatch", func(t *testing.T) {
+		url := server.URL + "/api/clients/250e8400-e29b-41d4-a716-446655440001"
+
+		resp := do(t, http.MethodGet, url, employee, nil)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		stale := resp.Header.Get("ETag")
+		require.NotEmpty(t, stale)
+
+		put := func(etag, name string) *http.Response {
+			body, err := json.Marshal(domain.UpdateClientRequest{Name: stringPtr(name)})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("If-Match", etag)
+			req.Header.Set("Authorization", "Bearer "+employee)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			return resp
+		}
+
+		resp = put(stale, "Michael J. Brown")
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NotEqual(t, stale, resp.Header.Get("ETag"))
+
+		// A second writer still holding the old ETag gets the current record back
+		resp = put(stale, "Mike Brown")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		var conflict struct {
+			Data domain.Client `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&conflict))
+		assert.Equal(t, "Michael J. Brown", conflict.Data.Name)
+		assert.Equal(t, resp.Header.Get("ETag"), `"`+domain.Version(conflict.Data.UpdatedAt)+`"`)
+	})
+}
+
+func TestLeadAPI(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+	employee := signIn(t, server, "employee1@goreal.com")
+
+	// page walks a lead listing through its cursors and returns every lead seen
+	page := func(t *testing.T, query string) []domain.Lead {
+		var seen []domain.Lead
+		next := server.URL + "/api/leads?limit=2" + query
+		for pages := 0; next != ""; pages++ {
+			require.Less(t, pages, 10, "the cursor must move forward")
+			resp := do(t, http.MethodGet, next, employee, nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var body struct {
+				Data       []domain.Lead `json:"data"`
+				Pagination struct {
+					NextCursor string `json:"next_cursor"`
+				} `json:"pagination"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			resp.Body.Close()
+			assert.LessOrEqual(t, len(body.Data), 2)
+			seen = append(seen, body.Data...)
+			next = ""
+			if body.Pagination.NextCursor != "" {
+				next = server.URL + "/api/leads?limit=2" + query + "&cursor=" + body.Pagination.NextCursor
+			}
+		}
+		return seen
+	}
+
+	t.Run("ListPagesWithCursor", func(t *testing.T) {
+		leads := page(t, "")
+		ids := map[uuid.UUID]bool{}
+		for _, lead := range leads {
+			ids[lead.ID] = true
+		}
+		assert.Len(t, leads, 5)
+		assert.Len(t, ids, 5, "no lead is repeated across pages")
+
+		mine := page(t, "&assigned_to="+memory.SeedEmployee1ID.String())
+		assert.Len(t, mine, 3, "filters apply on every page")
+
+		resp := do(t, http.MethodGet, server.URL+"/api/leads?cursor=garbage", employee, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("CreateAndScore", func(t *testing.T) {
+		resp := do(t, http.MethodPost, server.URL+"/api/leads", employee, map[string]interface{}{
+			"name":   "Priya Nair",
+			"source": "website",
+		})
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var created struct {
+			Data domain.Lead `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+		resp.Body.Close()
+		require.NotNil(t, created.Data.AssignedTo)
+		assert.Equal(t, memory.SeedEmployee1ID, *created.Data.AssignedTo, "the lead defaults to the current user")
+
+		leadURL := server.URL + "/api/leads/" + created.Data.ID.String()
+		resp = do(t, http.MethodPut, leadURL+"/score", employee, map[string]int{"score": 80})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = do(t, http.MethodGet, leadURL, employee, nil)
+		var got struct {
+			Data domain.Lead `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		resp.Body.Close()
+		assert.Equal(t, 80, got.Data.Score)
+	})
+
+	t.Run("RequiresEmployee", func(t *testing.T) {
+		client := signIn(t, server, "client1@goreal.com")
+		resp := do(t, http.MethodGet, server.URL+"/api/leads", client, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp = do(t, http.MethodGet, server.URL+"/api/leads", "", nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		resp = do(t, http.MethodPost, server.URL+"/api/leads/bulk-assign", employee, map[string]interface{}{
+			"lead_ids": []string{"350e8400-e29b-41d4-a716-446655440001"},
+			"user_id":  memory.SeedEmployee2ID,
+		})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, "bulk changes are for managers")
+	})
 }
 
 func TestTaskAPI(t *testing.T) {
 	server := setupTestServer(t)
 	defer server.Close()
+	employee := signIn(t, server, "employee1@goreal.com")
 
 	t.Run("CreateTask", func(t *testing.T) {
 		task := domain.CreateTaskRequest{
@@ -167,11 +406,7 @@ func TestTaskAPI(t *testing.T) {
 			Priority:    stringPtr("medium"),
 		}
 
-		jsonData, err := json.Marshal(task)
-		require.NoError(t, err)
-
-		resp, err := http.Post(server.URL+"/api/tasks/", "application/json", bytes.NewBuffer(jsonData))
-		require.NoError(t, err)
+		resp := do(t, http.MethodPost, server.URL+"/api/tasks/", employee, task)
 		defer resp.Body.Close()
 
 		// This might fail due to database not being available in test
@@ -190,6 +425,530 @@ func TestTaskAPI(t *testing.T) {
 	})
 }
 
+func TestSearchAPI(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+
+	t.Run("Search", func(t *testing.T) {
+		resp := do(t, http.MethodGet, server.URL+"/api/search/?q=chen", signIn(t, server, "manager@goreal.com"), nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data domain.SearchResults `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		// Lisa Chen was converted, so she is both a lead and a client
+		types := map[domain.SearchEntityType]bool{}
+		for _, hit := range body.Data.Hits {
+			if hit.Title == "Lisa Chen" {
+				types[hit.Type] = true
+			}
+		}
+		assert.True(t, types[domain.SearchEntityLead], "lead hit")
+		assert.True(t, types[domain.SearchEntityClient], "client hit")
+	})
+
+	t.Run("RejectsShortQuery", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/api/search/?q=c")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestOrganizationAPI(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+	admin := signIn(t, server, "admin@goreal.com")
+	url := server.URL + "/api/organizations/"
+
+	t.Run("ListOwnOrganization", func(t *testing.T) {
+		resp := do(t, http.MethodGet, url, admin, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data []domain.Organization `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Len(t, body.Data, 1)
+		assert.Equal(t, memory.SeedOrganizationID, body.Data[0].ID)
+	})
+
+	t.Run("Rename", func(t *testing.T) {
+		resp := do(t, http.MethodPut, url+memory.SeedOrganizationID.String(), admin,
+			map[string]string{"name": "GoReal Realty"})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data domain.Organization `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, "GoReal Realty", body.Data.Name)
+		assert.Equal(t, "default", body.Data.Slug)
+	})
+
+	t.Run("OtherOrganizationNotFound", func(t *testing.T) {
+		resp := do(t, http.MethodGet, url+uuid.New().String(), admin, nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("CreateRequiresSuperAdmin", func(t *testing.T) {
+		resp := do(t, http.MethodPost, url, admin, map[string]string{"name": "Rival Realty"})
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp = do(t, http.MethodPost, url+memory.SeedOrganizationID.String()+"/members", admin,
+			map[string]string{"user_id": memory.SeedEmployee1ID.String()})
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("RequiresAdmin", func(t *testing.T) {
+		resp := do(t, http.MethodGet, url, signIn(t, server, "manager@goreal.com"), nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestSalesAPI(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+	employee := signIn(t, server, "employee1@goreal.com")
+
+	// SAL202400003 was approved and then completed
+	url := server.URL + "/api/sales/850e8400-e29b-41d4-a716-446655440003"
+
+	t.Run("History", func(t *testing.T) {
+		resp := do(t, http.MethodGet, url+"/history", employee, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data []domain.SaleStatusHistory `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Len(t, body.Data, 2)
+		assert.Equal(t, domain.SaleStatusApproved, body.Data[0].ToStatus)
+		assert.Equal(t, domain.SaleStatusCompleted, body.Data[1].ToStatus)
+	})
+
+	t.Run("HistoryOfUnknownSale", func(t *testing.T) {
+		resp := do(t, http.MethodGet, server.URL+"/api/sales/00000000-0000-0000-0000-000000000000/history", employee, nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("RequiresEmployee", func(t *testing.T) {
+		client := signIn(t, server, "client2@goreal.com")
+		for _, path := range []string{"", "/history", "/agreement?format=pdf", "/payments"} {
+			resp := do(t, http.MethodGet, url+path, client, nil)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode, path)
+
+			resp = do(t, http.MethodGet, url+path, "", nil)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, path)
+		}
+	})
+
+	t.Run("TransitionRequiresUser", func(t *testing.T) {
+		resp, err := http.Post(url+"/transition", "application/json", bytes.NewBufferString(`{"status":"cancelled"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("AgreementFormat", func(t *testing.T) {
+		resp := do(t, http.MethodGet, url+"/agreement?format=odt", employee, nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("AgreementTemplates", func(t *testing.T) {
+		resp := do(t, http.MethodGet, server.URL+"/api/sales/agreement-templates", employee, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data []domain.AgreementTemplate `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Empty(t, body.Data)
+	})
+
+	t.Run("PaymentSchedule", func(t *testing.T) {
+		resp := do(t, http.MethodGet, server.URL+"/api/sales/850e8400-e29b-41d4-a716-446655440001/payments", employee, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data []domain.PaymentSchedule `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Len(t, body.Data, 3)
+		assert.Equal(t, 1, body.Data[0].InstallmentNumber)
+	})
+
+	t.Run("ListPagesWithCursor", func(t *testing.T) {
+		var seen []uuid.UUID
+		next := server.URL + "/api/sales?limit=2"
+		for next != "" {
+			resp := do(t, http.MethodGet, next, employee, nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var body struct {
+				Data       []domain.Sale `json:"data"`
+				Pagination struct {
+					Total      int    `json:"total"`
+					NextCursor string `json:"next_cursor"`
+				} `json:"pagination"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			resp.Body.Close()
+			assert.LessOrEqual(t, len(body.Data), 2)
+			for _, sale := range body.Data {
+				seen = append(seen, sale.ID)
+			}
+			next = ""
+			if body.Pagination.NextCursor != "" {
+				next = server.URL + "/api/sales?limit=2&cursor=" + body.Pagination.NextCursor
+			}
+			require.Less(t, len(seen), 10, "the cursor must move forward")
+		}
+		assert.Len(t, seen, 3)
+
+		resp := do(t, http.MethodGet, server.URL+"/api/sales?cursor=garbage", employee, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("CreateUpdateDeleteRestore", func(t *testing.T) {
+		resp := do(t, http.MethodPost, server.URL+"/api/sales", employee, map[string]interface{}{
+			"client_id":    "250e8400-e29b-41d4-a716-446655440002",
+			"inventory_id": "950e8400-e29b-41d4-a716-446655440002",
+			"total_amount": 6300000,
+		})
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var created struct {
+			Data domain.Sale `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+		resp.Body.Close()
+		require.NotNil(t, created.Data.SalespersonID)
+		assert.Equal(t, memory.SeedEmployee1ID, *created.Data.SalespersonID, "the seller defaults to the current user")
+		saleURL := server.URL + "/api/sales/" + created.Data.ID.String()
+		version := resp.Header.Get("ETag")
+		require.NotEmpty(t, version)
+
+		update := func(ifMatch string) *http.Response {
+			req, err := http.NewRequest(http.MethodPut, saleURL, bytes.NewBufferString(`{"notes":"Corner unit"}`))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+employee)
+			req.Header.Set("If-Match", ifMatch)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			return resp
+		}
+		resp = update(`"stale"`)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Equal(t, version, resp.Header.Get("ETag"))
+
+		resp = update(version)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NotEqual(t, version, resp.Header.Get("ETag"))
+
+		resp = do(t, http.MethodDelete, saleURL, employee, nil)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = do(t, http.MethodGet, saleURL, employee, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp = do(t, http.MethodPost, saleURL+"/restore", employee, nil)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = do(t, http.MethodGet, saleURL, employee, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("CRUDRequiresEmployee", func(t *testing.T) {
+		client := signIn(t, server, "client2@goreal.com")
+		resp := do(t, http.MethodGet, server.URL+"/api/sales", client, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp = do(t, http.MethodDelete, url, client, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("PaymentScheduleRequiresUser", func(t *testing.T) {
+		resp, err := http.Post(url+"/payments", "application/json",
+			bytes.NewBufferString(`{"plan":{"type":"time_based","installments":12}}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("PaymentScheduleRequiresManager", func(t *testing.T) {
+		plan := map[string]interface{}{"plan": map[string]interface{}{"type": "time_based", "installments": 12}}
+		resp := do(t, http.MethodPost, url+"/payments", employee, plan)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		manager := signIn(t, server, "manager@goreal.com")
+		resp = do(t, http.MethodPost, url+"/payments", manager, map[string]interface{}{})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "managers get past the role check")
+	})
+}
+
+func TestCommissionAPI(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+
+	t.Run("ListCommissions", func(t *testing.T) {
+		resp := do(t, http.MethodGet, server.URL+"/api/commissions?status=paid&limit=2", signIn(t, server, "manager@goreal.com"), nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data       []domain.Commission `json:"data"`
+			Pagination struct {
+				Count      int    `json:"count"`
+				NextCursor string `json:"next_cursor"`
+			} `json:"pagination"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body.Data, 2)
+		assert.NotEmpty(t, body.Pagination.NextCursor)
+		for _, c := range body.Data {
+			assert.Equal(t, domain.PaymentStatusPaid, c.Status)
+		}
+	})
+
+	t.Run("CommissionPlans", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/api/commissions/plans")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("ReportRequiresManager", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/api/commissions/report")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestPropertyAPI(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+	employee := signIn(t, server, "employee1@goreal.com")
+
+	t.Run("ListProjects", func(t *testing.T) {
+		resp := do(t, http.MethodGet, server.URL+"/api/projects?society_id=150e8400-e29b-41d4-a716-446655440001&limit=1", employee, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data       []domain.Project `json:"data"`
+			Pagination struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"pagination"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body.Data, 1)
+		assert.NotEmpty(t, body.Pagination.NextCursor)
+	})
+
+	t.Run("CursorOnlyPagesCreationOrder", func(t *testing.T) {
+		resp := do(t, http.MethodGet, server.URL+"/api/projects?limit=1", employee, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Pagination struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"pagination"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.NotEmpty(t, body.Pagination.NextCursor)
+
+		for query, status := range map[string]int{
+			"sort_by=name":                       http.StatusBadRequest,
+			"sort_by=created_at&sort_order=desc": http.StatusOK,
+		} {
+			resp := do(t, http.MethodGet, server.URL+"/api/projects?limit=1&"+query+"&cursor="+body.Pagination.NextCursor, employee, nil)
+			resp.Body.Close()
+			assert.Equal(t, status, resp.StatusCode, query)
+		}
+	})
+
+	t.Run("SocietyProjects", func(t *testing.T) {
+		resp := do(t, http.MethodGet, server.URL+"/api/societies/150e8400-e29b-41d4-a716-446655440001/projects", employee, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data []domain.Project `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body.Data, 2)
+	})
+
+	t.Run("SearchProperties", func(t *testing.T) {
+		resp := do(t, http.MethodPost, server.URL+"/api/inventory/search", employee,
+			map[string]string{"location": "bangalore", "unit_type": "2BHK"})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data []domain.Inventory `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Len(t, body.Data, 1, "the other 2BHK is reserved")
+		assert.Equal(t, "A-101", body.Data[0].UnitNumber)
+	})
+
+	t.Run("SearchPropertiesByRadius", func(t *testing.T) {
+		resp := do(t, http.MethodPost, server.URL+"/api/inventory/search", employee, json.RawMessage(
+			`{"near":{"latitude":12.9716,"longitude":77.5946},"radius_km":25,"unit_type":"2BHK"}`))
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data []domain.Inventory `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Len(t, body.Data, 1)
+		assert.Equal(t, "A-101", body.Data[0].UnitNumber)
+		require.NotNil(t, body.Data[0].DistanceKm)
+		assert.InDelta(t, 12.4, *body.Data[0].DistanceKm, 0.5)
+
+		resp = do(t, http.MethodPost, server.URL+"/api/inventory/search", employee,
+			json.RawMessage(`{"radius_km":0,"location":"bangalore"}`))
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("ClientRecommendations", func(t *testing.T) {
+		resp := do(t, http.MethodGet, server.URL+"/api/clients/250e8400-e29b-41d4-a716-446655440003/recommendations", employee, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data []domain.PropertyRecommendation `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Empty(t, body.Data, "every available unit is over Lisa Chen's budget")
+
+		resp = do(t, http.MethodGet, server.URL+"/api/clients/250e8400-e29b-41d4-a716-446655440099/recommendations", employee, nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp = do(t, http.MethodGet, server.URL+"/api/clients/250e8400-e29b-41d4-a716-446655440003/recommendations?limit=0", employee, nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("RecordInterestRequiresUser", func(t *testing.T) {
+		resp, err := http.Post(server.URL+"/api/clients/250e8400-e29b-41d4-a716-446655440001/interests", "application/json",
+			bytes.NewBufferString(`{"inventory_id":"950e8400-e29b-41d4-a716-446655440001","kind":"shortlisted"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("PriceLists", func(t *testing.T) {
+		resp := do(t, http.MethodGet, server.URL+"/api/projects/050e8400-e29b-41d4-a716-446655440001/price-lists", employee, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data []domain.PriceList `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Empty(t, body.Data)
+
+		resp = do(t, http.MethodGet, server.URL+"/api/projects/050e8400-e29b-41d4-a716-446655440001/price-lists/current?at=2024-01-01", employee, nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp = do(t, http.MethodGet, server.URL+"/api/projects/050e8400-e29b-41d4-a716-446655440001/price-lists/current?at=soon", employee, nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("AvailabilityMatrix", func(t *testing.T) {
+		resp := do(t, http.MethodGet, server.URL+"/api/projects/050e8400-e29b-41d4-a716-446655440001/availability-matrix?unit_type=2BHK", employee, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data domain.AvailabilityMatrix `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, 2, body.Data.TotalUnits)
+		require.Len(t, body.Data.Towers, 1)
+		require.Len(t, body.Data.Towers[0].Floors, 2)
+		assert.Equal(t, "A-201", body.Data.Towers[0].Floors[0].Units[0].UnitNumber, "top floor first")
+		assert.Equal(t, domain.UnitStatusReserved, body.Data.Towers[0].Floors[0].Units[0].Status)
+
+		resp = do(t, http.MethodGet, server.URL+"/api/projects/050e8400-e29b-41d4-a716-446655440099/availability-matrix", employee, nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("AvailabilityStream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			server.URL+"/api/projects/050e8400-e29b-41d4-a716-446655440001/availability-matrix/stream", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+employee)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		reader := bufio.NewReader(resp.Body)
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "event: matrix\n", line)
+		line, err = reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Contains(t, line, `"total_units":3`)
+	})
+
+	t.Run("ImportInventoryRequiresManager", func(t *testing.T) {
+		resp, err := http.Post(server.URL+"/api/projects/050e8400-e29b-41d4-a716-446655440001/inventory/import?dry_run=true",
+			"text/csv", bytes.NewBufferString("unit_number,carpet_area,base_price\nA-999,900,4500000\n"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("CreateSocietyRequiresManager", func(t *testing.T) {
+		resp, err := http.Post(server.URL+"/api/societies", "application/json",
+			bytes.NewBufferString(`{"name":"New Society","location":"Pune"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
 func TestAuthAPI(t *testing.T) {
 	server := setupTestServer(t)
 	defer server.Close()
@@ -214,6 +973,23 @@ func TestAuthAPI(t *testing.T) {
 		assert.True(t, resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusInternalServerError)
 	})
 
+	t.Run("RegisterRefusesPrivilegedRole", func(t *testing.T) {
+		resp := do(t, http.MethodPost, server.URL+"/api/auth/register", "", domain.RegisterRequest{
+			Email:    "intruder@example.com",
+			Username: "intruder",
+			FullName: "Intruder",
+			Password: "password123",
+			Role:     domain.RoleSuperAdmin,
+		})
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		resp = do(t, http.MethodPost, server.URL+"/api/auth/login", "",
+			map[string]string{"email": "intruder@example.com", "password": "password123"})
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "no account was created")
+	})
+
 	t.Run("Login", func(t *testing.T) {
 		loginReq := map[string]string{
 			"email":    "test@example.com",
@@ -231,6 +1007,309 @@ func TestAuthAPI(t *testing.T) {
 		// But it shows the endpoint is reachable
 		assert.True(t, resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusInternalServerError)
 	})
+
+	login := func(t *testing.T, email, device string) domain.AuthResponse {
+		jsonData, err := json.Marshal(map[string]string{"email": email, "password": memory.DemoPassword, "device_name": device})
+		require.NoError(t, err)
+		resp, err := http.Post(server.URL+"/api/auth/login", "application/json", bytes.NewBuffer(jsonData))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data domain.AuthResponse `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body.Data
+	}
+	send := func(t *testing.T, method, path, token string, payload interface{}) *http.Response {
+		var body bytes.Buffer
+		if payload != nil {
+			require.NoError(t, json.NewEncoder(&body).Encode(payload))
+		}
+		req, err := http.NewRequest(method, server.URL+path, &body)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("Sessions", func(t *testing.T) {
+		laptop := login(t, "employee1@goreal.com", "Laptop")
+		phone := login(t, "employee1@goreal.com", "Phone")
+
+		resp := send(t, http.MethodGet, "/api/auth/sessions", laptop.AccessToken, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var listed struct {
+			Data []domain.Session `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&listed))
+		require.Len(t, listed.Data, 2)
+		assert.Equal(t, "Phone", *listed.Data[0].DeviceName, "most recently used first")
+
+		// A refresh token can be exchanged once; the replay revokes the session
+		resp = send(t, http.MethodPost, "/api/auth/refresh", "", map[string]string{"refresh_token": laptop.RefreshToken})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp = send(t, http.MethodPost, "/api/auth/refresh", "", map[string]string{"refresh_token": laptop.RefreshToken})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		resp = send(t, http.MethodGet, "/api/auth/sessions", laptop.AccessToken, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		resp = send(t, http.MethodDelete, "/api/auth/sessions/"+uuid.NewString(), phone.AccessToken, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp = send(t, http.MethodPost, "/api/auth/logout", phone.AccessToken, map[string]string{"refresh_token": phone.RefreshToken})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp = send(t, http.MethodGet, "/api/auth/sessions", phone.AccessToken, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("ForceLogoutRequiresAdmin", func(t *testing.T) {
+		manager := login(t, "manager@goreal.com", "Desktop")
+		admin := login(t, "admin@goreal.com", "Desktop")
+		path := "/api/auth/users/" + memory.SeedManagerID.String() + "/sessions"
+
+		resp := send(t, http.MethodDelete, path, manager.AccessToken, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp = send(t, http.MethodDelete, path, admin.AccessToken, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp = send(t, http.MethodGet, "/api/auth/sessions", manager.AccessToken, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Lockout", func(t *testing.T) {
+		client := login(t, "client1@goreal.com", "Phone")
+		admin := login(t, "admin@goreal.com", "Desktop")
+		wrong := map[string]string{"email": "client1@goreal.com", "password": "wrong password"}
+
+		for i := 0; i < 5; i++ {
+			resp := send(t, http.MethodPost, "/api/auth/login", "", wrong)
+			resp.Body.Close()
+			require.Equal(t, http.StatusUnauthorized, resp.StatusCode, "attempt %d", i+1)
+		}
+		resp := send(t, http.MethodPost, "/api/auth/login", "", map[string]string{"email": "client1@goreal.com", "password": memory.DemoPassword})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+		path := "/api/auth/users/" + client.User.ID.String()
+		resp = send(t, http.MethodPost, path+"/unlock", client.AccessToken, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, "only admins unlock accounts")
+		resp = send(t, http.MethodPost, path+"/unlock", admin.AccessToken, nil)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		login(t, "client1@goreal.com", "Phone")
+
+		resp = send(t, http.MethodGet, path+"/events?limit=2", admin.AccessToken, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var events struct {
+			Data []domain.AuthEvent `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&events))
+		require.Len(t, events.Data, 2)
+		assert.Equal(t, domain.AuthEventAccountUnlocked, events.Data[0].Type)
+		require.NotNil(t, events.Data[0].ActorID)
+		assert.Equal(t, admin.User.ID, *events.Data[0].ActorID)
+	})
+
+	t.Run("WalletLogin", func(t *testing.T) {
+		session := login(t, "employee2@goreal.com", "Laptop")
+		key, err := secp256k1.GenerateKey()
+		require.NoError(t, err)
+
+		// signIn asks for a nonce and signs a message with it, as a wallet would
+		signIn := func(t *testing.T) map[string]string {
+			resp := send(t, http.MethodPost, "/api/auth/siwe/nonce", "", nil)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			var body struct {
+				Data domain.SIWEChallenge `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+			message := (&siwe.Message{
+				Domain:   body.Data.Domain,
+				Address:  siwe.PublicKeyAddress(&key.PublicKey),
+				URI:      body.Data.URI,
+				ChainID:  1,
+				Nonce:    body.Data.Nonce,
+				IssuedAt: time.Now().UTC(),
+			}).String()
+			sig, err := siwe.Sign(key, message)
+			require.NoError(t, err)
+			return map[string]string{"message": message, "signature": "0x" + hex.EncodeToString(sig)}
+		}
+
+		resp := send(t, http.MethodPost, "/api/auth/siwe/login", "", signIn(t))
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "wallet is not linked yet")
+
+		link := signIn(t)
+		link["wallet_type"] = "metamask"
+		resp = send(t, http.MethodPost, "/api/auth/wallets", session.AccessToken, link)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		resp = send(t, http.MethodPost, "/api/auth/wallets", session.AccessToken, link)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "nonce is spent")
+
+		resp = send(t, http.MethodPost, "/api/auth/siwe/login", "", signIn(t))
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var signedIn struct {
+			Data domain.AuthResponse `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&signedIn))
+		assert.Equal(t, session.User.ID, signedIn.Data.User.ID)
+		assert.NotEmpty(t, signedIn.Data.AccessToken)
+		assert.NotEmpty(t, signedIn.Data.RefreshToken)
+
+		resp = send(t, http.MethodGet, "/api/auth/wallets", signedIn.Data.AccessToken, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var wallets struct {
+			Data []domain.Wallet `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&wallets))
+		require.Len(t, wallets.Data, 1)
+		assert.Equal(t, "metamask", wallets.Data[0].WalletType)
+		assert.True(t, wallets.Data[0].IsVerified)
+
+		resp = send(t, http.MethodDelete, "/api/auth/wallets/"+wallets.Data[0].ID.String(), session.AccessToken, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("PasswordReset", func(t *testing.T) {
+		session := login(t, "client2@goreal.com", "Laptop")
+
+		resp := send(t, http.MethodPost, "/api/auth/reset-password", "", map[string]string{"email": "client2@goreal.com"})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// The file mailer leaves the message in the outbox
+		messages, err := filepath.Glob(filepath.Join(os.Getenv("MAIL_OUTBOX_DIR"), "*.eml"))
+		require.NoError(t, err)
+		var match [][]byte
+		for _, name := range messages {
+			message, err := os.ReadFile(name)
+			require.NoError(t, err)
+			if bytes.Contains(message, []byte("To: client2@goreal.com")) {
+				match = regexp.MustCompile(`/reset-password\?token=([A-Za-z0-9_-]+)`).FindSubmatch(message)
+			}
+		}
+		require.NotNil(t, match, "reset mail in %v", messages)
+		reset := map[string]string{"token": string(match[1]), "new_password": "a new password"}
+
+		resp = send(t, http.MethodPost, "/api/auth/confirm-reset", "", reset)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp = send(t, http.MethodPost, "/api/auth/confirm-reset", "", reset)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "links work once")
+
+		resp = send(t, http.MethodGet, "/api/auth/sessions", session.AccessToken, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		resp = send(t, http.MethodPost, "/api/auth/login", "", map[string]string{"email": "client2@goreal.com", "password": "a new password"})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestMFAAPI(t *testing.T) {
+	// Admin endpoints need MFA
+	t.Setenv("AUTH_REQUIRE_MFA", "true")
+	t.Setenv("AUTH_MFA_MIN_ROLE", "admin")
+	server := setupTestServer(t)
+	defer server.Close()
+
+	send := func(t *testing.T, method, path, token string, payload interface{}) *http.Response {
+		var body bytes.Buffer
+		if payload != nil {
+			require.NoError(t, json.NewEncoder(&body).Encode(payload))
+		}
+		req, err := http.NewRequest(method, server.URL+path, &body)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	decode := func(t *testing.T, resp *http.Response, data interface{}) {
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body := struct {
+			Data interface{} `json:"data"`
+		}{data}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	}
+	code := func(t *testing.T, secret string, at time.Time) string {
+		code, err := totp.Code(secret, at)
+		require.NoError(t, err)
+		return code
+	}
+	credentials := map[string]string{"email": "admin@goreal.com", "password": memory.DemoPassword}
+	path := "/api/auth/users/" + memory.SeedManagerID.String() + "/sessions"
+
+	var session domain.AuthResponse
+	decode(t, send(t, http.MethodPost, "/api/auth/login", "", credentials), &session)
+	resp := send(t, http.MethodDelete, path, session.AccessToken, nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "admin without MFA")
+	// The policy follows the user's role, so endpoints open to employees are
+	// closed to an admin without MFA too, while employees are unaffected
+	resp = send(t, http.MethodGet, "/api/sales/", session.AccessToken, nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "admin without MFA on an employee endpoint")
+	resp = send(t, http.MethodGet, "/api/sales/", signIn(t, server, "employee1@goreal.com"), nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "employee below the MFA role")
+
+	var setup domain.MFASetup
+	decode(t, send(t, http.MethodPost, "/api/auth/mfa/enroll", session.AccessToken, nil), &setup)
+	var enabled struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	decode(t, send(t, http.MethodPost, "/api/auth/mfa/confirm", session.AccessToken,
+		map[string]string{"code": code(t, setup.Secret, time.Now())}), &enabled)
+	assert.Len(t, enabled.RecoveryCodes, 10)
+
+	var challenge domain.AuthResponse
+	decode(t, send(t, http.MethodPost, "/api/auth/login", "", credentials), &challenge)
+	require.True(t, challenge.MFARequired)
+	assert.Empty(t, challenge.AccessToken)
+
+	resp = send(t, http.MethodPost, "/api/auth/mfa/verify", "", map[string]string{"mfa_token": challenge.MFAToken, "code": "000000"})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	decode(t, send(t, http.MethodPost, "/api/auth/mfa/verify", "",
+		map[string]string{"mfa_token": challenge.MFAToken, "code": enabled.RecoveryCodes[0]}), &session)
+	resp = send(t, http.MethodDelete, path, session.AccessToken, nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "admin with MFA")
+	resp = send(t, http.MethodGet, "/api/sales/", session.AccessToken, nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "admin with MFA on an employee endpoint")
 }
 
 // Helper function to create string pointers
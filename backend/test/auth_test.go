@@ -37,8 +37,8 @@ func (m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string)
 	return args.Get(0).(*domain.AuthResponse), args.Error(1)
 }
 
-func (m *MockAuthService) Logout(ctx context.Context, userID uuid.UUID) error {
-	args := m.Called(ctx, userID)
+func (m *MockAuthService) Logout(ctx context.Context, userID uuid.UUID, refreshToken string) error {
+	args := m.Called(ctx, userID, refreshToken)
 	return args.Error(0)
 }
 
@@ -65,8 +65,103 @@ func (m *MockAuthService) ConfirmPasswordReset(ctx context.Context, token, newPa
 	return args.Error(0)
 }
 
+func (m *MockAuthService) VerifyEmail(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ResendVerification(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ListSessions(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Session), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAuthService) VerifyMFA(ctx context.Context, mfaToken, code string) (*domain.AuthResponse, error) {
+	args := m.Called(ctx, mfaToken, code)
+	return args.Get(0).(*domain.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthService) EnrollMFA(ctx context.Context, userID uuid.UUID) (*domain.MFASetup, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(*domain.MFASetup), args.Error(1)
+}
+
+func (m *MockAuthService) ConfirmMFA(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAuthService) DisableMFA(ctx context.Context, userID uuid.UUID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MFAPolicy is asked for on every authenticated request, so the mock
+// answers without an expectation: no MFA required
+func (m *MockAuthService) MFAPolicy() domain.MFAPolicy {
+	return domain.MFAPolicy{}
+}
+
+func (m *MockAuthService) UnlockAccount(ctx context.Context, userID, unlockedBy uuid.UUID) error {
+	args := m.Called(ctx, userID, unlockedBy)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ListAuthEvents(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.AuthEvent, error) {
+	args := m.Called(ctx, userID, limit)
+	return args.Get(0).([]*domain.AuthEvent), args.Error(1)
+}
+
+func (m *MockAuthService) IssueSIWENonce(ctx context.Context) (*domain.SIWEChallenge, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*domain.SIWEChallenge), args.Error(1)
+}
+
+func (m *MockAuthService) LoginWithWallet(ctx context.Context, message, signature string) (*domain.AuthResponse, error) {
+	args := m.Called(ctx, message, signature)
+	return args.Get(0).(*domain.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthService) LinkWallet(ctx context.Context, userID uuid.UUID, message, signature, walletType string) (*domain.Wallet, error) {
+	args := m.Called(ctx, userID, message, signature, walletType)
+	return args.Get(0).(*domain.Wallet), args.Error(1)
+}
+
+func (m *MockAuthService) ListWallets(ctx context.Context, userID uuid.UUID) ([]*domain.Wallet, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*domain.Wallet), args.Error(1)
+}
+
+func (m *MockAuthService) UnlinkWallet(ctx context.Context, userID, walletID uuid.UUID) error {
+	args := m.Called(ctx, userID, walletID)
+	return args.Error(0)
+}
+
 func TestAuthMiddleware(t *testing.T) {
 	mockAuthService := new(MockAuthService)
+	organizationID := uuid.New()
 
 	// Create test router with auth middleware
 	r := chi.NewRouter()
@@ -123,6 +218,7 @@ func TestAuthMiddleware(t *testing.T) {
 			Username: "testuser",
 			Role:     domain.RoleUser,
 			IsActive: true,
+			OrganizationID: &organizationID,
 		}
 
 		mockAuthService.On("ValidateToken", mock.Anything, "valid-token").Return(user, nil)
@@ -138,10 +234,34 @@ func TestAuthMiddleware(t *testing.T) {
 
 		mockAuthService.AssertExpectations(t)
 	})
+
+	t.Run("NoOrganization", func(t *testing.T) {
+		user := &domain.User{
+			ID:       uuid.New(),
+			Email:    "stray@example.com",
+			Username: "stray",
+			Role:     domain.RoleUser,
+			IsActive: true,
+		}
+
+		mockAuthService.On("ValidateToken", mock.Anything, "stray-token").Return(user, nil)
+
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer stray-token")
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "Account does not belong to an organization")
+
+		mockAuthService.AssertExpectations(t)
+	})
 }
 
 func TestRoleBasedAccess(t *testing.T) {
 	mockAuthService := new(MockAuthService)
+	organizationID := uuid.New()
 
 	// Create test router with role-based middleware
 	r := chi.NewRouter()
@@ -173,6 +293,7 @@ func TestRoleBasedAccess(t *testing.T) {
 			Username: "user",
 			Role:     domain.RoleUser,
 			IsActive: true,
+			OrganizationID: &organizationID,
 		}
 
 		mockAuthService.On("ValidateToken", mock.Anything, "user-token").Return(user, nil)
@@ -196,6 +317,7 @@ func TestRoleBasedAccess(t *testing.T) {
 			Username: "admin",
 			Role:     domain.RoleAdmin,
 			IsActive: true,
+			OrganizationID: &organizationID,
 		}
 
 		mockAuthService.On("ValidateToken", mock.Anything, "admin-token").Return(admin, nil)
@@ -219,6 +341,7 @@ func TestRoleBasedAccess(t *testing.T) {
 			Username: "employee",
 			Role:     domain.RoleEmployee,
 			IsActive: true,
+			OrganizationID: &organizationID,
 		}
 
 		mockAuthService.On("ValidateToken", mock.Anything, "employee-token").Return(employee, nil)
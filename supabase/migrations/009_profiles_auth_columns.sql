@@ -0,0 +1,5 @@
+-- Credentials columns used by the backend's own authentication flow
+
+ALTER TABLE profiles
+    ADD COLUMN IF NOT EXISTS password_hash TEXT,
+    ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMP WITH TIME ZONE;
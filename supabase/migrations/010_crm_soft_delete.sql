@@ -0,0 +1,25 @@
+-- Soft delete for CRM records
+-- Deleting a lead, client, sale or task moves it to the trash; the backend
+-- purges trashed rows once they are older than the retention period
+
+ALTER TABLE leads
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES profiles(id);
+
+ALTER TABLE clients
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES profiles(id);
+
+ALTER TABLE sales
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES profiles(id);
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES profiles(id);
+
+-- The trash is small next to the live rows, so only index trashed rows
+CREATE INDEX IF NOT EXISTS idx_leads_deleted_at ON leads(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_clients_deleted_at ON clients(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_sales_deleted_at ON sales(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Indexes for keyset (cursor) pagination
+-- Cursor pages are read in (created_at, id) order from the live rows
+
+CREATE INDEX IF NOT EXISTS idx_leads_created_at_id ON leads(created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_clients_created_at_id ON clients(created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sales_created_at_id ON sales(created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_created_at_id ON tasks(created_at, id) WHERE deleted_at IS NULL;
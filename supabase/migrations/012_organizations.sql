@@ -0,0 +1,42 @@
+-- Multi-tenant organizations
+-- Each brokerage on the deployment is an organization. Profiles and CRM
+-- records belong to one; rows that predate tenancy are moved into a default
+-- organization so existing data stays visible to its users
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL,
+    slug TEXT UNIQUE NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TRIGGER update_organizations_updated_at BEFORE UPDATE ON organizations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id);
+ALTER TABLE leads ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id);
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id);
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id);
+ALTER TABLE sales ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id);
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id);
+
+-- Backfill existing rows into the default organization
+INSERT INTO organizations (id, name, slug)
+VALUES ('00000000-0000-0000-0000-000000000001', 'Default Organization', 'default')
+ON CONFLICT (id) DO NOTHING;
+
+UPDATE profiles SET organization_id = '00000000-0000-0000-0000-000000000001' WHERE organization_id IS NULL;
+UPDATE leads SET organization_id = '00000000-0000-0000-0000-000000000001' WHERE organization_id IS NULL;
+UPDATE clients SET organization_id = '00000000-0000-0000-0000-000000000001' WHERE organization_id IS NULL;
+UPDATE inventory SET organization_id = '00000000-0000-0000-0000-000000000001' WHERE organization_id IS NULL;
+UPDATE sales SET organization_id = '00000000-0000-0000-0000-000000000001' WHERE organization_id IS NULL;
+UPDATE tasks SET organization_id = '00000000-0000-0000-0000-000000000001' WHERE organization_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_profiles_organization_id ON profiles(organization_id);
+CREATE INDEX IF NOT EXISTS idx_leads_organization_id ON leads(organization_id);
+CREATE INDEX IF NOT EXISTS idx_clients_organization_id ON clients(organization_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_organization_id ON inventory(organization_id);
+CREATE INDEX IF NOT EXISTS idx_sales_organization_id ON sales(organization_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_organization_id ON tasks(organization_id);
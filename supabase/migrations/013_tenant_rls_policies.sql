@@ -0,0 +1,65 @@
+-- Tenant isolation policies
+-- These policies are RESTRICTIVE, so they are combined with AND on top of
+-- the role based policies from 007_crm_rls_policies.sql: a row must pass
+-- both to be visible. The backend's service role bypasses RLS and applies
+-- the same scoping in its repositories.
+
+ALTER TABLE organizations ENABLE ROW LEVEL SECURITY;
+
+-- The organization of the current request, taken from the org_id claim the
+-- backend puts in its tokens and falling back to the caller's profile
+CREATE OR REPLACE FUNCTION current_organization_id() RETURNS UUID AS $$
+BEGIN
+    RETURN COALESCE(
+        NULLIF(auth.jwt() ->> 'org_id', '')::UUID,
+        (SELECT organization_id FROM profiles WHERE id = auth.uid())
+    );
+END;
+$$ LANGUAGE plpgsql STABLE SECURITY DEFINER;
+
+-- Helper function to check if a row belongs to the current organization
+CREATE OR REPLACE FUNCTION in_current_organization(row_organization_id UUID) RETURNS BOOLEAN AS $$
+BEGIN
+    RETURN row_organization_id IS NOT NULL AND row_organization_id = current_organization_id();
+END;
+$$ LANGUAGE plpgsql STABLE SECURITY DEFINER;
+
+-- Organizations policies
+CREATE POLICY "Members can view their organization" ON organizations
+    FOR SELECT USING (in_current_organization(id));
+
+CREATE POLICY "Super admins can manage organizations" ON organizations
+    FOR ALL USING (
+        EXISTS (SELECT 1 FROM profiles WHERE id = auth.uid() AND role = 'super_admin')
+    );
+
+-- Profiles stay visible to their owner even outside an organization
+CREATE POLICY "Profiles are scoped to the current organization" ON profiles
+    AS RESTRICTIVE FOR ALL
+    USING (auth.uid() = id OR organization_id IS NULL OR in_current_organization(organization_id));
+
+-- CRM tables
+CREATE POLICY "Leads are scoped to the current organization" ON leads
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
+
+CREATE POLICY "Clients are scoped to the current organization" ON clients
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
+
+CREATE POLICY "Inventory is scoped to the current organization" ON inventory
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
+
+CREATE POLICY "Sales are scoped to the current organization" ON sales
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
+
+CREATE POLICY "Tasks are scoped to the current organization" ON tasks
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
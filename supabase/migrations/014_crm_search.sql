@@ -0,0 +1,141 @@
+-- Full-text and fuzzy search across leads, clients, companies and inventory
+-- search_vector holds the weighted words of each row: A for names, B for
+-- contact details, C for free text. Trigram indexes let misspelt names and
+-- partial phone numbers match as well.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE leads ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig,
+        coalesce(email, '') || ' ' || coalesce(company_name, '') || ' ' || coalesce(designation, '')), 'B') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(requirements, '') || ' ' || coalesce(notes, '')), 'C')
+) STORED;
+
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(email, '')), 'B')
+) STORED;
+
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig,
+        coalesce(email, '') || ' ' || coalesce(website, '') || ' ' || coalesce(registration_number, '')), 'B') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(industry, '')), 'C')
+) STORED;
+
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, coalesce(unit_number, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig,
+        coalesce(tower_block, '') || ' ' || coalesce(unit_type, '') || ' ' || coalesce(facing, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_leads_search ON leads USING GIN (search_vector) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_clients_search ON clients USING GIN (search_vector) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_companies_search ON companies USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_inventory_search ON inventory USING GIN (search_vector);
+
+CREATE INDEX IF NOT EXISTS idx_leads_name_trgm ON leads USING GIN (name gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_clients_name_trgm ON clients USING GIN (name gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_companies_name_trgm ON companies USING GIN (name gin_trgm_ops);
+
+-- Phone numbers are matched on their digits only
+CREATE INDEX IF NOT EXISTS idx_leads_phone_trgm ON leads
+    USING GIN (regexp_replace(phone, '\D', '', 'g') gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_clients_phone_trgm ON clients
+    USING GIN (regexp_replace(phone, '\D', '', 'g') gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_companies_phone_trgm ON companies
+    USING GIN (regexp_replace(phone, '\D', '', 'g') gin_trgm_ops);
+
+-- search_crm returns the rows matching search_query, best match first.
+-- Every word of the query must prefix a word of the row, or the query must
+-- be close to the row's name (word_similarity >= 0.6), or its digits must
+-- appear in the row's phone number. The score adds the full-text rank, the
+-- name similarity and a fixed bonus for phone matches.
+--
+-- The function runs with the caller's rights, so row level security still
+-- applies; organization additionally limits tenant tables to one tenant.
+CREATE OR REPLACE FUNCTION search_crm(
+    search_query TEXT,
+    entity_types TEXT[] DEFAULT NULL,
+    organization UUID DEFAULT NULL,
+    max_results INTEGER DEFAULT 20
+)
+RETURNS TABLE (
+    entity_type TEXT,
+    id UUID,
+    title TEXT,
+    subtitle TEXT,
+    score REAL,
+    highlight TEXT
+) AS $$
+    WITH params AS (
+        SELECT
+            (SELECT to_tsquery('simple', string_agg(word || ':*', ' & '))
+               FROM regexp_split_to_table(lower(regexp_replace(search_query, '[^[:alnum:]]+', ' ', 'g')), '\s+') AS word
+              WHERE word <> '') AS tsq,
+            lower(search_query) AS phrase,
+            regexp_replace(search_query, '\D', '', 'g') AS digits
+    ),
+    hits AS (
+        SELECT 'lead'::TEXT AS entity_type, l.id, l.name AS title,
+               coalesce(l.company_name, l.email) AS subtitle,
+               (coalesce(ts_rank(l.search_vector, p.tsq), 0)
+                + word_similarity(p.phrase, l.name)
+                + CASE WHEN length(p.digits) >= 4 AND regexp_replace(l.phone, '\D', '', 'g') LIKE '%' || p.digits || '%' THEN 0.5 ELSE 0 END)::REAL AS score,
+               ts_headline('simple', concat_ws(' ', l.name, l.email, l.company_name, l.requirements, l.notes), p.tsq,
+                   'MaxFragments=1, MaxWords=15, MinWords=3') AS highlight
+          FROM leads l, params p
+         WHERE l.deleted_at IS NULL
+           AND (organization IS NULL OR l.organization_id = organization)
+           AND (entity_types IS NULL OR 'lead' = ANY(entity_types))
+           AND (l.search_vector @@ p.tsq
+                OR p.phrase <% l.name
+                OR (length(p.digits) >= 4 AND regexp_replace(l.phone, '\D', '', 'g') LIKE '%' || p.digits || '%'))
+        UNION ALL
+        SELECT 'client', c.id, c.name,
+               coalesce(c.email, c.phone),
+               (coalesce(ts_rank(c.search_vector, p.tsq), 0)
+                + word_similarity(p.phrase, c.name)
+                + CASE WHEN length(p.digits) >= 4 AND regexp_replace(c.phone, '\D', '', 'g') LIKE '%' || p.digits || '%' THEN 0.5 ELSE 0 END)::REAL,
+               ts_headline('simple', concat_ws(' ', c.name, c.email), p.tsq,
+                   'MaxFragments=1, MaxWords=15, MinWords=3')
+          FROM clients c, params p
+         WHERE c.deleted_at IS NULL
+           AND (organization IS NULL OR c.organization_id = organization)
+           AND (entity_types IS NULL OR 'client' = ANY(entity_types))
+           AND (c.search_vector @@ p.tsq
+                OR p.phrase <% c.name
+                OR (length(p.digits) >= 4 AND regexp_replace(c.phone, '\D', '', 'g') LIKE '%' || p.digits || '%'))
+        UNION ALL
+        SELECT 'company', co.id, co.name,
+               co.industry,
+               (coalesce(ts_rank(co.search_vector, p.tsq), 0)
+                + word_similarity(p.phrase, co.name)
+                + CASE WHEN length(p.digits) >= 4 AND regexp_replace(co.phone, '\D', '', 'g') LIKE '%' || p.digits || '%' THEN 0.5 ELSE 0 END)::REAL,
+               ts_headline('simple', concat_ws(' ', co.name, co.email, co.website, co.industry), p.tsq,
+                   'MaxFragments=1, MaxWords=15, MinWords=3')
+          FROM companies co, params p
+         WHERE (entity_types IS NULL OR 'company' = ANY(entity_types))
+           AND (co.search_vector @@ p.tsq
+                OR p.phrase <% co.name
+                OR (length(p.digits) >= 4 AND regexp_replace(co.phone, '\D', '', 'g') LIKE '%' || p.digits || '%'))
+        UNION ALL
+        SELECT 'inventory', i.id, i.unit_number,
+               nullif(concat_ws(', ', pr.name, i.unit_type), ''),
+               coalesce(ts_rank(i.search_vector, p.tsq), 0)::REAL,
+               ts_headline('simple', concat_ws(' ', i.unit_number, i.tower_block, i.unit_type, i.facing), p.tsq,
+                   'MaxFragments=1, MaxWords=15, MinWords=3')
+          FROM inventory i
+          LEFT JOIN projects pr ON pr.id = i.project_id, params p
+         WHERE (organization IS NULL OR i.organization_id = organization)
+           AND (entity_types IS NULL OR 'inventory' = ANY(entity_types))
+           AND i.search_vector @@ p.tsq
+    )
+    SELECT entity_type, id, title, subtitle, score, highlight
+      FROM hits
+     ORDER BY score DESC, title, id
+     LIMIT max_results;
+$$ LANGUAGE sql STABLE;
+
+GRANT EXECUTE ON FUNCTION search_crm(TEXT, TEXT[], UUID, INTEGER) TO authenticated;
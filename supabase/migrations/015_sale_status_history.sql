@@ -0,0 +1,34 @@
+-- Sale status history
+-- Every change of a sale's status is recorded with who made it and why.
+-- The backend enforces the lifecycle (see domain.SaleTransitions); the
+-- table is append only.
+
+CREATE TABLE IF NOT EXISTS sale_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID REFERENCES organizations(id),
+    sale_id UUID REFERENCES sales(id) ON DELETE CASCADE NOT NULL,
+    from_status sale_status NOT NULL,
+    to_status sale_status NOT NULL,
+    changed_by UUID REFERENCES profiles(id) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sale_status_history_sale_id ON sale_status_history(sale_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_sale_status_history_organization_id ON sale_status_history(organization_id);
+
+ALTER TABLE sale_status_history ENABLE ROW LEVEL SECURITY;
+
+-- History follows the visibility of its sale
+CREATE POLICY "Sale history is viewable with its sale" ON sale_status_history
+    FOR SELECT USING (
+        EXISTS (SELECT 1 FROM sales WHERE sales.id = sale_id)
+    );
+
+CREATE POLICY "Users record their own sale transitions" ON sale_status_history
+    FOR INSERT WITH CHECK (auth.uid() = changed_by);
+
+CREATE POLICY "Sale history is scoped to the current organization" ON sale_status_history
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
@@ -0,0 +1,68 @@
+-- Sale agreements
+-- Agreement templates are versioned per organization, with optional
+-- per-project overrides (project_id set). Generated agreements are kept
+-- with the exact template version and a checksum of their content.
+
+CREATE TABLE IF NOT EXISTS agreement_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID REFERENCES organizations(id),
+    project_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    version INTEGER NOT NULL CHECK (version > 0),
+    body TEXT NOT NULL,
+    created_by UUID REFERENCES profiles(id) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- One row per version; NULL project (the default) and NULL organization
+-- (single tenant deployments) count as values here
+CREATE UNIQUE INDEX IF NOT EXISTS idx_agreement_templates_version ON agreement_templates(
+    COALESCE(organization_id, '00000000-0000-0000-0000-000000000000'),
+    COALESCE(project_id, '00000000-0000-0000-0000-000000000000'),
+    version
+);
+
+CREATE TABLE IF NOT EXISTS sale_agreements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID REFERENCES organizations(id),
+    sale_id UUID REFERENCES sales(id) ON DELETE CASCADE NOT NULL,
+    template_id UUID REFERENCES agreement_templates(id) ON DELETE SET NULL,
+    template_version INTEGER NOT NULL,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('pdf', 'docx')),
+    file_name VARCHAR(255) NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    content BYTEA NOT NULL,
+    created_by UUID REFERENCES profiles(id) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sale_agreements_sale_id ON sale_agreements(sale_id, format, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_sale_agreements_organization_id ON sale_agreements(organization_id);
+
+ALTER TABLE agreement_templates ENABLE ROW LEVEL SECURITY;
+ALTER TABLE sale_agreements ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Agreement templates are viewable by authenticated users" ON agreement_templates
+    FOR SELECT USING (auth.role() = 'authenticated');
+
+CREATE POLICY "Managers and admins add agreement template versions" ON agreement_templates
+    FOR INSERT WITH CHECK (is_admin_or_manager() AND auth.uid() = created_by);
+
+CREATE POLICY "Agreement templates are scoped to the current organization" ON agreement_templates
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
+
+-- Agreements follow the visibility of their sale
+CREATE POLICY "Sale agreements are viewable with their sale" ON sale_agreements
+    FOR SELECT USING (
+        EXISTS (SELECT 1 FROM sales WHERE sales.id = sale_id)
+    );
+
+CREATE POLICY "Users record the agreements they generate" ON sale_agreements
+    FOR INSERT WITH CHECK (auth.uid() = created_by);
+
+CREATE POLICY "Sale agreements are scoped to the current organization" ON sale_agreements
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
@@ -0,0 +1,39 @@
+-- Payment schedule revisions
+-- Schedules are generated from a payment plan and can be rescheduled.
+-- Paid installments are never touched; every change records the
+-- installments it replaced and the ones it added.
+
+-- Installment numbers continue across revisions, so they stay unique
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_schedules_installment ON payment_schedules(sale_id, installment_number);
+
+CREATE TABLE IF NOT EXISTS payment_schedule_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID REFERENCES organizations(id),
+    sale_id UUID REFERENCES sales(id) ON DELETE CASCADE NOT NULL,
+    revision INTEGER NOT NULL CHECK (revision > 0),
+    plan VARCHAR(50) NOT NULL,
+    reason TEXT,
+    previous JSONB NOT NULL DEFAULT '[]',
+    installments JSONB NOT NULL DEFAULT '[]',
+    changed_by UUID REFERENCES profiles(id) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (sale_id, revision)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_schedule_revisions_organization_id ON payment_schedule_revisions(organization_id);
+
+ALTER TABLE payment_schedule_revisions ENABLE ROW LEVEL SECURITY;
+
+-- Revisions follow the visibility of their sale
+CREATE POLICY "Payment schedule revisions are viewable with their sale" ON payment_schedule_revisions
+    FOR SELECT USING (
+        EXISTS (SELECT 1 FROM sales WHERE sales.id = sale_id)
+    );
+
+CREATE POLICY "Users record the schedule changes they make" ON payment_schedule_revisions
+    FOR INSERT WITH CHECK (auth.uid() = changed_by);
+
+CREATE POLICY "Payment schedule revisions are scoped to the current organization" ON payment_schedule_revisions
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
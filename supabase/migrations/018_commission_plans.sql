@@ -0,0 +1,49 @@
+-- Commission plans
+-- A plan sets the commission rate of a sale, either flat or by the
+-- salesperson's monthly sales volume, the manager's share of it and
+-- whether commission is clawed back on cancellation or refund. Plans are
+-- never edited; the newest plan of an organization is the one in effect.
+
+CREATE TABLE IF NOT EXISTS commission_plans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID REFERENCES organizations(id),
+    name TEXT NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('flat', 'tiered')),
+    rate DECIMAL(5,2) NOT NULL DEFAULT 0,
+    slabs JSONB NOT NULL DEFAULT '[]',
+    manager_share DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (manager_share BETWEEN 0 AND 100),
+    clawback BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by UUID REFERENCES profiles(id) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_commission_plans_organization_id ON commission_plans(organization_id, created_at DESC);
+
+-- Commissions record the plan they were calculated with and the
+-- organization of their sale
+ALTER TABLE commissions ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id);
+ALTER TABLE commissions ADD COLUMN IF NOT EXISTS plan_id UUID REFERENCES commission_plans(id);
+
+UPDATE commissions SET organization_id = sales.organization_id
+FROM sales WHERE sales.id = commissions.sale_id AND commissions.organization_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_commissions_organization_id ON commissions(organization_id);
+CREATE INDEX IF NOT EXISTS idx_commissions_created_at ON commissions(created_at);
+
+ALTER TABLE commission_plans ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Commission plans are viewable by employees" ON commission_plans
+    FOR SELECT USING (auth.uid() IS NOT NULL);
+
+CREATE POLICY "Managers can create commission plans" ON commission_plans
+    FOR INSERT WITH CHECK (is_admin_or_manager() AND auth.uid() = created_by);
+
+CREATE POLICY "Commission plans are scoped to the current organization" ON commission_plans
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
+
+CREATE POLICY "Commissions are scoped to the current organization" ON commissions
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
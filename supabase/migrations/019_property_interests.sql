@@ -0,0 +1,35 @@
+-- Property interests
+-- Records the units a client viewed or shortlisted. The recommendation
+-- engine uses them, together with the client's lead, tags and the
+-- purchases of similar clients, to suggest available units.
+
+CREATE TABLE IF NOT EXISTS property_interests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID REFERENCES organizations(id),
+    client_id UUID REFERENCES clients(id) ON DELETE CASCADE NOT NULL,
+    inventory_id UUID REFERENCES inventory(id) ON DELETE CASCADE NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('viewed', 'shortlisted')),
+    recorded_by UUID REFERENCES profiles(id) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (client_id, inventory_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_property_interests_client_id ON property_interests(client_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_property_interests_inventory_id ON property_interests(inventory_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_property_interests_organization_id ON property_interests(organization_id);
+
+ALTER TABLE property_interests ENABLE ROW LEVEL SECURITY;
+
+-- Interests follow the visibility of their client
+CREATE POLICY "Property interests are viewable with their client" ON property_interests
+    FOR SELECT USING (
+        EXISTS (SELECT 1 FROM clients WHERE clients.id = client_id)
+    );
+
+CREATE POLICY "Users record property interests themselves" ON property_interests
+    FOR INSERT WITH CHECK (auth.uid() = recorded_by);
+
+CREATE POLICY "Property interests are scoped to the current organization" ON property_interests
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
@@ -0,0 +1,39 @@
+-- Price lists
+-- Versioned, effective-dated price lists per project. Rates are per square
+-- foot of a unit's saleable area: the base rate plus a floor rise for every
+-- floor from floor_rise_from upwards, a premium for the unit's facing and
+-- preferential location charges (PLCs) for its features. A version is never
+-- edited; revisions add a new version.
+
+CREATE TABLE IF NOT EXISTS price_lists (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID REFERENCES organizations(id),
+    project_id UUID REFERENCES projects(id) ON DELETE CASCADE NOT NULL,
+    version INTEGER NOT NULL CHECK (version > 0),
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    base_rate DECIMAL(12,2) NOT NULL CHECK (base_rate > 0),
+    floor_rise DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (floor_rise >= 0),
+    floor_rise_from INTEGER NOT NULL DEFAULT 0 CHECK (floor_rise_from >= 0),
+    facing_premiums JSONB NOT NULL DEFAULT '{}',
+    plcs JSONB NOT NULL DEFAULT '{}',
+    notes TEXT,
+    created_by UUID REFERENCES profiles(id) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (organization_id, project_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_lists_project_id ON price_lists(project_id, effective_from DESC, version DESC);
+CREATE INDEX IF NOT EXISTS idx_price_lists_organization_id ON price_lists(organization_id);
+
+ALTER TABLE price_lists ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Price lists are viewable by employees" ON price_lists
+    FOR SELECT USING (auth.uid() IS NOT NULL);
+
+CREATE POLICY "Managers can create price lists" ON price_lists
+    FOR INSERT WITH CHECK (is_admin_or_manager() AND auth.uid() = created_by);
+
+CREATE POLICY "Price lists are scoped to the current organization" ON price_lists
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
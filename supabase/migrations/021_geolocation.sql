@@ -0,0 +1,45 @@
+-- Geolocation
+-- Societies carry WGS 84 coordinates, set by hand or geocoded from their
+-- address, so that properties can be searched by radius, bounding box or
+-- polygon. A project's nearby facilities become a list of named places
+-- with their own coordinates and their distance in kilometres from the
+-- society.
+
+ALTER TABLE societies
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180);
+
+ALTER TABLE societies DROP CONSTRAINT IF EXISTS societies_coordinates_together;
+ALTER TABLE societies ADD CONSTRAINT societies_coordinates_together
+    CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+CREATE INDEX IF NOT EXISTS idx_societies_coordinates ON societies(latitude, longitude)
+    WHERE latitude IS NOT NULL;
+
+-- Nearby facilities were free-form objects; keep each key as the name of a
+-- facility whose place is not yet known
+UPDATE projects
+SET nearby_facilities = COALESCE((
+    SELECT jsonb_agg(jsonb_build_object(
+        'name', facility.key,
+        'category', CASE WHEN jsonb_typeof(facility.value) = 'string' THEN facility.value #>> '{}' ELSE '' END,
+        'latitude', NULL,
+        'longitude', NULL,
+        'distance_km', NULL
+    ))
+    FROM jsonb_each(projects.nearby_facilities) AS facility
+), '[]'::jsonb)
+WHERE jsonb_typeof(nearby_facilities) = 'object';
+
+UPDATE projects SET nearby_facilities = '[]'::jsonb
+WHERE nearby_facilities IS NULL OR jsonb_typeof(nearby_facilities) <> 'array';
+
+ALTER TABLE projects ALTER COLUMN nearby_facilities SET DEFAULT '[]'::jsonb;
+
+-- Place the sample societies
+UPDATE societies SET latitude = 13.0827, longitude = 77.5877
+WHERE id = '150e8400-e29b-41d4-a716-446655440001' AND latitude IS NULL;
+UPDATE societies SET latitude = 18.9220, longitude = 72.8347
+WHERE id = '150e8400-e29b-41d4-a716-446655440002' AND latitude IS NULL;
+UPDATE societies SET latitude = 28.4595, longitude = 77.0266
+WHERE id = '150e8400-e29b-41d4-a716-446655440003' AND latitude IS NULL;
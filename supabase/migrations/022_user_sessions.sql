@@ -0,0 +1,32 @@
+-- User sessions
+-- One row per signed-in device. A session holds the SHA-256 of its current
+-- refresh token; every refresh replaces the token, and presenting one that
+-- was already replaced revokes the session. Access tokens name their
+-- session, so revoking it signs the device out at once.
+
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES profiles(id) ON DELETE CASCADE NOT NULL,
+    device_name TEXT,
+    user_agent TEXT,
+    ip_address TEXT,
+    token_hash TEXT NOT NULL,
+    rotations INTEGER NOT NULL DEFAULT 0 CHECK (rotations >= 0),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoked_reason TEXT CHECK (revoked_reason IN (
+        'logout', 'revoked_by_user', 'revoked_by_admin', 'token_reuse', 'password_changed'
+    )),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK ((revoked_at IS NULL) = (revoked_reason IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_active ON user_sessions(user_id, last_used_at DESC)
+    WHERE revoked_at IS NULL;
+
+-- Sessions are written by the backend only
+ALTER TABLE user_sessions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Users can view their own sessions" ON user_sessions
+    FOR SELECT USING (auth.uid() = user_id);
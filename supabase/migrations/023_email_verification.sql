@@ -0,0 +1,25 @@
+-- Email verification and password reset
+-- Mailed links carry a random secret; only its SHA-256 is stored. A token
+-- can be used once, expires, and is superseded by the next one issued to
+-- the same user for the same purpose.
+
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- Accounts from before verification existed count as verified
+UPDATE profiles SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS auth_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES profiles(id) ON DELETE CASCADE NOT NULL,
+    purpose TEXT NOT NULL CHECK (purpose IN ('email_verification', 'password_reset')),
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_user ON auth_tokens(user_id, purpose)
+    WHERE used_at IS NULL;
+
+-- Tokens are read and written by the backend only
+ALTER TABLE auth_tokens ENABLE ROW LEVEL SECURITY;
@@ -0,0 +1,36 @@
+-- TOTP multi-factor authentication
+-- Each user has at most one authenticator; it is pending until confirmed
+-- with a first code. last_step is the newest time step a code was accepted
+-- for, so no code works twice. Recovery codes are stored as SHA-256 hashes.
+
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS mfa_enabled BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id UUID PRIMARY KEY REFERENCES profiles(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    last_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES profiles(id) ON DELETE CASCADE NOT NULL,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfa_recovery_codes_user ON mfa_recovery_codes(user_id)
+    WHERE used_at IS NULL;
+
+-- Turning MFA on ends the user's sessions
+ALTER TABLE user_sessions DROP CONSTRAINT IF EXISTS user_sessions_revoked_reason_check;
+ALTER TABLE user_sessions ADD CONSTRAINT user_sessions_revoked_reason_check CHECK (revoked_reason IN (
+    'logout', 'revoked_by_user', 'revoked_by_admin', 'token_reuse', 'password_changed', 'mfa_enabled'
+));
+
+-- Secrets and recovery codes are read and written by the backend only
+ALTER TABLE user_mfa ENABLE ROW LEVEL SECURITY;
+ALTER TABLE mfa_recovery_codes ENABLE ROW LEVEL SECURITY;
@@ -0,0 +1,27 @@
+-- Account lockout and the login audit trail
+-- Per-account failure counts live on the profile; per-address counts are
+-- taken from the login_failed events of the audit trail.
+
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS failed_login_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS last_failed_login_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS auth_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    -- NULL for failed logins to an unknown email address
+    user_id UUID REFERENCES profiles(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('login_failed', 'account_locked', 'account_unlocked')),
+    ip_address TEXT,
+    user_agent TEXT,
+    actor_id UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_events_failures ON auth_events(ip_address, created_at)
+    WHERE type = 'login_failed';
+CREATE INDEX IF NOT EXISTS idx_auth_events_user ON auth_events(user_id, created_at DESC);
+
+-- The audit trail is read and written by the backend only
+ALTER TABLE auth_events ENABLE ROW LEVEL SECURITY;
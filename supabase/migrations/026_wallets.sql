@@ -0,0 +1,31 @@
+-- Sign-In with Ethereum
+-- Wallets are linked to a profile once they sign a message; a wallet
+-- address signs in to one account only. Nonces are issued before a user
+-- is known, so they are kept apart from auth_tokens.
+
+CREATE TABLE IF NOT EXISTS wallets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES profiles(id) ON DELETE CASCADE NOT NULL,
+    -- EIP-55 checksummed
+    address TEXT NOT NULL UNIQUE,
+    wallet_type TEXT NOT NULL DEFAULT 'unknown',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    is_verified BOOLEAN NOT NULL DEFAULT false,
+    last_used TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallets_user ON wallets(user_id, created_at);
+
+CREATE TABLE IF NOT EXISTS siwe_nonces (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    nonce TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Wallets and nonces are read and written by the backend only
+ALTER TABLE wallets ENABLE ROW LEVEL SECURITY;
+ALTER TABLE siwe_nonces ENABLE ROW LEVEL SECURITY;
@@ -0,0 +1,45 @@
+-- Tenant property tables
+-- Companies, societies, projects and payment schedules belong to an
+-- organization like the CRM records of 012. Projects follow their society
+-- and installments their sale; everything else that predates this moves
+-- into the default organization.
+
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id);
+ALTER TABLE societies ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id);
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id);
+ALTER TABLE payment_schedules ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id);
+
+UPDATE companies SET organization_id = '00000000-0000-0000-0000-000000000001' WHERE organization_id IS NULL;
+UPDATE societies SET organization_id = '00000000-0000-0000-0000-000000000001' WHERE organization_id IS NULL;
+
+UPDATE projects SET organization_id = societies.organization_id
+FROM societies WHERE societies.id = projects.society_id AND projects.organization_id IS NULL;
+UPDATE projects SET organization_id = '00000000-0000-0000-0000-000000000001' WHERE organization_id IS NULL;
+
+UPDATE payment_schedules SET organization_id = sales.organization_id
+FROM sales WHERE sales.id = payment_schedules.sale_id AND payment_schedules.organization_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_companies_organization_id ON companies(organization_id);
+CREATE INDEX IF NOT EXISTS idx_societies_organization_id ON societies(organization_id);
+CREATE INDEX IF NOT EXISTS idx_projects_organization_id ON projects(organization_id);
+CREATE INDEX IF NOT EXISTS idx_payment_schedules_organization_id ON payment_schedules(organization_id);
+
+CREATE POLICY "Companies are scoped to the current organization" ON companies
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
+
+CREATE POLICY "Societies are scoped to the current organization" ON societies
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
+
+CREATE POLICY "Projects are scoped to the current organization" ON projects
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
+
+CREATE POLICY "Payment schedules are scoped to the current organization" ON payment_schedules
+    AS RESTRICTIVE FOR ALL
+    USING (in_current_organization(organization_id))
+    WITH CHECK (in_current_organization(organization_id));
@@ -0,0 +1,88 @@
+-- Tenant company search
+-- Companies belong to an organization since 027, so search_crm limits its
+-- company hits to the organization it is given like the other tenant
+-- tables. The function is otherwise unchanged from 014.
+
+CREATE OR REPLACE FUNCTION search_crm(
+    search_query TEXT,
+    entity_types TEXT[] DEFAULT NULL,
+    organization UUID DEFAULT NULL,
+    max_results INTEGER DEFAULT 20
+)
+RETURNS TABLE (
+    entity_type TEXT,
+    id UUID,
+    title TEXT,
+    subtitle TEXT,
+    score REAL,
+    highlight TEXT
+) AS $$
+    WITH params AS (
+        SELECT
+            (SELECT to_tsquery('simple', string_agg(word || ':*', ' & '))
+               FROM regexp_split_to_table(lower(regexp_replace(search_query, '[^[:alnum:]]+', ' ', 'g')), '\s+') AS word
+              WHERE word <> '') AS tsq,
+            lower(search_query) AS phrase,
+            regexp_replace(search_query, '\D', '', 'g') AS digits
+    ),
+    hits AS (
+        SELECT 'lead'::TEXT AS entity_type, l.id, l.name AS title,
+               coalesce(l.company_name, l.email) AS subtitle,
+               (coalesce(ts_rank(l.search_vector, p.tsq), 0)
+                + word_similarity(p.phrase, l.name)
+                + CASE WHEN length(p.digits) >= 4 AND regexp_replace(l.phone, '\D', '', 'g') LIKE '%' || p.digits || '%' THEN 0.5 ELSE 0 END)::REAL AS score,
+               ts_headline('simple', concat_ws(' ', l.name, l.email, l.company_name, l.requirements, l.notes), p.tsq,
+                   'MaxFragments=1, MaxWords=15, MinWords=3') AS highlight
+          FROM leads l, params p
+         WHERE l.deleted_at IS NULL
+           AND (organization IS NULL OR l.organization_id = organization)
+           AND (entity_types IS NULL OR 'lead' = ANY(entity_types))
+           AND (l.search_vector @@ p.tsq
+                OR p.phrase <% l.name
+                OR (length(p.digits) >= 4 AND regexp_replace(l.phone, '\D', '', 'g') LIKE '%' || p.digits || '%'))
+        UNION ALL
+        SELECT 'client', c.id, c.name,
+               coalesce(c.email, c.phone),
+               (coalesce(ts_rank(c.search_vector, p.tsq), 0)
+                + word_similarity(p.phrase, c.name)
+                + CASE WHEN length(p.digits) >= 4 AND regexp_replace(c.phone, '\D', '', 'g') LIKE '%' || p.digits || '%' THEN 0.5 ELSE 0 END)::REAL,
+               ts_headline('simple', concat_ws(' ', c.name, c.email), p.tsq,
+                   'MaxFragments=1, MaxWords=15, MinWords=3')
+          FROM clients c, params p
+         WHERE c.deleted_at IS NULL
+           AND (organization IS NULL OR c.organization_id = organization)
+           AND (entity_types IS NULL OR 'client' = ANY(entity_types))
+           AND (c.search_vector @@ p.tsq
+                OR p.phrase <% c.name
+                OR (length(p.digits) >= 4 AND regexp_replace(c.phone, '\D', '', 'g') LIKE '%' || p.digits || '%'))
+        UNION ALL
+        SELECT 'company', co.id, co.name,
+               co.industry,
+               (coalesce(ts_rank(co.search_vector, p.tsq), 0)
+                + word_similarity(p.phrase, co.name)
+                + CASE WHEN length(p.digits) >= 4 AND regexp_replace(co.phone, '\D', '', 'g') LIKE '%' || p.digits || '%' THEN 0.5 ELSE 0 END)::REAL,
+               ts_headline('simple', concat_ws(' ', co.name, co.email, co.website, co.industry), p.tsq,
+                   'MaxFragments=1, MaxWords=15, MinWords=3')
+          FROM companies co, params p
+         WHERE (organization IS NULL OR co.organization_id = organization)
+           AND (entity_types IS NULL OR 'company' = ANY(entity_types))
+           AND (co.search_vector @@ p.tsq
+                OR p.phrase <% co.name
+                OR (length(p.digits) >= 4 AND regexp_replace(co.phone, '\D', '', 'g') LIKE '%' || p.digits || '%'))
+        UNION ALL
+        SELECT 'inventory', i.id, i.unit_number,
+               nullif(concat_ws(', ', pr.name, i.unit_type), ''),
+               coalesce(ts_rank(i.search_vector, p.tsq), 0)::REAL,
+               ts_headline('simple', concat_ws(' ', i.unit_number, i.tower_block, i.unit_type, i.facing), p.tsq,
+                   'MaxFragments=1, MaxWords=15, MinWords=3')
+          FROM inventory i
+          LEFT JOIN projects pr ON pr.id = i.project_id, params p
+         WHERE (organization IS NULL OR i.organization_id = organization)
+           AND (entity_types IS NULL OR 'inventory' = ANY(entity_types))
+           AND i.search_vector @@ p.tsq
+    )
+    SELECT entity_type, id, title, subtitle, score, highlight
+      FROM hits
+     ORDER BY score DESC, title, id
+     LIMIT max_results;
+$$ LANGUAGE sql STABLE;
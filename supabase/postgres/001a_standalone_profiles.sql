@@ -0,0 +1,7 @@
+-- Standalone profiles for plain PostgreSQL
+-- On Supabase every profile extends an auth.users row. Without Supabase the
+-- backend's own auth service owns the accounts and profiles is the users
+-- table, so it stops referencing the stand-in auth.users and new users need
+-- only a profile. This runs right after 001, before 008 seeds profiles.
+
+ALTER TABLE profiles DROP CONSTRAINT IF EXISTS profiles_id_fkey;